@@ -0,0 +1,3 @@
+module github.com/antnay/tcss460-api/client/go
+
+go 1.21
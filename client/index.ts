@@ -0,0 +1,2796 @@
+// Generated by npm run generate-client from api-docs/swagger.yaml (TCSS460 Movie Database API 1.0.0).
+// Do not edit; change the spec and regenerate.
+
+/* eslint-disable */
+
+// ============================================================================
+// Schemas
+// ============================================================================
+
+export interface Movie {
+  movie_id?: number;
+  /** Opaque ID, stable across instances; usable wherever a movie ID is accepted */
+  public_id?: string;
+  title?: string;
+  original_title?: string;
+  /** Comma-separated list of directors */
+  directors?: string;
+  /** Comma-separated list of genres */
+  genres?: string;
+  /** Calendar date (YYYY-MM-DD) with no time of day or time zone */
+  release_date?: string;
+  /** null when the runtime is unknown */
+  runtime_minutes?: number | null;
+  overview?: string;
+  /** Only present when a translation exists for the requested language */
+  tagline?: string | null;
+  budget?: number;
+  revenue?: number;
+  /** Budget in today's dollars (US CPI, CPI_BASE_YEAR) */
+  budget_adjusted?: number | null;
+  /** Revenue in today's dollars (US CPI, CPI_BASE_YEAR) */
+  revenue_adjusted?: number | null;
+  era?: ("silent" | "golden_age" | "new_hollywood" | "blockbuster" | "digital" | "streaming") | null;
+  vote_count?: number | null;
+  vote_average?: number | null;
+  /** 0-1 score refreshed nightly; null until the first refresh */
+  popularity?: number | null;
+  /** Only present on search results when explain=true */
+  score?: {
+    total?: number;
+    components?: {
+      title_match?: number;
+      popularity?: number;
+      recency?: number;
+    };
+  };
+  included?: MovieIncludes;
+  mpa_rating?: string;
+  /** Flagged adult; hidden from lists unless include_adult is set */
+  adult?: boolean;
+  poster_url?: string;
+  backdrop_url?: string;
+  trailer_url?: string | null;
+  /** Language of the returned title/overview, or null for the original text */
+  language?: string | null;
+  /** Row version, incremented on every update */
+  version?: number;
+  updated_at?: string;
+}
+
+/** Only present when requested with include; holds just the relations asked for */
+export interface MovieIncludes {
+  /** The first cast_limit entries in billing order */
+  cast?: {
+    actor_id?: number;
+    actor_name?: string;
+    character_name?: string | null;
+    character_names?: string[];
+    actor_order?: number;
+    billing_order?: number | null;
+    credited?: boolean;
+    profile_url?: string | null;
+  }[];
+  /** Size of the whole cast; only present with include=cast */
+  cast_total?: number;
+  genres?: {
+    genre_id?: number;
+    genre_name?: string;
+  }[];
+  studios?: {
+    studio_id?: number;
+    studio_name?: string;
+    logo_url?: string | null;
+    /** Mirrored copy under /api/assets, once the mirror-assets job has fetched it */
+    logo_asset_url?: string | null;
+    country?: string | null;
+  }[];
+}
+
+export interface MovieInput {
+  title: string;
+  original_title: string;
+  /** Calendar date (YYYY-MM-DD) with no time of day or time zone */
+  release_date: string;
+  /** Omit, or send 0 or null, when unknown; stored as null */
+  runtime_minutes?: number | null;
+  overview: string;
+  budget?: number;
+  revenue?: number;
+  /** Audience votes from the import source */
+  vote_count?: number;
+  vote_average?: number;
+  mpa_rating: string;
+  adult?: boolean;
+  collection_name?: string | null;
+  poster_url?: string | null;
+  backdrop_url?: string | null;
+  /** Adding one notifies users with the movie on their watchlist */
+  trailer_url?: string | null;
+  genres: string[];
+  directors?: string[];
+  producers?: string[];
+  /** Writing credits (screenplay, story) */
+  writers?: string[];
+  composers?: string[];
+  cinematographers?: string[];
+  editors?: string[];
+  /** Tags, as a list or one semicolon-separated string; stored lower-case */
+  keywords?: string[] | string;
+  /** ISO 639-1 codes or English language names, as a list or one comma-separated string. Known names are stored as their code; others are kept as given. */
+  spoken_languages?: string[] | string;
+  /** ISO 3166-1 alpha-2 codes or English country names, as a list or one comma-separated string. Known names are stored as their code; others are kept as given. */
+  production_countries?: string[] | string;
+  studios?: Studio[];
+  cast?: CastMember[];
+  translations?: MovieTranslation[];
+  /** Expected row version for updates (alternative to If-Match); ignored on create */
+  version?: number;
+}
+
+export type ExportedMovie = MovieInput & {
+  /** ID on the exporting instance */
+  movie_id?: number;
+  updated_at?: string;
+};
+
+export interface Studio {
+  studio_name: string;
+  logo_url?: string | null;
+  country?: string | null;
+}
+
+export interface Keyword {
+  keyword_id?: number;
+  keyword_name?: string;
+  movie_count?: number;
+}
+
+export interface CastMember {
+  actor_name: string;
+  /** Role credit; several roles may be joined with " / " */
+  character_name?: string | null;
+  /** One entry per role. Derived by splitting character_name on " / " when omitted. */
+  character_names?: string[];
+  /** Position in the supplied cast list */
+  actor_order: number;
+  /** Billing position from the source, when known. Cast is listed by this first, then actor_order. */
+  billing_order?: number | null;
+  /** false for uncredited appearances */
+  credited?: boolean;
+  profile_url?: string | null;
+}
+
+export interface MovieTranslation {
+  language: string;
+  title?: string | null;
+  overview?: string | null;
+  tagline?: string | null;
+}
+
+export interface DuplicateFlag {
+  flag_id?: number;
+  reason?: string;
+  status?: "pending" | "dismissed";
+  created_at?: string;
+  resolved_at?: string | null;
+  movie?: Record<string, unknown>;
+  duplicate_of?: Record<string, unknown>;
+}
+
+export interface MovieMergeResponse {
+  success?: boolean;
+  message?: string;
+  merged_id?: number;
+  kept_id?: number;
+  links_added?: number;
+  cast_added?: number;
+}
+
+/** At least one condition is required */
+export interface BulkMovieFilter {
+  title?: string;
+  year?: number;
+  minYear?: number;
+  maxYear?: number;
+  genre?: string;
+  rating?: string;
+  studio?: string;
+  minBudget?: number;
+  maxBudget?: number;
+  minRevenue?: number;
+  maxRevenue?: number;
+  startDate?: string;
+  endDate?: string;
+}
+
+/** Columns to set on every matching movie */
+export interface BulkMovieSet {
+  runtime_minutes?: number | null;
+  overview?: string | null;
+  budget?: number | null;
+  revenue?: number | null;
+  mpa_rating?: string | null;
+  adult?: boolean;
+  poster_url?: string | null;
+  backdrop_url?: string | null;
+}
+
+export interface BulkOperationResponse {
+  dry_run?: boolean;
+  action?: "delete" | "update";
+  matched?: number;
+  /** Present on dry runs */
+  batches?: number;
+  /** First matching movies, present on dry runs */
+  sample?: Record<string, unknown>[];
+  /** Present on dry runs */
+  previewToken?: string;
+  /** Present on execution */
+  affected?: number;
+  /** Present on execution */
+  batches_completed?: number;
+}
+
+export interface ActorSummary {
+  actor_id?: number;
+  actor_name?: string;
+  profile_url?: string | null;
+}
+
+export interface StudioCountryStats {
+  country?: string;
+  studio_count?: number;
+  movie_count?: number;
+  total_revenue?: number;
+}
+
+export interface BoxOfficePoint {
+  /** YYYY or YYYY-MM */
+  period?: string;
+  movie_count?: number;
+  total_revenue?: number;
+  total_budget?: number;
+  avg_revenue?: number | null;
+  avg_budget?: number | null;
+}
+
+export interface AuthResponse {
+  username?: string;
+  role?: string;
+  jwt?: {
+    /** JWT, valid for 15 minutes */
+    accessToken?: string;
+    type?: string;
+    /** Single-use token for POST /api/auth/refresh (also set as an HTTP-only cookie) */
+    refreshToken?: string;
+    refreshExpiresAt?: string;
+  };
+}
+
+/** Login result when a cookie session was started (AUTH_MODE=session, or both with "session" true) */
+export interface SessionResponse {
+  username?: string;
+  role?: string;
+  session?: {
+    /** Send in X-CSRF-Token on writes (same value as the csrf-token cookie) */
+    csrfToken?: string;
+    expiresAt?: string;
+  };
+}
+
+export interface MfaChallenge {
+  /** Present when the account has 2FA; continue at /api/auth/2fa/verify */
+  mfa_required?: boolean;
+  /** Present for admins without 2FA; enroll at /api/auth/2fa/setup */
+  mfa_setup_required?: boolean;
+  mfaToken?: string;
+  expiresIn?: number;
+}
+
+export interface RecoveryCodes {
+  success?: boolean;
+  /** Single-use codes for a lost authenticator. Shown only once. */
+  recovery_codes?: string[];
+}
+
+export interface RefreshTokenInput {
+  /** Omit to use the refresh-token cookie */
+  refreshToken?: string;
+}
+
+export interface Review {
+  review_id?: number;
+  movie_id?: number;
+  user_id?: number;
+  username?: string;
+  rating?: number;
+  body?: string | null;
+  contains_spoilers?: boolean;
+  /** Present (true) when body was withheld because it contains spoilers */
+  spoiler_hidden?: boolean;
+  helpful_count?: number;
+  unhelpful_count?: number;
+  /** Only shown to the author and moderators */
+  status?: "pending" | "approved" | "rejected";
+  /** What content filters matched; only shown to the author and moderators */
+  flagged_terms?: string[];
+  /** Reason given when rejected */
+  moderation_note?: string | null;
+  moderated_at?: string | null;
+  created_at?: string;
+  updated_at?: string;
+}
+
+export interface Activity {
+  activity_id?: number;
+  type?: "rating" | "review" | "watchlist_add";
+  created_at?: string;
+  user?: {
+    user_id?: number;
+    username?: string;
+  };
+  movie?: {
+    movie_id?: number;
+    public_id?: string;
+    title?: string;
+    poster_url?: string | null;
+  };
+  /** Only for rating and review activities */
+  review?: {
+    review_id?: number;
+    rating?: number;
+    contains_spoilers?: boolean;
+  };
+}
+
+export interface DigestSubscription {
+  subscribed?: boolean;
+  frequency?: ("daily" | "weekly") | null;
+  subscribed_at?: string | null;
+  /** Movies added after this go in the next digest */
+  last_sent_at?: string | null;
+  genres?: string[];
+  /** Present when no favorite genres are set */
+  warning?: string;
+}
+
+export interface PersonImage {
+  image_id?: number;
+  url?: string;
+  content_type?: string | null;
+  width?: number | null;
+  height?: number | null;
+  is_canonical?: boolean;
+  /** Best copy of the same photo */
+  duplicate_of?: number | null;
+  /** Downloaded and hashed (false until the person-images job sees it, or for formats it can't decode) */
+  hashed?: boolean;
+  /** The last download failed */
+  broken?: boolean;
+  added_at?: string;
+}
+
+export interface MovieList {
+  list_id?: number;
+  user_id?: number;
+  username?: string;
+  name?: string;
+  description?: string | null;
+  slug?: string;
+  visibility?: "private" | "unlisted" | "public";
+  item_count?: number;
+  /** Null while the list is private */
+  share_url?: string | null;
+  created_at?: string;
+  updated_at?: string;
+  movies?: MovieListItem[];
+}
+
+export interface MovieListItem {
+  position?: number;
+  movie_id?: number;
+  public_id?: string;
+  title?: string;
+  release_date?: string;
+  poster_url?: string | null;
+  note?: string | null;
+  added_at?: string;
+}
+
+export interface FeatureFlag {
+  flag?: string;
+  enabled?: boolean;
+  source?: "env" | "database" | "default";
+  description?: string;
+}
+
+export interface LatencySummary {
+  count?: number;
+  avg_ms?: number;
+  p50_ms?: number;
+  p95_ms?: number;
+  p99_ms?: number;
+  max_ms?: number;
+}
+
+export interface EndpointLatency {
+  endpoint?: string;
+  requests?: LatencySummary;
+  /** Responses with a 5xx status */
+  errors?: number;
+  slo_compliance?: number;
+  queries?: LatencySummary;
+}
+
+/** Any GET /api/movies query parameters except page and limit */
+export type SavedSearchQuery = Record<string, unknown>;
+
+export interface SavedSearchInput {
+  name: string;
+  query: SavedSearchQuery;
+}
+
+export interface SavedSearch {
+  search_id?: number;
+  name?: string;
+  query?: SavedSearchQuery;
+  /** GET /api/movies link reproducing the search */
+  share_url?: string;
+  created_at?: string;
+  updated_at?: string;
+}
+
+export interface MovieListResponse {
+  data?: Movie[];
+  meta?: {
+    page?: number;
+    limit?: number;
+    total?: number;
+    pages?: number;
+    hasNextPage?: boolean;
+    hasPreviousPage?: boolean;
+    query?: Record<string, unknown>;
+  };
+  /** Only present when facets=true */
+  facets?: {
+    genres?: FacetCount[];
+    decades?: FacetCount[];
+    ratings?: FacetCount[];
+  };
+  /** Only present when explain=true */
+  ranking?: {
+    sortBy?: string;
+    weights?: RankingWeights;
+  };
+}
+
+export interface RankingWeights {
+  title_weight?: number;
+  popularity_weight?: number;
+  recency_weight?: number;
+  recency_half_life_years?: number;
+}
+
+export interface RankingSetting {
+  setting?: "title_weight" | "popularity_weight" | "recency_weight" | "recency_half_life_years";
+  value?: number;
+  /** env values come from RANKING_<SETTING> and can't be changed through the API */
+  source?: "env" | "database" | "default";
+  description?: string;
+}
+
+export interface FacetCount {
+  /** Genre name, decade start year (e.g. 1990) or MPA rating */
+  value?: string | number;
+  count?: number;
+}
+
+export interface MovieCreateResponse {
+  success?: boolean;
+  movie_id?: number;
+  public_id?: string;
+  message?: string;
+}
+
+export interface MovieUpdateResponse {
+  success?: boolean;
+  movie_id?: number;
+  /** New row version after the update */
+  version?: number;
+  message?: string;
+}
+
+export interface ImportBatch {
+  batch_id?: number;
+  status?: "pending" | "approved" | "rejected";
+  movie_count?: number;
+  submitted_by?: number | null;
+  submitted_at?: string;
+  reviewed_by?: number | null;
+  reviewed_at?: string | null;
+  review_note?: string | null;
+}
+
+export interface ImportRun {
+  run_id?: number;
+  kind?: "api" | "bulk" | "batch" | "sync" | "enrich" | "imdb" | "fixture";
+  /** Remote base URL of a sync run */
+  source?: string | null;
+  batch_id?: number | null;
+  api_key_id?: number | null;
+  started_at?: string;
+}
+
+export interface FieldProfile {
+  field?: string;
+  /** Values seen, one per movie or one per list entry */
+  count?: number;
+  /** Share of values that are null, missing or empty (0 to 1) */
+  null_rate?: number;
+  type?: "integer" | "number" | "boolean" | "date" | "string" | "list" | "object" | "mixed" | "empty";
+  distinct?: number;
+  min?: (number | string) | null;
+  max?: (number | string) | null;
+  min_length?: number | null;
+  max_length?: number | null;
+  top_values?: {
+    value?: string | number | boolean;
+    count?: number;
+  }[];
+  warnings?: string[];
+}
+
+export type ImportBatchSummary = ImportBatch & {
+  movies?: {
+    position?: number;
+    title?: string;
+    release_date?: string;
+    /** Catalog movie with the same title and release date */
+    existing_movie_id?: number | null;
+    /** Set once the batch is approved */
+    movie_id?: number | null;
+  }[];
+  possible_duplicates?: number;
+  /** Names not in the catalog yet, by kind */
+  new_names?: {
+    genres?: string[];
+    directors?: string[];
+    producers?: string[];
+    writers?: string[];
+    composers?: string[];
+    cinematographers?: string[];
+    editors?: string[];
+    keywords?: string[];
+    spoken_languages?: string[];
+    production_countries?: string[];
+    studios?: string[];
+    actors?: string[];
+    collections?: string[];
+  };
+};
+
+export interface BulkImportResponse {
+  success?: boolean;
+  total_processed?: number;
+  successful?: number;
+  failed?: number;
+  results?: {
+    title?: string;
+    success?: boolean;
+    movie_id?: number;
+    error?: string;
+  }[];
+}
+
+export interface Error {
+  statusCode?: number;
+  message?: string;
+  /** Stable machine-readable error code, the same in every language */
+  code?: string;
+  timestamp?: string;
+  errors?: Record<string, unknown>[];
+}
+
+// ============================================================================
+// Client
+// ============================================================================
+
+export interface ClientOptions {
+  /** Server root, e.g. http://localhost:4000 (paths already start with /api) */
+  baseUrl: string;
+  /** Sent as X-API-Key */
+  apiKey?: string;
+  /** Access token from /api/auth/login, sent as a bearer token */
+  accessToken?: string;
+  /** Headers sent with every request */
+  headers?: Record<string, string>;
+  /** fetch implementation; the global one by default */
+  fetch?: typeof fetch;
+}
+
+interface RequestOptions {
+  query?: object;
+  body?: unknown;
+  headers?: object;
+  raw?: boolean;
+}
+
+/**
+ * A response outside 2xx; body is the parsed JSON error when there is one
+ */
+export class ApiClientError extends Error {
+  constructor(readonly status: number, readonly body: unknown) {
+    super(`Request failed with status ${status}${
+      body && typeof body === 'object' && 'message' in body && typeof body.message === 'string' ? `: ${body.message}` : ''
+    }`);
+    this.name = 'ApiClientError';
+  }
+}
+
+export class MovieApiClient {
+  private readonly options: ClientOptions;
+
+  constructor(options: ClientOptions) {
+    this.options = { ...options };
+  }
+
+  /** Replaces the bearer token, e.g. after a refresh */
+  setAccessToken(accessToken: string | undefined): void {
+    this.options.accessToken = accessToken;
+  }
+
+  private async request<T>(method: string, path: string, options: RequestOptions = {}): Promise<T> {
+    const url = new URL(path, this.options.baseUrl);
+    for (const [name, value] of Object.entries(options.query ?? {})) {
+      if (value === undefined || value === null) continue;
+      for (const item of Array.isArray(value) ? value : [value]) {
+        url.searchParams.append(name, String(item));
+      }
+    }
+
+    const headers: Record<string, string> = { Accept: 'application/json', ...this.options.headers };
+    if (this.options.apiKey) headers['X-API-Key'] = this.options.apiKey;
+    if (this.options.accessToken) headers.Authorization = `Bearer ${this.options.accessToken}`;
+    for (const [name, value] of Object.entries(options.headers ?? {})) {
+      if (value !== undefined) headers[name] = String(value);
+    }
+
+    let body: string | undefined;
+    if (options.body !== undefined) {
+      body = typeof options.body === 'string' ? options.body : JSON.stringify(options.body);
+      if (!headers['Content-Type']) headers['Content-Type'] = 'application/json';
+    }
+
+    const response = await (this.options.fetch ?? fetch)(url, { method, headers, body });
+    if (!response.ok) {
+      const text = await response.text();
+      let error: unknown = text;
+      try {
+        error = JSON.parse(text);
+      } catch {
+        // Not JSON; keep the text
+      }
+      throw new ApiClientError(response.status, error);
+    }
+
+    if (options.raw) return response as T;
+    if (response.status === 204) return undefined as T;
+    const text = await response.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+
+  /**
+   * GET /api/api-info
+   * Get API information
+   */
+  getApiInfo(): Promise<{
+    name?: string;
+    version?: string;
+    description?: string;
+    documentation?: string;
+    /** True when the server runs in read-only mode */
+    read_only?: boolean;
+  }> {
+    return this.request('GET', `/api/api-info`);
+  }
+
+  /**
+   * GET /api/features
+   * Enabled features
+   */
+  getFeatures(): Promise<Record<string, boolean>> {
+    return this.request('GET', `/api/features`);
+  }
+
+  /**
+   * GET /api/postman-collection
+   * Postman collection
+   */
+  getPostmanCollection(): Promise<{
+    info?: Record<string, unknown>;
+    item?: Record<string, unknown>[];
+    auth?: Record<string, unknown>;
+    event?: Record<string, unknown>[];
+    variable?: Record<string, unknown>[];
+  }> {
+    return this.request('GET', `/api/postman-collection`);
+  }
+
+  /**
+   * GET /api/lists/{slug}
+   * View a shared list
+   */
+  getListsBySlug(slug: string | number, options?: {
+    query?: {
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieList> {
+    return this.request('GET', `/api/lists/${encodeURIComponent(String(slug))}`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/digest/unsubscribe
+   * Unsubscribe from the email digest
+   */
+  getDigestUnsubscribe(options: {
+    query: {
+      token: string;
+    };
+  }): Promise<void> {
+    return this.request('GET', `/api/digest/unsubscribe`, { query: options?.query });
+  }
+
+  /**
+   * POST /api/digest/unsubscribe
+   * One-click unsubscribe
+   */
+  postDigestUnsubscribe(options: {
+    query: {
+      token: string;
+    };
+  }): Promise<void> {
+    return this.request('POST', `/api/digest/unsubscribe`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/releases/upcoming.ics
+   * Upcoming releases calendar
+   */
+  getReleasesUpcomingIcs(options?: {
+    query?: {
+      genre?: string;
+      /** How far ahead to look */
+      days?: number;
+      /** Personal feed token */
+      feed?: string;
+      include_adult?: boolean;
+    };
+  }): Promise<Response> {
+    return this.request('GET', `/api/releases/upcoming.ics`, { query: options?.query, raw: true });
+  }
+
+  /**
+   * GET /api/feeds/new-movies.atom
+   * Newly added movies (Atom feed)
+   */
+  getFeedsNewMoviesAtom(options?: {
+    query?: {
+      genre?: string;
+      limit?: number;
+    };
+  }): Promise<Response> {
+    return this.request('GET', `/api/feeds/new-movies.atom`, { query: options?.query, raw: true });
+  }
+
+  /**
+   * GET /api/sitemap.xml
+   * Sitemap index
+   */
+  getSitemapXml(): Promise<Response> {
+    return this.request('GET', `/api/sitemap.xml`, { raw: true });
+  }
+
+  /**
+   * GET /api/sitemaps/movies-{page}.xml
+   * Movie sitemap page
+   */
+  getSitemapsMoviesByPageXml(page: string | number): Promise<Response> {
+    return this.request('GET', `/api/sitemaps/movies-${encodeURIComponent(String(page))}.xml`, { raw: true });
+  }
+
+  /**
+   * GET /api/assets/{hash}
+   * Mirrored image by content hash
+   */
+  getAssetsByHash(hash: string | number): Promise<Response> {
+    return this.request('GET', `/api/assets/${encodeURIComponent(String(hash))}`, { raw: true });
+  }
+
+  /**
+   * GET /api/m/{code}
+   * Follow a movie short link
+   */
+  getMByCode(code: string | number): Promise<void> {
+    return this.request('GET', `/api/m/${encodeURIComponent(String(code))}`);
+  }
+
+  /**
+   * GET /api/oembed
+   * oEmbed card for a movie link
+   */
+  getOembed(options: {
+    query: {
+      url: string;
+      /** Only json is served; other formats answer 501 */
+      format?: string;
+      maxwidth?: number;
+      maxheight?: number;
+    };
+  }): Promise<{
+    version?: string;
+    type?: string;
+    title?: string;
+    provider_name?: string;
+    provider_url?: string;
+    cache_age?: number;
+    html?: string;
+    width?: number;
+    height?: number;
+    thumbnail_url?: string;
+    thumbnail_width?: number;
+    thumbnail_height?: number;
+    url?: string;
+    year?: number | null;
+    mpa_rating?: string | null;
+    rating?: number | null;
+  }> {
+    return this.request('GET', `/api/oembed`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/health
+   * Health check
+   */
+  getHealth(): Promise<{
+    message?: string;
+    timestamp?: string;
+  }> {
+    return this.request('GET', `/api/health`);
+  }
+
+  /**
+   * GET /api/api-key
+   * Get API key generation form
+   */
+  getApiKey(): Promise<Response> {
+    return this.request('GET', `/api/api-key`, { raw: true });
+  }
+
+  /**
+   * POST /api/api-key
+   * Generate a new API key
+   */
+  postApiKey(options: {
+    body: {
+      /** Name of the person, application, or organization */
+      name: string;
+      /** Optional contact email */
+      email?: string;
+    };
+  }): Promise<{
+    success?: boolean;
+    /** The generated API key (shown only once) */
+    api_key?: string;
+    name?: string;
+    email?: string;
+    rate_limit?: number;
+    created_at?: string;
+    message?: string;
+    important_notice?: string;
+  }> {
+    return this.request('POST', `/api/api-key`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/api-key/info
+   * Get API key information
+   */
+  getApiKeyInfo(): Promise<{
+    success?: boolean;
+    api_key_info?: {
+      name?: string;
+      email?: string | null;
+      rate_limit?: number;
+      created_at?: string;
+      last_used_at?: string | null;
+      expires_at?: string | null;
+    };
+  }> {
+    return this.request('GET', `/api/api-key/info`);
+  }
+
+  /**
+   * POST /api/auth/login
+   * Log in with email and password
+   */
+  postAuthLogin(options: {
+    body: {
+      email: string;
+      password: string;
+      /** With AUTH_MODE=both, start a cookie session instead of issuing tokens */
+      session?: boolean;
+    };
+  }): Promise<AuthResponse | SessionResponse | MfaChallenge> {
+    return this.request('POST', `/api/auth/login`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/register
+   * Create a user account
+   */
+  postAuthRegister(options: {
+    body: {
+      username: string;
+      email: string;
+      password: string;
+      role?: "user";
+    };
+  }): Promise<AuthResponse> {
+    return this.request('POST', `/api/auth/register`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/refresh
+   * Exchange a refresh token for a new token pair
+   */
+  postAuthRefresh(options?: {
+    body?: RefreshTokenInput;
+  }): Promise<AuthResponse> {
+    return this.request('POST', `/api/auth/refresh`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/logout
+   * Log out this device
+   */
+  postAuthLogout(options?: {
+    body?: RefreshTokenInput;
+  }): Promise<void> {
+    return this.request('POST', `/api/auth/logout`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/logout-all
+   * Log out all devices
+   */
+  postAuthLogoutAll(options?: {
+    body?: RefreshTokenInput;
+  }): Promise<{
+    success?: boolean;
+    message?: string;
+    sessions_revoked?: number;
+  }> {
+    return this.request('POST', `/api/auth/logout-all`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/auth/session
+   * Current cookie session
+   */
+  getAuthSession(): Promise<{
+    username?: string;
+    role?: string;
+    csrfToken?: string;
+  }> {
+    return this.request('GET', `/api/auth/session`);
+  }
+
+  /**
+   * GET /api/auth/oauth/providers
+   * List external sign-in providers
+   */
+  getAuthOauthProviders(): Promise<{
+    data?: {
+      name?: string;
+      label?: string;
+      login_url?: string;
+    }[];
+  }> {
+    return this.request('GET', `/api/auth/oauth/providers`);
+  }
+
+  /**
+   * GET /api/auth/oauth/{provider}
+   * Start external sign-in
+   */
+  getAuthOauthByProvider(provider: string | number): Promise<void> {
+    return this.request('GET', `/api/auth/oauth/${encodeURIComponent(String(provider))}`);
+  }
+
+  /**
+   * GET /api/auth/oauth/{provider}/callback
+   * Complete external sign-in
+   */
+  getAuthOauthByProviderCallback(provider: string | number, options?: {
+    query?: {
+      code?: string;
+      state?: string;
+      /** Set by the provider when the user cancels or sign-in fails */
+      error?: string;
+    };
+  }): Promise<AuthResponse> {
+    return this.request('GET', `/api/auth/oauth/${encodeURIComponent(String(provider))}/callback`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/auth/2fa
+   * Two-factor status
+   */
+  getAuth2fa(): Promise<{
+    enabled?: boolean;
+    /** Setup started but not confirmed */
+    pending?: boolean;
+    recovery_codes_remaining?: number;
+    /** Admin accounts must keep 2FA on */
+    required?: boolean;
+  }> {
+    return this.request('GET', `/api/auth/2fa`);
+  }
+
+  /**
+   * POST /api/auth/2fa/setup
+   * Start two-factor enrollment
+   */
+  postAuth2faSetup(options?: {
+    body?: {
+      mfaToken?: string;
+    };
+  }): Promise<{
+    secret?: string;
+    otpauth_url?: string;
+  }> {
+    return this.request('POST', `/api/auth/2fa/setup`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/2fa/enable
+   * Confirm enrollment and turn on 2FA
+   */
+  postAuth2faEnable(options: {
+    body: {
+      code: string;
+      mfaToken?: string;
+    };
+  }): Promise<RecoveryCodes> {
+    return this.request('POST', `/api/auth/2fa/enable`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/2fa/verify
+   * Complete a login with a 2FA code
+   */
+  postAuth2faVerify(options: {
+    body: {
+      mfaToken: string;
+      /** 6-digit code or a recovery code */
+      code: string;
+    };
+  }): Promise<AuthResponse> {
+    return this.request('POST', `/api/auth/2fa/verify`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/2fa/disable
+   * Turn off 2FA
+   */
+  postAuth2faDisable(options: {
+    body: {
+      /** 6-digit code or a recovery code */
+      code: string;
+    };
+  }): Promise<void> {
+    return this.request('POST', `/api/auth/2fa/disable`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/auth/2fa/recovery-codes
+   * Replace recovery codes
+   */
+  postAuth2faRecoveryCodes(options: {
+    body: {
+      code: string;
+    };
+  }): Promise<RecoveryCodes> {
+    return this.request('POST', `/api/auth/2fa/recovery-codes`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/movies
+   * Get all movies with filtering
+   */
+  getMovies(options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+      /** Search by movie title (case-insensitive substring match) */
+      title?: string;
+      /** Filter by release year */
+      year?: number;
+      /** Filter by genre name (exact match) */
+      genre?: string;
+      /** Filter by keywords, separated by semicolons; movies must have all of them */
+      keyword?: string;
+      /** Filter by MPA rating */
+      rating?: "G" | "PG" | "PG-13" | "R" | "NC-17" | "NR";
+      /** Filter by actor name (partial match) */
+      actor?: string;
+      /** Filter by director name (partial match) */
+      director?: string;
+      /** Filter by studio name (partial match) */
+      studio?: string;
+      /** Filter by collection name (partial match) */
+      collection?: string;
+      /** Minimum budget filter */
+      minBudget?: number;
+      /** Maximum budget filter */
+      maxBudget?: number;
+      /** Minimum revenue filter */
+      minRevenue?: number;
+      /** Maximum revenue filter */
+      maxRevenue?: number;
+      /** Minimum runtime in minutes */
+      minRuntime?: number;
+      /** Maximum runtime in minutes */
+      maxRuntime?: number;
+      /** Release date range start (YYYY-MM-DD) */
+      startDate?: string;
+      /** Release date range end (YYYY-MM-DD) */
+      endDate?: string;
+      /** Release era: silent (to 1929), golden_age (1930-1959), new_hollywood (1960-1979), blockbuster (1980-1999), digital (2000-2009), streaming (2010 on) */
+      era?: "silent" | "golden_age" | "new_hollywood" | "blockbuster" | "digital" | "streaming";
+      /** Localize title and overview into this language. Movies without a translation fall back to their original text. */
+      lang?: string;
+      /** Sort field; defaults to `relevance` with a title search and `popularity` otherwise. `relevance` weighs how closely the title matches against popularity and recency (weights set in /api/admin/search-ranking). `popularity` is a nightly score combining revenue, audience votes, rating and recency. The `_adjusted` fields compare amounts in today's dollars, for all-time lists. */
+      sortBy?: "relevance" | "popularity" | "title" | "release_date" | "runtime" | "budget" | "revenue" | "budget_adjusted" | "revenue_adjusted";
+      /** Sort direction (default desc for relevance and popularity, asc otherwise) */
+      order?: "asc" | "desc";
+      /** Add a `facets` object with match counts per genre, decade and MPA rating across the whole filtered result set (not just this page). */
+      facets?: boolean;
+      /** Debugging aid for tuning relevance. Adds a `score` object to each movie with its total relevance and components (each 0-1), and a top-level `ranking` object with the sort and weights used. */
+      explain?: boolean;
+      /** Comma-separated relations to expand into an `included` object on each movie. Each relation costs one query for the whole page, not one per movie. */
+      include?: string;
+      /** Cast entries to expand per movie with include=cast, in billing order. `included.cast_total` gives the full count; page through the rest with GET /api/movies/{id}/cast. */
+      cast_limit?: number;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/movies`, { query: options?.query });
+  }
+
+  /**
+   * POST /api/movies
+   * Add a new movie
+   */
+  postMovies(options: {
+    query?: {
+      /** Stage the import for admin review instead of inserting it (always on with IMPORT_STAGING=true) */
+      stage?: boolean;
+    };
+    body: MovieInput;
+  }): Promise<MovieCreateResponse | {
+    success?: boolean;
+    staged?: boolean;
+    batch_id?: number;
+    message?: string;
+    data?: ImportBatchSummary;
+  }> {
+    return this.request('POST', `/api/movies`, { query: options?.query, body: options?.body });
+  }
+
+  /**
+   * POST /api/movies/bulk
+   * Bulk import movies
+   */
+  postMoviesBulk(options: {
+    query?: {
+      /** Stage the import for admin review instead of inserting it (always on with IMPORT_STAGING=true) */
+      stage?: boolean;
+    };
+    body: {
+      movies: MovieInput[];
+    };
+  }): Promise<BulkImportResponse | {
+    success?: boolean;
+    staged?: boolean;
+    batch_id?: number;
+    message?: string;
+    data?: ImportBatchSummary;
+  }> {
+    return this.request('POST', `/api/movies/bulk`, { query: options?.query, body: options?.body });
+  }
+
+  /**
+   * GET /api/search/semantic
+   * Search movies by meaning
+   */
+  getSearchSemantic(options: {
+    query: {
+      /** What the movie is about */
+      q: string;
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<{
+    data?: {
+      movie_id?: number;
+      public_id?: string | null;
+      title?: string;
+      release_date?: string | null;
+      overview?: string;
+      poster_url?: string | null;
+      /** Cosine similarity, 1 is identical */
+      similarity?: number;
+    }[];
+    meta?: {
+      query?: string;
+      model?: string;
+    };
+  }> {
+    return this.request('GET', `/api/search/semantic`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/search/natural
+   * Search movies in plain English (experimental)
+   */
+  getSearchNatural(options: {
+    query: {
+      q: string;
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse & {
+    query?: {
+      text?: string;
+      parser?: "rules" | "openai";
+      filters?: Record<string, unknown>;
+      unparsed?: string[];
+    };
+  }> {
+    return this.request('GET', `/api/search/natural`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/movies/{id}
+   * Get movie by ID
+   */
+  getMoviesById(id: string | number, options?: {
+    query?: {
+      /** Localize title and overview into this language. Movies without a translation fall back to their original text. */
+      lang?: string;
+      /** Comma-separated relations to expand into an `included` object on each movie. Each relation costs one query for the whole page, not one per movie. */
+      include?: string;
+      /** Cast entries to expand per movie with include=cast, in billing order. `included.cast_total` gives the full count; page through the rest with GET /api/movies/{id}/cast. */
+      cast_limit?: number;
+    };
+  }): Promise<Movie> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}`, { query: options?.query });
+  }
+
+  /**
+   * PUT /api/movies/{id}
+   * Update movie (complete)
+   */
+  putMoviesById(id: string | number, options: {
+    body: MovieInput;
+    headers?: {
+      /** ETag from GET /api/movies/{id}. The update is rejected with 412 if the movie has been saved by someone else since. A `version` field in the body works the same way. */
+      "If-Match"?: string;
+    };
+  }): Promise<MovieUpdateResponse> {
+    return this.request('PUT', `/api/movies/${encodeURIComponent(String(id))}`, { body: options?.body, headers: options?.headers });
+  }
+
+  /**
+   * PATCH /api/movies/{id}
+   * Update movie (partial)
+   */
+  patchMoviesById(id: string | number, options: {
+    body: MovieInput;
+    headers?: {
+      /** ETag from GET /api/movies/{id}. The update is rejected with 412 if the movie has been saved by someone else since. A `version` field in the body works the same way. */
+      "If-Match"?: string;
+    };
+  }): Promise<MovieUpdateResponse> {
+    return this.request('PATCH', `/api/movies/${encodeURIComponent(String(id))}`, { body: options?.body, headers: options?.headers });
+  }
+
+  /**
+   * DELETE /api/movies/{id}
+   * Delete movie
+   */
+  deleteMoviesById(id: string | number): Promise<{
+    success?: boolean;
+    message?: string;
+    deleted_movie?: Movie;
+    warning?: string;
+  }> {
+    return this.request('DELETE', `/api/movies/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * GET /api/movies/{id}/cast
+   * Get a movie's cast
+   */
+  getMoviesByIdCast(id: string | number, options?: {
+    query?: {
+      /** true for credited roles only, false for uncredited only */
+      credited?: boolean;
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    movie_id?: number;
+    data?: (CastMember & {
+      actor_id?: number;
+    })[];
+    meta?: {
+      page?: number;
+      limit?: number;
+      /** Cast entries matching credited, across all pages */
+      total?: number;
+      pages?: number;
+      hasNextPage?: boolean;
+      hasPreviousPage?: boolean;
+    };
+    /** Entries on this page */
+    count?: number;
+  }> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/cast`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/movies/{id}/similar-by-plot
+   * Movies with a similar plot
+   */
+  getMoviesByIdSimilarByPlot(id: string | number, options?: {
+    query?: {
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<{
+    data?: {
+      movie_id?: number;
+      public_id?: string | null;
+      title?: string;
+      release_date?: string | null;
+      overview?: string;
+      poster_url?: string | null;
+      /** Cosine similarity, 1 is identical */
+      similarity?: number;
+    }[];
+    meta?: {
+      method?: "embeddings" | "tfidf";
+      /** Embedding model, for method=embeddings */
+      model?: string;
+    };
+  }> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/similar-by-plot`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/movies/{id}/history
+   * Earlier versions of a movie
+   */
+  getMoviesByIdHistory(id: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    movie_id?: number;
+    current_version?: number;
+    current_since?: string;
+    data?: {
+      version?: number;
+      valid_from?: string;
+      valid_to?: string;
+      /** The movie's columns as they were in this version */
+      data?: Record<string, unknown>;
+    }[];
+    meta?: Record<string, unknown>;
+  }> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/history`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/movies/{id}/jsonld
+   * Movie structured data (JSON-LD)
+   */
+  getMoviesByIdJsonld(id: string | number): Promise<Record<string, unknown>> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/jsonld`);
+  }
+
+  /**
+   * GET /api/movies/{id}/placeholder.svg
+   * Placeholder poster
+   */
+  getMoviesByIdPlaceholderSvg(id: string | number): Promise<Response> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/placeholder.svg`, { raw: true });
+  }
+
+  /**
+   * GET /api/og/{type}/{id}.png
+   * Open Graph preview image
+   */
+  getOgByTypeByIdPng(type: string | number, id: string | number): Promise<Response> {
+    return this.request('GET', `/api/og/${encodeURIComponent(String(type))}/${encodeURIComponent(String(id))}.png`, { raw: true });
+  }
+
+  /**
+   * GET /api/movies/{id}/short-link
+   * Get a movie's short link
+   */
+  getMoviesByIdShortLink(id: string | number): Promise<{
+    code?: string;
+    short_url?: string;
+    /** Where the short link redirects */
+    movie_url?: string;
+    qr_url?: string;
+    visits?: number;
+    created_at?: string;
+  }> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/short-link`);
+  }
+
+  /**
+   * GET /api/movies/{id}/qr.png
+   * QR code for a movie
+   */
+  getMoviesByIdQrPng(id: string | number, options?: {
+    query?: {
+      /** Pixels per QR module */
+      size?: number;
+    };
+  }): Promise<Response> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/qr.png`, { query: options?.query, raw: true });
+  }
+
+  /**
+   * GET /api/movies/{id}/reviews
+   * List a movie's reviews
+   */
+  getMoviesByIdReviews(id: string | number, options?: {
+    query?: {
+      /** helpful puts the most net helpful votes first */
+      sortBy?: "newest" | "helpful";
+      show_spoilers?: boolean;
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    data?: Review[];
+    meta?: Record<string, unknown>;
+    average_rating?: number | null;
+  }> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/reviews`, { query: options?.query });
+  }
+
+  /**
+   * POST /api/movies/{id}/reviews
+   * Review a movie
+   */
+  postMoviesByIdReviews(id: string | number, options: {
+    body: {
+      rating: number;
+      body?: string | null;
+      contains_spoilers?: boolean;
+    };
+  }): Promise<{
+    success?: boolean;
+    message?: string;
+    data?: Review;
+  }> {
+    return this.request('POST', `/api/movies/${encodeURIComponent(String(id))}/reviews`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/reviews/mine
+   * List my reviews
+   */
+  getReviewsMine(): Promise<void> {
+    return this.request('GET', `/api/reviews/mine`);
+  }
+
+  /**
+   * DELETE /api/reviews/{reviewId}
+   * Delete my review
+   */
+  deleteReviewsByReviewId(reviewId: string | number): Promise<void> {
+    return this.request('DELETE', `/api/reviews/${encodeURIComponent(String(reviewId))}`);
+  }
+
+  /**
+   * PUT /api/reviews/{reviewId}/reaction
+   * React to a review
+   */
+  putReviewsByReviewIdReaction(reviewId: string | number, options: {
+    body: {
+      reaction: "helpful" | "unhelpful";
+    };
+  }): Promise<{
+    success?: boolean;
+    data?: {
+      review_id?: number;
+      reaction?: string | null;
+      helpful_count?: number;
+      unhelpful_count?: number;
+    };
+  }> {
+    return this.request('PUT', `/api/reviews/${encodeURIComponent(String(reviewId))}/reaction`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/reviews/{reviewId}/reaction
+   * Remove my reaction to a review
+   */
+  deleteReviewsByReviewIdReaction(reviewId: string | number): Promise<void> {
+    return this.request('DELETE', `/api/reviews/${encodeURIComponent(String(reviewId))}/reaction`);
+  }
+
+  /**
+   * GET /api/movies/{id}/translations
+   * List movie translations
+   */
+  getMoviesByIdTranslations(id: string | number): Promise<{
+    movie_id?: number;
+    data?: MovieTranslation[];
+    count?: number;
+  }> {
+    return this.request('GET', `/api/movies/${encodeURIComponent(String(id))}/translations`);
+  }
+
+  /**
+   * PUT /api/movies/{id}/translations/{lang}
+   * Create or replace a movie translation
+   */
+  putMoviesByIdTranslationsByLang(id: string | number, lang: string | number, options: {
+    body: {
+      title?: string | null;
+      overview?: string | null;
+      tagline?: string | null;
+    };
+  }): Promise<{
+    success?: boolean;
+    movie_id?: number;
+    translation?: MovieTranslation;
+  }> {
+    return this.request('PUT', `/api/movies/${encodeURIComponent(String(id))}/translations/${encodeURIComponent(String(lang))}`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/studios/countries
+   * Studio statistics by country
+   */
+  getStudiosCountries(options?: {
+    query?: {
+      format?: "json" | "geojson";
+    };
+  }): Promise<{
+    data?: StudioCountryStats[];
+    count?: number;
+  } | {
+    type?: string;
+    features?: {
+      type?: string;
+      id?: string;
+      geometry?: {
+        type?: string;
+        coordinates?: number[];
+      } | null;
+      properties?: StudioCountryStats;
+    }[];
+  }> {
+    return this.request('GET', `/api/studios/countries`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/studios/{id}/movies
+   * Get movies by studio ID
+   */
+  getStudiosByIdMovies(id: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/studios/${encodeURIComponent(String(id))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/studios/name/{name}/movies
+   * Get movies by studio name
+   */
+  getStudiosNameByNameMovies(name: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/studios/name/${encodeURIComponent(String(name))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/keywords
+   * List keywords
+   */
+  getKeywords(options?: {
+    query?: {
+      /** Search by keyword (partial match) */
+      name?: string;
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      sortBy?: "name" | "movie_count";
+      /** Defaults to desc for movie_count and asc for name */
+      sortOrder?: "asc" | "desc";
+    };
+  }): Promise<{
+    data?: Keyword[];
+    meta?: {
+      page?: number;
+      limit?: number;
+      total?: number;
+      pages?: number;
+      hasNextPage?: boolean;
+      hasPreviousPage?: boolean;
+    };
+  }> {
+    return this.request('GET', `/api/keywords`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/directors/{id}/movies
+   * Get movies by director ID
+   */
+  getDirectorsByIdMovies(id: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/directors/${encodeURIComponent(String(id))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/directors/name/{name}/movies
+   * Get movies by director name
+   */
+  getDirectorsNameByNameMovies(name: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/directors/name/${encodeURIComponent(String(name))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/actors/{id}/images
+   * Actor profile images
+   */
+  getActorsByIdImages(id: string | number): Promise<{
+    actor_id?: number;
+    canonical?: PersonImage | null;
+    alternates?: PersonImage[];
+  }> {
+    return this.request('GET', `/api/actors/${encodeURIComponent(String(id))}/images`);
+  }
+
+  /**
+   * GET /api/actors/{id}/costars
+   * Co-star network for an actor
+   */
+  getActorsByIdCostars(id: string | number, options?: {
+    query?: {
+      limit?: number;
+      /** Only include co-stars with at least this many shared movies */
+      minShared?: number;
+    };
+  }): Promise<{
+    actor?: ActorSummary;
+    data?: (ActorSummary & {
+      movies_together?: number;
+      shared_movies?: {
+        movie_id?: number;
+        title?: string;
+        release_date?: string;
+      }[];
+    })[];
+    count?: number;
+  }> {
+    return this.request('GET', `/api/actors/${encodeURIComponent(String(id))}/costars`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/actors/{id}/path-to/{otherId}
+   * Degrees of separation between two actors
+   */
+  getActorsByIdPathToByOtherId(id: string | number, otherId: string | number, options?: {
+    query?: {
+      /** Give up beyond this many degrees */
+      maxDepth?: number;
+    };
+  }): Promise<{
+    from?: ActorSummary;
+    to?: ActorSummary;
+    degrees?: number;
+    path?: ActorSummary[];
+    links?: {
+      from_actor_id?: number;
+      to_actor_id?: number;
+      movie?: {
+        movie_id?: number;
+        title?: string;
+        release_date?: string;
+      };
+    }[];
+  }> {
+    return this.request('GET', `/api/actors/${encodeURIComponent(String(id))}/path-to/${encodeURIComponent(String(otherId))}`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/actors/{id}/movies
+   * Get movies by actor ID
+   */
+  getActorsByIdMovies(id: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/actors/${encodeURIComponent(String(id))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/actors/name/{name}/movies
+   * Get movies by actor name
+   */
+  getActorsNameByNameMovies(name: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/actors/name/${encodeURIComponent(String(name))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/collections/{id}/movies
+   * Get movies by collection ID
+   */
+  getCollectionsByIdMovies(id: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/collections/${encodeURIComponent(String(id))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/collections/name/{name}/movies
+   * Get movies by collection name
+   */
+  getCollectionsNameByNameMovies(name: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Include titles flagged adult or carrying a restricted rating. Ignored when the server's content filter is strict. */
+      include_adult?: boolean;
+    };
+  }): Promise<MovieListResponse> {
+    return this.request('GET', `/api/collections/name/${encodeURIComponent(String(name))}/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/stats/box-office
+   * Box-office time series
+   */
+  getStatsBoxOffice(options?: {
+    query?: {
+      interval?: "year" | "month";
+      /** First release year (inclusive) */
+      from?: number;
+      /** Last release year (inclusive) */
+      to?: number;
+      /** Restrict the series to one genre */
+      genre?: string;
+      /** Also return one series per genre */
+      byGenre?: boolean;
+    };
+  }): Promise<{
+    interval?: string;
+    data?: BoxOfficePoint[];
+    /** Only present when byGenre=true; keyed by genre name */
+    by_genre?: Record<string, BoxOfficePoint[]>;
+  }> {
+    return this.request('GET', `/api/stats/box-office`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/export/movies
+   * Export full movie records
+   */
+  getExportMovies(options?: {
+    query?: {
+      /** Only movies updated after this ISO timestamp */
+      since?: string;
+      /** The previous page's `meta.next_cursor`; omit for the first page */
+      cursor?: string;
+      limit?: number;
+    };
+  }): Promise<{
+    data?: ExportedMovie[];
+    meta?: {
+      limit?: number;
+      total?: number;
+      hasNextPage?: boolean;
+      /** Pass as `cursor` for the next page; null on the last page */
+      next_cursor?: string | null;
+      since?: string | null;
+      /** Use as `since` for the next delta */
+      server_time?: string;
+    };
+  }> {
+    return this.request('GET', `/api/export/movies`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/datasets
+   * List published dataset snapshots
+   */
+  getDatasets(): Promise<{
+    data?: {
+      version?: string;
+      movie_count?: number;
+      created_at?: string;
+      files?: {
+        format?: "jsonl" | "csv" | "diff";
+        file_name?: string;
+        /** Snapshot the diff applies to (diff files only) */
+        base_version?: string;
+        /** Operations in the diff (diff files only) */
+        change_count?: number;
+        byte_size?: number;
+        sha256?: string;
+        url?: string;
+      }[];
+    }[];
+  }> {
+    return this.request('GET', `/api/datasets`);
+  }
+
+  /**
+   * GET /api/datasets/{file}
+   * Download a dataset snapshot file
+   */
+  getDatasetsByFile(file: string | number): Promise<Response> {
+    return this.request('GET', `/api/datasets/${encodeURIComponent(String(file))}`, { raw: true });
+  }
+
+  /**
+   * GET /api/me/watchlist
+   * List my watchlist
+   */
+  getMeWatchlist(): Promise<void> {
+    return this.request('GET', `/api/me/watchlist`);
+  }
+
+  /**
+   * PUT /api/me/watchlist/{id}
+   * Add a movie to my watchlist
+   */
+  putMeWatchlistById(id: string | number): Promise<{
+    success?: boolean;
+    added?: boolean;
+  }> {
+    return this.request('PUT', `/api/me/watchlist/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * DELETE /api/me/watchlist/{id}
+   * Remove a movie from my watchlist
+   */
+  deleteMeWatchlistById(id: string | number): Promise<void> {
+    return this.request('DELETE', `/api/me/watchlist/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * GET /api/me/following
+   * List users I follow
+   */
+  getMeFollowing(): Promise<void> {
+    return this.request('GET', `/api/me/following`);
+  }
+
+  /**
+   * GET /api/me/followers
+   * List my followers
+   */
+  getMeFollowers(): Promise<void> {
+    return this.request('GET', `/api/me/followers`);
+  }
+
+  /**
+   * GET /api/me/feed
+   * Activity from users I follow
+   */
+  getMeFeed(options?: {
+    query?: {
+      type?: "rating" | "review" | "watchlist_add";
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    data?: Activity[];
+    meta?: {
+      page?: number;
+      limit?: number;
+      hasNextPage?: boolean;
+      hasPreviousPage?: boolean;
+    };
+  }> {
+    return this.request('GET', `/api/me/feed`, { query: options?.query });
+  }
+
+  /**
+   * PUT /api/users/{id}/follow
+   * Follow a user
+   */
+  putUsersByIdFollow(id: string | number): Promise<void> {
+    return this.request('PUT', `/api/users/${encodeURIComponent(String(id))}/follow`);
+  }
+
+  /**
+   * DELETE /api/users/{id}/follow
+   * Unfollow a user
+   */
+  deleteUsersByIdFollow(id: string | number): Promise<void> {
+    return this.request('DELETE', `/api/users/${encodeURIComponent(String(id))}/follow`);
+  }
+
+  /**
+   * GET /api/me/genres
+   * List my favorite genres
+   */
+  getMeGenres(): Promise<{
+    genres?: string[];
+  }> {
+    return this.request('GET', `/api/me/genres`);
+  }
+
+  /**
+   * PUT /api/me/genres
+   * Replace my favorite genres
+   */
+  putMeGenres(options: {
+    body: {
+      genres: string[];
+    };
+  }): Promise<void> {
+    return this.request('PUT', `/api/me/genres`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/me/digest
+   * My email digest subscription
+   */
+  getMeDigest(): Promise<DigestSubscription> {
+    return this.request('GET', `/api/me/digest`);
+  }
+
+  /**
+   * PUT /api/me/digest
+   * Subscribe to the new-releases digest
+   */
+  putMeDigest(options?: {
+    body?: {
+      frequency?: "daily" | "weekly";
+    };
+  }): Promise<DigestSubscription> {
+    return this.request('PUT', `/api/me/digest`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/me/digest
+   * Unsubscribe from the digest
+   */
+  deleteMeDigest(): Promise<void> {
+    return this.request('DELETE', `/api/me/digest`);
+  }
+
+  /**
+   * GET /api/me/devices
+   * List my push notification devices
+   */
+  getMeDevices(): Promise<void> {
+    return this.request('GET', `/api/me/devices`);
+  }
+
+  /**
+   * POST /api/me/devices
+   * Register a device for push notifications
+   */
+  postMeDevices(options: {
+    body: {
+      /** fcm for Android and web, apns for iOS */
+      platform: "fcm" | "apns";
+      /** FCM registration token or APNs device token */
+      token: string;
+    };
+  }): Promise<void> {
+    return this.request('POST', `/api/me/devices`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/me/devices/{deviceId}
+   * Unregister a device
+   */
+  deleteMeDevicesByDeviceId(deviceId: string | number): Promise<void> {
+    return this.request('DELETE', `/api/me/devices/${encodeURIComponent(String(deviceId))}`);
+  }
+
+  /**
+   * GET /api/me/calendar
+   * My calendar feed
+   */
+  getMeCalendar(): Promise<{
+    feed_url?: string | null;
+    created_at?: string | null;
+  }> {
+    return this.request('GET', `/api/me/calendar`);
+  }
+
+  /**
+   * POST /api/me/calendar
+   * Create my calendar feed
+   */
+  postMeCalendar(): Promise<{
+    feed_url?: string | null;
+    created_at?: string | null;
+  }> {
+    return this.request('POST', `/api/me/calendar`);
+  }
+
+  /**
+   * DELETE /api/me/calendar
+   * Delete my calendar feed
+   */
+  deleteMeCalendar(): Promise<void> {
+    return this.request('DELETE', `/api/me/calendar`);
+  }
+
+  /**
+   * GET /api/me/lists
+   * List my lists
+   */
+  getMeLists(): Promise<void> {
+    return this.request('GET', `/api/me/lists`);
+  }
+
+  /**
+   * POST /api/me/lists
+   * Create a list
+   */
+  postMeLists(options: {
+    body: {
+      name: string;
+      description?: string | null;
+      visibility?: "private" | "unlisted" | "public";
+    };
+  }): Promise<void> {
+    return this.request('POST', `/api/me/lists`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/me/lists/{listId}
+   * Get one of my lists
+   */
+  getMeListsByListId(listId: string | number): Promise<MovieList> {
+    return this.request('GET', `/api/me/lists/${encodeURIComponent(String(listId))}`);
+  }
+
+  /**
+   * PATCH /api/me/lists/{listId}
+   * Update a list
+   */
+  patchMeListsByListId(listId: string | number, options: {
+    body: {
+      name?: string;
+      description?: string | null;
+      visibility?: "private" | "unlisted" | "public";
+    };
+  }): Promise<void> {
+    return this.request('PATCH', `/api/me/lists/${encodeURIComponent(String(listId))}`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/me/lists/{listId}
+   * Delete a list
+   */
+  deleteMeListsByListId(listId: string | number): Promise<void> {
+    return this.request('DELETE', `/api/me/lists/${encodeURIComponent(String(listId))}`);
+  }
+
+  /**
+   * PUT /api/me/lists/{listId}/movies/{id}
+   * Add or move a movie on a list
+   */
+  putMeListsByListIdMoviesById(listId: string | number, id: string | number, options?: {
+    body?: {
+      position?: number;
+      note?: string | null;
+    };
+  }): Promise<{
+    success?: boolean;
+    movies?: MovieListItem[];
+  }> {
+    return this.request('PUT', `/api/me/lists/${encodeURIComponent(String(listId))}/movies/${encodeURIComponent(String(id))}`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/me/lists/{listId}/movies/{id}
+   * Remove a movie from a list
+   */
+  deleteMeListsByListIdMoviesById(listId: string | number, id: string | number): Promise<{
+    success?: boolean;
+    movies?: MovieListItem[];
+  }> {
+    return this.request('DELETE', `/api/me/lists/${encodeURIComponent(String(listId))}/movies/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * PUT /api/me/lists/{listId}/order
+   * Reorder a list
+   */
+  putMeListsByListIdOrder(listId: string | number, options: {
+    body: {
+      movie_ids: (number | string)[];
+    };
+  }): Promise<{
+    success?: boolean;
+    movies?: MovieListItem[];
+  }> {
+    return this.request('PUT', `/api/me/lists/${encodeURIComponent(String(listId))}/order`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/me/data
+   * Export my data
+   */
+  getMeData(): Promise<{
+    exported_at?: string;
+    account?: {
+      user_id?: number;
+      email?: string;
+      username?: string;
+      role?: "user" | "admin";
+      created_at?: string;
+      two_factor_enabled?: boolean;
+    };
+    identities?: Record<string, unknown>[];
+    sessions?: Record<string, unknown>[];
+    reviews?: Record<string, unknown>[];
+    review_reactions?: Record<string, unknown>[];
+    watchlist?: Record<string, unknown>[];
+    following?: Record<string, unknown>[];
+    followers?: Record<string, unknown>[];
+    activities?: Record<string, unknown>[];
+    lists?: Record<string, unknown>[];
+    favorite_genres?: string[];
+    digest_subscription?: Record<string, unknown> | null;
+    devices?: Record<string, unknown>[];
+    calendar_feed?: Record<string, unknown> | null;
+  }> {
+    return this.request('GET', `/api/me/data`);
+  }
+
+  /**
+   * DELETE /api/me
+   * Delete my account
+   */
+  deleteMe(options: {
+    query: {
+      /** Must be `true` */
+      confirm: "true";
+    };
+  }): Promise<{
+    success?: boolean;
+    message?: string;
+    deleted?: {
+      reviews?: number;
+      review_reactions?: number;
+      watchlist?: number;
+      follows?: number;
+      activities?: number;
+      lists?: number;
+    };
+  }> {
+    return this.request('DELETE', `/api/me`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/me/searches
+   * List saved searches
+   */
+  getMeSearches(): Promise<{
+    data?: SavedSearch[];
+    count?: number;
+  }> {
+    return this.request('GET', `/api/me/searches`);
+  }
+
+  /**
+   * POST /api/me/searches
+   * Save a search
+   */
+  postMeSearches(options: {
+    body: SavedSearchInput;
+  }): Promise<SavedSearch> {
+    return this.request('POST', `/api/me/searches`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/me/searches/{id}
+   * Get a saved search
+   */
+  getMeSearchesById(id: string | number): Promise<SavedSearch> {
+    return this.request('GET', `/api/me/searches/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * PATCH /api/me/searches/{id}
+   * Rename a saved search or replace its query
+   */
+  patchMeSearchesById(id: string | number, options: {
+    body: {
+      name?: string;
+      query?: SavedSearchQuery;
+    };
+  }): Promise<SavedSearch> {
+    return this.request('PATCH', `/api/me/searches/${encodeURIComponent(String(id))}`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/me/searches/{id}
+   * Delete a saved search
+   */
+  deleteMeSearchesById(id: string | number): Promise<void> {
+    return this.request('DELETE', `/api/me/searches/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * GET /api/me/searches/{id}/results
+   * Run a saved search
+   */
+  getMeSearchesByIdResults(id: string | number, options?: {
+    query?: {
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+      /** Add a `facets` object with match counts per genre, decade and MPA rating across the whole filtered result set (not just this page). */
+      facets?: boolean;
+      /** Debugging aid for tuning relevance. Adds a `score` object to each movie with its total relevance and components (each 0-1), and a top-level `ranking` object with the sort and weights used. */
+      explain?: boolean;
+      /** Comma-separated relations to expand into an `included` object on each movie. Each relation costs one query for the whole page, not one per movie. */
+      include?: string;
+      /** Cast entries to expand per movie with include=cast, in billing order. `included.cast_total` gives the full count; page through the rest with GET /api/movies/{id}/cast. */
+      cast_limit?: number;
+    };
+  }): Promise<MovieListResponse & {
+    search?: {
+      search_id?: number;
+      name?: string;
+    };
+  }> {
+    return this.request('GET', `/api/me/searches/${encodeURIComponent(String(id))}/results`, { query: options?.query });
+  }
+
+  /**
+   * POST /api/admin/people/{id}/merge-into/{targetId}
+   * Merge duplicate person
+   */
+  postAdminPeopleByIdMergeIntoByTargetId(id: string | number, targetId: string | number, options?: {
+    query?: {
+      /** Kind of person being merged */
+      type?: "actor" | "director" | "producer" | "writer" | "composer" | "cinematographer" | "editor";
+    };
+  }): Promise<{
+    success?: boolean;
+    message?: string;
+    type?: string;
+    merged_id?: number;
+    target?: Record<string, unknown>;
+    links_repointed?: number;
+    duplicate_links_dropped?: number;
+  }> {
+    return this.request('POST', `/api/admin/people/${encodeURIComponent(String(id))}/merge-into/${encodeURIComponent(String(targetId))}`, { query: options?.query });
+  }
+
+  /**
+   * POST /api/admin/movies/duplicates/scan
+   * Scan for duplicate movies
+   */
+  postAdminMoviesDuplicatesScan(options?: {
+    query?: {
+      maxYearGap?: number;
+    };
+  }): Promise<{
+    success?: boolean;
+    /** Newly flagged pairs */
+    flagged?: number;
+    maxYearGap?: number;
+  }> {
+    return this.request('POST', `/api/admin/movies/duplicates/scan`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/admin/movies/duplicates
+   * List duplicate flags
+   */
+  getAdminMoviesDuplicates(options?: {
+    query?: {
+      status?: "pending" | "dismissed";
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    data?: DuplicateFlag[];
+    meta?: Record<string, unknown>;
+  }> {
+    return this.request('GET', `/api/admin/movies/duplicates`, { query: options?.query });
+  }
+
+  /**
+   * PATCH /api/admin/movies/duplicates/{flagId}
+   * Dismiss or reopen a duplicate flag
+   */
+  patchAdminMoviesDuplicatesByFlagId(flagId: string | number, options: {
+    body: {
+      status: "pending" | "dismissed";
+    };
+  }): Promise<{
+    success?: boolean;
+    flag?: DuplicateFlag;
+  }> {
+    return this.request('PATCH', `/api/admin/movies/duplicates/${encodeURIComponent(String(flagId))}`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/admin/movies/duplicates/{flagId}/merge
+   * Merge a flagged pair
+   */
+  postAdminMoviesDuplicatesByFlagIdMerge(flagId: string | number): Promise<MovieMergeResponse> {
+    return this.request('POST', `/api/admin/movies/duplicates/${encodeURIComponent(String(flagId))}/merge`);
+  }
+
+  /**
+   * POST /api/admin/movies/{id}/flag-duplicate/{otherId}
+   * Flag two movies as duplicates
+   */
+  postAdminMoviesByIdFlagDuplicateByOtherId(id: string | number, otherId: string | number): Promise<{
+    success?: boolean;
+    flag?: DuplicateFlag;
+  }> {
+    return this.request('POST', `/api/admin/movies/${encodeURIComponent(String(id))}/flag-duplicate/${encodeURIComponent(String(otherId))}`);
+  }
+
+  /**
+   * POST /api/admin/movies/{id}/merge-into/{targetId}
+   * Merge a movie into another
+   */
+  postAdminMoviesByIdMergeIntoByTargetId(id: string | number, targetId: string | number): Promise<MovieMergeResponse> {
+    return this.request('POST', `/api/admin/movies/${encodeURIComponent(String(id))}/merge-into/${encodeURIComponent(String(targetId))}`);
+  }
+
+  /**
+   * POST /api/admin/movies/bulk-delete
+   * Delete movies matching a filter
+   */
+  postAdminMoviesBulkDelete(options: {
+    body: {
+      filter: BulkMovieFilter;
+      dryRun?: boolean;
+      /** Token from the dry-run response; required when dryRun is false */
+      previewToken?: string;
+    };
+  }): Promise<BulkOperationResponse> {
+    return this.request('POST', `/api/admin/movies/bulk-delete`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/admin/movies/bulk-update
+   * Update movies matching a filter
+   */
+  postAdminMoviesBulkUpdate(options: {
+    body: {
+      filter: BulkMovieFilter;
+      set: BulkMovieSet;
+      dryRun?: boolean;
+      /** Token from the dry-run response; required when dryRun is false */
+      previewToken?: string;
+    };
+  }): Promise<BulkOperationResponse> {
+    return this.request('POST', `/api/admin/movies/bulk-update`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/admin/movies/{id}/lineage
+   * Import lineage of a movie
+   */
+  getAdminMoviesByIdLineage(id: string | number, options?: {
+    query?: {
+      /** Only runs that changed this column */
+      field?: "title" | "original_title" | "release_date" | "runtime_minutes" | "overview" | "budget" | "revenue" | "mpa_rating" | "collection_id" | "poster_url" | "backdrop_url" | "adult" | "vote_count" | "vote_average" | "trailer_url";
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    movie_id?: number;
+    title?: string;
+    /** Null for movies added before lineage was recorded */
+    created_by?: ImportRun | null;
+    data?: (ImportRun & {
+      action?: "created" | "updated";
+      /** Changed columns, each as {"from": old, "to": new} */
+      changes?: Record<string, {
+        from?: unknown;
+        to?: unknown;
+      }>;
+      recorded_at?: string;
+    })[];
+    meta?: Record<string, unknown>;
+  }> {
+    return this.request('GET', `/api/admin/movies/${encodeURIComponent(String(id))}/lineage`, { query: options?.query });
+  }
+
+  /**
+   * POST /api/admin/movies/{id}/undo
+   * Undo changes to a movie
+   */
+  postAdminMoviesByIdUndo(id: string | number, options?: {
+    body?: {
+      version?: number;
+    };
+  }): Promise<{
+    success?: boolean;
+    movie_id?: number;
+    restored_version?: number;
+    /** The movie's new version */
+    version?: number;
+    message?: string;
+  }> {
+    return this.request('POST', `/api/admin/movies/${encodeURIComponent(String(id))}/undo`, { body: options?.body });
+  }
+
+  /**
+   * POST /api/admin/stats/box-office/refresh
+   * Refresh box-office aggregates
+   */
+  postAdminStatsBoxOfficeRefresh(): Promise<void> {
+    return this.request('POST', `/api/admin/stats/box-office/refresh`);
+  }
+
+  /**
+   * GET /api/admin/jobs
+   * List background jobs
+   */
+  getAdminJobs(): Promise<void> {
+    return this.request('GET', `/api/admin/jobs`);
+  }
+
+  /**
+   * POST /api/admin/jobs/{name}/run
+   * Run a background job now
+   */
+  postAdminJobsByNameRun(name: string | number): Promise<void> {
+    return this.request('POST', `/api/admin/jobs/${encodeURIComponent(String(name))}/run`);
+  }
+
+  /**
+   * GET /api/admin/metrics/latency
+   * Per-endpoint latency
+   */
+  getAdminMetricsLatency(): Promise<{
+    collecting_since?: string;
+    slo_latency_ms?: number;
+    slow_query_ms?: number;
+    buckets_ms?: number[];
+    endpoints?: EndpointLatency[];
+  }> {
+    return this.request('GET', `/api/admin/metrics/latency`);
+  }
+
+  /**
+   * DELETE /api/admin/metrics/latency
+   * Reset latency metrics
+   */
+  deleteAdminMetricsLatency(): Promise<void> {
+    return this.request('DELETE', `/api/admin/metrics/latency`);
+  }
+
+  /**
+   * GET /api/admin/features
+   * List feature flags
+   */
+  getAdminFeatures(): Promise<{
+    data?: FeatureFlag[];
+  }> {
+    return this.request('GET', `/api/admin/features`);
+  }
+
+  /**
+   * PUT /api/admin/features/{flag}
+   * Toggle a feature flag
+   */
+  putAdminFeaturesByFlag(flag: string | number, options: {
+    body: {
+      enabled: boolean;
+    };
+  }): Promise<{
+    success?: boolean;
+    flag?: FeatureFlag;
+  }> {
+    return this.request('PUT', `/api/admin/features/${encodeURIComponent(String(flag))}`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/admin/features/{flag}
+   * Reset a feature flag to its default
+   */
+  deleteAdminFeaturesByFlag(flag: string | number): Promise<{
+    success?: boolean;
+    flag?: FeatureFlag;
+  }> {
+    return this.request('DELETE', `/api/admin/features/${encodeURIComponent(String(flag))}`);
+  }
+
+  /**
+   * GET /api/admin/search-ranking
+   * List search ranking weights
+   */
+  getAdminSearchRanking(): Promise<{
+    data?: RankingSetting[];
+  }> {
+    return this.request('GET', `/api/admin/search-ranking`);
+  }
+
+  /**
+   * PATCH /api/admin/search-ranking
+   * Change search ranking weights
+   */
+  patchAdminSearchRanking(options: {
+    body: {
+      title_weight?: number;
+      popularity_weight?: number;
+      recency_weight?: number;
+      recency_half_life_years?: number;
+    };
+  }): Promise<void> {
+    return this.request('PATCH', `/api/admin/search-ranking`, { body: options?.body });
+  }
+
+  /**
+   * DELETE /api/admin/search-ranking/{setting}
+   * Reset a search ranking setting to its default
+   */
+  deleteAdminSearchRankingBySetting(setting: string | number): Promise<void> {
+    return this.request('DELETE', `/api/admin/search-ranking/${encodeURIComponent(String(setting))}`);
+  }
+
+  /**
+   * GET /api/admin/users/locked
+   * List accounts with failed logins
+   */
+  getAdminUsersLocked(): Promise<{
+    data?: {
+      user_id?: number;
+      username?: string;
+      email?: string;
+      role?: string;
+      failed_login_count?: number;
+      last_failed_login_at?: string | null;
+      locked_until?: string | null;
+      locked?: boolean;
+    }[];
+    count?: number;
+  }> {
+    return this.request('GET', `/api/admin/users/locked`);
+  }
+
+  /**
+   * POST /api/admin/users/{id}/unlock
+   * Unlock an account
+   */
+  postAdminUsersByIdUnlock(id: string | number): Promise<{
+    success?: boolean;
+    message?: string;
+  }> {
+    return this.request('POST', `/api/admin/users/${encodeURIComponent(String(id))}/unlock`);
+  }
+
+  /**
+   * POST /api/admin/users/{id}/2fa/reset
+   * Reset a user's 2FA
+   */
+  postAdminUsersById2faReset(id: string | number): Promise<{
+    success?: boolean;
+    message?: string;
+  }> {
+    return this.request('POST', `/api/admin/users/${encodeURIComponent(String(id))}/2fa/reset`);
+  }
+
+  /**
+   * GET /api/admin/reviews
+   * Review moderation queue
+   */
+  getAdminReviews(options?: {
+    query?: {
+      status?: "pending" | "approved" | "rejected";
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    data?: Review[];
+    meta?: Record<string, unknown>;
+  }> {
+    return this.request('GET', `/api/admin/reviews`, { query: options?.query });
+  }
+
+  /**
+   * POST /api/admin/reviews/{reviewId}/approve
+   * Approve a review
+   */
+  postAdminReviewsByReviewIdApprove(reviewId: string | number): Promise<void> {
+    return this.request('POST', `/api/admin/reviews/${encodeURIComponent(String(reviewId))}/approve`);
+  }
+
+  /**
+   * POST /api/admin/reviews/{reviewId}/reject
+   * Reject a review
+   */
+  postAdminReviewsByReviewIdReject(reviewId: string | number, options?: {
+    body?: {
+      note?: string;
+    };
+  }): Promise<void> {
+    return this.request('POST', `/api/admin/reviews/${encodeURIComponent(String(reviewId))}/reject`, { body: options?.body });
+  }
+
+  /**
+   * GET /api/admin/imports
+   * Staged import batches
+   */
+  getAdminImports(options?: {
+    query?: {
+      status?: "pending" | "approved" | "rejected";
+      /** Page number for pagination */
+      page?: number;
+      /** Number of items per page. page × limit may not exceed 10,000. */
+      limit?: number;
+    };
+  }): Promise<{
+    data?: ImportBatch[];
+    meta?: Record<string, unknown>;
+  }> {
+    return this.request('GET', `/api/admin/imports`, { query: options?.query });
+  }
+
+  /**
+   * GET /api/admin/imports/{batchId}
+   * Review a staged import
+   */
+  getAdminImportsByBatchId(batchId: string | number): Promise<{
+    data?: ImportBatchSummary;
+  }> {
+    return this.request('GET', `/api/admin/imports/${encodeURIComponent(String(batchId))}`);
+  }
+
+  /**
+   * GET /api/admin/imports/{batchId}/profile
+   * Profile a staged import's fields
+   */
+  getAdminImportsByBatchIdProfile(batchId: string | number): Promise<{
+    data?: {
+      batch_id?: number;
+      movie_count?: number;
+      fields?: FieldProfile[];
+    };
+  }> {
+    return this.request('GET', `/api/admin/imports/${encodeURIComponent(String(batchId))}/profile`);
+  }
+
+  /**
+   * POST /api/admin/imports/{batchId}/approve
+   * Approve a staged import
+   */
+  postAdminImportsByBatchIdApprove(batchId: string | number): Promise<{
+    success?: boolean;
+    batch_id?: number;
+    movie_ids?: number[];
+    message?: string;
+  }> {
+    return this.request('POST', `/api/admin/imports/${encodeURIComponent(String(batchId))}/approve`);
+  }
+
+  /**
+   * POST /api/admin/imports/{batchId}/reject
+   * Reject a staged import
+   */
+  postAdminImportsByBatchIdReject(batchId: string | number, options?: {
+    body?: {
+      note?: string;
+    };
+  }): Promise<void> {
+    return this.request('POST', `/api/admin/imports/${encodeURIComponent(String(batchId))}/reject`, { body: options?.body });
+  }
+}
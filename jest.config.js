@@ -22,17 +22,18 @@ module.exports = {
       statements: 80,
     },
   },
-  setupFilesAfterEnv: ['<rootDir>/src/test/setup.ts'],
   moduleNameMapper: {
     '^@controllers/(.*)$': '<rootDir>/src/controllers/$1',
     '^@controllers$': '<rootDir>/src/controllers',
+    '^@routes/(.*)$': '<rootDir>/src/routes/$1',
+    '^@routes$': '<rootDir>/src/routes',
     '^@middleware/(.*)$': '<rootDir>/src/core/middleware/$1',
     '^@middleware$': '<rootDir>/src/core/middleware',
-    '^@utilities/(.*)$': '<rootDir>/src/core/utilities/$1',
-    '^@utilities$': '<rootDir>/src/core/utilities',
+    '^@utils/(.*)$': '<rootDir>/src/core/utils/$1',
+    '^@utils$': '<rootDir>/src/core/utils',
     '^@models/(.*)$': '<rootDir>/src/core/models/$1',
     '^@models$': '<rootDir>/src/core/models',
-    '^@db$': '<rootDir>/src/core/utilities/database',
+    '^@db$': '<rootDir>/src/core/utils/database',
     '^@/types/(.*)$': '<rootDir>/src/types/$1',
     '^@/types$': '<rootDir>/src/types'
   },
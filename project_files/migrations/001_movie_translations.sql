@@ -0,0 +1,21 @@
+-- Migration 001: Movie translations
+-- Localized title/overview/tagline per movie, served through ?lang=
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS movie_translations (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   language VARCHAR(10) NOT NULL,
+   title VARCHAR(500),
+   overview TEXT,
+   tagline VARCHAR(500),
+   PRIMARY KEY (movie_id, language)
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_movie_translations_language ON movie_translations(language);
+
+
+COMMIT;
@@ -0,0 +1,32 @@
+-- Migration 002: Admin role on API keys and a general audit log
+-- Promote a key with: UPDATE api_keys SET role = 'admin' WHERE api_key_id = ...;
+
+
+BEGIN;
+
+
+ALTER TABLE api_keys
+   ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'user';
+
+ALTER TABLE api_keys DROP CONSTRAINT IF EXISTS check_api_key_role;
+ALTER TABLE api_keys
+   ADD CONSTRAINT check_api_key_role CHECK (role IN ('user', 'admin'));
+
+
+-- One row per administrative action (merges, bulk edits, ...)
+CREATE TABLE IF NOT EXISTS audit_log (
+   audit_id SERIAL PRIMARY KEY,
+   action VARCHAR(100) NOT NULL,
+   entity_type VARCHAR(50) NOT NULL,
+   entity_id INTEGER,
+   details JSONB,
+   performed_by INTEGER REFERENCES api_keys(api_key_id) ON DELETE SET NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log(entity_type, entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at);
+
+
+COMMIT;
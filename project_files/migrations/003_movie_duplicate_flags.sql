@@ -0,0 +1,24 @@
+-- Migration 003: Suspected duplicate movies awaiting admin review
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS movie_duplicate_flags (
+   flag_id SERIAL PRIMARY KEY,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   duplicate_of_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   reason VARCHAR(255),
+   status VARCHAR(20) NOT NULL DEFAULT 'pending',
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   resolved_at TIMESTAMPTZ,
+   CONSTRAINT check_flag_status CHECK (status IN ('pending', 'dismissed')),
+   CONSTRAINT check_flag_pair CHECK (movie_id < duplicate_of_id),
+   UNIQUE (movie_id, duplicate_of_id)
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_duplicate_flags_status ON movie_duplicate_flags(status);
+
+
+COMMIT;
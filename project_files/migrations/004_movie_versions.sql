@@ -0,0 +1,11 @@
+-- Migration 004: Row versions for optimistic concurrency on movie edits
+
+
+BEGIN;
+
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+
+COMMIT;
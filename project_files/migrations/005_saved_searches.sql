@@ -0,0 +1,21 @@
+-- Migration 005: Named movie searches saved per API key
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS saved_searches (
+   search_id SERIAL PRIMARY KEY,
+   api_key_id INTEGER NOT NULL REFERENCES api_keys(api_key_id) ON DELETE CASCADE,
+   name VARCHAR(100) NOT NULL,
+   query JSONB NOT NULL DEFAULT '{}',
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   UNIQUE (api_key_id, name)
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_api_key ON saved_searches(api_key_id);
+
+
+COMMIT;
@@ -0,0 +1,47 @@
+-- Migration 006: Precomputed monthly box-office totals for the time-series endpoint
+-- Refreshed by the API after imports and bulk edits, or with:
+--    REFRESH MATERIALIZED VIEW CONCURRENTLY box_office_monthly;
+
+
+BEGIN;
+
+
+-- genre_id 0 holds the all-genre totals; a movie is counted once per genre it belongs to
+CREATE MATERIALIZED VIEW IF NOT EXISTS box_office_monthly AS
+   SELECT
+      DATE_TRUNC('month', m.release_date)::date AS month,
+      0 AS genre_id,
+      NULL::VARCHAR(100) AS genre_name,
+      COUNT(*)::int AS movie_count,
+      COUNT(NULLIF(m.revenue, 0))::int AS revenue_count,
+      COALESCE(SUM(m.revenue), 0)::bigint AS total_revenue,
+      COUNT(NULLIF(m.budget, 0))::int AS budget_count,
+      COALESCE(SUM(m.budget), 0)::bigint AS total_budget
+   FROM movies m
+   WHERE m.release_date IS NOT NULL
+   GROUP BY DATE_TRUNC('month', m.release_date)
+
+   UNION ALL
+
+   SELECT
+      DATE_TRUNC('month', m.release_date)::date AS month,
+      g.genre_id,
+      g.genre_name,
+      COUNT(*)::int AS movie_count,
+      COUNT(NULLIF(m.revenue, 0))::int AS revenue_count,
+      COALESCE(SUM(m.revenue), 0)::bigint AS total_revenue,
+      COUNT(NULLIF(m.budget, 0))::int AS budget_count,
+      COALESCE(SUM(m.budget), 0)::bigint AS total_budget
+   FROM movies m
+   JOIN movie_genres mg ON m.movie_id = mg.movie_id
+   JOIN genres g ON mg.genre_id = g.genre_id
+   WHERE m.release_date IS NOT NULL
+   GROUP BY DATE_TRUNC('month', m.release_date), g.genre_id, g.genre_name
+WITH DATA;
+
+
+-- Required for REFRESH ... CONCURRENTLY
+CREATE UNIQUE INDEX IF NOT EXISTS idx_box_office_monthly_key ON box_office_monthly(month, genre_id);
+
+
+COMMIT;
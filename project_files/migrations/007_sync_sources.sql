@@ -0,0 +1,29 @@
+-- Migration 007: Bookkeeping for `npm run sync` (pulling movies from another instance)
+
+
+BEGIN;
+
+
+-- One row per remote instance we have pulled from
+CREATE TABLE IF NOT EXISTS sync_sources (
+   source_id SERIAL PRIMARY KEY,
+   base_url VARCHAR(500) UNIQUE NOT NULL,
+   last_synced_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+-- Remote movie_id -> local movie_id, so re-syncs update instead of duplicating
+CREATE TABLE IF NOT EXISTS movie_sync_map (
+   source_id INTEGER NOT NULL REFERENCES sync_sources(source_id) ON DELETE CASCADE,
+   source_movie_id INTEGER NOT NULL,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   synced_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   PRIMARY KEY (source_id, source_movie_id)
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_movies_updated_at ON movies(updated_at);
+
+
+COMMIT;
@@ -0,0 +1,13 @@
+-- Migration 008: Indexes behind every GET /api/movies sortBy option
+-- title and release_date are indexed in initialization.sql
+
+
+BEGIN;
+
+
+CREATE INDEX IF NOT EXISTS idx_movies_runtime ON movies(runtime_minutes);
+CREATE INDEX IF NOT EXISTS idx_movies_budget ON movies(budget);
+CREATE INDEX IF NOT EXISTS idx_movies_revenue ON movies(revenue);
+
+
+COMMIT;
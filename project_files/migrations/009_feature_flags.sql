@@ -0,0 +1,16 @@
+-- Migration 009: Feature flags toggled at runtime through /api/admin/features
+
+
+BEGIN;
+
+
+-- Only flags that have been toggled get a row; others use the default in code
+CREATE TABLE IF NOT EXISTS feature_flags (
+   flag_key VARCHAR(100) PRIMARY KEY,
+   enabled BOOLEAN NOT NULL,
+   updated_by INTEGER REFERENCES api_keys(api_key_id) ON DELETE SET NULL,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+COMMIT;
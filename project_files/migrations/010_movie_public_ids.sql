@@ -0,0 +1,25 @@
+-- Migration 010: Opaque public IDs for movies
+-- Integer movie_id stays the internal key; public_id is safe to expose since it
+-- doesn't reveal catalog size and doesn't collide across environments.
+
+
+BEGIN;
+
+
+-- 12 url-safe base64 characters (72 random bits) from a random UUID
+CREATE OR REPLACE FUNCTION generate_public_id() RETURNS VARCHAR(12) AS $$
+   SELECT SUBSTRING(
+      TRANSLATE(ENCODE(DECODE(REPLACE(gen_random_uuid()::text, '-', ''), 'hex'), 'base64'), '+/=', '-_'),
+      1, 12
+   );
+$$ LANGUAGE SQL VOLATILE;
+
+
+-- Existing rows each get their own value from the volatile default
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS public_id VARCHAR(12) NOT NULL DEFAULT generate_public_id();
+
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_movies_public_id ON movies(public_id);
+
+
+COMMIT;
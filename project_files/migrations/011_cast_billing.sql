@@ -0,0 +1,16 @@
+-- Migration 011: Explicit billing order and credited flag on cast entries
+-- actor_order stays the position the cast was supplied in; billing_order is
+-- the source's own billing when it has one.
+
+
+BEGIN;
+
+
+ALTER TABLE movie_actors ADD COLUMN IF NOT EXISTS billing_order INTEGER;
+ALTER TABLE movie_actors ADD COLUMN IF NOT EXISTS credited BOOLEAN NOT NULL DEFAULT TRUE;
+
+
+CREATE INDEX IF NOT EXISTS idx_movie_actors_billing ON movie_actors(movie_id, billing_order);
+
+
+COMMIT;
@@ -0,0 +1,22 @@
+-- Migration 012: One entry per role for actors playing several characters
+-- character_name keeps the combined text ("Character A / Character B") for
+-- older clients; character_names holds each role separately.
+
+
+BEGIN;
+
+
+ALTER TABLE movie_actors ADD COLUMN IF NOT EXISTS character_names TEXT[];
+
+
+-- Split existing combined names on " / "
+UPDATE movie_actors
+SET character_names = ARRAY(
+   SELECT TRIM(part)
+   FROM UNNEST(REGEXP_SPLIT_TO_ARRAY(character_name, '\s+/\s+')) AS part
+   WHERE TRIM(part) <> ''
+)
+WHERE character_name IS NOT NULL AND character_names IS NULL;
+
+
+COMMIT;
@@ -0,0 +1,12 @@
+-- Migration 013: Adult flag on movies
+-- Flagged titles, and titles with a restricted rating, are hidden from public
+-- lists and searches unless the content filter allows them.
+
+
+BEGIN;
+
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS adult BOOLEAN NOT NULL DEFAULT FALSE;
+
+
+COMMIT;
@@ -0,0 +1,49 @@
+-- Migration 014: User accounts for password login, with lockout bookkeeping
+-- users/password_login/sessions are the tables POST /api/auth/login and
+-- /register already use; created here if an older database lacks them.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS users (
+   user_id SERIAL PRIMARY KEY,
+   email VARCHAR(255) UNIQUE NOT NULL,
+   username VARCHAR(50) UNIQUE NOT NULL,
+   role VARCHAR(20) NOT NULL DEFAULT 'user',
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   CONSTRAINT check_user_role CHECK (role IN ('user', 'admin'))
+);
+
+CREATE TABLE IF NOT EXISTS password_login (
+   user_id INTEGER PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+   password_hash TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS sessions (
+   user_id INTEGER PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+   token TEXT NOT NULL
+);
+
+
+-- Consecutive failures since the last successful login; reset on success or unlock
+ALTER TABLE users ADD COLUMN IF NOT EXISTS failed_login_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS last_failed_login_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;
+
+
+-- Every login attempt, for per-IP throttling (includes unknown emails)
+CREATE TABLE IF NOT EXISTS login_attempts (
+   attempt_id BIGSERIAL PRIMARY KEY,
+   email VARCHAR(255) NOT NULL,
+   ip_address VARCHAR(64),
+   succeeded BOOLEAN NOT NULL,
+   attempted_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_login_attempts_ip ON login_attempts(ip_address, attempted_at);
+CREATE INDEX IF NOT EXISTS idx_login_attempts_email ON login_attempts(email, attempted_at);
+
+
+COMMIT;
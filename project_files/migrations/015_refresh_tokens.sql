@@ -0,0 +1,31 @@
+-- Migration 015: Rotating refresh tokens
+-- Each login starts a token family; every refresh marks the presented token
+-- used and issues its successor in the same family. Presenting a used or
+-- revoked token revokes the whole family. Replaces the single-token sessions
+-- table.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+   token_id BIGSERIAL PRIMARY KEY,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   token_hash CHAR(64) UNIQUE NOT NULL,
+   family_id UUID NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   expires_at TIMESTAMPTZ NOT NULL,
+   used_at TIMESTAMPTZ,
+   revoked_at TIMESTAMPTZ,
+   replaced_by BIGINT REFERENCES refresh_tokens(token_id) ON DELETE SET NULL
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user ON refresh_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);
+
+
+DROP TABLE IF EXISTS sessions;
+
+
+COMMIT;
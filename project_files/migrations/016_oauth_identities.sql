@@ -0,0 +1,32 @@
+-- Migration 016: External (OAuth2/OIDC) identities linked to local users
+-- A user can have a password, any number of linked identities, or both.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS user_identities (
+   provider VARCHAR(50) NOT NULL,
+   subject VARCHAR(255) NOT NULL,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   email VARCHAR(255),
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   last_login_at TIMESTAMPTZ,
+   PRIMARY KEY (provider, subject)
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_user_identities_user ON user_identities(user_id);
+
+
+-- In-flight authorization requests; each state is single use
+CREATE TABLE IF NOT EXISTS oauth_states (
+   state VARCHAR(64) PRIMARY KEY,
+   provider VARCHAR(50) NOT NULL,
+   code_verifier VARCHAR(128) NOT NULL,
+   nonce VARCHAR(64) NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Migration 017: TOTP two-factor authentication with recovery codes
+-- totp_secret is set when enrollment starts; 2FA is on once totp_enabled_at is set.
+
+
+BEGIN;
+
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret VARCHAR(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled_at TIMESTAMPTZ;
+-- Last accepted 30-second step, so a code can't be replayed
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_step BIGINT;
+
+
+-- Single-use recovery codes (SHA-256 hashes) for a lost authenticator
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+   code_id SERIAL PRIMARY KEY,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   code_hash CHAR(64) NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   used_at TIMESTAMPTZ
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_user_recovery_codes_user ON user_recovery_codes(user_id);
+
+
+COMMIT;
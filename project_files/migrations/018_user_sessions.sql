@@ -0,0 +1,23 @@
+-- Migration 018: Server-side sessions for cookie authentication (AUTH_MODE=session|both)
+-- Only the SHA-256 of each session ID is stored.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS user_sessions (
+   session_hash CHAR(64) PRIMARY KEY,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   expires_at TIMESTAMPTZ NOT NULL,
+   ip_address VARCHAR(64),
+   user_agent TEXT
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_user_sessions_user ON user_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_sessions_expires ON user_sessions(expires_at);
+
+
+COMMIT;
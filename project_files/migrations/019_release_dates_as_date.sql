@@ -0,0 +1,81 @@
+-- Migration 019: Release dates as DATE, with no time of day or time zone
+-- Databases created before release_date was a DATE hold it as a timestamp,
+-- often midnight in whatever zone wrote it, so the UTC date is a day early or
+-- late. Rounding to the nearest UTC day recovers the intended date. Databases
+-- that already use DATE are left as they are (the API now serializes those
+-- without a time zone shift). box_office_monthly depends on the column, so
+-- it is dropped and rebuilt (as in migration 006) in the same transaction.
+
+
+BEGIN;
+
+
+DO $$
+DECLARE
+   column_type TEXT;
+   had_view BOOLEAN;
+BEGIN
+   SELECT data_type INTO column_type
+   FROM information_schema.columns
+   WHERE table_name = 'movies' AND column_name = 'release_date';
+
+   IF column_type IS NULL OR column_type NOT IN ('timestamp without time zone', 'timestamp with time zone') THEN
+      RETURN;
+   END IF;
+
+   -- Depends on release_date; rebuilt below once the column is converted
+   SELECT EXISTS (SELECT 1 FROM pg_matviews WHERE matviewname = 'box_office_monthly') INTO had_view;
+   DROP MATERIALIZED VIEW IF EXISTS box_office_monthly;
+
+   IF column_type = 'timestamp with time zone' THEN
+      ALTER TABLE movies
+         ALTER COLUMN release_date TYPE DATE
+         USING ((release_date AT TIME ZONE 'UTC') + INTERVAL '12 hours')::date;
+   ELSE
+      ALTER TABLE movies
+         ALTER COLUMN release_date TYPE DATE
+         USING (release_date + INTERVAL '12 hours')::date;
+   END IF;
+
+   IF had_view THEN
+      CREATE MATERIALIZED VIEW box_office_monthly AS
+      SELECT
+         DATE_TRUNC('month', m.release_date)::date AS month,
+         0 AS genre_id,
+         NULL::VARCHAR(100) AS genre_name,
+         COUNT(*)::int AS movie_count,
+         COUNT(NULLIF(m.revenue, 0))::int AS revenue_count,
+         COALESCE(SUM(m.revenue), 0)::bigint AS total_revenue,
+         COUNT(NULLIF(m.budget, 0))::int AS budget_count,
+         COALESCE(SUM(m.budget), 0)::bigint AS total_budget
+      FROM movies m
+      WHERE m.release_date IS NOT NULL
+      GROUP BY DATE_TRUNC('month', m.release_date)
+
+      UNION ALL
+
+      SELECT
+         DATE_TRUNC('month', m.release_date)::date AS month,
+         g.genre_id,
+         g.genre_name,
+         COUNT(*)::int AS movie_count,
+         COUNT(NULLIF(m.revenue, 0))::int AS revenue_count,
+         COALESCE(SUM(m.revenue), 0)::bigint AS total_revenue,
+         COUNT(NULLIF(m.budget, 0))::int AS budget_count,
+         COALESCE(SUM(m.budget), 0)::bigint AS total_budget
+      FROM movies m
+      JOIN movie_genres mg ON m.movie_id = mg.movie_id
+      JOIN genres g ON mg.genre_id = g.genre_id
+      WHERE m.release_date IS NOT NULL
+      GROUP BY DATE_TRUNC('month', m.release_date), g.genre_id, g.genre_name
+      WITH DATA;
+
+      -- Required for REFRESH ... CONCURRENTLY
+      CREATE UNIQUE INDEX idx_box_office_monthly_key ON box_office_monthly(month, genre_id);
+   END IF;
+
+   RAISE NOTICE 'movies.release_date converted to DATE';
+END $$;
+
+
+COMMIT;
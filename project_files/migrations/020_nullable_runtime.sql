@@ -0,0 +1,18 @@
+-- Migration 020: Unknown runtimes are NULL instead of 0
+-- Older imports wrote 0 for a missing runtime, which dragged down averages
+-- and sorted those movies as the shortest. Averages skip NULLs.
+
+
+BEGIN;
+
+
+ALTER TABLE movies ALTER COLUMN runtime_minutes DROP NOT NULL;
+
+ALTER TABLE movies DROP CONSTRAINT IF EXISTS check_runtime;
+
+UPDATE movies SET runtime_minutes = NULL WHERE runtime_minutes <= 0;
+
+ALTER TABLE movies ADD CONSTRAINT check_runtime CHECK (runtime_minutes > 0);
+
+
+COMMIT;
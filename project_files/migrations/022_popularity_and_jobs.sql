@@ -0,0 +1,30 @@
+-- Migration 022: Popularity scores, audience votes and background job bookkeeping
+-- popularity is recomputed nightly by the 'popularity' job (see jobs.ts) and is
+-- the default sort for GET /api/movies. vote_count/vote_average are audience
+-- votes from the import source (e.g. TMDB).
+
+
+BEGIN;
+
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS vote_count INTEGER CHECK (vote_count >= 0);
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS vote_average NUMERIC(3, 1) CHECK (vote_average BETWEEN 0 AND 10);
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS popularity NUMERIC(6, 4);
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS popularity_updated_at TIMESTAMPTZ;
+
+
+-- Sortable columns must be indexed (see migration 008)
+CREATE INDEX IF NOT EXISTS idx_movies_popularity ON movies(popularity);
+
+
+-- Last run of each scheduled job, so restarts and other instances don't repeat it
+CREATE TABLE IF NOT EXISTS job_runs (
+   job_name VARCHAR(50) PRIMARY KEY,
+   started_at TIMESTAMPTZ NOT NULL,
+   finished_at TIMESTAMPTZ,
+   succeeded BOOLEAN,
+   summary TEXT
+);
+
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Migration 023: Search ranking weights tuned at runtime through /api/admin/search-ranking
+-- Only settings that have been changed get a row; others use the default in
+-- searchRanking.ts (or a RANKING_* environment variable, which wins).
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS search_ranking_settings (
+   setting_key VARCHAR(100) PRIMARY KEY,
+   value DOUBLE PRECISION NOT NULL CHECK (value >= 0),
+   updated_by INTEGER REFERENCES api_keys(api_key_id) ON DELETE SET NULL,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- Migration 024: User reviews with a moderation queue
+-- New and edited reviews wait in 'pending' until an admin approves them through
+-- /api/admin/reviews (or REVIEWS_AUTO_APPROVE lets clean ones through). Only
+-- approved reviews are shown on movie pages. flagged_terms records what the
+-- content filters matched, for moderators.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS reviews (
+   review_id SERIAL PRIMARY KEY,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 10),
+   body TEXT,
+   status VARCHAR(20) NOT NULL DEFAULT 'pending',
+   flagged_terms TEXT[] NOT NULL DEFAULT '{}',
+   moderation_note TEXT,
+   moderated_by INTEGER REFERENCES api_keys(api_key_id) ON DELETE SET NULL,
+   moderated_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   CONSTRAINT check_review_status CHECK (status IN ('pending', 'approved', 'rejected')),
+   -- One review per user per movie; writing again replaces it
+   CONSTRAINT unique_review_per_user UNIQUE (movie_id, user_id)
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_reviews_movie_status ON reviews(movie_id, status, created_at);
+CREATE INDEX IF NOT EXISTS idx_reviews_status ON reviews(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_reviews_user ON reviews(user_id);
+
+
+COMMIT;
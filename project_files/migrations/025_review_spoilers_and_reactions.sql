@@ -0,0 +1,30 @@
+-- Migration 025: Spoiler flags and helpful/unhelpful reactions on reviews
+-- helpful_count/unhelpful_count are kept in step with review_reactions by the
+-- reaction endpoints, so reviews can be sorted by helpfulness without a join.
+
+
+BEGIN;
+
+
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS contains_spoilers BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS helpful_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS unhelpful_count INTEGER NOT NULL DEFAULT 0;
+
+
+-- One reaction per user per review; reacting again replaces it
+CREATE TABLE IF NOT EXISTS review_reactions (
+   review_id INTEGER NOT NULL REFERENCES reviews(review_id) ON DELETE CASCADE,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   reaction VARCHAR(10) NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   PRIMARY KEY (review_id, user_id),
+   CONSTRAINT check_review_reaction CHECK (reaction IN ('helpful', 'unhelpful'))
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_review_reactions_user ON review_reactions(user_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_movie_helpful
+   ON reviews(movie_id, status, (helpful_count - unhelpful_count) DESC);
+
+
+COMMIT;
@@ -0,0 +1,44 @@
+-- Migration 026: Following other users, watchlists and the activity feed
+-- activities is written by the endpoints that publish something (an approved
+-- review, a watchlist addition) and read by GET /api/me/feed. Rows go away
+-- with what they describe: deleting a review or un-listing a movie removes them.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS user_follows (
+   follower_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   followed_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   PRIMARY KEY (follower_id, followed_id),
+   CONSTRAINT check_not_self_follow CHECK (follower_id <> followed_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_follows_followed ON user_follows(followed_id);
+
+
+CREATE TABLE IF NOT EXISTS watchlist (
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   PRIMARY KEY (user_id, movie_id)
+);
+
+
+CREATE TABLE IF NOT EXISTS activities (
+   activity_id BIGSERIAL PRIMARY KEY,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   activity_type VARCHAR(20) NOT NULL,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   review_id INTEGER REFERENCES reviews(review_id) ON DELETE CASCADE,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   CONSTRAINT check_activity_type CHECK (activity_type IN ('rating', 'review', 'watchlist_add'))
+);
+
+-- The feed reads one user's newest activities at a time
+CREATE INDEX IF NOT EXISTS idx_activities_user ON activities(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_activities_review ON activities(review_id) WHERE review_id IS NOT NULL;
+
+
+COMMIT;
@@ -0,0 +1,37 @@
+-- Migration 027: Named, ordered movie lists made by users
+-- slug is fixed when the list is created, so shared links survive renames.
+-- Visibility: private (owner only), unlisted (anyone with the link) or public.
+-- position orders a list (1 first); its uniqueness is checked at commit so a
+-- reorder can move every row in one statement.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS movie_lists (
+   list_id SERIAL PRIMARY KEY,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   name VARCHAR(100) NOT NULL,
+   description TEXT,
+   slug VARCHAR(120) NOT NULL UNIQUE,
+   visibility VARCHAR(10) NOT NULL DEFAULT 'private',
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   CONSTRAINT check_list_visibility CHECK (visibility IN ('private', 'unlisted', 'public'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_lists_user ON movie_lists(user_id);
+
+
+CREATE TABLE IF NOT EXISTS movie_list_items (
+   list_id INTEGER NOT NULL REFERENCES movie_lists(list_id) ON DELETE CASCADE,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   position INTEGER NOT NULL CHECK (position > 0),
+   note VARCHAR(500),
+   added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   PRIMARY KEY (list_id, movie_id),
+   CONSTRAINT unique_list_position UNIQUE (list_id, position) DEFERRABLE INITIALLY DEFERRED
+);
+
+
+COMMIT;
@@ -0,0 +1,35 @@
+-- Migration 028: Favorite genres and the new-releases email digest
+-- movies.added_at records when a movie entered the catalog. Movies already
+-- here keep NULL, so the first digests only cover movies added from now on.
+-- digest_subscriptions.last_sent_at marks how far each user's digest has got;
+-- the digest job sends what was added since then and moves it forward.
+
+
+BEGIN;
+
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS added_at TIMESTAMPTZ;
+ALTER TABLE movies ALTER COLUMN added_at SET DEFAULT NOW();
+
+CREATE INDEX IF NOT EXISTS idx_movies_added_at ON movies(added_at);
+
+
+CREATE TABLE IF NOT EXISTS user_favorite_genres (
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   genre_id INTEGER NOT NULL REFERENCES genres(genre_id) ON DELETE CASCADE,
+   PRIMARY KEY (user_id, genre_id)
+);
+
+
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+   user_id INTEGER PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+   frequency VARCHAR(10) NOT NULL DEFAULT 'weekly',
+   -- Goes in every digest's unsubscribe link; works without signing in
+   unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+   subscribed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   last_sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   CONSTRAINT check_digest_frequency CHECK (frequency IN ('daily', 'weekly'))
+);
+
+
+COMMIT;
@@ -0,0 +1,27 @@
+-- Migration 029: Movie trailers and push notification device tokens
+-- device_tokens holds the FCM registration tokens and APNs device tokens the
+-- mobile app registers for a signed-in user. Users with a movie on their
+-- watchlist are notified when it is edited or gets a trailer; tokens the push
+-- service reports as no longer valid are deleted when that happens.
+
+
+BEGIN;
+
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS trailer_url VARCHAR(500);
+
+
+CREATE TABLE IF NOT EXISTS device_tokens (
+   device_id SERIAL PRIMARY KEY,
+   user_id INTEGER NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+   platform VARCHAR(10) NOT NULL,
+   token VARCHAR(4096) NOT NULL UNIQUE,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   last_registered_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   CONSTRAINT check_device_platform CHECK (platform IN ('fcm', 'apns'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_device_tokens_user ON device_tokens(user_id);
+
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Migration 030: Personal calendar feed tokens
+-- Calendar apps can't send an API key or sign in, so a user's upcoming
+-- releases feed (limited to their watchlist) is addressed by a secret token
+-- in the URL. Creating a new token replaces the old one.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+   user_id INTEGER PRIMARY KEY REFERENCES users(user_id) ON DELETE CASCADE,
+   token VARCHAR(64) NOT NULL UNIQUE,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Migration 031: Short links to movies
+-- One short code per movie (/api/m/:code), created the first time a movie's
+-- link or QR code is asked for, so printed posters can point at a URL short
+-- enough for a small QR code. visits counts redirects followed.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS short_links (
+   code VARCHAR(16) PRIMARY KEY,
+   movie_id INTEGER NOT NULL UNIQUE REFERENCES movies(movie_id) ON DELETE CASCADE,
+   visits INTEGER NOT NULL DEFAULT 0,
+   last_visited_at TIMESTAMPTZ,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Migration 032: Placeholder posters
+-- Generated SVG stand-ins (title and year on a tinted card) for movies
+-- without a poster_url. title and release_year record what was rendered so
+-- a placeholder is redrawn when either changes. Existing movies get theirs
+-- from npm run generate-placeholders.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS placeholder_posters (
+   movie_id INTEGER PRIMARY KEY REFERENCES movies(movie_id) ON DELETE CASCADE,
+   title TEXT NOT NULL,
+   release_year INTEGER,
+   svg TEXT NOT NULL,
+   generated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+COMMIT;
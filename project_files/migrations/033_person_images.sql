@@ -0,0 +1,40 @@
+-- Migration 033: Actor profile images
+-- Every profile URL seen for an actor, so imports that bring a different
+-- photo no longer drop it. The person-images job downloads each one, stores
+-- its perceptual hash, groups near-identical photos (duplicate_of points at
+-- the best copy) and marks one canonical image, which becomes
+-- actors.profile_url. Existing profile URLs start out canonical.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS person_images (
+   image_id SERIAL PRIMARY KEY,
+   actor_id INTEGER NOT NULL REFERENCES actors(actor_id) ON DELETE CASCADE,
+   url VARCHAR(500) NOT NULL,
+   content_type VARCHAR(50),
+   width INTEGER,
+   height INTEGER,
+   phash CHAR(16),
+   fetch_error VARCHAR(200),
+   hashed_at TIMESTAMPTZ,
+   is_canonical BOOLEAN NOT NULL DEFAULT FALSE,
+   duplicate_of INTEGER REFERENCES person_images(image_id) ON DELETE SET NULL,
+   added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   UNIQUE (actor_id, url)
+);
+
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_person_images_canonical ON person_images(actor_id) WHERE is_canonical;
+CREATE INDEX IF NOT EXISTS idx_person_images_unhashed ON person_images(image_id) WHERE hashed_at IS NULL;
+
+
+INSERT INTO person_images (actor_id, url, is_canonical)
+SELECT actor_id, profile_url, TRUE
+FROM actors
+WHERE profile_url IS NOT NULL
+ON CONFLICT (actor_id, url) DO NOTHING;
+
+
+COMMIT;
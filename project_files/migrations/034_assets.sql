@@ -0,0 +1,32 @@
+-- Migration 034: Content-addressable asset store
+-- Mirrored images, stored once per SHA-256 of their bytes and served from
+-- /api/assets/:hash. asset_sources maps each mirrored URL to its content, so
+-- many URLs (the same studio logo hosted in different places) share one row.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS assets (
+   hash CHAR(64) PRIMARY KEY,
+   content_type VARCHAR(50) NOT NULL,
+   byte_size INTEGER NOT NULL,
+   width INTEGER,
+   height INTEGER,
+   data BYTEA NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+CREATE TABLE IF NOT EXISTS asset_sources (
+   source_url VARCHAR(500) PRIMARY KEY,
+   hash CHAR(64) REFERENCES assets(hash) ON DELETE CASCADE,
+   fetch_error VARCHAR(200),
+   fetched_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_asset_sources_hash ON asset_sources(hash);
+
+
+COMMIT;
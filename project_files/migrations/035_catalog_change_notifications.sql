@@ -0,0 +1,59 @@
+-- Migration 035: Catalog change notifications
+-- Every insert, edit and delete of a movie NOTIFYs catalog_changes with its
+-- ID and a summary, whichever path made the change (API, bulk edits, sync).
+-- The API relays them to /ws clients. Updates that only touch derived
+-- columns (popularity, inflation adjustment, row version) are skipped so the
+-- nightly jobs don't flood listeners.
+
+
+BEGIN;
+
+
+CREATE OR REPLACE FUNCTION notify_catalog_change() RETURNS TRIGGER AS $$
+DECLARE
+   movie movies;
+   changed TEXT[];
+BEGIN
+   IF TG_OP = 'UPDATE' THEN
+      SELECT ARRAY_AGG(n.key ORDER BY n.key) INTO changed
+      FROM JSONB_EACH(TO_JSONB(NEW)) n
+      WHERE n.value IS DISTINCT FROM TO_JSONB(OLD) -> n.key
+        AND n.key <> ALL (ARRAY[
+           'updated_at', 'version', 'popularity', 'popularity_updated_at',
+           'budget_adjusted', 'revenue_adjusted', 'era'
+        ]);
+
+      IF changed IS NULL THEN
+         RETURN NULL;
+      END IF;
+   END IF;
+
+   IF TG_OP = 'DELETE' THEN
+      movie := OLD;
+   ELSE
+      movie := NEW;
+   END IF;
+
+   PERFORM pg_notify('catalog_changes', JSON_BUILD_OBJECT(
+      'type', 'movie.' || CASE TG_OP WHEN 'INSERT' THEN 'created' WHEN 'UPDATE' THEN 'updated' ELSE 'deleted' END,
+      'movie_id', movie.movie_id,
+      'public_id', movie.public_id,
+      'title', movie.title,
+      'release_date', movie.release_date,
+      'changed', changed,
+      'at', NOW()
+   )::text);
+
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+
+DROP TRIGGER IF EXISTS movies_notify_catalog_change ON movies;
+
+CREATE TRIGGER movies_notify_catalog_change
+   AFTER INSERT OR UPDATE OR DELETE ON movies
+   FOR EACH ROW EXECUTE FUNCTION notify_catalog_change();
+
+
+COMMIT;
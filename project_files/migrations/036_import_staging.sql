@@ -0,0 +1,38 @@
+-- Migration 036: Import staging
+-- With IMPORT_STAGING=true (or ?stage=true), POST /api/movies and
+-- /api/movies/bulk store the validated movies in a pending batch instead of
+-- the catalog. An admin reviews the batch's diff summary and approves it,
+-- which inserts every movie in one transaction, or rejects it.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS import_batches (
+   batch_id SERIAL PRIMARY KEY,
+   status VARCHAR(20) NOT NULL DEFAULT 'pending',
+   movie_count INTEGER NOT NULL,
+   submitted_by INTEGER REFERENCES api_keys(api_key_id) ON DELETE SET NULL,
+   submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   reviewed_by INTEGER REFERENCES api_keys(api_key_id) ON DELETE SET NULL,
+   reviewed_at TIMESTAMPTZ,
+   review_note TEXT,
+   CONSTRAINT check_import_batch_status CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+
+-- One row per submitted movie; data is the POST /api/movies body.
+-- movie_id is filled in when the batch is approved.
+CREATE TABLE IF NOT EXISTS import_batch_movies (
+   batch_id INTEGER NOT NULL REFERENCES import_batches(batch_id) ON DELETE CASCADE,
+   position INTEGER NOT NULL,
+   data JSONB NOT NULL,
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE SET NULL,
+   PRIMARY KEY (batch_id, position)
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_import_batches_status ON import_batches(status, submitted_at);
+
+
+COMMIT;
@@ -0,0 +1,38 @@
+-- Migration 037: Import lineage
+-- Every import (POST /api/movies, /api/movies/bulk, an approved import batch,
+-- npm run sync) is an import run. Each movie a run creates or changes gets a
+-- movie_lineage row with the columns it changed, old and new values, shown by
+-- GET /api/admin/movies/:id/lineage.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS import_runs (
+   run_id SERIAL PRIMARY KEY,
+   kind VARCHAR(20) NOT NULL,
+   -- Remote base URL for sync runs
+   source VARCHAR(500),
+   batch_id INTEGER REFERENCES import_batches(batch_id) ON DELETE SET NULL,
+   api_key_id INTEGER REFERENCES api_keys(api_key_id) ON DELETE SET NULL,
+   started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   CONSTRAINT check_import_run_kind CHECK (kind IN ('api', 'bulk', 'batch', 'sync'))
+);
+
+
+-- changes: {"column": {"from": old, "to": new}, ...}; from is null for created movies
+CREATE TABLE IF NOT EXISTS movie_lineage (
+   run_id INTEGER NOT NULL REFERENCES import_runs(run_id) ON DELETE CASCADE,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   action VARCHAR(10) NOT NULL,
+   changes JSONB NOT NULL,
+   recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   PRIMARY KEY (run_id, movie_id),
+   CONSTRAINT check_movie_lineage_action CHECK (action IN ('created', 'updated'))
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_movie_lineage_movie ON movie_lineage(movie_id, recorded_at);
+
+
+COMMIT;
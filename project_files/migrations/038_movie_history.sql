@@ -0,0 +1,55 @@
+-- Migration 038: Movie history
+-- Before a movie row changes, the trigger copies the old row into
+-- movie_history with the period it was current (valid_from is the old
+-- updated_at). GET /api/movies/:id/history lists prior versions and
+-- POST /api/admin/movies/:id/undo restores one. As in migration 035, updates
+-- that only touch derived columns are not versioned. Related rows (genres,
+-- cast, translations) are not part of the history.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS movie_history (
+   history_id BIGSERIAL PRIMARY KEY,
+   movie_id INTEGER NOT NULL REFERENCES movies(movie_id) ON DELETE CASCADE,
+   version INTEGER NOT NULL,
+   data JSONB NOT NULL,
+   valid_from TIMESTAMPTZ NOT NULL,
+   valid_to TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_movie_history_movie ON movie_history(movie_id, valid_to DESC);
+
+
+CREATE OR REPLACE FUNCTION record_movie_history() RETURNS TRIGGER AS $$
+BEGIN
+   IF NOT EXISTS (
+      SELECT 1
+      FROM JSONB_EACH(TO_JSONB(NEW)) n
+      WHERE n.value IS DISTINCT FROM TO_JSONB(OLD) -> n.key
+        AND n.key <> ALL (ARRAY[
+           'updated_at', 'version', 'popularity', 'popularity_updated_at',
+           'budget_adjusted', 'revenue_adjusted', 'era'
+        ])
+   ) THEN
+      RETURN NULL;
+   END IF;
+
+   INSERT INTO movie_history (movie_id, version, data, valid_from)
+   VALUES (OLD.movie_id, OLD.version, TO_JSONB(OLD), OLD.updated_at);
+
+   RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+
+DROP TRIGGER IF EXISTS movies_record_history ON movies;
+
+CREATE TRIGGER movies_record_history
+   AFTER UPDATE ON movies
+   FOR EACH ROW EXECUTE FUNCTION record_movie_history();
+
+
+COMMIT;
@@ -0,0 +1,15 @@
+-- Migration 039: Enrichment runs
+-- npm run enrich-metadata records its changes as import runs of kind
+-- 'enrich', so GET /api/admin/movies/:id/lineage shows which refresh
+-- overwrote a field.
+
+
+BEGIN;
+
+
+ALTER TABLE import_runs DROP CONSTRAINT IF EXISTS check_import_run_kind;
+ALTER TABLE import_runs ADD CONSTRAINT check_import_run_kind
+   CHECK (kind IN ('api', 'bulk', 'batch', 'sync', 'enrich'));
+
+
+COMMIT;
@@ -0,0 +1,28 @@
+-- Migration 040: Overview embeddings for semantic search
+-- Needs the pgvector extension (the pgvector/pgvector Docker images, or
+-- apt install postgresql-16-pgvector). One vector per movie overview; the
+-- model is kept because vectors from different models can't be compared.
+-- The column has no fixed dimension so the model can change; searches scan
+-- the current model's vectors, which is fast enough for this catalog's size.
+
+
+BEGIN;
+
+
+CREATE EXTENSION IF NOT EXISTS vector;
+
+
+CREATE TABLE IF NOT EXISTS movie_embeddings (
+   movie_id INTEGER PRIMARY KEY REFERENCES movies(movie_id) ON DELETE CASCADE,
+   model VARCHAR(100) NOT NULL,
+   -- MD5 of the overview that was embedded, to find stale vectors
+   overview_md5 CHAR(32) NOT NULL,
+   embedding vector NOT NULL,
+   updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+
+CREATE INDEX IF NOT EXISTS idx_movie_embeddings_model ON movie_embeddings(model);
+
+
+COMMIT;
@@ -0,0 +1,32 @@
+-- Migration 041: Published dataset snapshots
+-- One row per file of a nightly catalog snapshot (movies-<date>.jsonl.gz and
+-- movies-<date>.csv.gz), with its checksum, listed by GET /api/datasets.
+-- The bytes live in the configured store: dataset_files for DATASET_STORE=
+-- database (the default), or a bucket for DATASET_STORE=s3.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS dataset_snapshots (
+   snapshot_id SERIAL PRIMARY KEY,
+   version DATE NOT NULL,
+   format VARCHAR(10) NOT NULL CHECK (format IN ('jsonl', 'csv')),
+   file_name VARCHAR(100) NOT NULL UNIQUE,
+   store VARCHAR(20) NOT NULL,
+   storage_key VARCHAR(200) NOT NULL,
+   byte_size BIGINT NOT NULL,
+   sha256 CHAR(64) NOT NULL,
+   movie_count INTEGER NOT NULL,
+   created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+   UNIQUE (version, format)
+);
+
+
+CREATE TABLE IF NOT EXISTS dataset_files (
+   storage_key VARCHAR(200) PRIMARY KEY,
+   data BYTEA NOT NULL
+);
+
+
+COMMIT;
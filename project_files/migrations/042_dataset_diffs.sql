@@ -0,0 +1,25 @@
+-- Migration 042: Differential dataset snapshots
+-- Alongside each full snapshot after the first, a diff file lists the movies
+-- added, changed and removed since the previous snapshot (base_version), by
+-- public_id. dataset_record_hashes holds each record's SHA-256 as of the last
+-- published snapshot, which is what the next diff is computed against.
+
+
+BEGIN;
+
+
+ALTER TABLE dataset_snapshots DROP CONSTRAINT IF EXISTS dataset_snapshots_format_check;
+ALTER TABLE dataset_snapshots
+   ADD CONSTRAINT dataset_snapshots_format_check CHECK (format IN ('jsonl', 'csv', 'diff'));
+
+ALTER TABLE dataset_snapshots ADD COLUMN IF NOT EXISTS base_version DATE;
+ALTER TABLE dataset_snapshots ADD COLUMN IF NOT EXISTS change_count INTEGER;
+
+
+CREATE TABLE IF NOT EXISTS dataset_record_hashes (
+   public_id VARCHAR(12) PRIMARY KEY,
+   record_sha256 CHAR(64) NOT NULL
+);
+
+
+COMMIT;
@@ -0,0 +1,19 @@
+-- Migration 043: Syncing from published dataset snapshots
+-- npm run sync -- --snapshots <location> follows another instance through
+-- its snapshot files instead of its API. sync_sources remembers which
+-- snapshot version was applied last, and movie_sync_map the remote public_id
+-- that diffs use to name deleted movies.
+
+
+BEGIN;
+
+
+ALTER TABLE sync_sources ADD COLUMN IF NOT EXISTS snapshot_version DATE;
+
+ALTER TABLE movie_sync_map ADD COLUMN IF NOT EXISTS source_public_id VARCHAR(12);
+
+
+CREATE INDEX IF NOT EXISTS idx_movie_sync_map_public_id ON movie_sync_map(source_id, source_public_id);
+
+
+COMMIT;
@@ -0,0 +1,20 @@
+-- Migration 044: IMDb dataset imports
+-- npm run import-imdb seeds the catalog from the official IMDb TSV dumps.
+-- movies.imdb_id (the tconst, e.g. tt0111161) lets a re-run skip titles it
+-- already imported, and the runs are recorded as imports of kind 'imdb'.
+
+
+BEGIN;
+
+
+ALTER TABLE movies ADD COLUMN IF NOT EXISTS imdb_id VARCHAR(12);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_movies_imdb_id ON movies(imdb_id) WHERE imdb_id IS NOT NULL;
+
+
+ALTER TABLE import_runs DROP CONSTRAINT IF EXISTS check_import_run_kind;
+ALTER TABLE import_runs ADD CONSTRAINT check_import_run_kind
+   CHECK (kind IN ('api', 'bulk', 'batch', 'sync', 'enrich', 'imdb'));
+
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration 045: Fixture seeding
+-- npm run seed -- --fixture loads the fixture catalog (src/fixtures/movies.json)
+-- into a database; its runs are recorded as imports of kind 'fixture'.
+
+
+BEGIN;
+
+
+ALTER TABLE import_runs DROP CONSTRAINT IF EXISTS check_import_run_kind;
+ALTER TABLE import_runs ADD CONSTRAINT check_import_run_kind
+   CHECK (kind IN ('api', 'bulk', 'batch', 'sync', 'enrich', 'imdb', 'fixture'));
+
+
+COMMIT;
@@ -0,0 +1,14 @@
+-- Migration 046: Full cast lists
+-- Movies kept only their first 10 cast entries and the rest were dropped
+-- without an error. actor_order is now any position from 1, so a movie can
+-- have its whole cast; responses already page through it with cast_limit.
+
+
+BEGIN;
+
+
+ALTER TABLE movie_actors DROP CONSTRAINT IF EXISTS check_actor_order;
+ALTER TABLE movie_actors ADD CONSTRAINT check_actor_order CHECK (actor_order >= 1);
+
+
+COMMIT;
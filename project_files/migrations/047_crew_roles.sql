@@ -0,0 +1,55 @@
+-- Migration 047: Writers, composers, cinematographers and editors
+-- Crew beyond directors and producers, stored the same way: a table of
+-- people per role and a junction table linking them to movies.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS writers (
+   writer_id SERIAL PRIMARY KEY,
+   writer_name VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS composers (
+   composer_id SERIAL PRIMARY KEY,
+   composer_name VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS cinematographers (
+   cinematographer_id SERIAL PRIMARY KEY,
+   cinematographer_name VARCHAR(255) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS editors (
+   editor_id SERIAL PRIMARY KEY,
+   editor_name VARCHAR(255) UNIQUE NOT NULL
+);
+
+
+CREATE TABLE IF NOT EXISTS movie_writers (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   writer_id INTEGER REFERENCES writers(writer_id) ON DELETE CASCADE,
+   PRIMARY KEY (movie_id, writer_id)
+);
+
+CREATE TABLE IF NOT EXISTS movie_composers (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   composer_id INTEGER REFERENCES composers(composer_id) ON DELETE CASCADE,
+   PRIMARY KEY (movie_id, composer_id)
+);
+
+CREATE TABLE IF NOT EXISTS movie_cinematographers (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   cinematographer_id INTEGER REFERENCES cinematographers(cinematographer_id) ON DELETE CASCADE,
+   PRIMARY KEY (movie_id, cinematographer_id)
+);
+
+CREATE TABLE IF NOT EXISTS movie_editors (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   editor_id INTEGER REFERENCES editors(editor_id) ON DELETE CASCADE,
+   PRIMARY KEY (movie_id, editor_id)
+);
+
+
+COMMIT;
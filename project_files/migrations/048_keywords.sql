@@ -0,0 +1,24 @@
+-- Migration 048: Keywords
+-- Free-form tags on movies ("time travel", "heist"), stored lower-case so
+-- spellings that differ only in case are one keyword. GET /api/keywords lists
+-- them and GET /api/movies?keyword= browses by them.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS keywords (
+   keyword_id SERIAL PRIMARY KEY,
+   keyword_name VARCHAR(100) UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_keywords (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   keyword_id INTEGER REFERENCES keywords(keyword_id) ON DELETE CASCADE,
+   PRIMARY KEY (movie_id, keyword_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_keywords_keyword ON movie_keywords(keyword_id);
+
+
+COMMIT;
@@ -0,0 +1,39 @@
+-- Migration 049: Spoken languages and production countries
+-- Lookup tables keyed by ISO code (ISO 639-1 for languages, ISO 3166-1
+-- alpha-2 for countries) where the input can be resolved to one, or by the
+-- cleaned-up name otherwise, with a display name; and a join table for each.
+
+
+BEGIN;
+
+
+CREATE TABLE IF NOT EXISTS languages (
+   language_id SERIAL PRIMARY KEY,
+   language_code VARCHAR(100) UNIQUE NOT NULL,
+   language_name VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_languages (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   language_id INTEGER REFERENCES languages(language_id) ON DELETE CASCADE,
+   PRIMARY KEY (movie_id, language_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_languages_language ON movie_languages(language_id);
+
+CREATE TABLE IF NOT EXISTS countries (
+   country_id SERIAL PRIMARY KEY,
+   country_code VARCHAR(100) UNIQUE NOT NULL,
+   country_name VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS movie_countries (
+   movie_id INTEGER REFERENCES movies(movie_id) ON DELETE CASCADE,
+   country_id INTEGER REFERENCES countries(country_id) ON DELETE CASCADE,
+   PRIMARY KEY (movie_id, country_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_movie_countries_country ON movie_countries(country_id);
+
+
+COMMIT;
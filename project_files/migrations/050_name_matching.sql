@@ -0,0 +1,53 @@
+-- Migration 050: Case- and accent-insensitive name matching
+-- Person and studio lookups used to compare names exactly, so "WARNER BROS."
+-- and "Warner Bros." became two studios. name_key() gives the form names are
+-- compared in: lower-case, accents removed, whitespace trimmed and collapsed.
+-- Where the unaccent extension can't be installed it only folds case and
+-- whitespace. To change how names match, replace name_key() in a later
+-- migration and REINDEX the idx_*_name_key indexes; nameKey() in
+-- src/core/utils/nameMatching.ts must fold names the same way.
+--
+-- Existing names that now match each other are not merged; find them with
+--   SELECT name_key(studio_name), ARRAY_AGG(studio_name) FROM studios
+--   GROUP BY 1 HAVING COUNT(*) > 1;
+-- and merge people with POST /api/admin/people/:id/merge-into/:targetId.
+
+
+BEGIN;
+
+
+DO $$
+BEGIN
+   BEGIN
+      CREATE EXTENSION IF NOT EXISTS unaccent;
+   EXCEPTION WHEN OTHERS THEN
+      RAISE NOTICE 'unaccent is not available (%); names will match ignoring case only', SQLERRM;
+   END;
+
+   IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'unaccent') THEN
+      CREATE OR REPLACE FUNCTION name_key(name TEXT) RETURNS TEXT
+      LANGUAGE sql IMMUTABLE PARALLEL SAFE
+      AS $fn$
+         SELECT LOWER(public.unaccent('public.unaccent'::regdictionary, REGEXP_REPLACE(BTRIM(name), '\s+', ' ', 'g')))
+      $fn$;
+   ELSE
+      CREATE OR REPLACE FUNCTION name_key(name TEXT) RETURNS TEXT
+      LANGUAGE sql IMMUTABLE PARALLEL SAFE
+      AS $fn$
+         SELECT LOWER(REGEXP_REPLACE(BTRIM(name), '\s+', ' ', 'g'))
+      $fn$;
+   END IF;
+END $$;
+
+
+CREATE INDEX IF NOT EXISTS idx_studios_name_key ON studios(name_key(studio_name));
+CREATE INDEX IF NOT EXISTS idx_directors_name_key ON directors(name_key(director_name));
+CREATE INDEX IF NOT EXISTS idx_producers_name_key ON producers(name_key(producer_name));
+CREATE INDEX IF NOT EXISTS idx_actors_name_key ON actors(name_key(actor_name));
+CREATE INDEX IF NOT EXISTS idx_writers_name_key ON writers(name_key(writer_name));
+CREATE INDEX IF NOT EXISTS idx_composers_name_key ON composers(name_key(composer_name));
+CREATE INDEX IF NOT EXISTS idx_cinematographers_name_key ON cinematographers(name_key(cinematographer_name));
+CREATE INDEX IF NOT EXISTS idx_editors_name_key ON editors(name_key(editor_name));
+
+
+COMMIT;
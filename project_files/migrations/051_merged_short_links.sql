@@ -0,0 +1,18 @@
+-- Migration 051: Short links of merged movies
+-- Merging a duplicate movie moves its short link to the movie kept, so QR
+-- codes already printed for the duplicate keep working. A movie can then have
+-- several codes: the one made for it (returned by /api/movies/:id/short-link)
+-- and any it took over, which are marked merged.
+
+
+BEGIN;
+
+
+ALTER TABLE short_links ADD COLUMN IF NOT EXISTS merged BOOLEAN NOT NULL DEFAULT false;
+
+ALTER TABLE short_links DROP CONSTRAINT IF EXISTS short_links_movie_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_short_links_movie ON short_links(movie_id) WHERE NOT merged;
+
+
+COMMIT;
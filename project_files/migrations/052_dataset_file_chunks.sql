@@ -0,0 +1,18 @@
+-- Migration 052: Dataset files in chunks
+-- Snapshot files of a large catalog run to hundreds of megabytes, more than
+-- one BYTEA value should hold or one query should move. DATASET_STORE=database
+-- now writes each file as numbered chunks; files written before this
+-- migration become their own chunk 0.
+
+
+BEGIN;
+
+
+ALTER TABLE dataset_files ADD COLUMN IF NOT EXISTS chunk_index INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE dataset_files DROP CONSTRAINT IF EXISTS dataset_files_pkey;
+
+ALTER TABLE dataset_files ADD PRIMARY KEY (storage_key, chunk_index);
+
+
+COMMIT;
@@ -1,31 +1,79 @@
 import 'module-alias/register';
+import './core/utils/loadEnv';
 import express, { Application } from 'express';
 import cors from 'cors';
-import dotenvx from '@dotenvx/dotenvx';
 import swaggerUi from 'swagger-ui-express';
 import YAML from 'yamljs';
 import path from 'path'; import { initializeDatabase, closeDatabase } from '@db';
 import publicRouter, { protectedRouter } from './routes';
-
-dotenvx.config();
+import { enforceReadOnlyMode, isReadOnlyMode } from '@middleware/readOnly';
+import { invalidateResponseCacheOnWrite } from '@middleware/responseCache';
+import { trackRequestMetrics } from '@middleware/requestMetrics';
+import { traceRequests } from '@middleware/tracing';
+import { csrfProtection } from '@middleware/csrf';
+import { securityHeaders } from '@middleware/securityHeaders';
+import { localizeErrors } from '@middleware/localizeErrors';
+import { injectFaults } from '@middleware/faultInjection';
+import { hideIntegerMovieIds } from '@middleware/resolveMovieId';
+import { sessionsEnabled } from '@utils/sessions';
+import { flushSpans } from '@utils/tracing';
+import { startJobs, stopJobs } from '@utils/jobs';
+import { startCacheBus } from '@utils/cacheBus';
+import { closeLiveClients, startLiveUpdates } from '@utils/liveUpdates';
+import { startNotifications, stopNotifications } from '@utils/notifications';
+import { rejectUpgrade } from '@utils/webSocket';
+import { handleLiveUpdatesUpgrade } from './controllers/liveUpdateControllers';
 
 // Initialize the database pool
 const startServer = async () => {
   try {
     await initializeDatabase();
+    // LISTEN/NOTIFY: cache busting from other replicas, catalog changes for /ws
+    startCacheBus();
+    startLiveUpdates();
+    await startNotifications();
 
     const app: Application = express();
-    app.use(cors());
+    app.disable('x-powered-by');
+    // Behind a load balancer req.ip (login throttling, API key usage) has to come from
+    // X-Forwarded-For, but only when set by a proxy we trust: a hop count, addresses or
+    // subnets (e.g. "loopback, 10.0.0.0/8"), or "true" for any. Unset trusts none.
+    const trustProxy = process.env.TRUST_PROXY?.trim();
+    if (trustProxy && trustProxy !== 'false') {
+      app.set('trust proxy', /^\d+$/.test(trustProxy) ? Number(trustProxy) : trustProxy === 'true' || trustProxy);
+    }
+    app.use(securityHeaders);
+    app.use(localizeErrors);
+    app.use(traceRequests);
+    app.use(trackRequestMetrics);
+    // Cookie sessions from another origin need credentialed CORS, limited to CORS_ORIGINS
+    const corsOrigins = process.env.CORS_ORIGINS?.split(',').map(origin => origin.trim()).filter(Boolean);
+    const credentialedCors = sessionsEnabled() && !!corsOrigins?.length;
+    app.use(cors({
+      exposedHeaders: ['ETag', 'X-Cache', 'Retry-After', 'X-Fault-Injected'],
+      ...(credentialedCors ? { origin: corsOrigins, credentials: true } : {})
+    }));
     app.use(express.json({ limit: '10mb' }));
     app.use(express.urlencoded({ extended: true }));
+    app.use(csrfProtection);
     app.use((req, res, next) => {
       console.log(`${req.method} ${req.url}`);
       next();
     });
 
+    // Public demo deployments set READ_ONLY=true: no writes, no API key needed
+    app.use(enforceReadOnlyMode);
+    app.use(invalidateResponseCacheOnWrite);
+    // MOVIE_ID_STRATEGY=public: responses name movies by public_id only
+    app.use(hideIntegerMovieIds);
+    // FAULT_INJECTION=on: added latency and errors for front-end testing
+    app.use('/api', injectFaults);
+
     // Routes
     app.use('/api', publicRouter);
     app.use('/api', protectedRouter);
+    // Read back by GET /api/postman-collection
+    app.locals.apiRouters = [publicRouter, protectedRouter];
     // app.use(express.static(path.join(__dirname, '../public')));
 
     // API Documentation - Swagger UI
@@ -34,15 +82,33 @@ const startServer = async () => {
 
     const PORT = process.env.SERVER_PORT || 4000;
     const server = app.listen(PORT, () => {
-      console.log(`Server running on port ${PORT}`);
+      console.log(`Server running on port ${PORT}${isReadOnlyMode() ? ' (read-only mode)' : ''}`);
     });
 
+    // WebSockets bypass Express; /ws is the only endpoint
+    server.on('upgrade', (req, socket) => {
+      if (new URL(req.url ?? '/', 'http://localhost').pathname !== '/ws') {
+        rejectUpgrade(socket, 404, 'Not Found');
+        return;
+      }
+      handleLiveUpdatesUpgrade(req, socket).catch(() => socket.destroy());
+    });
+
+    // Nightly jobs write to the database, so read-only deployments don't run them
+    if (!isReadOnlyMode()) {
+      startJobs();
+    }
+
     /**
      * Gracefully handles shutdown
      */
     const shutdown = async () => {
       console.log('Shutting down server...');
+      stopJobs();
+      closeLiveClients();
+      stopNotifications();
       server.close(async () => {
+        await flushSpans();
         await closeDatabase();
         console.log('Server and database connections closed.');
         process.exit(0);
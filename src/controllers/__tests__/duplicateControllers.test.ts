@@ -0,0 +1,76 @@
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import { mergeMovieInto } from '../duplicateControllers';
+
+jest.mock('@utils/database', () => ({
+  __esModule: true,
+  default: { connect: jest.fn() }
+}));
+jest.mock('@utils/auditLog', () => ({ recordAudit: jest.fn() }));
+jest.mock('@utils/inflation', () => ({ enrichMovies: jest.fn() }));
+jest.mock('@utils/placeholderPosters', () => ({ generatePlaceholderPosters: jest.fn() }));
+
+/**
+ * Merges movie 2 (a duplicate with reviews) into movie 1 against a client that
+ * finds both movies, and returns the statements it ran
+ */
+const mergeReviewedDuplicate = async () => {
+  const client = {
+    query: jest.fn(async (sql: string, _params?: unknown[]) => sql.includes('FOR UPDATE')
+      ? {
+        rows: [
+          { movie_id: 1, title: 'Alien', release_date: '1979-05-25' },
+          { movie_id: 2, title: 'Alien.', release_date: '1979-05-25' }
+        ]
+      }
+      : { rows: [], rowCount: 0 }),
+    release: jest.fn()
+  };
+  (pool.connect as jest.Mock).mockResolvedValue(client);
+
+  const req = { params: { id: '2', targetId: '1' }, apiKey: { api_key_id: 5 } } as unknown as ApiKeyRequest;
+  const res = { status: jest.fn().mockReturnThis(), json: jest.fn().mockReturnThis() };
+  await mergeMovieInto(req, res as unknown as Response);
+
+  const statements = client.query.mock.calls.map(([sql, params]) => ({
+    sql: sql.replace(/\s+/g, ' ').trim(),
+    params
+  }));
+  return { res, statements };
+};
+
+describe('mergeMovieInto', () => {
+  it('moves the duplicate\'s reviews to the kept movie before deleting it', async () => {
+    const { res, statements } = await mergeReviewedDuplicate();
+    expect(res.status).toHaveBeenCalledWith(200);
+
+    const sql = statements.map(statement => statement.sql);
+    const moved = sql.indexOf('UPDATE reviews SET movie_id = $2 WHERE movie_id = $1');
+    const deleted = sql.indexOf('DELETE FROM movies WHERE movie_id = $1');
+    expect(moved).toBeGreaterThan(0);
+    expect(moved).toBeLessThan(deleted);
+    expect(statements[moved].params).toEqual([2, 1]);
+    expect(statements[deleted].params).toEqual([2]);
+  });
+
+  it('keeps only the newer review of a user who reviewed both movies', async () => {
+    const { statements } = await mergeReviewedDuplicate();
+
+    const sql = statements.map(statement => statement.sql);
+    const conflicts = sql.findIndex(statement => statement.startsWith('DELETE FROM reviews'));
+    expect(conflicts).toBeGreaterThan(0);
+    expect(conflicts).toBeLessThan(sql.indexOf('UPDATE reviews SET movie_id = $2 WHERE movie_id = $1'));
+    expect(sql[conflicts]).toMatch(/r\.movie_id = \$1 AND other\.movie_id = \$2 AND r\.updated_at <= other\.updated_at/);
+    expect(sql[conflicts]).toMatch(/r\.movie_id = \$2 AND other\.movie_id = \$1 AND r\.updated_at < other\.updated_at/);
+    expect(statements[conflicts].params).toEqual([2, 1]);
+  });
+
+  it('commits the merge', async () => {
+    const { statements } = await mergeReviewedDuplicate();
+
+    const sql = statements.map(statement => statement.sql);
+    expect(sql[0]).toBe('BEGIN');
+    expect(sql[sql.length - 1]).toBe('COMMIT');
+  });
+});
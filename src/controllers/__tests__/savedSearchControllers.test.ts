@@ -0,0 +1,70 @@
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import { createSavedSearch } from '../savedSearchControllers';
+import { getAllMoviesSchema } from '../movieGetControllers';
+
+jest.mock('@utils/database', () => ({
+  __esModule: true,
+  default: { query: jest.fn() }
+}));
+
+const query = pool.query as jest.Mock;
+
+/**
+ * Saves a search and returns the stored query and the share_url sent back
+ */
+const save = async (body: Record<string, unknown>) => {
+  query.mockReset();
+  query
+    .mockResolvedValueOnce({ rows: [{ total: 0 }] })
+    .mockImplementationOnce(async (_sql: string, params: unknown[]) => ({
+      rows: [{
+        search_id: 1,
+        name: params[1],
+        query: JSON.parse(params[2] as string),
+        created_at: new Date(),
+        updated_at: new Date()
+      }]
+    }));
+
+  const req = { body: { name: 'Heists', query: body }, apiKey: { api_key_id: 5 } } as unknown as ApiKeyRequest;
+  const res = { status: jest.fn().mockReturnThis(), json: jest.fn().mockReturnThis() };
+  await createSavedSearch(req, res as unknown as Response);
+
+  expect(res.status).toHaveBeenCalledWith(201);
+  const sent = res.json.mock.calls[0][0];
+  return { stored: sent.query, shareUrl: sent.share_url as string };
+};
+
+/**
+ * What GET /movies makes of a share_url
+ */
+const parseShareUrl = (shareUrl: string) => {
+  const params = Object.fromEntries(new URL(shareUrl, 'http://localhost').searchParams);
+  return getAllMoviesSchema.omit({ page: true, limit: true }).parse(params);
+};
+
+describe('saved search share_url', () => {
+  it('runs the same search as the saved one', async () => {
+    const { stored, shareUrl } = await save({
+      genre: 'Crime',
+      keyword: 'Heist; Time Travel',
+      startDate: '1990-01-01',
+      minRuntime: 90,
+      include_adult: 'true',
+      sortBy: 'revenue',
+      order: 'desc'
+    });
+
+    expect(shareUrl.startsWith('/api/movies?')).toBe(true);
+    expect(parseShareUrl(shareUrl)).toEqual(stored);
+  });
+
+  it('keeps keywords containing commas whole', async () => {
+    const { stored, shareUrl } = await save({ keyword: 'heist; cat and mouse, again' });
+
+    expect(stored.keyword).toEqual(['heist', 'cat and mouse, again']);
+    expect(parseShareUrl(shareUrl).keyword).toEqual(['heist', 'cat and mouse, again']);
+  });
+});
@@ -0,0 +1,290 @@
+// server/src/controllers/accountControllers.ts
+
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { UserRequest } from '@middleware/userAuth';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+/**
+ * Deleting an account can't be undone, so it must be asked for explicitly
+ */
+const deleteAccountSchema = z.object({
+  confirm: z.literal('true', 'Add ?confirm=true to permanently delete this account and its data')
+});
+
+// ============================================================================
+// Account Controllers
+// ============================================================================
+
+/**
+ * GET /api/me/data
+ * Everything stored about the signed-in user, as a JSON download
+ *
+ * Includes the profile, linked sign-in providers, sessions, reviews and
+ * reactions, watchlist, follows, activity, lists, digest and device
+ * settings. Secrets (password hash, TOTP secret, recovery codes and the
+ * various tokens) are never included.
+ *
+ * @returns Account data export
+ */
+export const exportMyData = async (req: UserRequest, res: Response): Promise<void> => {
+  const userId = req.user!.userId;
+
+  try {
+    const [
+      user, identities, sessions, reviews, reactions, watchlist, following,
+      followers, activities, lists, listItems, genres, digest, devices, calendar
+    ] = await Promise.all([
+      pool.query(
+        `SELECT user_id, email, username, role, created_at,
+                totp_enabled_at IS NOT NULL AS two_factor_enabled
+         FROM users
+         WHERE user_id = $1`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT provider, email, created_at, last_login_at
+         FROM user_identities
+         WHERE user_id = $1
+         ORDER BY created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT created_at, last_seen_at, expires_at, ip_address, user_agent
+         FROM user_sessions
+         WHERE user_id = $1
+         ORDER BY created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT review_id, movie_id, rating, body, contains_spoilers, status,
+                created_at, updated_at
+         FROM reviews
+         WHERE user_id = $1
+         ORDER BY created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT review_id, reaction, created_at
+         FROM review_reactions
+         WHERE user_id = $1
+         ORDER BY created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT movie_id, added_at
+         FROM watchlist
+         WHERE user_id = $1
+         ORDER BY added_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT u.username, f.created_at
+         FROM user_follows f
+         JOIN users u ON u.user_id = f.followed_id
+         WHERE f.follower_id = $1
+         ORDER BY f.created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT u.username, f.created_at
+         FROM user_follows f
+         JOIN users u ON u.user_id = f.follower_id
+         WHERE f.followed_id = $1
+         ORDER BY f.created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT activity_type, movie_id, review_id, created_at
+         FROM activities
+         WHERE user_id = $1
+         ORDER BY created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT list_id, name, description, slug, visibility, created_at, updated_at
+         FROM movie_lists
+         WHERE user_id = $1
+         ORDER BY created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT i.list_id, i.movie_id, i.position, i.note, i.added_at
+         FROM movie_list_items i
+         JOIN movie_lists l ON l.list_id = i.list_id
+         WHERE l.user_id = $1
+         ORDER BY i.list_id, i.position`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT g.genre_name
+         FROM user_favorite_genres f
+         JOIN genres g ON g.genre_id = f.genre_id
+         WHERE f.user_id = $1
+         ORDER BY g.genre_name`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT frequency, subscribed_at, last_sent_at
+         FROM digest_subscriptions
+         WHERE user_id = $1`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT platform, created_at, last_registered_at
+         FROM device_tokens
+         WHERE user_id = $1
+         ORDER BY created_at`,
+        [userId]
+      ),
+      pool.query(
+        `SELECT created_at
+         FROM calendar_feeds
+         WHERE user_id = $1`,
+        [userId]
+      )
+    ]);
+
+    if (user.rowCount === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(ApiError.notFound(`User with ID ${userId} not found`, 'USER_NOT_FOUND', { id: userId }));
+      return;
+    }
+
+    res.set('Content-Disposition', `attachment; filename="account-${userId}-data.json"`);
+    res.set('Cache-Control', 'no-store');
+    res.status(HttpStatus.OK).json({
+      exported_at: new Date().toISOString(),
+      account: user.rows[0],
+      identities: identities.rows,
+      sessions: sessions.rows,
+      reviews: reviews.rows,
+      review_reactions: reactions.rows,
+      watchlist: watchlist.rows,
+      following: following.rows,
+      followers: followers.rows,
+      activities: activities.rows,
+      lists: lists.rows.map(list => ({
+        ...list,
+        items: listItems.rows.filter(item => item.list_id === list.list_id)
+      })),
+      favorite_genres: genres.rows.map(row => row.genre_name),
+      digest_subscription: digest.rows[0] ?? null,
+      devices: devices.rows,
+      calendar_feed: calendar.rows[0] ?? null
+    });
+  } catch (error) {
+    console.error('Error exporting account data:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to export account data')
+    );
+  }
+};
+
+/**
+ * DELETE /api/me?confirm=true
+ * Permanently delete the signed-in user's account
+ *
+ * Removes the user and everything keyed to them: sign-in methods, sessions,
+ * reviews, reactions, watchlist, follows in both directions, activity,
+ * lists, digest, devices and the calendar feed. Reactions on other people's
+ * reviews are removed first so their helpful counts stay right. The API key
+ * used for the request is left alone; it belongs to the app, not the user.
+ *
+ * @returns Counts of what was removed
+ */
+export const deleteMyAccount = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = deleteAccountSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const userId = req.user!.userId;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    // Lock the user first so sign-ins and new content can't race the delete
+    const user = await client.query<{ email: string }>(
+      'SELECT email FROM users WHERE user_id = $1 FOR UPDATE',
+      [userId]
+    );
+    if (user.rowCount === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(ApiError.notFound(`User with ID ${userId} not found`, 'USER_NOT_FOUND', { id: userId }));
+      return;
+    }
+
+    const counts = await client.query<Record<string, number>>(
+      `SELECT
+         (SELECT COUNT(*)::int FROM reviews WHERE user_id = $1) AS reviews,
+         (SELECT COUNT(*)::int FROM watchlist WHERE user_id = $1) AS watchlist,
+         (SELECT COUNT(*)::int FROM user_follows
+          WHERE follower_id = $1 OR followed_id = $1) AS follows,
+         (SELECT COUNT(*)::int FROM activities WHERE user_id = $1) AS activities,
+         (SELECT COUNT(*)::int FROM movie_lists WHERE user_id = $1) AS lists`,
+      [userId]
+    );
+
+    // Cascading would drop these without touching the reviews' cached counts
+    await client.query(
+      `SELECT review_id FROM reviews
+       WHERE review_id IN (SELECT review_id FROM review_reactions WHERE user_id = $1)
+       ORDER BY review_id
+       FOR UPDATE`,
+      [userId]
+    );
+    const reactions = await client.query<{ review_id: number }>(
+      'DELETE FROM review_reactions WHERE user_id = $1 RETURNING review_id',
+      [userId]
+    );
+    await client.query(
+      `UPDATE reviews r
+       SET helpful_count = (SELECT COUNT(*)::int FROM review_reactions
+                            WHERE review_id = r.review_id AND reaction = 'helpful'),
+           unhelpful_count = (SELECT COUNT(*)::int FROM review_reactions
+                              WHERE review_id = r.review_id AND reaction = 'unhelpful')
+       WHERE r.review_id = ANY($1::int[])`,
+      [reactions.rows.map(row => row.review_id)]
+    );
+
+    // Everything else references users(user_id) with ON DELETE CASCADE
+    await client.query('DELETE FROM users WHERE user_id = $1', [userId]);
+    await client.query('DELETE FROM login_attempts WHERE lower(email) = lower($1)', [user.rows[0].email]);
+
+    await recordAudit(client, {
+      action: 'account.delete',
+      entity_type: 'user',
+      entity_id: userId
+    });
+
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Account deleted',
+      deleted: {
+        ...counts.rows[0],
+        review_reactions: reactions.rowCount ?? 0
+      }
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error deleting account:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to delete account')
+    );
+  } finally {
+    client.release();
+  }
+};
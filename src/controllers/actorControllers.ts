@@ -22,10 +22,35 @@ const searchSchema = paginationSchema.extend({
   name: z.string().min(1).optional()
 });
 
+const coStarsSchema = z.object({
+  limit: z.coerce.number().int().min(1).max(100).default(20),
+  minShared: z.coerce.number().int().min(1).default(1)
+});
+
+const pathSchema = z.object({
+  maxDepth: z.coerce.number().int().min(1).max(10).default(6)
+});
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
 
+/**
+ * How an actor was reached during a path search: the previous actor, the
+ * shared movie and how many steps from that side's root
+ */
+interface PathLink {
+  via: number;
+  movieId: number;
+  depth: number;
+}
+
+/**
+ * Steps from the root of the search side that reached the actor; roots are 0
+ */
+const pathDepth = (visited: Map<number, PathLink | null>, actorId: number): number =>
+  visited.get(actorId)?.depth ?? 0;
+
 const createPaginationResponse = (
   data: any[],
   page: number,
@@ -172,7 +197,7 @@ export const getActorById = async (req: Request, res: Response): Promise<void> =
 
   if (isNaN(actorId)) {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Actor ID must be a valid number')
+      ApiError.badRequest('Actor ID must be a valid number', 'INVALID_ID')
     );
     return;
   }
@@ -199,7 +224,7 @@ export const getActorById = async (req: Request, res: Response): Promise<void> =
 
     if (result.rows.length === 0) {
       res.status(HttpStatus.NOT_FOUND).json(
-        ApiError.notFound(`Actor with ID ${actorId} not found`)
+        ApiError.notFound(`Actor with ID ${actorId} not found`, 'ACTOR_NOT_FOUND', { id: actorId })
       );
       return;
     }
@@ -213,6 +238,58 @@ export const getActorById = async (req: Request, res: Response): Promise<void> =
   }
 };
 
+/**
+ * GET /api/actors/:id/images
+ * Every profile image recorded for an actor: the canonical one (the
+ * actor's profile_url) and alternates. duplicate_of points at the best
+ * copy of the same photo; unhashed images haven't been checked yet.
+ *
+ * @param id - Actor ID
+ * @returns canonical image and alternates
+ */
+export const getActorImages = async (req: Request, res: Response): Promise<void> => {
+  const actorId = parseInt(req.params.id, 10);
+
+  if (isNaN(actorId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Actor ID must be a valid number', 'INVALID_ID')
+    );
+    return;
+  }
+
+  try {
+    const actorResult = await pool.query('SELECT 1 FROM actors WHERE actor_id = $1', [actorId]);
+    if (actorResult.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Actor with ID ${actorId} not found`, 'ACTOR_NOT_FOUND', { id: actorId })
+      );
+      return;
+    }
+
+    const result = await pool.query(
+      `SELECT image_id, url, content_type, width, height, is_canonical, duplicate_of,
+              phash IS NOT NULL AS hashed, fetch_error IS NOT NULL AS broken, added_at
+       FROM person_images
+       WHERE actor_id = $1
+       ORDER BY is_canonical DESC, image_id`,
+      [actorId]
+    );
+
+    const canonical = result.rows.find(image => image.is_canonical) ?? null;
+
+    res.status(HttpStatus.OK).json({
+      actor_id: actorId,
+      canonical,
+      alternates: result.rows.filter(image => image !== canonical)
+    });
+  } catch (error) {
+    console.error('Error fetching actor images:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch actor images')
+    );
+  }
+};
+
 /**
  * GET /api/actors/search
  * Search actors by name (returns array)
@@ -227,7 +304,7 @@ export const searchActors = async (req: Request, res: Response): Promise<void> =
 
   if (!name || typeof name !== 'string') {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Name query parameter is required')
+      ApiError.badRequest('Name query parameter is required', 'NAME_REQUIRED')
     );
     return;
   }
@@ -377,43 +454,78 @@ export const getActorsByNationality = async (req: Request, res: Response): Promi
 };
 
 /**
- * GET /api/actors/:id/co-stars
+ * GET /api/actors/:id/costars
  * Get actors who have appeared in movies with the specified actor
  * 
+ * Query Parameters:
+ * - limit: number (default: 20, max: 100)
+ * - minShared: number (default: 1) - Only co-stars with at least this many shared movies
+ * 
  * @param id - Actor ID
- * @returns Array of co-stars with collaboration count
+ * @returns Array of co-stars with collaboration count and the shared titles
  */
 export const getActorCoStars = async (req: Request, res: Response): Promise<void> => {
   const actorId = parseInt(req.params.id, 10);
-  const limit = Math.min(parseInt(req.query.limit as string) || 20, 100);
 
   if (isNaN(actorId)) {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Actor ID must be a valid number')
+      ApiError.badRequest('Actor ID must be a valid number', 'INVALID_ID')
     );
     return;
   }
 
+  const validation = coStarsSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { limit, minShared } = validation.data;
+
   try {
+    const actorResult = await pool.query(
+      'SELECT actor_id, actor_name, profile_url FROM actors WHERE actor_id = $1',
+      [actorId]
+    );
+
+    if (actorResult.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Actor with ID ${actorId} not found`, 'ACTOR_NOT_FOUND', { id: actorId })
+      );
+      return;
+    }
+
     const sql = `
       SELECT 
         a.actor_id,
         a.actor_name,
         a.profile_url,
-        COUNT(DISTINCT ma2.movie_id)::int AS movies_together
+        COUNT(DISTINCT ma2.movie_id)::int AS movies_together,
+        JSON_AGG(
+          DISTINCT JSONB_BUILD_OBJECT(
+            'movie_id', m.movie_id,
+            'title', m.title,
+            'release_date', m.release_date
+          )
+        ) AS shared_movies
       FROM movie_actors ma1
       JOIN movie_actors ma2 ON ma1.movie_id = ma2.movie_id
       JOIN actors a ON ma2.actor_id = a.actor_id
+      JOIN movies m ON ma1.movie_id = m.movie_id
       WHERE ma1.actor_id = $1 
         AND ma2.actor_id != $1
       GROUP BY a.actor_id, a.actor_name, a.profile_url
-      ORDER BY movies_together DESC
+      HAVING COUNT(DISTINCT ma2.movie_id) >= $3
+      ORDER BY movies_together DESC, a.actor_name
       LIMIT $2
     `;
 
-    const result = await pool.query(sql, [actorId, limit]);
+    const result = await pool.query(sql, [actorId, limit, minShared]);
 
     res.status(HttpStatus.OK).json({
+      actor: actorResult.rows[0],
       data: result.rows,
       count: result.rows.length
     });
@@ -423,4 +535,167 @@ export const getActorCoStars = async (req: Request, res: Response): Promise<void
       ApiError.internalError('Failed to fetch co-stars')
     );
   }
-};
\ No newline at end of file
+};
+/**
+ * One breadth-first step through the co-star graph: every actor sharing a movie
+ * with the frontier who hasn't been reached yet, with the movie linking them.
+ */
+const expandCoStarFrontier = async (
+  frontier: number[],
+  visited: Map<number, PathLink | null>
+): Promise<number[]> => {
+  const result = await pool.query<{ actor_id: number; co_star_id: number; movie_id: number }>(
+    `SELECT DISTINCT ON (ma2.actor_id)
+       ma1.actor_id,
+       ma2.actor_id AS co_star_id,
+       ma1.movie_id
+     FROM movie_actors ma1
+     JOIN movie_actors ma2 ON ma1.movie_id = ma2.movie_id
+     WHERE ma1.actor_id = ANY($1::int[])
+       AND ma2.actor_id <> ALL($2::int[])
+     ORDER BY ma2.actor_id, ma1.movie_id`,
+    [frontier, [...visited.keys()]]
+  );
+
+  for (const row of result.rows) {
+    visited.set(row.co_star_id, { via: row.actor_id, movieId: row.movie_id, depth: pathDepth(visited, row.actor_id) + 1 });
+  }
+
+  return result.rows.map(row => row.co_star_id);
+};
+
+/**
+ * GET /api/actors/:id/path-to/:otherId
+ * Shortest chain of co-stars linking two actors (degrees of separation)
+ *
+ * Runs a bidirectional breadth-first search over movie_actors, expanding the
+ * smaller side one level at a time, so only the neighbourhoods of the two
+ * actors are ever loaded.
+ *
+ * Query Parameters:
+ * - maxDepth: number (default: 6, max: 10) - Give up beyond this many degrees
+ *
+ * @param id - Starting actor ID
+ * @param otherId - Target actor ID
+ * @returns The actors along the path and the movie linking each pair
+ */
+export const getActorPath = async (req: Request, res: Response): Promise<void> => {
+  const startId = parseInt(req.params.id, 10);
+  const targetId = parseInt(req.params.otherId, 10);
+
+  if (isNaN(startId) || isNaN(targetId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Actor IDs must be valid numbers')
+    );
+    return;
+  }
+
+  const validation = pathSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { maxDepth } = validation.data;
+
+  try {
+    const actorsResult = await pool.query<{ actor_id: number; actor_name: string }>(
+      'SELECT actor_id, actor_name FROM actors WHERE actor_id = ANY($1::int[])',
+      [[startId, targetId]]
+    );
+    const missing = [startId, targetId].find(id => !actorsResult.rows.some(a => a.actor_id === id));
+    if (missing !== undefined) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Actor with ID ${missing} not found`, 'ACTOR_NOT_FOUND', { id: missing })
+      );
+      return;
+    }
+
+    // Each map records how an actor was reached from its side; the roots map to null
+    const fromStart = new Map<number, PathLink | null>([[startId, null]]);
+    const fromTarget = new Map<number, PathLink | null>([[targetId, null]]);
+    let startFrontier = [startId];
+    let targetFrontier = [targetId];
+    let meeting: number | undefined = startId === targetId ? startId : undefined;
+    let depth = 0;
+
+    // Of the actors reached from both sides, the one with the shortest total
+    // path; the other side's visits span several depths, so the first found
+    // isn't necessarily it
+    const closestMeeting = (frontier: number[], other: Map<number, PathLink | null>): number | undefined => {
+      let best: number | undefined;
+      let bestLength = Infinity;
+      for (const id of frontier) {
+        if (!other.has(id)) continue;
+        const length = pathDepth(fromStart, id) + pathDepth(fromTarget, id);
+        if (length < bestLength) {
+          best = id;
+          bestLength = length;
+        }
+      }
+      return best;
+    };
+
+    while (meeting === undefined && depth < maxDepth && startFrontier.length > 0 && targetFrontier.length > 0) {
+      if (startFrontier.length <= targetFrontier.length) {
+        startFrontier = await expandCoStarFrontier(startFrontier, fromStart);
+        meeting = closestMeeting(startFrontier, fromTarget);
+      } else {
+        targetFrontier = await expandCoStarFrontier(targetFrontier, fromTarget);
+        meeting = closestMeeting(targetFrontier, fromStart);
+      }
+      depth++;
+    }
+
+    if (meeting === undefined) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`No connection between actors ${startId} and ${targetId} within ${maxDepth} degrees`)
+      );
+      return;
+    }
+
+    // Walk back to the start, then forward to the target
+    const path: number[] = [meeting];
+    const movieIds: number[] = [];
+    for (let link = fromStart.get(meeting); link; link = fromStart.get(link.via)) {
+      path.unshift(link.via);
+      movieIds.unshift(link.movieId);
+    }
+    for (let link = fromTarget.get(meeting); link; link = fromTarget.get(link.via)) {
+      path.push(link.via);
+      movieIds.push(link.movieId);
+    }
+
+    const [namesResult, moviesResult] = await Promise.all([
+      pool.query<{ actor_id: number; actor_name: string; profile_url: string | null }>(
+        'SELECT actor_id, actor_name, profile_url FROM actors WHERE actor_id = ANY($1::int[])',
+        [path]
+      ),
+      pool.query<{ movie_id: number; title: string; release_date: string | null }>(
+        'SELECT movie_id, title, release_date FROM movies WHERE movie_id = ANY($1::int[])',
+        [movieIds]
+      )
+    ]);
+    const actorsById = new Map(namesResult.rows.map(a => [a.actor_id, a]));
+    const moviesById = new Map(moviesResult.rows.map(m => [m.movie_id, m]));
+
+    res.status(HttpStatus.OK).json({
+      from: actorsById.get(startId),
+      to: actorsById.get(targetId),
+      degrees: path.length - 1,
+      path: path.map(id => actorsById.get(id)),
+      links: movieIds.map((movieId, i) => ({
+        from_actor_id: path[i],
+        to_actor_id: path[i + 1],
+        movie: moviesById.get(movieId)
+      }))
+    });
+  } catch (error) {
+    console.error('Error finding actor path:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to find path between actors')
+    );
+  }
+};
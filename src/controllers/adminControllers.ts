@@ -0,0 +1,213 @@
+// server/src/controllers/adminControllers.ts
+
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { CREW_ROLES } from '@utils/movieCrew';
+import { reconcilePersonImages } from '@utils/personImages';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import z from 'zod';
+
+// ============================================================================
+// Person Tables
+// ============================================================================
+
+/**
+ * Table layout for each kind of person that can be merged.
+ * Identifiers are interpolated into SQL, so they must only come from this map.
+ */
+const PERSON_TABLES = {
+  actor: {
+    table: 'actors',
+    idColumn: 'actor_id',
+    nameColumn: 'actor_name',
+    linkTable: 'movie_actors',
+    metadataColumns: ['birth_date', 'biography', 'profile_url', 'nationality', 'awards']
+  },
+  director: {
+    table: 'directors',
+    idColumn: 'director_id',
+    nameColumn: 'director_name',
+    linkTable: 'movie_directors',
+    metadataColumns: ['birth_date', 'biography', 'profile_url', 'nationality', 'awards']
+  },
+  producer: {
+    table: 'producers',
+    idColumn: 'producer_id',
+    nameColumn: 'producer_name',
+    linkTable: 'movie_producers',
+    metadataColumns: [] as string[]
+  },
+  writer: { ...CREW_ROLES.writers, metadataColumns: [] as string[] },
+  composer: { ...CREW_ROLES.composers, metadataColumns: [] as string[] },
+  cinematographer: { ...CREW_ROLES.cinematographers, metadataColumns: [] as string[] },
+  editor: { ...CREW_ROLES.editors, metadataColumns: [] as string[] }
+} as const;
+
+const mergePersonQuerySchema = z.object({
+  type: z.enum(['actor', 'director', 'producer', 'writer', 'composer', 'cinematographer', 'editor']).default('actor')
+});
+
+// ============================================================================
+// Admin Controllers
+// ============================================================================
+
+/**
+ * POST /api/admin/people/:id/merge-into/:targetId
+ * Merge a duplicate person record into another one
+ *
+ * Repoints every movie link from the source to the target, fills the target's
+ * empty metadata from the source (non-null target values win), deletes the
+ * source and writes an audit_log entry. Runs in a single transaction.
+ *
+ * Query Parameters:
+ * - type: 'actor' | 'director' | 'producer' | 'writer' | 'composer' |
+ *         'cinematographer' | 'editor' (default: 'actor')
+ *
+ * @param id - ID of the duplicate to remove
+ * @param targetId - ID of the record to keep
+ * @returns The merged target record and link counts
+ */
+export const mergePerson = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const sourceId = parseInt(req.params.id, 10);
+  const targetId = parseInt(req.params.targetId, 10);
+
+  if (isNaN(sourceId) || isNaN(targetId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Person IDs must be valid numbers')
+    );
+    return;
+  }
+
+  if (sourceId === targetId) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Cannot merge a person into itself')
+    );
+    return;
+  }
+
+  const validation = mergePersonQuerySchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { type } = validation.data;
+  const { table, idColumn, nameColumn, linkTable, metadataColumns } = PERSON_TABLES[type];
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    // Lock both rows so concurrent merges can't interleave
+    const peopleResult = await client.query(
+      `SELECT * FROM ${table} WHERE ${idColumn} = ANY($1::int[]) FOR UPDATE`,
+      [[sourceId, targetId]]
+    );
+
+    const source = peopleResult.rows.find(row => row[idColumn] === sourceId);
+    const target = peopleResult.rows.find(row => row[idColumn] === targetId);
+
+    if (!source || !target) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`${type} with ID ${!source ? sourceId : targetId} not found`)
+      );
+      return;
+    }
+
+    // Repoint links, skipping movies the target is already linked to
+    const repointResult = await client.query(
+      `UPDATE ${linkTable} AS l
+       SET ${idColumn} = $2
+       WHERE l.${idColumn} = $1
+         AND NOT EXISTS (
+           SELECT 1 FROM ${linkTable} x
+           WHERE x.movie_id = l.movie_id AND x.${idColumn} = $2
+         )`,
+      [sourceId, targetId]
+    );
+
+    // Whatever is left would duplicate an existing link
+    const duplicateResult = await client.query(
+      `DELETE FROM ${linkTable} WHERE ${idColumn} = $1`,
+      [sourceId]
+    );
+
+    // Prefer the target's values, fill gaps from the source
+    if (metadataColumns.length > 0) {
+      const assignments = metadataColumns
+        .map(column => `${column} = COALESCE(t.${column}, s.${column})`)
+        .join(', ');
+
+      await client.query(
+        `UPDATE ${table} AS t
+         SET ${assignments}
+         FROM ${table} AS s
+         WHERE t.${idColumn} = $1 AND s.${idColumn} = $2`,
+        [targetId, sourceId]
+      );
+    }
+
+    // Keep the source's photos as alternates of the target
+    if (type === 'actor') {
+      await client.query(
+        `UPDATE person_images p
+         SET actor_id = $2, is_canonical = FALSE, duplicate_of = NULL
+         WHERE p.actor_id = $1
+           AND NOT EXISTS (SELECT 1 FROM person_images x WHERE x.actor_id = $2 AND x.url = p.url)`,
+        [sourceId, targetId]
+      );
+      await reconcilePersonImages(client, targetId);
+    }
+
+    await client.query(`DELETE FROM ${table} WHERE ${idColumn} = $1`, [sourceId]);
+
+    const movedLinks = repointResult.rowCount ?? 0;
+    const droppedLinks = duplicateResult.rowCount ?? 0;
+
+    await recordAudit(client, {
+      action: 'person.merge',
+      entity_type: type,
+      entity_id: targetId,
+      details: {
+        merged_id: sourceId,
+        merged_name: source[nameColumn],
+        merged_record: source,
+        links_repointed: movedLinks,
+        duplicate_links_dropped: droppedLinks
+      },
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    const mergedResult = await client.query(
+      `SELECT * FROM ${table} WHERE ${idColumn} = $1`,
+      [targetId]
+    );
+
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: `Merged ${type} "${source[nameColumn]}" into "${target[nameColumn]}"`,
+      type,
+      merged_id: sourceId,
+      target: mergedResult.rows[0],
+      links_repointed: movedLinks,
+      duplicate_links_dropped: droppedLinks
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error merging person:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to merge person')
+    );
+  } finally {
+    client.release();
+  }
+};
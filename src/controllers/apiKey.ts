@@ -471,7 +471,7 @@ export const getApiKeyInfo = async (req: any, res: Response): Promise<void> => {
     try {
         if (!req.apiKey) {
             res.status(HttpStatus.UNAUTHORIZED).json(
-                ApiError.unauthorized('API key authentication required')
+                ApiError.unauthorized('API key authentication required', 'API_KEY_REQUIRED')
             );
             return;
         }
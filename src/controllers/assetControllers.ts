@@ -0,0 +1,63 @@
+// server/src/controllers/assetControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+
+// ============================================================================
+// Asset Controllers
+// ============================================================================
+
+/**
+ * GET /api/assets/:hash
+ * A mirrored image by the SHA-256 of its content; needs no API key so it
+ * can be used as an <img> src
+ *
+ * The content at a hash never changes, so responses are cacheable forever
+ * and the hash doubles as the ETag.
+ *
+ * @param hash - 64 hex digits, e.g. from a studio's logo_asset_url
+ * @returns The image bytes with their content type
+ */
+export const getAsset = async (req: Request, res: Response): Promise<void> => {
+  const hash = req.params.hash.toLowerCase();
+  if (!/^[0-9a-f]{64}$/.test(hash)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Asset hash must be 64 hex digits', 'INVALID_ASSET_HASH')
+    );
+    return;
+  }
+
+  const etag = `"${hash}"`;
+  if (req.get('If-None-Match') === etag) {
+    res.status(HttpStatus.NOT_MODIFIED).end();
+    return;
+  }
+
+  try {
+    const result = await pool.query<{ content_type: string; data: Buffer }>(
+      'SELECT content_type, data FROM assets WHERE hash = $1',
+      [hash]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Asset not found', 'ASSET_NOT_FOUND')
+      );
+      return;
+    }
+
+    res
+      .status(HttpStatus.OK)
+      .type(result.rows[0].content_type)
+      .set('Cache-Control', 'public, max-age=31536000, immutable')
+      .set('ETag', etag)
+      .send(result.rows[0].data);
+  } catch (error) {
+    console.error('Error fetching asset:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch asset')
+    );
+  }
+};
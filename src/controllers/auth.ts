@@ -2,9 +2,51 @@ import { JwtClaims, JwtResponse, User } from '@models/authModel';
 import pool from '@utils/database';
 import { ApiError } from '@utils/httpError';
 import { HttpStatus } from '@utils/httpStatus';
-import { accessToken, decodeRefresh, refreshToken, verifyRefresh } from '@utils/jwtToken';
+import { MFA_TOKEN_SECONDS, accessToken, mfaToken, verifyMfaToken } from '@utils/jwtToken';
+import { reserveLoginAttempt, settleLoginAttempt } from '@utils/loginThrottle';
+import { getCookie } from '@utils/cookies';
+import {
+    IssuedRefreshToken,
+    REFRESH_COOKIE,
+    getRefreshTokenOwner,
+    issueRefreshToken,
+    revokeAllRefreshTokens,
+    revokeRefreshTokenFamily,
+    rotateRefreshToken
+} from '@utils/refreshTokens';
+import {
+    OAUTH_STATE_COOKIE,
+    OAUTH_STATE_COOKIE_MAX_AGE_MS,
+    OAuthError,
+    OAuthProvider,
+    completeAuthorization,
+    createAuthorizationUrl,
+    findOrCreateOAuthUser,
+    getOAuthProvider,
+    getOAuthProviders
+} from '@utils/oauth';
+import {
+    beginTotpEnrollment,
+    confirmTotpEnrollment,
+    disableTwoFactor,
+    getTwoFactorStatus,
+    isTwoFactorRequiredForRole,
+    replaceRecoveryCodes,
+    verifySecondFactor
+} from '@utils/twoFactor';
+import {
+    CSRF_COOKIE,
+    SESSION_COOKIE,
+    createSession,
+    csrfTokenFor,
+    destroyAllSessions,
+    destroySession,
+    getAuthMode,
+    getSessionUser,
+    sessionsEnabled
+} from '@utils/sessions';
+import { UserRequest, getRequestUser } from '@middleware/userAuth';
 import { Request, Response } from 'express';
-import { randomBytes } from 'node:crypto';
 import z from 'zod';
 import argon2 from 'argon2';
 
@@ -12,6 +54,8 @@ const loginSchema = z.object({
     email: z.email("email must be formatted as an email"),
     password: z.string("password must be a string")
         .min(8, "password must be greater than 8 characters"),
+    // AUTH_MODE=both: ask for a cookie session instead of tokens
+    session: z.boolean().optional(),
 });
 
 const registerSchema = z.object({
@@ -21,7 +65,9 @@ const registerSchema = z.object({
     email: z.email("email must be formatted as an email"),
     password: z.string("password must be a string")
         .min(8, "password must be greater than 8 characters"),
-    role: z.enum(['user', 'admin'], "role must be either 'user' or 'admin'"),
+    // Admins are promoted directly in the database, never self-registered
+    role: z.enum(['user'], "role must be 'user'").default('user'),
+    session: z.boolean().optional(),
 });
 
 const verifySchema = z.object({
@@ -30,6 +76,151 @@ const verifySchema = z.object({
     token: z.string()
 });
 
+const refreshSchema = z.object({
+    refreshToken: z.string().min(1).optional()
+});
+
+/**
+ * Refresh token from the JSON body (API clients) or the cookie (browsers)
+ */
+const getPresentedRefreshToken = (req: Request): string | undefined => {
+    const body = refreshSchema.safeParse(req.body ?? {});
+    return (body.success ? body.data.refreshToken : undefined) ?? getCookie(req, REFRESH_COOKIE);
+};
+
+/**
+ * Sets the refresh cookie and sends the token pair (plus any extra fields)
+ */
+const sendTokens = (
+    res: Response,
+    user: { user_id: number; username: string; role: string },
+    refresh: IssuedRefreshToken,
+    extra: Record<string, unknown> = {}
+) => {
+    const claims: JwtClaims = { userId: user.user_id, userName: user.username, role: user.role };
+    setRefreshCookie(res, refresh);
+    const out: JwtResponse = {
+        username: user.username,
+        role: user.role,
+        jwt: {
+            accessToken: accessToken(claims),
+            type: "Bearer",
+            refreshToken: refresh.token,
+            refreshExpiresAt: refresh.expiresAt.toISOString()
+        }
+    };
+    res.status(200).json({ ...out, ...extra });
+};
+
+const setRefreshCookie = (res: Response, refresh: IssuedRefreshToken) => {
+    res.cookie(REFRESH_COOKIE, refresh.token, {
+        httpOnly: true,
+        secure: process.env.NODE_ENV === 'production',
+        sameSite: 'strict',
+        path: '/api/auth',
+        expires: refresh.expiresAt
+    });
+};
+
+const clearRefreshCookie = (res: Response) => {
+    res.clearCookie(REFRESH_COOKIE, { path: '/api/auth' });
+};
+
+/**
+ * Whether this login should end in a cookie session rather than tokens:
+ * always with AUTH_MODE=session, on request ("session": true) with
+ * AUTH_MODE=both
+ */
+const wantsSession = (req: Request): boolean => {
+    const mode = getAuthMode();
+    return mode === 'session' || (mode === 'both' && req.body?.session === true);
+};
+
+/**
+ * Starts a cookie session: the httpOnly session cookie plus a readable CSRF
+ * cookie whose value must be echoed in X-CSRF-Token on writes
+ */
+const setSessionCookies = async (req: Request, res: Response, userId: number) => {
+    const { sessionId, expiresAt } = await createSession(userId, {
+        ip: req.ip,
+        userAgent: req.headers['user-agent']
+    });
+    const csrfToken = csrfTokenFor(sessionId);
+    const options = {
+        secure: process.env.NODE_ENV === 'production',
+        sameSite: 'lax' as const,
+        path: '/',
+        expires: expiresAt
+    };
+    res.cookie(SESSION_COOKIE, sessionId, { ...options, httpOnly: true });
+    res.cookie(CSRF_COOKIE, csrfToken, options);
+    return { csrfToken, expiresAt };
+};
+
+const clearSessionCookies = (res: Response) => {
+    res.clearCookie(SESSION_COOKIE, { path: '/' });
+    res.clearCookie(CSRF_COOKIE, { path: '/' });
+};
+
+/**
+ * Finishes a login with a session or a fresh token pair (plus any extra fields)
+ */
+const completeLogin = async (
+    req: Request,
+    res: Response,
+    user: { user_id: number; username: string; role: string },
+    extra: Record<string, unknown> = {}
+) => {
+    if (wantsSession(req)) {
+        const session = await setSessionCookies(req, res, user.user_id);
+        return res.status(200).json({
+            username: user.username,
+            role: user.role,
+            session: { csrfToken: session.csrfToken, expiresAt: session.expiresAt.toISOString() },
+            ...extra
+        });
+    }
+
+    // Each login starts a new token family, so devices can be logged out separately
+    const refresh = await issueRefreshToken(pool, user.user_id);
+    sendTokens(res, user, refresh, extra);
+};
+
+type LoginStep =
+    | { step: 'complete' }
+    | { step: 'mfa_required' | 'mfa_setup_required'; mfaToken: string };
+
+/**
+ * What a user who just proved their first factor gets: signed in, a 2FA
+ * challenge, or (admins without 2FA) a token that only allows enrolling
+ */
+const nextLoginStep = async (user: { user_id: number; role: string }): Promise<LoginStep> => {
+    const twoFactor = await getTwoFactorStatus(user.user_id);
+    if (twoFactor?.enabled) {
+        return { step: 'mfa_required', mfaToken: mfaToken(user.user_id, 'verify') };
+    }
+    if (isTwoFactorRequiredForRole(user.role)) {
+        return { step: 'mfa_setup_required', mfaToken: mfaToken(user.user_id, 'setup') };
+    }
+    return { step: 'complete' };
+};
+
+const sendLoginStep = async (
+    req: Request,
+    res: Response,
+    user: { user_id: number; username: string; role: string },
+    next: LoginStep
+) => {
+    if (next.step === 'complete') {
+        return completeLogin(req, res, user);
+    }
+    res.status(200).json({
+        [next.step]: true,
+        mfaToken: next.mfaToken,
+        expiresIn: MFA_TOKEN_SECONDS
+    });
+};
+
 
 /**
  * Authenticates a user and creates a new session.
@@ -41,26 +232,39 @@ const verifySchema = z.object({
  * **Process Flow:**
  * 1. Validates request body against loginSchema (email, password)
  * 2. Queries database for user by email
- * 3. Verifies password using Argon2
- * 4. Generates JWT access and refresh tokens
- * 5. Updates refresh token in sessions table
- * 6. Sets HTTP-only cookie with refresh token
- * 7. Returns access token and user info in response
+ * 3. Rejects the attempt with 429 if the account or IP is throttled
+ * 4. Verifies password using Argon2, recording the attempt
+ * 5. With 2FA enabled, returns { mfa_required, mfaToken } instead; the
+ *    tokens come from POST /api/auth/2fa/verify. Admins without 2FA get
+ *    { mfa_setup_required, mfaToken } and must enroll first.
+ * 6. Generates JWT access and refresh tokens
+ * 7. Issues a new refresh token (a new token family for this device)
+ * 8. Sets HTTP-only cookie with refresh token
+ * 9. Returns access token and user info in response
+ *
+ * With AUTH_MODE=session (or both and "session": true) steps 6-9 instead
+ * start a cookie session and return its CSRF token.
  * 
  * **Security Features:**
  * - Argon2 password hashing verification
+ * - Exponential delays after repeated failures, then a temporary lockout
+ *   (see loginThrottle); admins can unlock early
+ * - Per-IP failure limit across all accounts
+ * - Unknown emails and wrong passwords get the same response
  * - JWT-based authentication with separate access/refresh tokens
  * - Refresh token stored securely in HTTP-only cookie
- * - Session persistence in database
+ * - Refresh tokens stored hashed server-side and rotated on every use
  * 
  * @route POST /api/auth/login
  * @param req - Express request object
  * @param req.body.email - User's email address (must be valid email format)
  * @param req.body.password - User's password (minimum 8 characters)
+ * @param req.body.session - AUTH_MODE=both: true for a cookie session
  * @param res - Express response object
- * @returns 200 - Success with user info and JWT access token
- * @returns 400 - Invalid credentials or validation errors
- * @returns 401 - Incorrect password
+ * @returns 200 - Success with user info and JWT access token, or a 2FA step
+ * @returns 400 - Validation errors
+ * @returns 401 - Unknown email or incorrect password
+ * @returns 429 - Too many failed attempts; see Retry-After
  * @returns 500 - Database or server error
  * 
  * @example
@@ -87,6 +291,23 @@ export const login = async (req: Request, res: Response) => {
         return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(validation.error.issues));
     }
     const { email, password } = validation.data;
+    const ip = req.ip ?? null;
+
+    let attemptId: string;
+    try {
+        const throttle = await reserveLoginAttempt(email, ip);
+        if (!throttle.allowed) {
+            res.set('Retry-After', String(throttle.retryAfterSeconds));
+            return res.status(HttpStatus.TOO_MANY_REQUESTS).json(
+                throttle.reason === 'locked'
+                    ? ApiError.tooManyRequests('account temporarily locked after repeated failed logins', 'ACCOUNT_LOCKED')
+                    : ApiError.tooManyRequests('too many failed login attempts, try again later', 'LOGIN_RATE_LIMITED')
+            );
+        }
+        attemptId = throttle.attemptId;
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
 
     let user: User;
     try {
@@ -95,53 +316,36 @@ export const login = async (req: Request, res: Response) => {
             u.user_id,
             u.username,
             u.role,
-            p.password_hash,
-            s.token
+            p.password_hash
         FROM
             users AS u
             LEFT JOIN password_login AS p USING (user_id)
-            LEFT JOIN sessions AS s USING (user_id)
         WHERE u.email = $1
         `, [email]);
         user = q.rows[0] as User;
-        if (!q || !user) { return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest("user does not exist")); }
+        if (!q || !user) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("incorrect login provided", 'INVALID_CREDENTIALS'));
+        }
     } catch (e) {
         return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
     }
 
     try {
-        const isMatch = await argon2.verify(user.password_hash, password);
+        // Accounts created through OAuth have no password
+        const isMatch = user.password_hash ? await argon2.verify(user.password_hash, password) : false;
+        await settleLoginAttempt(attemptId, user.user_id, isMatch);
         if (!isMatch) {
-            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("incorrect login provided"));
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("incorrect login provided", 'INVALID_CREDENTIALS'));
         }
     } catch (error) {
         return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(error));
     }
 
-    const claims: JwtClaims = { userName: user.username, role: user.role };
-    const refresh = refreshToken(claims);
     try {
-        const sessionInsert = `
-            INSERT INTO sessions (user_id, token)
-            VALUES ($1, $2)
-            ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token;
-        `;
-        await pool.query(sessionInsert, [user.user_id, refresh]);
+        await sendLoginStep(req, res, user, await nextLoginStep(user));
     } catch (e) {
         return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
     }
-
-    const access = accessToken(claims);
-    let out: JwtResponse = {
-        username: user.username,
-        role: user.role,
-        jwt: {
-            accessToken: access,
-            type: "Bearer"
-        }
-    };
-    res.cookie("refresh-token", refresh);
-    res.status(200).json(out);
 };
 
 /**
@@ -149,14 +353,14 @@ export const login = async (req: Request, res: Response) => {
  * 
  * Creates a new user with hashed password, generates JWT tokens, and establishes
  * an initial session. Uses a database transaction to ensure atomicity across
- * multiple table inserts (users, password_login, sessions).
+ * multiple table inserts (users, password_login, refresh_tokens).
  * 
  * **Process Flow:**
  * 1. Validates request body against registerSchema
  * 2. Checks for existing users with same email or username
  * 3. Hashes password using Argon2
  * 4. Generates JWT access and refresh tokens
- * 5. Creates user, password, and session records in transaction
+ * 5. Creates user, password, and refresh token records in transaction
  * 6. Sets HTTP-only cookie with refresh token
  * 7. Returns access token and user info in response
  * 
@@ -171,14 +375,14 @@ export const login = async (req: Request, res: Response) => {
  * - Username: 3-50 characters
  * - Email: Valid email format
  * - Password: Minimum 8 characters
- * - Role: Must be 'user' or 'admin'
+ * - Role: 'user' (optional); admins are promoted in the database
  * 
  * @route POST /api/auth/register
  * @param req - Express request object
  * @param req.body.username - Desired username (3-50 characters, must be unique)
  * @param req.body.email - Email address (valid format, must be unique)
  * @param req.body.password - Password (minimum 8 characters)
- * @param req.body.role - User role ('user' or 'admin')
+ * @param req.body.role - User role, only 'user' may be requested
  * @param res - Express response object
  * @returns 200 - Success with user info and JWT access token
  * @returns 400 - Validation errors (invalid format or missing fields)
@@ -220,7 +424,7 @@ export const register = async (req: Request, res: Response) => {
         );
         if (existingUser.rows.length > 0) {
             return res.status(HttpStatus.CONFLICT).json(
-                ApiError.conflict('User with this email or username already exists')
+                ApiError.conflict('User with this email or username already exists', 'USER_EXISTS')
             );
         }
     } catch (e) {
@@ -230,10 +434,9 @@ export const register = async (req: Request, res: Response) => {
     const passwordHashBytes = await argon2.hash(password);
     const passwordHash: string = passwordHashBytes.toString();
 
-    const claims: JwtClaims = { userName: username, role: role };
-    const refresh = refreshToken(claims);
-
     let client = await pool.connect();
+    let userId: number;
+    let refresh: IssuedRefreshToken | undefined;
 
     try {
         await client.query('BEGIN');
@@ -247,12 +450,11 @@ export const register = async (req: Request, res: Response) => {
             INSERT INTO password_login (user_id, password_hash)
             VALUES ($1, $2)
         `;
-        await client.query(passwordInsert, [id.rows[0].user_id, passwordHash]);
-        const sessionInsert = `
-            INSERT INTO sessions (user_id, token)
-            VALUES ($1, $2)
-        `;
-        await client.query(sessionInsert, [id.rows[0].user_id, refresh]);
+        userId = id.rows[0].user_id;
+        await client.query(passwordInsert, [userId, passwordHash]);
+        if (!wantsSession(req)) {
+            refresh = await issueRefreshToken(client, userId);
+        }
         await client.query('COMMIT');
     } catch (e) {
         await client.query('ROLLBACK');
@@ -261,17 +463,569 @@ export const register = async (req: Request, res: Response) => {
         client.release();
     }
 
-    const access = accessToken(claims);
-    let out: JwtResponse = {
-        username: username,
-        role: role,
-        jwt: {
-            accessToken: access,
-            type: "Bearer"
+    if (!refresh) {
+        try {
+            return await completeLogin(req, res, { user_id: userId, username, role });
+        } catch (e) {
+            return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
         }
-    };
-    res.cookie("refresh-token", refresh);
-    res.status(200).json(out);
+    }
+
+    sendTokens(res, { user_id: userId, username, role }, refresh);
+};
+
+/**
+ * Exchanges a refresh token for a new access token and a new refresh token.
+ *
+ * Refresh tokens are single use. Presenting one that was already exchanged
+ * (or revoked) is treated as theft: every token from the same login is
+ * revoked and the client must log in again.
+ *
+ * @route POST /api/auth/refresh
+ * @param req.body.refreshToken - Refresh token (or the refresh-token cookie)
+ * @returns 200 - New token pair, same shape as login
+ * @returns 401 - Missing, unknown, expired, revoked or reused refresh token
+ * @returns 500 - Database or server error
+ */
+export const refreshAccessToken = async (req: Request, res: Response) => {
+    const token = getPresentedRefreshToken(req);
+    if (!token) {
+        return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("refresh token required", 'REFRESH_TOKEN_REQUIRED'));
+    }
+
+    try {
+        const result = await rotateRefreshToken(token);
+        if (result.status !== 'rotated') {
+            clearRefreshCookie(res);
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized(
+                result.status === 'reused'
+                    ? "refresh token already used; all sessions from that login were revoked"
+                    : `refresh token ${result.status}`
+            ));
+        }
+
+        sendTokens(res, result.user, result.refresh);
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * Logs out the current device by revoking its refresh token family and
+ * ending its cookie session. Access tokens already issued stay valid until
+ * they expire (15 minutes).
+ *
+ * @route POST /api/auth/logout
+ * @param req.body.refreshToken - Refresh token (or the refresh-token cookie)
+ * @returns 200 - Logged out (also when the token was already invalid)
+ * @returns 500 - Database or server error
+ */
+export const logout = async (req: Request, res: Response) => {
+    const token = getPresentedRefreshToken(req);
+    const sessionId = getCookie(req, SESSION_COOKIE);
+
+    try {
+        if (token) {
+            await revokeRefreshTokenFamily(token);
+        }
+        if (sessionId) {
+            await destroySession(sessionId);
+        }
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+
+    clearRefreshCookie(res);
+    clearSessionCookies(res);
+    res.status(200).json({ success: true, message: "logged out" });
+};
+
+/**
+ * Logs out every device by revoking all of the user's refresh tokens and
+ * cookie sessions.
+ *
+ * @route POST /api/auth/logout-all
+ * @param req.body.refreshToken - A currently valid refresh token (or the cookie),
+ *   or a current cookie session
+ * @returns 200 - Number of sessions revoked
+ * @returns 401 - Missing or no longer valid refresh token / session
+ * @returns 500 - Database or server error
+ */
+export const logoutAll = async (req: Request, res: Response) => {
+    const token = getPresentedRefreshToken(req);
+    const sessionId = sessionsEnabled() ? getCookie(req, SESSION_COOKIE) : undefined;
+    if (!token && !sessionId) {
+        return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("refresh token required", 'REFRESH_TOKEN_REQUIRED'));
+    }
+
+    try {
+        const userId = token
+            ? await getRefreshTokenOwner(token)
+            : (await getSessionUser(sessionId!))?.user_id ?? null;
+        if (userId === null) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized(token ? "refresh token invalid" : "session expired"));
+        }
+
+        const revoked = await revokeAllRefreshTokens(userId) + await destroyAllSessions(userId);
+        clearRefreshCookie(res);
+        clearSessionCookies(res);
+        res.status(200).json({ success: true, message: "logged out of all devices", sessions_revoked: revoked });
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * The signed-in user of the current cookie session, with its CSRF token.
+ * Lets a front end check whether it is still signed in after a reload.
+ *
+ * @route GET /api/auth/session
+ * @returns 200 - username, role and csrfToken
+ * @returns 401 - No session, or it expired
+ * @returns 404 - Cookie sessions are off (AUTH_MODE=token)
+ */
+export const getCurrentSession = async (req: Request, res: Response) => {
+    if (!sessionsEnabled()) {
+        return res.status(HttpStatus.NOT_FOUND).json(ApiError.notFound("cookie sessions are not enabled"));
+    }
+
+    const sessionId = getCookie(req, SESSION_COOKIE);
+    try {
+        const user = sessionId ? await getSessionUser(sessionId) : null;
+        if (!sessionId || !user) {
+            clearSessionCookies(res);
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("not signed in"));
+        }
+
+        res.set('Cache-Control', 'no-store');
+        res.status(200).json({ username: user.username, role: user.role, csrfToken: csrfTokenFor(sessionId) });
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+// ============================================================================
+// OAuth2 / OpenID Connect
+// ============================================================================
+
+const oauthCallbackSchema = z.object({
+    code: z.string().min(1),
+    state: z.string().min(1)
+});
+
+/**
+ * Callback URL registered with the provider. PUBLIC_BASE_URL overrides the
+ * request's host when the API sits behind a proxy.
+ */
+const getOAuthRedirectUri = (req: Request, provider: OAuthProvider): string =>
+    `${process.env.PUBLIC_BASE_URL?.replace(/\/+$/, '') ?? `${req.protocol}://${req.get('host')}`}/api/auth/oauth/${provider.name}/callback`;
+
+/**
+ * Lists the external sign-in options this deployment has configured.
+ *
+ * @route GET /api/auth/oauth/providers
+ * @returns 200 - Providers with the URL that starts each sign-in
+ */
+export const getOAuthProviderList = async (req: Request, res: Response) => {
+    res.status(200).json({
+        data: getOAuthProviders().map(provider => ({
+            name: provider.name,
+            label: provider.label,
+            login_url: `/api/auth/oauth/${provider.name}`
+        }))
+    });
+};
+
+/**
+ * Starts an OAuth2/OIDC sign-in by redirecting to the provider. A short-lived
+ * httpOnly cookie ties the sign-in to this browser; the callback is refused
+ * without it.
+ *
+ * @route GET /api/auth/oauth/:provider
+ * @returns 302 - Redirect to the provider's sign-in page
+ * @returns 404 - Unknown or unconfigured provider
+ * @returns 502 - Provider discovery failed
+ */
+export const startOAuthLogin = async (req: Request, res: Response) => {
+    const provider = getOAuthProvider(req.params.provider);
+    if (!provider) {
+        return res.status(HttpStatus.NOT_FOUND).json(ApiError.notFound(`sign-in with "${req.params.provider}" is not available`, 'OAUTH_PROVIDER_UNAVAILABLE', { provider: req.params.provider }));
+    }
+
+    try {
+        const { url, stateCookie } = await createAuthorizationUrl(provider, getOAuthRedirectUri(req, provider));
+        // Lax, because the callback arrives as a top-level navigation from the provider
+        res.cookie(OAUTH_STATE_COOKIE, stateCookie, {
+            httpOnly: true,
+            secure: process.env.NODE_ENV === 'production',
+            sameSite: 'lax',
+            path: '/api/auth/oauth',
+            maxAge: OAUTH_STATE_COOKIE_MAX_AGE_MS
+        });
+        res.redirect(url);
+    } catch (e) {
+        if (e instanceof OAuthError) {
+            return res.status(502).json(ApiError.createResponse(502, e.message));
+        }
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * Completes an OAuth2/OIDC sign-in. The external identity is mapped to a
+ * local user: an already linked account, else the account with the same
+ * verified email, else a new 'user' account without a password.
+ *
+ * With OAUTH_SUCCESS_REDIRECT set (browser front ends) the session cookie
+ * (AUTH_MODE=session|both) or the refresh cookie is set and the user is
+ * redirected there; in token mode the front end then calls
+ * POST /api/auth/refresh for an access token. Otherwise the token pair is
+ * returned as JSON, like login. Accounts with 2FA get the same second step
+ * as password login (in the redirect's fragment for browsers).
+ *
+ * @route GET /api/auth/oauth/:provider/callback
+ * @returns 200 - Token pair (or 302 to OAUTH_SUCCESS_REDIRECT)
+ * @returns 400 - Missing code/state
+ * @returns 401 - Sign-in denied, expired, started in another browser, or the
+ *   ID token didn't verify
+ * @returns 404 - Unknown or unconfigured provider
+ */
+export const completeOAuthLogin = async (req: Request, res: Response) => {
+    const provider = getOAuthProvider(req.params.provider);
+    if (!provider) {
+        return res.status(HttpStatus.NOT_FOUND).json(ApiError.notFound(`sign-in with "${req.params.provider}" is not available`, 'OAUTH_PROVIDER_UNAVAILABLE', { provider: req.params.provider }));
+    }
+
+    if (typeof req.query.error === 'string') {
+        return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized(`sign-in was not completed: ${req.query.error}`));
+    }
+
+    const validation = oauthCallbackSchema.safeParse(req.query);
+    if (!validation.success) {
+        return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(validation.error.issues));
+    }
+
+    const stateCookie = getCookie(req, OAUTH_STATE_COOKIE);
+    res.clearCookie(OAUTH_STATE_COOKIE, { path: '/api/auth/oauth' });
+
+    try {
+        const identity = await completeAuthorization(
+            provider,
+            validation.data.code,
+            validation.data.state,
+            stateCookie,
+            getOAuthRedirectUri(req, provider)
+        );
+        const { user } = await findOrCreateOAuthUser(identity);
+        const next = await nextLoginStep(user);
+
+        const successRedirect = process.env.OAUTH_SUCCESS_REDIRECT;
+        if (successRedirect) {
+            if (next.step === 'complete') {
+                // Browsers get a cookie session when sessions are on, else the refresh cookie
+                if (sessionsEnabled()) {
+                    await setSessionCookies(req, res, user.user_id);
+                } else {
+                    setRefreshCookie(res, await issueRefreshToken(pool, user.user_id));
+                }
+                return res.redirect(successRedirect);
+            }
+            // In the fragment so it never reaches a server log
+            const fragment = new URLSearchParams({ [next.step]: 'true', mfa_token: next.mfaToken });
+            return res.redirect(`${successRedirect}#${fragment.toString()}`);
+        }
+
+        await sendLoginStep(req, res, user, next);
+    } catch (e) {
+        if (e instanceof OAuthError) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized(e.message));
+        }
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+// ============================================================================
+// Two-factor authentication (TOTP)
+// ============================================================================
+
+const twoFactorCodeSchema = z.object({
+    code: z.string("code must be a string").trim().min(6, "code must be at least 6 characters")
+});
+
+const twoFactorVerifySchema = twoFactorCodeSchema.extend({
+    mfaToken: z.string("mfaToken must be a string").min(1)
+});
+
+const twoFactorEnrollSchema = z.object({
+    mfaToken: z.string().min(1).optional()
+});
+
+/**
+ * User enrolling in 2FA: the signed-in user, or an admin mid-login holding a
+ * 'setup' mfaToken
+ */
+const getEnrollingUser = async (req: Request): Promise<{ userId: number; viaLogin: boolean } | null> => {
+    const body = twoFactorEnrollSchema.safeParse(req.body ?? {});
+    const presented = body.success ? body.data.mfaToken : undefined;
+    if (presented) {
+        try {
+            const claims = verifyMfaToken(presented);
+            return claims.purpose === 'setup' ? { userId: claims.userId, viaLogin: true } : null;
+        } catch {
+            return null;
+        }
+    }
+
+    const claims = await getRequestUser(req);
+    return claims ? { userId: claims.userId, viaLogin: false } : null;
+};
+
+const getUserForLogin = async (userId: number) => {
+    const result = await pool.query(
+        'SELECT user_id, username, email, role FROM users WHERE user_id = $1',
+        [userId]
+    );
+    return result.rows[0] as { user_id: number; username: string; email: string; role: string } | undefined;
+};
+
+/**
+ * Shows whether the signed-in user has 2FA on.
+ *
+ * @route GET /api/auth/2fa
+ * @returns 200 - enabled, pending, recovery_codes_remaining, required
+ * @returns 401 - Not signed in
+ */
+export const getTwoFactorSettings = async (req: UserRequest, res: Response) => {
+    try {
+        const status = await getTwoFactorStatus(req.user!.userId);
+        if (!status) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("account no longer exists", 'ACCOUNT_NOT_FOUND'));
+        }
+        res.status(200).json({
+            enabled: status.enabled,
+            pending: status.pending,
+            recovery_codes_remaining: status.recoveryCodesRemaining,
+            required: status.required
+        });
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * Starts 2FA enrollment. Returns a new secret and an otpauth:// URI (render
+ * it as a QR code); nothing is enforced until POST /api/auth/2fa/enable.
+ *
+ * @route POST /api/auth/2fa/setup
+ * @param req.body.mfaToken - 'setup' token from login (admins), else Bearer auth
+ * @returns 200 - secret and otpauth_url
+ * @returns 401 - Not signed in / invalid or expired mfaToken
+ * @returns 409 - 2FA already enabled
+ */
+export const setupTwoFactor = async (req: Request, res: Response) => {
+    try {
+        const enrolling = await getEnrollingUser(req);
+        if (!enrolling) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("sign in or provide a valid mfaToken", 'MFA_TOKEN_REQUIRED'));
+        }
+
+        const [user, status] = await Promise.all([
+            getUserForLogin(enrolling.userId),
+            getTwoFactorStatus(enrolling.userId)
+        ]);
+        if (!user || !status) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("account no longer exists", 'ACCOUNT_NOT_FOUND'));
+        }
+        if (status.enabled) {
+            return res.status(HttpStatus.CONFLICT).json(ApiError.conflict("two-factor authentication is already enabled", 'TWO_FACTOR_ALREADY_ENABLED'));
+        }
+
+        const { secret, otpauthUrl } = await beginTotpEnrollment(user.user_id, user.email);
+        res.status(200).json({ secret, otpauth_url: otpauthUrl });
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * Confirms enrollment with a code from the authenticator and turns 2FA on.
+ * Returns ten single-use recovery codes, shown only this once. An admin
+ * enrolling mid-login also gets their tokens, completing the login.
+ *
+ * @route POST /api/auth/2fa/enable
+ * @param req.body.code - Current 6-digit code
+ * @param req.body.mfaToken - 'setup' token from login (admins), else Bearer auth
+ * @returns 200 - recovery_codes (plus the token pair when enrolling mid-login)
+ * @returns 400 - Wrong code or no enrollment pending
+ * @returns 401 - Not signed in / invalid or expired mfaToken
+ */
+export const enableTwoFactor = async (req: Request, res: Response) => {
+    const validation = twoFactorCodeSchema.safeParse(req.body);
+    if (!validation.success) {
+        return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(validation.error.issues));
+    }
+
+    try {
+        const enrolling = await getEnrollingUser(req);
+        if (!enrolling) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("sign in or provide a valid mfaToken", 'MFA_TOKEN_REQUIRED'));
+        }
+
+        const recoveryCodes = await confirmTotpEnrollment(enrolling.userId, validation.data.code);
+        if (!recoveryCodes) {
+            return res.status(HttpStatus.BAD_REQUEST).json(
+                ApiError.badRequest("code is incorrect or no setup is pending; call /api/auth/2fa/setup first")
+            );
+        }
+
+        if (enrolling.viaLogin) {
+            const user = await getUserForLogin(enrolling.userId);
+            if (!user) {
+                return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("account no longer exists", 'ACCOUNT_NOT_FOUND'));
+            }
+            return await completeLogin(req, res, user, { recovery_codes: recoveryCodes });
+        }
+
+        res.status(200).json({ success: true, recovery_codes: recoveryCodes });
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * Second login step: exchanges the mfaToken from login and a code (or a
+ * recovery code) for the token pair. Wrong codes count as failed logins, so
+ * the same delays and lockout apply.
+ *
+ * @route POST /api/auth/2fa/verify
+ * @param req.body.mfaToken - 'verify' token from login
+ * @param req.body.code - 6-digit code or a recovery code
+ * @param req.body.session - AUTH_MODE=both: true for a cookie session
+ * @returns 200 - Token pair (or session), same shape as login
+ * @returns 400 - Validation errors
+ * @returns 401 - Wrong code, or invalid/expired mfaToken
+ * @returns 429 - Too many failed attempts; see Retry-After
+ */
+export const verifyTwoFactor = async (req: Request, res: Response) => {
+    const validation = twoFactorVerifySchema.safeParse(req.body);
+    if (!validation.success) {
+        return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(validation.error.issues));
+    }
+
+    let userId: number;
+    try {
+        const claims = verifyMfaToken(validation.data.mfaToken);
+        if (claims.purpose !== 'verify') {
+            throw new Error('wrong purpose');
+        }
+        userId = claims.userId;
+    } catch {
+        return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("mfaToken invalid or expired; log in again"));
+    }
+
+    const ip = req.ip ?? null;
+
+    try {
+        const user = await getUserForLogin(userId);
+        if (!user) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("account no longer exists", 'ACCOUNT_NOT_FOUND'));
+        }
+
+        const throttle = await reserveLoginAttempt(user.email, ip);
+        if (!throttle.allowed) {
+            res.set('Retry-After', String(throttle.retryAfterSeconds));
+            return res.status(HttpStatus.TOO_MANY_REQUESTS).json(
+                throttle.reason === 'locked'
+                    ? ApiError.tooManyRequests('account temporarily locked after repeated failed logins', 'ACCOUNT_LOCKED')
+                    : ApiError.tooManyRequests('too many failed login attempts, try again later', 'LOGIN_RATE_LIMITED')
+            );
+        }
+
+        const method = await verifySecondFactor(user.user_id, validation.data.code);
+        await settleLoginAttempt(throttle.attemptId, user.user_id, method !== null);
+        if (!method) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("incorrect code", 'TWO_FACTOR_CODE_INVALID'));
+        }
+
+        if (method === 'recovery') {
+            const status = await getTwoFactorStatus(user.user_id);
+            return await completeLogin(req, res, user, { recovery_codes_remaining: status?.recoveryCodesRemaining ?? 0 });
+        }
+        await completeLogin(req, res, user);
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * Turns 2FA off for the signed-in user. Admins can't while 2FA is required
+ * for their role.
+ *
+ * @route POST /api/auth/2fa/disable
+ * @param req.body.code - Current 6-digit code or a recovery code
+ * @returns 200 - Disabled
+ * @returns 401 - Not signed in or wrong code
+ * @returns 403 - 2FA is required for this account
+ */
+export const disableTwoFactorForUser = async (req: UserRequest, res: Response) => {
+    const validation = twoFactorCodeSchema.safeParse(req.body);
+    if (!validation.success) {
+        return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(validation.error.issues));
+    }
+    const userId = req.user!.userId;
+
+    try {
+        const status = await getTwoFactorStatus(userId);
+        if (!status?.enabled) {
+            return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest("two-factor authentication is not enabled", 'TWO_FACTOR_NOT_ENABLED'));
+        }
+        if (status.required) {
+            return res.status(HttpStatus.FORBIDDEN).json(ApiError.forbidden("two-factor authentication is required for admin accounts"));
+        }
+
+        if (!await verifySecondFactor(userId, validation.data.code)) {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("incorrect code", 'TWO_FACTOR_CODE_INVALID'));
+        }
+
+        await disableTwoFactor(pool, userId);
+        res.status(200).json({ success: true, message: "two-factor authentication disabled" });
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
+};
+
+/**
+ * Replaces the signed-in user's recovery codes. Needs an authenticator code,
+ * not a recovery code, so a leaked recovery code can't mint more.
+ *
+ * @route POST /api/auth/2fa/recovery-codes
+ * @param req.body.code - Current 6-digit code
+ * @returns 200 - recovery_codes (old ones stop working)
+ * @returns 400 - 2FA not enabled
+ * @returns 401 - Not signed in or wrong code
+ */
+export const regenerateRecoveryCodes = async (req: UserRequest, res: Response) => {
+    const validation = twoFactorCodeSchema.safeParse(req.body);
+    if (!validation.success) {
+        return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(validation.error.issues));
+    }
+    const userId = req.user!.userId;
+
+    try {
+        const status = await getTwoFactorStatus(userId);
+        if (!status?.enabled) {
+            return res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest("two-factor authentication is not enabled", 'TWO_FACTOR_NOT_ENABLED'));
+        }
+
+        if (await verifySecondFactor(userId, validation.data.code, true) !== 'totp') {
+            return res.status(HttpStatus.UNAUTHORIZED).json(ApiError.unauthorized("incorrect code", 'TWO_FACTOR_CODE_INVALID'));
+        }
+
+        const recoveryCodes = await replaceRecoveryCodes(pool, userId);
+        res.status(200).json({ success: true, recovery_codes: recoveryCodes });
+    } catch (e) {
+        return res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(ApiError.internalError(e));
+    }
 };
 
 export const keyForm = async (req: Request, res: Response) => {
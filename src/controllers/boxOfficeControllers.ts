@@ -0,0 +1,160 @@
+// server/src/controllers/boxOfficeControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { refreshBoxOfficeStats } from '@utils/boxOfficeStats';
+import { SqlFilter, SqlValue } from '@utils/sqlFilter';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const timeSeriesSchema = z.object({
+  interval: z.enum(['year', 'month']).default('year'),
+  from: z.coerce.number().int().min(1870).max(2100).optional(),
+  to: z.coerce.number().int().min(1870).max(2100).optional(),
+  genre: z.string().min(1).optional(),
+  byGenre: z.stringbool().default(false)
+}).refine(
+  (q) => q.from === undefined || q.to === undefined || q.from <= q.to,
+  'from must not be after to'
+);
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * Builds the aggregate query over box_office_monthly.
+ * genre_id 0 rows are the all-genre totals.
+ */
+const buildSeriesQuery = (
+  interval: 'year' | 'month',
+  perGenre: boolean,
+  genre: string | undefined,
+  from: number | undefined,
+  to: number | undefined
+): { sql: string; params: SqlValue[] } => {
+  const filter = new SqlFilter();
+
+  if (genre) {
+    filter.add(p => `LOWER(genre_name) = LOWER(${p})`, genre);
+  } else {
+    filter.addRaw(perGenre ? 'genre_id <> 0' : 'genre_id = 0');
+  }
+  if (from !== undefined) filter.add(p => `month >= MAKE_DATE(${p}, 1, 1)`, from);
+  if (to !== undefined) filter.add(p => `month < MAKE_DATE(${p} + 1, 1, 1)`, to);
+
+  const period = interval === 'year' ? "TO_CHAR(month, 'YYYY')" : "TO_CHAR(month, 'YYYY-MM')";
+
+  const sql = `
+    SELECT
+      ${period} AS period,
+      ${perGenre ? 'genre_name,' : ''}
+      SUM(movie_count)::int AS movie_count,
+      SUM(total_revenue)::bigint AS total_revenue,
+      SUM(total_budget)::bigint AS total_budget,
+      (SUM(total_revenue) / NULLIF(SUM(revenue_count), 0))::bigint AS avg_revenue,
+      (SUM(total_budget) / NULLIF(SUM(budget_count), 0))::bigint AS avg_budget
+    FROM box_office_monthly
+    ${filter.whereSql}
+    GROUP BY period${perGenre ? ', genre_name' : ''}
+    ORDER BY period${perGenre ? ', genre_name' : ''}
+  `;
+
+  return { sql, params: filter.params };
+};
+
+// ============================================================================
+// Box Office Controllers
+// ============================================================================
+
+/**
+ * GET /api/stats/box-office
+ * Revenue and budget aggregates per year or month, for charting
+ *
+ * Served from precomputed monthly aggregates, so figures reflect the last
+ * refresh (after each import or bulk edit).
+ *
+ * Query Parameters:
+ * - interval: 'year' | 'month' (default: 'year')
+ * - from, to: Release year bounds, inclusive (optional)
+ * - genre: Restrict the series to one genre (optional)
+ * - byGenre: Also return one series per genre (default: false)
+ *
+ * @example
+ * GET /api/stats/box-office?interval=month&from=2015&to=2019
+ * GET /api/stats/box-office?byGenre=true&from=1990
+ *
+ * @returns Time series points with totals and averages
+ */
+export const getBoxOfficeTimeSeries = async (req: Request, res: Response): Promise<void> => {
+  const validation = timeSeriesSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { interval, from, to, genre, byGenre } = validation.data;
+
+  try {
+    const totals = buildSeriesQuery(interval, false, genre, from, to);
+    const perGenre = buildSeriesQuery(interval, true, genre, from, to);
+
+    const [totalsResult, genreResult] = await Promise.all([
+      pool.query(totals.sql, totals.params),
+      byGenre ? pool.query(perGenre.sql, perGenre.params) : null
+    ]);
+
+    const response: Record<string, unknown> = {
+      interval,
+      ...(from !== undefined && { from }),
+      ...(to !== undefined && { to }),
+      ...(genre && { genre }),
+      data: totalsResult.rows
+    };
+
+    if (genreResult) {
+      const series: Record<string, unknown[]> = {};
+      for (const { genre_name, ...point } of genreResult.rows) {
+        (series[genre_name] ??= []).push(point);
+      }
+      response.by_genre = series;
+    }
+
+    res.status(HttpStatus.OK).json(response);
+  } catch (error) {
+    console.error('Error fetching box office time series:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch box office time series')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/stats/box-office/refresh
+ * Rebuild the box-office aggregates now instead of waiting for the next import
+ *
+ * @returns Confirmation once the refresh has finished
+ */
+export const refreshBoxOfficeAggregates = async (req: Request, res: Response): Promise<void> => {
+  try {
+    await refreshBoxOfficeStats();
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Box office aggregates refreshed',
+      refreshed_at: new Date().toISOString()
+    });
+  } catch (error) {
+    console.error('Error refreshing box office aggregates:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to refresh box office aggregates')
+    );
+  }
+};
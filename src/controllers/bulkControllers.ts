@@ -0,0 +1,321 @@
+// server/src/controllers/bulkControllers.ts
+
+import { Response } from 'express';
+import crypto from 'crypto';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { scheduleBoxOfficeRefresh } from '@utils/boxOfficeStats';
+import { enrichMovies } from '@utils/inflation';
+import { generatePlaceholderPosters } from '@utils/placeholderPosters';
+import { sanitizeText } from '@utils/sanitize';
+import { addMovieFilters, SqlFilter, SqlValue } from '@utils/sqlFilter';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import z from 'zod';
+import { MPA_RATINGS } from './movieGetControllers';
+
+/**
+ * Rows changed per transaction. Keeps locks short on large operations.
+ */
+const BATCH_SIZE = 500;
+
+/**
+ * Number of matching movies returned in a dry-run preview
+ */
+const PREVIEW_SAMPLE_SIZE = 20;
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const dateSchema = z.iso.date();
+
+/**
+ * Which movies a bulk operation applies to. Same names as the GET /movies filters,
+ * plus year bounds. At least one condition is required so a typo can't match everything.
+ */
+const bulkFilterSchema = z.object({
+  title: z.string().min(2).optional(),
+  year: z.number().int().positive().optional(),
+  minYear: z.number().int().positive().optional(),
+  maxYear: z.number().int().positive().optional(),
+  genre: z.string().min(1).optional(),
+  rating: z.enum(MPA_RATINGS).optional(),
+  studio: z.string().min(1).optional(),
+  minBudget: z.number().int().nonnegative().optional(),
+  maxBudget: z.number().int().nonnegative().optional(),
+  minRevenue: z.number().int().nonnegative().optional(),
+  maxRevenue: z.number().int().nonnegative().optional(),
+  startDate: dateSchema.optional(),
+  endDate: dateSchema.optional()
+}).strict().refine(
+  (filter) => Object.values(filter).some(value => value !== undefined),
+  'filter must contain at least one condition'
+);
+
+/**
+ * Columns a bulk update may set. Relations and titles are deliberately excluded.
+ */
+const bulkSetSchema = z.object({
+  runtime_minutes: z.number().int().positive().nullable().optional(),
+  overview: z.string().transform(sanitizeText).nullable().optional(),
+  budget: z.number().int().nonnegative().nullable().optional(),
+  revenue: z.number().int().nonnegative().nullable().optional(),
+  mpa_rating: z.enum(MPA_RATINGS).nullable().optional(),
+  adult: z.boolean().optional(),
+  poster_url: z.string().max(500).nullable().optional(),
+  backdrop_url: z.string().max(500).nullable().optional()
+}).strict().refine(
+  (set) => Object.keys(set).length > 0,
+  'set must contain at least one column'
+);
+
+const bulkDeleteSchema = z.object({
+  filter: bulkFilterSchema,
+  dryRun: z.boolean().default(true),
+  previewToken: z.string().optional()
+});
+
+const bulkUpdateSchema = bulkDeleteSchema.extend({
+  set: bulkSetSchema
+});
+
+type BulkFilter = z.infer<typeof bulkFilterSchema>;
+type BulkSet = z.infer<typeof bulkSetSchema>;
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * Builds the WHERE clause for a bulk filter
+ */
+const buildFilterWhere = (filter: BulkFilter): { clause: string; params: SqlValue[] } => {
+  const sqlFilter = addMovieFilters(new SqlFilter(), filter);
+  return { clause: sqlFilter.conditionSql, params: sqlFilter.params };
+};
+
+/**
+ * Token tying an execution to the exact preview the caller saw.
+ * Changes if the operation, the new values or the set of matching movies changes.
+ */
+const getPreviewToken = (action: string, movieIds: number[], set?: BulkSet): string =>
+  crypto
+    .createHash('sha256')
+    .update(JSON.stringify({ action, set: set ?? null, movieIds }))
+    .digest('hex');
+
+/**
+ * Shared flow for bulk delete/update.
+ *
+ * With dryRun (the default) nothing is changed: the response lists how many
+ * movies match, a sample of them and a previewToken. Repeating the request with
+ * dryRun: false and that token runs the operation in batches of BATCH_SIZE,
+ * each in its own transaction and audited. If the matching movies changed in
+ * between, the token no longer matches and the caller must preview again.
+ */
+const runBulkOperation = async (
+  req: ApiKeyRequest,
+  res: Response,
+  action: 'delete' | 'update',
+  filter: BulkFilter,
+  dryRun: boolean,
+  previewToken: string | undefined,
+  set?: BulkSet
+): Promise<void> => {
+  const { clause, params } = buildFilterWhere(filter);
+
+  const matchResult = await pool.query<{ movie_id: number }>(
+    `SELECT m.movie_id FROM movies m WHERE ${clause} ORDER BY m.movie_id`,
+    params
+  );
+  const movieIds = matchResult.rows.map(row => row.movie_id);
+  const token = getPreviewToken(action, movieIds, set);
+
+  if (dryRun) {
+    const sampleResult = await pool.query(
+      `SELECT movie_id, title, release_date, budget::int8, revenue::int8, mpa_rating
+       FROM movies
+       WHERE movie_id = ANY($1::int[])
+       ORDER BY movie_id
+       LIMIT ${PREVIEW_SAMPLE_SIZE}`,
+      [movieIds]
+    );
+
+    res.status(HttpStatus.OK).json({
+      dry_run: true,
+      action,
+      matched: movieIds.length,
+      batches: Math.ceil(movieIds.length / BATCH_SIZE),
+      sample: sampleResult.rows,
+      set: set ?? undefined,
+      previewToken: token
+    });
+    return;
+  }
+
+  if (!previewToken) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('previewToken from a dry run is required to execute a bulk operation')
+    );
+    return;
+  }
+
+  if (previewToken !== token) {
+    res.status(HttpStatus.CONFLICT).json(
+      ApiError.createResponse(
+        HttpStatus.CONFLICT,
+        'Matching movies changed since the preview; run the dry run again'
+      )
+    );
+    return;
+  }
+
+  const assignments: string[] = [];
+  const setValues: (string | number | null)[] = [];
+  for (const [column, value] of Object.entries(set ?? {})) {
+    if (value === undefined) continue;
+    setValues.push(value);
+    assignments.push(`${column} = $${setValues.length + 1}`);
+  }
+  assignments.push('version = version + 1', 'updated_at = NOW()');
+
+  let affected = 0;
+  let batchesCompleted = 0;
+
+  for (let start = 0; start < movieIds.length; start += BATCH_SIZE) {
+    const batch = movieIds.slice(start, start + BATCH_SIZE);
+    const client = await pool.connect();
+
+    try {
+      await client.query('BEGIN');
+
+      const result = action === 'delete'
+        ? await client.query(
+          'DELETE FROM movies WHERE movie_id = ANY($1::int[]) RETURNING movie_id, title',
+          [batch]
+        )
+        : await client.query(
+          `UPDATE movies SET ${assignments.join(', ')} WHERE movie_id = ANY($1::int[]) RETURNING movie_id, title`,
+          [batch, ...setValues]
+        );
+      if (action === 'update') {
+        await enrichMovies(client, batch);
+        await generatePlaceholderPosters(client, batch);
+      }
+
+      await recordAudit(client, {
+        action: `movie.bulk_${action}`,
+        entity_type: 'movie',
+        entity_id: null,
+        details: {
+          filter,
+          set,
+          batch: batchesCompleted + 1,
+          movies: result.rows
+        },
+        performed_by: req.apiKey?.api_key_id
+      });
+
+      await client.query('COMMIT');
+
+      affected += result.rowCount ?? 0;
+      batchesCompleted++;
+    } catch (error) {
+      await client.query('ROLLBACK');
+      console.error(`Error in bulk ${action} batch:`, error);
+      res.status(HttpStatus.INTERNAL_SERVER_ERROR).json({
+        ...ApiError.internalError(`Bulk ${action} failed`),
+        affected,
+        batches_completed: batchesCompleted
+      });
+      return;
+    } finally {
+      client.release();
+    }
+  }
+
+  if (affected > 0) {
+    scheduleBoxOfficeRefresh();
+  }
+
+  res.status(HttpStatus.OK).json({
+    dry_run: false,
+    action,
+    matched: movieIds.length,
+    affected,
+    batches_completed: batchesCompleted
+  });
+};
+
+// ============================================================================
+// Bulk Controllers
+// ============================================================================
+
+/**
+ * POST /api/admin/movies/bulk-delete
+ * Delete every movie matching a filter
+ *
+ * Body: { filter, dryRun = true, previewToken? }
+ * Run once as a dry run to see what matches, then again with dryRun: false
+ * and the returned previewToken to delete.
+ *
+ * @example
+ * { "filter": { "maxRevenue": 0, "maxYear": 1989 } }
+ *
+ * @returns Preview (matched count, sample, previewToken) or the number deleted
+ */
+export const bulkDeleteMovies = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = bulkDeleteSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { filter, dryRun, previewToken } = validation.data;
+
+  try {
+    await runBulkOperation(req, res, 'delete', filter, dryRun, previewToken);
+  } catch (error) {
+    console.error('Error in bulk delete:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to run bulk delete')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/movies/bulk-update
+ * Set columns on every movie matching a filter
+ *
+ * Body: { filter, set, dryRun = true, previewToken? }
+ * Same preview-then-execute flow as bulk delete.
+ *
+ * @example
+ * { "filter": { "rating": "NC-17", "maxYear": 1995 }, "set": { "poster_url": null } }
+ *
+ * @returns Preview (matched count, sample, previewToken) or the number updated
+ */
+export const bulkUpdateMovies = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = bulkUpdateSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { filter, set, dryRun, previewToken } = validation.data;
+
+  try {
+    await runBulkOperation(req, res, 'update', filter, dryRun, previewToken, set);
+  } catch (error) {
+    console.error('Error in bulk update:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to run bulk update')
+    );
+  }
+};
@@ -0,0 +1,296 @@
+// server/src/controllers/calendarControllers.ts
+
+import crypto from 'crypto';
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { nonAdultCondition, shouldHideAdultContent } from '@utils/contentFilter';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { UserRequest } from '@middleware/userAuth';
+import z from 'zod';
+import { contentFilterSchema } from './movieGetControllers';
+
+/**
+ * Most events in one feed
+ */
+const MAX_CALENDAR_EVENTS = 1000;
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const upcomingCalendarSchema = contentFilterSchema.extend({
+  genre: z.string().trim().min(1).max(100).optional(),
+  days: z.coerce.number().int().min(1).max(730).default(365),
+  feed: z.string().min(1).max(64).optional()
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+interface ReleaseRow {
+  movie_id: number;
+  public_id: string | null;
+  title: string;
+  release_date: string; // YYYY-MM-DD
+  overview: string | null;
+  genres: string | null;
+  updated_at: Date;
+}
+
+/**
+ * Escapes TEXT values (RFC 5545 3.3.11)
+ */
+const escapeIcalText = (text: string): string =>
+  text
+    .replace(/\\/g, '\\\\')
+    .replace(/;/g, '\\;')
+    .replace(/,/g, '\\,')
+    .replace(/\r?\n/g, '\\n');
+
+/**
+ * Folds a content line to 75 octets, continuing on lines starting with a space
+ */
+const foldIcalLine = (line: string): string => {
+  const parts: string[] = [];
+  let current = '';
+  for (const char of line) {
+    const limit = parts.length === 0 ? 75 : 74;
+    if (Buffer.byteLength(current + char) > limit) {
+      parts.push(current);
+      current = '';
+    }
+    current += char;
+  }
+  parts.push(current);
+  return parts.join('\r\n ');
+};
+
+const icalDate = (date: Date): string => date.toISOString().slice(0, 10).replace(/-/g, '');
+
+const icalTimestamp = (date: Date): string => date.toISOString().replace(/[-:]/g, '').replace(/\.\d{3}/, '');
+
+/**
+ * One all-day event per movie on its release date
+ */
+const buildCalendar = (name: string, movies: ReleaseRow[]): string => {
+  const lines = [
+    'BEGIN:VCALENDAR',
+    'VERSION:2.0',
+    'PRODID:-//TCSS 460 Movie API//Upcoming Releases//EN',
+    'CALSCALE:GREGORIAN',
+    'METHOD:PUBLISH',
+    `X-WR-CALNAME:${escapeIcalText(name)}`,
+    'REFRESH-INTERVAL;VALUE=DURATION:PT12H',
+    'X-PUBLISHED-TTL:PT12H'
+  ];
+
+  for (const movie of movies) {
+    const releaseDate = new Date(`${movie.release_date}T00:00:00Z`);
+    const nextDay = new Date(releaseDate.getTime() + 24 * 60 * 60 * 1000);
+    const description = [
+      movie.genres,
+      movie.overview && movie.overview.length > 500 ? `${movie.overview.slice(0, 497)}...` : movie.overview
+    ].filter(Boolean).join('\n\n');
+
+    lines.push(
+      'BEGIN:VEVENT',
+      `UID:movie-${movie.public_id ?? movie.movie_id}@tcss460-api`,
+      `DTSTAMP:${icalTimestamp(new Date(movie.updated_at))}`,
+      `DTSTART;VALUE=DATE:${icalDate(releaseDate)}`,
+      `DTEND;VALUE=DATE:${icalDate(nextDay)}`,
+      `SUMMARY:${escapeIcalText(movie.title)}`,
+      ...(description ? [`DESCRIPTION:${escapeIcalText(description)}`] : []),
+      'TRANSP:TRANSPARENT',
+      'END:VEVENT'
+    );
+  }
+
+  lines.push('END:VCALENDAR');
+  return lines.map(foldIcalLine).join('\r\n') + '\r\n';
+};
+
+/**
+ * Feed URL for a token; PUBLIC_BASE_URL overrides the request's host
+ * behind a proxy
+ */
+const calendarFeedUrl = (req: Request, token: string): string =>
+  `${process.env.PUBLIC_BASE_URL?.replace(/\/+$/, '') ?? `${req.protocol}://${req.get('host')}`}/api/releases/upcoming.ics?feed=${token}`;
+
+// ============================================================================
+// Calendar Controllers
+// ============================================================================
+
+/**
+ * GET /api/releases/upcoming.ics
+ * iCalendar feed of movies releasing from today on, one all-day event each
+ *
+ * Needs no API key, so calendar apps can subscribe to it.
+ *
+ * Query Parameters:
+ * - genre: Only this genre (case-insensitive)
+ * - days: How far ahead to look (default: 365, max: 730)
+ * - feed: Personal token from POST /me/calendar; limits the feed to that user's watchlist
+ * - include_adult: Include adult titles where the server allows it (default: false)
+ *
+ * @returns text/calendar
+ */
+export const getUpcomingReleasesCalendar = async (req: Request, res: Response): Promise<void> => {
+  const validation = upcomingCalendarSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { genre, days, feed, include_adult } = validation.data;
+
+  try {
+    let watchlistUserId: number | null = null;
+    if (feed) {
+      const owner = await pool.query<{ user_id: number }>(
+        'SELECT user_id FROM calendar_feeds WHERE token = $1',
+        [feed]
+      );
+      if (owner.rows.length === 0) {
+        res.status(HttpStatus.NOT_FOUND).json(
+          ApiError.notFound('Calendar feed not found', 'CALENDAR_FEED_NOT_FOUND')
+        );
+        return;
+      }
+      watchlistUserId = owner.rows[0].user_id;
+    }
+
+    const result = await pool.query<ReleaseRow>(
+      `SELECT m.movie_id, m.public_id, m.title, m.release_date, m.overview, m.updated_at,
+              (SELECT STRING_AGG(g.genre_name, ', ' ORDER BY g.genre_name)
+               FROM movie_genres mg
+               JOIN genres g ON g.genre_id = mg.genre_id
+               WHERE mg.movie_id = m.movie_id) AS genres
+       FROM movies m
+       WHERE m.release_date >= CURRENT_DATE
+         AND m.release_date < CURRENT_DATE + $1::int
+         AND ($2::text IS NULL OR EXISTS (
+           SELECT 1 FROM movie_genres mg2
+           JOIN genres g2 ON mg2.genre_id = g2.genre_id
+           WHERE mg2.movie_id = m.movie_id AND LOWER(g2.genre_name) = LOWER($2)
+         ))
+         AND ($3::int IS NULL OR EXISTS (
+           SELECT 1 FROM watchlist w WHERE w.movie_id = m.movie_id AND w.user_id = $3
+         ))
+         ${shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition('m')}` : ''}
+       ORDER BY m.release_date, m.title
+       LIMIT $4`,
+      [days, genre ?? null, watchlistUserId, MAX_CALENDAR_EVENTS]
+    );
+
+    const name = [
+      watchlistUserId !== null ? 'My watchlist' : null,
+      genre ? `${genre} releases` : 'Upcoming releases'
+    ].filter(Boolean).join(': ');
+
+    res
+      .status(HttpStatus.OK)
+      .type('text/calendar; charset=utf-8')
+      .set('Content-Disposition', 'inline; filename="upcoming-releases.ics"')
+      .send(buildCalendar(name, result.rows));
+  } catch (error) {
+    console.error('Error building release calendar:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to build release calendar')
+    );
+  }
+};
+
+/**
+ * GET /api/me/calendar
+ * The signed-in user's personal calendar feed, if one was created
+ *
+ * @returns feed_url (null when there is none) and when it was created
+ */
+export const getMyCalendarFeed = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query<{ token: string; created_at: Date }>(
+      'SELECT token, created_at FROM calendar_feeds WHERE user_id = $1',
+      [req.user!.userId]
+    );
+    const feed = result.rows[0];
+
+    res.status(HttpStatus.OK).json({
+      feed_url: feed ? calendarFeedUrl(req, feed.token) : null,
+      created_at: feed?.created_at ?? null
+    });
+  } catch (error) {
+    console.error('Error fetching calendar feed:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch calendar feed')
+    );
+  }
+};
+
+/**
+ * POST /api/me/calendar
+ * Create a personal feed of upcoming releases on the signed-in user's
+ * watchlist, replacing any earlier one (its URL stops working)
+ *
+ * The URL is the only credential; add ?genre= to narrow it further.
+ *
+ * @returns feed_url to subscribe to in a calendar app
+ */
+export const createMyCalendarFeed = async (req: UserRequest, res: Response): Promise<void> => {
+  const token = crypto.randomBytes(24).toString('base64url');
+
+  try {
+    const result = await pool.query<{ created_at: Date }>(
+      `INSERT INTO calendar_feeds (user_id, token) VALUES ($1, $2)
+       ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = NOW()
+       RETURNING created_at`,
+      [req.user!.userId, token]
+    );
+
+    res.status(HttpStatus.CREATED).json({
+      success: true,
+      feed_url: calendarFeedUrl(req, token),
+      created_at: result.rows[0].created_at
+    });
+  } catch (error) {
+    console.error('Error creating calendar feed:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to create calendar feed')
+    );
+  }
+};
+
+/**
+ * DELETE /api/me/calendar
+ * Turn off the signed-in user's personal calendar feed
+ *
+ * @returns Confirmation
+ */
+export const deleteMyCalendarFeed = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query(
+      'DELETE FROM calendar_feeds WHERE user_id = $1',
+      [req.user!.userId]
+    );
+
+    if (result.rowCount === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Calendar feed not found', 'CALENDAR_FEED_NOT_FOUND')
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Calendar feed deleted'
+    });
+  } catch (error) {
+    console.error('Error deleting calendar feed:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to delete calendar feed')
+    );
+  }
+};
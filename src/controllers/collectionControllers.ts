@@ -186,7 +186,7 @@ export const getCollectionById = async (req: Request, res: Response): Promise<vo
 
   if (isNaN(collectionId)) {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Collection ID must be a valid number')
+      ApiError.badRequest('Collection ID must be a valid number', 'INVALID_ID')
     );
     return;
   }
@@ -203,6 +203,8 @@ export const getCollectionById = async (req: Request, res: Response): Promise<vo
         SUM(m.revenue)::bigint AS total_revenue,
         SUM(m.budget)::bigint AS total_budget,
         AVG(m.revenue)::bigint AS avg_revenue,
+        ROUND(AVG(m.runtime_minutes))::int AS avg_runtime_minutes,
+        SUM(m.runtime_minutes)::int AS total_runtime_minutes,
         MIN(m.release_date) AS first_movie_date,
         MAX(m.release_date) AS latest_movie_date
       FROM collections c
@@ -215,7 +217,7 @@ export const getCollectionById = async (req: Request, res: Response): Promise<vo
 
     if (result.rows.length === 0) {
       res.status(HttpStatus.NOT_FOUND).json(
-        ApiError.notFound(`Collection with ID ${collectionId} not found`)
+        ApiError.notFound(`Collection with ID ${collectionId} not found`, 'COLLECTION_NOT_FOUND', { id: collectionId })
       );
       return;
     }
@@ -243,7 +245,7 @@ export const searchCollections = async (req: Request, res: Response): Promise<vo
 
   if (!name || typeof name !== 'string') {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Name query parameter is required')
+      ApiError.badRequest('Name query parameter is required', 'NAME_REQUIRED')
     );
     return;
   }
@@ -374,7 +376,7 @@ export const getCollectionTimeline = async (req: Request, res: Response): Promis
 
   if (isNaN(collectionId)) {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Collection ID must be a valid number')
+      ApiError.badRequest('Collection ID must be a valid number', 'INVALID_ID')
     );
     return;
   }
@@ -383,6 +385,7 @@ export const getCollectionTimeline = async (req: Request, res: Response): Promis
     const sql = `
       SELECT 
         m.movie_id,
+        m.public_id,
         m.title,
         m.release_date,
         m.runtime_minutes,
@@ -0,0 +1,127 @@
+// server/src/controllers/datasetControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { getDatasetStore } from '@utils/datasets';
+import { getApiBaseUrl } from '@utils/publicUrls';
+
+// ============================================================================
+// Dataset Controllers
+// ============================================================================
+
+interface DatasetFileRow {
+  version: string;
+  format: string;
+  file_name: string;
+  byte_size: number;
+  sha256: string;
+  movie_count: number;
+  base_version: string | null;
+  change_count: number | null;
+  created_at: Date;
+}
+
+/**
+ * GET /api/datasets
+ * Published catalog snapshots, newest first, for bootstrapping another
+ * system from our data
+ *
+ * Each snapshot has a gzipped JSONL file (full records, as GET
+ * /api/export/movies) and a gzipped CSV (without cast and translations).
+ * From the second snapshot on there's also a diff file with the adds,
+ * updates and deletes since base_version, so a consumer that has one
+ * snapshot can apply the following diffs in order instead of downloading
+ * everything again. Check each download against its sha256.
+ *
+ * @returns Snapshots with their files
+ */
+export const listDatasets = async (req: Request, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query<DatasetFileRow>(
+      `SELECT TO_CHAR(version, 'YYYY-MM-DD') AS version, format, file_name,
+              byte_size::int8, sha256, movie_count,
+              TO_CHAR(base_version, 'YYYY-MM-DD') AS base_version, change_count, created_at
+       FROM dataset_snapshots
+       ORDER BY version DESC, format DESC`
+    );
+
+    const snapshots = new Map<string, { version: string; movie_count: number; created_at: Date; files: object[] }>();
+    for (const row of result.rows) {
+      const snapshot = snapshots.get(row.version)
+        ?? { version: row.version, movie_count: row.movie_count, created_at: row.created_at, files: [] };
+      snapshot.files.push({
+        format: row.format,
+        file_name: row.file_name,
+        byte_size: Number(row.byte_size),
+        sha256: row.sha256,
+        ...(row.format === 'diff' ? { base_version: row.base_version, change_count: row.change_count } : {}),
+        url: `${getApiBaseUrl(req)}/api/datasets/${row.file_name}`
+      });
+      snapshots.set(row.version, snapshot);
+    }
+
+    res.status(HttpStatus.OK).json({ data: [...snapshots.values()] });
+  } catch (error) {
+    console.error('Error listing datasets:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to list datasets')
+    );
+  }
+};
+
+/**
+ * GET /api/datasets/:file
+ * Downloads one snapshot file
+ *
+ * Files never change once published, so responses are cacheable and the
+ * checksum doubles as the ETag.
+ *
+ * @param file - File name from GET /api/datasets, e.g. movies-2024-11-01.jsonl.gz
+ * @returns The gzipped file
+ */
+export const downloadDataset = async (req: Request, res: Response): Promise<void> => {
+  const { file } = req.params;
+
+  try {
+    const result = await pool.query<{ store: string; storage_key: string; sha256: string }>(
+      'SELECT store, storage_key, sha256 FROM dataset_snapshots WHERE file_name = $1',
+      [file]
+    );
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Dataset file ${file} not found`, 'DATASET_NOT_FOUND', { file })
+      );
+      return;
+    }
+
+    const { store, storage_key, sha256 } = result.rows[0];
+    const etag = `"${sha256}"`;
+    if (req.get('If-None-Match') === etag) {
+      res.status(HttpStatus.NOT_MODIFIED).end();
+      return;
+    }
+
+    const data = await getDatasetStore(store).get(storage_key);
+    if (!data) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Dataset file ${file} not found`, 'DATASET_NOT_FOUND', { file })
+      );
+      return;
+    }
+
+    res
+      .status(HttpStatus.OK)
+      .type('application/gzip')
+      .set('Content-Disposition', `attachment; filename="${file}"`)
+      .set('Cache-Control', 'public, max-age=31536000, immutable')
+      .set('ETag', etag)
+      .send(data);
+  } catch (error) {
+    console.error('Error downloading dataset:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to download dataset')
+    );
+  }
+};
@@ -0,0 +1,162 @@
+// server/src/controllers/deviceControllers.ts
+
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { isPushPlatformConfigured } from '@utils/push';
+import { UserRequest } from '@middleware/userAuth';
+import z from 'zod';
+
+/**
+ * Devices one user can register (phones, tablets, browsers)
+ */
+const MAX_DEVICES_PER_USER = 20;
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const deviceSchema = z.object({
+  platform: z.enum(['fcm', 'apns']),
+  token: z.string().trim().min(1).max(4096)
+});
+
+// ============================================================================
+// Device Controllers
+// ============================================================================
+
+/**
+ * GET /api/me/devices
+ * Devices registered for push notifications by the signed-in user
+ *
+ * Tokens aren't returned; apps unregister by device_id.
+ *
+ * @returns Devices, most recently registered first
+ */
+export const getMyDevices = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query(
+      `SELECT device_id, platform, created_at, last_registered_at
+       FROM device_tokens
+       WHERE user_id = $1
+       ORDER BY last_registered_at DESC`,
+      [req.user!.userId]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows,
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching devices:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch devices')
+    );
+  }
+};
+
+/**
+ * POST /api/me/devices
+ * Register a device for push notifications about watchlisted movies
+ *
+ * Body: { platform: 'fcm' | 'apns', token: string }
+ * Call on every app start; a token already registered (by anyone) moves to
+ * the signed-in user, since it belongs to whoever uses the app now.
+ *
+ * @returns The device, with created true when the token is new
+ */
+export const registerDevice = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = deviceSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { platform, token } = validation.data;
+  const userId = req.user!.userId;
+
+  if (!isPushPlatformConfigured(platform)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(`Push notifications through ${platform} are not configured on this server`, 'PUSH_NOT_CONFIGURED', { platform })
+    );
+    return;
+  }
+
+  try {
+    const count = await pool.query<{ total: number }>(
+      'SELECT COUNT(*)::int AS total FROM device_tokens WHERE user_id = $1 AND token <> $2',
+      [userId, token]
+    );
+    if (count.rows[0].total >= MAX_DEVICES_PER_USER) {
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest(`You can register at most ${MAX_DEVICES_PER_USER} devices`, 'DEVICE_LIMIT', { max: MAX_DEVICES_PER_USER })
+      );
+      return;
+    }
+
+    const result = await pool.query(
+      `INSERT INTO device_tokens (user_id, platform, token)
+       VALUES ($1, $2, $3)
+       ON CONFLICT (token) DO UPDATE
+       SET user_id = EXCLUDED.user_id, platform = EXCLUDED.platform, last_registered_at = NOW()
+       RETURNING device_id, platform, created_at, last_registered_at, (xmax = 0) AS created`,
+      [userId, platform, token]
+    );
+    const { created, ...device } = result.rows[0];
+
+    res.status(created ? HttpStatus.CREATED : HttpStatus.OK).json({
+      success: true,
+      created,
+      data: device
+    });
+  } catch (error) {
+    console.error('Error registering device:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to register device')
+    );
+  }
+};
+
+/**
+ * DELETE /api/me/devices/:deviceId
+ * Stop push notifications to a device (e.g. on sign-out)
+ *
+ * @param deviceId - Device ID from registration
+ * @returns Confirmation
+ */
+export const unregisterDevice = async (req: UserRequest, res: Response): Promise<void> => {
+  const deviceId = parseInt(req.params.deviceId, 10);
+  if (isNaN(deviceId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Device ID must be a valid number', 'INVALID_ID')
+    );
+    return;
+  }
+
+  try {
+    const result = await pool.query(
+      'DELETE FROM device_tokens WHERE device_id = $1 AND user_id = $2',
+      [deviceId, req.user!.userId]
+    );
+
+    if (result.rowCount === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Device with ID ${deviceId} not found`, 'DEVICE_NOT_FOUND', { id: deviceId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Device unregistered'
+    });
+  } catch (error) {
+    console.error('Error unregistering device:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to unregister device')
+    );
+  }
+};
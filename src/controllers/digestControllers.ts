@@ -0,0 +1,277 @@
+// server/src/controllers/digestControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { createUnsubscribeToken } from '@utils/digest';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { UserRequest } from '@middleware/userAuth';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const favoriteGenresSchema = z.object({
+  genres: z.array(z.string().trim().min(1).max(100)).max(50)
+});
+
+const digestSettingsSchema = z.object({
+  frequency: z.enum(['daily', 'weekly']).default('weekly')
+});
+
+const unsubscribeSchema = z.object({
+  token: z.string().min(1).max(64)
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+const getFavoriteGenreNames = async (userId: number): Promise<string[]> => {
+  const result = await pool.query<{ genre_name: string }>(
+    `SELECT g.genre_name
+     FROM user_favorite_genres f
+     JOIN genres g ON g.genre_id = f.genre_id
+     WHERE f.user_id = $1
+     ORDER BY g.genre_name`,
+    [userId]
+  );
+  return result.rows.map(row => row.genre_name);
+};
+
+// ============================================================================
+// Favorite Genre Controllers
+// ============================================================================
+
+/**
+ * GET /api/me/genres
+ * The signed-in user's favorite genres
+ *
+ * @returns Genre names
+ */
+export const getFavoriteGenres = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    res.status(HttpStatus.OK).json({
+      genres: await getFavoriteGenreNames(req.user!.userId)
+    });
+  } catch (error) {
+    console.error('Error fetching favorite genres:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch favorite genres')
+    );
+  }
+};
+
+/**
+ * PUT /api/me/genres
+ * Replace the signed-in user's favorite genres
+ *
+ * Body: { genres: string[] } - Genre names (case-insensitive); empty clears them
+ *
+ * @returns The saved genre names
+ */
+export const setFavoriteGenres = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = favoriteGenresSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const names = [...new Set(validation.data.genres.map(name => name.toLowerCase()))];
+  const userId = req.user!.userId;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const genres = await client.query<{ genre_id: number; genre_name: string }>(
+      'SELECT genre_id, genre_name FROM genres WHERE LOWER(genre_name) = ANY($1::text[])',
+      [names]
+    );
+    const found = new Set(genres.rows.map(row => row.genre_name.toLowerCase()));
+    const unknown = validation.data.genres.filter(name => !found.has(name.toLowerCase()));
+
+    if (unknown.length > 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest(`Unknown genre: ${unknown.join(', ')}`, 'UNKNOWN_GENRE', { genres: unknown.join(', ') })
+      );
+      return;
+    }
+
+    await client.query('DELETE FROM user_favorite_genres WHERE user_id = $1', [userId]);
+    await client.query(
+      `INSERT INTO user_favorite_genres (user_id, genre_id)
+       SELECT $1, UNNEST($2::int[])`,
+      [userId, genres.rows.map(row => row.genre_id)]
+    );
+
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      genres: genres.rows.map(row => row.genre_name).sort()
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error saving favorite genres:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to save favorite genres')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+// ============================================================================
+// Email Digest Controllers
+// ============================================================================
+
+/**
+ * GET /api/me/digest
+ * The signed-in user's new-releases digest subscription
+ *
+ * @returns subscribed, frequency, the genres it covers and when it last went out
+ */
+export const getDigestSubscription = async (req: UserRequest, res: Response): Promise<void> => {
+  const userId = req.user!.userId;
+
+  try {
+    const result = await pool.query(
+      'SELECT frequency, subscribed_at, last_sent_at FROM digest_subscriptions WHERE user_id = $1',
+      [userId]
+    );
+    const subscription = result.rows[0];
+
+    res.status(HttpStatus.OK).json({
+      subscribed: Boolean(subscription),
+      frequency: subscription?.frequency ?? null,
+      subscribed_at: subscription?.subscribed_at ?? null,
+      last_sent_at: subscription?.last_sent_at ?? null,
+      genres: await getFavoriteGenreNames(userId)
+    });
+  } catch (error) {
+    console.error('Error fetching digest subscription:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch digest subscription')
+    );
+  }
+};
+
+/**
+ * PUT /api/me/digest
+ * Subscribe to the new-releases digest, or change how often it comes
+ *
+ * Body: { frequency?: 'daily' | 'weekly' (default) }
+ * The digest lists movies added since the last one (since subscribing, for
+ * the first) in the user's favorite genres (PUT /me/genres); no email is
+ * sent when nothing matched.
+ *
+ * @returns The subscription
+ */
+export const subscribeToDigest = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = digestSettingsSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const userId = req.user!.userId;
+
+  try {
+    const result = await pool.query(
+      `INSERT INTO digest_subscriptions (user_id, frequency, unsubscribe_token)
+       VALUES ($1, $2, $3)
+       ON CONFLICT (user_id) DO UPDATE SET frequency = EXCLUDED.frequency
+       RETURNING frequency, subscribed_at, last_sent_at`,
+      [userId, validation.data.frequency, createUnsubscribeToken()]
+    );
+    const genres = await getFavoriteGenreNames(userId);
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      subscribed: true,
+      ...result.rows[0],
+      genres,
+      ...(genres.length === 0 ? { warning: 'Set favorite genres with PUT /api/me/genres to receive digests' } : {})
+    });
+  } catch (error) {
+    console.error('Error subscribing to digest:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update digest subscription')
+    );
+  }
+};
+
+/**
+ * DELETE /api/me/digest
+ * Stop the new-releases digest
+ *
+ * @returns Confirmation
+ */
+export const unsubscribeFromDigest = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query(
+      'DELETE FROM digest_subscriptions WHERE user_id = $1',
+      [req.user!.userId]
+    );
+
+    if (result.rowCount === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('You are not subscribed to the digest', 'NOT_SUBSCRIBED')
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Unsubscribed from the digest'
+    });
+  } catch (error) {
+    console.error('Error unsubscribing from digest:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update digest subscription')
+    );
+  }
+};
+
+/**
+ * GET|POST /api/digest/unsubscribe?token=...
+ * Unsubscribe from the link in a digest email, without signing in
+ *
+ * POST is the one-click unsubscribe mail clients send (List-Unsubscribe-Post).
+ * Unsubscribing twice succeeds, so a repeated click isn't an error.
+ *
+ * @returns Confirmation
+ */
+export const unsubscribeByToken = async (req: Request, res: Response): Promise<void> => {
+  const validation = unsubscribeSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Unsubscribe token is required', 'UNSUBSCRIBE_TOKEN_REQUIRED')
+    );
+    return;
+  }
+
+  try {
+    await pool.query(
+      'DELETE FROM digest_subscriptions WHERE unsubscribe_token = $1',
+      [validation.data.token]
+    );
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Unsubscribed from the digest'
+    });
+  } catch (error) {
+    console.error('Error unsubscribing from digest:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update digest subscription')
+    );
+  }
+};
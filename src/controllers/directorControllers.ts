@@ -172,7 +172,7 @@ export const getDirectorById = async (req: Request, res: Response): Promise<void
 
     if (isNaN(directorId)) {
         res.status(HttpStatus.BAD_REQUEST).json(
-            ApiError.badRequest('Director ID must be a valid number')
+            ApiError.badRequest('Director ID must be a valid number', 'INVALID_ID')
         );
         return;
     }
@@ -199,7 +199,7 @@ export const getDirectorById = async (req: Request, res: Response): Promise<void
 
         if (result.rows.length === 0) {
             res.status(HttpStatus.NOT_FOUND).json(
-                ApiError.notFound(`Director with ID ${directorId} not found`)
+                ApiError.notFound(`Director with ID ${directorId} not found`, 'DIRECTOR_NOT_FOUND', { id: directorId })
             );
             return;
         }
@@ -227,7 +227,7 @@ export const searchDirectors = async (req: Request, res: Response): Promise<void
 
     if (!name || typeof name !== 'string') {
         res.status(HttpStatus.BAD_REQUEST).json(
-            ApiError.badRequest('Name query parameter is required')
+            ApiError.badRequest('Name query parameter is required', 'NAME_REQUIRED')
         );
         return;
     }
@@ -0,0 +1,639 @@
+// server/src/controllers/duplicateControllers.ts
+
+import { Response } from 'express';
+import { PoolClient } from 'pg';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { enrichMovies } from '@utils/inflation';
+import { generatePlaceholderPosters } from '@utils/placeholderPosters';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const scanSchema = z.object({
+  maxYearGap: z.coerce.number().int().min(0).max(100).default(1)
+});
+
+const listFlagsSchema = z.object({
+  status: z.enum(['pending', 'dismissed']).default('pending'),
+  page: z.coerce.number().int().positive().default(1),
+  limit: z.coerce.number().int().min(1).max(100).default(20)
+});
+
+const updateFlagSchema = z.object({
+  status: z.enum(['pending', 'dismissed'])
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * Title normalized for duplicate detection: case and accents folded by
+ * name_key() (migration 050), then spaces and punctuation dropped. Letters
+ * outside ASCII are kept, so "Amélie" matches "Amelie" and titles written
+ * only in other scripts don't all normalize to ''.
+ */
+const normalizedTitle = (alias: string) =>
+  `regexp_replace(name_key(${alias}.title), '[[:space:][:punct:]]', '', 'g')`;
+
+/**
+ * Junction tables whose links are simply unioned when merging movies
+ */
+const SIMPLE_LINK_TABLES = [
+  { table: 'movie_genres', column: 'genre_id' },
+  { table: 'movie_directors', column: 'director_id' },
+  { table: 'movie_producers', column: 'producer_id' },
+  { table: 'movie_writers', column: 'writer_id' },
+  { table: 'movie_composers', column: 'composer_id' },
+  { table: 'movie_cinematographers', column: 'cinematographer_id' },
+  { table: 'movie_editors', column: 'editor_id' },
+  { table: 'movie_keywords', column: 'keyword_id' },
+  { table: 'movie_languages', column: 'language_id' },
+  { table: 'movie_countries', column: 'country_id' },
+  { table: 'movie_studios', column: 'studio_id' }
+] as const;
+
+/**
+ * Scores how complete a movie record is: filled columns plus related links.
+ * Used to decide which record survives an automatic merge.
+ */
+const getRichness = async (client: PoolClient, movieIds: number[]): Promise<Map<number, number>> => {
+  const result = await client.query<{ movie_id: number; richness: number }>(`
+    SELECT
+      m.movie_id,
+      (
+        num_nonnulls(
+          m.original_title, m.release_date, m.runtime_minutes, m.overview,
+          NULLIF(m.budget, 0), NULLIF(m.revenue, 0), m.mpa_rating,
+          m.collection_id, m.poster_url, m.backdrop_url
+        )
+        + (SELECT COUNT(*) FROM movie_genres WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_directors WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_producers WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_writers WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_composers WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_cinematographers WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_editors WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_keywords WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_languages WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_countries WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_studios WHERE movie_id = m.movie_id)
+        + (SELECT COUNT(*) FROM movie_actors WHERE movie_id = m.movie_id)
+      )::int AS richness
+    FROM movies m
+    WHERE m.movie_id = ANY($1::int[])
+  `, [movieIds]);
+
+  return new Map(result.rows.map(row => [row.movie_id, row.richness]));
+};
+
+/**
+ * Folds the source movie into the target inside an open transaction.
+ *
+ * - Genre/director/producer/crew/keyword/language/country/studio links are unioned
+ * - Cast members missing from the target are appended after its existing cast
+ * - Translations the target lacks are copied over
+ * - Empty target columns are filled from the source (target values win)
+ * - Reviews move to the target; a user who reviewed both keeps the newer one
+ * - Watchlist entries and feed activities move to the target, once per user
+ * - List entries move to the target at the same position; a list holding both
+ *   keeps the target's entry and closes up the source's
+ * - Short links move to the target, so printed QR codes keep working; the
+ *   target's own link stays the one it hands out (migration 051)
+ * - Sync mappings move to the target, so re-syncing the source's remote movie
+ *   updates the target instead of re-creating the duplicate
+ * - The source movie is deleted (its links cascade)
+ *
+ * @returns Number of links and cast members added to the target
+ */
+const mergeMovies = async (
+  client: PoolClient,
+  sourceId: number,
+  targetId: number
+): Promise<{ links_added: number; cast_added: number }> => {
+  let linksAdded = 0;
+
+  for (const { table, column } of SIMPLE_LINK_TABLES) {
+    const result = await client.query(
+      `INSERT INTO ${table} (movie_id, ${column})
+       SELECT $2, ${column} FROM ${table} WHERE movie_id = $1
+       ON CONFLICT DO NOTHING`,
+      [sourceId, targetId]
+    );
+    linksAdded += result.rowCount ?? 0;
+  }
+
+  const castResult = await client.query(`
+    INSERT INTO movie_actors (movie_id, actor_id, character_name, character_names, actor_order, billing_order, credited)
+    SELECT $2, actor_id, character_name, character_names, new_order, billing_order, credited
+    FROM (
+      SELECT
+        ma.actor_id,
+        ma.character_name,
+        ma.character_names,
+        ma.billing_order,
+        ma.credited,
+        (SELECT COALESCE(MAX(actor_order), 0) FROM movie_actors WHERE movie_id = $2)
+          + ROW_NUMBER() OVER (ORDER BY ma.actor_order) AS new_order
+      FROM movie_actors ma
+      WHERE ma.movie_id = $1
+        AND ma.actor_id NOT IN (SELECT actor_id FROM movie_actors WHERE movie_id = $2)
+    ) AS missing
+  `, [sourceId, targetId]);
+
+  await client.query(`
+    INSERT INTO movie_translations (movie_id, language, title, overview, tagline)
+    SELECT $2, language, title, overview, tagline
+    FROM movie_translations WHERE movie_id = $1
+    ON CONFLICT DO NOTHING
+  `, [sourceId, targetId]);
+
+  await client.query(`
+    UPDATE movies AS t
+    SET original_title = COALESCE(t.original_title, s.original_title),
+        release_date = COALESCE(t.release_date, s.release_date),
+        runtime_minutes = COALESCE(t.runtime_minutes, s.runtime_minutes),
+        overview = COALESCE(NULLIF(t.overview, ''), s.overview),
+        budget = COALESCE(NULLIF(t.budget, 0), s.budget),
+        revenue = COALESCE(NULLIF(t.revenue, 0), s.revenue),
+        vote_count = COALESCE(t.vote_count, s.vote_count),
+        vote_average = COALESCE(t.vote_average, s.vote_average),
+        mpa_rating = COALESCE(NULLIF(t.mpa_rating, ''), s.mpa_rating),
+        collection_id = COALESCE(t.collection_id, s.collection_id),
+        poster_url = COALESCE(t.poster_url, s.poster_url),
+        backdrop_url = COALESCE(t.backdrop_url, s.backdrop_url),
+        version = t.version + 1,
+        updated_at = NOW()
+    FROM movies AS s
+    WHERE t.movie_id = $2 AND s.movie_id = $1
+  `, [sourceId, targetId]);
+  await enrichMovies(client, [targetId]);
+  await generatePlaceholderPosters(client, [targetId]);
+
+  // One review per user and movie: of a user's two reviews the older goes
+  await client.query(`
+    DELETE FROM reviews AS r
+    USING reviews AS other
+    WHERE r.user_id = other.user_id
+      AND ((r.movie_id = $1 AND other.movie_id = $2 AND r.updated_at <= other.updated_at)
+        OR (r.movie_id = $2 AND other.movie_id = $1 AND r.updated_at < other.updated_at))
+  `, [sourceId, targetId]);
+  await client.query('UPDATE reviews SET movie_id = $2 WHERE movie_id = $1', [sourceId, targetId]);
+
+  // A user with both movies on their watchlist already has an activity for the target
+  await client.query(`
+    DELETE FROM activities AS a
+    WHERE a.movie_id = $1
+      AND a.activity_type = 'watchlist_add'
+      AND EXISTS (SELECT 1 FROM watchlist WHERE user_id = a.user_id AND movie_id = $2)
+  `, [sourceId, targetId]);
+  await client.query(`
+    INSERT INTO watchlist (user_id, movie_id, added_at)
+    SELECT user_id, $2, added_at FROM watchlist WHERE movie_id = $1
+    ON CONFLICT DO NOTHING
+  `, [sourceId, targetId]);
+  await client.query('UPDATE activities SET movie_id = $2 WHERE movie_id = $1', [sourceId, targetId]);
+
+  // List positions are checked at commit (migration 027), so the shift can pass through duplicates
+  await client.query(`
+    WITH dropped AS (
+      DELETE FROM movie_list_items AS s
+      USING movie_list_items AS t
+      WHERE s.list_id = t.list_id AND s.movie_id = $1 AND t.movie_id = $2
+      RETURNING s.list_id, s.position
+    )
+    UPDATE movie_list_items AS i
+    SET position = i.position - 1
+    FROM dropped
+    WHERE i.list_id = dropped.list_id AND i.position > dropped.position
+  `, [sourceId, targetId]);
+  await client.query('UPDATE movie_list_items SET movie_id = $2 WHERE movie_id = $1', [sourceId, targetId]);
+
+  await client.query(`
+    UPDATE short_links
+    SET movie_id = $2,
+        merged = merged OR EXISTS (SELECT 1 FROM short_links WHERE movie_id = $2 AND NOT merged)
+    WHERE movie_id = $1
+  `, [sourceId, targetId]);
+
+  await client.query('UPDATE movie_sync_map SET movie_id = $2 WHERE movie_id = $1', [sourceId, targetId]);
+
+  await client.query('DELETE FROM movies WHERE movie_id = $1', [sourceId]);
+
+  return { links_added: linksAdded, cast_added: castResult.rowCount ?? 0 };
+};
+
+/**
+ * Locks both movies, merges source into target and records the audit entry.
+ * The caller owns BEGIN/COMMIT and sends the response once committed.
+ *
+ * @returns Response body on success, or null when either movie is missing
+ */
+const mergeWithAudit = async (
+  client: PoolClient,
+  req: ApiKeyRequest,
+  sourceId: number,
+  targetId: number,
+  flagId?: number
+): Promise<Record<string, unknown> | null> => {
+  const moviesResult = await client.query(
+    'SELECT movie_id, title, release_date FROM movies WHERE movie_id = ANY($1::int[]) FOR UPDATE',
+    [[sourceId, targetId]]
+  );
+
+  const source = moviesResult.rows.find(row => row.movie_id === sourceId);
+  const target = moviesResult.rows.find(row => row.movie_id === targetId);
+
+  if (!source || !target) {
+    return null;
+  }
+
+  const counts = await mergeMovies(client, sourceId, targetId);
+
+  await recordAudit(client, {
+    action: 'movie.merge',
+    entity_type: 'movie',
+    entity_id: targetId,
+    details: {
+      merged_id: sourceId,
+      merged_title: source.title,
+      merged_release_date: source.release_date,
+      flag_id: flagId ?? null,
+      ...counts
+    },
+    performed_by: req.apiKey?.api_key_id
+  });
+
+  return {
+    success: true,
+    message: `Merged movie "${source.title}" (${sourceId}) into "${target.title}" (${targetId})`,
+    merged_id: sourceId,
+    kept_id: targetId,
+    ...counts
+  };
+};
+
+// ============================================================================
+// Duplicate Controllers
+// ============================================================================
+
+/**
+ * POST /api/admin/movies/duplicates/scan
+ * Flag suspected duplicate movies
+ *
+ * Two movies are suspects when their normalized titles match and either their
+ * release years are within `maxYearGap` of each other or their runtimes are
+ * identical (re-releases). A missing release date matches nothing. Pairs
+ * already flagged, including dismissed ones, are not flagged again.
+ *
+ * Query Parameters:
+ * - maxYearGap: number (default: 1)
+ *
+ * @returns Number of newly flagged pairs
+ */
+export const scanDuplicateMovies = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = scanSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { maxYearGap } = validation.data;
+
+  try {
+    const sql = `
+      INSERT INTO movie_duplicate_flags (movie_id, duplicate_of_id, reason)
+      SELECT
+        a.movie_id,
+        b.movie_id,
+        CASE
+          WHEN a.runtime_minutes = b.runtime_minutes THEN 'same title and runtime'
+          ELSE 'same title and release year'
+        END
+      FROM movies a
+      JOIN movies b
+        ON a.movie_id < b.movie_id
+       AND ${normalizedTitle('a')} = ${normalizedTitle('b')}
+      -- A title of only punctuation says nothing, and an unknown release date
+      -- isn't evidence either way
+      WHERE ${normalizedTitle('a')} <> ''
+        AND (
+          a.runtime_minutes = b.runtime_minutes
+          OR ABS(EXTRACT(YEAR FROM a.release_date) - EXTRACT(YEAR FROM b.release_date)) <= $1
+        )
+      ON CONFLICT (movie_id, duplicate_of_id) DO NOTHING
+    `;
+
+    const result = await pool.query(sql, [maxYearGap]);
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      flagged: result.rowCount ?? 0,
+      maxYearGap
+    });
+  } catch (error) {
+    console.error('Error scanning for duplicates:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to scan for duplicate movies')
+    );
+  }
+};
+
+/**
+ * GET /api/admin/movies/duplicates
+ * List flagged duplicate pairs with enough detail to review them
+ *
+ * Query Parameters:
+ * - status: 'pending' | 'dismissed' (default: 'pending')
+ * - page: number (default: 1)
+ * - limit: number (default: 20, max: 100)
+ *
+ * @returns Paginated flags with both movies' summaries
+ */
+export const getDuplicateFlags = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = listFlagsSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { status, page, limit } = validation.data;
+  const offset = (page - 1) * limit;
+
+  try {
+    const countSql = `
+      SELECT COUNT(*)::int AS total
+      FROM movie_duplicate_flags
+      WHERE status = $1
+    `;
+
+    const dataSql = `
+      SELECT
+        f.flag_id, f.reason, f.status, f.created_at, f.resolved_at,
+        json_build_object(
+          'movie_id', a.movie_id, 'title', a.title, 'release_date', a.release_date,
+          'runtime_minutes', a.runtime_minutes, 'poster_url', a.poster_url
+        ) AS movie,
+        json_build_object(
+          'movie_id', b.movie_id, 'title', b.title, 'release_date', b.release_date,
+          'runtime_minutes', b.runtime_minutes, 'poster_url', b.poster_url
+        ) AS duplicate_of
+      FROM movie_duplicate_flags f
+      JOIN movies a ON f.movie_id = a.movie_id
+      JOIN movies b ON f.duplicate_of_id = b.movie_id
+      WHERE f.status = $1
+      ORDER BY f.created_at DESC, f.flag_id DESC
+      LIMIT $2 OFFSET $3
+    `;
+
+    const [countResult, dataResult] = await Promise.all([
+      pool.query<{ total: number }>(countSql, [status]),
+      pool.query(dataSql, [status, limit, offset])
+    ]);
+
+    const total = countResult.rows[0].total;
+    const pages = Math.max(1, Math.ceil(total / limit));
+
+    res.status(HttpStatus.OK).json({
+      data: dataResult.rows,
+      meta: {
+        page,
+        limit,
+        total,
+        pages,
+        hasNextPage: page < pages,
+        hasPreviousPage: page > 1,
+        query: { status }
+      }
+    });
+  } catch (error) {
+    console.error('Error fetching duplicate flags:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch duplicate flags')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/movies/:id/flag-duplicate/:otherId
+ * Manually flag two movies as suspected duplicates
+ *
+ * @param id - Movie ID
+ * @param otherId - Movie ID it appears to duplicate
+ * @returns The created (or existing) flag
+ */
+export const flagDuplicateMovie = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  const otherId = parseInt(req.params.otherId, 10);
+
+  if (isNaN(movieId) || isNaN(otherId) || movieId === otherId) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Two different valid movie IDs are required')
+    );
+    return;
+  }
+
+  try {
+    const moviesResult = await pool.query(
+      'SELECT movie_id FROM movies WHERE movie_id = ANY($1::int[])',
+      [[movieId, otherId]]
+    );
+    if (moviesResult.rows.length !== 2) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Both movies must exist to flag them')
+      );
+      return;
+    }
+
+    // Flags are stored with the lower ID first so a pair is only flagged once
+    const sql = `
+      INSERT INTO movie_duplicate_flags (movie_id, duplicate_of_id, reason)
+      VALUES (LEAST($1::int, $2::int), GREATEST($1::int, $2::int), 'flagged manually')
+      ON CONFLICT (movie_id, duplicate_of_id)
+        DO UPDATE SET status = 'pending', resolved_at = NULL
+      RETURNING flag_id, movie_id, duplicate_of_id, reason, status, created_at
+    `;
+
+    const result = await pool.query(sql, [movieId, otherId]);
+
+    res.status(HttpStatus.CREATED).json({
+      success: true,
+      flag: result.rows[0]
+    });
+  } catch (error) {
+    console.error('Error flagging duplicate:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to flag duplicate movie')
+    );
+  }
+};
+
+/**
+ * PATCH /api/admin/movies/duplicates/:flagId
+ * Dismiss a flag (not a duplicate) or reopen it
+ *
+ * Body: { status: 'pending' | 'dismissed' }
+ *
+ * @param flagId - Flag ID
+ * @returns The updated flag
+ */
+export const updateDuplicateFlag = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const flagId = parseInt(req.params.flagId, 10);
+  const validation = updateFlagSchema.safeParse(req.body ?? {});
+
+  if (isNaN(flagId) || !validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error?.issues ?? 'Flag ID must be a valid number')
+    );
+    return;
+  }
+
+  try {
+    const sql = `
+      UPDATE movie_duplicate_flags
+      SET status = $2,
+          resolved_at = CASE WHEN $2 = 'pending' THEN NULL ELSE NOW() END
+      WHERE flag_id = $1
+      RETURNING flag_id, movie_id, duplicate_of_id, reason, status, created_at, resolved_at
+    `;
+
+    const result = await pool.query(sql, [flagId, validation.data.status]);
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Duplicate flag with ID ${flagId} not found`)
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      flag: result.rows[0]
+    });
+  } catch (error) {
+    console.error('Error updating duplicate flag:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update duplicate flag')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/movies/duplicates/:flagId/merge
+ * Resolve a flag by merging the pair, keeping the richer record
+ *
+ * The record with more filled columns and related links survives; ties keep
+ * the lower (older) movie ID.
+ *
+ * @param flagId - Flag ID
+ * @returns IDs of the kept and merged movies with link counts
+ */
+export const mergeDuplicateFlag = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const flagId = parseInt(req.params.flagId, 10);
+
+  if (isNaN(flagId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Flag ID must be a valid number', 'INVALID_ID')
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const flagResult = await client.query(
+      'SELECT movie_id, duplicate_of_id FROM movie_duplicate_flags WHERE flag_id = $1 FOR UPDATE',
+      [flagId]
+    );
+
+    if (flagResult.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Duplicate flag with ID ${flagId} not found`)
+      );
+      return;
+    }
+
+    const { movie_id: first, duplicate_of_id: second } = flagResult.rows[0];
+    const richness = await getRichness(client, [first, second]);
+    const keepSecond = (richness.get(second) ?? 0) > (richness.get(first) ?? 0);
+    const [sourceId, targetId] = keepSecond ? [first, second] : [second, first];
+
+    const merged = await mergeWithAudit(client, req, sourceId, targetId, flagId);
+    if (!merged) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Flagged movies no longer exist')
+      );
+      return;
+    }
+
+    await client.query('COMMIT');
+    res.status(HttpStatus.OK).json(merged);
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error merging duplicate flag:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to merge duplicate movies')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * POST /api/admin/movies/:id/merge-into/:targetId
+ * Merge one movie into an explicitly chosen survivor
+ *
+ * @param id - Movie ID to remove
+ * @param targetId - Movie ID to keep
+ * @returns IDs of the kept and merged movies with link counts
+ */
+export const mergeMovieInto = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const sourceId = parseInt(req.params.id, 10);
+  const targetId = parseInt(req.params.targetId, 10);
+
+  if (isNaN(sourceId) || isNaN(targetId) || sourceId === targetId) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Two different valid movie IDs are required')
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+    const merged = await mergeWithAudit(client, req, sourceId, targetId);
+    if (!merged) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Both movies must exist to merge them')
+      );
+      return;
+    }
+
+    await client.query('COMMIT');
+    res.status(HttpStatus.OK).json(merged);
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error merging movies:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to merge movies')
+    );
+  } finally {
+    client.release();
+  }
+};
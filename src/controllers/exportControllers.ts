@@ -0,0 +1,145 @@
+// server/src/controllers/exportControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { EXPORTED_MOVIE_SQL } from '@utils/movieExport';
+import { ExportedMovie } from '@models/movieModel';
+import z from 'zod';
+
+/**
+ * Where a page ends: the last movie's updated_at (as text, to keep
+ * Postgres' microseconds) and movie_id
+ */
+interface ExportCursor {
+  updatedAt: string;
+  movieId: number;
+}
+
+const CURSOR_TIME = /^\d{4}-\d{2}-\d{2}[ T]\d{2}:\d{2}:\d{2}(\.\d+)?([+-]\d{2}(:?\d{2})?|Z)$/;
+
+/**
+ * Opaque to clients; they pass meta.next_cursor back unchanged
+ */
+const encodeCursor = (cursor: ExportCursor): string =>
+  Buffer.from(JSON.stringify([cursor.updatedAt, cursor.movieId])).toString('base64url');
+
+const decodeCursor = (value: string): ExportCursor | null => {
+  try {
+    const [updatedAt, movieId] = JSON.parse(Buffer.from(value, 'base64url').toString('utf8'));
+    if (typeof updatedAt !== 'string' || !CURSOR_TIME.test(updatedAt) || !Number.isInteger(movieId)) {
+      return null;
+    }
+    return { updatedAt, movieId };
+  } catch {
+    return null;
+  }
+};
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const exportSchema = z.object({
+  since: z.iso.datetime({ offset: true }).optional(),
+  cursor: z.string()
+    .refine(value => decodeCursor(value) !== null, 'Invalid cursor; pass meta.next_cursor from the previous page')
+    .transform(value => decodeCursor(value)!)
+    .optional(),
+  // Offset paging skipped or repeated movies edited mid-export; fail old clients loudly
+  page: z.undefined('page is not supported; follow meta.next_cursor instead'),
+  limit: z.coerce.number().int().min(1).max(500).default(100)
+});
+
+// ============================================================================
+// Export Controllers
+// ============================================================================
+
+/**
+ * GET /api/export/movies
+ * Full movie records, oldest change first, for cloning a catalog into another instance
+ *
+ * Records have the same shape as POST /movies input plus movie_id and
+ * updated_at. Pass the previous response's meta.server_time as `since` to
+ * fetch only movies changed after it. Deleted movies are not reported.
+ *
+ * Pages follow a keyset cursor on (updated_at, movie_id), so a movie edited
+ * mid-export moves to the end instead of shifting the pages after it.
+ *
+ * Query Parameters:
+ * - since: ISO timestamp (optional) - Only movies updated after this time
+ * - cursor: string (optional) - meta.next_cursor of the previous page
+ * - limit: number (default: 100, max: 500)
+ *
+ * @returns A page of movie records, the cursor for the next one and the server time to use for the next delta
+ */
+export const exportMovies = async (req: Request, res: Response): Promise<void> => {
+  const validation = exportSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { since, cursor, limit } = validation.data;
+
+  try {
+    // Captured before reading so nothing changed during the export is missed next time
+    const timeResult = await pool.query<{ now: Date }>('SELECT NOW() AS now');
+    const serverTime = timeResult.rows[0].now;
+
+    const countSql = `
+      SELECT COUNT(*)::int AS total
+      FROM movies m
+      WHERE $1::timestamptz IS NULL OR m.updated_at > $1::timestamptz
+    `;
+
+    // One extra row tells whether another page follows; cursor_time is the
+    // full-precision updated_at for the next cursor
+    const dataSql = `
+      WITH page AS (
+        SELECT m.movie_id, m.updated_at::text AS cursor_time
+        FROM movies m
+        WHERE ($1::timestamptz IS NULL OR m.updated_at > $1::timestamptz)
+          AND ($2::timestamptz IS NULL OR (m.updated_at, m.movie_id) > ($2::timestamptz, $3::int))
+        ORDER BY m.updated_at, m.movie_id
+        LIMIT $4 + 1
+      )
+      SELECT exported.*, page.cursor_time
+      FROM (${EXPORTED_MOVIE_SQL} WHERE m.movie_id IN (SELECT movie_id FROM page)) exported
+      JOIN page ON page.movie_id = exported.movie_id
+      ORDER BY exported.updated_at, exported.movie_id
+    `;
+
+    const [countResult, dataResult] = await Promise.all([
+      pool.query<{ total: number }>(countSql, [since ?? null]),
+      pool.query<ExportedMovie & { cursor_time: string }>(
+        dataSql,
+        [since ?? null, cursor?.updatedAt ?? null, cursor?.movieId ?? null, limit]
+      )
+    ]);
+
+    const hasNextPage = dataResult.rows.length > limit;
+    const rows = dataResult.rows.slice(0, limit);
+    const last = rows[rows.length - 1];
+
+    res.status(HttpStatus.OK).json({
+      data: rows.map(({ cursor_time, ...movie }) => movie),
+      meta: {
+        limit,
+        total: countResult.rows[0].total,
+        hasNextPage,
+        next_cursor: hasNextPage ? encodeCursor({ updatedAt: last.cursor_time, movieId: last.movie_id }) : null,
+        since: since ?? null,
+        server_time: serverTime
+      }
+    });
+  } catch (error) {
+    console.error('Error exporting movies:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to export movies')
+    );
+  }
+};
@@ -0,0 +1,185 @@
+// server/src/controllers/featureControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { getFeatureFlags, isFeatureFlag } from '@utils/featureFlags';
+import { invalidateCache } from '@utils/cacheBus';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const setFeatureSchema = z.object({
+  enabled: z.boolean()
+});
+
+// ============================================================================
+// Feature Flag Controllers
+// ============================================================================
+
+/**
+ * GET /api/features
+ * Which optional features this deployment has switched on
+ *
+ * Public so clients can hide UI for disabled features.
+ *
+ * @returns Map of flag key to enabled state
+ */
+export const getEnabledFeatures = async (req: Request, res: Response): Promise<void> => {
+  try {
+    const flags = await getFeatureFlags();
+    res.status(HttpStatus.OK).json(
+      Object.fromEntries(flags.map(flag => [flag.flag, flag.enabled]))
+    );
+  } catch (error) {
+    console.error('Error fetching feature flags:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch feature flags')
+    );
+  }
+};
+
+/**
+ * GET /api/admin/features
+ * Every feature flag with its state and where that state comes from
+ *
+ * source is 'env' (FEATURE_<FLAG> variable, can't be changed at runtime),
+ * 'database' (toggled through this API) or 'default'.
+ *
+ * @returns List of flags
+ */
+export const getFeatureFlagList = async (req: Request, res: Response): Promise<void> => {
+  try {
+    res.status(HttpStatus.OK).json({ data: await getFeatureFlags() });
+  } catch (error) {
+    console.error('Error fetching feature flags:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch feature flags')
+    );
+  }
+};
+
+/**
+ * PUT /api/admin/features/:flag
+ * Turn a feature on or off without redeploying
+ *
+ * Body: { enabled: boolean }
+ * Takes effect immediately on this instance and within 30 seconds on others.
+ * An environment variable for the flag still takes precedence.
+ *
+ * @param flag - Flag key
+ * @returns The flag's resulting state
+ */
+export const setFeatureFlag = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const { flag } = req.params;
+  const validation = setFeatureSchema.safeParse(req.body ?? {});
+
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  if (!isFeatureFlag(flag)) {
+    res.status(HttpStatus.NOT_FOUND).json(
+      ApiError.notFound(`Unknown feature flag "${flag}"`, 'UNKNOWN_FEATURE_FLAG', { flag })
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    await client.query(
+      `INSERT INTO feature_flags (flag_key, enabled, updated_by, updated_at)
+       VALUES ($1, $2, $3, NOW())
+       ON CONFLICT (flag_key) DO UPDATE
+       SET enabled = EXCLUDED.enabled, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+      [flag, validation.data.enabled, req.apiKey?.api_key_id ?? null]
+    );
+
+    await recordAudit(client, {
+      action: 'feature.set',
+      entity_type: 'feature_flag',
+      entity_id: null,
+      details: { flag, enabled: validation.data.enabled },
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    await client.query('COMMIT');
+    invalidateCache('feature_flags');
+
+    const state = (await getFeatureFlags()).find(entry => entry.flag === flag);
+    res.status(HttpStatus.OK).json({
+      success: true,
+      flag: state
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error setting feature flag:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to set feature flag')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * DELETE /api/admin/features/:flag
+ * Drop a runtime toggle so the flag falls back to its default
+ *
+ * @param flag - Flag key
+ * @returns The flag's resulting state
+ */
+export const resetFeatureFlag = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const { flag } = req.params;
+
+  if (!isFeatureFlag(flag)) {
+    res.status(HttpStatus.NOT_FOUND).json(
+      ApiError.notFound(`Unknown feature flag "${flag}"`, 'UNKNOWN_FEATURE_FLAG', { flag })
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    await client.query('DELETE FROM feature_flags WHERE flag_key = $1', [flag]);
+
+    await recordAudit(client, {
+      action: 'feature.reset',
+      entity_type: 'feature_flag',
+      entity_id: null,
+      details: { flag },
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    await client.query('COMMIT');
+    invalidateCache('feature_flags');
+
+    const state = (await getFeatureFlags()).find(entry => entry.flag === flag);
+    res.status(HttpStatus.OK).json({
+      success: true,
+      flag: state
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error resetting feature flag:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to reset feature flag')
+    );
+  } finally {
+    client.release();
+  }
+};
@@ -0,0 +1,138 @@
+// server/src/controllers/feedControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { nonAdultCondition } from '@utils/contentFilter';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { getApiBaseUrl, getMovieUrl } from '@utils/publicUrls';
+import { escapeXml } from '@utils/sanitize';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const newMoviesFeedSchema = z.object({
+  genre: z.string().trim().min(1).max(100).optional(),
+  limit: z.coerce.number().int().min(1).max(100).default(20)
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+interface FeedMovieRow {
+  movie_id: number;
+  public_id: string | null;
+  title: string;
+  release_date: string | null; // YYYY-MM-DD
+  overview: string | null;
+  poster_url: string | null;
+  genres: string[] | null;
+  added_at: Date;
+}
+
+const buildEntry = (req: Request, movie: FeedMovieRow): string => {
+  const url = getMovieUrl(req, movie);
+  const title = movie.release_date ? `${movie.title} (${movie.release_date.slice(0, 4)})` : movie.title;
+  const added = new Date(movie.added_at).toISOString();
+  const content = [
+    movie.poster_url ? `<p><img src="${escapeXml(movie.poster_url)}" alt="${escapeXml(movie.title)} poster"/></p>` : null,
+    movie.overview ? `<p>${escapeXml(movie.overview)}</p>` : null
+  ].filter(Boolean).join('');
+
+  return [
+    '  <entry>',
+    `    <title>${escapeXml(title)}</title>`,
+    `    <id>urn:tcss460-api:movie:${escapeXml(movie.public_id ?? String(movie.movie_id))}</id>`,
+    `    <link rel="alternate" href="${escapeXml(url)}"/>`,
+    ...(movie.poster_url ? [`    <link rel="enclosure" type="image/jpeg" href="${escapeXml(movie.poster_url)}"/>`] : []),
+    `    <published>${added}</published>`,
+    `    <updated>${added}</updated>`,
+    ...(movie.genres ?? []).map(genre => `    <category term="${escapeXml(genre)}"/>`),
+    ...(movie.overview ? [`    <summary>${escapeXml(movie.overview)}</summary>`] : []),
+    ...(content ? [`    <content type="html">${escapeXml(content)}</content>`] : []),
+    '  </entry>'
+  ].join('\n');
+};
+
+// ============================================================================
+// Feed Controllers
+// ============================================================================
+
+/**
+ * GET /api/feeds/new-movies.atom
+ * Atom feed of the movies most recently added to the catalog, for embedding
+ * on other sites; needs no API key
+ *
+ * Query Parameters:
+ * - genre: Only this genre (case-insensitive)
+ * - limit: Entries (default: 20, max: 100)
+ *
+ * Adult titles are always left out. Movies added before added_at was
+ * recorded (migration 028) don't appear.
+ *
+ * @returns application/atom+xml
+ */
+export const getNewMoviesFeed = async (req: Request, res: Response): Promise<void> => {
+  const validation = newMoviesFeedSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { genre, limit } = validation.data;
+
+  try {
+    const result = await pool.query<FeedMovieRow>(
+      `SELECT m.movie_id, m.public_id, m.title, m.release_date, m.overview, m.poster_url, m.added_at,
+              (SELECT ARRAY_AGG(g.genre_name ORDER BY g.genre_name)
+               FROM movie_genres mg
+               JOIN genres g ON g.genre_id = mg.genre_id
+               WHERE mg.movie_id = m.movie_id) AS genres
+       FROM movies m
+       WHERE m.added_at IS NOT NULL
+         AND ${nonAdultCondition('m')}
+         AND ($1::text IS NULL OR EXISTS (
+           SELECT 1 FROM movie_genres mg2
+           JOIN genres g2 ON mg2.genre_id = g2.genre_id
+           WHERE mg2.movie_id = m.movie_id AND LOWER(g2.genre_name) = LOWER($1)
+         ))
+       ORDER BY m.added_at DESC, m.movie_id DESC
+       LIMIT $2`,
+      [genre ?? null, limit]
+    );
+
+    const selfUrl = `${getApiBaseUrl(req)}${req.originalUrl}`;
+    const updated = result.rows.length > 0
+      ? new Date(result.rows[0].added_at).toISOString()
+      : new Date(0).toISOString();
+
+    const feed = [
+      '<?xml version="1.0" encoding="utf-8"?>',
+      '<feed xmlns="http://www.w3.org/2005/Atom">',
+      `  <title>${escapeXml(genre ? `New ${genre} movies` : 'New movies')}</title>`,
+      '  <subtitle>Latest additions to the TCSS 460 movie catalog</subtitle>',
+      `  <id>urn:tcss460-api:feeds:new-movies${genre ? `:${escapeXml(genre.toLowerCase())}` : ''}</id>`,
+      `  <link rel="self" type="application/atom+xml" href="${escapeXml(selfUrl)}"/>`,
+      `  <updated>${updated}</updated>`,
+      '  <author><name>TCSS 460 Movie API</name></author>',
+      ...result.rows.map(movie => buildEntry(req, movie)),
+      '</feed>',
+      ''
+    ].join('\n');
+
+    res
+      .status(HttpStatus.OK)
+      .type('application/atom+xml; charset=utf-8')
+      .send(feed);
+  } catch (error) {
+    console.error('Error building new movies feed:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to build feed')
+    );
+  }
+};
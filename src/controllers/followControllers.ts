@@ -0,0 +1,274 @@
+// server/src/controllers/followControllers.ts
+
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { UserRequest } from '@middleware/userAuth';
+import z from 'zod';
+import { paginationSchema } from './movieGetControllers';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const feedQuerySchema = paginationSchema.extend({
+  type: z.enum(['rating', 'review', 'watchlist_add']).optional()
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+interface FeedRow {
+  activity_id: string;
+  activity_type: string;
+  created_at: Date;
+  user_id: number;
+  username: string;
+  movie_id: number;
+  public_id: string | null;
+  title: string;
+  poster_url: string | null;
+  review_id: number | null;
+  rating: number | null;
+  contains_spoilers: boolean | null;
+}
+
+/**
+ * Parses the :id route parameter as a user ID, responding with 400 when invalid
+ */
+const parseUserId = (req: UserRequest, res: Response): number | null => {
+  const userId = parseInt(req.params.id, 10);
+  if (isNaN(userId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('User ID must be a valid number', 'INVALID_ID')
+    );
+    return null;
+  }
+  return userId;
+};
+
+// ============================================================================
+// Follow Controllers
+// ============================================================================
+
+/**
+ * PUT /api/users/:id/follow
+ * Follow another user so their activity shows up in GET /me/feed
+ *
+ * @param id - User to follow
+ * @returns Confirmation, with followed false when already following
+ */
+export const followUser = async (req: UserRequest, res: Response): Promise<void> => {
+  const userId = parseUserId(req, res);
+  if (userId === null) return;
+
+  if (userId === req.user!.userId) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest("You can't follow yourself", 'SELF_FOLLOW')
+    );
+    return;
+  }
+
+  try {
+    const result = await pool.query(
+      `INSERT INTO user_follows (follower_id, followed_id)
+       SELECT $1, user_id FROM users WHERE user_id = $2
+       ON CONFLICT (follower_id, followed_id) DO NOTHING
+       RETURNING followed_id`,
+      [req.user!.userId, userId]
+    );
+
+    if (result.rows.length === 0) {
+      const exists = await pool.query('SELECT 1 FROM users WHERE user_id = $1', [userId]);
+      if (exists.rows.length === 0) {
+        res.status(HttpStatus.NOT_FOUND).json(
+          ApiError.notFound(`User with ID ${userId} not found`, 'USER_NOT_FOUND', { id: userId })
+        );
+        return;
+      }
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      followed: result.rows.length > 0
+    });
+  } catch (error) {
+    console.error('Error following user:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to follow user')
+    );
+  }
+};
+
+/**
+ * DELETE /api/users/:id/follow
+ * Stop following a user
+ *
+ * @param id - User to unfollow
+ * @returns Confirmation
+ */
+export const unfollowUser = async (req: UserRequest, res: Response): Promise<void> => {
+  const userId = parseUserId(req, res);
+  if (userId === null) return;
+
+  try {
+    const result = await pool.query(
+      'DELETE FROM user_follows WHERE follower_id = $1 AND followed_id = $2',
+      [req.user!.userId, userId]
+    );
+
+    if (result.rowCount === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('You are not following this user', 'NOT_FOLLOWING')
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Unfollowed'
+    });
+  } catch (error) {
+    console.error('Error unfollowing user:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to unfollow user')
+    );
+  }
+};
+
+/**
+ * GET /api/me/following
+ * Users the signed-in user follows, most recent first
+ *
+ * @returns Followed users
+ */
+export const getFollowing = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query(
+      `SELECT u.user_id, u.username, f.created_at AS followed_at
+       FROM user_follows f
+       JOIN users u ON u.user_id = f.followed_id
+       WHERE f.follower_id = $1
+       ORDER BY f.created_at DESC`,
+      [req.user!.userId]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows,
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching followed users:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch followed users')
+    );
+  }
+};
+
+/**
+ * GET /api/me/followers
+ * Users following the signed-in user, most recent first
+ *
+ * @returns Followers
+ */
+export const getFollowers = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query(
+      `SELECT u.user_id, u.username, f.created_at AS followed_at
+       FROM user_follows f
+       JOIN users u ON u.user_id = f.follower_id
+       WHERE f.followed_id = $1
+       ORDER BY f.created_at DESC`,
+      [req.user!.userId]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows,
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching followers:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch followers')
+    );
+  }
+};
+
+/**
+ * GET /api/me/feed
+ * Recent ratings, reviews and watchlist additions by users the signed-in
+ * user follows, newest first
+ *
+ * Query Parameters:
+ * - type: rating | review | watchlist_add (default: all)
+ * - page: Page number (default: 1)
+ * - limit: Results per page (default: 20, max: 100)
+ *
+ * Reviews appear once approved; open them with GET /movies/:id/reviews.
+ *
+ * @returns Activities with who did what to which movie
+ */
+export const getFeed = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = feedQuerySchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { type, page, limit } = validation.data;
+
+  try {
+    // One extra row tells whether there is a next page without counting
+    const result = await pool.query<FeedRow>(
+      `SELECT
+         a.activity_id, a.activity_type, a.created_at,
+         a.user_id, u.username,
+         a.movie_id, m.public_id, m.title, m.poster_url,
+         r.review_id, r.rating, r.contains_spoilers
+       FROM user_follows f
+       JOIN activities a ON a.user_id = f.followed_id
+       JOIN users u ON u.user_id = a.user_id
+       JOIN movies m ON m.movie_id = a.movie_id
+       LEFT JOIN reviews r ON r.review_id = a.review_id
+       WHERE f.follower_id = $1
+         AND ($2::varchar IS NULL OR a.activity_type = $2)
+       ORDER BY a.created_at DESC, a.activity_id DESC
+       LIMIT $3 OFFSET $4`,
+      [req.user!.userId, type ?? null, limit + 1, (page - 1) * limit]
+    );
+
+    const rows = result.rows.slice(0, limit);
+    res.status(HttpStatus.OK).json({
+      data: rows.map(row => ({
+        activity_id: Number(row.activity_id),
+        type: row.activity_type,
+        created_at: row.created_at,
+        user: { user_id: row.user_id, username: row.username },
+        movie: {
+          movie_id: row.movie_id,
+          public_id: row.public_id,
+          title: row.title,
+          poster_url: row.poster_url
+        },
+        ...(row.review_id !== null
+          ? { review: { review_id: row.review_id, rating: row.rating, contains_spoilers: row.contains_spoilers } }
+          : {})
+      })),
+      meta: {
+        page,
+        limit,
+        hasNextPage: result.rows.length > limit,
+        hasPreviousPage: page > 1
+      }
+    });
+  } catch (error) {
+    console.error('Error fetching feed:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch feed')
+    );
+  }
+};
@@ -0,0 +1,754 @@
+// server/src/controllers/importControllers.ts
+
+import { NextFunction, Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { scheduleBoxOfficeRefresh } from '@utils/boxOfficeStats';
+import { FieldProfile, profileRecords } from '@utils/fieldProfiles';
+import { LINEAGE_FIELDS, startImportRun } from '@utils/lineage';
+import { nameMatchSql, uniqueNames } from '@utils/nameMatching';
+import { sanitizeText } from '@utils/sanitize';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import { MovieCreateInput } from '@models/movieModel';
+import z from 'zod';
+import { paginationSchema } from './movieGetControllers';
+import { insertMovie } from './moviePostControllers';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const stageQuerySchema = z.object({
+  stage: z.stringbool().default(false)
+});
+
+const listBatchesSchema = paginationSchema.extend({
+  status: z.enum(['pending', 'approved', 'rejected']).default('pending')
+});
+
+const rejectBatchSchema = z.object({
+  note: z.string().trim().min(1).max(500).optional()
+});
+
+const lineageQuerySchema = paginationSchema.extend({
+  field: z.enum(LINEAGE_FIELDS).optional()
+});
+
+// ============================================================================
+// Types
+// ============================================================================
+
+export interface StagedMovieSummary {
+  position: number;
+  title: string;
+  release_date: string;
+  /** Catalog movie with the same title and release date, if any */
+  existing_movie_id: number | null;
+  /** Set once the batch is approved */
+  movie_id: number | null;
+}
+
+export interface ImportBatchSummary {
+  batch_id: number;
+  status: 'pending' | 'approved' | 'rejected';
+  movie_count: number;
+  submitted_by: number | null;
+  submitted_at: string;
+  reviewed_by: number | null;
+  reviewed_at: string | null;
+  review_note: string | null;
+  movies: StagedMovieSummary[];
+  possible_duplicates: number;
+  /** Names approval would add to each lookup table */
+  new_names: Record<string, string[]>;
+}
+
+export type PromoteResult =
+  | { outcome: 'approved'; movie_ids: number[] }
+  | { outcome: 'not_found' }
+  | { outcome: 'not_pending'; status: string }
+  | { outcome: 'failed'; position: number; title: string; error: string };
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * IMPORT_STAGING=true sends every POST /api/movies and /api/movies/bulk
+ * through review
+ */
+export const isImportStagingEnabled = (): boolean => process.env.IMPORT_STAGING === 'true';
+
+/**
+ * Names each staged movie refers to, per lookup table. Each names query runs
+ * once per staged row (alias s) and yields one name per row.
+ */
+const LOOKUP_NAMES = [
+  { key: 'genres', table: 'genres', column: 'genre_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'genres', '[]'))` },
+  { key: 'directors', table: 'directors', column: 'director_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'directors', '[]'))` },
+  { key: 'producers', table: 'producers', column: 'producer_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'producers', '[]'))` },
+  { key: 'writers', table: 'writers', column: 'writer_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'writers', '[]'))` },
+  { key: 'composers', table: 'composers', column: 'composer_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'composers', '[]'))` },
+  { key: 'cinematographers', table: 'cinematographers', column: 'cinematographer_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'cinematographers', '[]'))` },
+  { key: 'editors', table: 'editors', column: 'editor_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'editors', '[]'))` },
+  { key: 'keywords', table: 'keywords', column: 'keyword_name',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'keywords', '[]'))` },
+  { key: 'spoken_languages', table: 'languages', column: 'language_code',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'spoken_languages', '[]'))` },
+  { key: 'production_countries', table: 'countries', column: 'country_code',
+    names: `SELECT jsonb_array_elements_text(COALESCE(s.data->'production_countries', '[]'))` },
+  { key: 'studios', table: 'studios', column: 'studio_name',
+    names: `SELECT e->>'studio_name' FROM jsonb_array_elements(COALESCE(s.data->'studios', '[]')) AS e` },
+  { key: 'actors', table: 'actors', column: 'actor_name',
+    names: `SELECT e->>'actor_name' FROM jsonb_array_elements(COALESCE(s.data->'cast', '[]')) AS e` },
+  { key: 'collections', table: 'collections', column: 'collection_name',
+    names: `SELECT s.data->>'collection_name' WHERE s.data->>'collection_name' IS NOT NULL` }
+] as const;
+
+/**
+ * Lookups of people and studios, whose names match ignoring case and accents
+ * (migration 050) like the lookups that create them
+ */
+const NAME_KEY_LOOKUPS: readonly string[] = [
+  'directors', 'producers', 'writers', 'composers', 'cinematographers', 'editors', 'studios', 'actors'
+];
+
+/**
+ * Parses the :batchId route parameter, responding with 400 when invalid
+ */
+const parseBatchId = (req: Request, res: Response): number | null => {
+  const batchId = parseInt(req.params.batchId, 10);
+  if (isNaN(batchId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Import batch ID must be a valid number', 'INVALID_ID')
+    );
+    return null;
+  }
+  return batchId;
+};
+
+const paginationMeta = (page: number, limit: number, total: number) => {
+  const pages = Math.max(1, Math.ceil(total / limit));
+  return { page, limit, total, pages, hasNextPage: page < pages, hasPreviousPage: page > 1 };
+};
+
+/**
+ * Stores validated movies as a pending batch
+ *
+ * @returns The new batch's ID
+ */
+export const stageImportBatch = async (
+  movies: MovieCreateInput[],
+  submittedBy: number | null
+): Promise<number> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const batch = await client.query<{ batch_id: number }>(
+      'INSERT INTO import_batches (movie_count, submitted_by) VALUES ($1, $2) RETURNING batch_id',
+      [movies.length, submittedBy]
+    );
+    const batchId = batch.rows[0].batch_id;
+
+    await client.query(
+      `INSERT INTO import_batch_movies (batch_id, position, data)
+       SELECT $1, e.position, e.data
+       FROM jsonb_array_elements($2::jsonb) WITH ORDINALITY AS e(data, position)`,
+      [batchId, JSON.stringify(movies)]
+    );
+
+    await client.query('COMMIT');
+    return batchId;
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * The batch with what approving it would change: each staged movie with any
+ * catalog movie it may duplicate, and the genres, people, studios and
+ * collections not in the catalog yet
+ *
+ * @returns The summary, or null if the batch doesn't exist
+ */
+export const summarizeImportBatch = async (batchId: number): Promise<ImportBatchSummary | null> => {
+  const batch = await pool.query(
+    `SELECT batch_id, status, movie_count, submitted_by, submitted_at,
+            reviewed_by, reviewed_at, review_note
+     FROM import_batches WHERE batch_id = $1`,
+    [batchId]
+  );
+  if (batch.rows.length === 0) {
+    return null;
+  }
+
+  const [movies, ...names] = await Promise.all([
+    pool.query<StagedMovieSummary>(
+      `SELECT
+         s.position,
+         s.data->>'title' AS title,
+         s.data->>'release_date' AS release_date,
+         (SELECT m.movie_id FROM movies m
+          WHERE LOWER(m.title) = LOWER(s.data->>'title')
+            AND m.release_date = (s.data->>'release_date')::date
+            AND m.movie_id IS DISTINCT FROM s.movie_id
+          ORDER BY m.movie_id LIMIT 1) AS existing_movie_id,
+         s.movie_id
+       FROM import_batch_movies s
+       WHERE s.batch_id = $1
+       ORDER BY s.position`,
+      [batchId]
+    ),
+    ...LOOKUP_NAMES.map(({ key, table, column, names }) =>
+      pool.query<{ name: string }>(
+        `SELECT DISTINCT n.name
+         FROM import_batch_movies s
+         CROSS JOIN LATERAL (${names}) AS n(name)
+         WHERE s.batch_id = $1
+           AND NOT EXISTS (
+             SELECT 1 FROM ${table} t
+             WHERE ${NAME_KEY_LOOKUPS.includes(key) ? nameMatchSql(`t.${column}`, 'n.name') : `t.${column} = n.name`}
+           )
+         ORDER BY n.name`,
+        [batchId]
+      )
+    )
+  ]);
+
+  return {
+    ...batch.rows[0],
+    movies: movies.rows,
+    possible_duplicates: movies.rows.filter(movie => movie.existing_movie_id !== null).length,
+    new_names: Object.fromEntries(
+      LOOKUP_NAMES.map(({ key }, i) => {
+        const found = names[i].rows.map(row => row.name);
+        return [key, NAME_KEY_LOOKUPS.includes(key) ? uniqueNames(found) : found];
+      })
+    )
+  };
+};
+
+/**
+ * Per-field profiles of a batch's staged movies (null rate, type, distinct
+ * values, range, most common values), for spotting bad fields before approval
+ *
+ * @returns The profiles, or null if the batch doesn't exist
+ */
+export const profileImportBatch = async (
+  batchId: number
+): Promise<{ batch_id: number; movie_count: number; fields: FieldProfile[] } | null> => {
+  const batch = await pool.query('SELECT batch_id FROM import_batches WHERE batch_id = $1', [batchId]);
+  if (batch.rows.length === 0) {
+    return null;
+  }
+
+  const movies = await pool.query<{ data: Record<string, unknown> }>(
+    'SELECT data FROM import_batch_movies WHERE batch_id = $1 ORDER BY position',
+    [batchId]
+  );
+  return {
+    batch_id: batchId,
+    movie_count: movies.rows.length,
+    fields: profileRecords(movies.rows.map(row => row.data))
+  };
+};
+
+/**
+ * Inserts every staged movie of a pending batch in one transaction and marks
+ * the batch approved. If any movie fails, nothing is inserted and the batch
+ * stays pending.
+ */
+export const promoteImportBatch = async (
+  batchId: number,
+  reviewedBy: number | null
+): Promise<PromoteResult> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const batch = await client.query<{ status: string }>(
+      'SELECT status FROM import_batches WHERE batch_id = $1 FOR UPDATE',
+      [batchId]
+    );
+    if (batch.rows.length === 0) {
+      await client.query('ROLLBACK');
+      return { outcome: 'not_found' };
+    }
+    if (batch.rows[0].status !== 'pending') {
+      await client.query('ROLLBACK');
+      return { outcome: 'not_pending', status: batch.rows[0].status };
+    }
+
+    const staged = await client.query<{ position: number; data: MovieCreateInput }>(
+      'SELECT position, data FROM import_batch_movies WHERE batch_id = $1 ORDER BY position',
+      [batchId]
+    );
+
+    const runId = await startImportRun(client, 'batch', { batch_id: batchId, api_key_id: reviewedBy });
+    const movieIds: number[] = [];
+    for (const { position, data } of staged.rows) {
+      let movieId: number;
+      try {
+        movieId = (await insertMovie(client, data, runId)).movie_id;
+      } catch (error) {
+        await client.query('ROLLBACK');
+        return {
+          outcome: 'failed',
+          position,
+          title: data.title,
+          error: error instanceof Error ? error.message : 'Unknown error'
+        };
+      }
+      await client.query(
+        'UPDATE import_batch_movies SET movie_id = $3 WHERE batch_id = $1 AND position = $2',
+        [batchId, position, movieId]
+      );
+      movieIds.push(movieId);
+    }
+
+    await client.query(
+      `UPDATE import_batches
+       SET status = 'approved', reviewed_by = $2, reviewed_at = NOW()
+       WHERE batch_id = $1`,
+      [batchId, reviewedBy]
+    );
+
+    await recordAudit(client, {
+      action: 'import.approve',
+      entity_type: 'import_batch',
+      entity_id: batchId,
+      details: { movie_count: movieIds.length },
+      performed_by: reviewedBy
+    });
+
+    await client.query('COMMIT');
+
+    // Keep the box-office time series in step with the import
+    if (movieIds.length > 0) {
+      scheduleBoxOfficeRefresh();
+    }
+
+    return { outcome: 'approved', movie_ids: movieIds };
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * Marks a pending batch rejected; its staged movies are kept for reference
+ *
+ * @returns The batch's status before, or null if it doesn't exist
+ */
+export const discardImportBatch = async (
+  batchId: number,
+  reviewedBy: number | null,
+  note: string | null
+): Promise<string | null> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const batch = await client.query<{ status: string }>(
+      'SELECT status FROM import_batches WHERE batch_id = $1 FOR UPDATE',
+      [batchId]
+    );
+    if (batch.rows.length === 0 || batch.rows[0].status !== 'pending') {
+      await client.query('ROLLBACK');
+      return batch.rows[0]?.status ?? null;
+    }
+
+    await client.query(
+      `UPDATE import_batches
+       SET status = 'rejected', reviewed_by = $2, reviewed_at = NOW(), review_note = $3
+       WHERE batch_id = $1`,
+      [batchId, reviewedBy, note]
+    );
+
+    await recordAudit(client, {
+      action: 'import.reject',
+      entity_type: 'import_batch',
+      entity_id: batchId,
+      details: note ? { note } : {},
+      performed_by: reviewedBy
+    });
+
+    await client.query('COMMIT');
+    return 'pending';
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+// ============================================================================
+// Middleware
+// ============================================================================
+
+/**
+ * Stages the request's movies for review instead of inserting them, when
+ * IMPORT_STAGING=true or the request has ?stage=true. Goes after
+ * validateRequest, so only valid movies are staged.
+ *
+ * @param selectMovies - Movies in the validated body
+ */
+export const stageImports = (selectMovies: (body: any) => MovieCreateInput[]) =>
+  async (req: ApiKeyRequest, res: Response, next: NextFunction): Promise<void> => {
+    const validation = stageQuerySchema.safeParse(req.query);
+    if (!validation.success) {
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest(validation.error.issues)
+      );
+      return;
+    }
+
+    if (!isImportStagingEnabled() && !validation.data.stage) {
+      next();
+      return;
+    }
+
+    try {
+      const batchId = await stageImportBatch(selectMovies(req.body), req.apiKey?.api_key_id ?? null);
+      const summary = await summarizeImportBatch(batchId);
+
+      res.status(HttpStatus.ACCEPTED).json({
+        success: true,
+        staged: true,
+        batch_id: batchId,
+        message: `Import staged as batch ${batchId}; an admin must approve it`,
+        data: summary
+      });
+    } catch (error) {
+      console.error('Error staging import:', error);
+      res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+        ApiError.internalError('Failed to stage import')
+      );
+    }
+  };
+
+// ============================================================================
+// Import Review Controllers
+// ============================================================================
+
+/**
+ * GET /api/admin/imports
+ * Staged import batches, oldest first while pending
+ *
+ * Query Parameters:
+ * - status: pending (default) | approved | rejected
+ * - page: Page number (default: 1)
+ * - limit: Items per page (default: 20, max: 100)
+ *
+ * @returns Batches without their movies
+ */
+export const listImportBatches = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = listBatchesSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { status, page, limit } = validation.data;
+  const order = status === 'pending' ? 'ASC' : 'DESC';
+
+  try {
+    const [count, batches] = await Promise.all([
+      pool.query<{ total: number }>(
+        'SELECT COUNT(*)::int AS total FROM import_batches WHERE status = $1',
+        [status]
+      ),
+      pool.query(
+        `SELECT batch_id, status, movie_count, submitted_by, submitted_at,
+                reviewed_by, reviewed_at, review_note
+         FROM import_batches
+         WHERE status = $1
+         ORDER BY submitted_at ${order}, batch_id ${order}
+         LIMIT $2 OFFSET $3`,
+        [status, limit, (page - 1) * limit]
+      )
+    ]);
+
+    res.status(HttpStatus.OK).json({
+      data: batches.rows,
+      meta: paginationMeta(page, limit, count.rows[0].total)
+    });
+  } catch (error) {
+    console.error('Error fetching import batches:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch import batches')
+    );
+  }
+};
+
+/**
+ * GET /api/admin/imports/:batchId
+ * Diff summary of a batch: its movies, possible duplicates already in the
+ * catalog, and the names approval would add
+ *
+ * @param batchId - Import batch ID
+ * @returns The batch summary
+ */
+export const getImportBatch = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const batchId = parseBatchId(req, res);
+  if (batchId === null) return;
+
+  try {
+    const summary = await summarizeImportBatch(batchId);
+    if (!summary) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Import batch with ID ${batchId} not found`, 'IMPORT_BATCH_NOT_FOUND', { id: batchId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({ data: summary });
+  } catch (error) {
+    console.error('Error fetching import batch:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch import batch')
+    );
+  }
+};
+
+/**
+ * GET /api/admin/imports/:batchId/profile
+ * Per-field profile of a batch's movies: null rate, detected type, distinct
+ * count, min/max, most common values and warnings such as mixed types
+ *
+ * @param batchId - Import batch ID
+ * @returns One profile per field, nested fields by path (cast[].actor_name)
+ */
+export const getImportBatchProfile = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const batchId = parseBatchId(req, res);
+  if (batchId === null) return;
+
+  try {
+    const profile = await profileImportBatch(batchId);
+    if (!profile) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Import batch with ID ${batchId} not found`, 'IMPORT_BATCH_NOT_FOUND', { id: batchId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({ data: profile });
+  } catch (error) {
+    console.error('Error profiling import batch:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to profile import batch')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/imports/:batchId/approve
+ * Insert a pending batch's movies into the catalog, all or nothing
+ *
+ * @param batchId - Import batch ID
+ * @returns IDs of the inserted movies, in batch order
+ */
+export const approveImportBatch = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const batchId = parseBatchId(req, res);
+  if (batchId === null) return;
+
+  try {
+    const result = await promoteImportBatch(batchId, req.apiKey?.api_key_id ?? null);
+
+    switch (result.outcome) {
+      case 'not_found':
+        res.status(HttpStatus.NOT_FOUND).json(
+          ApiError.notFound(`Import batch with ID ${batchId} not found`, 'IMPORT_BATCH_NOT_FOUND', { id: batchId })
+        );
+        return;
+      case 'not_pending':
+        res.status(HttpStatus.CONFLICT).json(
+          ApiError.conflict(`Import batch is already ${result.status}`, 'IMPORT_BATCH_NOT_PENDING', { status: result.status })
+        );
+        return;
+      case 'failed':
+        res.status(HttpStatus.CONFLICT).json(
+          ApiError.conflict(`Movie ${result.position} ("${result.title}") failed to import, nothing was approved: ${result.error}`, 'IMPORT_MOVIE_FAILED', { position: result.position, title: result.title, error: result.error })
+        );
+        return;
+      case 'approved':
+        res.status(HttpStatus.OK).json({
+          success: true,
+          batch_id: batchId,
+          movie_ids: result.movie_ids,
+          message: `Imported ${result.movie_ids.length} movies from batch ${batchId}`
+        });
+    }
+  } catch (error) {
+    console.error('Error approving import batch:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to approve import batch')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/imports/:batchId/reject
+ * Discard a pending batch
+ *
+ * Body: { note?: string } - Reason kept with the batch
+ *
+ * @param batchId - Import batch ID
+ */
+export const rejectImportBatch = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const batchId = parseBatchId(req, res);
+  if (batchId === null) return;
+
+  const validation = rejectBatchSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  try {
+    const previous = await discardImportBatch(
+      batchId,
+      req.apiKey?.api_key_id ?? null,
+      sanitizeText(validation.data.note) ?? null
+    );
+
+    if (previous === null) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Import batch with ID ${batchId} not found`, 'IMPORT_BATCH_NOT_FOUND', { id: batchId })
+      );
+      return;
+    }
+    if (previous !== 'pending') {
+      res.status(HttpStatus.CONFLICT).json(
+        ApiError.conflict(`Import batch is already ${previous}`, 'IMPORT_BATCH_NOT_PENDING', { status: previous })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      batch_id: batchId,
+      message: `Import batch ${batchId} rejected`
+    });
+  } catch (error) {
+    console.error('Error rejecting import batch:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to reject import batch')
+    );
+  }
+};
+
+// ============================================================================
+// Lineage Controllers
+// ============================================================================
+
+/**
+ * GET /api/admin/movies/:id/lineage
+ * Which import runs created and changed a movie, newest first, with the
+ * columns each one changed (old and new values)
+ *
+ * Query Parameters:
+ * - field: Only runs that changed this column, e.g. overview
+ * - page: Page number (default: 1)
+ * - limit: Items per page (default: 20, max: 100)
+ *
+ * @param id - Movie ID
+ * @returns The movie's lineage entries and the run that created it
+ */
+export const getMovieLineage = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const validation = lineageQuerySchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { field, page, limit } = validation.data;
+
+  try {
+    const movie = await pool.query<{ movie_id: number; title: string }>(
+      'SELECT movie_id, title FROM movies WHERE movie_id = $1',
+      [movieId]
+    );
+    if (movie.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const runColumns = `
+      r.run_id, r.kind, r.source, r.batch_id, r.api_key_id, r.started_at,
+      l.action, l.changes, l.recorded_at`;
+
+    const [count, entries, created] = await Promise.all([
+      pool.query<{ total: number }>(
+        `SELECT COUNT(*)::int AS total FROM movie_lineage l
+         WHERE l.movie_id = $1 AND ($2::text IS NULL OR l.changes ? $2)`,
+        [movieId, field ?? null]
+      ),
+      pool.query(
+        `SELECT ${runColumns}
+         FROM movie_lineage l
+         JOIN import_runs r ON r.run_id = l.run_id
+         WHERE l.movie_id = $1 AND ($2::text IS NULL OR l.changes ? $2)
+         ORDER BY l.recorded_at DESC, l.run_id DESC
+         LIMIT $3 OFFSET $4`,
+        [movieId, field ?? null, limit, (page - 1) * limit]
+      ),
+      pool.query(
+        `SELECT r.run_id, r.kind, r.source, r.batch_id, r.api_key_id, r.started_at
+         FROM movie_lineage l
+         JOIN import_runs r ON r.run_id = l.run_id
+         WHERE l.movie_id = $1 AND l.action = 'created'`,
+        [movieId]
+      )
+    ]);
+
+    res.status(HttpStatus.OK).json({
+      movie_id: movieId,
+      title: movie.rows[0].title,
+      // Null for movies added before lineage was recorded, or by hand
+      created_by: created.rows[0] ?? null,
+      data: entries.rows,
+      meta: paginationMeta(page, limit, count.rows[0].total)
+    });
+  } catch (error) {
+    console.error('Error fetching movie lineage:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch movie lineage')
+    );
+  }
+};
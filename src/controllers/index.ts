@@ -8,4 +8,39 @@ export * from './collectionControllers'
 export * from './directorControllers'
 export * from './studioControllers'
 export * from './auth';
-export * from './apiKey';
\ No newline at end of file
+export * from './apiKey';
+export * from './translationControllers';
+export * from './adminControllers';
+export * from './duplicateControllers';
+export * from './bulkControllers';
+export * from './savedSearchControllers';
+export * from './boxOfficeControllers';
+export * from './exportControllers';
+export * from './metricsControllers';
+export * from './featureControllers';
+export * from './accountControllers';
+export * from './userControllers';
+export * from './jobControllers';
+export * from './searchRankingControllers';
+export * from './reviewControllers';
+export * from './watchlistControllers';
+export * from './followControllers';
+export * from './listControllers';
+export * from './digestControllers';
+export * from './deviceControllers';
+export * from './calendarControllers';
+export * from './feedControllers';
+export * from './seoControllers';
+export * from './oembedControllers';
+export * from './shortLinkControllers';
+export * from './placeholderControllers';
+export * from './assetControllers';
+export * from './liveUpdateControllers';
+export * from './importControllers';
+export * from './movieHistoryControllers';
+export * from './semanticSearchControllers';
+export * from './naturalQueryControllers';
+export * from './datasetControllers';
+export * from './ogImageControllers';
+export * from './postmanControllers';
+export * from './keywordControllers';
\ No newline at end of file
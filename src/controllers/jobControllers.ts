@@ -0,0 +1,75 @@
+// server/src/controllers/jobControllers.ts
+
+import { Request, Response } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import pool from '@utils/database';
+import { getJob, getJobRuns, runJob } from '@utils/jobs';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+
+// ============================================================================
+// Background Job Controllers
+// ============================================================================
+
+/**
+ * GET /api/admin/jobs
+ * Scheduled background jobs and how each last ran
+ *
+ * @returns List of jobs with their last run (null if never run)
+ */
+export const getJobList = async (req: Request, res: Response): Promise<void> => {
+  try {
+    res.status(HttpStatus.OK).json({ data: await getJobRuns() });
+  } catch (error) {
+    console.error('Error fetching jobs:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch jobs')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/jobs/:name/run
+ * Run a job now instead of waiting for its nightly slot
+ *
+ * Waits for the job to finish. Answers 409 if it is already running
+ * (here or on another instance).
+ *
+ * @param name - Job name
+ * @returns The finished run
+ */
+export const runJobNow = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const job = getJob(req.params.name);
+  if (!job) {
+    res.status(HttpStatus.NOT_FOUND).json(
+      ApiError.notFound(`Unknown job "${req.params.name}"`, 'JOB_NOT_FOUND', { name: req.params.name })
+    );
+    return;
+  }
+
+  try {
+    const run = await runJob(job, true);
+    if (!run) {
+      res.status(HttpStatus.CONFLICT).json(
+        ApiError.createResponse(HttpStatus.CONFLICT, `Job "${job.name}" is already running`, 'JOB_RUNNING', { name: job.name })
+      );
+      return;
+    }
+
+    await recordAudit(pool, {
+      action: 'job.run',
+      entity_type: 'job',
+      entity_id: null,
+      details: { job: job.name, succeeded: run.succeeded, summary: run.summary },
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    res.status(HttpStatus.OK).json({ success: run.succeeded === true, data: run });
+  } catch (error) {
+    console.error('Error running job:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to run job')
+    );
+  }
+};
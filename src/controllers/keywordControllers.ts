@@ -0,0 +1,113 @@
+// server/src/controllers/keywordControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { normalizeKeyword } from '@utils/movieKeywords';
+import { KeywordListResponse, KeywordWithCount } from '@models';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const searchSchema = z.object({
+    name: z.string().min(1).optional(),
+    page: z.coerce.number().int().positive().default(1),
+    limit: z.coerce.number().int().min(1).max(100).default(20),
+    sortBy: z.enum(['name', 'movie_count']).optional().default('movie_count'),
+    sortOrder: z.enum(['asc', 'desc']).optional()
+});
+
+// ============================================================================
+// Keyword Controllers
+// ============================================================================
+
+/**
+ * GET /api/keywords
+ * Lists keywords with the number of movies tagged with each, for browsing
+ * movies by tag (GET /api/movies?keyword=)
+ *
+ * Query Parameters:
+ * - name: string (optional) - Search by keyword
+ * - page: number (default: 1)
+ * - limit: number (default: 20, max: 100)
+ * - sortBy: 'name' | 'movie_count' (default: 'movie_count')
+ * - sortOrder: 'asc' | 'desc' (default: 'desc' for movie_count, 'asc' for name)
+ *
+ * @returns Paginated keywords with movie counts
+ */
+export const getAllKeywords = async (req: Request, res: Response): Promise<void> => {
+    const validation = searchSchema.safeParse(req.query);
+
+    if (!validation.success) {
+        res.status(HttpStatus.BAD_REQUEST).json(
+            ApiError.badRequest(validation.error.issues)
+        );
+        return;
+    }
+
+    const { name, page, limit, sortBy } = validation.data;
+    const sortOrder = (validation.data.sortOrder ?? (sortBy === 'name' ? 'asc' : 'desc')).toUpperCase();
+    const offset = (page - 1) * limit;
+
+    try {
+        const params: any[] = [];
+        let whereClause = '';
+        if (name) {
+            params.push(`%${normalizeKeyword(name)}%`);
+            whereClause = 'WHERE k.keyword_name LIKE $1';
+        }
+
+        const orderByClause = sortBy === 'name'
+            ? `ORDER BY k.keyword_name ${sortOrder}`
+            : `ORDER BY movie_count ${sortOrder}, k.keyword_name ASC`;
+
+        const countSql = `
+      SELECT COUNT(*)::int AS total
+      FROM keywords k
+      ${whereClause}
+    `;
+
+        const dataSql = `
+      SELECT
+        k.keyword_id,
+        k.keyword_name,
+        COUNT(mk.movie_id)::int AS movie_count
+      FROM keywords k
+      LEFT JOIN movie_keywords mk ON k.keyword_id = mk.keyword_id
+      ${whereClause}
+      GROUP BY k.keyword_id, k.keyword_name
+      ${orderByClause}
+      LIMIT $${params.length + 1} OFFSET $${params.length + 2}
+    `;
+
+        const [countResult, dataResult] = await Promise.all([
+            pool.query<{ total: number; }>(countSql, params),
+            pool.query<KeywordWithCount>(dataSql, [...params, limit, offset])
+        ]);
+
+        const total = countResult.rows[0].total;
+        const pages = Math.max(1, Math.ceil(total / limit));
+
+        const response: KeywordListResponse = {
+            data: dataResult.rows,
+            meta: {
+                page,
+                limit,
+                total,
+                pages,
+                hasNextPage: page < pages,
+                hasPreviousPage: page > 1
+            }
+        };
+
+        res.status(HttpStatus.OK).json(response);
+    } catch (error) {
+        console.error('Error fetching keywords:', error);
+        res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+            ApiError.internalError('Failed to fetch keywords')
+        );
+    }
+};
@@ -0,0 +1,668 @@
+// server/src/controllers/listControllers.ts
+
+import crypto from 'crypto';
+import { Request, Response } from 'express';
+import { PoolClient } from 'pg';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { sanitizeText } from '@utils/sanitize';
+import { shouldHideAdultContent, nonAdultCondition } from '@utils/contentFilter';
+import { UserRequest } from '@middleware/userAuth';
+import { PUBLIC_ID_PATTERN, getIdStrategy, toMovieIds } from '@middleware/resolveMovieId';
+import z from 'zod';
+
+/**
+ * Upper bounds per user and per list
+ */
+const MAX_LISTS_PER_USER = 100;
+const MAX_ITEMS_PER_LIST = 500;
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const visibilitySchema = z.enum(['private', 'unlisted', 'public']);
+
+const createListSchema = z.object({
+  name: z.string().trim().min(1).max(100),
+  description: z.string().trim().max(2000).nullable().optional(),
+  visibility: visibilitySchema.default('private')
+});
+
+const updateListSchema = z.object({
+  name: z.string().trim().min(1).max(100).optional(),
+  description: z.string().trim().max(2000).nullable().optional(),
+  visibility: visibilitySchema.optional()
+}).refine(
+  (body) => body.name !== undefined || body.description !== undefined || body.visibility !== undefined,
+  'Provide name, description and/or visibility'
+);
+
+const listItemSchema = z.object({
+  // Where to insert, 1 = first; appended when omitted or past the end
+  position: z.number().int().positive().optional(),
+  note: z.string().trim().max(500).nullable().optional()
+});
+
+const reorderSchema = z.object({
+  // Integer movie_ids or public_ids, as in movie URLs
+  movie_ids: z.array(z.union([
+    z.number().int().positive(),
+    z.string().regex(PUBLIC_ID_PATTERN)
+  ])).min(1)
+});
+
+const sharedListSchema = z.object({
+  include_adult: z.stringbool().default(false)
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+interface ListRow {
+  list_id: number;
+  user_id: number;
+  username: string;
+  name: string;
+  description: string | null;
+  slug: string;
+  visibility: 'private' | 'unlisted' | 'public';
+  item_count: number;
+  created_at: Date;
+  updated_at: Date;
+}
+
+const LIST_COLUMNS = `
+  l.list_id, l.user_id, u.username, l.name, l.description, l.slug, l.visibility,
+  (SELECT COUNT(*)::int FROM movie_list_items i WHERE i.list_id = l.list_id) AS item_count,
+  l.created_at, l.updated_at`;
+
+/**
+ * URL-safe slug from a list name plus a random suffix, so names can repeat
+ * and unlisted lists can't be guessed ("Best Heist Movies" -> "best-heist-movies-3f9a0c1b")
+ */
+const createSlug = (name: string): string => {
+  const base = name
+    .normalize('NFKD')
+    .replace(/[\u0300-\u036f]/g, '')
+    .toLowerCase()
+    .replace(/[^a-z0-9]+/g, '-')
+    .replace(/^-+|-+$/g, '')
+    .slice(0, 100);
+  const suffix = crypto.randomBytes(4).toString('hex');
+  return base ? `${base}-${suffix}` : suffix;
+};
+
+/**
+ * Adds the link that serves the list without signing in (null while private)
+ */
+const withShareUrl = (list: ListRow) => ({
+  ...list,
+  share_url: list.visibility === 'private' ? null : `/api/lists/${list.slug}`
+});
+
+/**
+ * Movies on a list in order, leaving out adult titles when hideAdult is set
+ */
+const getListItems = async (db: PoolClient | typeof pool, listId: number, hideAdult = false) => {
+  const result = await db.query(
+    `SELECT i.position, m.movie_id, m.public_id, m.title, m.release_date, m.poster_url,
+            i.note, i.added_at
+     FROM movie_list_items i
+     JOIN movies m ON m.movie_id = i.movie_id
+     WHERE i.list_id = $1
+       ${hideAdult ? `AND ${nonAdultCondition('m')}` : ''}
+     ORDER BY i.position`,
+    [listId]
+  );
+  return result.rows;
+};
+
+/**
+ * Parses :listId and loads the list if the signed-in user owns it,
+ * responding with 400/404 otherwise. Pass a transaction client to lock the row.
+ */
+const findOwnList = async (
+  req: UserRequest,
+  res: Response,
+  db: PoolClient | typeof pool = pool
+): Promise<ListRow | null> => {
+  const listId = parseInt(req.params.listId, 10);
+  if (isNaN(listId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('List ID must be a valid number', 'INVALID_ID')
+    );
+    return null;
+  }
+
+  const result = await db.query<ListRow>(
+    `SELECT ${LIST_COLUMNS}
+     FROM movie_lists l
+     JOIN users u ON u.user_id = l.user_id
+     WHERE l.list_id = $1 AND l.user_id = $2
+     ${db === pool ? '' : 'FOR UPDATE OF l'}`,
+    [listId, req.user!.userId]
+  );
+
+  if (result.rows.length === 0) {
+    res.status(HttpStatus.NOT_FOUND).json(
+      ApiError.notFound(`List with ID ${listId} not found`, 'LIST_NOT_FOUND', { id: listId })
+    );
+    return null;
+  }
+  return result.rows[0];
+};
+
+// ============================================================================
+// List Controllers
+// ============================================================================
+
+/**
+ * GET /api/lists/:slug
+ * A shared list, without signing in or an API key
+ *
+ * Works for public and unlisted lists; private lists answer 404. Adult
+ * titles are left out like on the other public movie lists, and item_count
+ * counts only the movies shown.
+ *
+ * Query Parameters:
+ * - include_adult: Include adult titles where the server allows it (default: false)
+ *
+ * @param slug - The list's slug from its share_url
+ * @returns The list and its movies in order
+ */
+export const getSharedList = async (req: Request, res: Response): Promise<void> => {
+  const validation = sharedListSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  try {
+    const result = await pool.query<ListRow>(
+      `SELECT ${LIST_COLUMNS}
+       FROM movie_lists l
+       JOIN users u ON u.user_id = l.user_id
+       WHERE l.slug = $1 AND l.visibility <> 'private'`,
+      [req.params.slug]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('List not found', 'LIST_NOT_FOUND')
+      );
+      return;
+    }
+
+    const list = result.rows[0];
+    const movies = await getListItems(pool, list.list_id, shouldHideAdultContent(validation.data.include_adult));
+    res.status(HttpStatus.OK).json({
+      ...withShareUrl(list),
+      item_count: movies.length,
+      movies
+    });
+  } catch (error) {
+    console.error('Error fetching shared list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch list')
+    );
+  }
+};
+
+/**
+ * GET /api/me/lists
+ * The signed-in user's lists, most recently updated first
+ *
+ * @returns Lists with item counts (no movies)
+ */
+export const getMyLists = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query<ListRow>(
+      `SELECT ${LIST_COLUMNS}
+       FROM movie_lists l
+       JOIN users u ON u.user_id = l.user_id
+       WHERE l.user_id = $1
+       ORDER BY l.updated_at DESC`,
+      [req.user!.userId]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows.map(withShareUrl),
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching lists:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch lists')
+    );
+  }
+};
+
+/**
+ * POST /api/me/lists
+ * Create a list
+ *
+ * Body: { name, description?, visibility?: 'private' (default) | 'unlisted' | 'public' }
+ *
+ * @returns The new list with its slug and share_url
+ */
+export const createList = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = createListSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { name, description, visibility } = validation.data;
+  const userId = req.user!.userId;
+
+  try {
+    const count = await pool.query<{ total: number }>(
+      'SELECT COUNT(*)::int AS total FROM movie_lists WHERE user_id = $1',
+      [userId]
+    );
+    if (count.rows[0].total >= MAX_LISTS_PER_USER) {
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest(`You can have at most ${MAX_LISTS_PER_USER} lists`, 'LIST_LIMIT', { max: MAX_LISTS_PER_USER })
+      );
+      return;
+    }
+
+    const created = await pool.query<{ list_id: number }>(
+      `INSERT INTO movie_lists (user_id, name, description, slug, visibility)
+       VALUES ($1, $2, $3, $4, $5)
+       RETURNING list_id`,
+      [userId, sanitizeText(name), sanitizeText(description) || null, createSlug(name), visibility]
+    );
+
+    const result = await pool.query<ListRow>(
+      `SELECT ${LIST_COLUMNS}
+       FROM movie_lists l
+       JOIN users u ON u.user_id = l.user_id
+       WHERE l.list_id = $1`,
+      [created.rows[0].list_id]
+    );
+
+    res.status(HttpStatus.CREATED).json({
+      success: true,
+      data: { ...withShareUrl(result.rows[0]), movies: [] }
+    });
+  } catch (error) {
+    console.error('Error creating list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to create list')
+    );
+  }
+};
+
+/**
+ * GET /api/me/lists/:listId
+ * One of the signed-in user's lists, whatever its visibility
+ *
+ * @param listId - List ID
+ * @returns The list and its movies in order
+ */
+export const getMyList = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const list = await findOwnList(req, res);
+    if (!list) return;
+
+    res.status(HttpStatus.OK).json({
+      ...withShareUrl(list),
+      movies: await getListItems(pool, list.list_id)
+    });
+  } catch (error) {
+    console.error('Error fetching list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch list')
+    );
+  }
+};
+
+/**
+ * PATCH /api/me/lists/:listId
+ * Rename a list, change its description or visibility
+ *
+ * The slug (and so the share link) stays the same.
+ *
+ * @param listId - List ID
+ * @returns The updated list
+ */
+export const updateList = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = updateListSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { name, description, visibility } = validation.data;
+
+  try {
+    const list = await findOwnList(req, res);
+    if (!list) return;
+
+    const result = await pool.query<ListRow>(
+      `WITH updated AS (
+         UPDATE movie_lists
+         SET name = COALESCE($2, name),
+             description = CASE WHEN $3::boolean THEN $4 ELSE description END,
+             visibility = COALESCE($5, visibility),
+             updated_at = NOW()
+         WHERE list_id = $1
+         RETURNING *
+       )
+       SELECT ${LIST_COLUMNS}
+       FROM updated l
+       JOIN users u ON u.user_id = l.user_id`,
+      [
+        list.list_id,
+        name === undefined ? null : sanitizeText(name),
+        description !== undefined,
+        sanitizeText(description) || null,
+        visibility ?? null
+      ]
+    );
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      data: withShareUrl(result.rows[0])
+    });
+  } catch (error) {
+    console.error('Error updating list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update list')
+    );
+  }
+};
+
+/**
+ * DELETE /api/me/lists/:listId
+ * Delete a list; its share link stops working
+ *
+ * @param listId - List ID
+ * @returns Confirmation
+ */
+export const deleteList = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const list = await findOwnList(req, res);
+    if (!list) return;
+
+    await pool.query('DELETE FROM movie_lists WHERE list_id = $1', [list.list_id]);
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'List deleted'
+    });
+  } catch (error) {
+    console.error('Error deleting list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to delete list')
+    );
+  }
+};
+
+/**
+ * PUT /api/me/lists/:listId/movies/:id
+ * Add a movie to a list, or move it and update its note if already there
+ *
+ * Body: { position?: number, note?: string }
+ * Movies at and after position shift down one; without a position a new
+ * movie goes last and an existing one stays put.
+ *
+ * @param listId - List ID
+ * @param id - Movie ID
+ * @returns The list's movies in their new order
+ */
+export const putListItem = async (req: UserRequest, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  const validation = listItemSchema.safeParse(req.body ?? {});
+
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { position, note } = validation.data;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const list = await findOwnList(req, res, client);
+    if (!list) {
+      await client.query('ROLLBACK');
+      return;
+    }
+
+    const movie = await client.query('SELECT 1 FROM movies WHERE movie_id = $1', [movieId]);
+    if (movie.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const existing = await client.query<{ position: number }>(
+      'SELECT position FROM movie_list_items WHERE list_id = $1 AND movie_id = $2',
+      [list.list_id, movieId]
+    );
+
+    if (existing.rows.length === 0 && list.item_count >= MAX_ITEMS_PER_LIST) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest(`A list can hold at most ${MAX_ITEMS_PER_LIST} movies`, 'LIST_LIMIT', { max: MAX_ITEMS_PER_LIST })
+      );
+      return;
+    }
+
+    // Take the movie out (closing its gap) and put it back where it belongs
+    if (existing.rows.length > 0) {
+      await client.query(
+        'DELETE FROM movie_list_items WHERE list_id = $1 AND movie_id = $2',
+        [list.list_id, movieId]
+      );
+      await client.query(
+        'UPDATE movie_list_items SET position = position - 1 WHERE list_id = $1 AND position > $2',
+        [list.list_id, existing.rows[0].position]
+      );
+    }
+
+    const last = await client.query<{ max: number }>(
+      'SELECT COALESCE(MAX(position), 0)::int AS max FROM movie_list_items WHERE list_id = $1',
+      [list.list_id]
+    );
+    const target = Math.min(position ?? existing.rows[0]?.position ?? Infinity, last.rows[0].max + 1);
+
+    await client.query(
+      'UPDATE movie_list_items SET position = position + 1 WHERE list_id = $1 AND position >= $2',
+      [list.list_id, target]
+    );
+    await client.query(
+      `INSERT INTO movie_list_items (list_id, movie_id, position, note)
+       VALUES ($1, $2, $3, $4)`,
+      [list.list_id, movieId, target, note === undefined ? null : sanitizeText(note) || null]
+    );
+    await client.query('UPDATE movie_lists SET updated_at = NOW() WHERE list_id = $1', [list.list_id]);
+
+    const movies = await getListItems(client, list.list_id);
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      movies
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error adding movie to list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update list')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * DELETE /api/me/lists/:listId/movies/:id
+ * Take a movie off a list; the ones after it move up
+ *
+ * @param listId - List ID
+ * @param id - Movie ID
+ * @returns The list's remaining movies in order
+ */
+export const deleteListItem = async (req: UserRequest, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const list = await findOwnList(req, res, client);
+    if (!list) {
+      await client.query('ROLLBACK');
+      return;
+    }
+
+    const removed = await client.query<{ position: number }>(
+      'DELETE FROM movie_list_items WHERE list_id = $1 AND movie_id = $2 RETURNING position',
+      [list.list_id, movieId]
+    );
+    if (removed.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Movie is not on this list', 'NOT_ON_LIST')
+      );
+      return;
+    }
+
+    await client.query(
+      'UPDATE movie_list_items SET position = position - 1 WHERE list_id = $1 AND position > $2',
+      [list.list_id, removed.rows[0].position]
+    );
+    await client.query('UPDATE movie_lists SET updated_at = NOW() WHERE list_id = $1', [list.list_id]);
+
+    const movies = await getListItems(client, list.list_id);
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      movies
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error removing movie from list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update list')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * PUT /api/me/lists/:listId/order
+ * Reorder a whole list at once
+ *
+ * Body: { movie_ids: (number | string)[] } - Every movie on the list, each once,
+ * in the new order, by movie_id or public_id
+ *
+ * @param listId - List ID
+ * @returns The list's movies in their new order
+ */
+export const reorderList = async (req: UserRequest, res: Response): Promise<void> => {
+  const validation = reorderSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  if (getIdStrategy() === 'public' && validation.data.movie_ids.some(id => typeof id === 'number')) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movies are addressed by public_id on this server', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    // Unknown public IDs stay null and fail the check below
+    const movie_ids = await toMovieIds(validation.data.movie_ids);
+
+    await client.query('BEGIN');
+
+    const list = await findOwnList(req, res, client);
+    if (!list) {
+      await client.query('ROLLBACK');
+      return;
+    }
+
+    const current = await client.query<{ movie_id: number }>(
+      'SELECT movie_id FROM movie_list_items WHERE list_id = $1',
+      [list.list_id]
+    );
+    const onList = new Set(current.rows.map(row => row.movie_id));
+    const sameMovies = movie_ids.length === onList.size
+      && new Set(movie_ids).size === movie_ids.length
+      && movie_ids.every(id => id !== null && onList.has(id));
+
+    if (!sameMovies) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest('movie_ids must list every movie on the list exactly once', 'INVALID_LIST_ORDER')
+      );
+      return;
+    }
+
+    await client.query(
+      `UPDATE movie_list_items i
+       SET position = ordered.position
+       FROM UNNEST($2::int[]) WITH ORDINALITY AS ordered(movie_id, position)
+       WHERE i.list_id = $1 AND i.movie_id = ordered.movie_id`,
+      [list.list_id, movie_ids]
+    );
+    await client.query('UPDATE movie_lists SET updated_at = NOW() WHERE list_id = $1', [list.list_id]);
+
+    const movies = await getListItems(client, list.list_id);
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      movies
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error reordering list:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to reorder list')
+    );
+  } finally {
+    client.release();
+  }
+};
@@ -0,0 +1,59 @@
+// server/src/controllers/liveUpdateControllers.ts
+
+import { IncomingMessage } from 'http';
+import { Duplex } from 'stream';
+import { verifyApiKey } from '@middleware/apiKeyAuth';
+import { addLiveClient, liveClientCount, MAX_LIVE_CLIENTS } from '@utils/liveUpdates';
+import { acceptWebSocket, rejectUpgrade } from '@utils/webSocket';
+
+// ============================================================================
+// Live Update Controllers
+// ============================================================================
+
+/**
+ * GET /ws (WebSocket upgrade)
+ * Stream of catalog changes (movie created, updated or deleted) for the
+ * live admin dashboard
+ *
+ * Runs on the HTTP server's upgrade event, outside Express. Browsers can't
+ * set headers on a WebSocket, so the admin API key may also be passed as
+ * ?api_key=.
+ *
+ * @returns A WebSocket carrying one JSON message per change (see liveUpdates)
+ */
+export const handleLiveUpdatesUpgrade = async (req: IncomingMessage, socket: Duplex): Promise<void> => {
+  const url = new URL(req.url ?? '/', 'http://localhost');
+  const header = req.headers['x-api-key'];
+  const providedKey = (typeof header === 'string' ? header : null) ?? url.searchParams.get('api_key');
+
+  if (!providedKey) {
+    rejectUpgrade(socket, 401, 'Unauthorized');
+    return;
+  }
+
+  try {
+    const apiKey = await verifyApiKey(providedKey);
+    if (!apiKey) {
+      rejectUpgrade(socket, 401, 'Unauthorized');
+      return;
+    }
+    if (apiKey.role !== 'admin') {
+      rejectUpgrade(socket, 403, 'Forbidden');
+      return;
+    }
+  } catch (error) {
+    console.error('Error authenticating live updates connection:', error);
+    rejectUpgrade(socket, 500, 'Internal Server Error');
+    return;
+  }
+
+  if (liveClientCount() >= MAX_LIVE_CLIENTS) {
+    rejectUpgrade(socket, 503, 'Service Unavailable');
+    return;
+  }
+
+  const client = acceptWebSocket(req, socket);
+  if (client) {
+    addLiveClient(client);
+  }
+};
@@ -0,0 +1,36 @@
+// server/src/controllers/metricsControllers.ts
+
+import { Request, Response } from 'express';
+import { HttpStatus } from '@utils/httpStatus';
+import { getLatencyMetrics, resetLatencyMetrics } from '@utils/queryMetrics';
+
+// ============================================================================
+// Metrics Controllers
+// ============================================================================
+
+/**
+ * GET /api/admin/metrics/latency
+ * Request and database query latency per endpoint
+ *
+ * Collected in memory since startup or the last reset, so each instance
+ * reports only its own traffic. Endpoints are labelled by route pattern
+ * (e.g. "GET /api/movies/:id"); queries outside a request count as "background".
+ * slo_compliance is the share of requests answered within SLO_LATENCY_MS.
+ * Individual queries slower than SLOW_QUERY_MS are also written to the log.
+ *
+ * @returns Latency summaries, slowest endpoint first
+ */
+export const getEndpointLatency = async (req: Request, res: Response): Promise<void> => {
+  res.status(HttpStatus.OK).json(getLatencyMetrics());
+};
+
+/**
+ * DELETE /api/admin/metrics/latency
+ * Clear collected latency data, e.g. after deploying an index
+ *
+ * @returns Confirmation
+ */
+export const resetEndpointLatency = async (req: Request, res: Response): Promise<void> => {
+  resetLatencyMetrics();
+  res.status(HttpStatus.OK).json({ success: true, message: 'Latency metrics reset' });
+};
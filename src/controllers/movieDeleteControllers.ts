@@ -77,6 +77,7 @@ export const deleteMovieById = async (req: Request, res: Response) => {
         const getMovieSql = `
       SELECT 
         m.movie_id,
+        m.public_id,
         m.title, 
         m.original_title, 
         m.release_date,
@@ -104,7 +105,7 @@ export const deleteMovieById = async (req: Request, res: Response) => {
         if (!movieResult || movieResult.rowCount === 0) {
             await client.query('ROLLBACK');
             return res.status(HttpStatus.NOT_FOUND).json(
-                ApiError.notFound(`Movie with id ${id} not found`)
+                ApiError.notFound(`Movie with id ${id} not found`, 'MOVIE_NOT_FOUND', { id })
             );
         }
 
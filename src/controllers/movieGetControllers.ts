@@ -4,6 +4,12 @@ import { Request, Response } from 'express';
 import pool from '@utils/database';
 import { ApiError } from '@utils/httpError';
 import { HttpStatus } from '@utils/httpStatus';
+import { nonAdultCondition, shouldHideAdultContent } from '@utils/contentFilter';
+import { ERA_NAMES } from '@utils/inflation';
+import { getRankingWeights, RankingWeights } from '@utils/searchRanking';
+import { addMovieFilters, SqlFilter } from '@utils/sqlFilter';
+import { parseKeywords } from '@utils/movieKeywords';
+import { attachMovieIncludes, CAST_ORDER_SQL, MOVIE_RELATIONS } from '@utils/movieRelations';
 import z from 'zod';
 import { Movie } from '@models';
 
@@ -32,6 +38,36 @@ const paginationSchema = z.object({
   limit: z.coerce.number().int().min(1).max(100).default(20)
 });
 
+/**
+ * Adult content opt-in for public movie lists; the server's CONTENT_FILTER
+ * mode decides whether it is honoured
+ */
+const contentFilterSchema = z.object({
+  include_adult: z.stringbool().default(false)
+});
+
+/**
+ * Query for the movies-by-studio/director/actor/collection lists
+ */
+const movieListSchema = paginationSchema.extend(contentFilterSchema.shape);
+
+/**
+ * Query for GET /movies/:id/cast
+ */
+const castPageSchema = paginationSchema.extend({
+  credited: z.stringbool().optional()
+});
+
+/**
+ * Language tag used to localize title/overview (e.g. "es", "pt-BR")
+ */
+const languageSchema = z.string().regex(/^[a-z]{2}(-[A-Z]{2})?$/, 'lang must look like "es" or "pt-BR"');
+
+/**
+ * ETag for a movie's row version, used for If-Match preconditions on updates
+ */
+const movieETag = (version: number): string => `"${version}"`;
+
 /**
  * Consolidated schema for getAllMovies with all filtering options
  */
@@ -42,6 +78,7 @@ const getAllMoviesSchema = paginationSchema.extend({
   // Basic filters
   year: z.coerce.number().int().positive().optional(),
   genre: z.string().optional(),
+  keyword: z.string().transform(parseKeywords).optional(),
   rating: z.enum(MPA_RATINGS).optional(),
   
   // Related resources
@@ -55,18 +92,93 @@ const getAllMoviesSchema = paginationSchema.extend({
   maxBudget: z.coerce.number().int().nonnegative().optional(),
   minRevenue: z.coerce.number().int().nonnegative().optional(),
   maxRevenue: z.coerce.number().int().nonnegative().optional(),
+
+  // Runtime in minutes
+  minRuntime: z.coerce.number().int().nonnegative().optional(),
+  maxRuntime: z.coerce.number().int().nonnegative().optional(),
   
   // Date range
-  startDate: z.string().regex(/^\d{4}-\d{2}-\d{2}$/).optional(),
-  endDate: z.string().regex(/^\d{4}-\d{2}-\d{2}$/).optional()
+  startDate: z.iso.date('startDate must be a YYYY-MM-DD date').optional(),
+  endDate: z.iso.date('endDate must be a YYYY-MM-DD date').optional(),
+  era: z.enum(ERA_NAMES).optional(),
+
+  // Localization
+  lang: languageSchema.optional(),
+
+  // Content filtering
+  include_adult: contentFilterSchema.shape.include_adult,
+
+  // Sorting; defaults to relevance when searching by title, popularity otherwise
+  sortBy: z.enum(['relevance', 'popularity', 'title', 'release_date', 'runtime', 'budget', 'revenue', 'budget_adjusted', 'revenue_adjusted']).optional(),
+  // Defaults to desc for relevance and popularity (best first), asc otherwise
+  order: z.enum(['asc', 'desc']).optional()
+});
+
+type MovieSearchParams = z.infer<typeof getAllMoviesSchema>;
+
+/**
+ * Comma-separated relations to expand into each movie (see MOVIE_RELATIONS)
+ */
+const includeSchema = z.string()
+  .transform(value => value.split(',').map(part => part.trim()).filter(part => part.length > 0))
+  .pipe(z.array(z.enum(MOVIE_RELATIONS)))
+  .optional();
+
+/**
+ * Expanded relations and how many cast entries to inline per movie
+ * (default DEFAULT_CAST_LIMIT); the rest of the cast is paged with GET
+ * /movies/:id/cast
+ */
+const includeOptionsSchema = z.object({
+  include: includeSchema,
+  cast_limit: z.coerce.number().int().min(1).max(100).optional()
+});
+
+/**
+ * Opt-in extras for a search response: facet counts, expanded relations and,
+ * for tuning ranking, each result's score components. Kept out of getAllMoviesSchema so saved
+ * searches don't store them.
+ */
+const searchOptionsSchema = includeOptionsSchema.extend({
+  facets: z.stringbool().default(false),
+  explain: z.stringbool().default(false)
 });
 
+type SearchOptions = Partial<z.infer<typeof searchOptionsSchema>>;
+
+/**
+ * Column behind each sortBy value. Every column here must be indexed
+ * (see migration 008) so sorting never forces a full sort of the table.
+ * relevance is the exception: it is computed per query (see relevanceSql),
+ * and is only the default when a title search has narrowed the results.
+ */
+const MOVIE_SORT_COLUMNS: Record<NonNullable<MovieSearchParams['sortBy']>, string> = {
+  relevance: 'relevance',
+  popularity: 'm.popularity',
+  title: 'm.title',
+  release_date: 'm.release_date',
+  runtime: 'm.runtime_minutes',
+  budget: 'm.budget',
+  revenue: 'm.revenue',
+  budget_adjusted: 'm.budget_adjusted',
+  revenue_adjusted: 'm.revenue_adjusted'
+};
+
 // Export schemas
 export {
   MPA_RATINGS,
+  languageSchema,
+  movieETag,
+  CAST_ORDER_SQL,
   paginationSchema,
-  getAllMoviesSchema
+  contentFilterSchema,
+  movieListSchema,
+  getAllMoviesSchema,
+  includeSchema,
+  includeOptionsSchema,
+  searchOptionsSchema
 };
+export type { MovieSearchParams, SearchOptions };
 
 // ============================================================================
 // Helper Functions
@@ -75,6 +187,23 @@ export {
 /**
  * Creates a standardized pagination response object
  */
+interface FacetCount {
+  value: string | number;
+  count: number;
+}
+
+interface MovieFacets {
+  genres: FacetCount[];
+  decades: FacetCount[];
+  ratings: FacetCount[];
+}
+
+interface ScoreComponents {
+  title_match: number;
+  popularity: number;
+  recency: number;
+}
+
 interface PaginationMeta {
   page: number;
   limit: number;
@@ -109,6 +238,64 @@ const createPaginationResponse = (
   return { data, meta };
 };
 
+/**
+ * Score components for sortBy=relevance, each between 0 and 1, and their
+ * weighted sum. Adds its parameters to filter.
+ *
+ * A title match scores 1 when exact, 0.75 for a prefix, 0.5 when the term
+ * starts a later word and 0.25 for any other substring (case-insensitive).
+ */
+const relevanceSql = (
+  title: string | undefined,
+  weights: RankingWeights,
+  filter: SqlFilter
+): string => {
+  const [term, titleWeight, popularityWeight, recencyWeight, halfLife] = [
+    title ?? null,
+    weights.title_weight,
+    weights.popularity_weight,
+    weights.recency_weight,
+    weights.recency_half_life_years
+  ].map(value => filter.param(value));
+
+  const titleMatch = `CASE
+        WHEN ${term}::text IS NULL THEN 0
+        WHEN LOWER(m.title) = LOWER(${term}) THEN 1
+        WHEN m.title ILIKE ${term} || '%' THEN 0.75
+        WHEN m.title ILIKE '% ' || ${term} || '%' THEN 0.5
+        WHEN m.title ILIKE '%' || ${term} || '%' THEN 0.25
+        ELSE 0
+      END`;
+  const popularity = 'COALESCE(m.popularity, 0)';
+  const recency = `COALESCE(POWER(0.5,
+        GREATEST(CURRENT_DATE - m.release_date, 0) / (NULLIF(${halfLife}::float8, 0) * 365.25)
+      ), 0)`;
+
+  return `
+      (${titleMatch})::float8 AS score_title_match,
+      ${popularity}::float8 AS score_popularity,
+      ${recency}::float8 AS score_recency,
+      (${titleWeight}::float8 * (${titleMatch})
+        + ${popularityWeight}::float8 * ${popularity}
+        + ${recencyWeight}::float8 * ${recency})::float8 AS relevance,`;
+};
+
+/**
+ * Moves the score columns of each row into a score object (explain mode) or
+ * drops them
+ */
+const applyScores = (rows: any[], explain: boolean) =>
+  rows.map(({ score_title_match, score_popularity, score_recency, relevance, ...movie }) => {
+    if (!explain || relevance === undefined) return movie;
+
+    const components: ScoreComponents = {
+      title_match: score_title_match,
+      popularity: score_popularity,
+      recency: score_recency
+    };
+    return { ...movie, score: { total: relevance, components } };
+  });
+
 // ============================================================================
 // Controller Functions
 // ============================================================================
@@ -120,6 +307,7 @@ const createPaginationResponse = (
  * @queryparam title - Search by title (substring match)
  * @queryparam year - Filter by release year
  * @queryparam genre - Filter by genre name
+ * @queryparam keyword - Filter by keywords, semicolon-separated; movies must have all of them
  * @queryparam rating - Filter by MPA rating
  * @queryparam actor - Filter by actor name
  * @queryparam director - Filter by director name
@@ -129,8 +317,18 @@ const createPaginationResponse = (
  * @queryparam maxBudget - Maximum budget threshold
  * @queryparam minRevenue - Minimum revenue threshold
  * @queryparam maxRevenue - Maximum revenue threshold
+ * @queryparam minRuntime - Minimum runtime in minutes
+ * @queryparam maxRuntime - Maximum runtime in minutes
  * @queryparam startDate - Release date range start (YYYY-MM-DD)
  * @queryparam endDate - Release date range end (YYYY-MM-DD)
+ * @queryparam era - silent | golden_age | new_hollywood | blockbuster | digital | streaming
+ * @queryparam lang - Localize title/overview, falling back to the original text
+ * @queryparam sortBy - relevance | popularity | title | release_date | runtime | budget | revenue | budget_adjusted | revenue_adjusted (default: relevance with a title search, otherwise popularity)
+ * @queryparam order - asc | desc (default: desc for relevance and popularity, otherwise asc)
+ * @queryparam facets - Include genre/decade/rating counts for the whole result set (default: false)
+ * @queryparam explain - Include each result's relevance score components and the weights used (default: false)
+ * @queryparam include - Relations to expand into each result: cast, genres, studios (comma-separated)
+ * @queryparam cast_limit - Cast entries to expand per movie with include=cast (default: 20, max: 100)
  * @queryparam page - Page number (default: 1)
  * @queryparam limit - Results per page (default: 20, max: 100)
  * 
@@ -138,149 +336,81 @@ const createPaginationResponse = (
  * GET /api/movies?genre=Action&year=2020
  * GET /api/movies?title=batman&minRevenue=1000000
  * GET /api/movies?actor=Tom+Hanks&genre=Drama&startDate=2000-01-01
+ * GET /api/movies?genre=Comedy&lang=es
+ * GET /api/movies?genre=Drama&sortBy=revenue&order=desc
+ * GET /api/movies?sortBy=revenue_adjusted&order=desc
+ * GET /api/movies?startDate=1990-01-01&facets=true
+ * GET /api/movies?title=star&explain=true
+ * GET /api/movies?genre=Drama&include=cast,studios
+ * GET /api/movies?keyword=heist;time+travel
  */
 export const getAllMovies = async (req: Request, res: Response) => {
   const validation = getAllMoviesSchema.safeParse(req.query);
-  if (!validation.success) {
+  const optionsValidation = searchOptionsSchema.safeParse(req.query);
+  if (!validation.success || !optionsValidation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest(validation.error.issues)
+      ApiError.badRequest([
+        ...(validation.error?.issues ?? []),
+        ...(optionsValidation.error?.issues ?? [])
+      ])
     );
   }
 
+  try {
+    const response = await searchMovies(validation.data, optionsValidation.data);
+
+    if (response.meta.total === 0) {
+      return res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('No movies found matching the specified criteria', 'NO_MOVIES_FOUND')
+      );
+    }
+
+    return res.status(200).json(response);
+  } catch (error) {
+    return res.status(500).json(ApiError.internalError(error));
+  }
+};
+
+/**
+ * Runs a validated movie search and builds the paginated response.
+ * Shared by GET /movies and saved searches.
+ *
+ * @param filters - Parsed getAllMoviesSchema values
+ * @param options - facets: also count matches per genre, decade and rating;
+ *                  explain: include relevance score components;
+ *                  include: relations to expand into each movie
+ */
+export const searchMovies = async (filters: MovieSearchParams, options: SearchOptions = {}) => {
+  const { facets: includeFacets = false, explain = false, include, cast_limit: castLimit } = options;
   const {
-    title, year, genre, rating,
+    title, year, genre, keyword, rating,
     actor, director, studio, collection,
     minBudget, maxBudget, minRevenue, maxRevenue,
-    startDate, endDate,
+    minRuntime, maxRuntime,
+    startDate, endDate, era, lang, include_adult,
+    sortBy, order,
     page, limit
-  } = validation.data;
+  } = filters;
 
   const offset = (page - 1) * limit;
+  const sort = sortBy ?? (title ? 'relevance' : 'popularity');
+  const direction = order ?? (sort === 'relevance' || sort === 'popularity' ? 'desc' : 'asc');
+  const scoring = sort === 'relevance' || explain;
 
-  // Build dynamic WHERE conditions
-  const whereConditions: string[] = [];
-  const params: (string | number)[] = [];
-  let paramCounter = 1;
-
-  // Title search (ILIKE for partial match)
-  if (title) {
-    whereConditions.push(`m.title ILIKE $${paramCounter}`);
-    params.push(`%${title}%`);
-    paramCounter++;
-  }
-
-  // Year filter
-  if (year) {
-    whereConditions.push(`EXTRACT(YEAR FROM m.release_date) = $${paramCounter}`);
-    params.push(year);
-    paramCounter++;
-  }
-
-  // Genre filter
-  if (genre) {
-    whereConditions.push(`EXISTS (
-      SELECT 1 FROM movie_genres mg2 
-      JOIN genres g2 ON mg2.genre_id = g2.genre_id 
-      WHERE mg2.movie_id = m.movie_id 
-      AND LOWER(g2.genre_name) = LOWER($${paramCounter})
-    )`);
-    params.push(genre);
-    paramCounter++;
-  }
-
-  // MPA Rating filter
-  if (rating) {
-    whereConditions.push(`m.mpa_rating = $${paramCounter}`);
-    params.push(rating);
-    paramCounter++;
-  }
-
-  // Actor filter
-  if (actor) {
-    whereConditions.push(`EXISTS (
-      SELECT 1 FROM movie_actors ma2 
-      JOIN actors a2 ON ma2.actor_id = a2.actor_id 
-      WHERE ma2.movie_id = m.movie_id 
-      AND LOWER(a2.actor_name) LIKE LOWER($${paramCounter})
-    )`);
-    params.push(`%${actor}%`);
-    paramCounter++;
-  }
-
-  // Director filter
-  if (director) {
-    whereConditions.push(`EXISTS (
-      SELECT 1 FROM movie_directors md2 
-      JOIN directors d2 ON md2.director_id = d2.director_id 
-      WHERE md2.movie_id = m.movie_id 
-      AND LOWER(d2.director_name) LIKE LOWER($${paramCounter})
-    )`);
-    params.push(`%${director}%`);
-    paramCounter++;
-  }
-
-  // Studio filter
-  if (studio) {
-    whereConditions.push(`EXISTS (
-      SELECT 1 FROM movie_studios ms2 
-      JOIN studios s2 ON ms2.studio_id = s2.studio_id 
-      WHERE ms2.movie_id = m.movie_id 
-      AND LOWER(s2.studio_name) LIKE LOWER($${paramCounter})
-    )`);
-    params.push(`%${studio}%`);
-    paramCounter++;
-  }
-
-  // Collection filter
-  if (collection) {
-    whereConditions.push(`EXISTS (
-      SELECT 1 FROM collections c2 
-      WHERE m.collection_id = c2.collection_id 
-      AND LOWER(c2.collection_name) LIKE LOWER($${paramCounter})
-    )`);
-    params.push(`%${collection}%`);
-    paramCounter++;
-  }
-
-  // Budget filters
-  if (minBudget !== undefined) {
-    whereConditions.push(`m.budget >= $${paramCounter}`);
-    params.push(minBudget);
-    paramCounter++;
-  }
-  if (maxBudget !== undefined) {
-    whereConditions.push(`m.budget <= $${paramCounter}`);
-    params.push(maxBudget);
-    paramCounter++;
-  }
-
-  // Revenue filters
-  if (minRevenue !== undefined) {
-    whereConditions.push(`m.revenue >= $${paramCounter}`);
-    params.push(minRevenue);
-    paramCounter++;
-  }
-  if (maxRevenue !== undefined) {
-    whereConditions.push(`m.revenue <= $${paramCounter}`);
-    params.push(maxRevenue);
-    paramCounter++;
-  }
+  const filter = addMovieFilters(new SqlFilter(), {
+    title, year, genre, keywords: keyword, rating,
+    actor, director, studio, collection,
+    minBudget, maxBudget, minRevenue, maxRevenue,
+    minRuntime, maxRuntime,
+    startDate, endDate, era
+  });
 
-  // Date range filters
-  if (startDate) {
-    whereConditions.push(`m.release_date >= $${paramCounter}`);
-    params.push(startDate);
-    paramCounter++;
-  }
-  if (endDate) {
-    whereConditions.push(`m.release_date <= $${paramCounter}`);
-    params.push(endDate);
-    paramCounter++;
+  // Adult titles and restricted ratings, unless the caller opted in
+  if (shouldHideAdultContent(include_adult)) {
+    filter.addRaw(nonAdultCondition());
   }
 
-  const whereClause = whereConditions.length > 0 
-    ? `WHERE ${whereConditions.join(' AND ')}` 
-    : '';
+  const whereClause = filter.whereSql;
 
   const countSql = `
     SELECT COUNT(DISTINCT m.movie_id)::int AS total
@@ -288,15 +418,33 @@ export const getAllMovies = async (req: Request, res: Response) => {
     ${whereClause}
   `;
 
+  const countParams = [...filter.params];
+  const langParam = filter.param(lang ?? null);
+  const limitParam = filter.param(limit);
+  const offsetParam = filter.param(offset);
+
+  const weights = scoring ? await getRankingWeights() : null;
+  const scoreColumns = weights ? relevanceSql(title, weights, filter) : '';
+
+  // Translation join; a NULL language matches nothing so the original text is used
   const dataSql = `
     SELECT 
-      m.movie_id, m.title, m.original_title,
+      m.movie_id,
+      m.public_id,
+      COALESCE(t.title, m.title) AS title,
+      m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
-      m.release_date, m.runtime_minutes, m.overview,
-      m.budget::int8, m.revenue::int8, m.mpa_rating,
-      m.poster_url, m.backdrop_url
+      m.release_date, m.runtime_minutes,
+      COALESCE(t.overview, m.overview) AS overview,
+      t.tagline,
+      m.budget::int8, m.revenue::int8, m.mpa_rating, m.adult,
+      m.budget_adjusted::int8, m.revenue_adjusted::int8, m.era,
+      m.vote_count, m.vote_average::float8, m.popularity::float8,
+      m.poster_url, m.backdrop_url,${scoreColumns}
+      t.language
     FROM movies m
+    LEFT JOIN movie_translations t ON t.movie_id = m.movie_id AND t.language = ${langParam}
     LEFT JOIN movie_directors md ON m.movie_id = md.movie_id
     LEFT JOIN directors d ON md.director_id = d.director_id
     LEFT JOIN movie_genres mg ON m.movie_id = mg.movie_id
@@ -304,56 +452,99 @@ export const getAllMovies = async (req: Request, res: Response) => {
     ${whereClause}
     GROUP BY m.movie_id, m.title, m.original_title, m.release_date, 
              m.runtime_minutes, m.overview, m.budget, m.revenue, 
-             m.mpa_rating, m.poster_url, m.backdrop_url
-    ORDER BY m.title
-    LIMIT $${paramCounter} OFFSET $${paramCounter + 1}
+             m.mpa_rating, m.poster_url, m.backdrop_url,
+             t.title, t.overview, t.tagline, t.language
+    ORDER BY ${MOVIE_SORT_COLUMNS[sort]} ${direction === 'desc' ? 'DESC' : 'ASC'} NULLS LAST, m.movie_id
+    LIMIT ${limitParam} OFFSET ${offsetParam}
   `;
 
-  params.push(limit, offset);
-
-  try {
-    const [countR, dataR] = await Promise.all([
-      pool.query<{ total: number }>(countSql, params.slice(0, -2)),
-      pool.query<Movie>(dataSql, params)
-    ]);
-
-    const total = countR.rows[0].total;
-
-    if (total === 0) {
-      return res.status(HttpStatus.NOT_FOUND).json(
-        ApiError.notFound('No movies found matching the specified criteria')
-      );
-    }
+  // One pass over the filtered movies; each grouping set yields one facet
+  const facetSql = `
+    WITH filtered AS (
+      SELECT
+        m.movie_id,
+        m.mpa_rating,
+        (FLOOR(EXTRACT(YEAR FROM m.release_date) / 10) * 10)::int AS decade
+      FROM movies m
+      ${whereClause}
+    )
+    SELECT
+      g.genre_name,
+      f.decade,
+      f.mpa_rating,
+      GROUPING(g.genre_name, f.decade, f.mpa_rating) AS grouping_id,
+      COUNT(DISTINCT f.movie_id)::int AS count
+    FROM filtered f
+    LEFT JOIN movie_genres mg ON f.movie_id = mg.movie_id
+    LEFT JOIN genres g ON mg.genre_id = g.genre_id
+    GROUP BY GROUPING SETS ((g.genre_name), (f.decade), (f.mpa_rating))
+    ORDER BY count DESC
+  `;
 
-    // Build query object for response metadata
-    const queryParams: Record<string, any> = {};
-    if (title) queryParams.title = title;
-    if (year) queryParams.year = year;
-    if (genre) queryParams.genre = genre;
-    if (rating) queryParams.rating = rating;
-    if (actor) queryParams.actor = actor;
-    if (director) queryParams.director = director;
-    if (studio) queryParams.studio = studio;
-    if (collection) queryParams.collection = collection;
-    if (minBudget !== undefined) queryParams.minBudget = minBudget;
-    if (maxBudget !== undefined) queryParams.maxBudget = maxBudget;
-    if (minRevenue !== undefined) queryParams.minRevenue = minRevenue;
-    if (maxRevenue !== undefined) queryParams.maxRevenue = maxRevenue;
-    if (startDate) queryParams.startDate = startDate;
-    if (endDate) queryParams.endDate = endDate;
-
-    const response = createPaginationResponse(
-      dataR.rows,
+  const [countR, dataR, facetR] = await Promise.all([
+    pool.query<{ total: number }>(countSql, countParams),
+    pool.query<Movie>(dataSql, filter.params),
+    includeFacets ? pool.query(facetSql, countParams) : null
+  ]);
+
+  const total = countR.rows[0].total;
+  const movies = await attachMovieIncludes(applyScores(dataR.rows, explain), include, { castLimit });
+
+  // Build query object for response metadata
+  const queryParams: Record<string, any> = {};
+  if (title) queryParams.title = title;
+  if (year) queryParams.year = year;
+  if (genre) queryParams.genre = genre;
+  if (keyword && keyword.length > 0) queryParams.keyword = keyword;
+  if (rating) queryParams.rating = rating;
+  if (actor) queryParams.actor = actor;
+  if (director) queryParams.director = director;
+  if (studio) queryParams.studio = studio;
+  if (collection) queryParams.collection = collection;
+  if (minBudget !== undefined) queryParams.minBudget = minBudget;
+  if (maxBudget !== undefined) queryParams.maxBudget = maxBudget;
+  if (minRevenue !== undefined) queryParams.minRevenue = minRevenue;
+  if (maxRevenue !== undefined) queryParams.maxRevenue = maxRevenue;
+  if (minRuntime !== undefined) queryParams.minRuntime = minRuntime;
+  if (maxRuntime !== undefined) queryParams.maxRuntime = maxRuntime;
+  if (startDate) queryParams.startDate = startDate;
+  if (endDate) queryParams.endDate = endDate;
+  if (era) queryParams.era = era;
+  if (lang) queryParams.lang = lang;
+  if (include_adult) queryParams.include_adult = include_adult;
+  if (sortBy) queryParams.sortBy = sortBy;
+  if (order) queryParams.order = order;
+
+  const response = {
+    ...createPaginationResponse(
+      movies,
       page,
       limit,
       total,
       Object.keys(queryParams).length > 0 ? queryParams : undefined
-    );
+    ),
+    ...(explain && weights ? { ranking: { sortBy: sort, weights } } : {})
+  };
 
-    return res.status(200).json(response);
-  } catch (error) {
-    return res.status(500).json(ApiError.internalError(error));
+  if (!facetR) {
+    return response;
   }
+
+  // GROUPING() sets a bit for every column not in the row's grouping set:
+  // 3 = genre only, 5 = decade only, 6 = rating only. NULL values are skipped.
+  const facets: MovieFacets = { genres: [], decades: [], ratings: [] };
+  for (const row of facetR.rows) {
+    if (row.grouping_id === 3 && row.genre_name !== null) {
+      facets.genres.push({ value: row.genre_name, count: row.count });
+    } else if (row.grouping_id === 5 && row.decade !== null) {
+      facets.decades.push({ value: row.decade, count: row.count });
+    } else if (row.grouping_id === 6 && row.mpa_rating !== null) {
+      facets.ratings.push({ value: row.mpa_rating, count: row.count });
+    }
+  }
+  facets.decades.sort((a, b) => (a.value as number) - (b.value as number));
+
+  return { ...response, facets };
 };
 
 /**
@@ -361,6 +552,9 @@ export const getAllMovies = async (req: Request, res: Response) => {
  * 
  * @route GET /api/movies/:id
  * @param req.params.id - The movie ID to retrieve
+ * @queryparam lang - Localize title/overview, falling back to the original text
+ * @queryparam include - Relations to expand: cast, genres, studios (comma-separated)
+ * @queryparam cast_limit - Cast entries to expand with include=cast (default: 20, max: 100)
  */
 export const getMovieById = async (req: Request, res: Response) => {
   const idParam = req.params.id;
@@ -368,25 +562,55 @@ export const getMovieById = async (req: Request, res: Response) => {
 
   if (isNaN(id)) {
     return res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest("ID must be a valid number")
+      ApiError.badRequest("ID must be a valid number", 'INVALID_ID')
+    );
+  }
+
+  const langValidation = languageSchema.optional().safeParse(req.query.lang);
+  if (!langValidation.success) {
+    return res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(langValidation.error.issues)
+    );
+  }
+  const lang = langValidation.data ?? null;
+
+  const includeValidation = includeOptionsSchema.safeParse(req.query);
+  if (!includeValidation.success) {
+    return res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(includeValidation.error.issues)
     );
   }
 
   const sql = `
     SELECT 
-      m.title, 
+      m.movie_id,
+      m.public_id,
+      COALESCE(t.title, m.title) AS title, 
       m.original_title, 
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       m.release_date, 
       m.runtime_minutes, 
-      m.overview, 
+      COALESCE(t.overview, m.overview) AS overview, 
+      t.tagline,
       m.budget::int8, 
       m.revenue::int8, 
+      m.budget_adjusted::int8,
+      m.revenue_adjusted::int8,
+      m.era,
+      m.vote_count,
+      m.vote_average::float8,
+      m.popularity::float8,
       m.mpa_rating, 
+      m.adult,
       m.poster_url, 
-      m.backdrop_url
+      m.backdrop_url,
+      m.trailer_url,
+      t.language,
+      m.version,
+      m.updated_at
     FROM movies m
+    LEFT JOIN movie_translations t ON t.movie_id = m.movie_id AND t.language = $2
     LEFT JOIN movie_directors md ON m.movie_id = md.movie_id
     LEFT JOIN directors d ON md.director_id = d.director_id
     LEFT JOIN movie_genres mg ON m.movie_id = mg.movie_id
@@ -394,19 +618,105 @@ export const getMovieById = async (req: Request, res: Response) => {
     WHERE m.movie_id = $1
     GROUP BY m.movie_id, m.title, m.original_title, m.release_date, 
              m.runtime_minutes, m.overview, m.budget, m.revenue, 
-             m.mpa_rating, m.poster_url, m.backdrop_url
+             m.mpa_rating, m.poster_url, m.backdrop_url,
+             m.version, m.updated_at,
+             t.title, t.overview, t.tagline, t.language
   `;
 
   try {
-    const result = await pool.query<Movie>(sql, [id]);
+    const result = await pool.query<Movie>(sql, [id, lang]);
 
     if (result.rowCount === 0) {
       return res.status(HttpStatus.NOT_FOUND).json(
-        ApiError.notFound('Movie not found')
+        ApiError.notFound('Movie not found', 'MOVIE_NOT_FOUND')
       );
     }
 
-    return res.status(200).json(result.rows[0]);
+    // Editors send this back in If-Match so concurrent edits don't overwrite each other
+    res.set('ETag', movieETag(result.rows[0].version ?? 1));
+
+    const { include, cast_limit: castLimit } = includeValidation.data;
+    const [movie] = await attachMovieIncludes(result.rows, include, { castLimit });
+    return res.status(200).json(movie);
+  } catch (error) {
+    return res.status(500).json(ApiError.internalError(error));
+  }
+};
+
+/**
+ * Retrieves a movie's cast in billing order, a page at a time.
+ * 
+ * Entries with an explicit billing_order sort by it, others by their supplied
+ * position (actor_order); remaining ties are broken by actor ID.
+ * 
+ * @route GET /api/movies/:id/cast
+ * @param req.params.id - The movie ID
+ * @queryparam credited - true for credited roles only, false for uncredited only
+ * @queryparam page - Page number (default: 1)
+ * @queryparam limit - Cast entries per page (default: 20, max: 100)
+ */
+export const getMovieCast = async (req: Request, res: Response) => {
+  const id = parseInt(req.params.id, 10);
+
+  if (isNaN(id)) {
+    return res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest("ID must be a valid number", 'INVALID_ID')
+    );
+  }
+
+  const validation = castPageSchema.safeParse(req.query);
+  if (!validation.success) {
+    return res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+  }
+  const { page, limit } = validation.data;
+  const credited = validation.data.credited ?? null;
+  const offset = (page - 1) * limit;
+
+  const countSql = `
+    SELECT COUNT(*)::int AS total
+    FROM movie_actors ma
+    WHERE ma.movie_id = $1
+      AND ($2::boolean IS NULL OR ma.credited = $2)
+  `;
+
+  const sql = `
+    SELECT
+      a.actor_id,
+      a.actor_name,
+      ma.character_name,
+      COALESCE(ma.character_names, '{}') AS character_names,
+      ma.actor_order,
+      ma.billing_order,
+      ma.credited,
+      a.profile_url
+    FROM movie_actors ma
+    JOIN actors a ON ma.actor_id = a.actor_id
+    WHERE ma.movie_id = $1
+      AND ($2::boolean IS NULL OR ma.credited = $2)
+    ORDER BY ${CAST_ORDER_SQL}
+    LIMIT $3 OFFSET $4
+  `;
+
+  try {
+    const movieResult = await pool.query('SELECT movie_id FROM movies WHERE movie_id = $1', [id]);
+    if (movieResult.rowCount === 0) {
+      return res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Movie not found', 'MOVIE_NOT_FOUND')
+      );
+    }
+
+    const [countR, result] = await Promise.all([
+      pool.query<{ total: number }>(countSql, [id, credited]),
+      pool.query(sql, [id, credited, limit, offset])
+    ]);
+
+    return res.status(HttpStatus.OK).json({
+      movie_id: id,
+      ...createPaginationResponse(result.rows, page, limit, countR.rows[0].total),
+      count: result.rows.length
+    });
   } catch (error) {
     return res.status(500).json(ApiError.internalError(error));
   }
@@ -433,14 +743,15 @@ export const getMoviesByStudio = async (req: Request, res: Response) => {
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
@@ -449,11 +760,12 @@ export const getMoviesByStudio = async (req: Request, res: Response) => {
     JOIN movie_studios ms ON m.movie_id = ms.movie_id
     JOIN studios s ON ms.studio_id = s.studio_id
     WHERE LOWER(s.studio_name) LIKE LOWER($1)
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT 
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       STRING_AGG(DISTINCT s2.studio_name, ', ') as studios,
@@ -470,6 +782,7 @@ export const getMoviesByStudio = async (req: Request, res: Response) => {
     LEFT JOIN movie_genres mg ON m.movie_id = mg.movie_id
     LEFT JOIN genres g ON mg.genre_id = g.genre_id
     WHERE LOWER(s.studio_name) LIKE LOWER($1)
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
@@ -520,14 +833,15 @@ export const getMoviesByDirector = async (req: Request, res: Response) => {
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
@@ -536,11 +850,12 @@ export const getMoviesByDirector = async (req: Request, res: Response) => {
     JOIN movie_directors md ON m.movie_id = md.movie_id
     JOIN directors d ON md.director_id = d.director_id
     WHERE LOWER(d.director_name) LIKE LOWER($1)
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT 
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d2.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       m.release_date, m.runtime_minutes, m.overview,
@@ -554,6 +869,7 @@ export const getMoviesByDirector = async (req: Request, res: Response) => {
     LEFT JOIN movie_genres mg ON m.movie_id = mg.movie_id
     LEFT JOIN genres g ON mg.genre_id = g.genre_id
     WHERE LOWER(d.director_name) LIKE LOWER($1)
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
@@ -604,14 +920,15 @@ export const getMoviesByActor = async (req: Request, res: Response) => {
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
@@ -620,17 +937,19 @@ export const getMoviesByActor = async (req: Request, res: Response) => {
     JOIN movie_actors ma ON m.movie_id = ma.movie_id
     JOIN actors a ON ma.actor_id = a.actor_id
     WHERE LOWER(a.actor_name) LIKE LOWER($1)
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT DISTINCT
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       m.release_date, m.runtime_minutes, m.overview,
       m.budget::int8, m.revenue::int8, m.mpa_rating,
       m.poster_url, m.backdrop_url,
       ma.character_name as actor_character,
+      ma.character_names as actor_characters,
       a.profile_url as actor_profile_url,
       a.actor_name
     FROM movies m
@@ -641,11 +960,12 @@ export const getMoviesByActor = async (req: Request, res: Response) => {
     JOIN movie_actors ma ON m.movie_id = ma.movie_id
     JOIN actors a ON ma.actor_id = a.actor_id
     WHERE LOWER(a.actor_name) LIKE LOWER($1)
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
       m.mpa_rating, m.poster_url, m.backdrop_url,
-      ma.character_name, a.profile_url, a.actor_name
+      ma.character_name, ma.character_names, a.profile_url, a.actor_name
     ORDER BY m.release_date DESC
     LIMIT $2 OFFSET $3
   `;
@@ -692,14 +1012,15 @@ export const getMoviesByCollection = async (req: Request, res: Response) => {
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
@@ -707,11 +1028,12 @@ export const getMoviesByCollection = async (req: Request, res: Response) => {
     FROM movies m
     INNER JOIN collections c ON m.collection_id = c.collection_id
     WHERE LOWER(c.collection_name) LIKE LOWER($1)
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT 
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       STRING_AGG(DISTINCT s.studio_name, ', ') as studios,
@@ -729,6 +1051,7 @@ export const getMoviesByCollection = async (req: Request, res: Response) => {
     LEFT JOIN movie_studios ms ON m.movie_id = ms.movie_id
     LEFT JOIN studios s ON ms.studio_id = s.studio_id
     WHERE LOWER(c.collection_name) LIKE LOWER($1)
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
@@ -776,18 +1099,19 @@ export const getMoviesByStudioId = async (req: Request, res: Response) => {
 
   if (isNaN(studioId)) {
     return res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest("Studio ID must be a valid number")
+      ApiError.badRequest("Studio ID must be a valid number", 'INVALID_ID')
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
@@ -795,11 +1119,12 @@ export const getMoviesByStudioId = async (req: Request, res: Response) => {
     FROM movies m
     JOIN movie_studios ms ON m.movie_id = ms.movie_id
     WHERE ms.studio_id = $1
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT 
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       STRING_AGG(DISTINCT s.studio_name, ', ') as studios,
@@ -814,6 +1139,7 @@ export const getMoviesByStudioId = async (req: Request, res: Response) => {
     LEFT JOIN movie_genres mg ON m.movie_id = mg.movie_id
     LEFT JOIN genres g ON mg.genre_id = g.genre_id
     WHERE ms.studio_id = $1
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
@@ -861,18 +1187,19 @@ export const getMoviesByDirectorId = async (req: Request, res: Response) => {
 
   if (isNaN(directorId)) {
     return res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest("Director ID must be a valid number")
+      ApiError.badRequest("Director ID must be a valid number", 'INVALID_ID')
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
@@ -880,11 +1207,12 @@ export const getMoviesByDirectorId = async (req: Request, res: Response) => {
     FROM movies m
     JOIN movie_directors md ON m.movie_id = md.movie_id
     WHERE md.director_id = $1
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT 
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       m.release_date, m.runtime_minutes, m.overview,
@@ -896,6 +1224,7 @@ export const getMoviesByDirectorId = async (req: Request, res: Response) => {
     LEFT JOIN movie_genres mg ON m.movie_id = mg.movie_id
     LEFT JOIN genres g ON mg.genre_id = g.genre_id
     WHERE md.director_id = $1
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
@@ -943,18 +1272,19 @@ export const getMoviesByActorId = async (req: Request, res: Response) => {
 
   if (isNaN(actorId)) {
     return res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest("Actor ID must be a valid number")
+      ApiError.badRequest("Actor ID must be a valid number", 'INVALID_ID')
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
@@ -962,17 +1292,19 @@ export const getMoviesByActorId = async (req: Request, res: Response) => {
     FROM movies m
     JOIN movie_actors ma ON m.movie_id = ma.movie_id
     WHERE ma.actor_id = $1
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT DISTINCT
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       m.release_date, m.runtime_minutes, m.overview,
       m.budget::int8, m.revenue::int8, m.mpa_rating,
       m.poster_url, m.backdrop_url,
       ma.character_name as actor_character,
+      ma.character_names as actor_characters,
       a.profile_url as actor_profile_url,
       a.actor_name
     FROM movies m
@@ -983,11 +1315,12 @@ export const getMoviesByActorId = async (req: Request, res: Response) => {
     JOIN movie_actors ma ON m.movie_id = ma.movie_id
     JOIN actors a ON ma.actor_id = a.actor_id
     WHERE ma.actor_id = $1
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
       m.mpa_rating, m.poster_url, m.backdrop_url,
-      ma.character_name, a.profile_url, a.actor_name
+      ma.character_name, ma.character_names, a.profile_url, a.actor_name
     ORDER BY m.release_date DESC
     LIMIT $2 OFFSET $3
   `;
@@ -1031,29 +1364,31 @@ export const getMoviesByCollectionId = async (req: Request, res: Response) => {
 
   if (isNaN(collectionId)) {
     return res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest("Collection ID must be a valid number")
+      ApiError.badRequest("Collection ID must be a valid number", 'INVALID_ID')
     );
   }
 
-  const validation = paginationSchema.safeParse(req.query);
+  const validation = movieListSchema.safeParse(req.query);
   if (!validation.success) {
     return res.status(HttpStatus.BAD_REQUEST).json(
       ApiError.badRequest(validation.error.issues)
     );
   }
 
-  const { page, limit } = validation.data;
+  const { page, limit, include_adult } = validation.data;
+  const contentFilter = shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition()}` : '';
   const offset = (page - 1) * limit;
 
   const countSql = `
     SELECT COUNT(DISTINCT m.movie_id)::int AS total
     FROM movies m
     WHERE m.collection_id = $1
+    ${contentFilter}
   `;
 
   const dataSql = `
     SELECT 
-      m.movie_id, m.title, m.original_title,
+      m.movie_id, m.public_id, m.title, m.original_title,
       STRING_AGG(DISTINCT d.director_name, ', ') as directors,
       STRING_AGG(DISTINCT g.genre_name, ', ') as genres,
       STRING_AGG(DISTINCT s.studio_name, ', ') as studios,
@@ -1071,6 +1406,7 @@ export const getMoviesByCollectionId = async (req: Request, res: Response) => {
     LEFT JOIN movie_studios ms ON m.movie_id = ms.movie_id
     LEFT JOIN studios s ON ms.studio_id = s.studio_id
     WHERE m.collection_id = $1
+    ${contentFilter}
     GROUP BY 
       m.movie_id, m.title, m.original_title, m.release_date,
       m.runtime_minutes, m.overview, m.budget, m.revenue,
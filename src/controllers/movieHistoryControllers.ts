@@ -0,0 +1,231 @@
+// server/src/controllers/movieHistoryControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { enrichMovies } from '@utils/inflation';
+import { LINEAGE_FIELDS } from '@utils/lineage';
+import { generatePlaceholderPosters } from '@utils/placeholderPosters';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import z from 'zod';
+import { paginationSchema } from './movieGetControllers';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const undoSchema = z.object({
+  /** Version to go back to; the one before the current by default */
+  version: z.number().int().positive().optional()
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * Columns an undo restores: the ones edits and imports write. Derived columns
+ * are recomputed afterwards.
+ */
+const RESTORED_FIELDS = LINEAGE_FIELDS;
+
+/**
+ * Parses the :id route parameter, responding with 400 when invalid
+ */
+const parseMovieId = (req: Request, res: Response): number | null => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return null;
+  }
+  return movieId;
+};
+
+const paginationMeta = (page: number, limit: number, total: number) => {
+  const pages = Math.max(1, Math.ceil(total / limit));
+  return { page, limit, total, pages, hasNextPage: page < pages, hasPreviousPage: page > 1 };
+};
+
+// ============================================================================
+// Movie History Controllers
+// ============================================================================
+
+/**
+ * GET /api/movies/:id/history
+ * Earlier versions of a movie, newest first, each with the period it was
+ * current (valid_from to valid_to)
+ *
+ * Query Parameters:
+ * - page: Page number (default: 1)
+ * - limit: Items per page (default: 20, max: 100)
+ *
+ * @param id - Movie ID
+ * @returns Prior versions of the movie's own columns (not genres, cast or translations)
+ */
+export const getMovieHistory = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseMovieId(req, res);
+  if (movieId === null) return;
+
+  const validation = paginationSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { page, limit } = validation.data;
+
+  try {
+    const movieResult = await pool.query<{ version: number; updated_at: string }>(
+      'SELECT version, updated_at FROM movies WHERE movie_id = $1',
+      [movieId]
+    );
+    if (movieResult.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const [count, versions] = await Promise.all([
+      pool.query<{ total: number }>(
+        'SELECT COUNT(*)::int AS total FROM movie_history WHERE movie_id = $1',
+        [movieId]
+      ),
+      pool.query(
+        `SELECT version, valid_from, valid_to,
+                (SELECT JSONB_OBJECT_AGG(key, value)
+                 FROM JSONB_EACH(data)
+                 WHERE key = ANY($4::text[])) AS data
+         FROM movie_history
+         WHERE movie_id = $1
+         ORDER BY valid_to DESC, history_id DESC
+         LIMIT $2 OFFSET $3`,
+        [movieId, limit, (page - 1) * limit, [...RESTORED_FIELDS]]
+      )
+    ]);
+
+    res.status(HttpStatus.OK).json({
+      movie_id: movieId,
+      current_version: movieResult.rows[0].version,
+      current_since: movieResult.rows[0].updated_at,
+      data: versions.rows,
+      meta: paginationMeta(page, limit, count.rows[0].total)
+    });
+  } catch (error) {
+    console.error('Error fetching movie history:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch movie history')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/movies/:id/undo
+ * Restore a movie's columns to an earlier version
+ *
+ * Body: { version?: number } - Version to restore (default: the latest earlier one)
+ *
+ * The restore is itself a new version, so it can be undone too.
+ *
+ * @param id - Movie ID
+ * @returns The restored version and the movie's new version number
+ */
+export const undoMovieChange = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const movieId = parseMovieId(req, res);
+  if (movieId === null) return;
+
+  const validation = undoSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { version } = validation.data;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const movieResult = await client.query<{ version: number }>(
+      'SELECT version FROM movies WHERE movie_id = $1 FOR UPDATE',
+      [movieId]
+    );
+    if (movieResult.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const historyResult = await client.query<{ history_id: number; version: number; data: Record<string, unknown> }>(
+      `SELECT history_id, version, data
+       FROM movie_history
+       WHERE movie_id = $1 AND ($2::int IS NULL OR version = $2)
+       ORDER BY valid_to DESC, history_id DESC
+       LIMIT 1`,
+      [movieId, version ?? null]
+    );
+    if (historyResult.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        version === undefined
+          ? ApiError.notFound(`Movie ${movieId} has no earlier version`, 'NO_EARLIER_VERSION', { id: movieId })
+          : ApiError.notFound(`Version ${version} of movie ${movieId} not found`, 'VERSION_NOT_FOUND', { version, id: movieId })
+      );
+      return;
+    }
+
+    const restored = historyResult.rows[0];
+    const assignments = RESTORED_FIELDS.map(field => `${field} = r.${field}`).join(', ');
+
+    const updateResult = await client.query<{ version: number }>(
+      `UPDATE movies AS m
+       SET ${assignments}, version = m.version + 1, updated_at = NOW()
+       FROM JSONB_POPULATE_RECORD(NULL::movies, $2::jsonb) AS r
+       WHERE m.movie_id = $1
+       RETURNING m.version`,
+      [movieId, JSON.stringify(restored.data)]
+    );
+    await enrichMovies(client, [movieId]);
+    await generatePlaceholderPosters(client, [movieId]);
+
+    await recordAudit(client, {
+      action: 'movie.undo',
+      entity_type: 'movie',
+      entity_id: movieId,
+      details: {
+        from_version: movieResult.rows[0].version,
+        restored_version: restored.version,
+        history_id: restored.history_id
+      },
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      movie_id: movieId,
+      restored_version: restored.version,
+      version: updateResult.rows[0].version,
+      message: `Movie ${movieId} restored to version ${restored.version}`
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error undoing movie change:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to undo movie change')
+    );
+  } finally {
+    client.release();
+  }
+};
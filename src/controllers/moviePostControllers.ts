@@ -1,12 +1,128 @@
 import { MovieCreateInput, MovieCreateResponse, BulkImportResponse, MovieStudio, CastMember } from '@models/movieModel';
 import pool from '@utils/database';
-import { Request, Response } from 'express';
+import { scheduleBoxOfficeRefresh } from '@utils/boxOfficeStats';
+import { enrichMovies } from '@utils/inflation';
+import { recordMovieLineage, startImportRun } from '@utils/lineage';
+import { insertMovieCrew } from '@utils/movieCrew';
+import { insertMovieKeywords, MAX_KEYWORD_LENGTH, parseKeywords } from '@utils/movieKeywords';
+import {
+  insertMovieLocales,
+  MAX_LOCALE_LENGTH,
+  parseLocaleList,
+  resolveCountry,
+  resolveLanguage
+} from '@utils/movieLocales';
+import { nameMatchSql } from '@utils/nameMatching';
+import { recordPersonImage } from '@utils/personImages';
+import { generatePlaceholderPosters } from '@utils/placeholderPosters';
+import { startSpan } from '@utils/tracing';
+import { Response } from 'express';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
 import { PoolClient } from 'pg';
+import { saveMovieTranslations } from './translationControllers';
+import { sanitizeText } from '@utils/sanitize';
+import { MPA_RATINGS, languageSchema } from './movieGetControllers';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation (request DTOs, applied by validateRequest)
+// ============================================================================
+
+const nameSchema = z.string().trim().min(1).max(255);
+const urlSchema = z.string().max(500);
+
+/**
+ * Calendar date with no time of day
+ */
+const releaseDateSchema = z.iso.date('release_date must be a YYYY-MM-DD date');
+
+/**
+ * Runtime in minutes; blank (0 or null) means unknown and is stored as NULL
+ */
+const runtimeSchema = z.number().int().nonnegative().nullable().optional()
+  .transform(minutes => minutes || null);
+
+const castMemberSchema = z.object({
+  actor_name: nameSchema,
+  character_name: z.string().max(500).optional(),
+  character_names: z.array(z.string().trim().max(255)).optional(),
+  profile_url: urlSchema.optional(),
+  actor_order: z.number().int().nonnegative(),
+  billing_order: z.number().int().nonnegative().optional(),
+  credited: z.boolean().optional()
+});
+
+const studioSchema = z.object({
+  studio_name: nameSchema,
+  logo_url: urlSchema.optional(),
+  country: z.string().max(5).optional()
+});
+
+const translationSchema = z.object({
+  language: languageSchema,
+  title: z.string().max(500).nullable().optional(),
+  overview: z.string().nullable().optional(),
+  tagline: z.string().max(500).nullable().optional()
+});
+
+/**
+ * Keywords as a list or one semicolon-separated string, normalized
+ */
+const keywordsSchema = z.union([z.array(z.string()), z.string()])
+  .transform(parseKeywords)
+  .pipe(z.array(z.string().max(MAX_KEYWORD_LENGTH)));
+
+/**
+ * Languages or countries as a list or one comma-separated string, resolved
+ * to ISO codes where known
+ */
+const localeListSchema = (resolve: (value: string) => { code: string }) =>
+  z.union([z.array(z.string()), z.string()])
+    .transform(value => [...new Set(parseLocaleList(value).map(name => resolve(name).code))])
+    .pipe(z.array(z.string().max(MAX_LOCALE_LENGTH)));
+
+/**
+ * Body of POST /api/movies, and each entry of POST /api/movies/bulk
+ */
+const movieCreateSchema = z.object({
+  title: z.string().trim().min(1).max(500),
+  original_title: z.string().trim().min(1).max(500),
+  release_date: releaseDateSchema,
+  runtime_minutes: runtimeSchema,
+  genres: z.array(z.string().trim().min(1).max(100)).min(1, 'genres must list at least one genre'),
+  overview: z.string(),
+  mpa_rating: z.enum(MPA_RATINGS),
+  adult: z.boolean().optional(),
+  budget: z.number().int().nonnegative().optional(),
+  revenue: z.number().int().nonnegative().optional(),
+  vote_count: z.number().int().nonnegative().optional(),
+  vote_average: z.number().min(0).max(10).optional(),
+  directors: z.array(nameSchema).optional(),
+  producers: z.array(nameSchema).optional(),
+  writers: z.array(nameSchema).optional(),
+  composers: z.array(nameSchema).optional(),
+  cinematographers: z.array(nameSchema).optional(),
+  editors: z.array(nameSchema).optional(),
+  keywords: keywordsSchema.optional(),
+  spoken_languages: localeListSchema(resolveLanguage).optional(),
+  production_countries: localeListSchema(resolveCountry).optional(),
+  studios: z.array(studioSchema).optional(),
+  cast: z.array(castMemberSchema).optional(),
+  poster_url: urlSchema.optional(),
+  backdrop_url: urlSchema.optional(),
+  trailer_url: urlSchema.nullable().optional(),
+  collection_name: nameSchema.optional(),
+  translations: z.array(translationSchema).optional()
+});
+
+const bulkImportSchema = z.object({
+  movies: z.array(movieCreateSchema).min(1, 'movies must contain at least one movie')
+});
 
 /**
  * Helper function to get or create a genre and return its ID
  */
-const getOrCreateGenreId = async (client: PoolClient, genreName: string): Promise<number> => {
+export const getOrCreateGenreId = async (client: PoolClient, genreName: string): Promise<number> => {
   const checkSql = 'SELECT genre_id FROM genres WHERE genre_name = $1';
   let result = await client.query(checkSql, [genreName.trim()]);
   
@@ -22,8 +138,8 @@ const getOrCreateGenreId = async (client: PoolClient, genreName: string): Promis
 /**
  * Helper function to get or create a director and return its ID
  */
-const getOrCreateDirectorId = async (client: PoolClient, directorName: string): Promise<number> => {
-  const checkSql = 'SELECT director_id FROM directors WHERE director_name = $1';
+export const getOrCreateDirectorId = async (client: PoolClient, directorName: string): Promise<number> => {
+  const checkSql = `SELECT director_id FROM directors WHERE ${nameMatchSql('director_name', '$1')} ORDER BY director_id LIMIT 1`;
   let result = await client.query(checkSql, [directorName.trim()]);
   
   if (result.rows.length > 0) {
@@ -38,8 +154,8 @@ const getOrCreateDirectorId = async (client: PoolClient, directorName: string):
 /**
  * Helper function to get or create a producer and return its ID
  */
-const getOrCreateProducerId = async (client: PoolClient, producerName: string): Promise<number> => {
-  const checkSql = 'SELECT producer_id FROM producers WHERE producer_name = $1';
+export const getOrCreateProducerId = async (client: PoolClient, producerName: string): Promise<number> => {
+  const checkSql = `SELECT producer_id FROM producers WHERE ${nameMatchSql('producer_name', '$1')} ORDER BY producer_id LIMIT 1`;
   let result = await client.query(checkSql, [producerName.trim()]);
   
   if (result.rows.length > 0) {
@@ -54,8 +170,8 @@ const getOrCreateProducerId = async (client: PoolClient, producerName: string):
 /**
  * Helper function to get or create a studio and return its ID
  */
-const getOrCreateStudioId = async (client: PoolClient, studio: MovieStudio): Promise<number> => {
-  const checkSql = 'SELECT studio_id FROM studios WHERE studio_name = $1';
+export const getOrCreateStudioId = async (client: PoolClient, studio: MovieStudio): Promise<number> => {
+  const checkSql = `SELECT studio_id FROM studios WHERE ${nameMatchSql('studio_name', '$1')} ORDER BY studio_id LIMIT 1`;
   let result = await client.query(checkSql, [studio.studio_name.trim()]);
   
   if (result.rows.length > 0) {
@@ -76,25 +192,38 @@ const getOrCreateStudioId = async (client: PoolClient, studio: MovieStudio): Pro
 };
 
 /**
- * Helper function to get or create an actor and return its ID
+ * Helper function to get or create an actor and return its ID.
+ * The profile URL is recorded in person_images even when the actor exists,
+ * and fills in a missing profile_url.
  */
-const getOrCreateActorId = async (client: PoolClient, actorName: string, profileUrl?: string): Promise<number> => {
-  const checkSql = 'SELECT actor_id FROM actors WHERE actor_name = $1';
+export const getOrCreateActorId = async (client: PoolClient, actorName: string, profileUrl?: string): Promise<number> => {
+  const checkSql = `SELECT actor_id FROM actors WHERE ${nameMatchSql('actor_name', '$1')} ORDER BY actor_id LIMIT 1`;
   let result = await client.query(checkSql, [actorName.trim()]);
   
   if (result.rows.length > 0) {
-    return result.rows[0].actor_id;
+    const actorId = result.rows[0].actor_id;
+    if (profileUrl) {
+      await client.query(
+        'UPDATE actors SET profile_url = $2 WHERE actor_id = $1 AND profile_url IS NULL',
+        [actorId, profileUrl]
+      );
+      await recordPersonImage(client, actorId, profileUrl);
+    }
+    return actorId;
   }
   
   const insertSql = 'INSERT INTO actors (actor_name, profile_url) VALUES ($1, $2) RETURNING actor_id';
   result = await client.query(insertSql, [actorName.trim(), profileUrl || null]);
+  if (profileUrl) {
+    await recordPersonImage(client, result.rows[0].actor_id, profileUrl);
+  }
   return result.rows[0].actor_id;
 };
 
 /**
  * Helper function to get or create a collection and return its ID
  */
-const getOrCreateCollectionId = async (client: PoolClient, collectionName: string): Promise<number> => {
+export const getOrCreateCollectionId = async (client: PoolClient, collectionName: string): Promise<number> => {
   const checkSql = 'SELECT collection_id FROM collections WHERE collection_name = $1';
   let result = await client.query(checkSql, [collectionName.trim()]);
   
@@ -107,110 +236,182 @@ const getOrCreateCollectionId = async (client: PoolClient, collectionName: strin
   return result.rows[0].collection_id;
 };
 
+/**
+ * Splits a combined character credit ("Character A / Character B") into roles
+ */
+export const splitCharacterNames = (characterName?: string | null): string[] =>
+  (characterName ?? '')
+    .split(/\s+\/\s+/)
+    .map(part => part.trim())
+    .filter(part => part.length > 0);
+
+/**
+ * Inserts a movie's whole cast, top-billed first. Each entry's roles are stored separately in character_names and joined in
+ * character_name, whichever of the two the caller supplied.
+ */
+export const insertCastMembers = async (client: PoolClient, movieId: number, cast: CastMember[]): Promise<number> => {
+  const castToInsert = [...cast]
+    .sort((a, b) => (a.billing_order ?? a.actor_order) - (b.billing_order ?? b.actor_order) || a.actor_order - b.actor_order);
+
+  for (const castMember of castToInsert) {
+    const actorId = await getOrCreateActorId(client, castMember.actor_name, castMember.profile_url);
+    const characterNames = castMember.character_names?.map(name => name.trim()).filter(name => name.length > 0)
+      ?? splitCharacterNames(castMember.character_name);
+    const characterName = castMember.character_name || characterNames.join(' / ') || null;
+
+    await client.query(
+      `INSERT INTO movie_actors (movie_id, actor_id, character_name, character_names, actor_order, billing_order, credited)
+       VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+      [
+        movieId,
+        actorId,
+        characterName,
+        characterNames.length > 0 ? characterNames : null,
+        castMember.actor_order,
+        castMember.billing_order ?? null,
+        castMember.credited ?? true
+      ]
+    );
+  }
+
+  return castToInsert.length;
+};
+
+/**
+ * Inserts a movie with all related data inside the caller's transaction,
+ * recording it as created by the import run
+ */
+export const insertMovie = async (
+  client: PoolClient,
+  movieData: MovieCreateInput,
+  runId: number
+): Promise<{ movie_id: number; public_id: string }> => {
+  // Handle collection if provided
+  let collectionId: number | null = null;
+  if (movieData.collection_name) {
+    collectionId = await getOrCreateCollectionId(client, movieData.collection_name);
+  }
+  
+  // Insert the main movie record
+  const movieInsertSql = `
+    INSERT INTO movies (
+      title, original_title, release_date, runtime_minutes, 
+      overview, budget, revenue, mpa_rating, collection_id,
+      poster_url, backdrop_url, adult, vote_count, vote_average, trailer_url
+    ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+    RETURNING movie_id, public_id
+  `;
+  
+  const movieResult = await client.query(movieInsertSql, [
+    movieData.title,
+    movieData.original_title,
+    movieData.release_date,
+    movieData.runtime_minutes,
+    sanitizeText(movieData.overview),
+    movieData.budget || null,
+    movieData.revenue || null,
+    movieData.mpa_rating,
+    collectionId,
+    movieData.poster_url || null,
+    movieData.backdrop_url || null,
+    movieData.adult ?? false,
+    movieData.vote_count ?? null,
+    movieData.vote_average ?? null,
+    movieData.trailer_url || null
+  ]);
+  
+  const movieId = movieResult.rows[0].movie_id;
+  await enrichMovies(client, [movieId]);
+  await generatePlaceholderPosters(client, [movieId]);
+  
+  // Insert genres (required)
+  if (movieData.genres && movieData.genres.length > 0) {
+    for (const genreName of movieData.genres) {
+      const genreId = await getOrCreateGenreId(client, genreName);
+      await client.query(
+        'INSERT INTO movie_genres (movie_id, genre_id) VALUES ($1, $2)',
+        [movieId, genreId]
+      );
+    }
+  }
+  
+  // Insert directors (optional)
+  if (movieData.directors && movieData.directors.length > 0) {
+    for (const directorName of movieData.directors) {
+      const directorId = await getOrCreateDirectorId(client, directorName);
+      await client.query(
+        'INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2) ON CONFLICT DO NOTHING',
+        [movieId, directorId]
+      );
+    }
+  }
+  
+  // Insert producers (optional)
+  if (movieData.producers && movieData.producers.length > 0) {
+    for (const producerName of movieData.producers) {
+      const producerId = await getOrCreateProducerId(client, producerName);
+      await client.query(
+        'INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2) ON CONFLICT DO NOTHING',
+        [movieId, producerId]
+      );
+    }
+  }
+
+  // Insert writers, composers, cinematographers and editors (optional)
+  await insertMovieCrew(client, movieId, movieData);
+
+  // Insert keywords (optional)
+  if (movieData.keywords && movieData.keywords.length > 0) {
+    await insertMovieKeywords(client, movieId, movieData.keywords);
+  }
+
+  // Insert spoken languages and production countries (optional)
+  await insertMovieLocales(client, movieId, movieData);
+  
+  // Insert studios (optional)
+  if (movieData.studios && movieData.studios.length > 0) {
+    for (const studio of movieData.studios) {
+      const studioId = await getOrCreateStudioId(client, studio);
+      await client.query(
+        'INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2) ON CONFLICT DO NOTHING',
+        [movieId, studioId]
+      );
+    }
+  }
+  
+  // Insert cast (optional)
+  if (movieData.cast && movieData.cast.length > 0) {
+    await insertCastMembers(client, movieId, movieData.cast);
+  }
+  
+  // Insert translations (optional)
+  if (movieData.translations && movieData.translations.length > 0) {
+    await saveMovieTranslations(client, movieId, movieData.translations);
+  }
+
+  await recordMovieLineage(client, runId, movieId, null);
+
+  return { movie_id: movieId, public_id: movieResult.rows[0].public_id };
+};
+
 /**
  * Main function to add a single movie with all related data
  */
-export const addMovie = async (req: Request, res: Response) => {
+export const addMovie = async (req: ApiKeyRequest, res: Response) => {
   const movieData: MovieCreateInput = req.body;
   
   const client = await pool.connect();
   
   try {
     await client.query('BEGIN');
-    
-    // Handle collection if provided
-    let collectionId: number | null = null;
-    if (movieData.collection_name) {
-      collectionId = await getOrCreateCollectionId(client, movieData.collection_name);
-    }
-    
-    // Insert the main movie record
-    const movieInsertSql = `
-      INSERT INTO movies (
-        title, original_title, release_date, runtime_minutes, 
-        overview, budget, revenue, mpa_rating, collection_id,
-        poster_url, backdrop_url
-      ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-      RETURNING movie_id
-    `;
-    
-    const movieResult = await client.query(movieInsertSql, [
-      movieData.title,
-      movieData.original_title,
-      movieData.release_date,
-      movieData.runtime_minutes,
-      movieData.overview,
-      movieData.budget || null,
-      movieData.revenue || null,
-      movieData.mpa_rating,
-      collectionId,
-      movieData.poster_url || null,
-      movieData.backdrop_url || null
-    ]);
-    
-    const movieId = movieResult.rows[0].movie_id;
-    
-    // Insert genres (required)
-    if (movieData.genres && movieData.genres.length > 0) {
-      for (const genreName of movieData.genres) {
-        const genreId = await getOrCreateGenreId(client, genreName);
-        await client.query(
-          'INSERT INTO movie_genres (movie_id, genre_id) VALUES ($1, $2)',
-          [movieId, genreId]
-        );
-      }
-    }
-    
-    // Insert directors (optional)
-    if (movieData.directors && movieData.directors.length > 0) {
-      for (const directorName of movieData.directors) {
-        const directorId = await getOrCreateDirectorId(client, directorName);
-        await client.query(
-          'INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2)',
-          [movieId, directorId]
-        );
-      }
-    }
-    
-    // Insert producers (optional)
-    if (movieData.producers && movieData.producers.length > 0) {
-      for (const producerName of movieData.producers) {
-        const producerId = await getOrCreateProducerId(client, producerName);
-        await client.query(
-          'INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2)',
-          [movieId, producerId]
-        );
-      }
-    }
-    
-    // Insert studios (optional)
-    if (movieData.studios && movieData.studios.length > 0) {
-      for (const studio of movieData.studios) {
-        const studioId = await getOrCreateStudioId(client, studio);
-        await client.query(
-          'INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2)',
-          [movieId, studioId]
-        );
-      }
-    }
-    
-    // Insert cast (optional, max 10)
-    if (movieData.cast && movieData.cast.length > 0) {
-      const castToInsert = movieData.cast.slice(0, 10); // Limit to 10
-      for (const castMember of castToInsert) {
-        const actorId = await getOrCreateActorId(client, castMember.actor_name, castMember.profile_url);
-        await client.query(
-          'INSERT INTO movie_actors (movie_id, actor_id, character_name, actor_order) VALUES ($1, $2, $3, $4)',
-          [movieId, actorId, castMember.character_name || null, castMember.actor_order]
-        );
-      }
-    }
-    
+    const runId = await startImportRun(client, 'api', { api_key_id: req.apiKey?.api_key_id });
+    const movie = await insertMovie(client, movieData, runId);
     await client.query('COMMIT');
     
     const response: MovieCreateResponse = {
       success: true,
-      movie_id: movieId,
+      movie_id: movie.movie_id,
+      public_id: movie.public_id,
       message: `Movie "${movieData.title}" added successfully`
     };
     
@@ -231,109 +432,36 @@ export const addMovie = async (req: Request, res: Response) => {
 /**
  * Bulk import multiple movies
  */
-export const addMoviesBulk = async (req: Request, res: Response) => {
+export const addMoviesBulk = async (req: ApiKeyRequest, res: Response) => {
   const movies: MovieCreateInput[] = req.body.movies;
-  
-  if (!Array.isArray(movies) || movies.length === 0) {
-    return res.status(400).json({
-      success: false,
-      message: 'Request body must contain a "movies" array with at least one movie'
-    });
-  }
+  const runId = await startImportRun(pool, 'bulk', { api_key_id: req.apiKey?.api_key_id });
   
   const results: BulkImportResponse['results'] = [];
   let successCount = 0;
   let failCount = 0;
   
   for (const movieData of movies) {
+    // One span per movie so traces show which record a slow import spent its time on
+    const span = startSpan('import.movie', { attributes: { 'movie.title': movieData.title } });
     const client = await pool.connect();
     
     try {
       await client.query('BEGIN');
-      
-      // Handle collection
-      let collectionId: number | null = null;
-      if (movieData.collection_name) {
-        collectionId = await getOrCreateCollectionId(client, movieData.collection_name);
-      }
-      
-      // Insert movie
-      const movieInsertSql = `
-        INSERT INTO movies (
-          title, original_title, release_date, runtime_minutes, 
-          overview, budget, revenue, mpa_rating, collection_id,
-          poster_url, backdrop_url
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-        RETURNING movie_id
-      `;
-      
-      const movieResult = await client.query(movieInsertSql, [
-        movieData.title,
-        movieData.original_title,
-        movieData.release_date,
-        movieData.runtime_minutes,
-        movieData.overview,
-        movieData.budget || null,
-        movieData.revenue || null,
-        movieData.mpa_rating,
-        collectionId,
-        movieData.poster_url || null,
-        movieData.backdrop_url || null
-      ]);
-      
-      const movieId = movieResult.rows[0].movie_id;
-      
-      // Insert all related entities (same as addMovie)
-      if (movieData.genres && movieData.genres.length > 0) {
-        for (const genreName of movieData.genres) {
-          const genreId = await getOrCreateGenreId(client, genreName);
-          await client.query('INSERT INTO movie_genres (movie_id, genre_id) VALUES ($1, $2)', [movieId, genreId]);
-        }
-      }
-      
-      if (movieData.directors && movieData.directors.length > 0) {
-        for (const directorName of movieData.directors) {
-          const directorId = await getOrCreateDirectorId(client, directorName);
-          await client.query('INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2)', [movieId, directorId]);
-        }
-      }
-      
-      if (movieData.producers && movieData.producers.length > 0) {
-        for (const producerName of movieData.producers) {
-          const producerId = await getOrCreateProducerId(client, producerName);
-          await client.query('INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2)', [movieId, producerId]);
-        }
-      }
-      
-      if (movieData.studios && movieData.studios.length > 0) {
-        for (const studio of movieData.studios) {
-          const studioId = await getOrCreateStudioId(client, studio);
-          await client.query('INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2)', [movieId, studioId]);
-        }
-      }
-      
-      if (movieData.cast && movieData.cast.length > 0) {
-        const castToInsert = movieData.cast.slice(0, 10);
-        for (const castMember of castToInsert) {
-          const actorId = await getOrCreateActorId(client, castMember.actor_name, castMember.profile_url);
-          await client.query(
-            'INSERT INTO movie_actors (movie_id, actor_id, character_name, actor_order) VALUES ($1, $2, $3, $4)',
-            [movieId, actorId, castMember.character_name || null, castMember.actor_order]
-          );
-        }
-      }
-      
+      const movie = await insertMovie(client, movieData, runId);
       await client.query('COMMIT');
       
       results.push({
         title: movieData.title,
         success: true,
-        movie_id: movieId
+        movie_id: movie.movie_id,
+        public_id: movie.public_id
       });
       successCount++;
+      span.setAttributes({ 'movie.id': movie.movie_id });
       
     } catch (error) {
       await client.query('ROLLBACK');
+      span.recordError(error);
       results.push({
         title: movieData.title,
         success: false,
@@ -342,9 +470,15 @@ export const addMoviesBulk = async (req: Request, res: Response) => {
       failCount++;
     } finally {
       client.release();
+      span.end();
     }
   }
   
+  // Keep the box-office time series in step with the import
+  if (successCount > 0) {
+    scheduleBoxOfficeRefresh();
+  }
+  
   const response: BulkImportResponse = {
     success: failCount === 0,
     total_processed: movies.length,
@@ -354,4 +488,13 @@ export const addMoviesBulk = async (req: Request, res: Response) => {
   };
   
   res.status(failCount === 0 ? 201 : 207).json(response);
-};
\ No newline at end of file
+};
+
+// Export schemas
+export {
+  castMemberSchema,
+  studioSchema,
+  translationSchema,
+  movieCreateSchema,
+  bulkImportSchema
+};
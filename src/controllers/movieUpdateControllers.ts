@@ -1,7 +1,44 @@
 import { MovieUpdateInput, CastMember, MovieStudio } from '@models/movieModel';
 import pool from '@utils/database';
+import { sanitizeText } from '@utils/sanitize';
+import { enrichMovies } from '@utils/inflation';
+import { generatePlaceholderPosters } from '@utils/placeholderPosters';
+import { replaceMovieCrew } from '@utils/movieCrew';
+import { replaceMovieKeywords } from '@utils/movieKeywords';
+import { replaceMovieLocales } from '@utils/movieLocales';
+import { nameMatchSql } from '@utils/nameMatching';
+import { notifyMovieWatchers } from '@utils/push';
 import { Request, Response } from 'express';
 import { PoolClient } from 'pg';
+import { saveMovieTranslations } from './translationControllers';
+import { movieETag } from './movieGetControllers';
+import { castMemberSchema, insertCastMembers, movieCreateSchema } from './moviePostControllers';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation (request DTOs, applied by validateRequest)
+// ============================================================================
+
+/**
+ * Expected row version; also accepted as an If-Match header
+ */
+const versionSchema = z.number().int().nonnegative().optional();
+
+/**
+ * Body of PUT and PATCH /api/movies/:id. Fields left out are unchanged;
+ * relation arrays that are present replace the existing ones.
+ */
+const movieUpdateSchema = movieCreateSchema.partial().extend({
+  version: versionSchema
+});
+
+/**
+ * Body of PATCH /api/movies/:id/cast
+ */
+const castUpdateSchema = z.object({
+  cast: z.array(castMemberSchema),
+  version: versionSchema
+});
 
 /**
  * Helper functions (same as in POST controllers)
@@ -20,7 +57,7 @@ const getOrCreateGenreId = async (client: PoolClient, genreName: string): Promis
 };
 
 const getOrCreateDirectorId = async (client: PoolClient, directorName: string): Promise<number> => {
-  const checkSql = 'SELECT director_id FROM directors WHERE director_name = $1';
+  const checkSql = `SELECT director_id FROM directors WHERE ${nameMatchSql('director_name', '$1')} ORDER BY director_id LIMIT 1`;
   let result = await client.query(checkSql, [directorName.trim()]);
   
   if (result.rows.length > 0) {
@@ -33,7 +70,7 @@ const getOrCreateDirectorId = async (client: PoolClient, directorName: string):
 };
 
 const getOrCreateProducerId = async (client: PoolClient, producerName: string): Promise<number> => {
-  const checkSql = 'SELECT producer_id FROM producers WHERE producer_name = $1';
+  const checkSql = `SELECT producer_id FROM producers WHERE ${nameMatchSql('producer_name', '$1')} ORDER BY producer_id LIMIT 1`;
   let result = await client.query(checkSql, [producerName.trim()]);
   
   if (result.rows.length > 0) {
@@ -46,7 +83,7 @@ const getOrCreateProducerId = async (client: PoolClient, producerName: string):
 };
 
 const getOrCreateStudioId = async (client: PoolClient, studio: MovieStudio): Promise<number> => {
-  const checkSql = 'SELECT studio_id FROM studios WHERE studio_name = $1';
+  const checkSql = `SELECT studio_id FROM studios WHERE ${nameMatchSql('studio_name', '$1')} ORDER BY studio_id LIMIT 1`;
   let result = await client.query(checkSql, [studio.studio_name.trim()]);
   
   if (result.rows.length > 0) {
@@ -66,19 +103,6 @@ const getOrCreateStudioId = async (client: PoolClient, studio: MovieStudio): Pro
   return result.rows[0].studio_id;
 };
 
-const getOrCreateActorId = async (client: PoolClient, actorName: string, profileUrl?: string): Promise<number> => {
-  const checkSql = 'SELECT actor_id FROM actors WHERE actor_name = $1';
-  let result = await client.query(checkSql, [actorName.trim()]);
-  
-  if (result.rows.length > 0) {
-    return result.rows[0].actor_id;
-  }
-  
-  const insertSql = 'INSERT INTO actors (actor_name, profile_url) VALUES ($1, $2) RETURNING actor_id';
-  result = await client.query(insertSql, [actorName.trim(), profileUrl || null]);
-  return result.rows[0].actor_id;
-};
-
 const getOrCreateCollectionId = async (client: PoolClient, collectionName: string): Promise<number> => {
   const checkSql = 'SELECT collection_id FROM collections WHERE collection_name = $1';
   let result = await client.query(checkSql, [collectionName.trim()]);
@@ -92,6 +116,26 @@ const getOrCreateCollectionId = async (client: PoolClient, collectionName: strin
   return result.rows[0].collection_id;
 };
 
+/**
+ * Read the version the caller expects to be editing, from an If-Match header
+ * ("3" or W/"3") or a `version` field in the body. Returns undefined when no
+ * precondition was sent and NaN when the value isn't a version number.
+ */
+const getExpectedVersion = (req: Request): number | undefined => {
+  const ifMatch = req.get('If-Match')?.trim();
+  
+  if (ifMatch && ifMatch !== '*') {
+    const match = /^(?:W\/)?"(\d+)"$/.exec(ifMatch);
+    return match ? parseInt(match[1], 10) : NaN;
+  }
+  
+  if (req.body?.version !== undefined) {
+    return Number.isInteger(req.body.version) ? req.body.version : NaN;
+  }
+  
+  return undefined;
+};
+
 /**
  * PUT - Update complete movie record (replaces all data)
  */
@@ -105,14 +149,22 @@ export const updateMovie = async (req: Request, res: Response) => {
     });
   }
   
+  const expectedVersion = getExpectedVersion(req);
+  if (Number.isNaN(expectedVersion)) {
+    return res.status(400).json({
+      success: false,
+      message: 'If-Match or version must be a movie version number'
+    });
+  }
+  
   const movieData: MovieUpdateInput = req.body;
   const client = await pool.connect();
   
   try {
     await client.query('BEGIN');
     
-    // Check if movie exists
-    const checkResult = await client.query('SELECT movie_id FROM movies WHERE movie_id = $1', [movieId]);
+    // Check if movie exists, locking it so the version check and the write are atomic
+    const checkResult = await client.query('SELECT version, trailer_url FROM movies WHERE movie_id = $1 FOR UPDATE', [movieId]);
     if (checkResult.rows.length === 0) {
       await client.query('ROLLBACK');
       return res.status(404).json({
@@ -121,6 +173,18 @@ export const updateMovie = async (req: Request, res: Response) => {
       });
     }
     
+    // Reject the edit if someone else saved the movie after the caller read it
+    const currentVersion: number = checkResult.rows[0].version;
+    if (expectedVersion !== undefined && expectedVersion !== currentVersion) {
+      await client.query('ROLLBACK');
+      res.set('ETag', movieETag(currentVersion));
+      return res.status(412).json({
+        success: false,
+        message: 'Movie has been modified since it was last read',
+        current_version: currentVersion
+      });
+    }
+    
     // Build dynamic UPDATE query for movies table
     const updateFields: string[] = [];
     const updateValues: any[] = [];
@@ -144,7 +208,7 @@ export const updateMovie = async (req: Request, res: Response) => {
     }
     if (movieData.overview !== undefined) {
       updateFields.push(`overview = $${paramIndex++}`);
-      updateValues.push(movieData.overview);
+      updateValues.push(sanitizeText(movieData.overview));
     }
     if (movieData.budget !== undefined) {
       updateFields.push(`budget = $${paramIndex++}`);
@@ -154,10 +218,22 @@ export const updateMovie = async (req: Request, res: Response) => {
       updateFields.push(`revenue = $${paramIndex++}`);
       updateValues.push(movieData.revenue);
     }
+    if (movieData.vote_count !== undefined) {
+      updateFields.push(`vote_count = $${paramIndex++}`);
+      updateValues.push(movieData.vote_count);
+    }
+    if (movieData.vote_average !== undefined) {
+      updateFields.push(`vote_average = $${paramIndex++}`);
+      updateValues.push(movieData.vote_average);
+    }
     if (movieData.mpa_rating !== undefined) {
       updateFields.push(`mpa_rating = $${paramIndex++}`);
       updateValues.push(movieData.mpa_rating);
     }
+    if (movieData.adult !== undefined) {
+      updateFields.push(`adult = $${paramIndex++}`);
+      updateValues.push(movieData.adult);
+    }
     if (movieData.poster_url !== undefined) {
       updateFields.push(`poster_url = $${paramIndex++}`);
       updateValues.push(movieData.poster_url);
@@ -166,6 +242,10 @@ export const updateMovie = async (req: Request, res: Response) => {
       updateFields.push(`backdrop_url = $${paramIndex++}`);
       updateValues.push(movieData.backdrop_url);
     }
+    if (movieData.trailer_url !== undefined) {
+      updateFields.push(`trailer_url = $${paramIndex++}`);
+      updateValues.push(movieData.trailer_url || null);
+    }
     
     // Handle collection
     if (movieData.collection_name !== undefined) {
@@ -176,16 +256,19 @@ export const updateMovie = async (req: Request, res: Response) => {
       updateValues.push(collectionId);
     }
     
-    // Update movies table if there are fields to update
-    if (updateFields.length > 0) {
-      updateValues.push(movieId); // Add movieId as last parameter
-      const updateSql = `
-        UPDATE movies 
-        SET ${updateFields.join(', ')} 
-        WHERE movie_id = $${paramIndex}
-      `;
-      await client.query(updateSql, updateValues);
-    }
+    // Update movies table; the version is bumped even if only related entities change
+    updateFields.push('version = version + 1', 'updated_at = NOW()');
+    updateValues.push(movieId);
+    const updateSql = `
+      UPDATE movies 
+      SET ${updateFields.join(', ')} 
+      WHERE movie_id = $${paramIndex}
+      RETURNING version
+    `;
+    const updateResult = await client.query(updateSql, updateValues);
+    const newVersion: number = updateResult.rows[0].version;
+    await enrichMovies(client, [movieId]);
+    await generatePlaceholderPosters(client, [movieId]);
     
     // Update genres (replace all)
     if (movieData.genres !== undefined) {
@@ -204,7 +287,7 @@ export const updateMovie = async (req: Request, res: Response) => {
       if (movieData.directors.length > 0) {
         for (const directorName of movieData.directors) {
           const directorId = await getOrCreateDirectorId(client, directorName);
-          await client.query('INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2)', [movieId, directorId]);
+          await client.query('INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, directorId]);
         }
       }
     }
@@ -215,10 +298,19 @@ export const updateMovie = async (req: Request, res: Response) => {
       if (movieData.producers.length > 0) {
         for (const producerName of movieData.producers) {
           const producerId = await getOrCreateProducerId(client, producerName);
-          await client.query('INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2)', [movieId, producerId]);
+          await client.query('INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, producerId]);
         }
       }
     }
+
+    // Update other crew (replace each role listed)
+    await replaceMovieCrew(client, movieId, movieData);
+
+    // Update keywords (replace all)
+    await replaceMovieKeywords(client, movieId, movieData.keywords);
+
+    // Update spoken languages and production countries (replace each listed)
+    await replaceMovieLocales(client, movieId, movieData);
     
     // Update studios (replace all)
     if (movieData.studios !== undefined) {
@@ -226,7 +318,7 @@ export const updateMovie = async (req: Request, res: Response) => {
       if (movieData.studios.length > 0) {
         for (const studio of movieData.studios) {
           const studioId = await getOrCreateStudioId(client, studio);
-          await client.query('INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2)', [movieId, studioId]);
+          await client.query('INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, studioId]);
         }
       }
     }
@@ -235,22 +327,26 @@ export const updateMovie = async (req: Request, res: Response) => {
     if (movieData.cast !== undefined) {
       await client.query('DELETE FROM movie_actors WHERE movie_id = $1', [movieId]);
       if (movieData.cast.length > 0) {
-        const castToInsert = movieData.cast.slice(0, 10);
-        for (const castMember of castToInsert) {
-          const actorId = await getOrCreateActorId(client, castMember.actor_name, castMember.profile_url);
-          await client.query(
-            'INSERT INTO movie_actors (movie_id, actor_id, character_name, actor_order) VALUES ($1, $2, $3, $4)',
-            [movieId, actorId, castMember.character_name || null, castMember.actor_order]
-          );
-        }
+        await insertCastMembers(client, movieId, movieData.cast);
       }
     }
     
+    // Update translations (replace all)
+    if (movieData.translations !== undefined) {
+      await client.query('DELETE FROM movie_translations WHERE movie_id = $1', [movieId]);
+      await saveMovieTranslations(client, movieId, movieData.translations);
+    }
+    
     await client.query('COMMIT');
     
+    const trailerAdded = !checkResult.rows[0].trailer_url && Boolean(movieData.trailer_url);
+    void notifyMovieWatchers(movieId, trailerAdded ? 'trailer_added' : 'movie_updated');
+    
+    res.set('ETag', movieETag(newVersion));
     res.status(200).json({
       success: true,
       movie_id: movieId,
+      version: newVersion,
       message: 'Movie updated successfully'
     });
     
@@ -279,14 +375,22 @@ export const patchMovie = async (req: Request, res: Response) => {
     });
   }
   
+  const expectedVersion = getExpectedVersion(req);
+  if (Number.isNaN(expectedVersion)) {
+    return res.status(400).json({
+      success: false,
+      message: 'If-Match or version must be a movie version number'
+    });
+  }
+  
   const movieData: MovieUpdateInput = req.body;
   const client = await pool.connect();
   
   try {
     await client.query('BEGIN');
     
-    // Check if movie exists
-    const checkResult = await client.query('SELECT movie_id FROM movies WHERE movie_id = $1', [movieId]);
+    // Check if movie exists, locking it so the version check and the write are atomic
+    const checkResult = await client.query('SELECT version, trailer_url FROM movies WHERE movie_id = $1 FOR UPDATE', [movieId]);
     if (checkResult.rows.length === 0) {
       await client.query('ROLLBACK');
       return res.status(404).json({
@@ -295,6 +399,18 @@ export const patchMovie = async (req: Request, res: Response) => {
       });
     }
     
+    // Reject the edit if someone else saved the movie after the caller read it
+    const currentVersion: number = checkResult.rows[0].version;
+    if (expectedVersion !== undefined && expectedVersion !== currentVersion) {
+      await client.query('ROLLBACK');
+      res.set('ETag', movieETag(currentVersion));
+      return res.status(412).json({
+        success: false,
+        message: 'Movie has been modified since it was last read',
+        current_version: currentVersion
+      });
+    }
+    
     // Build dynamic UPDATE query (same as PUT but only for provided fields)
     const updateFields: string[] = [];
     const updateValues: any[] = [];
@@ -318,7 +434,7 @@ export const patchMovie = async (req: Request, res: Response) => {
     }
     if (movieData.overview !== undefined) {
       updateFields.push(`overview = $${paramIndex++}`);
-      updateValues.push(movieData.overview);
+      updateValues.push(sanitizeText(movieData.overview));
     }
     if (movieData.budget !== undefined) {
       updateFields.push(`budget = $${paramIndex++}`);
@@ -328,10 +444,22 @@ export const patchMovie = async (req: Request, res: Response) => {
       updateFields.push(`revenue = $${paramIndex++}`);
       updateValues.push(movieData.revenue);
     }
+    if (movieData.vote_count !== undefined) {
+      updateFields.push(`vote_count = $${paramIndex++}`);
+      updateValues.push(movieData.vote_count);
+    }
+    if (movieData.vote_average !== undefined) {
+      updateFields.push(`vote_average = $${paramIndex++}`);
+      updateValues.push(movieData.vote_average);
+    }
     if (movieData.mpa_rating !== undefined) {
       updateFields.push(`mpa_rating = $${paramIndex++}`);
       updateValues.push(movieData.mpa_rating);
     }
+    if (movieData.adult !== undefined) {
+      updateFields.push(`adult = $${paramIndex++}`);
+      updateValues.push(movieData.adult);
+    }
     if (movieData.poster_url !== undefined) {
       updateFields.push(`poster_url = $${paramIndex++}`);
       updateValues.push(movieData.poster_url);
@@ -340,6 +468,10 @@ export const patchMovie = async (req: Request, res: Response) => {
       updateFields.push(`backdrop_url = $${paramIndex++}`);
       updateValues.push(movieData.backdrop_url);
     }
+    if (movieData.trailer_url !== undefined) {
+      updateFields.push(`trailer_url = $${paramIndex++}`);
+      updateValues.push(movieData.trailer_url || null);
+    }
     
     // Handle collection
     if (movieData.collection_name !== undefined) {
@@ -350,16 +482,19 @@ export const patchMovie = async (req: Request, res: Response) => {
       updateValues.push(collectionId);
     }
     
-    // Update movies table if there are fields to update
-    if (updateFields.length > 0) {
-      updateValues.push(movieId);
-      const updateSql = `
-        UPDATE movies 
-        SET ${updateFields.join(', ')} 
-        WHERE movie_id = $${paramIndex}
-      `;
-      await client.query(updateSql, updateValues);
-    }
+    // Update movies table; the version is bumped even if only related entities change
+    updateFields.push('version = version + 1', 'updated_at = NOW()');
+    updateValues.push(movieId);
+    const updateSql = `
+      UPDATE movies 
+      SET ${updateFields.join(', ')} 
+      WHERE movie_id = $${paramIndex}
+      RETURNING version
+    `;
+    const updateResult = await client.query(updateSql, updateValues);
+    const newVersion: number = updateResult.rows[0].version;
+    await enrichMovies(client, [movieId]);
+    await generatePlaceholderPosters(client, [movieId]);
     
     // Only update related entities if explicitly provided
     if (movieData.genres !== undefined) {
@@ -377,7 +512,7 @@ export const patchMovie = async (req: Request, res: Response) => {
       if (movieData.directors.length > 0) {
         for (const directorName of movieData.directors) {
           const directorId = await getOrCreateDirectorId(client, directorName);
-          await client.query('INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2)', [movieId, directorId]);
+          await client.query('INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, directorId]);
         }
       }
     }
@@ -387,17 +522,26 @@ export const patchMovie = async (req: Request, res: Response) => {
       if (movieData.producers.length > 0) {
         for (const producerName of movieData.producers) {
           const producerId = await getOrCreateProducerId(client, producerName);
-          await client.query('INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2)', [movieId, producerId]);
+          await client.query('INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, producerId]);
         }
       }
     }
+
+    // Update other crew (replace each role listed)
+    await replaceMovieCrew(client, movieId, movieData);
+
+    // Update keywords (replace all)
+    await replaceMovieKeywords(client, movieId, movieData.keywords);
+
+    // Update spoken languages and production countries (replace each listed)
+    await replaceMovieLocales(client, movieId, movieData);
     
     if (movieData.studios !== undefined) {
       await client.query('DELETE FROM movie_studios WHERE movie_id = $1', [movieId]);
       if (movieData.studios.length > 0) {
         for (const studio of movieData.studios) {
           const studioId = await getOrCreateStudioId(client, studio);
-          await client.query('INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2)', [movieId, studioId]);
+          await client.query('INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, studioId]);
         }
       }
     }
@@ -405,22 +549,25 @@ export const patchMovie = async (req: Request, res: Response) => {
     if (movieData.cast !== undefined) {
       await client.query('DELETE FROM movie_actors WHERE movie_id = $1', [movieId]);
       if (movieData.cast.length > 0) {
-        const castToInsert = movieData.cast.slice(0, 10);
-        for (const castMember of castToInsert) {
-          const actorId = await getOrCreateActorId(client, castMember.actor_name, castMember.profile_url);
-          await client.query(
-            'INSERT INTO movie_actors (movie_id, actor_id, character_name, actor_order) VALUES ($1, $2, $3, $4)',
-            [movieId, actorId, castMember.character_name || null, castMember.actor_order]
-          );
-        }
+        await insertCastMembers(client, movieId, movieData.cast);
       }
     }
     
+    // Translations are merged per language rather than replaced
+    if (movieData.translations !== undefined) {
+      await saveMovieTranslations(client, movieId, movieData.translations);
+    }
+    
     await client.query('COMMIT');
     
+    const trailerAdded = !checkResult.rows[0].trailer_url && Boolean(movieData.trailer_url);
+    void notifyMovieWatchers(movieId, trailerAdded ? 'trailer_added' : 'movie_updated');
+    
+    res.set('ETag', movieETag(newVersion));
     res.status(200).json({
       success: true,
       movie_id: movieId,
+      version: newVersion,
       message: 'Movie updated successfully'
     });
     
@@ -449,22 +596,23 @@ export const updateCast = async (req: Request, res: Response) => {
     });
   }
   
-  const cast: CastMember[] = req.body.cast;
-  
-  if (!Array.isArray(cast)) {
+  const expectedVersion = getExpectedVersion(req);
+  if (Number.isNaN(expectedVersion)) {
     return res.status(400).json({
       success: false,
-      message: 'Request body must contain a "cast" array'
+      message: 'If-Match or version must be a movie version number'
     });
   }
   
+  const cast: CastMember[] = req.body.cast;
+  
   const client = await pool.connect();
   
   try {
     await client.query('BEGIN');
     
-    // Check if movie exists
-    const checkResult = await client.query('SELECT movie_id FROM movies WHERE movie_id = $1', [movieId]);
+    // Check if movie exists, locking it so the version check and the write are atomic
+    const checkResult = await client.query('SELECT version FROM movies WHERE movie_id = $1 FOR UPDATE', [movieId]);
     if (checkResult.rows.length === 0) {
       await client.query('ROLLBACK');
       return res.status(404).json({
@@ -473,28 +621,41 @@ export const updateCast = async (req: Request, res: Response) => {
       });
     }
     
+    // Reject the edit if someone else saved the movie after the caller read it
+    const currentVersion: number = checkResult.rows[0].version;
+    if (expectedVersion !== undefined && expectedVersion !== currentVersion) {
+      await client.query('ROLLBACK');
+      res.set('ETag', movieETag(currentVersion));
+      return res.status(412).json({
+        success: false,
+        message: 'Movie has been modified since it was last read',
+        current_version: currentVersion
+      });
+    }
+    
     // Delete existing cast
     await client.query('DELETE FROM movie_actors WHERE movie_id = $1', [movieId]);
     
-    // Insert new cast (max 10)
-    if (cast.length > 0) {
-      const castToInsert = cast.slice(0, 10);
-      for (const castMember of castToInsert) {
-        const actorId = await getOrCreateActorId(client, castMember.actor_name, castMember.profile_url);
-        await client.query(
-          'INSERT INTO movie_actors (movie_id, actor_id, character_name, actor_order) VALUES ($1, $2, $3, $4)',
-          [movieId, actorId, castMember.character_name || null, castMember.actor_order]
-        );
-      }
-    }
+    // Insert new cast (top-billed first)
+    const castCount = cast.length > 0 ? await insertCastMembers(client, movieId, cast) : 0;
+    
+    const versionResult = await client.query(
+      'UPDATE movies SET version = version + 1, updated_at = NOW() WHERE movie_id = $1 RETURNING version',
+      [movieId]
+    );
+    const newVersion: number = versionResult.rows[0].version;
     
     await client.query('COMMIT');
     
+    void notifyMovieWatchers(movieId, 'movie_updated');
+    
+    res.set('ETag', movieETag(newVersion));
     res.status(200).json({
       success: true,
       movie_id: movieId,
+      version: newVersion,
       message: 'Cast updated successfully',
-      cast_count: Math.min(cast.length, 10)
+      cast_count: castCount
     });
     
   } catch (error) {
@@ -507,4 +668,10 @@ export const updateCast = async (req: Request, res: Response) => {
   } finally {
     client.release();
   }
-};
\ No newline at end of file
+};
+
+// Export schemas
+export {
+  movieUpdateSchema,
+  castUpdateSchema
+};
@@ -0,0 +1,72 @@
+// server/src/controllers/naturalQueryControllers.ts
+
+import { Request, Response } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { translateNaturalQuery } from '@utils/naturalQuery';
+import { getAllMoviesSchema, searchMovies } from './movieGetControllers';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const naturalQuerySchema = z.object({
+  q: z.string().trim().min(1).max(300)
+});
+
+// ============================================================================
+// Natural-Language Search Controllers
+// ============================================================================
+
+/**
+ * GET /api/search/natural
+ * Experimental: search movies with a request in plain English
+ *
+ * Query Parameters:
+ * - q: e.g. "comedies from the 90s under 100 minutes"
+ * - page, limit, include_adult: As for GET /api/movies
+ *
+ * The request is translated into GET /api/movies filters, which come back in
+ * query.filters so clients can show or tweak them; query.unparsed lists the
+ * words that weren't understood and were ignored.
+ *
+ * @returns The parsed query and the first page of matching movies
+ */
+export const naturalLanguageSearch = async (req: Request, res: Response): Promise<void> => {
+  const validation = naturalQuerySchema.safeParse(req.query);
+
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { q } = validation.data;
+  const { page, limit, include_adult } = req.query;
+
+  try {
+    const parsed = await translateNaturalQuery(q, filters => getAllMoviesSchema.safeParse(filters).success);
+
+    const filters = getAllMoviesSchema.safeParse({ ...parsed.filters, page, limit, include_adult });
+    if (!filters.success) {
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest(filters.error.issues)
+      );
+      return;
+    }
+
+    const response = await searchMovies(filters.data);
+
+    res.status(HttpStatus.OK).json({
+      query: { text: q, ...parsed },
+      ...response
+    });
+  } catch (error) {
+    console.error('Error running natural-language search:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to run natural-language search')
+    );
+  }
+};
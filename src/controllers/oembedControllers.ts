@@ -0,0 +1,158 @@
+// server/src/controllers/oembedControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { nonAdultCondition } from '@utils/contentFilter';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { getApiBaseUrl, getMovieUrl, parseMovieUrl } from '@utils/publicUrls';
+import { escapeHtml } from '@utils/sanitize';
+import z from 'zod';
+
+/**
+ * Card size before maxwidth/maxheight (px)
+ */
+const CARD_WIDTH = 400;
+const CARD_HEIGHT = 150;
+
+/**
+ * Posters are 2:3; their real size isn't stored, so this is what we report
+ */
+const THUMBNAIL_WIDTH = 300;
+const THUMBNAIL_HEIGHT = 450;
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const oembedQuerySchema = z.object({
+  url: z.string().trim().min(1).max(2000),
+  format: z.string().default('json'),
+  maxwidth: z.coerce.number().int().positive().optional(),
+  maxheight: z.coerce.number().int().positive().optional()
+});
+
+// ============================================================================
+// oEmbed Controllers
+// ============================================================================
+
+/**
+ * GET /api/oembed?url=...
+ * oEmbed (https://oembed.com) card for a movie link, so chat apps and CMSs
+ * can unfurl links to the catalog; needs no API key
+ *
+ * Accepts the links getMovieUrl produces: SITE_MOVIE_URL pages, or
+ * /api/movies/:id on this server. Pages can advertise it with
+ * <link rel="alternate" type="application/json+oembed" href="/api/oembed?url=...">.
+ *
+ * Query Parameters:
+ * - url: The movie link
+ * - format: json (the only format served; xml answers 501)
+ * - maxwidth / maxheight: Largest card the consumer can show
+ *
+ * @returns A "rich" oEmbed response with title, poster, year and rating
+ */
+export const getOEmbed = async (req: Request, res: Response): Promise<void> => {
+  const validation = oembedQuerySchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { url, format, maxwidth, maxheight } = validation.data;
+
+  if (format !== 'json') {
+    res.status(HttpStatus.NOT_IMPLEMENTED).json(
+      ApiError.createResponse(HttpStatus.NOT_IMPLEMENTED, 'Only format=json is supported', 'OEMBED_FORMAT_UNSUPPORTED')
+    );
+    return;
+  }
+
+  const id = parseMovieUrl(req, url);
+  if (id === null) {
+    res.status(HttpStatus.NOT_FOUND).json(
+      ApiError.notFound('url is not a movie link from this site', 'NOT_A_MOVIE_URL')
+    );
+    return;
+  }
+
+  // Integer ids only resolve where MOVIE_ID_STRATEGY still accepts them
+  const numericId = /^\d+$/.test(id) && process.env.MOVIE_ID_STRATEGY !== 'public'
+    ? parseInt(id, 10)
+    : null;
+
+  try {
+    const result = await pool.query(
+      `SELECT m.movie_id, m.public_id, m.title, m.release_date, m.mpa_rating,
+              m.vote_average::float8, m.vote_count, m.poster_url
+       FROM movies m
+       WHERE (m.public_id = $1 OR m.movie_id = $2)
+         AND ${nonAdultCondition('m')}
+       LIMIT 1`,
+      [id, numericId]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${id} not found`, 'MOVIE_NOT_FOUND', { id })
+      );
+      return;
+    }
+
+    const movie = result.rows[0];
+    const year = movie.release_date ? movie.release_date.slice(0, 4) : null;
+    const rating = movie.vote_count > 0 && movie.vote_average !== null
+      ? Math.round(movie.vote_average * 10) / 10
+      : null;
+    const title = year ? `${movie.title} (${year})` : movie.title;
+    const width = Math.min(CARD_WIDTH, maxwidth ?? CARD_WIDTH);
+    const height = Math.min(CARD_HEIGHT, maxheight ?? CARD_HEIGHT);
+    const link = getMovieUrl(req, movie);
+    const posterWidth = Math.round(height * 2 / 3);
+
+    const details = [movie.mpa_rating, rating !== null ? `★ ${rating}/10` : null].filter(Boolean).join(' · ');
+    const html = [
+      `<a href="${escapeHtml(link)}" style="display:flex;gap:12px;width:${width}px;height:${height}px;`
+        + 'overflow:hidden;text-decoration:none;color:inherit;font-family:sans-serif;border:1px solid #ddd;border-radius:8px">',
+      movie.poster_url
+        ? `<img src="${escapeHtml(movie.poster_url)}" alt="" width="${posterWidth}" height="${height}" style="object-fit:cover">`
+        : '',
+      '<span style="padding:12px 12px 12px 0">',
+      `<strong>${escapeHtml(title)}</strong>`,
+      details ? `<br><span>${escapeHtml(details)}</span>` : '',
+      '</span>',
+      '</a>'
+    ].join('');
+
+    res.status(HttpStatus.OK).json({
+      version: '1.0',
+      type: 'rich',
+      title,
+      provider_name: 'TCSS 460 Movie API',
+      provider_url: getApiBaseUrl(req),
+      cache_age: 86400,
+      html,
+      width,
+      height,
+      ...(movie.poster_url
+        ? {
+            thumbnail_url: movie.poster_url,
+            thumbnail_width: THUMBNAIL_WIDTH,
+            thumbnail_height: THUMBNAIL_HEIGHT
+          }
+        : {}),
+      // Extensions for consumers that build their own card
+      url: link,
+      year: year ? parseInt(year, 10) : null,
+      mpa_rating: movie.mpa_rating,
+      rating
+    });
+  } catch (error) {
+    console.error('Error building oEmbed response:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to build embed')
+    );
+  }
+};
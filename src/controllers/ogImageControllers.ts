@@ -0,0 +1,78 @@
+// server/src/controllers/ogImageControllers.ts
+
+import { Request, Response } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { loadOgCard, OG_IMAGE_TYPES, OgImageType, renderOgImage } from '@utils/ogImages';
+
+/**
+ * What each image type is called in a 404
+ */
+const NOT_FOUND_NAMES: Record<OgImageType, string> = {
+  movie: 'Movie',
+  person: 'Actor',
+  director: 'Director',
+  genre: 'Genre'
+};
+
+// ============================================================================
+// Open Graph Image Controllers
+// ============================================================================
+
+/**
+ * GET /api/og/:type/:id.png
+ * Open Graph preview image (1200x630) for a movie, person, director or genre
+ * page; needs no API key so pages can point og:image and twitter:image at it
+ *
+ * Cards show the title, a few stats and the poster, profile photo or a
+ * collage of best-known posters. They are drawn on first request and cached;
+ * the ETag changes whenever anything on the card does.
+ *
+ * @param type - movie, person (an actor ID), director or genre
+ * @param id - Movie ID or public_id, or the actor, director or genre ID
+ * @returns image/png
+ */
+export const getOgImage = async (req: Request, res: Response): Promise<void> => {
+  const type = req.params.type as OgImageType;
+  const id = req.params.id;
+
+  if (!OG_IMAGE_TYPES.includes(type)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(`Image type must be one of ${OG_IMAGE_TYPES.join(', ')}`, 'OG_IMAGE_TYPE_INVALID', { types: OG_IMAGE_TYPES.join(', ') })
+    );
+    return;
+  }
+
+  if (type !== 'movie' && !/^\d+$/.test(id)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('ID must be a valid number', 'INVALID_ID')
+    );
+    return;
+  }
+
+  try {
+    const card = await loadOgCard(type, id);
+    if (!card) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`${NOT_FOUND_NAMES[type]} with ID ${id} not found`)
+      );
+      return;
+    }
+
+    res.set('Cache-Control', 'public, max-age=86400').set('ETag', card.etag);
+    if (req.get('If-None-Match') === card.etag) {
+      res.status(HttpStatus.NOT_MODIFIED).end();
+      return;
+    }
+
+    res
+      .status(HttpStatus.OK)
+      .type('image/png')
+      .send(await renderOgImage(card));
+  } catch (error) {
+    console.error('Error rendering OG image:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to render preview image')
+    );
+  }
+};
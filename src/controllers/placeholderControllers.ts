@@ -0,0 +1,63 @@
+// server/src/controllers/placeholderControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { renderPlaceholderPoster } from '@utils/placeholderPosters';
+
+// ============================================================================
+// Placeholder Poster Controllers
+// ============================================================================
+
+/**
+ * GET /api/movies/:id/placeholder.svg
+ * Stand-in poster (title and year) for a movie without a poster_url; needs
+ * no API key so it can be used directly as an <img> src
+ *
+ * Served from placeholder_posters, or drawn on the spot for a movie whose
+ * placeholder hasn't been generated yet. Movies with a poster get one too,
+ * so front ends can fall back to it if the poster fails to load.
+ *
+ * @param id - Movie ID
+ * @returns image/svg+xml
+ */
+export const getPlaceholderPoster = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  try {
+    const result = await pool.query<{ title: string; release_year: number | null; svg: string | null }>(
+      `SELECT m.title, EXTRACT(YEAR FROM m.release_date)::int AS release_year, pp.svg
+       FROM movies m
+       LEFT JOIN placeholder_posters pp ON pp.movie_id = m.movie_id
+       WHERE m.movie_id = $1`,
+      [movieId]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const movie = result.rows[0];
+
+    res
+      .status(HttpStatus.OK)
+      .type('image/svg+xml')
+      .set('Cache-Control', 'public, max-age=86400')
+      .send(movie.svg ?? renderPlaceholderPoster(movie.title, movie.release_year));
+  } catch (error) {
+    console.error('Error fetching placeholder poster:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch placeholder poster')
+    );
+  }
+};
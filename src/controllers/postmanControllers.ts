@@ -0,0 +1,47 @@
+// server/src/controllers/postmanControllers.ts
+
+import { Request, Response, Router } from 'express';
+import { isReadOnlyMode } from '@middleware/readOnly';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { buildPostmanCollection } from '@utils/postmanCollection';
+import { getApiBaseUrl } from '@utils/publicUrls';
+import { sessionsEnabled, tokensEnabled } from '@utils/sessions';
+
+// ============================================================================
+// Postman Collection Controllers
+// ============================================================================
+
+/**
+ * GET /api/postman-collection
+ * Postman v2.1 collection of every route this server is running, with auth
+ * set up for the current configuration (API key, READ_ONLY, AUTH_MODE);
+ * import it into Postman or Hoppscotch
+ *
+ * Unlike testing/postman/postman.json it can't fall behind the routes. The
+ * collection saves the API key and access token from the responses that
+ * issue them, so running POST /api-key and a login first fills them in.
+ *
+ * @returns Collection JSON, served as a download
+ */
+export const getPostmanCollection = async (req: Request, res: Response): Promise<void> => {
+  try {
+    const routers = (req.app.locals.apiRouters ?? []) as Router[];
+    const collection = buildPostmanCollection(routers, '/api', {
+      baseUrl: `${getApiBaseUrl(req)}/api`,
+      readOnly: isReadOnlyMode(),
+      tokensEnabled: tokensEnabled(),
+      sessionsEnabled: sessionsEnabled()
+    });
+
+    res
+      .status(HttpStatus.OK)
+      .set('Content-Disposition', 'attachment; filename="tcss-460-api.postman_collection.json"')
+      .json(collection);
+  } catch (error) {
+    console.error('Error building Postman collection:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to build Postman collection')
+    );
+  }
+};
@@ -0,0 +1,624 @@
+// server/src/controllers/reviewControllers.ts
+
+import { Request, Response } from 'express';
+import { PoolClient } from 'pg';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { sanitizeText } from '@utils/sanitize';
+import { checkReviewText, reviewsAutoApprove } from '@utils/moderation';
+import { syncReviewActivity } from '@utils/activity';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import { UserRequest } from '@middleware/userAuth';
+import { Review, ReviewReaction } from '@models/reviewModel';
+import z from 'zod';
+import { paginationSchema } from './movieGetControllers';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const reviewBodySchema = z.object({
+  rating: z.number().int().min(1).max(10),
+  body: z.string().trim().max(5000).nullable().optional(),
+  contains_spoilers: z.boolean().default(false)
+});
+
+const movieReviewsQuerySchema = paginationSchema.extend({
+  sortBy: z.enum(['newest', 'helpful']).default('newest'),
+  show_spoilers: z.stringbool().default(false)
+});
+
+const reactionSchema = z.object({
+  reaction: z.enum(['helpful', 'unhelpful'])
+});
+
+const moderationQueueSchema = paginationSchema.extend({
+  status: z.enum(['pending', 'approved', 'rejected']).default('pending')
+});
+
+const rejectReviewSchema = z.object({
+  note: z.string().trim().min(1).max(500).optional()
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * Columns shown to everyone for an approved review
+ */
+const PUBLIC_REVIEW_COLUMNS = `
+  r.review_id, r.movie_id, r.user_id, u.username,
+  r.rating, r.body, r.contains_spoilers, r.helpful_count, r.unhelpful_count,
+  r.created_at, r.updated_at`;
+
+/**
+ * Columns returned after writing a review (no join to users)
+ */
+const RETURNED_REVIEW_COLUMNS = `
+  review_id, movie_id, user_id, rating, body, contains_spoilers,
+  helpful_count, unhelpful_count, status, flagged_terms,
+  moderation_note, moderated_at, created_at, updated_at`;
+
+/**
+ * ORDER BY for each sortBy value of a movie's reviews; helpful puts the
+ * best net rating first and breaks ties by total helpful votes
+ */
+const REVIEW_SORT_SQL = {
+  newest: 'r.created_at DESC, r.review_id DESC',
+  helpful: '(r.helpful_count - r.unhelpful_count) DESC, r.helpful_count DESC, r.created_at DESC, r.review_id DESC'
+} as const;
+
+/**
+ * Columns shown to the author and moderators
+ */
+const FULL_REVIEW_COLUMNS = `${PUBLIC_REVIEW_COLUMNS},
+  r.status, r.flagged_terms, r.moderation_note, r.moderated_at`;
+
+/**
+ * Parses the :reviewId route parameter, responding with 400 when invalid
+ */
+const parseReviewId = (req: Request, res: Response): number | null => {
+  const reviewId = parseInt(req.params.reviewId, 10);
+  if (isNaN(reviewId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Review ID must be a valid number', 'INVALID_ID')
+    );
+    return null;
+  }
+  return reviewId;
+};
+
+/**
+ * Withholds the text of spoiler reviews unless the reader asked for them
+ */
+const hideSpoilers = (reviews: Review[], showSpoilers: boolean): Review[] =>
+  reviews.map(review => review.contains_spoilers && !showSpoilers
+    ? { ...review, body: null, spoiler_hidden: true }
+    : review);
+
+/**
+ * Recounts a review's reactions into helpful_count/unhelpful_count. Call
+ * with the review row locked.
+ */
+const refreshReactionCounts = async (client: PoolClient, reviewId: number) => {
+  const result = await client.query<{ helpful_count: number; unhelpful_count: number }>(
+    `UPDATE reviews r
+     SET helpful_count = counts.helpful, unhelpful_count = counts.unhelpful
+     FROM (
+       SELECT COUNT(*) FILTER (WHERE reaction = 'helpful')::int AS helpful,
+              COUNT(*) FILTER (WHERE reaction = 'unhelpful')::int AS unhelpful
+       FROM review_reactions
+       WHERE review_id = $1
+     ) counts
+     WHERE r.review_id = $1
+     RETURNING r.helpful_count, r.unhelpful_count`,
+    [reviewId]
+  );
+  return result.rows[0];
+};
+
+const paginationMeta = (page: number, limit: number, total: number) => {
+  const pages = Math.max(1, Math.ceil(total / limit));
+  return { page, limit, total, pages, hasNextPage: page < pages, hasPreviousPage: page > 1 };
+};
+
+// ============================================================================
+// Review Controllers
+// ============================================================================
+
+/**
+ * GET /api/movies/:id/reviews
+ * Approved reviews of a movie
+ *
+ * Query Parameters:
+ * - sortBy: newest (default) | helpful
+ * - show_spoilers: Include the text of reviews marked as spoilers (default: false;
+ *   they are listed with body null and spoiler_hidden true)
+ * - page: Page number (default: 1)
+ * - limit: Results per page (default: 20, max: 100)
+ *
+ * @param id - Movie ID
+ * @returns Paginated reviews with the movie's average approved rating
+ */
+export const getMovieReviews = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  const validation = movieReviewsQuerySchema.safeParse(req.query);
+
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { page, limit, sortBy, show_spoilers } = validation.data;
+
+  try {
+    const [summary, reviews] = await Promise.all([
+      pool.query<{ exists: boolean; total: number; average_rating: number | null }>(
+        `SELECT
+           EXISTS (SELECT 1 FROM movies WHERE movie_id = $1) AS exists,
+           COUNT(*)::int AS total,
+           ROUND(AVG(rating), 1)::float8 AS average_rating
+         FROM reviews
+         WHERE movie_id = $1 AND status = 'approved'`,
+        [movieId]
+      ),
+      pool.query<Review>(
+        `SELECT ${PUBLIC_REVIEW_COLUMNS}
+         FROM reviews r
+         JOIN users u ON u.user_id = r.user_id
+         WHERE r.movie_id = $1 AND r.status = 'approved'
+         ORDER BY ${REVIEW_SORT_SQL[sortBy]}
+         LIMIT $2 OFFSET $3`,
+        [movieId, limit, (page - 1) * limit]
+      )
+    ]);
+
+    const { exists, total, average_rating } = summary.rows[0];
+    if (!exists) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      data: hideSpoilers(reviews.rows, show_spoilers),
+      meta: paginationMeta(page, limit, total),
+      average_rating
+    });
+  } catch (error) {
+    console.error('Error fetching reviews:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch reviews')
+    );
+  }
+};
+
+/**
+ * POST /api/movies/:id/reviews
+ * Write (or rewrite) the signed-in user's review of a movie
+ *
+ * Body: { rating: 1-10, body?: string, contains_spoilers?: boolean }
+ * Each user has one review per movie; posting again replaces it. The review
+ * is public once a moderator approves it, or straight away when
+ * REVIEWS_AUTO_APPROVE is on and no content filter matched it.
+ *
+ * @param id - Movie ID
+ * @returns The stored review with its moderation status
+ */
+export const submitReview = async (req: UserRequest, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  const validation = reviewBodySchema.safeParse(req.body ?? {});
+
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const body = sanitizeText(validation.data.body) || null;
+  const flaggedTerms = await checkReviewText(body);
+  const approved = reviewsAutoApprove() && flaggedTerms.length === 0;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const result = await client.query<Review & { created: boolean }>(
+      `WITH movie AS (SELECT movie_id FROM movies WHERE movie_id = $1)
+       INSERT INTO reviews (movie_id, user_id, rating, body, contains_spoilers, status, flagged_terms, moderated_at)
+       SELECT movie_id, $2::int, $3::smallint, $4::text, $7::boolean, $5::varchar, $6::text[],
+              CASE WHEN $5::varchar = 'approved' THEN NOW() END
+       FROM movie
+       ON CONFLICT (movie_id, user_id) DO UPDATE
+       SET rating = EXCLUDED.rating,
+           body = EXCLUDED.body,
+           contains_spoilers = EXCLUDED.contains_spoilers,
+           status = EXCLUDED.status,
+           flagged_terms = EXCLUDED.flagged_terms,
+           moderation_note = NULL,
+           moderated_by = NULL,
+           moderated_at = EXCLUDED.moderated_at,
+           updated_at = NOW()
+       RETURNING ${RETURNED_REVIEW_COLUMNS}, (xmax = 0) AS created`,
+      [
+        movieId,
+        req.user!.userId,
+        validation.data.rating,
+        body,
+        approved ? 'approved' : 'pending',
+        flaggedTerms,
+        validation.data.contains_spoilers
+      ]
+    );
+
+    if (result.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const { created, ...review } = result.rows[0];
+    // A rewrite waiting for moderation leaves followers' feeds until approved
+    await syncReviewActivity(client, review.review_id);
+
+    await client.query('COMMIT');
+
+    res.status(created ? HttpStatus.CREATED : HttpStatus.OK).json({
+      success: true,
+      message: approved ? 'Review published' : 'Review submitted for moderation',
+      data: review
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error submitting review:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to submit review')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * GET /api/reviews/mine
+ * The signed-in user's reviews in every moderation state, newest first
+ *
+ * @returns Reviews with status, flagged terms and any rejection note
+ */
+export const getMyReviews = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query<Review>(
+      `SELECT ${FULL_REVIEW_COLUMNS}, m.title AS movie_title
+       FROM reviews r
+       JOIN users u ON u.user_id = r.user_id
+       JOIN movies m ON m.movie_id = r.movie_id
+       WHERE r.user_id = $1
+       ORDER BY r.updated_at DESC`,
+      [req.user!.userId]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows,
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching own reviews:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch reviews')
+    );
+  }
+};
+
+/**
+ * DELETE /api/reviews/:reviewId
+ * Delete one of the signed-in user's reviews
+ *
+ * @param reviewId - Review ID
+ * @returns Confirmation
+ */
+export const deleteMyReview = async (req: UserRequest, res: Response): Promise<void> => {
+  const reviewId = parseReviewId(req, res);
+  if (reviewId === null) return;
+
+  try {
+    const result = await pool.query(
+      'DELETE FROM reviews WHERE review_id = $1 AND user_id = $2',
+      [reviewId, req.user!.userId]
+    );
+
+    if (result.rowCount === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Review with ID ${reviewId} not found`, 'REVIEW_NOT_FOUND', { id: reviewId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Review deleted'
+    });
+  } catch (error) {
+    console.error('Error deleting review:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to delete review')
+    );
+  }
+};
+
+/**
+ * Sets or (with null) removes the user's reaction and recounts the review's
+ * totals in one transaction
+ */
+const changeReaction = async (
+  req: UserRequest,
+  res: Response,
+  reviewId: number,
+  reaction: ReviewReaction | null
+): Promise<void> => {
+  const userId = req.user!.userId;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    // Lock the review so concurrent reactions recount in turn
+    const review = await client.query<{ user_id: number }>(
+      `SELECT user_id FROM reviews WHERE review_id = $1 AND status = 'approved' FOR UPDATE`,
+      [reviewId]
+    );
+
+    if (review.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Review with ID ${reviewId} not found`, 'REVIEW_NOT_FOUND', { id: reviewId })
+      );
+      return;
+    }
+    if (review.rows[0].user_id === userId) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.FORBIDDEN).json(
+        ApiError.forbidden("You can't react to your own review", 'OWN_REVIEW_REACTION')
+      );
+      return;
+    }
+
+    if (reaction) {
+      await client.query(
+        `INSERT INTO review_reactions (review_id, user_id, reaction)
+         VALUES ($1, $2, $3)
+         ON CONFLICT (review_id, user_id) DO UPDATE
+         SET reaction = EXCLUDED.reaction, created_at = NOW()`,
+        [reviewId, userId, reaction]
+      );
+    } else {
+      await client.query(
+        'DELETE FROM review_reactions WHERE review_id = $1 AND user_id = $2',
+        [reviewId, userId]
+      );
+    }
+
+    const counts = await refreshReactionCounts(client, reviewId);
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      data: { review_id: reviewId, reaction, ...counts }
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error saving review reaction:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to save reaction')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * PUT /api/reviews/:reviewId/reaction
+ * Mark someone else's review as helpful or unhelpful
+ *
+ * Body: { reaction: 'helpful' | 'unhelpful' }
+ * Replaces the user's earlier reaction to the review, if any.
+ *
+ * @param reviewId - Review ID (approved reviews only)
+ * @returns The review's updated reaction counts
+ */
+export const setReviewReaction = async (req: UserRequest, res: Response): Promise<void> => {
+  const reviewId = parseReviewId(req, res);
+  if (reviewId === null) return;
+
+  const validation = reactionSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  await changeReaction(req, res, reviewId, validation.data.reaction);
+};
+
+/**
+ * DELETE /api/reviews/:reviewId/reaction
+ * Take back the user's reaction to a review
+ *
+ * @param reviewId - Review ID
+ * @returns The review's updated reaction counts
+ */
+export const removeReviewReaction = async (req: UserRequest, res: Response): Promise<void> => {
+  const reviewId = parseReviewId(req, res);
+  if (reviewId === null) return;
+
+  await changeReaction(req, res, reviewId, null);
+};
+
+// ============================================================================
+// Admin: Moderation Queue
+// ============================================================================
+
+/**
+ * GET /api/admin/reviews
+ * Reviews in a moderation state; pending ones oldest first so none wait forever
+ *
+ * Query Parameters:
+ * - status: pending (default) | approved | rejected
+ * - page, limit: Pagination
+ *
+ * @returns Paginated reviews with their movie title and flagged terms
+ */
+export const getModerationQueue = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = moderationQueueSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { status, page, limit } = validation.data;
+  const order = status === 'pending' ? 'ASC' : 'DESC';
+
+  try {
+    const [count, reviews] = await Promise.all([
+      pool.query<{ total: number }>(
+        'SELECT COUNT(*)::int AS total FROM reviews WHERE status = $1',
+        [status]
+      ),
+      pool.query<Review>(
+        `SELECT ${FULL_REVIEW_COLUMNS}, m.title AS movie_title
+         FROM reviews r
+         JOIN users u ON u.user_id = r.user_id
+         JOIN movies m ON m.movie_id = r.movie_id
+         WHERE r.status = $1
+         ORDER BY r.updated_at ${order}, r.review_id ${order}
+         LIMIT $2 OFFSET $3`,
+        [status, limit, (page - 1) * limit]
+      )
+    ]);
+
+    res.status(HttpStatus.OK).json({
+      data: reviews.rows,
+      meta: paginationMeta(page, limit, count.rows[0].total)
+    });
+  } catch (error) {
+    console.error('Error fetching moderation queue:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch reviews')
+    );
+  }
+};
+
+/**
+ * Sets a review's moderation state and audits it
+ */
+const moderateReview = async (
+  req: ApiKeyRequest,
+  res: Response,
+  status: 'approved' | 'rejected',
+  note: string | null
+): Promise<void> => {
+  const reviewId = parseReviewId(req, res);
+  if (reviewId === null) return;
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const result = await client.query<Review>(
+      `UPDATE reviews
+       SET status = $2, moderation_note = $3, moderated_by = $4, moderated_at = NOW()
+       WHERE review_id = $1
+       RETURNING ${RETURNED_REVIEW_COLUMNS}`,
+      [reviewId, status, note, req.apiKey?.api_key_id ?? null]
+    );
+
+    if (result.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Review with ID ${reviewId} not found`, 'REVIEW_NOT_FOUND', { id: reviewId })
+      );
+      return;
+    }
+
+    await syncReviewActivity(client, reviewId);
+
+    await recordAudit(client, {
+      action: status === 'approved' ? 'review.approve' : 'review.reject',
+      entity_type: 'review',
+      entity_id: reviewId,
+      details: { movie_id: result.rows[0].movie_id, ...(note ? { note } : {}) },
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      data: result.rows[0]
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error moderating review:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to moderate review')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * POST /api/admin/reviews/:reviewId/approve
+ * Publish a review on its movie's page
+ *
+ * @param reviewId - Review ID
+ * @returns The updated review
+ */
+export const approveReview = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  await moderateReview(req, res, 'approved', null);
+};
+
+/**
+ * POST /api/admin/reviews/:reviewId/reject
+ * Keep a review off its movie's page
+ *
+ * Body: { note?: string } - Reason shown to the author
+ *
+ * @param reviewId - Review ID
+ * @returns The updated review
+ */
+export const rejectReview = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = rejectReviewSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  await moderateReview(req, res, 'rejected', sanitizeText(validation.data.note) ?? null);
+};
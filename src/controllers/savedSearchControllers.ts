@@ -0,0 +1,371 @@
+// server/src/controllers/savedSearchControllers.ts
+
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { KEYWORD_SEPARATOR } from '@utils/movieKeywords';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import z from 'zod';
+import { getAllMoviesSchema, paginationSchema, searchMovies, searchOptionsSchema } from './movieGetControllers';
+
+/**
+ * Upper bound on saved searches per API key
+ */
+const MAX_SAVED_SEARCHES = 50;
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+/**
+ * Same filters and sorting as GET /movies; pagination is chosen when running it
+ */
+const savedQuerySchema = getAllMoviesSchema.omit({ page: true, limit: true });
+
+const createSearchSchema = z.object({
+  name: z.string().trim().min(1).max(100),
+  query: savedQuerySchema
+});
+
+const updateSearchSchema = z.object({
+  name: z.string().trim().min(1).max(100).optional(),
+  query: savedQuerySchema.optional()
+}).refine(
+  (body) => body.name !== undefined || body.query !== undefined,
+  'Provide name and/or query'
+);
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+interface SavedSearchRow {
+  search_id: number;
+  name: string;
+  query: Record<string, unknown>;
+  created_at: Date;
+  updated_at: Date;
+}
+
+/**
+ * Adds a GET /movies link reproducing the search, for sharing outside the app.
+ * Lists (keywords) are joined the way GET /movies splits them.
+ */
+const withShareUrl = (row: SavedSearchRow) => {
+  const params = new URLSearchParams();
+  for (const [key, value] of Object.entries(row.query)) {
+    if (value !== undefined && value !== null) {
+      params.set(key, Array.isArray(value) ? value.join(KEYWORD_SEPARATOR) : String(value));
+    }
+  }
+
+  const queryString = params.toString();
+  return {
+    ...row,
+    share_url: `/api/movies${queryString ? `?${queryString}` : ''}`
+  };
+};
+
+/**
+ * Parses the :id route parameter, responding with 400 when invalid
+ */
+const parseSearchId = (req: ApiKeyRequest, res: Response): number | null => {
+  const searchId = parseInt(req.params.id, 10);
+  if (isNaN(searchId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Search ID must be a valid number', 'INVALID_ID')
+    );
+    return null;
+  }
+  return searchId;
+};
+
+/**
+ * Unique violation on (api_key_id, name)
+ */
+const isDuplicateName = (error: unknown): boolean =>
+  typeof error === 'object' && error !== null && (error as { code?: string }).code === '23505';
+
+// ============================================================================
+// Saved Search Controllers
+// ============================================================================
+
+/**
+ * GET /api/me/searches
+ * List the caller's saved searches
+ *
+ * @returns Saved searches, most recently updated first
+ */
+export const getSavedSearches = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query<SavedSearchRow>(
+      `SELECT search_id, name, query, created_at, updated_at
+       FROM saved_searches
+       WHERE api_key_id = $1
+       ORDER BY updated_at DESC`,
+      [req.apiKey!.api_key_id]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows.map(withShareUrl),
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching saved searches:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch saved searches')
+    );
+  }
+};
+
+/**
+ * POST /api/me/searches
+ * Save a named filter/sort combination
+ *
+ * Body: { name, query } where query takes the same fields as GET /movies
+ *
+ * @example
+ * { "name": "90s action", "query": { "genre": "Action", "startDate": "1990-01-01", "endDate": "1999-12-31", "sortBy": "revenue", "order": "desc" } }
+ *
+ * @returns The stored search
+ */
+export const createSavedSearch = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = createSearchSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { name, query } = validation.data;
+  const apiKeyId = req.apiKey!.api_key_id;
+
+  try {
+    const countResult = await pool.query<{ total: number }>(
+      'SELECT COUNT(*)::int AS total FROM saved_searches WHERE api_key_id = $1',
+      [apiKeyId]
+    );
+    if (countResult.rows[0].total >= MAX_SAVED_SEARCHES) {
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest(`A key can save at most ${MAX_SAVED_SEARCHES} searches`)
+      );
+      return;
+    }
+
+    const result = await pool.query<SavedSearchRow>(
+      `INSERT INTO saved_searches (api_key_id, name, query)
+       VALUES ($1, $2, $3)
+       RETURNING search_id, name, query, created_at, updated_at`,
+      [apiKeyId, name, JSON.stringify(query)]
+    );
+
+    res.status(HttpStatus.CREATED).json(withShareUrl(result.rows[0]));
+  } catch (error) {
+    if (isDuplicateName(error)) {
+      res.status(HttpStatus.CONFLICT).json(
+        ApiError.conflict(`A saved search named "${name}" already exists`, 'SAVED_SEARCH_EXISTS', { name })
+      );
+      return;
+    }
+    console.error('Error saving search:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to save search')
+    );
+  }
+};
+
+/**
+ * GET /api/me/searches/:id
+ * Get one saved search
+ *
+ * @param id - Saved search ID
+ * @returns The saved search with its share_url
+ */
+export const getSavedSearch = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const searchId = parseSearchId(req, res);
+  if (searchId === null) return;
+
+  try {
+    const result = await pool.query<SavedSearchRow>(
+      `SELECT search_id, name, query, created_at, updated_at
+       FROM saved_searches
+       WHERE search_id = $1 AND api_key_id = $2`,
+      [searchId, req.apiKey!.api_key_id]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Saved search with ID ${searchId} not found`, 'SAVED_SEARCH_NOT_FOUND', { id: searchId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json(withShareUrl(result.rows[0]));
+  } catch (error) {
+    console.error('Error fetching saved search:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch saved search')
+    );
+  }
+};
+
+/**
+ * PATCH /api/me/searches/:id
+ * Rename a saved search and/or replace its query
+ *
+ * Body: { name?, query? }
+ *
+ * @param id - Saved search ID
+ * @returns The updated search
+ */
+export const updateSavedSearch = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const searchId = parseSearchId(req, res);
+  if (searchId === null) return;
+
+  const validation = updateSearchSchema.safeParse(req.body ?? {});
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { name, query } = validation.data;
+
+  try {
+    const result = await pool.query<SavedSearchRow>(
+      `UPDATE saved_searches
+       SET name = COALESCE($3, name),
+           query = COALESCE($4::jsonb, query),
+           updated_at = NOW()
+       WHERE search_id = $1 AND api_key_id = $2
+       RETURNING search_id, name, query, created_at, updated_at`,
+      [searchId, req.apiKey!.api_key_id, name ?? null, query ? JSON.stringify(query) : null]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Saved search with ID ${searchId} not found`, 'SAVED_SEARCH_NOT_FOUND', { id: searchId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json(withShareUrl(result.rows[0]));
+  } catch (error) {
+    if (isDuplicateName(error)) {
+      res.status(HttpStatus.CONFLICT).json(
+        ApiError.conflict(`A saved search named "${name}" already exists`, 'SAVED_SEARCH_EXISTS', { name })
+      );
+      return;
+    }
+    console.error('Error updating saved search:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update saved search')
+    );
+  }
+};
+
+/**
+ * DELETE /api/me/searches/:id
+ * Delete a saved search
+ *
+ * @param id - Saved search ID
+ */
+export const deleteSavedSearch = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const searchId = parseSearchId(req, res);
+  if (searchId === null) return;
+
+  try {
+    const result = await pool.query(
+      'DELETE FROM saved_searches WHERE search_id = $1 AND api_key_id = $2',
+      [searchId, req.apiKey!.api_key_id]
+    );
+
+    if (result.rowCount === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Saved search with ID ${searchId} not found`, 'SAVED_SEARCH_NOT_FOUND', { id: searchId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: `Saved search ${searchId} deleted`
+    });
+  } catch (error) {
+    console.error('Error deleting saved search:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to delete saved search')
+    );
+  }
+};
+
+/**
+ * GET /api/me/searches/:id/results
+ * Run a saved search
+ *
+ * Query Parameters:
+ * - page: Page number (default: 1)
+ * - limit: Results per page (default: 20, max: 100)
+ * - facets: Include genre/decade/rating counts (default: false)
+ * - explain: Include each result's relevance score components (default: false)
+ *
+ * @param id - Saved search ID
+ * @returns Same paginated shape as GET /movies; an empty page rather than 404 when nothing matches
+ */
+export const getSavedSearchResults = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const searchId = parseSearchId(req, res);
+  if (searchId === null) return;
+
+  const pagination = paginationSchema.safeParse(req.query);
+  const optionsValidation = searchOptionsSchema.safeParse(req.query);
+  if (!pagination.success || !optionsValidation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest([
+        ...(pagination.error?.issues ?? []),
+        ...(optionsValidation.error?.issues ?? [])
+      ])
+    );
+    return;
+  }
+
+  try {
+    const result = await pool.query<SavedSearchRow>(
+      'SELECT search_id, name, query FROM saved_searches WHERE search_id = $1 AND api_key_id = $2',
+      [searchId, req.apiKey!.api_key_id]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Saved search with ID ${searchId} not found`, 'SAVED_SEARCH_NOT_FOUND', { id: searchId })
+      );
+      return;
+    }
+
+    // Re-validate in case the movie filters changed since the search was saved
+    const filters = getAllMoviesSchema.safeParse({ ...result.rows[0].query, ...pagination.data });
+    if (!filters.success) {
+      res.status(HttpStatus.BAD_REQUEST).json(
+        ApiError.badRequest({
+          message: 'Saved query is no longer valid; update it with PATCH',
+          issues: filters.error.issues
+        })
+      );
+      return;
+    }
+
+    const response = await searchMovies(filters.data, optionsValidation.data);
+
+    res.status(HttpStatus.OK).json({
+      search: { search_id: searchId, name: result.rows[0].name },
+      ...response
+    });
+  } catch (error) {
+    console.error('Error running saved search:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to run saved search')
+    );
+  }
+};
@@ -0,0 +1,163 @@
+// server/src/controllers/searchRankingControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { getRankingSettings, isRankingSetting } from '@utils/searchRanking';
+import { invalidateCache } from '@utils/cacheBus';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const weightSchema = z.number().min(0).max(10);
+
+const updateRankingSchema = z.object({
+  title_weight: weightSchema.optional(),
+  popularity_weight: weightSchema.optional(),
+  recency_weight: weightSchema.optional(),
+  recency_half_life_years: z.number().positive().max(100).optional()
+}).strict().refine(
+  values => Object.keys(values).length > 0,
+  'body must change at least one setting'
+);
+
+// ============================================================================
+// Search Ranking Controllers
+// ============================================================================
+
+/**
+ * GET /api/admin/search-ranking
+ * Weights used for sortBy=relevance and where each value comes from
+ *
+ * source is 'env' (RANKING_<SETTING> variable, can't be changed at runtime),
+ * 'database' (changed through this API) or 'default'.
+ *
+ * @returns List of settings
+ */
+export const getSearchRanking = async (req: Request, res: Response): Promise<void> => {
+  try {
+    res.status(HttpStatus.OK).json({ data: await getRankingSettings() });
+  } catch (error) {
+    console.error('Error fetching search ranking settings:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch search ranking settings')
+    );
+  }
+};
+
+/**
+ * PATCH /api/admin/search-ranking
+ * Tune relevance ranking without redeploying
+ *
+ * Body: any of { title_weight, popularity_weight, recency_weight, recency_half_life_years }
+ * Takes effect immediately on this instance and within 30 seconds on others.
+ * An environment variable for a setting still takes precedence.
+ *
+ * @returns Every setting's resulting state
+ */
+export const updateSearchRanking = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const validation = updateRankingSchema.safeParse(req.body ?? {});
+
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const changes = Object.entries(validation.data).filter(([, value]) => value !== undefined);
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    for (const [setting, value] of changes) {
+      await client.query(
+        `INSERT INTO search_ranking_settings (setting_key, value, updated_by, updated_at)
+         VALUES ($1, $2, $3, NOW())
+         ON CONFLICT (setting_key) DO UPDATE
+         SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, updated_at = NOW()`,
+        [setting, value, req.apiKey?.api_key_id ?? null]
+      );
+    }
+
+    await recordAudit(client, {
+      action: 'search_ranking.set',
+      entity_type: 'search_ranking',
+      entity_id: null,
+      details: Object.fromEntries(changes),
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    await client.query('COMMIT');
+    invalidateCache('search_ranking');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      data: await getRankingSettings()
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error updating search ranking settings:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update search ranking settings')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * DELETE /api/admin/search-ranking/:setting
+ * Drop a runtime value so the setting falls back to its default
+ *
+ * @param setting - Setting name
+ * @returns Every setting's resulting state
+ */
+export const resetSearchRankingSetting = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const { setting } = req.params;
+
+  if (!isRankingSetting(setting)) {
+    res.status(HttpStatus.NOT_FOUND).json(
+      ApiError.notFound(`Unknown search ranking setting "${setting}"`, 'UNKNOWN_RANKING_SETTING', { setting })
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    await client.query('DELETE FROM search_ranking_settings WHERE setting_key = $1', [setting]);
+
+    await recordAudit(client, {
+      action: 'search_ranking.reset',
+      entity_type: 'search_ranking',
+      entity_id: null,
+      details: { setting },
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    await client.query('COMMIT');
+    invalidateCache('search_ranking');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      data: await getRankingSettings()
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error resetting search ranking setting:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to reset search ranking setting')
+    );
+  } finally {
+    client.release();
+  }
+};
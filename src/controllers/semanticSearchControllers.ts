@@ -0,0 +1,234 @@
+// server/src/controllers/semanticSearchControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { nonAdultCondition, shouldHideAdultContent } from '@utils/contentFilter';
+import { embedText, getEmbeddingConfig, toVectorLiteral } from '@utils/embeddings';
+import { findSimilarPlots } from '@utils/plotIndex';
+import { contentFilterSchema } from './movieGetControllers';
+import z from 'zod';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const semanticSearchSchema = contentFilterSchema.extend({
+  q: z.string().trim().min(1).max(500),
+  limit: z.coerce.number().int().min(1).max(100).default(20)
+});
+
+const similarByPlotSchema = contentFilterSchema.extend({
+  limit: z.coerce.number().int().min(1).max(50).default(10)
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * Extra TF-IDF candidates fetched so that hiding adult titles still leaves
+ * enough results
+ */
+const CANDIDATE_MARGIN = 50;
+
+interface SimilarMovie {
+  movie_id: number;
+  public_id: string | null;
+  title: string;
+  release_date: string | null;
+  overview: string;
+  poster_url: string | null;
+  similarity: number;
+}
+
+/**
+ * Nearest overviews by embedding, or null when the movie has no vector for
+ * the current model
+ */
+const findSimilarByEmbedding = async (
+  movieId: number,
+  model: string,
+  limit: number,
+  hideAdult: boolean
+): Promise<SimilarMovie[] | null> => {
+  const source = await pool.query<{ embedding: string }>(
+    'SELECT embedding::text FROM movie_embeddings WHERE movie_id = $1 AND model = $2',
+    [movieId, model]
+  );
+  if (source.rows.length === 0) {
+    return null;
+  }
+
+  const result = await pool.query<SimilarMovie>(
+    `SELECT m.movie_id, m.public_id, m.title, m.release_date, m.overview, m.poster_url,
+            ROUND((1 - (e.embedding <=> $1::vector))::numeric, 4)::float AS similarity
+     FROM movie_embeddings e
+     JOIN movies m ON m.movie_id = e.movie_id
+     WHERE e.model = $2 AND e.movie_id <> $3
+       ${hideAdult ? `AND ${nonAdultCondition('m')}` : ''}
+     ORDER BY e.embedding <=> $1::vector
+     LIMIT $4`,
+    [source.rows[0].embedding, model, movieId, limit]
+  );
+  return result.rows;
+};
+
+/**
+ * Nearest overviews by TF-IDF cosine similarity
+ */
+const findSimilarByTerms = async (
+  movieId: number,
+  limit: number,
+  hideAdult: boolean
+): Promise<SimilarMovie[]> => {
+  const candidates = await findSimilarPlots(movieId, limit + (hideAdult ? CANDIDATE_MARGIN : 0));
+  if (candidates.length === 0) {
+    return [];
+  }
+
+  const result = await pool.query<Omit<SimilarMovie, 'similarity'>>(
+    `SELECT m.movie_id, m.public_id, m.title, m.release_date, m.overview, m.poster_url
+     FROM movies m
+     WHERE m.movie_id = ANY($1::int[])
+       ${hideAdult ? `AND ${nonAdultCondition('m')}` : ''}`,
+    [candidates.map(candidate => candidate.movieId)]
+  );
+  const movies = new Map(result.rows.map(movie => [movie.movie_id, movie]));
+
+  return candidates
+    .filter(candidate => movies.has(candidate.movieId))
+    .slice(0, limit)
+    .map(candidate => ({
+      ...movies.get(candidate.movieId)!,
+      similarity: Math.round(candidate.similarity * 10000) / 10000
+    }));
+};
+
+// ============================================================================
+// Semantic Search Controllers
+// ============================================================================
+
+/**
+ * GET /api/search/semantic
+ * Movies whose overview is closest in meaning to a free-text description
+ *
+ * Query Parameters:
+ * - q: What the movie is about, e.g. "heist gone wrong in space"
+ * - limit: Results to return (default 20, max 100)
+ * - include_adult: Include adult titles (if the server allows it)
+ *
+ * Only movies whose overview has been embedded (nightly embeddings job or
+ * npm run embed-overviews) can match. Answers 501 when EMBEDDINGS is unset.
+ *
+ * @returns Movies ordered by similarity (cosine, 1 = identical)
+ */
+export const semanticSearch = async (req: Request, res: Response): Promise<void> => {
+  const validation = semanticSearchSchema.safeParse(req.query);
+
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  if (!getEmbeddingConfig()) {
+    res.status(HttpStatus.NOT_IMPLEMENTED).json(
+      ApiError.createResponse(HttpStatus.NOT_IMPLEMENTED, 'Semantic search is not enabled', 'SEMANTIC_SEARCH_DISABLED')
+    );
+    return;
+  }
+
+  const { q, limit, include_adult } = validation.data;
+
+  try {
+    const { vector, model } = await embedText(q);
+
+    const result = await pool.query<SimilarMovie>(
+      `SELECT m.movie_id, m.public_id, m.title, m.release_date, m.overview, m.poster_url,
+              ROUND((1 - (e.embedding <=> $1::vector))::numeric, 4)::float AS similarity
+       FROM movie_embeddings e
+       JOIN movies m ON m.movie_id = e.movie_id
+       WHERE e.model = $2
+         ${shouldHideAdultContent(include_adult) ? `AND ${nonAdultCondition('m')}` : ''}
+       ORDER BY e.embedding <=> $1::vector
+       LIMIT $3`,
+      [toVectorLiteral(vector), model, limit]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows,
+      meta: { query: q, model }
+    });
+  } catch (error) {
+    console.error('Error running semantic search:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to run semantic search')
+    );
+  }
+};
+
+/**
+ * GET /api/movies/:id/similar-by-plot
+ * Movies with the most similar overview
+ *
+ * Query Parameters:
+ * - limit: Results to return (default 10, max 50)
+ * - include_adult: Include adult titles (if the server allows it)
+ *
+ * Compares overview embeddings when EMBEDDINGS is set and the movie has been
+ * embedded, and TF-IDF word vectors otherwise; meta.method says which.
+ * Only the plot counts: genres, cast and crew are ignored.
+ *
+ * @param id - Movie ID
+ * @returns Movies ordered by similarity (cosine, 1 = identical)
+ */
+export const getSimilarByPlot = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const validation = similarByPlotSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const { limit, include_adult } = validation.data;
+  const hideAdult = shouldHideAdultContent(include_adult);
+
+  try {
+    const movie = await pool.query('SELECT 1 FROM movies WHERE movie_id = $1', [movieId]);
+    if (movie.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const config = getEmbeddingConfig();
+    const byEmbedding = config
+      ? await findSimilarByEmbedding(movieId, config.model, limit, hideAdult)
+      : null;
+
+    res.status(HttpStatus.OK).json({
+      data: byEmbedding ?? await findSimilarByTerms(movieId, limit, hideAdult),
+      meta: byEmbedding
+        ? { method: 'embeddings', model: config!.model }
+        : { method: 'tfidf' }
+    });
+  } catch (error) {
+    console.error('Error finding movies with a similar plot:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to find movies with a similar plot')
+    );
+  }
+};
@@ -0,0 +1,240 @@
+// server/src/controllers/seoControllers.ts
+
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { nonAdultCondition } from '@utils/contentFilter';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { getApiBaseUrl, getMovieUrl } from '@utils/publicUrls';
+import { escapeXml } from '@utils/sanitize';
+import { CAST_ORDER_SQL } from './movieGetControllers';
+
+/**
+ * URLs per sitemap file (the protocol allows 50,000)
+ */
+const SITEMAP_PAGE_SIZE = 45000;
+
+/**
+ * Actors listed in a movie's structured data
+ */
+const JSONLD_MAX_ACTORS = 10;
+
+// ============================================================================
+// Sitemap Controllers
+// ============================================================================
+
+/**
+ * GET /api/sitemap.xml
+ * Sitemap index pointing at one sitemap per SITEMAP_PAGE_SIZE movies;
+ * needs no API key so crawlers can read it
+ *
+ * Movie URLs come from SITE_MOVIE_URL, so set it to the front end's movie
+ * page before submitting the sitemap. Adult titles are left out.
+ *
+ * @returns application/xml sitemap index
+ */
+export const getSitemapIndex = async (req: Request, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query<{ total: number; last_updated: Date | null }>(
+      `SELECT COUNT(*)::int AS total, MAX(m.updated_at) AS last_updated
+       FROM movies m
+       WHERE ${nonAdultCondition('m')}`
+    );
+    const { total, last_updated } = result.rows[0];
+    const pages = Math.max(1, Math.ceil(total / SITEMAP_PAGE_SIZE));
+    const baseUrl = getApiBaseUrl(req);
+
+    const sitemaps = Array.from({ length: pages }, (_, index) => [
+      '  <sitemap>',
+      `    <loc>${escapeXml(`${baseUrl}/api/sitemaps/movies-${index + 1}.xml`)}</loc>`,
+      ...(last_updated ? [`    <lastmod>${new Date(last_updated).toISOString()}</lastmod>`] : []),
+      '  </sitemap>'
+    ].join('\n'));
+
+    res
+      .status(HttpStatus.OK)
+      .type('application/xml; charset=utf-8')
+      .send([
+        '<?xml version="1.0" encoding="UTF-8"?>',
+        '<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">',
+        ...sitemaps,
+        '</sitemapindex>',
+        ''
+      ].join('\n'));
+  } catch (error) {
+    console.error('Error building sitemap index:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to build sitemap')
+    );
+  }
+};
+
+/**
+ * GET /api/sitemaps/movies-:page.xml
+ * One page of movie detail URLs, oldest movie first so pages stay stable
+ *
+ * @param page - Page number from the sitemap index (1-based)
+ * @returns application/xml urlset
+ */
+export const getMovieSitemap = async (req: Request, res: Response): Promise<void> => {
+  const page = parseInt(req.params.page, 10);
+  if (isNaN(page) || page < 1) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Page must be a positive number', 'INVALID_PAGE')
+    );
+    return;
+  }
+
+  try {
+    const result = await pool.query<{ movie_id: number; public_id: string | null; updated_at: Date }>(
+      `SELECT m.movie_id, m.public_id, m.updated_at
+       FROM movies m
+       WHERE ${nonAdultCondition('m')}
+       ORDER BY m.movie_id
+       LIMIT $1 OFFSET $2`,
+      [SITEMAP_PAGE_SIZE, (page - 1) * SITEMAP_PAGE_SIZE]
+    );
+
+    if (result.rows.length === 0 && page > 1) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Sitemap not found', 'SITEMAP_NOT_FOUND')
+      );
+      return;
+    }
+
+    const urls = result.rows.map(movie => [
+      '  <url>',
+      `    <loc>${escapeXml(getMovieUrl(req, movie))}</loc>`,
+      `    <lastmod>${new Date(movie.updated_at).toISOString()}</lastmod>`,
+      '  </url>'
+    ].join('\n'));
+
+    res
+      .status(HttpStatus.OK)
+      .type('application/xml; charset=utf-8')
+      .send([
+        '<?xml version="1.0" encoding="UTF-8"?>',
+        '<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">',
+        ...urls,
+        '</urlset>',
+        ''
+      ].join('\n'));
+  } catch (error) {
+    console.error('Error building movie sitemap:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to build sitemap')
+    );
+  }
+};
+
+// ============================================================================
+// Structured Data Controllers
+// ============================================================================
+
+/**
+ * GET /api/movies/:id/jsonld
+ * schema.org Movie description of a movie, for the front end to embed in a
+ * <script type="application/ld+json"> tag
+ *
+ * @param id - Movie ID
+ * @returns application/ld+json
+ */
+export const getMovieJsonLd = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  try {
+    const result = await pool.query(
+      `SELECT
+         m.movie_id, m.public_id, m.title, m.original_title, m.release_date,
+         m.runtime_minutes, m.overview, m.mpa_rating, m.poster_url, m.trailer_url,
+         m.vote_count, m.vote_average::float8,
+         (SELECT ARRAY_AGG(g.genre_name ORDER BY g.genre_name)
+          FROM movie_genres mg JOIN genres g ON g.genre_id = mg.genre_id
+          WHERE mg.movie_id = m.movie_id) AS genres,
+         (SELECT ARRAY_AGG(d.director_name ORDER BY d.director_name)
+          FROM movie_directors md JOIN directors d ON d.director_id = md.director_id
+          WHERE md.movie_id = m.movie_id) AS directors,
+         (SELECT ARRAY_AGG(s.studio_name ORDER BY s.studio_name)
+          FROM movie_studios ms JOIN studios s ON s.studio_id = ms.studio_id
+          WHERE ms.movie_id = m.movie_id) AS studios,
+         (SELECT ARRAY_AGG(cast_list.actor_name)
+          FROM (
+            SELECT a.actor_name
+            FROM movie_actors ma JOIN actors a ON a.actor_id = ma.actor_id
+            WHERE ma.movie_id = m.movie_id
+            ORDER BY ${CAST_ORDER_SQL}
+            LIMIT ${JSONLD_MAX_ACTORS}
+          ) cast_list) AS actors
+       FROM movies m
+       WHERE m.movie_id = $1`,
+      [movieId]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const movie = result.rows[0];
+    const person = (name: string) => ({ '@type': 'Person', name });
+
+    const jsonLd = {
+      '@context': 'https://schema.org',
+      '@type': 'Movie',
+      '@id': getMovieUrl(req, movie),
+      url: getMovieUrl(req, movie),
+      name: movie.title,
+      ...(movie.original_title && movie.original_title !== movie.title ? { alternateName: movie.original_title } : {}),
+      ...(movie.overview ? { description: movie.overview } : {}),
+      ...(movie.poster_url ? { image: movie.poster_url } : {}),
+      ...(movie.release_date ? { datePublished: movie.release_date } : {}),
+      ...(movie.runtime_minutes ? { duration: `PT${movie.runtime_minutes}M` } : {}),
+      ...(movie.mpa_rating ? { contentRating: movie.mpa_rating } : {}),
+      ...(movie.genres ? { genre: movie.genres } : {}),
+      ...(movie.directors ? { director: movie.directors.map(person) } : {}),
+      ...(movie.actors ? { actor: movie.actors.map(person) } : {}),
+      ...(movie.studios
+        ? { productionCompany: movie.studios.map((name: string) => ({ '@type': 'Organization', name })) }
+        : {}),
+      ...(movie.vote_count > 0 && movie.vote_average !== null
+        ? {
+            aggregateRating: {
+              '@type': 'AggregateRating',
+              ratingValue: movie.vote_average,
+              ratingCount: movie.vote_count,
+              bestRating: 10,
+              worstRating: 0
+            }
+          }
+        : {}),
+      ...(movie.trailer_url
+        ? {
+            trailer: {
+              '@type': 'VideoObject',
+              name: `${movie.title} trailer`,
+              url: movie.trailer_url,
+              ...(movie.poster_url ? { thumbnailUrl: movie.poster_url } : {})
+            }
+          }
+        : {})
+    };
+
+    res
+      .status(HttpStatus.OK)
+      .type('application/ld+json')
+      .send(JSON.stringify(jsonLd));
+  } catch (error) {
+    console.error('Error building movie JSON-LD:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to build structured data')
+    );
+  }
+};
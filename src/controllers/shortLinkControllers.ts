@@ -0,0 +1,205 @@
+// server/src/controllers/shortLinkControllers.ts
+
+import crypto from 'crypto';
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { getApiBaseUrl, getMovieUrl, getShortLinkUrl } from '@utils/publicUrls';
+import { encodeQrCode, renderQrPng } from '@utils/qrCode';
+import z from 'zod';
+
+/**
+ * Short codes leave out look-alike characters (0/O, 1/l/I) since people
+ * may type them from a poster
+ */
+const CODE_ALPHABET = '23456789abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ';
+const CODE_LENGTH = 7;
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const qrCodeQuerySchema = z.object({
+  size: z.coerce.number().int().min(1).max(32).default(8)
+});
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+interface ShortLinkRow {
+  code: string;
+  movie_id: number;
+  public_id: string | null;
+  visits: number;
+  created_at: Date;
+}
+
+const createCode = (): string =>
+  Array.from({ length: CODE_LENGTH }, () => CODE_ALPHABET[crypto.randomInt(CODE_ALPHABET.length)]).join('');
+
+/**
+ * A movie's short link, created on first use
+ *
+ * @returns null when the movie doesn't exist
+ */
+const getOrCreateShortLink = async (movieId: number): Promise<ShortLinkRow | null> => {
+  const select = () => pool.query<ShortLinkRow>(
+    `SELECT sl.code, m.movie_id, m.public_id, sl.visits, sl.created_at
+     FROM movies m
+     LEFT JOIN short_links sl ON sl.movie_id = m.movie_id AND NOT sl.merged
+     WHERE m.movie_id = $1`,
+    [movieId]
+  );
+
+  for (let attempt = 0; attempt < 5; attempt++) {
+    const existing = await select();
+    if (existing.rows.length === 0) return null;
+    if (existing.rows[0].code) return existing.rows[0];
+
+    // Nothing is inserted if another request just created this movie's
+    // link, or the code is taken; either way the next pass sorts it out
+    await pool.query(
+      'INSERT INTO short_links (code, movie_id) VALUES ($1, $2) ON CONFLICT DO NOTHING',
+      [createCode(), movieId]
+    );
+  }
+
+  throw new Error(`Could not allocate a short link for movie ${movieId}`);
+};
+
+// ============================================================================
+// Short Link Controllers
+// ============================================================================
+
+/**
+ * GET /api/m/:code
+ * Follow a short link to the movie's page (SITE_MOVIE_URL, or the API's
+ * movie detail); needs no API key since it's opened from scanned posters
+ *
+ * @param code - Short code from GET /movies/:id/short-link
+ * @returns 302 redirect
+ */
+export const followShortLink = async (req: Request, res: Response): Promise<void> => {
+  const { code } = req.params;
+
+  try {
+    const result = await pool.query<{ movie_id: number; public_id: string | null }>(
+      `UPDATE short_links sl
+       SET visits = sl.visits + 1, last_visited_at = NOW()
+       FROM movies m
+       WHERE sl.code = $1 AND m.movie_id = sl.movie_id
+       RETURNING m.movie_id, m.public_id`,
+      [code]
+    );
+
+    if (result.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Short link not found', 'SHORT_LINK_NOT_FOUND')
+      );
+      return;
+    }
+
+    res.redirect(getMovieUrl(req, result.rows[0]));
+  } catch (error) {
+    console.error('Error following short link:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to follow short link')
+    );
+  }
+};
+
+/**
+ * GET /api/movies/:id/short-link
+ * A movie's short link, created the first time it's asked for
+ *
+ * @param id - Movie ID
+ * @returns code, short_url, the movie_url it redirects to, qr_url and visits
+ */
+export const getMovieShortLink = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  try {
+    const link = await getOrCreateShortLink(movieId);
+    if (!link) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    res.status(HttpStatus.OK).json({
+      code: link.code,
+      short_url: getShortLinkUrl(req, link.code),
+      movie_url: getMovieUrl(req, link),
+      qr_url: `${getApiBaseUrl(req)}/api/movies/${encodeURIComponent(link.public_id ?? String(link.movie_id))}/qr.png`,
+      visits: link.visits,
+      created_at: link.created_at
+    });
+  } catch (error) {
+    console.error('Error fetching short link:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch short link')
+    );
+  }
+};
+
+/**
+ * GET /api/movies/:id/qr.png
+ * QR code of the movie's short link, for printing on posters
+ *
+ * Query Parameters:
+ * - size: Pixels per QR module (default: 8, max: 32); the image is
+ *   (modules + 8) * size pixels square, including the quiet zone
+ *
+ * @param id - Movie ID
+ * @returns image/png
+ */
+export const getMovieQrCode = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const validation = qrCodeQuerySchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  try {
+    const link = await getOrCreateShortLink(movieId);
+    if (!link) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const png = renderQrPng(encodeQrCode(getShortLinkUrl(req, link.code)), validation.data.size);
+
+    res
+      .status(HttpStatus.OK)
+      .type('image/png')
+      .set('Cache-Control', 'public, max-age=86400')
+      .set('Content-Disposition', `inline; filename="movie-${link.public_id ?? link.movie_id}-qr.png"`)
+      .send(png);
+  } catch (error) {
+    console.error('Error generating QR code:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to generate QR code')
+    );
+  }
+};
@@ -4,6 +4,8 @@ import { Request, Response } from 'express';
 import pool from '@utils/database';
 import { ApiError } from '@utils/httpError';
 import { HttpStatus } from '@utils/httpStatus';
+import { assetUrlSql } from '@utils/assets';
+import { COUNTRY_ALIASES, getCountryCentroid } from '@utils/countryCentroids';
 import { Studio, StudioWithCount, StudioListResponse } from '@models';
 import z from 'zod';
 
@@ -22,6 +24,10 @@ const searchSchema = paginationSchema.extend({
   name: z.string().min(1).optional()
 });
 
+const countryStatsSchema = z.object({
+  format: z.enum(['json', 'geojson']).optional()
+});
+
 // ============================================================================
 // Helper Functions
 // ============================================================================
@@ -114,6 +120,7 @@ export const getAllStudios = async (req: Request, res: Response): Promise<void>
         s.studio_id,
         s.studio_name,
         s.logo_url,
+        ${assetUrlSql('s.logo_url')} AS logo_asset_url,
         s.country,
         COUNT(ms.movie_id)::int AS movie_count
       FROM studios s
@@ -169,7 +176,7 @@ export const getStudioById = async (req: Request, res: Response): Promise<void>
 
   if (isNaN(studioId)) {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Studio ID must be a valid number')
+      ApiError.badRequest('Studio ID must be a valid number', 'INVALID_ID')
     );
     return;
   }
@@ -180,6 +187,7 @@ export const getStudioById = async (req: Request, res: Response): Promise<void>
         s.studio_id,
         s.studio_name,
         s.logo_url,
+        ${assetUrlSql('s.logo_url')} AS logo_asset_url,
         s.country,
         COUNT(ms.movie_id)::int AS movie_count
       FROM studios s
@@ -192,7 +200,7 @@ export const getStudioById = async (req: Request, res: Response): Promise<void>
 
     if (result.rows.length === 0) {
       res.status(HttpStatus.NOT_FOUND).json(
-        ApiError.notFound(`Studio with ID ${studioId} not found`)
+        ApiError.notFound(`Studio with ID ${studioId} not found`, 'STUDIO_NOT_FOUND', { id: studioId })
       );
       return;
     }
@@ -220,7 +228,7 @@ export const searchStudios = async (req: Request, res: Response): Promise<void>
 
   if (!name || typeof name !== 'string') {
     res.status(HttpStatus.BAD_REQUEST).json(
-      ApiError.badRequest('Name query parameter is required')
+      ApiError.badRequest('Name query parameter is required', 'NAME_REQUIRED')
     );
     return;
   }
@@ -231,6 +239,7 @@ export const searchStudios = async (req: Request, res: Response): Promise<void>
         s.studio_id,
         s.studio_name,
         s.logo_url,
+        ${assetUrlSql('s.logo_url')} AS logo_asset_url,
         s.country,
         COUNT(ms.movie_id)::int AS movie_count
       FROM studios s
@@ -273,6 +282,7 @@ export const getTopStudios = async (req: Request, res: Response): Promise<void>
         s.studio_id,
         s.studio_name,
         s.logo_url,
+        ${assetUrlSql('s.logo_url')} AS logo_asset_url,
         s.country,
         COUNT(ms.movie_id)::int AS movie_count,
         SUM(m.revenue)::bigint AS total_revenue,
@@ -332,4 +342,93 @@ export const getStudioStats = async (req: Request, res: Response): Promise<void>
       ApiError.internalError('Failed to fetch studio statistics')
     );
   }
-};
\ No newline at end of file
+};
+/**
+ * GET /api/studios/countries
+ * Studio counts and movie revenue grouped by studio country
+ *
+ * A movie is counted once per country even if several of its studios share
+ * that country. Country spellings such as "USA" are folded into ISO codes.
+ *
+ * Query Parameters:
+ * - format: 'json' | 'geojson' (default: 'json'; an Accept of application/geo+json also selects GeoJSON)
+ *
+ * GeoJSON output is a FeatureCollection of Point features at each country's
+ * approximate centre, with the aggregates as properties. Countries without a
+ * known centre have a null geometry; join on the feature id (ISO code) to use polygons.
+ *
+ * @returns Per-country aggregates, ordered by total revenue
+ */
+export const getStudioCountryStats = async (req: Request, res: Response): Promise<void> => {
+  const validation = countryStatsSchema.safeParse(req.query);
+  if (!validation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(validation.error.issues)
+    );
+    return;
+  }
+
+  const format = validation.data.format
+    ?? (req.accepts(['application/json', 'application/geo+json']) === 'application/geo+json' ? 'geojson' : 'json');
+
+  try {
+    const sql = `
+      WITH studio_countries AS (
+        SELECT
+          s.studio_id,
+          COALESCE($1::jsonb ->> UPPER(TRIM(s.country)), UPPER(TRIM(s.country))) AS country
+        FROM studios s
+        WHERE s.country IS NOT NULL AND TRIM(s.country) <> ''
+      ),
+      country_movies AS (
+        SELECT DISTINCT sc.country, ms.movie_id
+        FROM studio_countries sc
+        JOIN movie_studios ms ON sc.studio_id = ms.studio_id
+      )
+      SELECT
+        sc.country,
+        sc.studio_count,
+        COUNT(m.movie_id)::int AS movie_count,
+        COALESCE(SUM(m.revenue), 0)::bigint AS total_revenue
+      FROM (
+        SELECT country, COUNT(*)::int AS studio_count
+        FROM studio_countries
+        GROUP BY country
+      ) sc
+      LEFT JOIN country_movies cm ON sc.country = cm.country
+      LEFT JOIN movies m ON cm.movie_id = m.movie_id
+      GROUP BY sc.country, sc.studio_count
+      ORDER BY total_revenue DESC, sc.country
+    `;
+
+    const result = await pool.query(sql, [JSON.stringify(COUNTRY_ALIASES)]);
+
+    if (format === 'json') {
+      res.status(HttpStatus.OK).json({
+        data: result.rows,
+        count: result.rows.length
+      });
+      return;
+    }
+
+    const features = result.rows.map(row => {
+      const centroid = getCountryCentroid(row.country);
+      return {
+        type: 'Feature',
+        id: row.country,
+        geometry: centroid ? { type: 'Point', coordinates: centroid } : null,
+        properties: row
+      };
+    });
+
+    res
+      .status(HttpStatus.OK)
+      .type('application/geo+json')
+      .send(JSON.stringify({ type: 'FeatureCollection', features }));
+  } catch (error) {
+    console.error('Error fetching studio country stats:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch studio country statistics')
+    );
+  }
+};
@@ -1,5 +1,6 @@
 import pool from '@utils/database';
 import { Request, Response } from 'express';
+import { isReadOnlyMode } from '@middleware/readOnly';
 
 /**
  * Returns info about the api.
@@ -12,7 +13,8 @@ export const info = async (req: Request, res: Response) => {
         name: 'TCSS 460 API',
         version: '1.0.0',
         description: 'RESTful API for movies',
-        documentation: '/api-docs'
+        documentation: '/api-docs',
+        read_only: isReadOnlyMode()
     });
 }
 
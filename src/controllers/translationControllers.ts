@@ -0,0 +1,187 @@
+// server/src/controllers/translationControllers.ts
+
+import { Request, Response } from 'express';
+import { PoolClient } from 'pg';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { MovieTranslation } from '@models/movieModel';
+import { sanitizeText } from '@utils/sanitize';
+import z from 'zod';
+import { languageSchema } from './movieGetControllers';
+
+// ============================================================================
+// Zod Schemas for Validation
+// ============================================================================
+
+const translationBodySchema = z.object({
+  title: z.string().min(1).max(500).nullable().optional(),
+  overview: z.string().min(1).nullable().optional(),
+  tagline: z.string().min(1).max(500).nullable().optional()
+}).refine(
+  (t) => t.title != null || t.overview != null || t.tagline != null,
+  'At least one of title, overview or tagline is required'
+);
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+/**
+ * Upserts localized text for a movie inside an existing transaction.
+ * Used by the create/update controllers when a `translations` array is supplied.
+ *
+ * @param client - Pool client with an open transaction
+ * @param movieId - Movie the translations belong to
+ * @param translations - One entry per language
+ */
+export const saveMovieTranslations = async (
+  client: PoolClient,
+  movieId: number,
+  translations: MovieTranslation[]
+): Promise<void> => {
+  for (const translation of translations) {
+    await client.query(
+      `INSERT INTO movie_translations (movie_id, language, title, overview, tagline)
+       VALUES ($1, $2, $3, $4, $5)
+       ON CONFLICT (movie_id, language) DO UPDATE
+         SET title = EXCLUDED.title,
+             overview = EXCLUDED.overview,
+             tagline = EXCLUDED.tagline`,
+      [
+        movieId,
+        translation.language,
+        translation.title || null,
+        sanitizeText(translation.overview) || null,
+        sanitizeText(translation.tagline) || null
+      ]
+    );
+  }
+};
+
+// ============================================================================
+// Translation Controllers
+// ============================================================================
+
+/**
+ * GET /api/movies/:id/translations
+ * List every language a movie has been translated into
+ *
+ * @param id - Movie ID
+ * @returns Array of translations ordered by language
+ */
+export const getMovieTranslations = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  try {
+    const movieResult = await pool.query('SELECT movie_id FROM movies WHERE movie_id = $1', [movieId]);
+    if (movieResult.rows.length === 0) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const sql = `
+      SELECT language, title, overview, tagline
+      FROM movie_translations
+      WHERE movie_id = $1
+      ORDER BY language ASC
+    `;
+
+    const result = await pool.query<MovieTranslation>(sql, [movieId]);
+
+    res.status(HttpStatus.OK).json({
+      movie_id: movieId,
+      data: result.rows,
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching translations:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch translations')
+    );
+  }
+};
+
+/**
+ * PUT /api/movies/:id/translations/:lang
+ * Create or replace the translation of a movie for one language
+ *
+ * Body: { title?, overview?, tagline? } (at least one required)
+ *
+ * @param id - Movie ID
+ * @param lang - Language tag (e.g. "es", "pt-BR")
+ * @returns The stored translation
+ */
+export const upsertMovieTranslation = async (req: Request, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const langValidation = languageSchema.safeParse(req.params.lang);
+  const bodyValidation = translationBodySchema.safeParse(req.body ?? {});
+
+  if (!langValidation.success || !bodyValidation.success) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest([
+        ...(langValidation.error?.issues ?? []),
+        ...(bodyValidation.error?.issues ?? [])
+      ])
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    // Touch the movie so the translation shows up in export deltas
+    const movieResult = await client.query(
+      'UPDATE movies SET version = version + 1, updated_at = NOW() WHERE movie_id = $1 RETURNING movie_id',
+      [movieId]
+    );
+    if (movieResult.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const translation: MovieTranslation = {
+      language: langValidation.data,
+      ...bodyValidation.data
+    };
+    await saveMovieTranslations(client, movieId, [translation]);
+
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      movie_id: movieId,
+      translation
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error saving translation:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to save translation')
+    );
+  } finally {
+    client.release();
+  }
+};
@@ -0,0 +1,150 @@
+// server/src/controllers/userControllers.ts
+
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordAudit } from '@utils/auditLog';
+import { unlockUser } from '@utils/loginThrottle';
+import { disableTwoFactor } from '@utils/twoFactor';
+import { revokeAllRefreshTokens } from '@utils/refreshTokens';
+import { destroyAllSessions } from '@utils/sessions';
+import { ApiKeyRequest } from '@middleware/apiKeyAuth';
+
+// ============================================================================
+// Admin: User Accounts
+// ============================================================================
+
+/**
+ * GET /api/admin/users/locked
+ * Accounts currently locked out or being throttled after failed logins
+ *
+ * @returns Users with at least one consecutive failed login, locked first
+ */
+export const getLockedUsers = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query(
+      `SELECT
+         user_id, username, email, role,
+         failed_login_count, last_failed_login_at, locked_until,
+         COALESCE(locked_until > NOW(), false) AS locked
+       FROM users
+       WHERE failed_login_count > 0
+       ORDER BY locked DESC, last_failed_login_at DESC`
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows,
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching locked users:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch locked users')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/users/:id/unlock
+ * Lift a login lockout and reset the account's failure count
+ *
+ * @param id - User ID
+ * @returns Confirmation
+ */
+export const unlockUserAccount = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const userId = parseInt(req.params.id, 10);
+  if (isNaN(userId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('User ID must be a valid number', 'INVALID_ID')
+    );
+    return;
+  }
+
+  try {
+    const found = await unlockUser(userId);
+    if (!found) {
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`User with ID ${userId} not found`, 'USER_NOT_FOUND', { id: userId })
+      );
+      return;
+    }
+
+    await recordAudit(pool, {
+      action: 'user.unlock',
+      entity_type: 'user',
+      entity_id: userId,
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: `User ${userId} unlocked`
+    });
+  } catch (error) {
+    console.error('Error unlocking user:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to unlock user')
+    );
+  }
+};
+
+/**
+ * POST /api/admin/users/:id/2fa/reset
+ * Turn off 2FA for a user who lost their authenticator and recovery codes
+ *
+ * Also signs the user out everywhere. Admins are asked to enroll again at
+ * their next login.
+ *
+ * @param id - User ID
+ * @returns Confirmation
+ */
+export const resetUserTwoFactor = async (req: ApiKeyRequest, res: Response): Promise<void> => {
+  const userId = parseInt(req.params.id, 10);
+  if (isNaN(userId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('User ID must be a valid number', 'INVALID_ID')
+    );
+    return;
+  }
+
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const found = await disableTwoFactor(client, userId);
+    if (!found) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`User with ID ${userId} not found`, 'USER_NOT_FOUND', { id: userId })
+      );
+      return;
+    }
+
+    await recordAudit(client, {
+      action: 'user.2fa_reset',
+      entity_type: 'user',
+      entity_id: userId,
+      performed_by: req.apiKey?.api_key_id
+    });
+
+    await client.query('COMMIT');
+
+    await revokeAllRefreshTokens(userId);
+    await destroyAllSessions(userId);
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: `Two-factor authentication reset for user ${userId}`
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error resetting two-factor authentication:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to reset two-factor authentication')
+    );
+  } finally {
+    client.release();
+  }
+};
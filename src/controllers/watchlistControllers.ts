@@ -0,0 +1,153 @@
+// server/src/controllers/watchlistControllers.ts
+
+import { Response } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { recordWatchlistActivity, removeWatchlistActivity } from '@utils/activity';
+import { UserRequest } from '@middleware/userAuth';
+
+// ============================================================================
+// Watchlist Controllers
+// ============================================================================
+
+/**
+ * GET /api/me/watchlist
+ * Movies the signed-in user wants to watch, most recently added first
+ *
+ * @returns Movies with when each was added
+ */
+export const getWatchlist = async (req: UserRequest, res: Response): Promise<void> => {
+  try {
+    const result = await pool.query(
+      `SELECT m.movie_id, m.public_id, m.title, m.release_date, m.poster_url, w.added_at
+       FROM watchlist w
+       JOIN movies m ON m.movie_id = w.movie_id
+       WHERE w.user_id = $1
+       ORDER BY w.added_at DESC`,
+      [req.user!.userId]
+    );
+
+    res.status(HttpStatus.OK).json({
+      data: result.rows,
+      count: result.rows.length
+    });
+  } catch (error) {
+    console.error('Error fetching watchlist:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to fetch watchlist')
+    );
+  }
+};
+
+/**
+ * PUT /api/me/watchlist/:id
+ * Add a movie to the signed-in user's watchlist (no-op if already there)
+ *
+ * Followers see the addition in their feed.
+ *
+ * @param id - Movie ID
+ * @returns Confirmation, with added false when the movie was already listed
+ */
+export const addToWatchlist = async (req: UserRequest, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const userId = req.user!.userId;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const movie = await client.query('SELECT 1 FROM movies WHERE movie_id = $1', [movieId]);
+    if (movie.rows.length === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound(`Movie with ID ${movieId} not found`, 'MOVIE_NOT_FOUND', { id: movieId })
+      );
+      return;
+    }
+
+    const result = await client.query(
+      `INSERT INTO watchlist (user_id, movie_id) VALUES ($1, $2)
+       ON CONFLICT (user_id, movie_id) DO NOTHING`,
+      [userId, movieId]
+    );
+    const added = (result.rowCount ?? 0) > 0;
+    if (added) {
+      await recordWatchlistActivity(client, userId, movieId);
+    }
+
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      added
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error adding to watchlist:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update watchlist')
+    );
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * DELETE /api/me/watchlist/:id
+ * Take a movie off the signed-in user's watchlist
+ *
+ * @param id - Movie ID
+ * @returns Confirmation
+ */
+export const removeFromWatchlist = async (req: UserRequest, res: Response): Promise<void> => {
+  const movieId = parseInt(req.params.id, 10);
+  if (isNaN(movieId)) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest('Movie ID must be a valid number', 'INVALID_MOVIE_ID')
+    );
+    return;
+  }
+
+  const userId = req.user!.userId;
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const result = await client.query(
+      'DELETE FROM watchlist WHERE user_id = $1 AND movie_id = $2',
+      [userId, movieId]
+    );
+    if (result.rowCount === 0) {
+      await client.query('ROLLBACK');
+      res.status(HttpStatus.NOT_FOUND).json(
+        ApiError.notFound('Movie is not on your watchlist', 'NOT_ON_WATCHLIST')
+      );
+      return;
+    }
+
+    await removeWatchlistActivity(client, userId, movieId);
+    await client.query('COMMIT');
+
+    res.status(HttpStatus.OK).json({
+      success: true,
+      message: 'Removed from watchlist'
+    });
+  } catch (error) {
+    await client.query('ROLLBACK');
+    console.error('Error removing from watchlist:', error);
+    res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+      ApiError.internalError('Failed to update watchlist')
+    );
+  } finally {
+    client.release();
+  }
+};
@@ -0,0 +1,116 @@
+import { Request, Response } from 'express';
+import { csrfProtection } from '../csrf';
+import { csrfTokenFor } from '@utils/sessions';
+
+jest.mock('@utils/database', () => ({ __esModule: true, default: {} }));
+
+const SESSION_ID = 'session-abc';
+
+const run = (
+  method: string,
+  path: string,
+  { cookies = {}, headers = {} }: { cookies?: Record<string, string>; headers?: Record<string, string> } = {}
+) => {
+  const allHeaders: Record<string, string> = {
+    ...headers,
+    cookie: Object.entries(cookies).map(([name, value]) => `${name}=${value}`).join('; ')
+  };
+  const req = {
+    method,
+    path,
+    headers: allHeaders,
+    get: (name: string) => allHeaders[name.toLowerCase()]
+  } as unknown as Request;
+  const res = {
+    status: jest.fn().mockReturnThis(),
+    json: jest.fn().mockReturnThis()
+  };
+  const next = jest.fn();
+
+  csrfProtection(req, res as unknown as Response, next);
+  return { res, next };
+};
+
+describe('csrfProtection', () => {
+  const env = process.env;
+
+  beforeEach(() => {
+    process.env = { ...env, AUTH_MODE: 'session', CSRF_SECRET: 'test-secret' };
+  });
+
+  afterEach(() => {
+    process.env = env;
+  });
+
+  it('lets a session write through with the matching token', () => {
+    const token = csrfTokenFor(SESSION_ID);
+    const { next } = run('POST', '/api/me/lists', {
+      cookies: { sid: SESSION_ID, 'csrf-token': token },
+      headers: { 'x-csrf-token': token }
+    });
+
+    expect(next).toHaveBeenCalled();
+  });
+
+  it('rejects a session write without the header', () => {
+    const { res, next } = run('DELETE', '/api/me/lists/3', {
+      cookies: { sid: SESSION_ID, 'csrf-token': csrfTokenFor(SESSION_ID) }
+    });
+
+    expect(next).not.toHaveBeenCalled();
+    expect(res.status).toHaveBeenCalledWith(403);
+    expect(res.json.mock.calls[0][0].code).toBe('CSRF_TOKEN_INVALID');
+  });
+
+  it('rejects a header that differs from the cookie', () => {
+    const { res, next } = run('POST', '/api/me/lists', {
+      cookies: { sid: SESSION_ID, 'csrf-token': csrfTokenFor(SESSION_ID) },
+      headers: { 'x-csrf-token': csrfTokenFor('another-session') }
+    });
+
+    expect(next).not.toHaveBeenCalled();
+    expect(res.status).toHaveBeenCalledWith(403);
+  });
+
+  it('rejects a planted cookie that matches the header but not the session', () => {
+    const planted = csrfTokenFor('attacker-session');
+    const { res, next } = run('POST', '/api/me/lists', {
+      cookies: { sid: SESSION_ID, 'csrf-token': planted },
+      headers: { 'x-csrf-token': planted }
+    });
+
+    expect(next).not.toHaveBeenCalled();
+    expect(res.status).toHaveBeenCalledWith(403);
+  });
+
+  it.each(['/api/auth/login', '/api/auth/register', '/api/auth/2fa/verify'])(
+    'exempts %s, which starts a session',
+    path => {
+      const { next } = run('POST', path, { cookies: { sid: 'stale-session' } });
+      expect(next).toHaveBeenCalled();
+    }
+  );
+
+  it('does not exempt other auth routes', () => {
+    const { next } = run('POST', '/api/auth/logout', { cookies: { sid: SESSION_ID } });
+    expect(next).not.toHaveBeenCalled();
+  });
+
+  it('skips reads and requests without a session cookie', () => {
+    expect(run('GET', '/api/me', { cookies: { sid: SESSION_ID } }).next).toHaveBeenCalled();
+    expect(run('POST', '/api/me/lists').next).toHaveBeenCalled();
+  });
+
+  it('skips requests authenticated by header', () => {
+    const { next } = run('POST', '/api/me/lists', {
+      cookies: { sid: SESSION_ID },
+      headers: { authorization: 'Bearer abc' }
+    });
+    expect(next).toHaveBeenCalled();
+  });
+
+  it('does nothing with AUTH_MODE=token', () => {
+    process.env.AUTH_MODE = 'token';
+    expect(run('POST', '/api/me/lists', { cookies: { sid: SESSION_ID } }).next).toHaveBeenCalled();
+  });
+});
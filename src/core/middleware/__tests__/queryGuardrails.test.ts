@@ -0,0 +1,67 @@
+import { Request, Response } from 'express';
+import { queryGuardrails } from '../queryGuardrails';
+
+const run = (path: string, query: Record<string, unknown>, method = 'GET') => {
+  const req = { method, path, query } as unknown as Request;
+  const res = {
+    status: jest.fn().mockReturnThis(),
+    json: jest.fn().mockReturnThis()
+  };
+  const next = jest.fn();
+
+  queryGuardrails(req, res as unknown as Response, next);
+  return { res, next };
+};
+
+describe('queryGuardrails', () => {
+  it('passes ordinary queries through', () => {
+    const { res, next } = run('/movies', { title: 'alien', page: '2', limit: '50' });
+
+    expect(next).toHaveBeenCalled();
+    expect(res.status).not.toHaveBeenCalled();
+  });
+
+  it('rejects q on routes that do not take it', () => {
+    const { res, next } = run('/movies', { q: 'space horror' });
+
+    expect(next).not.toHaveBeenCalled();
+    expect(res.status).toHaveBeenCalledWith(400);
+    expect(res.json.mock.calls[0][0].message).toMatch(/Full-text search is not supported/);
+  });
+
+  it.each(['/search/semantic', '/search/natural'])('lets %s take q', path => {
+    const { res, next } = run(path, { q: 'space horror' });
+
+    expect(next).toHaveBeenCalled();
+    expect(res.status).not.toHaveBeenCalled();
+  });
+
+  it('rejects other unsupported parameters even where q is accepted', () => {
+    const { res, next } = run('/search/semantic', { q: 'space horror', regex: '.*' });
+
+    expect(next).not.toHaveBeenCalled();
+    expect(res.status).toHaveBeenCalledWith(400);
+  });
+
+  it('rejects pages past the result window', () => {
+    const { res } = run('/movies', { page: '101', limit: '100' });
+
+    expect(res.status).toHaveBeenCalledWith(400);
+  });
+
+  it('rejects a limit above the page size', () => {
+    const { res } = run('/movies', { limit: '101' });
+
+    expect(res.status).toHaveBeenCalledWith(400);
+  });
+
+  it('rejects wildcards and repeated search terms', () => {
+    expect(run('/movies', { title: '100%' }).res.status).toHaveBeenCalledWith(400);
+    expect(run('/movies', { title: ['a', 'b'] }).res.status).toHaveBeenCalledWith(400);
+  });
+
+  it('leaves the export route and non-GET requests alone', () => {
+    expect(run('/export/movies', { limit: '1000' }).next).toHaveBeenCalled();
+    expect(run('/movies', { q: 'x' }, 'POST').next).toHaveBeenCalled();
+  });
+});
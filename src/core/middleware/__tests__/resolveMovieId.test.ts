@@ -0,0 +1,85 @@
+import { Request, Response } from 'express';
+import pool from '@utils/database';
+import { hideIntegerMovieIds } from '../resolveMovieId';
+
+jest.mock('@utils/database', () => ({ __esModule: true, default: { query: jest.fn() } }));
+
+const query = pool.query as jest.Mock;
+
+/**
+ * Sends body through the middleware and resolves with what reached the client
+ */
+const send = (body: unknown, statusCode = 200): Promise<unknown> =>
+  new Promise(resolve => {
+    const res = {
+      statusCode,
+      status: jest.fn().mockReturnThis(),
+      json: jest.fn((sent: unknown) => {
+        resolve(sent);
+        return res;
+      })
+    };
+    hideIntegerMovieIds({} as Request, res as unknown as Response, () => undefined);
+    res.json(body);
+  });
+
+describe('hideIntegerMovieIds', () => {
+  const env = process.env;
+
+  beforeEach(() => {
+    process.env = { ...env, MOVIE_ID_STRATEGY: 'public' };
+    query.mockReset();
+  });
+
+  afterEach(() => {
+    process.env = env;
+  });
+
+  it('drops movie_id from rows that carry public_id', async () => {
+    const sent = await send({ data: [{ movie_id: 7, public_id: 'abcdefghijkl', title: 'Heat' }] });
+
+    expect(sent).toEqual({ data: [{ public_id: 'abcdefghijkl', title: 'Heat' }] });
+    expect(query).not.toHaveBeenCalled();
+  });
+
+  it('swaps other integer movie IDs for public IDs in one query', async () => {
+    query.mockResolvedValue({
+      rows: [
+        { movie_id: 7, public_id: 'abcdefghijkl' },
+        { movie_id: 9, public_id: 'mnopqrstuvwx' }
+      ]
+    });
+
+    const sent = await send({
+      review: { review_id: 1, movie_id: 7, rating: 8 },
+      movie_ids: [7, 9],
+      existing_movie_id: 9
+    });
+
+    expect(sent).toEqual({
+      review: { review_id: 1, movie_id: 'abcdefghijkl', rating: 8 },
+      movie_ids: ['abcdefghijkl', 'mnopqrstuvwx'],
+      existing_movie_id: 'mnopqrstuvwx'
+    });
+    expect(query).toHaveBeenCalledTimes(1);
+    expect(query.mock.calls[0][1]).toEqual([[7, 9]]);
+  });
+
+  it('leaves responses alone under the default strategy', async () => {
+    delete process.env.MOVIE_ID_STRATEGY;
+    const body = { movie_id: 7, public_id: 'abcdefghijkl' };
+
+    const sent = await send(body);
+
+    expect(sent).toBe(body);
+  });
+
+  it('leaves error responses alone', async () => {
+    const body = { message: 'Not found', params: { movie_id: 7 } };
+
+    const sent = await send(body, 404);
+
+    expect(sent).toBe(body);
+    expect(query).not.toHaveBeenCalled();
+  });
+});
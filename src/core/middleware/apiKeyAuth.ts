@@ -15,6 +15,7 @@ export interface ApiKeyRequest extends Request {
         name: string;
         email: string | null;
         rate_limit: number;
+        role: string;
     };
 }
 
@@ -29,6 +30,55 @@ const hashApiKey = (apiKey: string): string => {
     return crypto.createHash('sha256').update(apiKey).digest('hex');
 };
 
+/**
+ * Look up an API key by its plain text value
+ * 
+ * @param providedKey - The plain text API key
+ * @returns The stored key, or undefined if there is none
+ */
+const findApiKey = async (providedKey: string) => {
+    // Hash the provided key to compare with stored hash
+    const hashedKey = hashApiKey(providedKey);
+
+    const query = `
+      SELECT 
+        api_key_id,
+        name,
+        email,
+        rate_limit,
+        role,
+        is_active,
+        expires_at,
+        last_used_at
+      FROM api_keys
+      WHERE api_key = $1
+    `;
+
+    const result = await pool.query(query, [hashedKey]);
+    return result.rows[0];
+};
+
+/**
+ * Check an API key outside the Express pipeline (e.g. a WebSocket upgrade).
+ * No rate limiting or usage logging.
+ * 
+ * @param providedKey - The plain text API key
+ * @returns The key's details, or null if it is unknown, revoked or expired
+ */
+export const verifyApiKey = async (providedKey: string): Promise<ApiKeyRequest['apiKey'] | null> => {
+    const keyData = await findApiKey(providedKey);
+    if (!keyData || !keyData.is_active) return null;
+    if (keyData.expires_at && new Date(keyData.expires_at) < new Date()) return null;
+
+    return {
+        api_key_id: keyData.api_key_id,
+        name: keyData.name,
+        email: keyData.email,
+        rate_limit: keyData.rate_limit,
+        role: keyData.role
+    };
+};
+
 /**
  * Middleware to authenticate requests using API key
  * 
@@ -58,44 +108,25 @@ export const requireApiKey = async (
 
         if (!providedKey) {
             res.status(HttpStatus.UNAUTHORIZED).json(
-                ApiError.unauthorized('API key is required. Include X-API-Key header.')
+                ApiError.unauthorized('API key is required. Include X-API-Key header.', 'API_KEY_REQUIRED')
             );
             return;
         }
 
-        // Hash the provided key to compare with stored hash
-        const hashedKey = hashApiKey(providedKey);
-
-        // Look up API key in database
-        const query = `
-      SELECT 
-        api_key_id,
-        name,
-        email,
-        rate_limit,
-        is_active,
-        expires_at,
-        last_used_at
-      FROM api_keys
-      WHERE api_key = $1
-    `;
-
-        const result = await pool.query(query, [hashedKey]);
+        const keyData = await findApiKey(providedKey);
 
         // Check if key exists
-        if (result.rows.length === 0) {
+        if (!keyData) {
             res.status(HttpStatus.UNAUTHORIZED).json(
-                ApiError.unauthorized('Invalid API key')
+                ApiError.unauthorized('Invalid API key', 'API_KEY_INVALID')
             );
             return;
         }
 
-        const keyData = result.rows[0];
-
         // Check if key is active
         if (!keyData.is_active) {
             res.status(HttpStatus.FORBIDDEN).json(
-                ApiError.forbidden('API key has been revoked')
+                ApiError.forbidden('API key has been revoked', 'API_KEY_REVOKED')
             );
             return;
         }
@@ -103,7 +134,7 @@ export const requireApiKey = async (
         // Check if key is expired
         if (keyData.expires_at && new Date(keyData.expires_at) < new Date()) {
             res.status(HttpStatus.FORBIDDEN).json(
-                ApiError.forbidden('API key has expired')
+                ApiError.forbidden('API key has expired', 'API_KEY_EXPIRED')
             );
             return;
         }
@@ -140,7 +171,8 @@ export const requireApiKey = async (
             api_key_id: keyData.api_key_id,
             name: keyData.name,
             email: keyData.email,
-            rate_limit: keyData.rate_limit
+            rate_limit: keyData.rate_limit,
+            role: keyData.role
         };
 
         // Continue to next middleware/controller
@@ -259,7 +291,7 @@ export const requireApiKeyOrJWT = async (
         await requireApiKey(req, res, next);
     } else {
         res.status(HttpStatus.UNAUTHORIZED).json(
-            ApiError.unauthorized('Authentication required. Provide either Bearer token or X-API-Key header.')
+            ApiError.unauthorized('Authentication required. Provide either Bearer token or X-API-Key header.', 'AUTHENTICATION_REQUIRED')
         );
     }
 };
@@ -4,6 +4,7 @@ import { Request, Response, NextFunction } from 'express';
 import { z } from 'zod';
 import { ApiError } from '@utils/httpError';
 import { HttpStatus } from '@utils/httpStatus';
+import { validateRequest } from './validateRequest';
 
 /**
  * Zod schema for API key generation request
@@ -34,46 +35,10 @@ const generateApiKeySchema = z.object({
  * Middleware to validate API key generation request
  * 
  * Validates the request body against generateApiKeySchema
- * If validation fails, returns 400 with detailed error messages
- * If validation succeeds, continues to the next middleware/controller
- * 
- * @param req - Express request object
- * @param res - Express response object
- * @param next - Express next function
+ * If validation fails, returns 400 with field-path errors (see validateRequest)
+ * If validation succeeds, replaces req.body with the normalized values
  */
-export const validateGenerateApiKey = (
-    req: Request,
-    res: Response,
-    next: NextFunction
-): void => {
-    try {
-        // Validate request body
-        const validation = generateApiKeySchema.safeParse(req.body);
-
-        if (!validation.success) {
-            // Extract validation errors
-            const errors = validation.error.issues.map(issue => ({
-                field: issue.path.join('.'),
-                message: issue.message
-            }));
-
-            res.status(HttpStatus.BAD_REQUEST).json(
-                ApiError.badRequest(errors)
-            );
-            return;
-        }
-
-        // Replace request body with validated and transformed data
-        req.body = validation.data;
-
-        // Continue to next middleware/controller
-        next();
-    } catch (error) {
-        res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
-            ApiError.internalError('Validation processing failed')
-        );
-    }
-};
+export const validateGenerateApiKey = validateRequest({ body: generateApiKeySchema });
 
 /**
  * Zod schema for API key authentication header
@@ -104,7 +69,7 @@ export const validateApiKeyHeader = (
 
         if (!apiKey) {
             res.status(HttpStatus.UNAUTHORIZED).json(
-                ApiError.unauthorized('API key is required. Provide X-API-Key header.')
+                ApiError.unauthorized('API key is required. Provide X-API-Key header.', 'API_KEY_REQUIRED')
             );
             return;
         }
@@ -115,7 +80,7 @@ export const validateApiKeyHeader = (
 
         if (!validation.success) {
             res.status(HttpStatus.UNAUTHORIZED).json(
-                ApiError.unauthorized('Invalid API key format')
+                ApiError.unauthorized('Invalid API key format', 'API_KEY_INVALID')
             );
             return;
         }
@@ -0,0 +1,63 @@
+// server/src/middleware/csrf.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { getCookie } from '@utils/cookies';
+import {
+    CSRF_COOKIE,
+    CSRF_HEADER,
+    SESSION_COOKIE,
+    isValidCsrfToken,
+    sessionsEnabled
+} from '@utils/sessions';
+
+const SAFE_METHODS = ['GET', 'HEAD', 'OPTIONS'];
+
+/**
+ * Endpoints that start a session rather than use one, so a stale session
+ * cookie mustn't block them
+ */
+const EXEMPT_PATHS = ['/api/auth/login', '/api/auth/register', '/api/auth/2fa/verify'];
+
+/**
+ * Middleware enforcing double-submit CSRF tokens on cookie sessions
+ *
+ * Browsers attach the session cookie to requests other sites trigger, so
+ * every non-GET request carrying it must also send the csrf-token cookie's
+ * value in the X-CSRF-Token header. Another site can't read that cookie, and
+ * the token is an HMAC of the session ID, so it can't plant a matching one.
+ *
+ * Requests authenticated by header (Bearer token, X-API-Key) aren't
+ * forgeable cross-site and skip the check. Does nothing when AUTH_MODE=token.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const csrfProtection = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    if (!sessionsEnabled() || SAFE_METHODS.includes(req.method) || EXEMPT_PATHS.includes(req.path)) {
+        next();
+        return;
+    }
+
+    const sessionId = getCookie(req, SESSION_COOKIE);
+    if (!sessionId || req.headers.authorization || req.headers['x-api-key']) {
+        next();
+        return;
+    }
+
+    const presented = req.get(CSRF_HEADER);
+    if (!presented || presented !== getCookie(req, CSRF_COOKIE) || !isValidCsrfToken(sessionId, presented)) {
+        res.status(HttpStatus.FORBIDDEN).json(
+            ApiError.forbidden(`Missing or invalid CSRF token. Send the ${CSRF_COOKIE} cookie's value in the X-CSRF-Token header.`, 'CSRF_TOKEN_INVALID', { cookie: CSRF_COOKIE })
+        );
+        return;
+    }
+
+    next();
+};
@@ -0,0 +1,164 @@
+// server/src/middleware/faultInjection.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+
+/**
+ * Rules used when FAULT_INJECTION=on and FAULT_INJECTION_RULES is unset
+ */
+const DEFAULT_RULES = '*: delay=0-500 errors=0.05';
+
+/**
+ * Never slowed down or failed, so load balancers don't take the instance out
+ */
+const EXEMPT_PATHS = ['/api/health'];
+
+/**
+ * Retry-After sent with injected 429 and 503 responses (seconds)
+ */
+const RETRY_AFTER_SECONDS = 1;
+
+export interface FaultRule {
+    /** Upper-case method, or null for any */
+    method: string | null;
+    /** Path pattern as written */
+    pattern: string;
+    matcher: RegExp;
+    /** Added latency range (ms) */
+    delay: [number, number];
+    /** Share of requests (0 to 1) answered with an error */
+    errorRate: number;
+    /** Status of injected errors */
+    status: number;
+}
+
+let parsedFrom: string | null = null;
+let parsedRules: FaultRule[] = [];
+
+/**
+ * Whether fault injection is on (FAULT_INJECTION=on). Never in production,
+ * where the flag is ignored.
+ */
+export const isFaultInjectionEnabled = (): boolean =>
+    process.env.FAULT_INJECTION === 'on' && process.env.NODE_ENV !== 'production';
+
+/**
+ * Parses rules like "GET /api/movies/:id: delay=200-800 errors=0.1 status=500",
+ * separated by semicolons. In paths, * matches anything and :name one
+ * segment. The first rule matching a request applies.
+ *
+ * @throws Error naming the rule that doesn't parse
+ */
+export const parseFaultRules = (spec: string): FaultRule[] =>
+    spec.split(';').map(rule => rule.trim()).filter(Boolean).map(rule => {
+        // The colon ending the route is followed by a space (or nothing), unlike :id
+        const split = /^(.*?):(?=\s|$)(.*)$/.exec(rule);
+        if (!split) {
+            throw new Error(`Fault rule "${rule}" needs a route and a colon, e.g. "/api/movies*: errors=0.1"`);
+        }
+
+        const route = split[1].trim().split(/\s+/);
+        const [method, pattern] = route.length === 2 ? [route[0].toUpperCase(), route[1]] : [null, route[0]];
+        const parsed: FaultRule = {
+            method,
+            pattern,
+            matcher: new RegExp(`^${pattern
+                .split('*')
+                .map(part => part.replace(/[.+?^${}()|[\]\\]/g, '\\$&').replace(/:\w+/g, '[^/]+'))
+                .join('.*')}$`),
+            delay: [0, 0],
+            errorRate: 0,
+            status: HttpStatus.SERVICE_UNAVAILABLE
+        };
+
+        for (const setting of split[2].trim().split(/\s+/).filter(Boolean)) {
+            const [key, value = ''] = setting.split('=');
+            if (key === 'delay' && /^\d+(-\d+)?$/.test(value)) {
+                const [min, max = min] = value.split('-').map(Number);
+                parsed.delay = [Math.min(min, max), Math.max(min, max)];
+            } else if (key === 'errors' && /^(0(\.\d+)?|1(\.0+)?)$/.test(value)) {
+                parsed.errorRate = Number(value);
+            } else if (key === 'status' && /^[45]\d\d$/.test(value)) {
+                parsed.status = Number(value);
+            } else {
+                throw new Error(`Fault rule "${rule}": can't use "${setting}" (delay=MS or MIN-MAX, errors=0-1, status=4xx/5xx)`);
+            }
+        }
+        return parsed;
+    });
+
+/**
+ * The configured rules, parsed again only when FAULT_INJECTION_RULES changes.
+ * Rules that don't parse are logged and none are applied.
+ */
+export const getFaultRules = (): FaultRule[] => {
+    const spec = process.env.FAULT_INJECTION_RULES || DEFAULT_RULES;
+    if (spec !== parsedFrom) {
+        parsedFrom = spec;
+        try {
+            parsedRules = parseFaultRules(spec);
+            console.warn(`Fault injection on: ${parsedRules.map(rule => `${rule.method ?? '*'} ${rule.pattern}`).join(', ')}`);
+        } catch (error) {
+            console.error('FAULT_INJECTION_RULES ignored:', error instanceof Error ? error.message : error);
+            parsedRules = [];
+        }
+    }
+    return parsedRules;
+};
+
+/**
+ * Middleware adding latency and errors to matching requests, so front ends
+ * can exercise their loading states and retry handling against the real API
+ *
+ * Off unless FAULT_INJECTION=on (and ignored when NODE_ENV=production). Each
+ * request takes the first rule in FAULT_INJECTION_RULES whose method and
+ * path match, waits a random delay from its range, then fails with its
+ * status at its error rate. Affected responses carry X-Fault-Injected;
+ * injected 429s and 503s also carry Retry-After. Mount before the API
+ * routers.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const injectFaults = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    const path = req.originalUrl.split('?')[0];
+    if (!isFaultInjectionEnabled() || EXEMPT_PATHS.includes(path)) {
+        next();
+        return;
+    }
+
+    const rule = getFaultRules().find(candidate =>
+        (candidate.method === null || candidate.method === req.method) && candidate.matcher.test(path));
+    if (!rule) {
+        next();
+        return;
+    }
+
+    const [min, max] = rule.delay;
+    const delay = min + Math.floor(Math.random() * (max - min + 1));
+    const fail = Math.random() < rule.errorRate;
+
+    const injected = [delay > 0 ? `delay=${delay}` : null, fail ? `status=${rule.status}` : null].filter(Boolean);
+    if (injected.length > 0) {
+        res.set('X-Fault-Injected', injected.join(' '));
+    }
+
+    setTimeout(() => {
+        if (!fail) {
+            next();
+            return;
+        }
+        if (rule.status === HttpStatus.TOO_MANY_REQUESTS || rule.status === HttpStatus.SERVICE_UNAVAILABLE) {
+            res.set('Retry-After', String(RETRY_AFTER_SECONDS));
+        }
+        res.status(rule.status).json(
+            ApiError.createResponse(rule.status, 'Injected fault for testing (FAULT_INJECTION is on)', 'FAULT_INJECTED')
+        );
+    }, delay);
+};
@@ -0,0 +1,47 @@
+// server/src/middleware/localizeErrors.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { ErrorResponse } from '@utils/httpError';
+import { localizeError, negotiateLocale } from '@utils/i18n';
+
+const isErrorResponse = (body: unknown): body is ErrorResponse =>
+    typeof body === 'object' && body !== null &&
+    typeof (body as ErrorResponse).statusCode === 'number' &&
+    typeof (body as ErrorResponse).timestamp === 'string' &&
+    'message' in body;
+
+/**
+ * App middleware giving every ApiError response a stable `code` and, when
+ * the client's Accept-Language prefers a supported language, a translated
+ * message
+ *
+ * Handlers keep building errors with ApiError in English, passing the
+ * code that picks the translation; translation happens here as the
+ * response is sent. Content-Language says which language the message
+ * ended up in.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const localizeErrors = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    const locale = negotiateLocale(req.headers['accept-language']);
+    res.vary('Accept-Language');
+
+    const originalJson = res.json.bind(res);
+    res.json = ((body: unknown) => {
+        if (res.statusCode < 400 || !isErrorResponse(body)) {
+            return originalJson(body);
+        }
+
+        const localized = localizeError(body, res.statusCode, locale);
+        res.set('Content-Language', localized.language);
+        return originalJson(localized.body);
+    }) as Response['json'];
+
+    next();
+};
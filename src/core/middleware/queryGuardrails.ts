@@ -0,0 +1,125 @@
+// server/src/middleware/queryGuardrails.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+
+/**
+ * Largest page size any list endpoint serves
+ */
+export const MAX_PAGE_SIZE = 100;
+
+/**
+ * Deepest row a paginated request may reach (page * limit). Past this an
+ * OFFSET scan costs more than the page is worth; callers should filter instead.
+ */
+export const MAX_RESULT_WINDOW = 10_000;
+
+/**
+ * Longest free-text search term accepted
+ */
+const MAX_SEARCH_TERM_LENGTH = 100;
+
+/**
+ * Query parameters matched with ILIKE somewhere in the API
+ */
+const TEXT_SEARCH_PARAMS = ['title', 'name', 'actor', 'director', 'studio', 'collection', 'genre'];
+
+/**
+ * Searches callers try that would need a full scan of large text columns.
+ * Rejected explicitly rather than silently ignored, so the caller knows why
+ * their filter had no effect.
+ */
+const UNSUPPORTED_PARAMS: Record<string, string> = {
+  overview: 'Searching movie overviews is not supported; filter by title, genre or people instead',
+  regex: 'Regular expression search is not supported',
+  q: 'Full-text search is not supported; use title (or name on people/studio/collection searches)',
+  sort: 'Unknown parameter "sort"; use sortBy and order',
+  orderBy: 'Unknown parameter "orderBy"; use sortBy and order',
+  offset: 'Unknown parameter "offset"; use page and limit'
+};
+
+/**
+ * Routes that take one of the unsupported parameters as their own input
+ */
+const ACCEPTED_BY: Record<string, string[]> = {
+  q: ['/search/semantic', '/search/natural']
+};
+
+/**
+ * Routes that page through the whole catalog on purpose (instance sync)
+ */
+const EXEMPT_PATHS = ['/export/movies'];
+
+/**
+ * Middleware rejecting GET queries that would be disproportionately expensive
+ *
+ * Checks, in order:
+ * 1. Parameters for unsupported expensive searches (overview, regex, ...),
+ *    except on the routes that accept them
+ * 2. limit above MAX_PAGE_SIZE
+ * 3. page * limit beyond MAX_RESULT_WINDOW
+ * 4. Search terms that are too long or contain SQL wildcards
+ *
+ * Each failure is a 400 that says what to change. Endpoint schemas still do
+ * their own validation; this only catches the costly cases early and uniformly.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const queryGuardrails = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    if (req.method !== 'GET' || EXEMPT_PATHS.includes(req.path)) {
+        next();
+        return;
+    }
+
+    const reject = (message: string) => {
+        res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(message));
+    };
+
+    const query = req.query as Record<string, unknown>;
+
+    for (const [param, message] of Object.entries(UNSUPPORTED_PARAMS)) {
+        if (query[param] !== undefined && !ACCEPTED_BY[param]?.includes(req.path)) {
+            reject(message);
+            return;
+        }
+    }
+
+    const limit = Number(query.limit ?? 20);
+    if (Number.isFinite(limit) && limit > MAX_PAGE_SIZE) {
+        reject(`limit may not exceed ${MAX_PAGE_SIZE}`);
+        return;
+    }
+
+    const page = Number(query.page ?? 1);
+    if (Number.isFinite(page) && Number.isFinite(limit) && page * limit > MAX_RESULT_WINDOW) {
+        reject(`Results past the first ${MAX_RESULT_WINDOW} are not available; narrow the search with filters instead of paging further`);
+        return;
+    }
+
+    for (const param of TEXT_SEARCH_PARAMS) {
+        const value = query[param];
+        if (value === undefined) continue;
+
+        if (typeof value !== 'string') {
+            reject(`${param} may only be given once`);
+            return;
+        }
+        if (value.length > MAX_SEARCH_TERM_LENGTH) {
+            reject(`${param} may be at most ${MAX_SEARCH_TERM_LENGTH} characters`);
+            return;
+        }
+        if (value.includes('%')) {
+            reject(`${param} may not contain "%"; searches already match partial text`);
+            return;
+        }
+    }
+
+    next();
+};
@@ -0,0 +1,94 @@
+// server/src/middleware/readOnly.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { ApiKeyRequest, requireApiKey } from './apiKeyAuth';
+
+const SAFE_METHODS = ['GET', 'HEAD', 'OPTIONS'];
+
+/**
+ * Routes that need a caller identity or expose admin tools. Not served at all
+ * in read-only mode, even for GET.
+ */
+const PRIVATE_PATH_PREFIXES = ['/api/admin', '/api/me', '/api/api-key'];
+
+/**
+ * Cache lifetime (seconds) for responses served in read-only mode.
+ * Override with READ_ONLY_CACHE_SECONDS.
+ */
+const DEFAULT_CACHE_SECONDS = 300;
+
+/**
+ * Whether the server runs as a public read-only catalog (READ_ONLY=true)
+ */
+export const isReadOnlyMode = (): boolean => process.env.READ_ONLY === 'true';
+
+/**
+ * Middleware enforcing read-only mode
+ *
+ * When READ_ONLY=true:
+ * 1. Rejects every non-GET/HEAD/OPTIONS request with 405
+ * 2. Hides admin, per-key (/me) and API key issuing routes behind a 404
+ * 3. Marks everything else as publicly cacheable
+ *
+ * Does nothing when the flag is off. Mount before the API routers.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const enforceReadOnlyMode = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    if (!isReadOnlyMode()) {
+        next();
+        return;
+    }
+
+    if (!SAFE_METHODS.includes(req.method)) {
+        res.set('Allow', SAFE_METHODS.join(', '));
+        res.status(HttpStatus.METHOD_NOT_ALLOWED).json(
+            ApiError.createResponse(
+                HttpStatus.METHOD_NOT_ALLOWED,
+                'This server is running in read-only mode',
+                'READ_ONLY_MODE'
+            )
+        );
+        return;
+    }
+
+    if (PRIVATE_PATH_PREFIXES.some(prefix => req.path === prefix || req.path.startsWith(`${prefix}/`))) {
+        res.status(HttpStatus.NOT_FOUND).json(
+            ApiError.notFound('Not available in read-only mode', 'READ_ONLY_MODE')
+        );
+        return;
+    }
+
+    const maxAge = parseInt(process.env.READ_ONLY_CACHE_SECONDS ?? '', 10) || DEFAULT_CACHE_SECONDS;
+    res.set('Cache-Control', `public, max-age=${maxAge}, stale-while-revalidate=${maxAge * 2}`);
+
+    next();
+};
+
+/**
+ * requireApiKey, except in read-only mode where the catalog is open to anonymous callers
+ *
+ * @param req - Express request object (extended with apiKey property)
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const requireApiKeyUnlessReadOnly = async (
+    req: ApiKeyRequest,
+    res: Response,
+    next: NextFunction
+): Promise<void> => {
+    if (isReadOnlyMode()) {
+        next();
+        return;
+    }
+
+    await requireApiKey(req, res, next);
+};
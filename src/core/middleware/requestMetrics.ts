@@ -0,0 +1,28 @@
+// server/src/middleware/requestMetrics.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { recordRequest, runWithRequestContext } from '@utils/queryMetrics';
+
+/**
+ * Middleware timing each request and attributing its database queries to it
+ *
+ * Mount first so the timing covers every other middleware. Results are
+ * available from GET /api/admin/metrics/latency.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const trackRequestMetrics = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    const start = process.hrtime.bigint();
+
+    res.on('finish', () => {
+        recordRequest(req, Number(process.hrtime.bigint() - start) / 1e6, res.statusCode);
+    });
+
+    runWithRequestContext(req, next);
+};
@@ -0,0 +1,79 @@
+// server/src/middleware/requireAdmin.ts
+
+import { Response, NextFunction } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { isTwoFactorRequiredForRole } from '@utils/twoFactor';
+import { ApiKeyRequest } from './apiKeyAuth';
+import { getRequestUser } from './userAuth';
+
+/**
+ * Middleware to restrict a route to admin API keys
+ * 
+ * Must run after requireApiKey, which attaches the key's role to the request.
+ * Keys are promoted to admin directly in the database (api_keys.role).
+ * 
+ * An admin key alone isn't enough while admins must use 2FA
+ * (REQUIRE_ADMIN_2FA): the request must also be signed in as an admin user
+ * who has two-factor authentication turned on, so a leaked key can't be
+ * used without the second factor.
+ * 
+ * @param req - Express request object (extended with apiKey property)
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const requireAdmin = async (
+    req: ApiKeyRequest,
+    res: Response,
+    next: NextFunction
+): Promise<void> => {
+    if (!req.apiKey) {
+        res.status(HttpStatus.UNAUTHORIZED).json(
+            ApiError.unauthorized('API key authentication required', 'API_KEY_REQUIRED')
+        );
+        return;
+    }
+
+    if (req.apiKey.role !== 'admin') {
+        res.status(HttpStatus.FORBIDDEN).json(
+            ApiError.forbidden('Admin privileges required', 'ADMIN_REQUIRED')
+        );
+        return;
+    }
+
+    if (!isTwoFactorRequiredForRole('admin')) {
+        next();
+        return;
+    }
+
+    try {
+        const user = await getRequestUser(req);
+        if (!user) {
+            res.status(HttpStatus.UNAUTHORIZED).json(
+                ApiError.unauthorized('Admin routes also require signing in as an admin user', 'ADMIN_SIGN_IN_REQUIRED')
+            );
+            return;
+        }
+
+        // The token's role may be stale, and 2FA may have been turned off since
+        const result = await pool.query<{ role: string; two_factor: boolean }>(
+            'SELECT role, totp_enabled_at IS NOT NULL AS two_factor FROM users WHERE user_id = $1',
+            [user.userId]
+        );
+        const account = result.rows[0];
+        if (account?.role !== 'admin' || !account.two_factor) {
+            res.status(HttpStatus.FORBIDDEN).json(
+                ApiError.forbidden('Admin routes require an admin user with two-factor authentication enabled', 'ADMIN_2FA_REQUIRED')
+            );
+            return;
+        }
+
+        next();
+    } catch (error) {
+        console.error('Admin authentication error:', error);
+        res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+            ApiError.internalError('Authentication failed')
+        );
+    }
+};
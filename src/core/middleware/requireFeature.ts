@@ -0,0 +1,33 @@
+// server/src/middleware/requireFeature.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { FeatureFlag, isFeatureEnabled } from '@utils/featureFlags';
+
+/**
+ * Middleware hiding a route while its feature flag is off
+ *
+ * Disabled features answer 404 so dark-launched endpoints look like they
+ * don't exist yet.
+ *
+ * @param flag - Flag key from FEATURE_FLAGS
+ * @returns Express middleware
+ *
+ * @example
+ * router.get('/movies/:id/reviews', requireFeature('enable_reviews'), getReviews);
+ */
+export const requireFeature = (flag: FeatureFlag) => async (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): Promise<void> => {
+    if (!(await isFeatureEnabled(flag))) {
+        res.status(HttpStatus.NOT_FOUND).json(
+            ApiError.notFound('This feature is not enabled', 'FEATURE_DISABLED')
+        );
+        return;
+    }
+
+    next();
+};
@@ -0,0 +1,211 @@
+// server/src/middleware/resolveMovieId.ts
+
+import { Request, Response, NextFunction } from 'express';
+import pool from '@utils/database';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+
+/**
+ * Shape of generated public IDs (see migration 010)
+ */
+export const PUBLIC_ID_PATTERN = /^[A-Za-z0-9_-]{12}$/;
+
+/**
+ * Which movie identifiers route parameters accept (MOVIE_ID_STRATEGY):
+ * - 'both' (default): integer movie_id or public_id
+ * - 'public': public_id only, so sequential IDs can't be enumerated
+ */
+export const getIdStrategy = (): 'both' | 'public' =>
+    process.env.MOVIE_ID_STRATEGY === 'public' ? 'public' : 'both';
+
+/**
+ * Middleware translating movie route parameters to internal integer IDs
+ *
+ * Each named parameter may hold a numeric movie_id or a public_id. Public IDs
+ * are looked up and the parameter is rewritten to the integer, so handlers
+ * keep parsing req.params as before. Unknown public IDs answer 404.
+ *
+ * @param paramNames - Route parameters holding movie IDs (default: 'id')
+ * @returns Express middleware
+ *
+ * @example
+ * router.get('/movies/:id', resolveMovieIds(), getMovieById);
+ * router.post('/admin/movies/:id/merge-into/:targetId', resolveMovieIds('id', 'targetId'), mergeMovieInto);
+ */
+export const resolveMovieIds = (...paramNames: string[]) => async (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): Promise<void> => {
+    const names = paramNames.length > 0 ? paramNames : ['id'];
+
+    try {
+        for (const name of names) {
+            const value = req.params[name];
+            if (value === undefined) continue;
+
+            if (/^\d+$/.test(value)) {
+                if (getIdStrategy() === 'public') {
+                    res.status(HttpStatus.BAD_REQUEST).json(
+                        ApiError.badRequest('Movies are addressed by public_id on this server', 'INVALID_MOVIE_ID')
+                    );
+                    return;
+                }
+                continue;
+            }
+
+            if (!PUBLIC_ID_PATTERN.test(value)) {
+                // Leave it to the handler's own validation message
+                continue;
+            }
+
+            const result = await pool.query<{ movie_id: number }>(
+                'SELECT movie_id FROM movies WHERE public_id = $1',
+                [value]
+            );
+
+            if (result.rows.length === 0) {
+                res.status(HttpStatus.NOT_FOUND).json(
+                    ApiError.notFound(`Movie with ID ${value} not found`, 'MOVIE_NOT_FOUND', { id: value })
+                );
+                return;
+            }
+
+            req.params[name] = String(result.rows[0].movie_id);
+        }
+
+        next();
+    } catch (error) {
+        console.error('Error resolving movie ID:', error);
+        res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+            ApiError.internalError('Failed to resolve movie ID')
+        );
+    }
+};
+
+/**
+ * Translates movie IDs taken from a request body, where each may be a
+ * movie_id or a public_id, to internal integer IDs in the same order
+ *
+ * Public IDs that don't match a movie come back as null.
+ *
+ * @param ids - Integer movie_ids and/or public_ids
+ * @returns The integer IDs
+ */
+export const toMovieIds = async (ids: Array<number | string>): Promise<Array<number | null>> => {
+    const publicIds = ids.filter((id): id is string => typeof id === 'string');
+    const byPublicId = new Map<string, number>();
+
+    if (publicIds.length > 0) {
+        const result = await pool.query<{ movie_id: number; public_id: string }>(
+            'SELECT movie_id, public_id FROM movies WHERE public_id = ANY($1::text[])',
+            [publicIds]
+        );
+        for (const row of result.rows) byPublicId.set(row.public_id, row.movie_id);
+    }
+
+    return ids.map(id => typeof id === 'number' ? id : byPublicId.get(id) ?? null);
+};
+
+/**
+ * Response keys holding integer movie IDs: movie_id and e.g. existing_movie_id
+ */
+const MOVIE_ID_KEY = /^(\w+_)?movie_id$/;
+
+const isPlainObject = (value: unknown): value is Record<string, unknown> =>
+    typeof value === 'object' && value !== null &&
+    [Object.prototype, null].includes(Object.getPrototypeOf(value));
+
+/**
+ * Integer movie IDs in a response body that have no public_id next to them
+ */
+const collectMovieIds = (value: unknown, ids: Set<number>): void => {
+    if (Array.isArray(value)) {
+        value.forEach(item => collectMovieIds(item, ids));
+        return;
+    }
+    if (!isPlainObject(value)) return;
+
+    for (const [key, field] of Object.entries(value)) {
+        if (key === 'movie_id' && 'public_id' in value) continue;
+        if (MOVIE_ID_KEY.test(key) && typeof field === 'number') {
+            ids.add(field);
+        } else if (key === 'movie_ids' && Array.isArray(field)) {
+            field.forEach(id => typeof id === 'number' && ids.add(id));
+        } else {
+            collectMovieIds(field, ids);
+        }
+    }
+};
+
+/**
+ * Copy of a response body with movie_id dropped where public_id is already
+ * there and every other integer movie ID swapped for its public_id
+ */
+const replaceMovieIds = (value: unknown, publicIds: Map<number, string>): unknown => {
+    if (Array.isArray(value)) return value.map(item => replaceMovieIds(item, publicIds));
+    if (!isPlainObject(value)) return value;
+
+    const toPublicId = (id: unknown) => typeof id === 'number' ? publicIds.get(id) ?? null : id;
+    const copy: Record<string, unknown> = {};
+    for (const [key, field] of Object.entries(value)) {
+        if (key === 'movie_id' && 'public_id' in value) continue;
+        if (MOVIE_ID_KEY.test(key) && typeof field === 'number') {
+            copy[key] = toPublicId(field);
+        } else if (key === 'movie_ids' && Array.isArray(field)) {
+            copy[key] = field.map(toPublicId);
+        } else {
+            copy[key] = replaceMovieIds(field, publicIds);
+        }
+    }
+    return copy;
+};
+
+/**
+ * App middleware keeping integer movie IDs out of JSON responses when
+ * MOVIE_ID_STRATEGY=public, so they can't be collected from list and
+ * search results either
+ *
+ * Rows that carry public_id lose movie_id; elsewhere (reviews, watchlist
+ * entries, movie_ids arrays) the integer is replaced by the public_id,
+ * looked up in one query per response. Does nothing under 'both'.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const hideIntegerMovieIds = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    if (getIdStrategy() !== 'public') {
+        next();
+        return;
+    }
+
+    const originalJson = res.json.bind(res);
+    res.json = ((body: unknown) => {
+        if (res.statusCode >= 400) return originalJson(body);
+
+        const ids = new Set<number>();
+        collectMovieIds(body, ids);
+        if (ids.size === 0) return originalJson(replaceMovieIds(body, new Map()));
+
+        pool.query<{ movie_id: number; public_id: string }>(
+            'SELECT movie_id, public_id FROM movies WHERE movie_id = ANY($1::int[])',
+            [[...ids]]
+        )
+            .then(result => {
+                originalJson(replaceMovieIds(body, new Map(result.rows.map(row => [row.movie_id, row.public_id]))));
+            })
+            .catch(error => {
+                console.error('Error replacing movie IDs:', error);
+                res.status(HttpStatus.INTERNAL_SERVER_ERROR);
+                originalJson(ApiError.internalError('Failed to resolve movie ID'));
+            });
+        return res;
+    }) as Response['json'];
+
+    next();
+};
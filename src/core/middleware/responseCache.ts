@@ -0,0 +1,227 @@
+// server/src/middleware/responseCache.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { CachedResponse, MAX_CACHED_BODY_BYTES, responseCache } from '@utils/responseCache';
+import { invalidateCache } from '@utils/cacheBus';
+
+/**
+ * How long a cached response may be served
+ */
+export interface CacheOptions {
+  /** Seconds the response is served without touching the handler */
+  ttl: number;
+  /** Further seconds a stale copy is served while a refresh runs in the background */
+  staleWhileRevalidate?: number;
+}
+
+/**
+ * A background refresh that hasn't finished after this long is assumed lost
+ */
+const REVALIDATION_TIMEOUT_MS = 30_000;
+
+/**
+ * Response headers kept with a cached body
+ */
+const CACHED_HEADERS = ['content-type', 'content-disposition', 'etag'];
+
+const SAFE_METHODS = ['GET', 'HEAD', 'OPTIONS'];
+
+/**
+ * Writes that can change what cached routes return: the movie routes and the
+ * admin routes (merges, bulk edits, imports, moderation, jobs). Account, auth
+ * and list writes leave the cache alone; shared lists and reviews catch up
+ * within their TTL.
+ */
+const CATALOG_WRITE_PATH = /^\/api\/(movies|admin)(\/|$)/;
+
+/**
+ * Whether caching is turned off (RESPONSE_CACHE=off)
+ */
+const isCacheDisabled = (): boolean => process.env.RESPONSE_CACHE === 'off';
+
+/**
+ * Cache key: path + query, plus Accept since some routes negotiate the format
+ */
+const getCacheKey = (req: Request): string => `${req.originalUrl}|${req.get('accept') ?? ''}`;
+
+/**
+ * Sends a cached response, tagging it with X-Cache and its age
+ */
+const sendCached = (res: Response, entry: CachedResponse, ttl: number, status: 'HIT' | 'STALE'): void => {
+  res.set(entry.headers);
+  res.set('X-Cache', status);
+  res.set('Age', String(Math.max(0, Math.floor((Date.now() - (entry.freshUntil - ttl * 1000)) / 1000))));
+  res.status(entry.statusCode).send(entry.body);
+};
+
+/**
+ * Whether res.send() was given the final body. res.json() and objects passed
+ * to res.send() come back through res.send() as a string, so only strings
+ * and buffers are stored.
+ */
+const isFinalBody = (body: unknown): body is string | Buffer =>
+  typeof body === 'string' || Buffer.isBuffer(body);
+
+/**
+ * Stores a handler's 200 response under the given key, with the content type
+ * the handler set (or the one Express would default to)
+ */
+const storeResponse = (
+  key: string,
+  statusCode: number,
+  headers: Record<string, string>,
+  body: string | Buffer,
+  options: CacheOptions
+): void => {
+  if (statusCode !== 200) return;
+  if (Buffer.byteLength(body) > MAX_CACHED_BODY_BYTES) return;
+
+  const now = Date.now();
+  const freshUntil = now + options.ttl * 1000;
+
+  responseCache.set(key, {
+    statusCode,
+    headers: {
+      'content-type': typeof body === 'string' ? 'text/html; charset=utf-8' : 'application/octet-stream',
+      ...headers
+    },
+    body,
+    freshUntil,
+    staleUntil: freshUntil + (options.staleWhileRevalidate ?? 0) * 1000
+  });
+};
+
+/**
+ * Picks the headers worth keeping from a live response
+ */
+const pickHeaders = (res: Response): Record<string, string> => {
+  const headers: Record<string, string> = {};
+  for (const name of CACHED_HEADERS) {
+    const value = res.get(name);
+    if (value) headers[name] = value;
+  }
+  return headers;
+};
+
+/**
+ * Route middleware caching GET responses in process memory
+ *
+ * - Fresh entry: served directly (X-Cache: HIT), the handler doesn't run
+ * - Stale entry within the staleWhileRevalidate window: served directly
+ *   (X-Cache: STALE), then the handler runs once in the background to refresh it
+ * - No entry: the handler runs and a 200 response is stored with its content
+ *   type, whether sent with res.json() or res.send() (X-Cache: MISS)
+ *
+ * Successful catalog writes clear the whole cache, on every replica (see
+ * invalidateResponseCacheOnWrite). Set RESPONSE_CACHE=off to disable.
+ *
+ * @param options - Per-route TTL and stale window, in seconds
+ * @returns Express middleware
+ *
+ * @example
+ * router.get('/movies', cacheResponse({ ttl: 60, staleWhileRevalidate: 300 }), getAllMovies);
+ */
+export const cacheResponse = (options: CacheOptions) => (
+  req: Request,
+  res: Response,
+  next: NextFunction
+): void => {
+  if (req.method !== 'GET' || isCacheDisabled()) {
+    next();
+    return;
+  }
+
+  const key = getCacheKey(req);
+  const entry = responseCache.get(key);
+  const now = Date.now();
+
+  if (entry && now < entry.freshUntil) {
+    sendCached(res, entry, options.ttl, 'HIT');
+    return;
+  }
+
+  if (entry) {
+    sendCached(res, entry, options.ttl, 'STALE');
+
+    if (entry.revalidatingSince && now - entry.revalidatingSince < REVALIDATION_TIMEOUT_MS) {
+      return;
+    }
+    entry.revalidatingSince = now;
+
+    // The client already has its answer; run the handler against a response
+    // that only records what it would have sent.
+    let statusCode = 200;
+    // res.json() skips setting a content type the stale copy already sent
+    const headers: Record<string, string> = { 'content-type': entry.headers['content-type'] };
+
+    res.status = ((code: number) => {
+      statusCode = code;
+      return res;
+    }) as Response['status'];
+    res.set = ((field: string | Record<string, string>, value?: string) => {
+      if (typeof field === 'string') {
+        headers[field.toLowerCase()] = String(value);
+      }
+      return res;
+    }) as Response['set'];
+    res.type = ((type: string) => {
+      headers['content-type'] = type;
+      return res;
+    }) as Response['type'];
+    const send = res.send.bind(res);
+    res.send = ((body?: unknown) => {
+      if (!isFinalBody(body)) {
+        // Serialized by Express, which calls res.send() again with the string
+        return send(body);
+      }
+      const kept = Object.fromEntries(
+        Object.entries(headers).filter(([name]) => CACHED_HEADERS.includes(name))
+      );
+      if (statusCode === 200) {
+        storeResponse(key, statusCode, kept, body, options);
+      } else {
+        entry.revalidatingSince = undefined;
+      }
+      return res;
+    }) as Response['send'];
+
+    next();
+    return;
+  }
+
+  const originalSend = res.send.bind(res);
+  res.send = ((body?: unknown) => {
+    if (isFinalBody(body)) {
+      storeResponse(key, res.statusCode, pickHeaders(res), body, options);
+      res.set('X-Cache', 'MISS');
+    }
+    return originalSend(body);
+  }) as Response['send'];
+
+  next();
+};
+
+/**
+ * App middleware clearing the response cache after a successful catalog write
+ * (CATALOG_WRITE_PATH), here and on the other replicas (see cacheBus), so
+ * edits are visible immediately instead of after the TTL
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const invalidateResponseCacheOnWrite = (
+  req: Request,
+  res: Response,
+  next: NextFunction
+): void => {
+  if (!SAFE_METHODS.includes(req.method) && CATALOG_WRITE_PATH.test(req.path)) {
+    res.on('finish', () => {
+      if (res.statusCode < 400) {
+        invalidateCache('responses');
+      }
+    });
+  }
+
+  next();
+};
@@ -0,0 +1,88 @@
+// server/src/middleware/securityHeaders.ts
+
+import { Request, Response, NextFunction } from 'express';
+
+/**
+ * JSON responses never load anything, so they get the strictest policy
+ */
+const API_POLICY = "default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'";
+
+/**
+ * Swagger UI and the API key form are HTML pages with inline styles and scripts
+ */
+const DOCUMENT_POLICY = [
+    "default-src 'self'",
+    "script-src 'self' 'unsafe-inline'",
+    "style-src 'self' 'unsafe-inline'",
+    "img-src 'self' data: https:",
+    "connect-src 'self'",
+    "frame-ancestors 'none'",
+    "base-uri 'self'",
+    "form-action 'self'"
+].join('; ');
+
+const DOCUMENT_PATH_PREFIXES = ['/api-docs', '/api/api-key'];
+
+/**
+ * One year, the minimum for HSTS preload lists
+ */
+const DEFAULT_HSTS_MAX_AGE = 31536000;
+
+/**
+ * Strict-Transport-Security value, or null when disabled.
+ *
+ * On by default in production only (browsers ignore it over plain HTTP, and
+ * pinning localhost to HTTPS breaks other dev servers). HSTS_MAX_AGE sets the
+ * lifetime in seconds, 0 turns it off; HSTS_INCLUDE_SUBDOMAINS and
+ * HSTS_PRELOAD add those directives.
+ */
+const getHstsHeader = (): string | null => {
+    const configured = process.env.HSTS_MAX_AGE;
+    const maxAge = configured !== undefined
+        ? parseInt(configured, 10)
+        : process.env.NODE_ENV === 'production' ? DEFAULT_HSTS_MAX_AGE : 0;
+    if (!maxAge || maxAge < 0) {
+        return null;
+    }
+
+    let header = `max-age=${maxAge}`;
+    if (process.env.HSTS_INCLUDE_SUBDOMAINS === 'true') header += '; includeSubDomains';
+    if (process.env.HSTS_PRELOAD === 'true') header += '; preload';
+    return header;
+};
+
+/**
+ * Middleware setting security headers on every response
+ *
+ * - Content-Security-Policy: CONTENT_SECURITY_POLICY if set, else a deny-all
+ *   policy for API responses and a same-origin one for the HTML pages
+ * - X-Content-Type-Options: nosniff
+ * - Referrer-Policy: REFERRER_POLICY, default no-referrer
+ * - X-Frame-Options: DENY (for browsers without frame-ancestors)
+ * - Strict-Transport-Security: see getHstsHeader
+ *
+ * Mount first so error responses get them too.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const securityHeaders = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    const isDocument = DOCUMENT_PATH_PREFIXES.some(prefix => req.path === prefix || req.path.startsWith(`${prefix}/`));
+
+    res.set('Content-Security-Policy', process.env.CONTENT_SECURITY_POLICY ?? (isDocument ? DOCUMENT_POLICY : API_POLICY));
+    res.set('X-Content-Type-Options', 'nosniff');
+    res.set('Referrer-Policy', process.env.REFERRER_POLICY ?? 'no-referrer');
+    res.set('X-Frame-Options', 'DENY');
+
+    const hsts = getHstsHeader();
+    if (hsts) {
+        res.set('Strict-Transport-Security', hsts);
+    }
+
+    next();
+};
@@ -0,0 +1,49 @@
+// server/src/middleware/tracing.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { SpanKind, parseTraceparent, runInSpan, startSpan } from '@utils/tracing';
+
+/**
+ * Middleware opening a SERVER span for each request
+ *
+ * Continues the caller's trace when a traceparent header is present. The span
+ * is renamed to the matched route pattern when the response finishes, and
+ * database queries made while handling the request become its children.
+ * Does nothing visible unless OTEL_EXPORTER_OTLP_ENDPOINT is set.
+ *
+ * @param req - Express request object
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const traceRequests = (
+    req: Request,
+    res: Response,
+    next: NextFunction
+): void => {
+    const span = startSpan(`${req.method}`, {
+        kind: SpanKind.SERVER,
+        parent: parseTraceparent(req.get('traceparent')),
+        attributes: {
+            'http.request.method': req.method,
+            'url.path': req.path,
+            'user_agent.original': req.get('user-agent')
+        }
+    });
+
+    res.on('finish', () => {
+        const route = req.route ? `${req.baseUrl}${req.route.path}` : undefined;
+        if (route) {
+            span.name = `${req.method} ${route}`;
+        }
+        span.setAttributes({
+            'http.route': route,
+            'http.response.status_code': res.statusCode
+        });
+        if (res.statusCode >= 500) {
+            span.recordError(new Error(`HTTP ${res.statusCode}`));
+        }
+        span.end();
+    });
+
+    runInSpan(span, next);
+};
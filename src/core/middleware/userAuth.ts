@@ -0,0 +1,96 @@
+// server/src/middleware/userAuth.ts
+
+import { Request, Response, NextFunction } from 'express';
+import { JwtClaims } from '@models/authModel';
+import { ApiError } from '@utils/httpError';
+import { HttpStatus } from '@utils/httpStatus';
+import { verifyAccess } from '@utils/jwtToken';
+import { getCookie } from '@utils/cookies';
+import { SESSION_COOKIE, getSessionUser, sessionsEnabled, tokensEnabled } from '@utils/sessions';
+
+/**
+ * Extended Request interface to include the signed-in user
+ */
+export interface UserRequest extends Request {
+    user?: JwtClaims;
+}
+
+/**
+ * Claims from a valid "Authorization: Bearer <access token>" header
+ *
+ * @param req - Express request object
+ * @returns The token's claims, or null when missing, invalid or expired
+ */
+export const getBearerClaims = (req: Request): JwtClaims | null => {
+    const header = req.headers.authorization;
+    if (!header?.startsWith('Bearer ')) {
+        return null;
+    }
+
+    try {
+        const claims = verifyAccess(header.slice('Bearer '.length).trim());
+        // Login-step (2FA) tokens share the signing key but aren't access tokens
+        if (typeof claims === 'string' || claims.aud !== undefined || typeof claims.userId !== 'number') {
+            return null;
+        }
+        return { userId: claims.userId, userName: claims.userName, role: claims.role };
+    } catch {
+        return null;
+    }
+};
+
+/**
+ * The signed-in user, from a bearer access token or a session cookie
+ * depending on AUTH_MODE
+ *
+ * @param req - Express request object
+ * @returns The user's claims, or null when not signed in
+ */
+export const getRequestUser = async (req: Request): Promise<JwtClaims | null> => {
+    const claims = tokensEnabled() ? getBearerClaims(req) : null;
+    if (claims) {
+        return claims;
+    }
+
+    const sessionId = sessionsEnabled() ? getCookie(req, SESSION_COOKIE) : undefined;
+    if (!sessionId) {
+        return null;
+    }
+
+    const user = await getSessionUser(sessionId);
+    return user ? { userId: user.user_id, userName: user.username, role: user.role } : null;
+};
+
+/**
+ * Middleware to restrict a route to signed-in users (access token from
+ * POST /api/auth/login, or the session cookie when AUTH_MODE allows it)
+ *
+ * @param req - Express request object (extended with user property)
+ * @param res - Express response object
+ * @param next - Express next function
+ */
+export const requireUser = async (
+    req: UserRequest,
+    res: Response,
+    next: NextFunction
+): Promise<void> => {
+    try {
+        const user = await getRequestUser(req);
+        if (!user) {
+            res.status(HttpStatus.UNAUTHORIZED).json(
+                ApiError.unauthorized(tokensEnabled()
+                    ? 'Sign in required. Include "Authorization: Bearer <access token>".'
+                    : 'Sign in required.', 'SIGN_IN_REQUIRED')
+            );
+            return;
+        }
+
+        req.user = user;
+        next();
+    } catch (error) {
+        console.error('User authentication error:', error);
+        res.status(HttpStatus.INTERNAL_SERVER_ERROR).json(
+            ApiError.internalError('Authentication failed')
+        );
+    }
+};
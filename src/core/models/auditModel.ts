@@ -0,0 +1,10 @@
+/**
+ * Entry written to the audit_log table for administrative actions
+ */
+export interface AuditEntry {
+  action: string; // e.g. "person.merge"
+  entity_type: string; // e.g. "actor", "movie"
+  entity_id: number | null;
+  details?: Record<string, unknown>;
+  performed_by?: number | null; // api_key_id
+}
@@ -1,4 +1,5 @@
 export interface JwtClaims {
+    userId: number;
     userName: string;
     role: string;
 }
@@ -6,6 +7,8 @@ export interface JwtClaims {
 export interface JwtInfo {
     accessToken: string;
     type: string;
+    refreshToken: string; // Opaque; single use, exchange at POST /api/auth/refresh
+    refreshExpiresAt: string;
 }
 
 export interface JwtResponse {
@@ -19,5 +22,4 @@ export interface User {
     username: string;
     role: string;
     password_hash: string;
-    token: string;
 }
\ No newline at end of file
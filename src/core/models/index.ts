@@ -1,3 +1,5 @@
 export * from './movieModel';
 export * from './authModel';
-export * from './resourceModels';
\ No newline at end of file
+export * from './resourceModels';
+export * from './auditModel';
+export * from './reviewModel';
\ No newline at end of file
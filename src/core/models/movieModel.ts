@@ -2,18 +2,30 @@
  * Complete movie model representing a full movie entity.
  */
 export interface Movie {
+  movie_id?: number;
+  public_id?: string; // Opaque external ID, accepted anywhere a movie ID is
   title: string;
   original_title: string;
   directors: string;
   genres: string;
-  release_date: Date;
-  runtime_minutes: number;
+  release_date: string; // YYYY-MM-DD format
+  runtime_minutes: number | null; // null when unknown
   overview: string;
   budget: number;
   revenue: number;
   mpa_rating: string;
+  budget_adjusted?: number | null; // In CPI_BASE_YEAR dollars
+  revenue_adjusted?: number | null;
+  era?: string | null;
+  vote_count?: number | null;
+  vote_average?: number | null;
+  popularity?: number | null; // 0-1, refreshed nightly
+  adult?: boolean; // Hidden from public lists unless the content filter allows it
   poster_url: string;
   backdrop_url: string;
+  trailer_url?: string | null;
+  version?: number;
+  updated_at?: Date;
 }
 
 /**
@@ -24,8 +36,8 @@ export interface MovieRow {
   original_title: string;
   directors: string;
   genres: string;
-  release_date: string | Date;
-  runtime_minutes: number;
+  release_date: string; // YYYY-MM-DD format
+  runtime_minutes: number | null; // null when unknown
   overview: string;
   budget: number;
   revenue: number;
@@ -42,8 +54,8 @@ export interface MovieOptional {
   original_title: string;
   directors: string;
   genres: string;
-  release_date: Date;
-  runtime_minutes?: number;
+  release_date: string; // YYYY-MM-DD format
+  runtime_minutes?: number | null;
   overview?: string;
   budget?: number;
   revenue?: number;
@@ -59,7 +71,7 @@ export interface MovieDeletionLog {
   movie_id: number;
   title: string;
   original_title: string | null;
-  release_date: Date | null;
+  release_date: string | null; // YYYY-MM-DD format
   deleted_at: Date;
   deleted_by?: string;
 }
@@ -69,9 +81,22 @@ export interface MovieDeletionLog {
  */
 export interface CastMember {
   actor_name: string;
-  character_name?: string;
+  character_name?: string; // Several roles may be joined with " / "
+  character_names?: string[]; // One entry per role; derived from character_name when omitted
   profile_url?: string;
-  actor_order: number; // 1-10
+  actor_order: number; // Position in the supplied list, from 1
+  billing_order?: number; // Billing position from the source, when it has one
+  credited?: boolean; // false for uncredited appearances (default true)
+}
+
+/**
+ * Localized text for a movie in a single language
+ */
+export interface MovieTranslation {
+  language: string; // e.g. "es", "pt-BR"
+  title?: string | null;
+  overview?: string | null;
+  tagline?: string | null;
 }
 
 /**
@@ -91,27 +116,43 @@ export interface MovieCreateInput {
   title: string;
   original_title: string;
   release_date: string; // YYYY-MM-DD format
-  runtime_minutes: number;
+  runtime_minutes?: number | null; // 0 or null when unknown, stored as null
   genres: string[]; // Array of genre names
   overview: string;
   mpa_rating: string; // PG, PG-13, R
+  adult?: boolean; // Defaults to false
   
   // Optional financial data
   budget?: number;
   revenue?: number;
+
+  // Optional audience votes from the import source
+  vote_count?: number;
+  vote_average?: number; // 0-10
   
   // Optional related entities
   directors?: string[]; // Array of director names
   producers?: string[]; // Array of producer names
+  writers?: string[]; // Writing credits (screenplay, story)
+  composers?: string[];
+  cinematographers?: string[];
+  editors?: string[];
+  keywords?: string[]; // Tags, stored lower-case
+  spoken_languages?: string[]; // ISO 639-1 codes or language names
+  production_countries?: string[]; // ISO 3166-1 alpha-2 codes or country names
   studios?: MovieStudio[]; // Array of studio objects
-  cast?: CastMember[]; // Array of cast members (max 10)
+  cast?: CastMember[]; // Array of cast members
   
   // Optional visual assets
   poster_url?: string;
   backdrop_url?: string;
+  trailer_url?: string;
   
   // Optional collection
   collection_name?: string;
+
+  // Optional localized text
+  translations?: MovieTranslation[];
 }
 
 /**
@@ -121,21 +162,45 @@ export interface MovieUpdateInput {
   title?: string;
   original_title?: string;
   release_date?: string;
-  runtime_minutes?: number;
+  runtime_minutes?: number | null;
   overview?: string;
   budget?: number;
   revenue?: number;
+  vote_count?: number;
+  vote_average?: number;
   mpa_rating?: string;
+  adult?: boolean;
   poster_url?: string;
   backdrop_url?: string;
+  trailer_url?: string | null;
   
   // Related entities (if provided, will replace existing)
   genres?: string[];
   directors?: string[];
   producers?: string[];
+  writers?: string[];
+  composers?: string[];
+  cinematographers?: string[];
+  editors?: string[];
+  keywords?: string[];
+  spoken_languages?: string[];
+  production_countries?: string[];
   studios?: MovieStudio[];
   cast?: CastMember[];
   collection_name?: string;
+  translations?: MovieTranslation[];
+
+  // Expected row version; the update is rejected if the movie has changed since
+  version?: number;
+}
+
+/**
+ * Full movie record as served by GET /api/export/movies and consumed by `npm run sync`
+ */
+export interface ExportedMovie extends MovieCreateInput {
+  movie_id: number;
+  public_id?: string;
+  updated_at: string;
 }
 
 /**
@@ -144,6 +209,7 @@ export interface MovieUpdateInput {
 export interface MovieCreateResponse {
   success: boolean;
   movie_id: number;
+  public_id: string;
   message: string;
 }
 
@@ -159,6 +225,7 @@ export interface BulkImportResponse {
     title: string;
     success: boolean;
     movie_id?: number;
+    public_id?: string;
     error?: string;
   }>;
 }
\ No newline at end of file
@@ -8,6 +8,8 @@ export interface Studio {
   studio_id: number;
   studio_name: string;
   logo_url: string | null;
+  /** /api/assets path of the mirrored logo, once mirrored */
+  logo_asset_url?: string | null;
   country: string | null;
   founded_year: number | null;
   headquarters: string | null;
@@ -80,6 +82,38 @@ export interface DirectorListResponse {
   };
 }
 
+/**
+ * Keyword Model
+ * A tag on movies, stored lower-case
+ */
+export interface Keyword {
+  keyword_id: number;
+  keyword_name: string;
+}
+
+/**
+ * Keyword with movie count
+ */
+export interface KeywordWithCount extends Keyword {
+  movie_count: number;
+}
+
+/**
+ * Keyword List Response
+ * Paginated response for keyword list
+ */
+export interface KeywordListResponse {
+  data: KeywordWithCount[];
+  meta: {
+    page: number;
+    limit: number;
+    total: number;
+    pages: number;
+    hasNextPage: boolean;
+    hasPreviousPage: boolean;
+  };
+}
+
 /**
  * Actor Model
  * Represents a movie actor/actress
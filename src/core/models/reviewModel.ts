@@ -0,0 +1,31 @@
+/**
+ * Moderation state of a review; only approved reviews are public
+ */
+export type ReviewStatus = 'pending' | 'approved' | 'rejected';
+
+/**
+ * A reader's verdict on a review
+ */
+export type ReviewReaction = 'helpful' | 'unhelpful';
+
+/**
+ * A user's review of a movie
+ */
+export interface Review {
+  review_id: number;
+  movie_id: number;
+  user_id: number;
+  username?: string;
+  rating: number; // 1-10
+  body: string | null; // null in public lists when it contains spoilers and they weren't asked for
+  contains_spoilers: boolean;
+  spoiler_hidden?: boolean; // body withheld because it contains spoilers
+  helpful_count: number;
+  unhelpful_count: number;
+  status: ReviewStatus;
+  flagged_terms?: string[]; // What content filters matched; shown to moderators and the author
+  moderation_note?: string | null; // Reason given when rejected
+  moderated_at?: Date | null;
+  created_at: Date;
+  updated_at: Date;
+}
@@ -0,0 +1,45 @@
+import { FIXTURE_MOVIES, fixtureMovieRecords } from '../fixtures';
+
+describe('FIXTURE_MOVIES', () => {
+  it('has 100 movies', () => {
+    expect(FIXTURE_MOVIES).toHaveLength(100);
+  });
+
+  it('has no two movies with the same title', () => {
+    // The seed script finds movies that are already loaded by title
+    const titles = new Set(FIXTURE_MOVIES.map(movie => movie.title.toLowerCase()));
+    expect(titles.size).toBe(FIXTURE_MOVIES.length);
+  });
+
+  it('gives every movie a release date and at least one genre', () => {
+    for (const movie of FIXTURE_MOVIES) {
+      expect(movie.release_date).toMatch(/^\d{4}-\d{2}-\d{2}$/);
+      expect(movie.genres.length).toBeGreaterThan(0);
+    }
+  });
+});
+
+describe('fixtureMovieRecords', () => {
+  it('numbers movies from 1 with 12-character public IDs', () => {
+    const records = fixtureMovieRecords();
+    expect(records.map(record => record.movie_id)).toEqual(FIXTURE_MOVIES.map((_, i) => i + 1));
+    expect(records[0].public_id).toBe('fixture00001');
+    expect(new Set(records.map(record => record.public_id)).size).toBe(records.length);
+  });
+
+  it('gives the same actor the same ID in every movie', () => {
+    const ids = new Map<string, unknown>();
+    for (const record of fixtureMovieRecords()) {
+      const { cast } = record.included as { cast: { actor_id: number; actor_name: string }[] };
+      for (const member of cast) {
+        if (!ids.has(member.actor_name)) ids.set(member.actor_name, member.actor_id);
+        expect(member.actor_id).toBe(ids.get(member.actor_name));
+      }
+    }
+    expect(new Set(ids.values()).size).toBe(ids.size);
+  });
+
+  it('builds the records once', () => {
+    expect(fixtureMovieRecords()).toBe(fixtureMovieRecords());
+  });
+});
@@ -0,0 +1,127 @@
+import pool from '../database';
+import { getLoginDelaySeconds, reserveLoginAttempt, settleLoginAttempt } from '../loginThrottle';
+
+jest.mock('../database', () => ({
+  __esModule: true,
+  default: { connect: jest.fn(), query: jest.fn() }
+}));
+
+describe('getLoginDelaySeconds', () => {
+  it('allows the first three failures without waiting', () => {
+    expect(getLoginDelaySeconds(0)).toBe(0);
+    expect(getLoginDelaySeconds(2)).toBe(0);
+  });
+
+  it('doubles the wait after each further failure', () => {
+    expect(getLoginDelaySeconds(3)).toBe(1);
+    expect(getLoginDelaySeconds(4)).toBe(2);
+    expect(getLoginDelaySeconds(5)).toBe(4);
+    expect(getLoginDelaySeconds(9)).toBe(64);
+  });
+
+  it('caps the wait at five minutes', () => {
+    expect(getLoginDelaySeconds(12)).toBe(300);
+    expect(getLoginDelaySeconds(1000)).toBe(300);
+  });
+});
+
+/**
+ * A pooled client that answers each statement with the rows of the first
+ * fragment it contains
+ */
+const mockClient = (rows: Record<string, unknown[]>) => {
+  const client = {
+    query: jest.fn(async (sql: string) => {
+      const fragment = Object.keys(rows).find(key => sql.includes(key));
+      return { rows: fragment ? rows[fragment] : [] };
+    }),
+    release: jest.fn()
+  };
+  (pool.connect as jest.Mock).mockResolvedValue(client);
+  return client;
+};
+
+const statements = (client: ReturnType<typeof mockClient>): string[] =>
+  client.query.mock.calls.map(([sql]) => sql.trim());
+
+describe('reserveLoginAttempt', () => {
+  it('counts the attempt as a failure before the password is checked', async () => {
+    const client = mockClient({
+      'FROM login_attempts': [{ failures: 0, retry_after: null }],
+      'FROM users': [{ user_id: 7, failed_login_count: 1, locked_for: null, last_failed_ago: 30 }],
+      'INSERT INTO login_attempts': [{ attempt_id: '42' }]
+    });
+
+    await expect(reserveLoginAttempt('ana@example.com', '203.0.113.9')).resolves.toEqual({ allowed: true, attemptId: '42' });
+
+    const sql = statements(client);
+    expect(sql.find(statement => statement.includes('FROM users'))).toMatch(/FOR UPDATE$/);
+    const counted = sql.findIndex(statement => statement.includes('failed_login_count = failed_login_count + 1'));
+    expect(counted).toBeGreaterThan(0);
+    expect(counted).toBeLessThan(sql.indexOf('COMMIT'));
+    expect(client.release).toHaveBeenCalled();
+  });
+
+  it('rejects a locked account without counting the attempt', async () => {
+    const client = mockClient({
+      'FROM login_attempts': [{ failures: 0, retry_after: null }],
+      'FROM users': [{ user_id: 7, failed_login_count: 10, locked_for: 600, last_failed_ago: 5 }]
+    });
+
+    await expect(reserveLoginAttempt('ana@example.com', '203.0.113.9'))
+      .resolves.toEqual({ allowed: false, retryAfterSeconds: 600, reason: 'locked' });
+
+    const sql = statements(client);
+    expect(sql).toContain('ROLLBACK');
+    expect(sql.some(statement => /^(INSERT|UPDATE)/.test(statement))).toBe(false);
+  });
+
+  it('makes the caller wait out the backoff', async () => {
+    mockClient({
+      'FROM login_attempts': [{ failures: 5, retry_after: 600 }],
+      'FROM users': [{ user_id: 7, failed_login_count: 5, locked_for: null, last_failed_ago: 1 }]
+    });
+
+    await expect(reserveLoginAttempt('ana@example.com', '203.0.113.9'))
+      .resolves.toEqual({ allowed: false, retryAfterSeconds: 3, reason: 'delay' });
+  });
+
+  it('limits failures per IP address across accounts', async () => {
+    const client = mockClient({
+      'FROM login_attempts': [{ failures: 20, retry_after: 120 }]
+    });
+
+    await expect(reserveLoginAttempt('bo@example.com', '203.0.113.9'))
+      .resolves.toEqual({ allowed: false, retryAfterSeconds: 120, reason: 'ip' });
+    expect(statements(client).some(statement => statement.includes('FROM users'))).toBe(false);
+  });
+
+  it('rolls back and releases the client when a statement fails', async () => {
+    const client = mockClient({});
+    client.query.mockImplementation(async (sql: string) => {
+      if (sql.includes('FROM users')) throw new Error('connection lost');
+      return { rows: [{ failures: 0, retry_after: null }] };
+    });
+
+    await expect(reserveLoginAttempt('ana@example.com', null)).rejects.toThrow('connection lost');
+    expect(statements(client)).toContain('ROLLBACK');
+    expect(client.release).toHaveBeenCalled();
+  });
+});
+
+describe('settleLoginAttempt', () => {
+  beforeEach(() => {
+    (pool.query as jest.Mock).mockClear();
+  });
+
+  it('leaves a failed attempt counted', async () => {
+    await settleLoginAttempt('42', 7, false);
+    expect(pool.query).not.toHaveBeenCalled();
+  });
+
+  it('clears the failure count after a correct password', async () => {
+    await settleLoginAttempt('42', 7, true);
+    expect(pool.query).toHaveBeenCalledWith('UPDATE login_attempts SET succeeded = true WHERE attempt_id = $1', ['42']);
+    expect((pool.query as jest.Mock).mock.calls[1][1]).toEqual([7]);
+  });
+});
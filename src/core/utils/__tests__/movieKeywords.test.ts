@@ -0,0 +1,26 @@
+import { normalizeKeyword, parseKeywords } from '../movieKeywords';
+
+describe('normalizeKeyword', () => {
+  it('lower-cases and collapses whitespace', () => {
+    expect(normalizeKeyword('  Time   Travel ')).toBe('time travel');
+  });
+});
+
+describe('parseKeywords', () => {
+  it('splits a semicolon-separated string', () => {
+    expect(parseKeywords('heist; Time Travel ;dystopia')).toEqual(['heist', 'time travel', 'dystopia']);
+  });
+
+  it('normalizes a list', () => {
+    expect(parseKeywords(['Heist', ' heist ', 'Space  Opera'])).toEqual(['heist', 'space opera']);
+  });
+
+  it('drops blanks', () => {
+    expect(parseKeywords(';; ;heist;')).toEqual(['heist']);
+    expect(parseKeywords('')).toEqual([]);
+  });
+
+  it('keeps commas inside a keyword', () => {
+    expect(parseKeywords('good, bad and ugly')).toEqual(['good, bad and ugly']);
+  });
+});
@@ -0,0 +1,35 @@
+import { nameKey, nameMatchSql, uniqueNames } from '../nameMatching';
+
+describe('nameKey', () => {
+  it('ignores case, accents and extra whitespace', () => {
+    expect(nameKey('  WARNER   Bros. ')).toBe('warner bros.');
+    expect(nameKey('Pedro Almodóvar')).toBe(nameKey('pedro almodovar'));
+    expect(nameKey('Zoë\tKravitz')).toBe('zoe kravitz');
+  });
+
+  it('keeps letters that are not accented forms', () => {
+    expect(nameKey('Søren')).toBe('søren');
+    expect(nameKey('Straße')).toBe('straße');
+  });
+
+  it('leaves non-Latin names intact', () => {
+    expect(nameKey('黒澤 明')).toBe('黒澤 明');
+  });
+});
+
+describe('nameMatchSql', () => {
+  it('compares both sides with name_key', () => {
+    expect(nameMatchSql('a.actor_name', '$1')).toBe('name_key(a.actor_name) = name_key($1)');
+  });
+});
+
+describe('uniqueNames', () => {
+  it('drops repeats, keeping the first spelling', () => {
+    expect(uniqueNames(['Penélope Cruz', 'penelope cruz', 'Javier Bardem', 'PENÉLOPE  CRUZ']))
+      .toEqual(['Penélope Cruz', 'Javier Bardem']);
+  });
+
+  it('accepts any iterable', () => {
+    expect(uniqueNames(new Set(['A24', 'a24']))).toEqual(['A24']);
+  });
+});
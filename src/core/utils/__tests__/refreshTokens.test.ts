@@ -0,0 +1,134 @@
+import pool from '../database';
+import { issueRefreshToken, rotateRefreshToken } from '../refreshTokens';
+
+jest.mock('../database', () => ({
+  __esModule: true,
+  default: { connect: jest.fn(), query: jest.fn() }
+}));
+
+interface TokenRow {
+  token_id: number;
+  user_id: number;
+  token_hash: string;
+  family_id: string;
+  expires_at: Date;
+  used_at: Date | null;
+  revoked_at: Date | null;
+  replaced_by: number | null;
+}
+
+/**
+ * An in-memory refresh_tokens table answering the statements refreshTokens.ts runs
+ */
+const fakeDatabase = () => {
+  const tokens: TokenRow[] = [];
+
+  const query = jest.fn(async (sql: string, params: unknown[] = []) => {
+    if (sql.startsWith('INSERT INTO refresh_tokens')) {
+      const [userId, tokenHash, familyId, expiresAt] = params as [number, string, string, Date];
+      const row: TokenRow = {
+        token_id: tokens.length + 1,
+        user_id: userId,
+        token_hash: tokenHash,
+        family_id: familyId,
+        expires_at: expiresAt,
+        used_at: null,
+        revoked_at: null,
+        replaced_by: null
+      };
+      tokens.push(row);
+      return { rows: [{ token_id: row.token_id }] };
+    }
+    if (sql.includes('SET replaced_by')) {
+      tokens[(params[0] as number) - 1].replaced_by = params[1] as number;
+      return { rows: [] };
+    }
+    if (sql.includes('WHERE rt.token_hash = $1')) {
+      const row = tokens.find(token => token.token_hash === params[0]);
+      return {
+        rows: row
+          ? [{ ...row, expired: row.expires_at.getTime() < Date.now(), username: 'ana', role: 'user' }]
+          : []
+      };
+    }
+    if (sql.includes('SET revoked_at = NOW() WHERE family_id = $1')) {
+      tokens.filter(token => token.family_id === params[0] && !token.revoked_at)
+        .forEach(token => { token.revoked_at = new Date(); });
+      return { rows: [] };
+    }
+    if (sql.includes('SET used_at = NOW()')) {
+      tokens[(params[0] as number) - 1].used_at = new Date();
+      return { rows: [] };
+    }
+    return { rows: [] };
+  });
+
+  const client = { query, release: jest.fn() };
+  (pool.connect as jest.Mock).mockResolvedValue(client);
+  return { tokens, client };
+};
+
+describe('rotateRefreshToken', () => {
+  beforeEach(() => {
+    // Reuse is logged as a warning
+    jest.spyOn(console, 'warn').mockImplementation(() => undefined);
+  });
+
+  afterEach(() => {
+    jest.restoreAllMocks();
+  });
+
+  it('exchanges a token for a new one in the same family', async () => {
+    const { tokens, client } = fakeDatabase();
+    const first = await issueRefreshToken(client as never, 7);
+
+    const rotation = await rotateRefreshToken(first.token);
+
+    expect(rotation.status).toBe('rotated');
+    if (rotation.status !== 'rotated') return;
+    expect(rotation.user.user_id).toBe(7);
+    expect(rotation.refresh.token).not.toBe(first.token);
+    expect(tokens).toHaveLength(2);
+    expect(tokens[1].family_id).toBe(tokens[0].family_id);
+    expect(tokens[0].used_at).not.toBeNull();
+    expect(tokens[0].replaced_by).toBe(tokens[1].token_id);
+  });
+
+  it('stores only a hash of the token', async () => {
+    const { tokens, client } = fakeDatabase();
+    const issued = await issueRefreshToken(client as never, 7);
+
+    expect(tokens[0].token_hash).not.toBe(issued.token);
+    expect(tokens[0].token_hash).toMatch(/^[0-9a-f]{64}$/);
+  });
+
+  it('revokes the whole family when a used token is presented again', async () => {
+    const { tokens, client } = fakeDatabase();
+    const first = await issueRefreshToken(client as never, 7);
+    const rotation = await rotateRefreshToken(first.token);
+    if (rotation.status !== 'rotated') throw new Error('first rotation failed');
+
+    await expect(rotateRefreshToken(first.token)).resolves.toEqual({ status: 'reused' });
+    expect(tokens.every(token => token.revoked_at !== null)).toBe(true);
+
+    // The successor stolen along with it is dead too
+    await expect(rotateRefreshToken(rotation.refresh.token)).resolves.toEqual({ status: 'reused' });
+  });
+
+  it('leaves other logins alone', async () => {
+    const { tokens, client } = fakeDatabase();
+    const phone = await issueRefreshToken(client as never, 7);
+    const laptop = await issueRefreshToken(client as never, 7);
+    await rotateRefreshToken(phone.token);
+
+    await rotateRefreshToken(phone.token);
+
+    expect(tokens[1].revoked_at).toBeNull();
+    await expect(rotateRefreshToken(laptop.token)).resolves.toMatchObject({ status: 'rotated' });
+  });
+
+  it('rejects unknown tokens', async () => {
+    fakeDatabase();
+    await expect(rotateRefreshToken('not-a-token')).resolves.toEqual({ status: 'invalid' });
+  });
+});
@@ -0,0 +1,82 @@
+import { escapeHtml, escapeXml, sanitizeText, stripHtml } from '../sanitize';
+
+describe('stripHtml', () => {
+  it('removes script blocks with their contents', () => {
+    expect(stripHtml('Heist<script>alert(document.cookie)</script> movie')).toBe('Heist movie');
+    expect(stripHtml('<SCRIPT type="text/javascript">steal()</SCRIPT >Plot')).toBe('Plot');
+  });
+
+  it('removes an unterminated script tag', () => {
+    expect(stripHtml('Plot <script src=//evil.example/x.js')).not.toMatch(/<script/i);
+  });
+
+  it('drops links with javascript: URLs, keeping their text', () => {
+    expect(stripHtml('<a href="javascript:alert(1)">Read more</a>')).toBe('Read more');
+    expect(stripHtml('<a href="JaVaScRiPt:alert(1)">x</a>')).not.toMatch(/javascript:/i);
+  });
+
+  it('drops tags carrying event handler attributes', () => {
+    expect(stripHtml('<img src=x onerror="alert(1)">Poster')).toBe('Poster');
+    expect(stripHtml('<div onmouseover=alert(1)>Hover</div>')).toBe('Hover');
+    expect(stripHtml('<img src=x onerror=alert(1)')).not.toMatch(/<img/);
+  });
+
+  it('removes other executable elements', () => {
+    expect(stripHtml('<svg><script>alert(1)</script></svg>Title')).toBe('Title');
+    expect(stripHtml('<iframe src="javascript:alert(1)"></iframe>Title')).toBe('Title');
+  });
+
+  it('keeps text that only looks like markup', () => {
+    expect(stripHtml('Rated 4 < 5 stars')).toBe('Rated 4 < 5 stars');
+    expect(stripHtml('<p>One<br>line</p><p>Two</p>')).toBe('One\nline\n\nTwo');
+  });
+});
+
+describe('escapeHtml', () => {
+  it('escapes script tags so they show as text', () => {
+    expect(escapeHtml('<script>alert(1)</script>')).toBe('&lt;script&gt;alert(1)&lt;/script&gt;');
+  });
+
+  it('escapes quotes so text can\'t break out of an attribute', () => {
+    expect(escapeHtml('" onmouseover="alert(1)')).toBe('&quot; onmouseover=&quot;alert(1)');
+    expect(escapeHtml("' onfocus='alert(1)")).toBe('&#39; onfocus=&#39;alert(1)');
+  });
+
+  it('leaves existing entities alone', () => {
+    expect(escapeHtml('Tom &amp; Jerry & friends')).toBe('Tom &amp; Jerry &amp; friends');
+  });
+});
+
+describe('escapeXml', () => {
+  it('escapes every ampersand', () => {
+    expect(escapeXml('Tom &amp; Jerry')).toBe('Tom &amp;amp; Jerry');
+  });
+});
+
+describe('sanitizeText', () => {
+  const env = process.env;
+
+  afterEach(() => {
+    process.env = env;
+  });
+
+  it('strips by default', () => {
+    process.env = { ...env, HTML_SANITIZE: undefined };
+    expect(sanitizeText('<b onclick="alert(1)">Bold</b>')).toBe('Bold');
+  });
+
+  it('escapes with HTML_SANITIZE=escape', () => {
+    process.env = { ...env, HTML_SANITIZE: 'escape' };
+    expect(sanitizeText('<b>Bold</b>')).toBe('&lt;b&gt;Bold&lt;/b&gt;');
+  });
+
+  it('stores text as given with HTML_SANITIZE=off', () => {
+    process.env = { ...env, HTML_SANITIZE: 'off' };
+    expect(sanitizeText('<b>Bold</b>')).toBe('<b>Bold</b>');
+  });
+
+  it('passes null and undefined through', () => {
+    expect(sanitizeText(null)).toBeNull();
+    expect(sanitizeText(undefined)).toBeUndefined();
+  });
+});
@@ -0,0 +1,81 @@
+import { SqlFilter, addMovieFilters } from '../sqlFilter';
+
+describe('SqlFilter', () => {
+  it('numbers placeholders in the order values are added', () => {
+    const filter = new SqlFilter()
+      .add(p => `m.budget >= ${p}`, 1000)
+      .add(p => `m.title ILIKE ${p}`, '%alien%');
+    const limit = filter.param(20);
+
+    expect(filter.whereSql).toBe('WHERE m.budget >= $1 AND m.title ILIKE $2');
+    expect(limit).toBe('$3');
+    expect(filter.params).toEqual([1000, '%alien%', 20]);
+  });
+
+  it('keeps values out of the SQL text', () => {
+    const filter = new SqlFilter().add(p => `m.title = ${p}`, "'; DROP TABLE movies; --");
+
+    expect(filter.conditionSql).toBe('m.title = $1');
+    expect(filter.params).toEqual(["'; DROP TABLE movies; --"]);
+  });
+
+  it('adds raw conditions without parameters', () => {
+    const filter = new SqlFilter().addRaw('m.deleted_at IS NULL').add(p => `m.era = ${p}`, 'silent');
+
+    expect(filter.conditionSql).toBe('m.deleted_at IS NULL AND m.era = $1');
+    expect(filter.params).toEqual(['silent']);
+  });
+
+  it('is empty with no conditions', () => {
+    const filter = new SqlFilter();
+
+    expect(filter.isEmpty).toBe(true);
+    expect(filter.whereSql).toBe('');
+    expect(filter.conditionSql).toBe('TRUE');
+  });
+});
+
+describe('addMovieFilters', () => {
+  it('adds nothing when no criteria are set', () => {
+    const filter = addMovieFilters(new SqlFilter(), {});
+
+    expect(filter.isEmpty).toBe(true);
+    expect(filter.params).toEqual([]);
+  });
+
+  it('matches names as case-insensitive substrings', () => {
+    const filter = addMovieFilters(new SqlFilter(), { title: 'alien', actor: 'Weaver' });
+
+    expect(filter.conditionSql).toContain('m.title ILIKE $1');
+    expect(filter.conditionSql).toContain('LOWER(a2.actor_name) LIKE LOWER($2)');
+    expect(filter.params).toEqual(['%alien%', '%Weaver%']);
+  });
+
+  it('keeps zero-valued numeric criteria', () => {
+    const filter = addMovieFilters(new SqlFilter(), { minBudget: 0, maxRuntime: 0 });
+
+    expect(filter.conditionSql).toBe('m.budget >= $1 AND m.runtime_minutes <= $2');
+    expect(filter.params).toEqual([0, 0]);
+  });
+
+  it('requires every keyword', () => {
+    const filter = addMovieFilters(new SqlFilter(), { keywords: ['heist', 'time travel'] });
+
+    expect(filter.conditionSql).toContain('k2.keyword_name = ANY($1::text[])');
+    expect(filter.conditionSql).toMatch(/\) = 2$/);
+    expect(filter.params).toEqual([['heist', 'time travel']]);
+  });
+
+  it('ignores an empty keyword list', () => {
+    expect(addMovieFilters(new SqlFilter(), { keywords: [] }).isEmpty).toBe(true);
+  });
+
+  it('continues numbering after parameters already on the filter', () => {
+    const filter = new SqlFilter();
+    filter.param('caller');
+    addMovieFilters(filter, { year: 1979, era: 'new_hollywood' });
+
+    expect(filter.conditionSql).toBe('EXTRACT(YEAR FROM m.release_date) = $2 AND m.era = $3');
+    expect(filter.params).toEqual(['caller', 1979, 'new_hollywood']);
+  });
+});
@@ -0,0 +1,54 @@
+import { matchTotpStep } from '../twoFactor';
+
+// RFC 6238 appendix B: the ASCII secret "12345678901234567890"
+const SECRET = 'GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ';
+
+const at = (seconds: number) => jest.spyOn(Date, 'now').mockReturnValue(seconds * 1000);
+
+describe('matchTotpStep', () => {
+  afterEach(() => {
+    jest.restoreAllMocks();
+  });
+
+  it('matches the RFC 6238 test vectors', () => {
+    at(59);
+    expect(matchTotpStep(SECRET, '287082', null)).toBe(1);
+
+    at(1111111109);
+    expect(matchTotpStep(SECRET, '081804', null)).toBe(37037036);
+
+    at(1234567890);
+    expect(matchTotpStep(SECRET, '005924', null)).toBe(41152263);
+  });
+
+  it('accepts a code from one step either side of now', () => {
+    at(1111111109 + 30);
+    expect(matchTotpStep(SECRET, '081804', null)).toBe(37037036);
+
+    at(1111111109 - 30);
+    expect(matchTotpStep(SECRET, '081804', null)).toBe(37037036);
+  });
+
+  it('rejects a code from further away', () => {
+    at(1111111109 + 60);
+    expect(matchTotpStep(SECRET, '081804', null)).toBeNull();
+  });
+
+  it('rejects a step that was already used', () => {
+    at(1111111109);
+    expect(matchTotpStep(SECRET, '081804', 37037036)).toBeNull();
+    expect(matchTotpStep(SECRET, '081804', 37037035)).toBe(37037036);
+  });
+
+  it('rejects codes that are not six digits', () => {
+    at(59);
+    expect(matchTotpStep(SECRET, '28708', null)).toBeNull();
+    expect(matchTotpStep(SECRET, '2870820', null)).toBeNull();
+    expect(matchTotpStep(SECRET, '28708a', null)).toBeNull();
+  });
+
+  it('ignores case and spacing in the secret', () => {
+    at(59);
+    expect(matchTotpStep('gezd gnbv gy3t qojq gezd gnbv gy3t qojq', '287082', null)).toBe(1);
+  });
+});
@@ -0,0 +1,58 @@
+import { Pool, PoolClient } from 'pg';
+
+/**
+ * What a followed user did, as shown in GET /api/me/feed:
+ * - rating: published a review with a rating but no text
+ * - review: published a review with text
+ * - watchlist_add: added a movie to their watchlist
+ */
+export type ActivityType = 'rating' | 'review' | 'watchlist_add';
+
+/**
+ * Brings a review's feed entry in line with the review: one entry while it is
+ * approved, none otherwise. Call after any change to its status or text, with
+ * the transaction's client.
+ *
+ * @param db - Pool or the caller's transaction client
+ * @param reviewId - Review that changed
+ */
+export const syncReviewActivity = async (db: Pool | PoolClient, reviewId: number): Promise<void> => {
+  await db.query('DELETE FROM activities WHERE review_id = $1', [reviewId]);
+  await db.query(
+    `INSERT INTO activities (user_id, activity_type, movie_id, review_id)
+     SELECT user_id, CASE WHEN body IS NULL THEN 'rating' ELSE 'review' END, movie_id, review_id
+     FROM reviews
+     WHERE review_id = $1 AND status = 'approved'`,
+    [reviewId]
+  );
+};
+
+/**
+ * Records a watchlist addition for followers' feeds
+ */
+export const recordWatchlistActivity = async (
+  db: Pool | PoolClient,
+  userId: number,
+  movieId: number
+): Promise<void> => {
+  await db.query(
+    `INSERT INTO activities (user_id, activity_type, movie_id)
+     VALUES ($1, 'watchlist_add', $2)`,
+    [userId, movieId]
+  );
+};
+
+/**
+ * Drops the feed entry for a movie taken off a watchlist
+ */
+export const removeWatchlistActivity = async (
+  db: Pool | PoolClient,
+  userId: number,
+  movieId: number
+): Promise<void> => {
+  await db.query(
+    `DELETE FROM activities
+     WHERE user_id = $1 AND movie_id = $2 AND activity_type = 'watchlist_add'`,
+    [userId, movieId]
+  );
+};
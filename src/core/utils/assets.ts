@@ -0,0 +1,134 @@
+import crypto from 'crypto';
+import pool from './database';
+import { detectImage } from './images';
+import { tracedFetch } from './tracing';
+
+/**
+ * Content-addressable store for mirrored images (migration 034).
+ *
+ * Each image is stored once under the SHA-256 of its bytes, however many
+ * URLs it was fetched from, and served immutably from /api/assets/:hash.
+ * The mirror-assets job copies studio logos, which repeat across hundreds
+ * of movies and often live on several hosts.
+ */
+
+const MAX_ASSET_BYTES = 5 * 1024 * 1024;
+const FETCH_TIMEOUT_MS = 10_000;
+
+/**
+ * Source URLs mirrored per job run
+ */
+const MIRROR_BATCH_SIZE = 200;
+
+const RETRY_FAILED_AFTER = '7 days';
+
+export interface AssetInfo {
+  hash: string;
+  content_type: string;
+  byte_size: number;
+  width: number | null;
+  height: number | null;
+}
+
+/**
+ * SQL for the /api/assets path of a mirrored URL column, or NULL
+ */
+export const assetUrlSql = (column: string): string =>
+  `(SELECT '/api/assets/' || src.hash FROM asset_sources src WHERE src.source_url = ${column})`;
+
+/**
+ * Stores image bytes under their hash; storing the same bytes again is a no-op
+ *
+ * @throws When the bytes aren't a JPEG, PNG, GIF or WebP image
+ */
+export const storeAsset = async (data: Buffer): Promise<AssetInfo> => {
+  const info = detectImage(data);
+  if (!info) {
+    throw new Error('Not an image');
+  }
+
+  const asset: AssetInfo = {
+    hash: crypto.createHash('sha256').update(data).digest('hex'),
+    content_type: info.contentType,
+    byte_size: data.length,
+    width: info.width,
+    height: info.height
+  };
+
+  await pool.query(
+    `INSERT INTO assets (hash, content_type, byte_size, width, height, data)
+     VALUES ($1, $2, $3, $4, $5, $6)
+     ON CONFLICT (hash) DO NOTHING`,
+    [asset.hash, asset.content_type, asset.byte_size, asset.width, asset.height, data]
+  );
+  return asset;
+};
+
+/**
+ * Downloads a URL into the store and records where it came from. A failed
+ * download is recorded too, so it's retried only after RETRY_FAILED_AFTER.
+ *
+ * @returns The stored asset, or null if the download failed
+ */
+export const mirrorAsset = async (url: string): Promise<AssetInfo | null> => {
+  try {
+    const response = await tracedFetch(url, { signal: AbortSignal.timeout(FETCH_TIMEOUT_MS) });
+    if (!response.ok) {
+      throw new Error(`HTTP ${response.status}`);
+    }
+    if (Number(response.headers.get('content-length') ?? 0) > MAX_ASSET_BYTES) {
+      throw new Error('Image too large');
+    }
+    const data = Buffer.from(await response.arrayBuffer());
+    if (data.length > MAX_ASSET_BYTES) {
+      throw new Error('Image too large');
+    }
+
+    const asset = await storeAsset(data);
+    await pool.query(
+      `INSERT INTO asset_sources (source_url, hash) VALUES ($1, $2)
+       ON CONFLICT (source_url) DO UPDATE SET hash = EXCLUDED.hash, fetch_error = NULL, fetched_at = NOW()`,
+      [url, asset.hash]
+    );
+    return asset;
+  } catch (error) {
+    // Keep the last good copy if a re-fetch fails
+    await pool.query(
+      `INSERT INTO asset_sources (source_url, fetch_error) VALUES ($1, $2)
+       ON CONFLICT (source_url) DO UPDATE SET fetch_error = EXCLUDED.fetch_error, fetched_at = NOW()`,
+      [url, (error instanceof Error ? error.message : String(error)).slice(0, 200)]
+    );
+    return null;
+  }
+};
+
+/**
+ * Mirrors studio logos not stored yet (the mirror-assets job)
+ *
+ * @returns Summary for job_runs
+ */
+export const mirrorStudioLogos = async (): Promise<string> => {
+  const pending = await pool.query<{ url: string }>(
+    `SELECT DISTINCT s.logo_url AS url
+     FROM studios s
+     LEFT JOIN asset_sources src ON src.source_url = s.logo_url
+     WHERE s.logo_url IS NOT NULL
+       AND (src.source_url IS NULL
+            OR (src.hash IS NULL AND src.fetched_at < NOW() - $2::interval))
+     LIMIT $1`,
+    [MIRROR_BATCH_SIZE, RETRY_FAILED_AFTER]
+  );
+
+  const hashes = new Set<string>();
+  let failed = 0;
+  for (const { url } of pending.rows) {
+    const asset = await mirrorAsset(url);
+    if (asset) {
+      hashes.add(asset.hash);
+    } else {
+      failed++;
+    }
+  }
+
+  return `${pending.rows.length - failed} logos mirrored as ${hashes.size} distinct images, ${failed} failed`;
+};
@@ -0,0 +1,20 @@
+import { Pool, PoolClient } from 'pg';
+import { AuditEntry } from '@models/auditModel';
+
+/**
+ * Records an administrative action in the audit_log table.
+ * Pass the transaction's client so the entry commits or rolls back with the change.
+ */
+export const recordAudit = async (db: Pool | PoolClient, entry: AuditEntry): Promise<void> => {
+  await db.query(
+    `INSERT INTO audit_log (action, entity_type, entity_id, details, performed_by)
+     VALUES ($1, $2, $3, $4, $5)`,
+    [
+      entry.action,
+      entry.entity_type,
+      entry.entity_id,
+      entry.details ? JSON.stringify(entry.details) : null,
+      entry.performed_by ?? null
+    ]
+  );
+};
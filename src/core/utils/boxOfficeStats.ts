@@ -0,0 +1,57 @@
+import { Client } from 'pg';
+import { invalidateCache } from './cacheBus';
+
+/**
+ * How long one refresh may run. The shared pool gives up on queries after
+ * 4 seconds, far less than a full rebuild takes on a large catalog.
+ */
+const REFRESH_TIMEOUT_MS = 10 * 60_000;
+
+let refreshInFlight: Promise<void> | null = null;
+let refreshQueued = false;
+
+/**
+ * Rebuilds the box_office_monthly aggregates. CONCURRENTLY keeps the
+ * time-series endpoint readable while the refresh runs; it gets its own
+ * connection and timeout so it neither holds a pool client for minutes nor
+ * hits the pool's query timeout.
+ */
+export const refreshBoxOfficeStats = async (): Promise<void> => {
+  const client = new Client({
+    connectionString: process.env.DB_URL,
+    connectionTimeoutMillis: 2000,
+    statement_timeout: REFRESH_TIMEOUT_MS
+  });
+  await client.connect();
+  try {
+    await client.query('REFRESH MATERIALIZED VIEW CONCURRENTLY box_office_monthly');
+  } finally {
+    await client.end();
+  }
+  // Cached time series were built from the old aggregates
+  invalidateCache('responses');
+};
+
+/**
+ * Fire-and-forget refresh for after imports and bulk edits. Failures are only
+ * logged so they never fail the triggering request, and calls made while a
+ * refresh is running collapse into a single follow-up refresh.
+ */
+export const scheduleBoxOfficeRefresh = (): void => {
+  if (refreshInFlight) {
+    refreshQueued = true;
+    return;
+  }
+
+  refreshInFlight = refreshBoxOfficeStats()
+    .catch(error => {
+      console.error('Failed to refresh box office aggregates:', error);
+    })
+    .finally(() => {
+      refreshInFlight = null;
+      if (refreshQueued) {
+        refreshQueued = false;
+        scheduleBoxOfficeRefresh();
+      }
+    });
+};
@@ -0,0 +1,85 @@
+import crypto from 'crypto';
+import pool from './database';
+import { clearFeatureFlagCache } from './featureFlags';
+import { listenTo } from './notifications';
+import { responseCache } from './responseCache';
+import { clearRankingCache } from './searchRanking';
+
+/**
+ * Cache invalidation across API replicas over Postgres LISTEN/NOTIFY.
+ *
+ * Each replica keeps its own in-process caches. invalidateCache() clears one
+ * locally and NOTIFYs the others, which clear theirs when the message
+ * arrives. Replicas ignore their own messages. A replica that loses its
+ * listening connection clears everything once it reconnects, since it may
+ * have missed messages meanwhile. Set CACHE_BUS=off to run without it.
+ */
+
+const CHANNEL = 'api_cache_invalidation';
+
+/**
+ * Each cache the bus can clear
+ */
+const CACHES = {
+  responses: () => responseCache.clear(),
+  feature_flags: clearFeatureFlagCache,
+  search_ranking: clearRankingCache
+} as const;
+
+export type CacheName = keyof typeof CACHES;
+
+/**
+ * Tells this replica's messages apart from other replicas'
+ */
+const INSTANCE_ID = crypto.randomUUID();
+
+/**
+ * On unless CACHE_BUS=off
+ */
+const isCacheBusEnabled = (): boolean => process.env.CACHE_BUS !== 'off';
+
+const isCacheName = (name: unknown): name is CacheName =>
+  typeof name === 'string' && Object.prototype.hasOwnProperty.call(CACHES, name);
+
+const clearAll = (): void => {
+  Object.values(CACHES).forEach(clear => clear());
+};
+
+/**
+ * Clears a cache on this replica and tells the others to clear theirs. Only
+ * call it for changes the cache can have seen: catalog writes for
+ * 'responses' (see invalidateResponseCacheOnWrite), settings changes for the
+ * others. Every call is a NOTIFY. Broadcast failures are only logged; the
+ * other replicas' TTLs still apply.
+ */
+export const invalidateCache = (cache: CacheName): void => {
+  CACHES[cache]();
+  if (!isCacheBusEnabled()) return;
+
+  pool.query('SELECT pg_notify($1, $2)', [CHANNEL, JSON.stringify({ cache, from: INSTANCE_ID })])
+    .catch(error => console.error(`Failed to broadcast ${cache} cache invalidation:`, error));
+};
+
+const handleNotification = (payload: string | undefined): void => {
+  try {
+    const message = JSON.parse(payload ?? '');
+    if (message.from === INSTANCE_ID || !isCacheName(message.cache)) return;
+    CACHES[message.cache]();
+  } catch {
+    console.warn('Ignoring malformed cache invalidation message:', payload);
+  }
+};
+
+/**
+ * Starts listening for other replicas' invalidations, once startNotifications()
+ * connects
+ */
+export const startCacheBus = (): void => {
+  if (!isCacheBusEnabled()) return;
+
+  listenTo(CHANNEL, {
+    onMessage: handleNotification,
+    // Anything broadcast while we weren't listening was missed
+    onReconnect: clearAll
+  });
+};
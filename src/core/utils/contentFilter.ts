@@ -0,0 +1,57 @@
+/**
+ * How adult content is filtered (CONTENT_FILTER):
+ * - on: hidden unless the request passes include_adult=true (default)
+ * - strict: always hidden, include_adult is ignored
+ * - off: never hidden
+ */
+export type ContentFilterMode = 'on' | 'strict' | 'off';
+
+/**
+ * Ratings treated as adult even when the title isn't flagged
+ */
+const DEFAULT_RESTRICTED_RATINGS = ['NC-17', '18', '18+', '18A', 'R18+', 'R 18+', 'Κ-18'];
+
+/**
+ * Current filter mode
+ */
+export const getContentFilterMode = (): ContentFilterMode => {
+  const mode = process.env.CONTENT_FILTER?.toLowerCase();
+  return mode === 'strict' || mode === 'off' ? mode : 'on';
+};
+
+/**
+ * Restricted ratings, overridable with a comma-separated RESTRICTED_RATINGS
+ */
+export const getRestrictedRatings = (): string[] => {
+  const configured = process.env.RESTRICTED_RATINGS
+    ?.split(',')
+    .map(rating => rating.trim())
+    .filter(rating => rating.length > 0);
+  return configured && configured.length > 0 ? configured : DEFAULT_RESTRICTED_RATINGS;
+};
+
+/**
+ * Whether a request's results should exclude adult content
+ *
+ * @param includeAdult - The request's include_adult parameter
+ */
+export const shouldHideAdultContent = (includeAdult: boolean): boolean => {
+  const mode = getContentFilterMode();
+  if (mode === 'off') return false;
+  if (mode === 'strict') return true;
+  return !includeAdult;
+};
+
+/**
+ * SQL condition keeping only non-adult movies. The ratings come from server
+ * config rather than the request, so they are inlined as escaped literals and
+ * the caller's parameter numbering is left alone.
+ *
+ * @param alias - Alias of the movies table in the query
+ */
+export const nonAdultCondition = (alias = 'm'): string => {
+  const ratings = getRestrictedRatings()
+    .map(rating => `'${rating.replace(/'/g, "''")}'`)
+    .join(', ');
+  return `(NOT ${alias}.adult AND COALESCE(${alias}.mpa_rating, '') NOT IN (${ratings}))`;
+};
@@ -0,0 +1,27 @@
+import type { Request } from 'express';
+
+/**
+ * Parses a Cookie header into name/value pairs
+ */
+export const parseCookies = (header: string | undefined): Record<string, string> => {
+  const cookies: Record<string, string> = {};
+  for (const pair of (header ?? '').split(';')) {
+    const index = pair.indexOf('=');
+    if (index <= 0) continue;
+
+    const name = pair.slice(0, index).trim();
+    const value = pair.slice(index + 1).trim().replace(/^"(.*)"$/, '$1');
+    try {
+      cookies[name] = decodeURIComponent(value);
+    } catch {
+      cookies[name] = value;
+    }
+  }
+  return cookies;
+};
+
+/**
+ * A single cookie from the request, without needing cookie-parser
+ */
+export const getCookie = (req: Request, name: string): string | undefined =>
+  parseCookies(req.headers.cookie)[name];
@@ -0,0 +1,108 @@
+/**
+ * Approximate geographic centres ([longitude, latitude], GeoJSON order) for
+ * countries that commonly appear as studio countries. Keyed by ISO 3166-1
+ * alpha-2 code. Countries missing here get a null geometry in GeoJSON output.
+ */
+const COUNTRY_CENTROIDS: Record<string, [number, number]> = {
+  // Americas
+  US: [-98.6, 39.8],
+  CA: [-106.3, 56.1],
+  MX: [-102.6, 23.6],
+  CU: [-77.8, 21.5],
+  BR: [-51.9, -14.2],
+  AR: [-63.6, -38.4],
+  CL: [-71.5, -35.7],
+  CO: [-74.3, 4.6],
+  PE: [-75.0, -9.2],
+  VE: [-66.6, 6.4],
+
+  // Europe
+  GB: [-3.4, 55.4],
+  IE: [-8.2, 53.4],
+  FR: [2.2, 46.2],
+  DE: [10.5, 51.2],
+  IT: [12.6, 41.9],
+  ES: [-3.7, 40.5],
+  PT: [-8.2, 39.4],
+  NL: [5.3, 52.1],
+  BE: [4.5, 50.5],
+  LU: [6.1, 49.8],
+  CH: [8.2, 46.8],
+  AT: [14.6, 47.5],
+  DK: [9.5, 56.3],
+  SE: [18.6, 60.1],
+  NO: [8.5, 60.5],
+  FI: [25.7, 61.9],
+  IS: [-19.0, 64.9],
+  PL: [19.1, 51.9],
+  CZ: [15.5, 49.8],
+  HU: [19.5, 47.2],
+  RO: [25.0, 45.9],
+  GR: [21.8, 39.1],
+  TR: [35.2, 39.0],
+  RU: [105.3, 61.5],
+  UA: [31.2, 48.4],
+
+  // Middle East & Africa
+  IL: [34.9, 31.0],
+  IR: [53.7, 32.4],
+  AE: [53.8, 23.4],
+  EG: [30.8, 26.8],
+  MA: [-7.1, 31.8],
+  NG: [8.7, 9.1],
+  ZA: [22.9, -30.6],
+
+  // Asia & Oceania
+  IN: [79.0, 20.6],
+  PK: [69.3, 30.4],
+  CN: [104.2, 35.9],
+  HK: [114.1, 22.4],
+  TW: [121.0, 23.7],
+  JP: [138.3, 36.2],
+  KR: [127.8, 35.9],
+  TH: [101.0, 15.9],
+  VN: [108.3, 14.1],
+  PH: [121.8, 12.9],
+  MY: [102.0, 4.2],
+  SG: [103.8, 1.35],
+  ID: [113.9, -0.8],
+  AU: [133.8, -25.3],
+  NZ: [174.9, -40.9]
+};
+
+/**
+ * Non-ISO spellings seen in imported data
+ */
+export const COUNTRY_ALIASES: Record<string, string> = {
+  USA: 'US',
+  UK: 'GB',
+  GBR: 'GB',
+  FRA: 'FR',
+  DEU: 'DE',
+  CAN: 'CA',
+  JPN: 'JP',
+  KOR: 'KR',
+  CHN: 'CN',
+  IND: 'IN',
+  AUS: 'AU'
+};
+
+/**
+ * Resolves a stored studio country to its ISO alpha-2 code
+ *
+ * @param country - Country as stored on the studio
+ * @returns Upper-case alpha-2 code, or the cleaned input when unknown
+ */
+export const normalizeCountryCode = (country: string): string => {
+  const code = country.trim().toUpperCase();
+  return COUNTRY_ALIASES[code] ?? code;
+};
+
+/**
+ * Looks up a country's approximate centre for map markers
+ *
+ * @param country - ISO alpha-2 code (or a known alias)
+ * @returns [longitude, latitude], or null when the country isn't listed
+ */
+export const getCountryCentroid = (country: string): [number, number] | null =>
+  COUNTRY_CENTROIDS[normalizeCountryCode(country)] ?? null;
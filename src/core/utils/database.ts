@@ -1,8 +1,14 @@
-import { Pool } from 'pg';
+import { Pool, types } from 'pg';
 import dotenvx from '@dotenvx/dotenvx';
+import { instrumentClient } from './queryMetrics';
 
 // dotenvx.config();
 
+// DATE columns stay 'YYYY-MM-DD' strings. The default parser makes a Date at
+// local midnight, which serializes in UTC and lands on the previous day on
+// servers east of UTC.
+types.setTypeParser(types.builtins.DATE, (value: string) => value);
+
 const pool: Pool = new Pool({
   // user: process.env.DB_USER,
   // password: process.env.DB_PASSWORD,
@@ -14,6 +20,9 @@ const pool: Pool = new Pool({
   query_timeout: 4000,
 });
 
+// Time every query for the per-endpoint latency metrics and slow-query log
+pool.on('connect', instrumentClient);
+
 /**
  * Initialize the PostgreSQL connection pool.
  * Ensures the pool is created only once and is reused throughout the application.
@@ -0,0 +1,526 @@
+import crypto from 'crypto';
+import zlib from 'zlib';
+import { Client, PoolClient } from 'pg';
+import { ExportedMovie } from '@models/movieModel';
+import pool from './database';
+import { EXPORTED_MOVIE_SQL } from './movieExport';
+import { tracedFetch } from './tracing';
+
+/**
+ * Nightly snapshots of the whole catalog for other teams to bootstrap from
+ * (migration 041), listed by GET /api/datasets.
+ *
+ * Each snapshot is versioned by its UTC date and written twice, gzipped:
+ * movies-<date>.jsonl.gz holds one full record per line (the shape of GET
+ * /api/export/movies) and movies-<date>.csv.gz a flat table without cast and
+ * translations. The SHA-256 of each file is recorded so downloads can be
+ * checked. DATASET_STORE picks where the files go:
+ * - database (default): the dataset_files table, in chunks (migration 052)
+ * - s3: an S3-compatible bucket (S3_BUCKET, S3_REGION, S3_ACCESS_KEY_ID,
+ *   S3_SECRET_ACCESS_KEY; S3_ENDPOINT for MinIO, R2 and the like)
+ *
+ * From the second snapshot on, movies-<date>.diff.jsonl.gz lists what changed
+ * since the previous one (base_version), keyed by public_id, one operation
+ * per line:
+ *
+ *   {"op":"add","public_id":"...","record":{...}}
+ *   {"op":"update","public_id":"...","record":{...}}
+ *   {"op":"delete","public_id":"..."}
+ *
+ * so consumers can apply each day's diff instead of downloading everything.
+ * Changes are found by comparing each record's SHA-256 with the one it had in
+ * the previous snapshot (dataset_record_hashes, migration 042).
+ *
+ * The store also gets datasets/manifest.json listing every published file,
+ * so another instance can follow along from the bucket alone (npm run sync
+ * -- --snapshots <location>).
+ *
+ * Only the newest DATASET_KEEP snapshots (default 30) are kept. Other stores
+ * can be added with registerDatasetStore.
+ */
+
+export interface DatasetStore {
+  name: string;
+  put: (key: string, data: Buffer, contentType: string) => Promise<void>;
+  /** The file's bytes, or null if it's gone */
+  get: (key: string) => Promise<Buffer | null>;
+  delete: (key: string) => Promise<void>;
+}
+
+export type DatasetFormat = 'jsonl' | 'csv' | 'diff';
+
+/**
+ * A published file as listed in manifest.json
+ */
+export interface DatasetManifestEntry {
+  version: string;
+  format: DatasetFormat;
+  file_name: string;
+  byte_size: number;
+  sha256: string;
+  movie_count: number;
+  base_version: string | null;
+  change_count: number | null;
+  created_at: string;
+}
+
+/**
+ * Movies read per query while writing a snapshot
+ */
+const EXPORT_BATCH_SIZE = 500;
+
+/**
+ * Record hashes written per query when saving a snapshot's state
+ */
+const HASH_BATCH_SIZE = 5000;
+
+const KEY_PREFIX = 'datasets/';
+
+const CSV_COLUMNS = [
+  'movie_id', 'public_id', 'title', 'original_title', 'release_date', 'runtime_minutes',
+  'overview', 'budget', 'revenue', 'vote_count', 'vote_average', 'mpa_rating', 'adult',
+  'poster_url', 'backdrop_url', 'collection_name', 'genres', 'directors', 'producers',
+  'writers', 'composers', 'cinematographers', 'editors', 'keywords', 'spoken_languages',
+  'production_countries', 'studios', 'updated_at'
+] as const;
+
+/**
+ * Bytes per dataset_files row
+ */
+const DATABASE_CHUNK_SIZE = 8 * 1024 * 1024;
+
+/**
+ * How long one chunk may take to write or read. The shared pool gives up on
+ * queries after 4 seconds, too soon for megabytes of BYTEA on a slow link.
+ */
+const DATABASE_CHUNK_TIMEOUT_MS = 60_000;
+
+/**
+ * Runs fn on a connection of its own, so moving a large file neither holds a
+ * pool client for long nor hits the pool's query timeout
+ */
+const withFileClient = async <T>(fn: (client: Client) => Promise<T>): Promise<T> => {
+  const client = new Client({
+    connectionString: process.env.DB_URL,
+    connectionTimeoutMillis: 2000,
+    statement_timeout: DATABASE_CHUNK_TIMEOUT_MS
+  });
+  await client.connect();
+  try {
+    return await fn(client);
+  } finally {
+    await client.end();
+  }
+};
+
+const databaseStore: DatasetStore = {
+  name: 'database',
+  // Replaces all of the file's chunks at once, so readers never see half of it
+  put: (key, data) => withFileClient(async client => {
+    try {
+      await client.query('BEGIN');
+      await client.query('DELETE FROM dataset_files WHERE storage_key = $1', [key]);
+      for (let offset = 0, index = 0; offset < data.length || index === 0; offset += DATABASE_CHUNK_SIZE, index++) {
+        await client.query(
+          'INSERT INTO dataset_files (storage_key, chunk_index, data) VALUES ($1, $2, $3)',
+          [key, index, data.subarray(offset, offset + DATABASE_CHUNK_SIZE)]
+        );
+      }
+      await client.query('COMMIT');
+    } catch (error) {
+      await client.query('ROLLBACK');
+      throw error;
+    }
+  }),
+  // One chunk per query keeps each result small; the snapshot keeps a
+  // concurrent put from mixing old and new chunks
+  get: key => withFileClient(async client => {
+    const chunks: Buffer[] = [];
+    await client.query('BEGIN ISOLATION LEVEL REPEATABLE READ READ ONLY');
+    try {
+      for (;;) {
+        const result = await client.query<{ data: Buffer }>(
+          'SELECT data FROM dataset_files WHERE storage_key = $1 AND chunk_index = $2',
+          [key, chunks.length]
+        );
+        if (result.rows.length === 0) break;
+        chunks.push(result.rows[0].data);
+      }
+    } finally {
+      await client.query('COMMIT');
+    }
+    return chunks.length > 0 ? Buffer.concat(chunks) : null;
+  }),
+  delete: async key => {
+    await pool.query('DELETE FROM dataset_files WHERE storage_key = $1', [key]);
+  }
+};
+
+const sha256 = (data: string | Buffer): string => crypto.createHash('sha256').update(data).digest('hex');
+
+const hmac = (key: string | Buffer, data: string): Buffer => crypto.createHmac('sha256', key).update(data).digest();
+
+/**
+ * Sends an S3 request signed with AWS Signature Version 4 (path-style URLs)
+ */
+const s3Request = async (
+  method: 'GET' | 'PUT' | 'DELETE',
+  bucket: string,
+  key: string,
+  body?: Buffer,
+  contentType?: string
+) => {
+  const { S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY } = process.env;
+  if (!S3_ACCESS_KEY_ID || !S3_SECRET_ACCESS_KEY) {
+    throw new Error('S3 access needs S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY');
+  }
+  const region = process.env.S3_REGION || 'us-east-1';
+  const endpoint = (process.env.S3_ENDPOINT || `https://s3.${region}.amazonaws.com`).replace(/\/+$/, '');
+  const url = new URL(`${endpoint}/${bucket}/${key.split('/').map(encodeURIComponent).join('/')}`);
+
+  const amzDate = new Date().toISOString().replace(/[-:]|\.\d{3}/g, '');
+  const date = amzDate.slice(0, 8);
+  const scope = `${date}/${region}/s3/aws4_request`;
+
+  const headers: Record<string, string> = {
+    host: url.host,
+    'x-amz-content-sha256': sha256(body ?? ''),
+    'x-amz-date': amzDate,
+    ...(contentType ? { 'content-type': contentType } : {})
+  };
+  const names = Object.keys(headers).sort();
+  const canonicalRequest = [
+    method,
+    url.pathname,
+    '',
+    ...names.map(name => `${name}:${headers[name]}`),
+    '',
+    names.join(';'),
+    headers['x-amz-content-sha256']
+  ].join('\n');
+  const stringToSign = ['AWS4-HMAC-SHA256', amzDate, scope, sha256(canonicalRequest)].join('\n');
+
+  const signingKey = ['s3', 'aws4_request'].reduce(
+    (signed: Buffer, part) => hmac(signed, part),
+    hmac(hmac(`AWS4${S3_SECRET_ACCESS_KEY}`, date), region)
+  );
+  const signature = hmac(signingKey, stringToSign).toString('hex');
+
+  // fetch sets Host itself
+  const sent = Object.fromEntries(Object.entries(headers).filter(([name]) => name !== 'host'));
+  return tracedFetch(url, {
+    method,
+    headers: {
+      ...sent,
+      Authorization: `AWS4-HMAC-SHA256 Credential=${S3_ACCESS_KEY_ID}/${scope}, SignedHeaders=${names.join(';')}, Signature=${signature}`
+    },
+    body
+  });
+};
+
+/**
+ * Bucket for DATASET_STORE=s3
+ */
+const getS3Bucket = (): string => {
+  if (!process.env.S3_BUCKET) {
+    throw new Error('DATASET_STORE=s3 needs S3_BUCKET');
+  }
+  return process.env.S3_BUCKET;
+};
+
+/**
+ * Reads an object from any bucket the S3 credentials can read
+ *
+ * @returns Its bytes, or null if there's no such object
+ */
+export const getS3Object = async (bucket: string, key: string): Promise<Buffer | null> => {
+  const response = await s3Request('GET', bucket, key);
+  if (response.status === 404) return null;
+  if (!response.ok) {
+    throw new Error(`S3 GET ${bucket}/${key} failed with ${response.status}`);
+  }
+  return Buffer.from(await response.arrayBuffer());
+};
+
+const s3Store: DatasetStore = {
+  name: 's3',
+  put: async (key, data, contentType) => {
+    const response = await s3Request('PUT', getS3Bucket(), key, data, contentType);
+    if (!response.ok) {
+      throw new Error(`S3 PUT ${key} failed with ${response.status}: ${await response.text()}`);
+    }
+  },
+  get: key => getS3Object(getS3Bucket(), key),
+  delete: async key => {
+    const response = await s3Request('DELETE', getS3Bucket(), key);
+    if (!response.ok && response.status !== 404) {
+      throw new Error(`S3 DELETE ${key} failed with ${response.status}`);
+    }
+  }
+};
+
+const stores = new Map<string, DatasetStore>([databaseStore, s3Store].map(store => [store.name, store]));
+
+/**
+ * Adds (or replaces) a store that DATASET_STORE can name
+ */
+export const registerDatasetStore = (store: DatasetStore): void => {
+  stores.set(store.name, store);
+};
+
+/**
+ * A store by name; DATASET_STORE when none is given
+ */
+export const getDatasetStore = (name = process.env.DATASET_STORE || 'database'): DatasetStore => {
+  const store = stores.get(name);
+  if (!store) {
+    throw new Error(`Unknown DATASET_STORE "${name}"`);
+  }
+  return store;
+};
+
+const getKeepCount = (): number => parseInt(process.env.DATASET_KEEP ?? '', 10) || 30;
+
+const csvCell = (value: unknown): string => {
+  if (value === null || value === undefined) return '';
+  const text = value instanceof Date ? value.toISOString() : String(value);
+  return /[",\r\n]/.test(text) ? `"${text.replace(/"/g, '""')}"` : text;
+};
+
+/**
+ * A movie as a CSV row; lists are joined with "|"
+ */
+const toCsvRow = (movie: ExportedMovie): string => {
+  const flat: Record<string, unknown> = {
+    ...movie,
+    genres: movie.genres?.join('|'),
+    directors: movie.directors?.join('|'),
+    producers: movie.producers?.join('|'),
+    writers: movie.writers?.join('|'),
+    composers: movie.composers?.join('|'),
+    cinematographers: movie.cinematographers?.join('|'),
+    editors: movie.editors?.join('|'),
+    keywords: movie.keywords?.join('|'),
+    spoken_languages: movie.spoken_languages?.join('|'),
+    production_countries: movie.production_countries?.join('|'),
+    studios: movie.studios?.map(studio => studio.studio_name).join('|')
+  };
+  return CSV_COLUMNS.map(column => csvCell(flat[column])).join(',');
+};
+
+/**
+ * Collects what's written to a gzip stream
+ */
+const gzipWriter = () => {
+  const gzip = zlib.createGzip();
+  const chunks: Buffer[] = [];
+  gzip.on('data', (chunk: Buffer) => chunks.push(chunk));
+  const done = new Promise<Buffer>((resolve, reject) => {
+    gzip.on('end', () => resolve(Buffer.concat(chunks)));
+    gzip.on('error', reject);
+  });
+
+  return {
+    write: (text: string) => new Promise<void>(resolve => {
+      if (gzip.write(text)) resolve();
+      else gzip.once('drain', resolve);
+    }),
+    finish: () => {
+      gzip.end();
+      return done;
+    }
+  };
+};
+
+/**
+ * Deletes all but the newest snapshots
+ *
+ * @returns Files removed
+ */
+const pruneDatasetSnapshots = async (keep: number): Promise<number> => {
+  const old = await pool.query<{ snapshot_id: number; store: string; storage_key: string }>(
+    `SELECT snapshot_id, store, storage_key
+     FROM dataset_snapshots
+     WHERE version NOT IN (
+       SELECT DISTINCT version FROM dataset_snapshots ORDER BY version DESC LIMIT $1
+     )`,
+    [keep]
+  );
+
+  for (const file of old.rows) {
+    await getDatasetStore(file.store).delete(file.storage_key);
+    await pool.query('DELETE FROM dataset_snapshots WHERE snapshot_id = $1', [file.snapshot_id]);
+  }
+  return old.rows.length;
+};
+
+/**
+ * Rewrites manifest.json from the recorded snapshots, newest first
+ */
+const writeDatasetManifest = async (store: DatasetStore): Promise<void> => {
+  const result = await pool.query<DatasetManifestEntry>(
+    `SELECT TO_CHAR(version, 'YYYY-MM-DD') AS version, format, file_name, byte_size::float8 AS byte_size,
+            sha256, movie_count, TO_CHAR(base_version, 'YYYY-MM-DD') AS base_version, change_count,
+            created_at
+     FROM dataset_snapshots
+     WHERE store = $1
+     ORDER BY version DESC, format`,
+    [store.name]
+  );
+  const manifest = { generated_at: new Date().toISOString(), files: result.rows };
+  await store.put(`${KEY_PREFIX}manifest.json`, Buffer.from(JSON.stringify(manifest, null, 2)), 'application/json');
+};
+
+/**
+ * Each record's hash as of the last snapshot, or null before the first one
+ */
+const loadRecordHashes = async (): Promise<{ baseVersion: string; hashes: Map<string, string> } | null> => {
+  const base = await pool.query<{ version: string }>(
+    `SELECT TO_CHAR(MAX(version), 'YYYY-MM-DD') AS version FROM dataset_snapshots WHERE format = 'jsonl'`
+  );
+  if (!base.rows[0].version) {
+    return null;
+  }
+
+  const result = await pool.query<{ public_id: string; record_sha256: string }>(
+    'SELECT public_id, record_sha256 FROM dataset_record_hashes'
+  );
+  // Snapshots from before migration 042 have no hashes to compare against
+  if (result.rows.length === 0) {
+    return null;
+  }
+  return {
+    baseVersion: base.rows[0].version,
+    hashes: new Map(result.rows.map(row => [row.public_id, row.record_sha256]))
+  };
+};
+
+/**
+ * Replaces the stored hashes with the ones of the snapshot being recorded
+ */
+const saveRecordHashes = async (client: PoolClient, hashes: Map<string, string>): Promise<void> => {
+  const entries = [...hashes.entries()];
+
+  await client.query('DELETE FROM dataset_record_hashes');
+  for (let i = 0; i < entries.length; i += HASH_BATCH_SIZE) {
+    const batch = entries.slice(i, i + HASH_BATCH_SIZE);
+    await client.query(
+      `INSERT INTO dataset_record_hashes (public_id, record_sha256)
+       SELECT * FROM UNNEST($1::varchar[], $2::char(64)[])`,
+      [batch.map(([publicId]) => publicId), batch.map(([, hash]) => hash)]
+    );
+  }
+};
+
+/**
+ * Writes and records today's snapshot unless there already is one, then
+ * prunes old snapshots (the datasets job)
+ *
+ * @returns Summary for job_runs
+ */
+export const publishDatasetSnapshot = async (): Promise<string> => {
+  const store = getDatasetStore();
+  const version = new Date().toISOString().slice(0, 10);
+
+  const existing = await pool.query('SELECT 1 FROM dataset_snapshots WHERE version = $1', [version]);
+  if (existing.rows.length > 0) {
+    return `skipped: snapshot ${version} already published`;
+  }
+
+  const previous = await loadRecordHashes();
+  const hashes = new Map<string, string>();
+  let changeCount = 0;
+
+  const jsonl = gzipWriter();
+  const csv = gzipWriter();
+  const diff = gzipWriter();
+  await csv.write(`${CSV_COLUMNS.join(',')}\n`);
+
+  // Keyset pages so the catalog is never held in memory uncompressed
+  let movieCount = 0;
+  let lastId = 0;
+  for (;;) {
+    const page = await pool.query<ExportedMovie>(
+      `${EXPORTED_MOVIE_SQL}
+       WHERE m.movie_id > $1
+       ORDER BY m.movie_id
+       LIMIT $2`,
+      [lastId, EXPORT_BATCH_SIZE]
+    );
+    if (page.rows.length === 0) break;
+
+    for (const movie of page.rows) {
+      const line = JSON.stringify(movie);
+      await jsonl.write(`${line}\n`);
+      await csv.write(`${toCsvRow(movie)}\n`);
+
+      const publicId = movie.public_id!;
+      const hash = sha256(line);
+      hashes.set(publicId, hash);
+
+      const previousHash = previous?.hashes.get(publicId);
+      if (previous && previousHash !== hash) {
+        const op = previousHash === undefined ? 'add' : 'update';
+        await diff.write(`${JSON.stringify({ op, public_id: publicId, record: movie })}\n`);
+        changeCount++;
+      }
+    }
+    movieCount += page.rows.length;
+    lastId = page.rows[page.rows.length - 1].movie_id;
+  }
+
+  for (const publicId of previous?.hashes.keys() ?? []) {
+    if (!hashes.has(publicId)) {
+      await diff.write(`${JSON.stringify({ op: 'delete', public_id: publicId })}\n`);
+      changeCount++;
+    }
+  }
+
+  const files: [DatasetFormat, Buffer][] = [
+    ['jsonl', await jsonl.finish()],
+    ['csv', await csv.finish()]
+  ];
+  const diffData = await diff.finish();
+  if (previous) {
+    files.push(['diff', diffData]);
+  }
+
+  const stored = [];
+  for (const [format, data] of files) {
+    const fileName = `movies-${version}.${format === 'diff' ? 'diff.jsonl' : format}.gz`;
+    const key = `${KEY_PREFIX}${fileName}`;
+    await store.put(key, data, 'application/gzip');
+    stored.push({ format, fileName, key, data });
+  }
+
+  // The files and the hashes the next diff starts from are recorded together
+  const client = await pool.connect();
+  try {
+    await client.query('BEGIN');
+    for (const { format, fileName, key, data } of stored) {
+      await client.query(
+        `INSERT INTO dataset_snapshots
+           (version, format, file_name, store, storage_key, byte_size, sha256, movie_count, base_version, change_count)
+         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+        [
+          version, format, fileName, store.name, key, data.length, sha256(data), movieCount,
+          format === 'diff' ? previous!.baseVersion : null,
+          format === 'diff' ? changeCount : null
+        ]
+      );
+    }
+    await saveRecordHashes(client, hashes);
+    await client.query('COMMIT');
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+
+  const pruned = await pruneDatasetSnapshots(getKeepCount());
+  await writeDatasetManifest(store);
+  return `snapshot ${version}: ${movieCount} movies to ${store.name}` +
+    (previous ? `, ${changeCount} changes since ${previous.baseVersion}` : '') +
+    (pruned > 0 ? `, ${pruned} old files removed` : '');
+};
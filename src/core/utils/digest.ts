@@ -0,0 +1,152 @@
+import crypto from 'crypto';
+import pool from './database';
+import { isMailEnabled, sendMail } from './mailer';
+import { escapeHtml } from './sanitize';
+
+/**
+ * New-releases email digest. Subscribed users get the movies added to the
+ * catalog since their last digest that match their favorite genres; the
+ * digest job (see jobs.ts) runs daily and sends to whoever is due.
+ */
+export type DigestFrequency = 'daily' | 'weekly';
+
+const FREQUENCY_DAYS: Record<DigestFrequency, number> = {
+  daily: 1,
+  weekly: 7
+};
+
+/**
+ * Most movies listed in one digest
+ */
+const MAX_DIGEST_MOVIES = 20;
+
+interface DueSubscription {
+  user_id: number;
+  email: string;
+  username: string;
+  unsubscribe_token: string;
+  last_sent_at: Date;
+}
+
+interface DigestMovie {
+  movie_id: number;
+  public_id: string | null;
+  title: string;
+  release_date: string | null; // YYYY-MM-DD
+  genres: string[];
+}
+
+export const createUnsubscribeToken = (): string => crypto.randomBytes(24).toString('base64url');
+
+/**
+ * Base URL for links in emails, which are sent outside any request
+ */
+const getPublicBaseUrl = (): string =>
+  (process.env.PUBLIC_BASE_URL || `http://localhost:${process.env.SERVER_PORT || 4000}`).replace(/\/+$/, '');
+
+const getUnsubscribeUrl = (token: string): string =>
+  `${getPublicBaseUrl()}/api/digest/unsubscribe?token=${encodeURIComponent(token)}`;
+
+const formatMovie = (movie: DigestMovie): string => {
+  const year = movie.release_date ? ` (${movie.release_date.slice(0, 4)})` : '';
+  return `${movie.title}${year} - ${movie.genres.join(', ')}`;
+};
+
+const buildDigest = (subscription: DueSubscription, movies: DigestMovie[], more: boolean) => {
+  const unsubscribeUrl = getUnsubscribeUrl(subscription.unsubscribe_token);
+  const intro = `Hi ${subscription.username}, here's what was added to the catalog in your favorite genres:`;
+  const moreLine = more ? 'More matched too; search the catalog to see them all.' : null;
+
+  const text = [
+    intro,
+    '',
+    ...movies.map(movie => `- ${formatMovie(movie)}`),
+    ...(moreLine ? ['', moreLine] : []),
+    '',
+    `Unsubscribe: ${unsubscribeUrl}`
+  ].join('\n');
+
+  const html = [
+    `<p>${escapeHtml(intro)}</p>`,
+    '<ul>',
+    ...movies.map(movie => `<li>${escapeHtml(formatMovie(movie))}</li>`),
+    '</ul>',
+    ...(moreLine ? [`<p>${escapeHtml(moreLine)}</p>`] : []),
+    `<p><a href="${escapeHtml(unsubscribeUrl)}">Unsubscribe</a></p>`
+  ].join('\n');
+
+  return {
+    to: subscription.email,
+    subject: `${movies.length}${more ? '+' : ''} new ${movies.length === 1 && !more ? 'movie' : 'movies'} in your favorite genres`,
+    text,
+    html,
+    headers: {
+      'List-Unsubscribe': `<${unsubscribeUrl}>`,
+      'List-Unsubscribe-Post': 'List-Unsubscribe=One-Click'
+    }
+  };
+};
+
+/**
+ * Sends every digest that is due. A user with nothing new gets no email but
+ * is still moved forward; a send that fails is retried on the next run.
+ * Nothing is sent while email is disabled.
+ *
+ * @returns Summary for job_runs
+ */
+export const sendDigests = async (): Promise<string> => {
+  // Nobody is moved forward, so the digests go out once email is set up
+  if (!isMailEnabled()) {
+    return 'Email is disabled (MAILER is not set), no digests sent';
+  }
+
+  const runStartedAt = new Date();
+  // An hour's slack so a run that starts a little earlier than yesterday's
+  // still counts a day as passed
+  const due = await pool.query<DueSubscription>(
+    `SELECT s.user_id, u.email, u.username, s.unsubscribe_token, s.last_sent_at
+     FROM digest_subscriptions s
+     JOIN users u ON u.user_id = s.user_id
+     WHERE s.last_sent_at <= $1::timestamptz + INTERVAL '1 hour'
+             - (CASE s.frequency WHEN 'daily' THEN $2 ELSE $3 END) * INTERVAL '1 day'`,
+    [runStartedAt, FREQUENCY_DAYS.daily, FREQUENCY_DAYS.weekly]
+  );
+
+  let sent = 0;
+  let failed = 0;
+
+  for (const subscription of due.rows) {
+    try {
+      const movies = await pool.query<DigestMovie>(
+        `SELECT m.movie_id, m.public_id, m.title, m.release_date,
+                ARRAY_AGG(g.genre_name ORDER BY g.genre_name) AS genres
+         FROM movies m
+         JOIN movie_genres mg ON mg.movie_id = m.movie_id
+         JOIN user_favorite_genres f ON f.genre_id = mg.genre_id AND f.user_id = $1
+         JOIN genres g ON g.genre_id = mg.genre_id
+         WHERE m.added_at > $2 AND m.added_at <= $3
+         GROUP BY m.movie_id
+         ORDER BY m.popularity DESC NULLS LAST, m.added_at DESC
+         LIMIT $4`,
+        [subscription.user_id, subscription.last_sent_at, runStartedAt, MAX_DIGEST_MOVIES + 1]
+      );
+
+      if (movies.rows.length > 0) {
+        const more = movies.rows.length > MAX_DIGEST_MOVIES;
+        await sendMail(buildDigest(subscription, movies.rows.slice(0, MAX_DIGEST_MOVIES), more));
+        sent++;
+      }
+
+      await pool.query(
+        'UPDATE digest_subscriptions SET last_sent_at = $2 WHERE user_id = $1',
+        [subscription.user_id, runStartedAt]
+      );
+    } catch (error) {
+      failed++;
+      console.error(`Digest for user ${subscription.user_id} failed:`, error);
+    }
+  }
+
+  const summary = `${sent} digests sent, ${due.rows.length - sent - failed} with nothing new`;
+  return failed > 0 ? `${summary}, ${failed} failed` : summary;
+};
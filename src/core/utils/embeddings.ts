@@ -0,0 +1,160 @@
+import pool from './database';
+import { tracedFetch } from './tracing';
+
+/**
+ * Embedding vectors of movie overviews for semantic search (migration 040,
+ * pgvector). EMBEDDINGS picks the provider; semantic search is off when it's
+ * unset:
+ * - openai: OpenAI's embeddings API with OPENAI_API_KEY
+ *   (EMBEDDING_MODEL, default text-embedding-3-small)
+ * - ollama: a local Ollama server at OLLAMA_URL (default
+ *   http://localhost:11434; EMBEDDING_MODEL, default nomic-embed-text)
+ *
+ * Vectors from different models can't be compared, so each stored vector
+ * keeps its model and searches only use the current model's. Other providers
+ * can be added with registerEmbeddingProvider.
+ */
+
+export interface EmbeddingProvider {
+  name: string;
+  /** Model used when EMBEDDING_MODEL is unset */
+  defaultModel: string;
+  embed: (texts: string[], model: string) => Promise<number[][]>;
+}
+
+/**
+ * Overviews sent per provider request
+ */
+const EMBED_BATCH_SIZE = 64;
+
+const postJson = async <T>(url: string, body: unknown, headers: Record<string, string> = {}): Promise<T> => {
+  const response = await tracedFetch(url, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/json', ...headers },
+    body: JSON.stringify(body),
+    signal: AbortSignal.timeout(60_000)
+  });
+  if (!response.ok) {
+    throw new Error(`${url} answered ${response.status}: ${await response.text()}`);
+  }
+  return await response.json() as T;
+};
+
+const openAiProvider: EmbeddingProvider = {
+  name: 'openai',
+  defaultModel: 'text-embedding-3-small',
+  embed: async (texts, model) => {
+    if (!process.env.OPENAI_API_KEY) {
+      throw new Error('OPENAI_API_KEY is not set');
+    }
+    const result = await postJson<{ data: { index: number; embedding: number[] }[] }>(
+      'https://api.openai.com/v1/embeddings',
+      { model, input: texts },
+      { Authorization: `Bearer ${process.env.OPENAI_API_KEY}` }
+    );
+    return result.data
+      .sort((a, b) => a.index - b.index)
+      .map(item => item.embedding);
+  }
+};
+
+const ollamaProvider: EmbeddingProvider = {
+  name: 'ollama',
+  defaultModel: 'nomic-embed-text',
+  embed: async (texts, model) => {
+    const baseUrl = (process.env.OLLAMA_URL || 'http://localhost:11434').replace(/\/+$/, '');
+    const result = await postJson<{ embeddings: number[][] }>(`${baseUrl}/api/embed`, { model, input: texts });
+    return result.embeddings;
+  }
+};
+
+const providers = new Map<string, EmbeddingProvider>(
+  [openAiProvider, ollamaProvider].map(provider => [provider.name, provider])
+);
+
+/**
+ * Adds (or replaces) a provider that EMBEDDINGS can name
+ */
+export const registerEmbeddingProvider = (provider: EmbeddingProvider): void => {
+  providers.set(provider.name, provider);
+};
+
+/**
+ * The configured provider and model, or null when semantic search is off
+ */
+export const getEmbeddingConfig = (): { provider: EmbeddingProvider; model: string } | null => {
+  const name = process.env.EMBEDDINGS;
+  if (!name) {
+    return null;
+  }
+  const provider = providers.get(name);
+  if (!provider) {
+    throw new Error(`Unknown EMBEDDINGS provider "${name}"`);
+  }
+  return { provider, model: process.env.EMBEDDING_MODEL || provider.defaultModel };
+};
+
+/**
+ * pgvector's text form of a vector, e.g. [0.1,0.2]
+ */
+export const toVectorLiteral = (vector: number[]): string => `[${vector.join(',')}]`;
+
+/**
+ * Embeds one text with the configured provider (a search query)
+ */
+export const embedText = async (text: string): Promise<{ vector: number[]; model: string }> => {
+  const config = getEmbeddingConfig();
+  if (!config) {
+    throw new Error('EMBEDDINGS is not set');
+  }
+  const [vector] = await config.provider.embed([text], config.model);
+  return { vector, model: config.model };
+};
+
+/**
+ * Embeds overviews that have no vector for the current model yet, or whose
+ * text changed since (compared by MD5)
+ *
+ * @param all - Re-embed every overview
+ * @returns Summary for job_runs
+ */
+export const embedMovieOverviews = async (all = false): Promise<string> => {
+  const config = getEmbeddingConfig();
+  if (!config) {
+    return 'skipped: EMBEDDINGS is not set';
+  }
+
+  const pending = await pool.query<{ movie_id: number; overview: string; overview_md5: string }>(
+    `SELECT m.movie_id, m.overview, MD5(m.overview) AS overview_md5
+     FROM movies m
+     LEFT JOIN movie_embeddings e ON e.movie_id = m.movie_id
+     WHERE m.overview IS NOT NULL AND m.overview <> ''
+       AND ($1 OR e.movie_id IS NULL OR e.model <> $2 OR e.overview_md5 <> MD5(m.overview))
+     ORDER BY m.movie_id`,
+    [all, config.model]
+  );
+
+  let embedded = 0;
+  for (let i = 0; i < pending.rows.length; i += EMBED_BATCH_SIZE) {
+    const batch = pending.rows.slice(i, i + EMBED_BATCH_SIZE);
+    const vectors = await config.provider.embed(batch.map(row => row.overview), config.model);
+
+    await pool.query(
+      `INSERT INTO movie_embeddings (movie_id, model, overview_md5, embedding)
+       SELECT v.movie_id, v.model, v.md5, v.embedding::vector
+       FROM UNNEST($1::int[], $2::text[], $3::text[], $4::text[]) AS v(movie_id, model, md5, embedding)
+       ON CONFLICT (movie_id) DO UPDATE
+       SET model = EXCLUDED.model, overview_md5 = EXCLUDED.overview_md5,
+           embedding = EXCLUDED.embedding, updated_at = NOW()`,
+      [
+        batch.map(row => row.movie_id),
+        batch.map(() => config.model),
+        batch.map(row => row.overview_md5),
+        vectors.map(toVectorLiteral)
+      ]
+    );
+    embedded += batch.length;
+  }
+
+  return `${embedded} overviews embedded with ${config.provider.name}/${config.model}`;
+};
@@ -0,0 +1,107 @@
+import { omdbEnricher } from './omdb';
+import { tmdbEnricher } from './tmdb';
+
+/**
+ * Metadata providers for refreshing catalog fields (npm run enrich-metadata).
+ * ENRICHERS lists the providers to ask, in order (default: tmdb,omdb); those
+ * without an API key are skipped. Each field takes the first provider's value
+ * that isn't null, so a later provider only fills what earlier ones lack.
+ *
+ * Built in:
+ * - tmdb: The Movie Database, needs TMDB_API_KEY; supplies every field
+ * - omdb: OMDb (IMDb data), needs OMDB_API_KEY; overview, runtime, poster
+ *   and IMDb rating/votes
+ *
+ * Other sources can be added with registerEnricher.
+ */
+
+/**
+ * Catalog columns providers can supply
+ */
+export const METADATA_FIELDS = [
+  'original_title', 'overview', 'runtime_minutes', 'budget', 'revenue',
+  'vote_count', 'vote_average', 'poster_url', 'backdrop_url', 'trailer_url'
+] as const;
+
+export type MetadataField = typeof METADATA_FIELDS[number];
+
+/**
+ * Fields a provider doesn't know are null or missing
+ */
+export type MovieMetadata = Partial<Record<MetadataField, string | number | null>>;
+
+export interface Enricher {
+  name: string;
+  /** False when the provider's API key isn't set */
+  isConfigured: () => boolean;
+  /** Finds a movie by title and release year; null when there's no match */
+  lookup: (title: string, year: number | null) => Promise<MovieMetadata | null>;
+}
+
+export interface MetadataResult {
+  metadata: MovieMetadata;
+  /** Provider that supplied each field */
+  sources: Partial<Record<MetadataField, string>>;
+}
+
+const enrichers = new Map<string, Enricher>([tmdbEnricher, omdbEnricher].map(enricher => [enricher.name, enricher]));
+
+/**
+ * Adds (or replaces) a provider that ENRICHERS can name
+ */
+export const registerEnricher = (enricher: Enricher): void => {
+  enrichers.set(enricher.name, enricher);
+};
+
+/**
+ * Configured providers in order
+ *
+ * @param names - Providers to use instead of ENRICHERS
+ */
+export const getEnricherChain = (names?: string[]): Enricher[] => {
+  const requested = names ?? (process.env.ENRICHERS || 'tmdb,omdb').split(',').map(name => name.trim()).filter(Boolean);
+
+  return requested
+    .map(name => {
+      const enricher = enrichers.get(name);
+      if (!enricher) {
+        throw new Error(`Unknown enricher "${name}"; available: ${[...enrichers.keys()].join(', ')}`);
+      }
+      return enricher;
+    })
+    .filter(enricher => enricher.isConfigured());
+};
+
+/**
+ * Asks each provider in turn until every wanted field has a value
+ *
+ * @returns The merged metadata, or null if no provider found the movie
+ */
+export const lookupMetadata = async (
+  chain: Enricher[],
+  title: string,
+  year: number | null,
+  fields: readonly MetadataField[] = METADATA_FIELDS
+): Promise<MetadataResult | null> => {
+  const result: MetadataResult = { metadata: {}, sources: {} };
+  let found = false;
+
+  for (const enricher of chain) {
+    const missing = fields.filter(field => result.metadata[field] == null);
+    if (missing.length === 0) break;
+
+    const metadata = await enricher.lookup(title, year);
+    if (!metadata) continue;
+    found = true;
+
+    for (const field of missing) {
+      const value = metadata[field];
+      if (value != null) {
+        result.metadata[field] = value;
+        result.sources[field] = enricher.name;
+      }
+    }
+  }
+
+  return found ? result : null;
+};
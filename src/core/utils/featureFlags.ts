@@ -0,0 +1,117 @@
+import pool from './database';
+
+/**
+ * Every feature flag with its default and what it controls. Add new flags
+ * here; the admin endpoint refuses keys that aren't listed.
+ */
+export const FEATURE_FLAGS = {
+  enable_saved_searches: {
+    default: true,
+    description: 'Saved searches under /api/me/searches'
+  },
+  enable_actor_paths: {
+    default: true,
+    description: 'Degrees-of-separation search at /api/actors/:id/path-to/:otherId'
+  },
+  enable_recommendations: {
+    default: false,
+    description: 'Movie recommendations (in development)'
+  },
+  enable_reviews: {
+    default: false,
+    description: 'User reviews and ratings, with a moderation queue'
+  }
+} as const;
+
+export type FeatureFlag = keyof typeof FEATURE_FLAGS;
+
+export interface FeatureFlagState {
+  flag: FeatureFlag;
+  enabled: boolean;
+  source: 'env' | 'database' | 'default';
+  description: string;
+}
+
+/**
+ * How long database values are reused before being read again
+ */
+const CACHE_TTL_MS = 30_000;
+
+let databaseValues: Map<string, boolean> | null = null;
+let loadedAt = 0;
+
+export const isFeatureFlag = (key: string): key is FeatureFlag =>
+  Object.prototype.hasOwnProperty.call(FEATURE_FLAGS, key);
+
+/**
+ * Environment override, e.g. FEATURE_ENABLE_REVIEWS=true
+ */
+const getEnvOverride = (flag: FeatureFlag): boolean | undefined => {
+  const value = process.env[`FEATURE_${flag.toUpperCase()}`];
+  if (value === 'true' || value === '1') return true;
+  if (value === 'false' || value === '0') return false;
+  return undefined;
+};
+
+const loadDatabaseValues = async (): Promise<Map<string, boolean>> => {
+  if (databaseValues && Date.now() - loadedAt < CACHE_TTL_MS) {
+    return databaseValues;
+  }
+
+  try {
+    const result = await pool.query<{ flag_key: string; enabled: boolean }>(
+      'SELECT flag_key, enabled FROM feature_flags'
+    );
+    databaseValues = new Map(result.rows.map(row => [row.flag_key, row.enabled]));
+    loadedAt = Date.now();
+  } catch (error) {
+    // Keep serving the last known values (or defaults) if the table is unreachable
+    console.error('Failed to load feature flags:', error);
+    databaseValues ??= new Map();
+  }
+
+  return databaseValues;
+};
+
+/**
+ * Resolves every flag. An environment variable wins over the database row,
+ * which wins over the default, so an environment can pin a flag regardless
+ * of what is toggled at runtime.
+ */
+export const getFeatureFlags = async (): Promise<FeatureFlagState[]> => {
+  const stored = await loadDatabaseValues();
+
+  return (Object.keys(FEATURE_FLAGS) as FeatureFlag[]).map(flag => {
+    const envValue = getEnvOverride(flag);
+    const storedValue = stored.get(flag);
+
+    return {
+      flag,
+      enabled: envValue ?? storedValue ?? FEATURE_FLAGS[flag].default,
+      source: envValue !== undefined ? 'env' : storedValue !== undefined ? 'database' : 'default',
+      description: FEATURE_FLAGS[flag].description
+    };
+  });
+};
+
+/**
+ * Whether a feature is on for this deployment
+ *
+ * @param flag - Flag key from FEATURE_FLAGS
+ */
+export const isFeatureEnabled = async (flag: FeatureFlag): Promise<boolean> => {
+  const envValue = getEnvOverride(flag);
+  if (envValue !== undefined) return envValue;
+
+  const stored = await loadDatabaseValues();
+  return stored.get(flag) ?? FEATURE_FLAGS[flag].default;
+};
+
+/**
+ * Forgets cached database values so a toggle takes effect immediately on this
+ * instance (invalidateCache() also clears other
+ * replicas; without the cache bus they pick it up within CACHE_TTL_MS)
+ */
+export const clearFeatureFlagCache = (): void => {
+  databaseValues = null;
+};
@@ -0,0 +1,162 @@
+/**
+ * Per-field profiles of a set of records (null rate, detected type, distinct
+ * count, range and most common values), for spotting problem fields in data
+ * before it is imported.
+ *
+ * Fields are named by path: "title", "genres[]" for the entries of a list of
+ * names, "cast[].actor_name" for a field of the objects in a list. A list's
+ * own field ("genres", "cast") profiles the list lengths.
+ */
+
+export type ProfiledType = 'integer' | 'number' | 'boolean' | 'date' | 'string' | 'list' | 'object' | 'mixed' | 'empty';
+
+export interface FieldProfile {
+  field: string;
+  /** Values seen: one per record, or one per list entry for fields inside lists */
+  count: number;
+  /** Share of values that are null, missing or empty strings (0 to 1) */
+  null_rate: number;
+  type: ProfiledType;
+  distinct: number;
+  /** Smallest and largest value of numbers and dates, or list lengths */
+  min: number | string | null;
+  max: number | string | null;
+  /** Shortest and longest string */
+  min_length: number | null;
+  max_length: number | null;
+  top_values: { value: string | number | boolean; count: number }[];
+  /** Likely problems: mixed types, mostly empty, a single value */
+  warnings: string[];
+}
+
+export interface ProfileOptions {
+  /** Most common values listed per field (default 5) */
+  topValues?: number;
+}
+
+/**
+ * Share of empty values above which a field is flagged as mostly empty
+ */
+const MOSTLY_EMPTY_RATE = 0.5;
+
+const DATE_PATTERN = /^\d{4}-\d{2}-\d{2}$/;
+
+const isEmpty = (value: unknown): boolean => value === null || value === undefined || value === '';
+
+const typeOf = (value: unknown): ProfiledType => {
+  if (Array.isArray(value)) return 'list';
+  switch (typeof value) {
+    case 'number': return Number.isInteger(value) ? 'integer' : 'number';
+    case 'boolean': return 'boolean';
+    case 'string': return DATE_PATTERN.test(value) ? 'date' : 'string';
+    case 'object': return 'object';
+    default: return 'mixed';
+  }
+};
+
+/**
+ * One type for a field's values; integers mixed with decimals are numbers
+ * and dates mixed with other text are strings
+ */
+const detectType = (types: Set<ProfiledType>): ProfiledType => {
+  if (types.size === 0) return 'empty';
+  if (types.size === 1) return [...types][0];
+  if ([...types].every(type => type === 'integer' || type === 'number')) return 'number';
+  if ([...types].every(type => type === 'date' || type === 'string')) return 'string';
+  return 'mixed';
+};
+
+/**
+ * Adds every path of a value to the collected values, recursing into lists
+ * and the objects in them, and counts the objects found in each list
+ */
+const collect = (values: Map<string, unknown[]>, listObjects: Map<string, number>, path: string, value: unknown): void => {
+  if (!values.has(path)) values.set(path, []);
+  values.get(path)!.push(value);
+
+  if (Array.isArray(value)) {
+    for (const entry of value) {
+      if (entry !== null && typeof entry === 'object' && !Array.isArray(entry)) {
+        listObjects.set(path, (listObjects.get(path) ?? 0) + 1);
+        for (const [key, nested] of Object.entries(entry)) {
+          collect(values, listObjects, `${path}[].${key}`, nested);
+        }
+      } else {
+        collect(values, listObjects, `${path}[]`, entry);
+      }
+    }
+  } else if (value !== null && typeof value === 'object') {
+    for (const [key, nested] of Object.entries(value)) {
+      collect(values, listObjects, `${path}.${key}`, nested);
+    }
+  }
+};
+
+const profileField = (field: string, values: unknown[], topValues: number): FieldProfile => {
+  const present = values.filter(value => !isEmpty(value));
+  const types = new Set(present.map(typeOf));
+  const type = detectType(types);
+
+  const counts = new Map<string, { value: string | number | boolean; count: number }>();
+  for (const value of present) {
+    if (typeof value === 'object') continue;
+    const key = `${typeof value}:${String(value)}`;
+    const entry = counts.get(key) ?? { value: value as string | number | boolean, count: 0 };
+    entry.count++;
+    counts.set(key, entry);
+  }
+
+  const numbers = type === 'list'
+    ? present.map(value => (value as unknown[]).length)
+    : present.filter((value): value is number => typeof value === 'number');
+  const dates = present.filter((value): value is string => typeof value === 'string' && DATE_PATTERN.test(value)).sort();
+  const lengths = present.filter((value): value is string => typeof value === 'string').map(value => value.length);
+  const nullRate = values.length > 0 ? (values.length - present.length) / values.length : 0;
+
+  const warnings: string[] = [];
+  if (type === 'mixed') warnings.push(`mixed types: ${[...types].sort().join(', ')}`);
+  if (nullRate > MOSTLY_EMPTY_RATE) warnings.push('mostly empty');
+  if (present.length > 1 && counts.size === 1 && type !== 'list' && type !== 'object') warnings.push('single value');
+
+  const useNumbers = numbers.length > 0 && (type === 'integer' || type === 'number' || type === 'list');
+  const useDates = !useNumbers && dates.length > 0 && type === 'date';
+
+  return {
+    field,
+    count: values.length,
+    null_rate: Math.round(nullRate * 1000) / 1000,
+    type,
+    distinct: type === 'list' || type === 'object' ? 0 : counts.size,
+    min: useNumbers ? Math.min(...numbers) : useDates ? dates[0] : null,
+    max: useNumbers ? Math.max(...numbers) : useDates ? dates[dates.length - 1] : null,
+    min_length: lengths.length > 0 && type !== 'date' ? Math.min(...lengths) : null,
+    max_length: lengths.length > 0 && type !== 'date' ? Math.max(...lengths) : null,
+    top_values: [...counts.values()]
+      .sort((a, b) => b.count - a.count || String(a.value).localeCompare(String(b.value)))
+      .slice(0, topValues),
+    warnings
+  };
+};
+
+/**
+ * Profiles every field of the records, in the order fields first appear.
+ * A field missing from a record, or from an object in a list, counts as
+ * empty there.
+ */
+export const profileRecords = (records: Record<string, unknown>[], options: ProfileOptions = {}): FieldProfile[] => {
+  const values = new Map<string, unknown[]>();
+  const listObjects = new Map<string, number>();
+  const topLevel = [...new Set(records.flatMap(record => Object.keys(record)))];
+
+  for (const record of records) {
+    for (const field of topLevel) {
+      collect(values, listObjects, field, record[field]);
+    }
+  }
+
+  return [...values.entries()].map(([field, fieldValues]) => {
+    const list = field.includes('[].') ? field.slice(0, field.lastIndexOf('[].')) : null;
+    const missing = list !== null ? (listObjects.get(list) ?? 0) - fieldValues.length : 0;
+    return profileField(field, [...fieldValues, ...new Array(Math.max(0, missing)).fill(undefined)], options.topValues ?? 5);
+  });
+};
@@ -0,0 +1,97 @@
+import { MovieCreateInput } from '@models/movieModel';
+import fixture from '../../fixtures/movies.json';
+
+/**
+ * The fixture catalog in src/fixtures/movies.json: 100 made-up movies with
+ * genres, directors, producers, studios, cast, three collections and a few
+ * Spanish titles. It is compiled into the build, so every checkout has the
+ * same data: `npm run seed -- --fixture` loads it into a database for new
+ * developers, and the mock server answers movie requests from it.
+ *
+ * It also covers the awkward cases: non-Latin original titles, a silent-era
+ * release, a movie with no runtime, budget or votes yet, documentaries with
+ * no cast and uncredited cast members.
+ */
+
+export const FIXTURE_VERSION: number = fixture.version;
+
+export const FIXTURE_MOVIES = fixture.movies as readonly MovieCreateInput[];
+
+/**
+ * Public ID of a fixture movie as the mock server reports it (12 characters,
+ * like generated ones)
+ */
+const fixturePublicId = (movieId: number): string => `fixture${String(movieId).padStart(5, '0')}`;
+
+/**
+ * Gives names stable IDs in order of first appearance
+ */
+const idsFor = (names: Iterable<string>): Map<string, number> => {
+  const ids = new Map<string, number>();
+  for (const name of names) {
+    if (!ids.has(name)) ids.set(name, ids.size + 1);
+  }
+  return ids;
+};
+
+let records: Record<string, unknown>[] | null = null;
+
+/**
+ * The fixture movies shaped like GET /api/movies/{id} responses, with cast,
+ * genres and studios under `included`. movie_id is the position in the
+ * fixture (from 1); actor, genre and studio IDs are numbered the same way.
+ * A database seeded from the fixture assigns its own IDs.
+ */
+export const fixtureMovieRecords = (): Record<string, unknown>[] => {
+  if (records) return records;
+
+  const actorIds = idsFor(FIXTURE_MOVIES.flatMap(movie => (movie.cast ?? []).map(member => member.actor_name)));
+  const genreIds = idsFor(FIXTURE_MOVIES.flatMap(movie => movie.genres));
+  const studioIds = idsFor(FIXTURE_MOVIES.flatMap(movie => (movie.studios ?? []).map(studio => studio.studio_name)));
+
+  records = FIXTURE_MOVIES.map((movie, i) => {
+    const cast = (movie.cast ?? []).map(member => ({
+      actor_id: actorIds.get(member.actor_name),
+      actor_name: member.actor_name,
+      character_name: member.character_name ?? null,
+      character_names: member.character_name ? [member.character_name] : [],
+      actor_order: member.actor_order,
+      billing_order: member.billing_order ?? null,
+      credited: member.credited ?? true,
+      profile_url: null
+    }));
+
+    return {
+      movie_id: i + 1,
+      public_id: fixturePublicId(i + 1),
+      title: movie.title,
+      original_title: movie.original_title,
+      directors: (movie.directors ?? []).join(', '),
+      genres: movie.genres.join(', '),
+      release_date: movie.release_date,
+      runtime_minutes: movie.runtime_minutes ?? null,
+      overview: movie.overview,
+      budget: movie.budget ?? null,
+      revenue: movie.revenue ?? null,
+      vote_count: movie.vote_count ?? 0,
+      vote_average: movie.vote_average ?? null,
+      mpa_rating: movie.mpa_rating || null,
+      adult: movie.adult ?? false,
+      poster_url: null,
+      backdrop_url: null,
+      trailer_url: null,
+      included: {
+        cast,
+        cast_total: cast.length,
+        genres: movie.genres.map(name => ({ genre_id: genreIds.get(name), genre_name: name })),
+        studios: (movie.studios ?? []).map(studio => ({
+          studio_id: studioIds.get(studio.studio_name),
+          studio_name: studio.studio_name,
+          logo_url: null,
+          country: studio.country ?? null
+        }))
+      }
+    };
+  });
+  return records;
+};
@@ -1,17 +1,35 @@
+/**
+ * Values for a message's {name} placeholders, by name
+ */
+export type MessageParams = Record<string, string | number>;
+
 export interface ErrorResponse {
   statusCode: number;
   message: object | string;
+  /** Stable machine-readable code; the same whatever language message is in */
+  code?: string;
+  /** Values for the translated message's placeholders; not sent to clients */
+  params?: MessageParams;
   timestamp: string;
 }
 
 export class ApiError {
   /**
-   * Create a standardized error response
+   * Create a standardized error response. The code picks the message's
+   * translation, and params fill that translation's placeholders; without
+   * a code, one is filled in from the status when the response is sent.
    */
-  static createResponse(statusCode: number, message: object | string): ErrorResponse {
+  static createResponse(
+    statusCode: number,
+    message: object | string,
+    code?: string,
+    params?: MessageParams
+  ): ErrorResponse {
     return {
       statusCode,
       message,
+      ...(code ? { code } : {}),
+      ...(params ? { params } : {}),
       timestamp: new Date().toISOString(),
     };
   }
@@ -26,26 +44,42 @@ export class ApiError {
       stack: error instanceof Error ? error.stack : undefined,
     });
 
-    return this.createResponse(500, 'internal server error');
+    return this.createResponse(500, 'internal server error', 'INTERNAL_ERROR');
+  }
+
+  static badRequest(message?: object | string, code?: string, params?: MessageParams): ErrorResponse {
+    return message === undefined
+      ? this.createResponse(400, 'Bad request', 'BAD_REQUEST')
+      : this.createResponse(400, message, code, params);
   }
 
-  static badRequest(message: object | string = 'Bad request'): ErrorResponse {
-    return this.createResponse(400, message);
+  static unauthorized(message?: string, code?: string, params?: MessageParams): ErrorResponse {
+    return message === undefined
+      ? this.createResponse(401, 'Unauthorized', 'UNAUTHORIZED')
+      : this.createResponse(401, message, code, params);
   }
 
-  static unauthorized(message: string = 'Unauthorized'): ErrorResponse {
-    return this.createResponse(401, message);
+  static forbidden(message?: string, code?: string, params?: MessageParams): ErrorResponse {
+    return message === undefined
+      ? this.createResponse(403, 'Forbidden', 'FORBIDDEN')
+      : this.createResponse(403, message, code, params);
   }
 
-  static forbidden(message: string = 'Forbidden'): ErrorResponse {
-    return this.createResponse(403, message);
+  static conflict(message?: string, code?: string, params?: MessageParams): ErrorResponse {
+    return message === undefined
+      ? this.createResponse(400, 'Conflict', 'CONFLICT')
+      : this.createResponse(400, message, code, params);
   }
 
-  static conflict(message: string = 'Conflict'): ErrorResponse {
-    return this.createResponse(400, message);
+  static notFound(message?: string, code?: string, params?: MessageParams): ErrorResponse {
+    return message === undefined
+      ? this.createResponse(404, 'Not found', 'NOT_FOUND')
+      : this.createResponse(404, message, code, params);
   }
 
-  static notFound(message: string = 'Not found'): ErrorResponse {
-    return this.createResponse(404, message);
+  static tooManyRequests(message?: string, code?: string, params?: MessageParams): ErrorResponse {
+    return message === undefined
+      ? this.createResponse(429, 'Too many requests', 'RATE_LIMITED')
+      : this.createResponse(429, message, code, params);
   }
 }
\ No newline at end of file
@@ -1,11 +1,16 @@
 export enum HttpStatus {
     OK = 200,
     CREATED = 201,
+    ACCEPTED = 202,
+    NOT_MODIFIED = 304,
     BAD_REQUEST = 400,
     UNAUTHORIZED = 401,
     FORBIDDEN = 403,
     NOT_FOUND = 404,
+    METHOD_NOT_ALLOWED = 405,
     CONFLICT = 409,
     TOO_MANY_REQUESTS = 429,
     INTERNAL_SERVER_ERROR = 500,
+    NOT_IMPLEMENTED = 501,
+    SERVICE_UNAVAILABLE = 503,
 }
\ No newline at end of file
@@ -0,0 +1,203 @@
+import { ErrorResponse } from './httpError';
+
+/**
+ * Localized error messages. Clients pick a language with Accept-Language;
+ * the error's `code` stays the same in every language, so clients should
+ * branch on that and only show `message`.
+ *
+ * Handlers pass the code (and any values the message mentions) to ApiError
+ * along with the English message; codes without a translation are sent as
+ * written.
+ */
+export const SUPPORTED_LOCALES = ['en', 'es'] as const;
+
+export type Locale = typeof SUPPORTED_LOCALES[number];
+
+export const DEFAULT_LOCALE: Locale = 'en';
+
+/**
+ * Codes for errors sent without one, by HTTP status
+ */
+const STATUS_CODES: Record<number, string> = {
+  400: 'BAD_REQUEST',
+  401: 'UNAUTHORIZED',
+  403: 'FORBIDDEN',
+  404: 'NOT_FOUND',
+  405: 'METHOD_NOT_ALLOWED',
+  409: 'CONFLICT',
+  412: 'PRECONDITION_FAILED',
+  413: 'PAYLOAD_TOO_LARGE',
+  428: 'PRECONDITION_REQUIRED',
+  429: 'RATE_LIMITED',
+  500: 'INTERNAL_ERROR',
+  501: 'NOT_IMPLEMENTED',
+  502: 'UPSTREAM_ERROR',
+  503: 'SERVICE_UNAVAILABLE'
+};
+
+/**
+ * Translations by error code; {name} is filled from the error's params.
+ * English is whatever the handler wrote, so it has no entries here.
+ */
+const TRANSLATIONS: Record<string, Record<Exclude<Locale, 'en'>, string>> = {
+  // Server
+  INTERNAL_ERROR: { es: 'error interno del servidor' },
+  READ_ONLY_MODE: { es: 'Este servidor está en modo de solo lectura' },
+  FEATURE_DISABLED: { es: 'Esta función no está habilitada' },
+
+  // API keys
+  API_KEY_REQUIRED: { es: 'Se requiere una clave de API. Incluya el encabezado X-API-Key.' },
+  API_KEY_INVALID: { es: 'Clave de API no válida' },
+  API_KEY_REVOKED: { es: 'La clave de API ha sido revocada' },
+  API_KEY_EXPIRED: { es: 'La clave de API ha caducado' },
+  ADMIN_REQUIRED: { es: 'Se requieren privilegios de administrador' },
+  ADMIN_SIGN_IN_REQUIRED: { es: 'Las rutas de administración también requieren iniciar sesión como administrador' },
+  ADMIN_2FA_REQUIRED: { es: 'Las rutas de administración requieren un administrador con la autenticación en dos pasos activada' },
+  AUTHENTICATION_REQUIRED: { es: 'Se requiere autenticación. Envíe un token Bearer o el encabezado X-API-Key.' },
+
+  // Users and sign-in
+  SIGN_IN_REQUIRED: { es: 'Debe iniciar sesión.' },
+  INVALID_CREDENTIALS: { es: 'credenciales incorrectas' },
+  ACCOUNT_LOCKED: { es: 'cuenta bloqueada temporalmente tras varios intentos fallidos' },
+  LOGIN_RATE_LIMITED: { es: 'demasiados intentos fallidos, inténtelo más tarde' },
+  USER_EXISTS: { es: 'Ya existe un usuario con este correo o nombre de usuario' },
+  ACCOUNT_NOT_FOUND: { es: 'la cuenta ya no existe' },
+  REFRESH_TOKEN_REQUIRED: { es: 'se requiere un token de actualización' },
+  CSRF_TOKEN_INVALID: { es: 'Token CSRF ausente o no válido. Envíe el valor de la cookie {cookie} en el encabezado X-CSRF-Token.' },
+  TWO_FACTOR_CODE_INVALID: { es: 'código incorrecto' },
+  TWO_FACTOR_NOT_ENABLED: { es: 'la autenticación de dos factores no está activada' },
+  TWO_FACTOR_ALREADY_ENABLED: { es: 'la autenticación de dos factores ya está activada' },
+  MFA_TOKEN_REQUIRED: { es: 'inicie sesión o envíe un mfaToken válido' },
+  OAUTH_PROVIDER_UNAVAILABLE: { es: 'el inicio de sesión con "{provider}" no está disponible' },
+  USER_NOT_FOUND: { es: 'No se encontró el usuario con ID {id}' },
+  SELF_FOLLOW: { es: 'No puede seguirse a sí mismo' },
+  NOT_FOLLOWING: { es: 'No sigue a este usuario' },
+
+  // Movies
+  MOVIE_NOT_FOUND: { es: 'Película no encontrada' },
+  INVALID_MOVIE_ID: { es: 'ID de película no válido' },
+  NOT_ON_WATCHLIST: { es: 'La película no está en su lista de pendientes' },
+  NO_MOVIES_FOUND: { es: 'No se encontraron películas que coincidan con los criterios' },
+
+  // People, studios and collections
+  INVALID_ID: { es: 'El ID debe ser un número válido' },
+  NAME_REQUIRED: { es: 'El parámetro de consulta name es obligatorio' },
+  ACTOR_NOT_FOUND: { es: 'No se encontró el actor con ID {id}' },
+  DIRECTOR_NOT_FOUND: { es: 'No se encontró el director con ID {id}' },
+  STUDIO_NOT_FOUND: { es: 'No se encontró el estudio con ID {id}' },
+  COLLECTION_NOT_FOUND: { es: 'No se encontró la colección con ID {id}' },
+  SAVED_SEARCH_NOT_FOUND: { es: 'No se encontró la búsqueda guardada con ID {id}' },
+  REVIEW_NOT_FOUND: { es: 'No se encontró la reseña con ID {id}' },
+  OWN_REVIEW_REACTION: { es: 'No puede reaccionar a su propia reseña' },
+  LIST_NOT_FOUND: { es: 'Lista no encontrada' },
+  NOT_ON_LIST: { es: 'La película no está en esta lista' },
+  LIST_LIMIT: { es: 'Se alcanzó el límite de {max}' },
+  INVALID_LIST_ORDER: { es: 'movie_ids debe incluir cada película de la lista exactamente una vez' },
+  NOT_SUBSCRIBED: { es: 'No está suscrito al resumen' },
+  UNSUBSCRIBE_TOKEN_REQUIRED: { es: 'Se requiere el token para cancelar la suscripción' },
+  UNKNOWN_GENRE: { es: 'Género desconocido: {genres}' },
+  DEVICE_NOT_FOUND: { es: 'No se encontró el dispositivo con ID {id}' },
+  DEVICE_LIMIT: { es: 'Puede registrar como máximo {max} dispositivos' },
+  PUSH_NOT_CONFIGURED: { es: 'Las notificaciones push mediante {platform} no están configuradas en este servidor' },
+  CALENDAR_FEED_NOT_FOUND: { es: 'Calendario no encontrado' },
+  INVALID_PAGE: { es: 'La página debe ser un número positivo' },
+  SITEMAP_NOT_FOUND: { es: 'Mapa del sitio no encontrado' },
+  NOT_A_MOVIE_URL: { es: 'url no es un enlace a una película de este sitio' },
+  OEMBED_FORMAT_UNSUPPORTED: { es: 'Solo se admite format=json' },
+  SHORT_LINK_NOT_FOUND: { es: 'Enlace corto no encontrado' },
+  INVALID_ASSET_HASH: { es: 'El hash del recurso debe tener 64 dígitos hexadecimales' },
+  ASSET_NOT_FOUND: { es: 'Recurso no encontrado' },
+  IMPORT_BATCH_NOT_FOUND: { es: 'No se encontró el lote de importación con ID {id}' },
+  IMPORT_BATCH_NOT_PENDING: { es: 'El lote de importación ya está en estado {status}' },
+  IMPORT_MOVIE_FAILED: { es: 'La película {position} ("{title}") no se pudo importar; no se aprobó nada: {error}' },
+  NO_EARLIER_VERSION: { es: 'La película {id} no tiene una versión anterior' },
+  VERSION_NOT_FOUND: { es: 'No se encontró la versión {version} de la película {id}' },
+  SEMANTIC_SEARCH_DISABLED: { es: 'La búsqueda semántica no está habilitada' },
+  DATASET_NOT_FOUND: { es: 'No se encontró el archivo de datos {file}' },
+  OG_IMAGE_TYPE_INVALID: { es: 'El tipo de imagen debe ser uno de {types}' },
+  FAULT_INJECTED: { es: 'Fallo inyectado para pruebas (FAULT_INJECTION está activado)' },
+  SAVED_SEARCH_EXISTS: { es: 'Ya existe una búsqueda guardada llamada "{name}"' },
+  UNKNOWN_FEATURE_FLAG: { es: 'Indicador de función desconocido "{flag}"' },
+  JOB_NOT_FOUND: { es: 'Tarea desconocida "{name}"' },
+  JOB_RUNNING: { es: 'La tarea "{name}" ya se está ejecutando' },
+  UNKNOWN_RANKING_SETTING: { es: 'Parámetro de clasificación de búsqueda desconocido "{setting}"' },
+
+  // Defaults from ApiError
+  BAD_REQUEST: { es: 'Solicitud incorrecta' },
+  UNAUTHORIZED: { es: 'No autorizado' },
+  FORBIDDEN: { es: 'Prohibido' },
+  CONFLICT: { es: 'Conflicto' },
+  NOT_FOUND: { es: 'No encontrado' },
+  RATE_LIMITED: { es: 'Demasiadas solicitudes' }
+};
+
+/**
+ * Best supported locale for an Accept-Language header ("es-MX,es;q=0.9,en;q=0.8"),
+ * honoring q-values; the default when nothing matches
+ */
+export const negotiateLocale = (acceptLanguage: string | undefined): Locale => {
+  if (!acceptLanguage) return DEFAULT_LOCALE;
+
+  const ranges = acceptLanguage
+    .split(',')
+    .map((part, index) => {
+      const [tag, ...params] = part.trim().split(';');
+      const q = params.map(param => param.trim()).find(param => param.startsWith('q='));
+      return { language: tag.trim().toLowerCase().split('-')[0], q: q ? Number(q.slice(2)) : 1, index };
+    })
+    .filter(range => range.language && range.q > 0)
+    .sort((a, b) => b.q - a.q || a.index - b.index);
+
+  for (const range of ranges) {
+    if (range.language === '*') return DEFAULT_LOCALE;
+    const locale = SUPPORTED_LOCALES.find(supported => supported === range.language);
+    if (locale) return locale;
+  }
+  return DEFAULT_LOCALE;
+};
+
+/**
+ * Machine-readable code for an error response with no code of its own
+ */
+export const errorCodeForStatus = (status: number): string =>
+  STATUS_CODES[status] ?? (status >= 500 ? 'INTERNAL_ERROR' : 'BAD_REQUEST');
+
+/**
+ * Fills in an error response's code, translates its message and drops the
+ * placeholder values the translation needed
+ *
+ * @param body - Error response as a handler built it
+ * @param status - HTTP status it is being sent with
+ * @param locale - Language the client asked for
+ * @returns The response to send, and the language its message is actually in
+ */
+export const localizeError = (
+  body: ErrorResponse,
+  status: number,
+  locale: Locale
+): { body: ErrorResponse; language: Locale } => {
+  const { params, ...response } = body;
+
+  // Validation failures carry an issue list with their own per-issue codes
+  if (typeof response.message !== 'string') {
+    return {
+      body: { ...response, code: response.code ?? (Array.isArray(response.message) ? 'VALIDATION_FAILED' : errorCodeForStatus(status)) },
+      language: locale
+    };
+  }
+
+  const english = { body: { ...response, code: response.code ?? errorCodeForStatus(status) }, language: DEFAULT_LOCALE };
+  const template = locale !== 'en' && response.code ? TRANSLATIONS[response.code]?.[locale] : undefined;
+  if (!template) return english;
+
+  // A placeholder the handler didn't give a value for keeps the English text
+  let complete = true;
+  const message = template.replace(/\{(\w+)\}/g, (placeholder, name: string) => {
+    if (params?.[name] === undefined) {
+      complete = false;
+      return placeholder;
+    }
+    return String(params[name]);
+  });
+  return complete ? { body: { ...english.body, message }, language: locale } : english;
+};
@@ -0,0 +1,261 @@
+import zlib from 'zlib';
+import { GrayImage } from './images';
+
+/**
+ * A small RGB canvas for drawing images on the server without image
+ * libraries: filled rectangles and gradients, text in a built-in 5x7 pixel
+ * font (ASCII; accents are dropped, anything else becomes ?), and grayscale
+ * pictures pasted as two-color duotones. Output is PNG.
+ *
+ * Only what the Open Graph cards (see ogImages) and QR codes need; there is
+ * no anti-aliasing, so text is drawn at whole-pixel scales.
+ */
+
+export type Color = [number, number, number];
+
+/**
+ * Glyphs for ASCII 32-126: five columns each, bit 0 the top row
+ */
+const FONT_5X7 = [
+  0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x5f, 0x00, 0x00, 0x00, 0x07, 0x00, 0x07, 0x00, // space ! "
+  0x14, 0x7f, 0x14, 0x7f, 0x14, 0x24, 0x2a, 0x7f, 0x2a, 0x12, 0x23, 0x13, 0x08, 0x64, 0x62, // # $ %
+  0x36, 0x49, 0x55, 0x22, 0x50, 0x00, 0x05, 0x03, 0x00, 0x00, 0x00, 0x1c, 0x22, 0x41, 0x00, // & ' (
+  0x00, 0x41, 0x22, 0x1c, 0x00, 0x08, 0x2a, 0x1c, 0x2a, 0x08, 0x08, 0x08, 0x3e, 0x08, 0x08, // ) * +
+  0x00, 0x50, 0x30, 0x00, 0x00, 0x08, 0x08, 0x08, 0x08, 0x08, 0x00, 0x60, 0x60, 0x00, 0x00, // , - .
+  0x20, 0x10, 0x08, 0x04, 0x02, 0x3e, 0x51, 0x49, 0x45, 0x3e, 0x00, 0x42, 0x7f, 0x40, 0x00, // / 0 1
+  0x42, 0x61, 0x51, 0x49, 0x46, 0x21, 0x41, 0x45, 0x4b, 0x31, 0x18, 0x14, 0x12, 0x7f, 0x10, // 2 3 4
+  0x27, 0x45, 0x45, 0x45, 0x39, 0x3c, 0x4a, 0x49, 0x49, 0x30, 0x01, 0x71, 0x09, 0x05, 0x03, // 5 6 7
+  0x36, 0x49, 0x49, 0x49, 0x36, 0x06, 0x49, 0x49, 0x29, 0x1e, 0x00, 0x36, 0x36, 0x00, 0x00, // 8 9 :
+  0x00, 0x56, 0x36, 0x00, 0x00, 0x08, 0x14, 0x22, 0x41, 0x00, 0x14, 0x14, 0x14, 0x14, 0x14, // ; < =
+  0x00, 0x41, 0x22, 0x14, 0x08, 0x02, 0x01, 0x51, 0x09, 0x06, 0x32, 0x49, 0x79, 0x41, 0x3e, // > ? @
+  0x7e, 0x11, 0x11, 0x11, 0x7e, 0x7f, 0x49, 0x49, 0x49, 0x36, 0x3e, 0x41, 0x41, 0x41, 0x22, // A B C
+  0x7f, 0x41, 0x41, 0x22, 0x1c, 0x7f, 0x49, 0x49, 0x49, 0x41, 0x7f, 0x09, 0x09, 0x01, 0x01, // D E F
+  0x3e, 0x41, 0x41, 0x51, 0x32, 0x7f, 0x08, 0x08, 0x08, 0x7f, 0x00, 0x41, 0x7f, 0x41, 0x00, // G H I
+  0x20, 0x40, 0x41, 0x3f, 0x01, 0x7f, 0x08, 0x14, 0x22, 0x41, 0x7f, 0x40, 0x40, 0x40, 0x40, // J K L
+  0x7f, 0x02, 0x04, 0x02, 0x7f, 0x7f, 0x04, 0x08, 0x10, 0x7f, 0x3e, 0x41, 0x41, 0x41, 0x3e, // M N O
+  0x7f, 0x09, 0x09, 0x09, 0x06, 0x3e, 0x41, 0x51, 0x21, 0x5e, 0x7f, 0x09, 0x19, 0x29, 0x46, // P Q R
+  0x46, 0x49, 0x49, 0x49, 0x31, 0x01, 0x01, 0x7f, 0x01, 0x01, 0x3f, 0x40, 0x40, 0x40, 0x3f, // S T U
+  0x1f, 0x20, 0x40, 0x20, 0x1f, 0x7f, 0x20, 0x18, 0x20, 0x7f, 0x63, 0x14, 0x08, 0x14, 0x63, // V W X
+  0x03, 0x04, 0x78, 0x04, 0x03, 0x61, 0x51, 0x49, 0x45, 0x43, 0x00, 0x7f, 0x41, 0x41, 0x00, // Y Z [
+  0x02, 0x04, 0x08, 0x10, 0x20, 0x00, 0x41, 0x41, 0x7f, 0x00, 0x04, 0x02, 0x01, 0x02, 0x04, // \ ] ^
+  0x40, 0x40, 0x40, 0x40, 0x40, 0x00, 0x01, 0x02, 0x04, 0x00, 0x20, 0x54, 0x54, 0x54, 0x78, // _ ` a
+  0x7f, 0x48, 0x44, 0x44, 0x38, 0x38, 0x44, 0x44, 0x44, 0x20, 0x38, 0x44, 0x44, 0x48, 0x7f, // b c d
+  0x38, 0x54, 0x54, 0x54, 0x18, 0x08, 0x7e, 0x09, 0x01, 0x02, 0x08, 0x14, 0x54, 0x54, 0x3c, // e f g
+  0x7f, 0x08, 0x04, 0x04, 0x78, 0x00, 0x44, 0x7d, 0x40, 0x00, 0x20, 0x40, 0x44, 0x3d, 0x00, // h i j
+  0x00, 0x7f, 0x10, 0x28, 0x44, 0x00, 0x41, 0x7f, 0x40, 0x00, 0x7c, 0x04, 0x18, 0x04, 0x78, // k l m
+  0x7c, 0x08, 0x04, 0x04, 0x78, 0x38, 0x44, 0x44, 0x44, 0x38, 0x7c, 0x14, 0x14, 0x14, 0x08, // n o p
+  0x08, 0x14, 0x14, 0x18, 0x7c, 0x7c, 0x08, 0x04, 0x04, 0x08, 0x48, 0x54, 0x54, 0x54, 0x20, // q r s
+  0x04, 0x3f, 0x44, 0x40, 0x20, 0x3c, 0x40, 0x40, 0x20, 0x7c, 0x1c, 0x20, 0x40, 0x20, 0x1c, // t u v
+  0x3c, 0x40, 0x30, 0x40, 0x3c, 0x44, 0x28, 0x10, 0x28, 0x44, 0x0c, 0x50, 0x50, 0x50, 0x3c, // w x y
+  0x44, 0x64, 0x54, 0x4c, 0x44, 0x00, 0x08, 0x36, 0x41, 0x00, 0x00, 0x00, 0x7f, 0x00, 0x00, // z { |
+  0x00, 0x41, 0x36, 0x08, 0x00, 0x08, 0x04, 0x08, 0x10, 0x08                                // } ~
+];
+
+const GLYPH_WIDTH = 5;
+const GLYPH_HEIGHT = 7;
+
+/**
+ * Characters outside the font that have an obvious stand-in
+ */
+const CHARACTER_FALLBACKS: Record<string, string> = {
+  '★': '*', '·': '-', '•': '-', '–': '-', '—': '-', '‘': "'", '’': "'", '“': '"', '”': '"', '…': '...'
+};
+
+/**
+ * Rewrites text into the font's characters
+ */
+const toFontText = (text: string): string =>
+  [...text.normalize('NFD').replace(/[\u0300-\u036f]/g, '')]
+    .map(char => {
+      if (char >= ' ' && char <= '~') return char;
+      return CHARACTER_FALLBACKS[char] ?? '?';
+    })
+    .join('');
+
+/**
+ * Width in pixels of text drawn at a scale (one blank column between glyphs)
+ */
+export const textWidth = (text: string, scale: number): number => {
+  const length = toFontText(text).length;
+  return length === 0 ? 0 : (length * (GLYPH_WIDTH + 1) - 1) * scale;
+};
+
+/**
+ * Height in pixels of a line of text at a scale
+ */
+export const textHeight = (scale: number): number => GLYPH_HEIGHT * scale;
+
+/**
+ * Color from hue (degrees), saturation and lightness (0-1)
+ */
+export const hsl = (hue: number, saturation: number, lightness: number): Color => {
+  const chroma = (1 - Math.abs(2 * lightness - 1)) * saturation;
+  const section = ((hue % 360) + 360) % 360 / 60;
+  const x = chroma * (1 - Math.abs((section % 2) - 1));
+  const [r, g, b] = section < 1 ? [chroma, x, 0]
+    : section < 2 ? [x, chroma, 0]
+    : section < 3 ? [0, chroma, x]
+    : section < 4 ? [0, x, chroma]
+    : section < 5 ? [x, 0, chroma]
+    : [chroma, 0, x];
+  const m = lightness - chroma / 2;
+  return [r, g, b].map(channel => Math.round((channel + m) * 255)) as Color;
+};
+
+const mix = (a: Color, b: Color, amount: number): Color =>
+  a.map((channel, i) => Math.round(channel + (b[i] - channel) * amount)) as Color;
+
+/**
+ * RGB pixels; drawing outside the canvas is clipped
+ */
+export class Canvas {
+  readonly pixels: Uint8Array;
+
+  constructor(readonly width: number, readonly height: number, background: Color = [0, 0, 0]) {
+    this.pixels = new Uint8Array(width * height * 3);
+    this.fillRect(0, 0, width, height, background);
+  }
+
+  private setPixel(x: number, y: number, color: Color): void {
+    if (x < 0 || y < 0 || x >= this.width || y >= this.height) return;
+    this.pixels.set(color, (y * this.width + x) * 3);
+  }
+
+  fillRect(x: number, y: number, width: number, height: number, color: Color): void {
+    const left = Math.max(0, Math.round(x));
+    const top = Math.max(0, Math.round(y));
+    const right = Math.min(this.width, Math.round(x + width));
+    const bottom = Math.min(this.height, Math.round(y + height));
+    for (let py = top; py < bottom; py++) {
+      for (let px = left; px < right; px++) {
+        this.pixels.set(color, (py * this.width + px) * 3);
+      }
+    }
+  }
+
+  /**
+   * Fills the whole canvas with a top-to-bottom gradient
+   */
+  fillGradient(top: Color, bottom: Color): void {
+    for (let y = 0; y < this.height; y++) {
+      this.fillRect(0, y, this.width, 1, mix(top, bottom, y / Math.max(1, this.height - 1)));
+    }
+  }
+
+  /**
+   * Draws one line of text with its top-left corner at x, y
+   *
+   * @param scale - Pixels per font pixel (a scale of 4 draws 28px capitals)
+   * @returns Width drawn
+   */
+  drawText(text: string, x: number, y: number, scale: number, color: Color): number {
+    const chars = toFontText(text);
+    for (let i = 0; i < chars.length; i++) {
+      const glyph = (chars.charCodeAt(i) - 32) * GLYPH_WIDTH;
+      const left = x + i * (GLYPH_WIDTH + 1) * scale;
+      for (let column = 0; column < GLYPH_WIDTH; column++) {
+        const bits = FONT_5X7[glyph + column];
+        for (let row = 0; row < GLYPH_HEIGHT; row++) {
+          if (bits & (1 << row)) {
+            this.fillRect(left + column * scale, y + row * scale, scale, scale, color);
+          }
+        }
+      }
+    }
+    return textWidth(text, scale);
+  }
+
+  /**
+   * Pastes a grayscale picture into a box as a duotone (black maps to dark,
+   * white to light), cropped to fill the box and resampled bilinearly
+   */
+  drawGrayImage(image: GrayImage, x: number, y: number, width: number, height: number, dark: Color, light: Color): void {
+    // Crop the source to the box's aspect ratio, centered
+    const scale = Math.max(width / image.width, height / image.height);
+    const offsetX = (image.width - width / scale) / 2;
+    const offsetY = (image.height - height / scale) / 2;
+
+    const sample = (sx: number, sy: number): number => {
+      const cx = Math.min(image.width - 1, Math.max(0, sx));
+      const cy = Math.min(image.height - 1, Math.max(0, sy));
+      return image.pixels[Math.round(cy) * image.width + Math.round(cx)];
+    };
+
+    for (let py = 0; py < height; py++) {
+      const sy = offsetY + (py + 0.5) / scale - 0.5;
+      const y0 = Math.floor(sy);
+      const fy = sy - y0;
+      for (let px = 0; px < width; px++) {
+        const sx = offsetX + (px + 0.5) / scale - 0.5;
+        const x0 = Math.floor(sx);
+        const fx = sx - x0;
+        const gray =
+          sample(x0, y0) * (1 - fx) * (1 - fy) +
+          sample(x0 + 1, y0) * fx * (1 - fy) +
+          sample(x0, y0 + 1) * (1 - fx) * fy +
+          sample(x0 + 1, y0 + 1) * fx * fy;
+        this.setPixel(x + px, y + py, mix(dark, light, Math.min(1, Math.max(0, gray / 255))));
+      }
+    }
+  }
+
+  toPng(): Buffer {
+    return encodePng(this.width, this.height, this.pixels, 3);
+  }
+}
+
+// ============================================================================
+// PNG output
+// ============================================================================
+
+const CRC_TABLE = Array.from({ length: 256 }, (_, n) => {
+  let c = n;
+  for (let k = 0; k < 8; k++) c = c & 1 ? 0xedb88320 ^ (c >>> 1) : c >>> 1;
+  return c >>> 0;
+});
+
+const crc32 = (data: Buffer): number => {
+  let crc = 0xffffffff;
+  for (const byte of data) crc = CRC_TABLE[(crc ^ byte) & 0xff] ^ (crc >>> 8);
+  return (crc ^ 0xffffffff) >>> 0;
+};
+
+const pngChunk = (type: string, data: Buffer): Buffer => {
+  const length = Buffer.alloc(4);
+  length.writeUInt32BE(data.length);
+  const body = Buffer.concat([Buffer.from(type, 'ascii'), data]);
+  const crc = Buffer.alloc(4);
+  crc.writeUInt32BE(crc32(body));
+  return Buffer.concat([length, body, crc]);
+};
+
+/**
+ * Encodes 8-bit pixels as a PNG
+ *
+ * @param pixels - Rows top to bottom, one byte per channel
+ * @param channels - 1 for grayscale, 3 for RGB
+ */
+export const encodePng = (width: number, height: number, pixels: Uint8Array, channels: 1 | 3): Buffer => {
+  const stride = width * channels;
+  const raw = Buffer.alloc((stride + 1) * height);
+  for (let y = 0; y < height; y++) {
+    raw[y * (stride + 1)] = 0; // filter: none
+    raw.set(pixels.subarray(y * stride, (y + 1) * stride), y * (stride + 1) + 1);
+  }
+
+  const header = Buffer.alloc(13);
+  header.writeUInt32BE(width, 0);
+  header.writeUInt32BE(height, 4);
+  header[8] = 8; // bit depth
+  header[9] = channels === 3 ? 2 : 0; // truecolor or grayscale
+  header[10] = 0; // deflate
+  header[11] = 0; // adaptive filtering
+  header[12] = 0; // no interlace
+
+  return Buffer.concat([
+    Buffer.from([0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a]),
+    pngChunk('IHDR', header),
+    pngChunk('IDAT', zlib.deflateSync(raw)),
+    pngChunk('IEND', Buffer.alloc(0))
+  ]);
+};
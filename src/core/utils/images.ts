@@ -0,0 +1,573 @@
+import zlib from 'zlib';
+
+/**
+ * Image sniffing and perceptual hashing without image libraries.
+ *
+ * detectImage reads the type and pixel size from JPEG, PNG, GIF and WebP
+ * headers. perceptualHash computes a 64-bit difference hash (dHash) from a
+ * small grayscale version of the image: for JPEG that is the luma DC
+ * coefficients (an 1/8-scale picture that needs no IDCT, and which
+ * progressive files carry in their first scan), for PNG the decoded pixels.
+ * Two hashes a few bits apart are almost certainly the same photo, even
+ * across resizing and recompression.
+ */
+
+export interface ImageInfo {
+  contentType: 'image/jpeg' | 'image/png' | 'image/gif' | 'image/webp';
+  width: number;
+  height: number;
+}
+
+export interface GrayImage {
+  width: number;
+  height: number;
+  pixels: Float64Array;
+}
+
+// ============================================================================
+// Type and size
+// ============================================================================
+
+const PNG_SIGNATURE = Buffer.from([0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a]);
+
+/**
+ * JPEG frame headers that carry the image size (SOF0-15 except DHT, JPG and DAC)
+ */
+const isStartOfFrame = (marker: number): boolean =>
+  marker >= 0xc0 && marker <= 0xcf && marker !== 0xc4 && marker !== 0xc8 && marker !== 0xcc;
+
+const jpegSize = (data: Buffer): { width: number; height: number } | null => {
+  let offset = 2;
+  while (offset + 9 < data.length) {
+    if (data[offset] !== 0xff) return null;
+    const marker = data[offset + 1];
+    if (marker === 0xff) {
+      offset++;
+      continue;
+    }
+    if (isStartOfFrame(marker)) {
+      return { height: data.readUInt16BE(offset + 5), width: data.readUInt16BE(offset + 7) };
+    }
+    offset += 2 + data.readUInt16BE(offset + 2);
+  }
+  return null;
+};
+
+const webpSize = (data: Buffer): { width: number; height: number } | null => {
+  const chunk = data.toString('ascii', 12, 16);
+  if (chunk === 'VP8X' && data.length >= 30) {
+    return { width: data.readUIntLE(24, 3) + 1, height: data.readUIntLE(27, 3) + 1 };
+  }
+  if (chunk === 'VP8 ' && data.length >= 30) {
+    return { width: data.readUInt16LE(26) & 0x3fff, height: data.readUInt16LE(28) & 0x3fff };
+  }
+  if (chunk === 'VP8L' && data.length >= 25) {
+    const bits = data.readUInt32LE(21);
+    return { width: (bits & 0x3fff) + 1, height: ((bits >>> 14) & 0x3fff) + 1 };
+  }
+  return null;
+};
+
+/**
+ * Identifies an image from its bytes
+ *
+ * @returns Type and pixel size, or null for anything else (or a truncated header)
+ */
+export const detectImage = (data: Buffer): ImageInfo | null => {
+  if (data.length >= 4 && data[0] === 0xff && data[1] === 0xd8 && data[2] === 0xff) {
+    const size = jpegSize(data);
+    return size && { contentType: 'image/jpeg', ...size };
+  }
+  if (data.length >= 24 && data.subarray(0, 8).equals(PNG_SIGNATURE)) {
+    return { contentType: 'image/png', width: data.readUInt32BE(16), height: data.readUInt32BE(20) };
+  }
+  if (data.length >= 10 && /^GIF8[79]a$/.test(data.toString('ascii', 0, 6))) {
+    return { contentType: 'image/gif', width: data.readUInt16LE(6), height: data.readUInt16LE(8) };
+  }
+  if (data.length >= 16 && data.toString('ascii', 0, 4) === 'RIFF' && data.toString('ascii', 8, 12) === 'WEBP') {
+    const size = webpSize(data);
+    return size && { contentType: 'image/webp', ...size };
+  }
+  return null;
+};
+
+// ============================================================================
+// JPEG luma thumbnail
+// ============================================================================
+
+interface HuffmanTable {
+  maxCode: Int32Array;
+  valueOffset: Int32Array;
+  values: Uint8Array;
+}
+
+interface JpegComponent {
+  id: number;
+  h: number;
+  v: number;
+  quantTable: number;
+  blocksPerLine: number;
+  blocksPerColumn: number;
+  dc: Float64Array | null;
+  pred: number;
+}
+
+const buildHuffmanTable = (counts: Uint8Array, values: Uint8Array): HuffmanTable => {
+  const maxCode = new Int32Array(18).fill(-1);
+  const valueOffset = new Int32Array(17);
+  let code = 0;
+  let k = 0;
+  for (let length = 1; length <= 16; length++) {
+    valueOffset[length] = k - code;
+    code += counts[length - 1];
+    k += counts[length - 1];
+    if (counts[length - 1] > 0) maxCode[length] = code - 1;
+    code <<= 1;
+  }
+  maxCode[17] = 0x7fffffff;
+  return { maxCode, valueOffset, values };
+};
+
+class JpegBitReader {
+  private bitBuffer = 0;
+  private bitCount = 0;
+
+  constructor(private readonly data: Buffer, public offset: number) {}
+
+  private readBit(): number {
+    if (this.bitCount === 0) {
+      let byte = 0;
+      if (this.offset < this.data.length) {
+        byte = this.data[this.offset];
+        if (byte === 0xff) {
+          const next = this.data[this.offset + 1];
+          if (next === 0x00) {
+            this.offset += 2;
+          } else {
+            // A marker ends the data; pad with ones like the encoder did
+            byte = 0xff;
+          }
+        } else {
+          this.offset++;
+        }
+      }
+      this.bitBuffer = byte;
+      this.bitCount = 8;
+    }
+    this.bitCount--;
+    return (this.bitBuffer >>> this.bitCount) & 1;
+  }
+
+  receive(length: number): number {
+    let value = 0;
+    for (let i = 0; i < length; i++) value = (value << 1) | this.readBit();
+    return value;
+  }
+
+  receiveExtend(length: number): number {
+    if (length === 0) return 0;
+    const value = this.receive(length);
+    return value < 1 << (length - 1) ? value - (1 << length) + 1 : value;
+  }
+
+  decode(table: HuffmanTable): number {
+    let code = 0;
+    for (let length = 1; length <= 16; length++) {
+      code = (code << 1) | this.readBit();
+      if (code <= table.maxCode[length]) return table.values[table.valueOffset[length] + code];
+    }
+    throw new Error('Invalid Huffman code');
+  }
+
+  /**
+   * Skips to just after the RSTn marker at a restart interval boundary
+   */
+  restart(): void {
+    this.bitCount = 0;
+    while (this.offset + 1 < this.data.length) {
+      if (this.data[this.offset] === 0xff && this.data[this.offset + 1] >= 0xd0 && this.data[this.offset + 1] <= 0xd7) {
+        this.offset += 2;
+        return;
+      }
+      this.offset++;
+    }
+  }
+
+  /**
+   * Offset of the marker that follows the entropy-coded data
+   */
+  skipToMarker(): number {
+    let offset = this.offset;
+    while (offset + 1 < this.data.length) {
+      if (this.data[offset] === 0xff) {
+        const next = this.data[offset + 1];
+        if (next !== 0x00 && (next < 0xd0 || next > 0xd7) && next !== 0xff) return offset;
+      }
+      offset++;
+    }
+    return this.data.length;
+  }
+}
+
+/**
+ * The luma channel at 1/8 scale, from each 8x8 block's DC coefficient.
+ * Handles baseline and progressive Huffman JPEGs; null for anything else.
+ */
+const jpegLumaThumbnail = (data: Buffer): GrayImage | null => {
+  const quantTables: Uint16Array[] = [];
+  const dcTables: HuffmanTable[] = [];
+  const acTables: HuffmanTable[] = [];
+  let components: JpegComponent[] = [];
+  let width = 0;
+  let height = 0;
+  let progressive = false;
+  let maxH = 1;
+  let maxV = 1;
+  let mcusPerLine = 0;
+  let mcusPerColumn = 0;
+  let restartInterval = 0;
+  let lumaDone = false;
+
+  let offset = 2;
+  while (offset + 4 <= data.length && !lumaDone) {
+    if (data[offset] !== 0xff) return null;
+    const marker = data[offset + 1];
+    if (marker === 0xff) {
+      offset++;
+      continue;
+    }
+    if (marker === 0xd9) break;
+    const length = data.readUInt16BE(offset + 2);
+    const segment = data.subarray(offset + 4, offset + 2 + length);
+    offset += 2 + length;
+
+    switch (marker) {
+      case 0xdb: { // DQT
+        for (let p = 0; p < segment.length;) {
+          const precision = segment[p] >>> 4;
+          const id = segment[p] & 15;
+          const table = new Uint16Array(64);
+          for (let i = 0; i < 64; i++) {
+            table[i] = precision ? segment.readUInt16BE(p + 1 + i * 2) : segment[p + 1 + i];
+          }
+          quantTables[id] = table;
+          p += 1 + 64 * (precision ? 2 : 1);
+        }
+        break;
+      }
+      case 0xc4: { // DHT
+        for (let p = 0; p < segment.length;) {
+          const tableClass = segment[p] >>> 4;
+          const id = segment[p] & 15;
+          const counts = segment.subarray(p + 1, p + 17);
+          const total = counts.reduce((sum, count) => sum + count, 0);
+          const table = buildHuffmanTable(counts, segment.subarray(p + 17, p + 17 + total));
+          (tableClass === 0 ? dcTables : acTables)[id] = table;
+          p += 17 + total;
+        }
+        break;
+      }
+      case 0xdd: // DRI
+        restartInterval = segment.readUInt16BE(0);
+        break;
+      case 0xc0:
+      case 0xc1:
+      case 0xc2: { // SOF0/1 (baseline, extended) and SOF2 (progressive)
+        progressive = marker === 0xc2;
+        height = segment.readUInt16BE(1);
+        width = segment.readUInt16BE(3);
+        const count = segment[5];
+        components = [];
+        for (let i = 0; i < count; i++) {
+          components.push({
+            id: segment[6 + i * 3],
+            h: segment[7 + i * 3] >>> 4,
+            v: segment[7 + i * 3] & 15,
+            quantTable: segment[8 + i * 3],
+            blocksPerLine: 0,
+            blocksPerColumn: 0,
+            dc: null,
+            pred: 0
+          });
+        }
+        maxH = Math.max(...components.map(c => c.h));
+        maxV = Math.max(...components.map(c => c.v));
+        mcusPerLine = Math.ceil(width / (8 * maxH));
+        mcusPerColumn = Math.ceil(height / (8 * maxV));
+        for (const component of components) {
+          component.blocksPerLine = Math.ceil(Math.ceil((width * component.h) / maxH) / 8);
+          component.blocksPerColumn = Math.ceil(Math.ceil((height * component.v) / maxV) / 8);
+          // Sized for whole MCUs; interleaved scans code the padding blocks too
+          component.dc = new Float64Array(mcusPerLine * component.h * mcusPerColumn * component.v);
+        }
+        break;
+      }
+      case 0xda: { // SOS
+        if (components.length === 0) return null;
+        const scanComponents = Array.from({ length: segment[0] }, (_, i) => ({
+          component: components.find(c => c.id === segment[1 + i * 2])!,
+          dcTable: dcTables[segment[2 + i * 2] >>> 4],
+          acTable: acTables[segment[2 + i * 2] & 15]
+        }));
+        const p = 1 + segment[0] * 2;
+        const spectralStart = segment[p];
+        const approximationHigh = segment[p + 2] >>> 4;
+        const approximationLow = segment[p + 2] & 15;
+        const reader = new JpegBitReader(data, offset);
+
+        // Progressive files: only the first DC scan matters
+        const wanted = !progressive || (spectralStart === 0 && approximationHigh === 0);
+        if (wanted && scanComponents.some(s => !s.component || !s.dcTable)) return null;
+
+        if (wanted) {
+          scanComponents.forEach(s => { s.component.pred = 0; });
+
+          const decodeBlock = (s: typeof scanComponents[number], row: number, col: number) => {
+            const { component } = s;
+            component.pred += reader.receiveExtend(reader.decode(s.dcTable));
+            const lineLength = mcusPerLine * component.h;
+            component.dc![row * lineLength + col] = component.pred << approximationLow;
+
+            if (!progressive) {
+              for (let k = 1; k < 64;) {
+                const rs = reader.decode(s.acTable);
+                const run = rs >>> 4;
+                const size = rs & 15;
+                if (size === 0) {
+                  if (run !== 15) break;
+                  k += 16;
+                } else {
+                  k += run;
+                  reader.receive(size);
+                  k++;
+                }
+              }
+            }
+          };
+
+          const single = scanComponents.length === 1;
+          const totalUnits = single
+            ? scanComponents[0].component.blocksPerLine * scanComponents[0].component.blocksPerColumn
+            : mcusPerLine * mcusPerColumn;
+
+          for (let unit = 0; unit < totalUnits; unit++) {
+            if (restartInterval > 0 && unit > 0 && unit % restartInterval === 0) {
+              reader.restart();
+              scanComponents.forEach(s => { s.component.pred = 0; });
+            }
+            if (single) {
+              const s = scanComponents[0];
+              decodeBlock(s, Math.floor(unit / s.component.blocksPerLine), unit % s.component.blocksPerLine);
+            } else {
+              const mcuRow = Math.floor(unit / mcusPerLine);
+              const mcuCol = unit % mcusPerLine;
+              for (const s of scanComponents) {
+                for (let v = 0; v < s.component.v; v++) {
+                  for (let h = 0; h < s.component.h; h++) {
+                    decodeBlock(s, mcuRow * s.component.v + v, mcuCol * s.component.h + h);
+                  }
+                }
+              }
+            }
+          }
+
+          if (scanComponents.some(s => s.component === components[0])) lumaDone = true;
+        }
+
+        offset = reader.skipToMarker();
+        break;
+      }
+      default:
+        // Arithmetic coding and lossless frames aren't supported
+        if (isStartOfFrame(marker)) return null;
+    }
+  }
+
+  const luma = components[0];
+  if (!lumaDone || !luma?.dc) return null;
+
+  // Partial blocks at the right and bottom edges are mostly padding
+  const thumbWidth = Math.max(1, Math.floor(Math.ceil((width * luma.h) / maxH) / 8));
+  const thumbHeight = Math.max(1, Math.floor(Math.ceil((height * luma.v) / maxV) / 8));
+  const quant = quantTables[luma.quantTable]?.[0] ?? 1;
+  const lineLength = mcusPerLine * luma.h;
+  const pixels = new Float64Array(thumbWidth * thumbHeight);
+  for (let row = 0; row < thumbHeight; row++) {
+    for (let col = 0; col < thumbWidth; col++) {
+      pixels[row * thumbWidth + col] = luma.dc[row * lineLength + col] * quant;
+    }
+  }
+  return { width: thumbWidth, height: thumbHeight, pixels };
+};
+
+// ============================================================================
+// PNG grayscale
+// ============================================================================
+
+const PNG_CHANNELS: Record<number, number> = { 0: 1, 2: 3, 3: 1, 4: 2, 6: 4 };
+
+/**
+ * Decodes a non-interlaced 8-bit PNG to gray, over white where transparent
+ */
+const pngGray = (data: Buffer): GrayImage | null => {
+  const width = data.readUInt32BE(16);
+  const height = data.readUInt32BE(20);
+  const bitDepth = data[24];
+  const colorType = data[25];
+  const interlaced = data[28] !== 0;
+  const channels = PNG_CHANNELS[colorType];
+  if (bitDepth !== 8 || interlaced || !channels || width === 0 || height === 0) return null;
+
+  const idat: Buffer[] = [];
+  let palette: Buffer | null = null;
+  let transparency: Buffer | null = null;
+  for (let offset = 8; offset + 8 <= data.length;) {
+    const length = data.readUInt32BE(offset);
+    const type = data.toString('ascii', offset + 4, offset + 8);
+    const body = data.subarray(offset + 8, offset + 8 + length);
+    if (type === 'IDAT') idat.push(body);
+    if (type === 'PLTE') palette = body;
+    if (type === 'tRNS') transparency = body;
+    if (type === 'IEND') break;
+    offset += 12 + length;
+  }
+  if (colorType === 3 && !palette) return null;
+
+  const raw = zlib.inflateSync(Buffer.concat(idat));
+  const stride = width * channels;
+  if (raw.length < (stride + 1) * height) return null;
+
+  const current = Buffer.alloc(stride);
+  const previous = Buffer.alloc(stride);
+  const pixels = new Float64Array(width * height);
+
+  for (let y = 0; y < height; y++) {
+    const filter = raw[y * (stride + 1)];
+    const line = raw.subarray(y * (stride + 1) + 1, (y + 1) * (stride + 1));
+    for (let i = 0; i < stride; i++) {
+      const left = i >= channels ? current[i - channels] : 0;
+      const up = previous[i];
+      const upLeft = i >= channels ? previous[i - channels] : 0;
+      let predictor = 0;
+      switch (filter) {
+        case 1: predictor = left; break;
+        case 2: predictor = up; break;
+        case 3: predictor = (left + up) >>> 1; break;
+        case 4: {
+          const estimate = left + up - upLeft;
+          const distanceLeft = Math.abs(estimate - left);
+          const distanceUp = Math.abs(estimate - up);
+          const distanceUpLeft = Math.abs(estimate - upLeft);
+          predictor = distanceLeft <= distanceUp && distanceLeft <= distanceUpLeft
+            ? left
+            : distanceUp <= distanceUpLeft ? up : upLeft;
+          break;
+        }
+      }
+      current[i] = (line[i] + predictor) & 0xff;
+    }
+
+    for (let x = 0; x < width; x++) {
+      const p = x * channels;
+      let gray: number;
+      let alpha = 255;
+      switch (colorType) {
+        case 0: gray = current[p]; break;
+        case 2: gray = 0.299 * current[p] + 0.587 * current[p + 1] + 0.114 * current[p + 2]; break;
+        case 3: {
+          const index = current[p] * 3;
+          gray = 0.299 * palette![index] + 0.587 * palette![index + 1] + 0.114 * palette![index + 2];
+          if (transparency && current[p] < transparency.length) alpha = transparency[current[p]];
+          break;
+        }
+        case 4: gray = current[p]; alpha = current[p + 1]; break;
+        default: gray = 0.299 * current[p] + 0.587 * current[p + 1] + 0.114 * current[p + 2]; alpha = current[p + 3];
+      }
+      pixels[y * width + x] = (gray * alpha + 255 * (255 - alpha)) / 255;
+    }
+    current.copy(previous);
+  }
+
+  return { width, height, pixels };
+};
+
+/**
+ * A small grayscale version of an image: JPEGs at 1/8 scale, PNGs at full size
+ *
+ * @returns null for other types, and for files that fail to decode
+ */
+export const decodeGray = (data: Buffer): GrayImage | null => {
+  const info = detectImage(data);
+  try {
+    if (info?.contentType === 'image/jpeg') return jpegLumaThumbnail(data);
+    if (info?.contentType === 'image/png') return pngGray(data);
+  } catch {
+    // Corrupt or truncated files are treated as undecodable
+  }
+  return null;
+};
+
+// ============================================================================
+// Hashing
+// ============================================================================
+
+/**
+ * Box-filters an image down to width x height
+ */
+const resize = (image: GrayImage, width: number, height: number): Float64Array => {
+  const result = new Float64Array(width * height);
+  for (let y = 0; y < height; y++) {
+    const top = (y * image.height) / height;
+    const bottom = ((y + 1) * image.height) / height;
+    for (let x = 0; x < width; x++) {
+      const left = (x * image.width) / width;
+      const right = ((x + 1) * image.width) / width;
+      let sum = 0;
+      let area = 0;
+      for (let sy = Math.floor(top); sy < Math.ceil(bottom); sy++) {
+        const coverY = Math.min(bottom, sy + 1) - Math.max(top, sy);
+        for (let sx = Math.floor(left); sx < Math.ceil(right); sx++) {
+          const cover = coverY * (Math.min(right, sx + 1) - Math.max(left, sx));
+          sum += image.pixels[sy * image.width + sx] * cover;
+          area += cover;
+        }
+      }
+      result[y * width + x] = sum / area;
+    }
+  }
+  return result;
+};
+
+/**
+ * 64-bit difference hash of an image
+ *
+ * @returns 16 hex digits, or null when the image is tiny or can't be decoded
+ *   (only JPEG and 8-bit non-interlaced PNG are)
+ */
+export const perceptualHash = (data: Buffer): string | null => {
+  const image = decodeGray(data);
+  // Anything smaller than the 9x8 hash grid (a JPEG under ~72px) says too little
+  if (!image || image.width < 9 || image.height < 8) return null;
+
+  const small = resize(image, 9, 8);
+  let hash = 0n;
+  for (let y = 0; y < 8; y++) {
+    for (let x = 0; x < 8; x++) {
+      hash = (hash << 1n) | (small[y * 9 + x] < small[y * 9 + x + 1] ? 1n : 0n);
+    }
+  }
+  return hash.toString(16).padStart(16, '0');
+};
+
+/**
+ * Bits that differ between two perceptual hashes (0-64)
+ */
+export const hashDistance = (a: string, b: string): number => {
+  let diff = BigInt(`0x${a}`) ^ BigInt(`0x${b}`);
+  let count = 0;
+  while (diff > 0n) {
+    count += Number(diff & 1n);
+    diff >>= 1n;
+  }
+  return count;
+};
@@ -1,4 +1,59 @@
 export * from './database';
 export * from './httpError'
 export * from './httpStatus'
-export * from './jwtToken'
\ No newline at end of file
+export * from './jwtToken'
+export * from './auditLog'
+export * from './boxOfficeStats'
+export * from './countryCentroids'
+export * from './responseCache'
+export * from './queryMetrics'
+export * from './tracing'
+export * from './featureFlags'
+export * from './contentFilter'
+export * from './loginThrottle'
+export * from './cookies'
+export * from './refreshTokens'
+export * from './oauth'
+export * from './twoFactor'
+export * from './sessions'
+export * from './sanitize'
+export * from './i18n'
+export * from './inflation'
+export * from './popularity'
+export * from './jobs'
+export * from './searchRanking'
+export * from './moderation'
+export * from './activity'
+export * from './mailer'
+export * from './digest'
+export * from './push'
+export * from './publicUrls'
+export * from './qrCode'
+export * from './placeholderPosters'
+export * from './images'
+export * from './personImages'
+export * from './assets'
+export * from './sqlFilter'
+export * from './movieRelations'
+export * from './cacheBus'
+export * from './notifications'
+export * from './webSocket'
+export * from './liveUpdates'
+export * from './lineage'
+export * from './tmdb'
+export * from './omdb'
+export * from './enrichers'
+export * from './embeddings'
+export * from './naturalQuery'
+export * from './datasets'
+export * from './movieExport'
+export * from './imageComposition'
+export * from './ogImages'
+export * from './postmanCollection'
+export * from './openApi'
+export * from './fixtures'
+export * from './movieCrew'
+export * from './fieldProfiles'
+export * from './movieKeywords'
+export * from './movieLocales'
+export * from './nameMatching'
\ No newline at end of file
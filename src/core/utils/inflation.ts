@@ -0,0 +1,98 @@
+import { Pool, PoolClient } from 'pg';
+
+/**
+ * Derived money and era fields on movies, so "top grossing of all time"
+ * compares films in today's dollars instead of favoring recent releases.
+ *
+ * budget_adjusted and revenue_adjusted scale the stored amounts by US CPI
+ * (cpi_annual, migration 021) from the release year to CPI_BASE_YEAR, which
+ * defaults to the latest year in the table. Release years outside the table
+ * use its nearest year.
+ */
+
+/**
+ * Film history eras by release year, oldest first; each runs through `until`
+ */
+export const ERAS = [
+  { name: 'silent', until: 1929 },
+  { name: 'golden_age', until: 1959 },
+  { name: 'new_hollywood', until: 1979 },
+  { name: 'blockbuster', until: 1999 },
+  { name: 'digital', until: 2009 },
+  { name: 'streaming', until: null }
+] as const;
+
+export type Era = typeof ERAS[number]['name'];
+
+export const ERA_NAMES = ERAS.map(era => era.name) as [Era, ...Era[]];
+
+const ERA_SQL = `CASE
+  WHEN m.release_date IS NULL THEN NULL
+  ${ERAS.map(era => era.until === null
+    ? `ELSE '${era.name}'`
+    : `WHEN EXTRACT(YEAR FROM m.release_date) <= ${era.until} THEN '${era.name}'`).join('\n  ')}
+END`;
+
+/**
+ * Year to express adjusted amounts in (CPI_BASE_YEAR); null for the latest
+ */
+const getCpiBaseYear = (): number | null =>
+  parseInt(process.env.CPI_BASE_YEAR ?? '', 10) || null;
+
+/**
+ * The year adjusted amounts are expressed in: CPI_BASE_YEAR when cpi_annual
+ * has it, otherwise the latest year there; null when the table is empty
+ */
+export const resolveCpiBaseYear = async (db: Pool | PoolClient): Promise<number | null> => {
+  const result = await db.query<{ year: number | null }>(
+    `SELECT COALESCE(
+       (SELECT year FROM cpi_annual WHERE year = $1),
+       (SELECT MAX(year) FROM cpi_annual)
+     )::int AS year`,
+    [getCpiBaseYear()]
+  );
+  return result.rows[0].year;
+};
+
+/**
+ * Recomputes the adjusted amounts and era for some movies, or all of them.
+ * Call after writing a movie's budget, revenue or release date.
+ *
+ * @param db - Pool or the caller's transaction client
+ * @param movieIds - Movies to update; omit for every movie
+ * @returns Number of movies updated
+ */
+export const enrichMovies = async (db: Pool | PoolClient, movieIds?: number[]): Promise<number> => {
+  if (movieIds && movieIds.length === 0) return 0;
+
+  const result = await db.query(
+    `WITH base AS (
+       SELECT COALESCE(
+         (SELECT cpi FROM cpi_annual WHERE year = $2),
+         (SELECT cpi FROM cpi_annual ORDER BY year DESC LIMIT 1)
+       ) AS cpi
+     ),
+     factors AS (
+       SELECT m.movie_id,
+              CASE WHEN m.release_date IS NOT NULL THEN
+                (SELECT cpi FROM base) / (
+                  SELECT c.cpi FROM cpi_annual c
+                  WHERE c.year <= GREATEST(EXTRACT(YEAR FROM m.release_date), (SELECT MIN(year) FROM cpi_annual))
+                  ORDER BY c.year DESC
+                  LIMIT 1
+                )
+              END AS factor,
+              ${ERA_SQL} AS era
+       FROM movies m
+       WHERE $1::int[] IS NULL OR m.movie_id = ANY($1::int[])
+     )
+     UPDATE movies m
+     SET budget_adjusted = ROUND(m.budget * f.factor)::bigint,
+         revenue_adjusted = ROUND(m.revenue * f.factor)::bigint,
+         era = f.era
+     FROM factors f
+     WHERE m.movie_id = f.movie_id`,
+    [movieIds ?? null, getCpiBaseYear()]
+  );
+  return result.rowCount ?? 0;
+};
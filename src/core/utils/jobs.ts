@@ -0,0 +1,203 @@
+import pool from './database';
+import { mirrorStudioLogos } from './assets';
+import { publishDatasetSnapshot } from './datasets';
+import { sendDigests } from './digest';
+import { embedMovieOverviews } from './embeddings';
+import { hashPersonImages } from './personImages';
+import { refreshPopularityScores } from './popularity';
+
+/**
+ * Background jobs run by the API process once a day.
+ *
+ * Every instance schedules them, but a Postgres advisory lock lets only one
+ * run a job at a time, and job_runs (migration 022) records when each last
+ * ran so a restart or another instance doesn't repeat it the same day.
+ * JOBS_ENABLED=false turns the schedule off (jobs can still be run from
+ * POST /api/admin/jobs/:name/run).
+ */
+export interface JobDefinition {
+  name: string;
+  description: string;
+  /** UTC hour the job runs at */
+  hourUtc: number;
+  /** Does the work; returns a one-line summary for job_runs */
+  run: () => Promise<string>;
+}
+
+export interface JobRun {
+  job_name: string;
+  started_at: Date;
+  finished_at: Date | null;
+  succeeded: boolean | null;
+  summary: string | null;
+}
+
+export interface JobStatus {
+  name: string;
+  description: string;
+  hour_utc: number;
+  last_run: JobRun | null;
+}
+
+export const JOBS: JobDefinition[] = [
+  {
+    name: 'popularity',
+    description: 'Recompute movie popularity scores',
+    hourUtc: 3,
+    run: async () => `${await refreshPopularityScores()} movies scored`
+  },
+  {
+    name: 'digest',
+    description: 'Email subscribers new movies in their favorite genres',
+    hourUtc: 15,
+    run: sendDigests
+  },
+  {
+    name: 'person-images',
+    description: 'Hash new actor profile images and pick canonical ones',
+    hourUtc: 4,
+    run: hashPersonImages
+  },
+  {
+    name: 'mirror-assets',
+    description: 'Copy new studio logos into the asset store',
+    hourUtc: 5,
+    run: mirrorStudioLogos
+  },
+  {
+    name: 'embeddings',
+    description: 'Embed new and edited overviews for semantic search',
+    hourUtc: 6,
+    run: () => embedMovieOverviews()
+  },
+  {
+    name: 'datasets',
+    description: 'Publish a compressed catalog snapshot for GET /api/datasets',
+    hourUtc: 2,
+    run: publishDatasetSnapshot
+  }
+];
+
+/**
+ * A job that already ran within this long is skipped by the schedule
+ */
+const MIN_HOURS_BETWEEN_RUNS = 20;
+
+const timers = new Map<string, NodeJS.Timeout>();
+
+export const getJob = (name: string): JobDefinition | undefined =>
+  JOBS.find(job => job.name === name);
+
+/**
+ * Runs a job unless another instance is running it (or, for scheduled runs,
+ * ran it recently)
+ *
+ * @param job - Job to run
+ * @param force - Run even if it ran recently (manual runs)
+ * @returns The recorded run, or null when skipped
+ */
+export const runJob = async (job: JobDefinition, force = false): Promise<JobRun | null> => {
+  const lockClient = await pool.connect();
+
+  try {
+    const lock = await lockClient.query<{ locked: boolean }>(
+      'SELECT pg_try_advisory_lock(hashtext($1)) AS locked',
+      [`job:${job.name}`]
+    );
+    if (!lock.rows[0].locked) {
+      return null;
+    }
+
+    try {
+      if (!force) {
+        const recent = await lockClient.query(
+          `SELECT 1 FROM job_runs
+           WHERE job_name = $1 AND succeeded AND started_at > NOW() - $2 * INTERVAL '1 hour'`,
+          [job.name, MIN_HOURS_BETWEEN_RUNS]
+        );
+        if (recent.rows.length > 0) {
+          return null;
+        }
+      }
+
+      await lockClient.query(
+        `INSERT INTO job_runs (job_name, started_at, finished_at, succeeded, summary)
+         VALUES ($1, NOW(), NULL, NULL, NULL)
+         ON CONFLICT (job_name) DO UPDATE
+         SET started_at = NOW(), finished_at = NULL, succeeded = NULL, summary = NULL`,
+        [job.name]
+      );
+
+      let succeeded = true;
+      let summary: string;
+      try {
+        summary = await job.run();
+      } catch (error) {
+        succeeded = false;
+        summary = error instanceof Error ? error.message : String(error);
+        console.error(`Job ${job.name} failed:`, error);
+      }
+
+      const result = await lockClient.query<JobRun>(
+        `UPDATE job_runs SET finished_at = NOW(), succeeded = $2, summary = $3
+         WHERE job_name = $1
+         RETURNING job_name, started_at, finished_at, succeeded, summary`,
+        [job.name, succeeded, summary]
+      );
+      return result.rows[0];
+    } finally {
+      await lockClient.query('SELECT pg_advisory_unlock(hashtext($1))', [`job:${job.name}`]);
+    }
+  } finally {
+    lockClient.release();
+  }
+};
+
+/**
+ * Last run of every job (null for jobs that never ran)
+ */
+export const getJobRuns = async (): Promise<JobStatus[]> => {
+  const result = await pool.query<JobRun>(
+    'SELECT job_name, started_at, finished_at, succeeded, summary FROM job_runs'
+  );
+  const runs = new Map(result.rows.map(run => [run.job_name, run]));
+  return JOBS.map(job => ({
+    name: job.name,
+    description: job.description,
+    hour_utc: job.hourUtc,
+    last_run: runs.get(job.name) ?? null
+  }));
+};
+
+const msUntilHour = (hourUtc: number): number => {
+  const now = new Date();
+  const next = new Date(Date.UTC(now.getUTCFullYear(), now.getUTCMonth(), now.getUTCDate(), hourUtc));
+  if (next.getTime() <= now.getTime()) {
+    next.setUTCDate(next.getUTCDate() + 1);
+  }
+  return next.getTime() - now.getTime();
+};
+
+const scheduleJob = (job: JobDefinition): void => {
+  const timer = setTimeout(() => {
+    runJob(job)
+      .catch(error => console.error(`Job ${job.name} could not start:`, error))
+      .finally(() => scheduleJob(job));
+  }, msUntilHour(job.hourUtc));
+  // Don't keep the process alive just for the next run
+  timer.unref();
+  timers.set(job.name, timer);
+};
+
+/**
+ * Starts the daily schedule, unless JOBS_ENABLED=false
+ */
+export const startJobs = (): void => {
+  if (process.env.JOBS_ENABLED === 'false') return;
+  JOBS.forEach(scheduleJob);
+};
+
+export const stopJobs = (): void => {
+  timers.forEach(timer => clearTimeout(timer));
+  timers.clear();
+};
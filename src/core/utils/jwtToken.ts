@@ -5,7 +5,7 @@ import jwt from 'jsonwebtoken';
 dotenvx.config();
 
 const refreshSecret: string = process.env.REFRESH_SECRET ?? "NO";
-const accessSecret: string = process.env.REFRESH_SECRET ?? "NO";
+const accessSecret: string = process.env.ACCESS_SECRET ?? process.env.REFRESH_SECRET ?? "NO";
 
 export const refreshToken = (claims: JwtClaims) => {
     if (refreshSecret == "NO") { throw new Error("Must set REFRESH_SECRET env variable"); }
@@ -13,7 +13,26 @@ export const refreshToken = (claims: JwtClaims) => {
 };
 export const accessToken = (claims: JwtClaims) => {
     if (accessSecret == "NO") { throw new Error("Must set ACCESS_SECRET env variable"); }
-    return jwt.sign(claims, accessSecret, { expiresIn: "3h" });
+    // Short-lived; clients renew it with their rotating refresh token
+    return jwt.sign(claims, accessSecret, { expiresIn: "15m" });
+};
+
+/**
+ * Short-lived token for the second step of a login: 'verify' exchanges a 2FA
+ * code for the real tokens, 'setup' lets an admin enroll before signing in.
+ * Carries an audience so it can't be used as an access token.
+ */
+export type MfaPurpose = 'verify' | 'setup';
+export const MFA_TOKEN_SECONDS = 300;
+
+export const mfaToken = (userId: number, purpose: MfaPurpose) => {
+    if (accessSecret == "NO") { throw new Error("Must set ACCESS_SECRET env variable"); }
+    return jwt.sign({ userId, purpose }, accessSecret, { expiresIn: MFA_TOKEN_SECONDS, audience: "mfa" });
+};
+
+export const verifyMfaToken = (token: string) => {
+    if (accessSecret == "NO") { throw new Error("Must set ACCESS_SECRET env variable"); }
+    return jwt.verify(token, accessSecret, { audience: "mfa" }) as { userId: number; purpose: MfaPurpose };
 };
 
 export const verifyRefresh = (token: string) => {
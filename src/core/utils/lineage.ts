@@ -0,0 +1,104 @@
+import { Pool, PoolClient } from 'pg';
+
+/**
+ * Field-level lineage of imported movies (migration 037). Each import opens a
+ * run with startImportRun(); every movie it writes is recorded with
+ * recordMovieLineage(), passing the snapshot taken before an update, so the
+ * changed columns and their old and new values are kept per run.
+ */
+
+export type ImportRunKind = 'api' | 'bulk' | 'batch' | 'sync' | 'enrich' | 'imdb' | 'fixture';
+
+export interface ImportRunOptions {
+  source?: string | null;
+  batch_id?: number | null;
+  api_key_id?: number | null;
+}
+
+/**
+ * Movie columns imports write; changes to other columns aren't recorded
+ */
+export const LINEAGE_FIELDS = [
+  'title', 'original_title', 'release_date', 'runtime_minutes', 'overview',
+  'budget', 'revenue', 'mpa_rating', 'collection_id', 'poster_url',
+  'backdrop_url', 'adult', 'vote_count', 'vote_average', 'trailer_url'
+] as const;
+
+export type MovieSnapshot = Record<typeof LINEAGE_FIELDS[number], unknown>;
+
+export type FieldChanges = Record<string, { from: unknown; to: unknown }>;
+
+/**
+ * Opens an import run. Pass the transaction's client when the whole import is
+ * one transaction, so a failed import leaves no empty run behind.
+ *
+ * @returns The run's ID
+ */
+export const startImportRun = async (
+  db: Pool | PoolClient,
+  kind: ImportRunKind,
+  options: ImportRunOptions = {}
+): Promise<number> => {
+  const result = await db.query<{ run_id: number }>(
+    `INSERT INTO import_runs (kind, source, batch_id, api_key_id)
+     VALUES ($1, $2, $3, $4)
+     RETURNING run_id`,
+    [kind, options.source ?? null, options.batch_id ?? null, options.api_key_id ?? null]
+  );
+  return result.rows[0].run_id;
+};
+
+/**
+ * Tracked columns of a movie, or null if it doesn't exist
+ */
+export const snapshotMovie = async (db: Pool | PoolClient, movieId: number): Promise<MovieSnapshot | null> => {
+  const result = await db.query<MovieSnapshot>(
+    `SELECT ${LINEAGE_FIELDS.join(', ')} FROM movies WHERE movie_id = $1`,
+    [movieId]
+  );
+  return result.rows[0] ?? null;
+};
+
+/**
+ * Columns that differ between two snapshots; every non-null column when
+ * there is no earlier snapshot
+ */
+export const diffSnapshots = (before: MovieSnapshot | null, after: MovieSnapshot): FieldChanges => {
+  const changes: FieldChanges = {};
+  for (const field of LINEAGE_FIELDS) {
+    const from = before ? before[field] ?? null : null;
+    const to = after[field] ?? null;
+    if (JSON.stringify(from) !== JSON.stringify(to)) {
+      changes[field] = { from, to };
+    }
+  }
+  return changes;
+};
+
+/**
+ * Records what a run did to a movie, inside the transaction that wrote it.
+ * Updates that changed no tracked column aren't recorded.
+ *
+ * @param before - Snapshot taken before an update, or null for a new movie
+ */
+export const recordMovieLineage = async (
+  client: PoolClient,
+  runId: number,
+  movieId: number,
+  before: MovieSnapshot | null
+): Promise<void> => {
+  const after = await snapshotMovie(client, movieId);
+  if (!after) return;
+
+  const changes = diffSnapshots(before, after);
+  if (before && Object.keys(changes).length === 0) return;
+
+  // A run that writes the same movie twice keeps one row with both change sets
+  await client.query(
+    `INSERT INTO movie_lineage (run_id, movie_id, action, changes)
+     VALUES ($1, $2, $3, $4)
+     ON CONFLICT (run_id, movie_id)
+     DO UPDATE SET changes = movie_lineage.changes || EXCLUDED.changes, recorded_at = NOW()`,
+    [runId, movieId, before ? 'updated' : 'created', JSON.stringify(changes)]
+  );
+};
@@ -0,0 +1,62 @@
+import { listenTo } from './notifications';
+import { WebSocketConnection } from './webSocket';
+
+/**
+ * Relays catalog changes to WebSocket clients of /ws (the live admin
+ * dashboard). Migration 035's trigger NOTIFYs catalog_changes for every
+ * movie insert, edit and delete; each message is forwarded unchanged, e.g.
+ *
+ *   {"type": "movie.updated", "movie_id": 42, "public_id": "...", "title": "...",
+ *    "release_date": "1999-03-31", "changed": ["overview"], "at": "..."}
+ *
+ * If the listening connection drops, clients get {"type": "resync"} once it's
+ * back, since changes in between were missed.
+ */
+
+const CHANNEL = 'catalog_changes';
+
+/**
+ * Connections served per API process
+ */
+export const MAX_LIVE_CLIENTS = 100;
+
+const clients = new Set<WebSocketConnection>();
+
+const broadcast = (message: string): void => {
+  clients.forEach(client => client.send(message));
+};
+
+export const liveClientCount = (): number => clients.size;
+
+/**
+ * Starts relaying once the connection is accepted
+ */
+export const addLiveClient = (client: WebSocketConnection): void => {
+  clients.add(client);
+  client.onClose(() => clients.delete(client));
+  client.send(JSON.stringify({ type: 'connected' }));
+};
+
+/**
+ * Subscribes to catalog_changes, once startNotifications() connects
+ */
+export const startLiveUpdates = (): void => {
+  listenTo(CHANNEL, {
+    onMessage: payload => {
+      if (payload === undefined || clients.size === 0) return;
+      try {
+        JSON.parse(payload);
+      } catch {
+        console.warn('Ignoring malformed catalog change:', payload);
+        return;
+      }
+      broadcast(payload);
+    },
+    onReconnect: () => broadcast(JSON.stringify({ type: 'resync' }))
+  });
+};
+
+export const closeLiveClients = (): void => {
+  clients.forEach(client => client.close(1001, 'Server shutting down'));
+  clients.clear();
+};
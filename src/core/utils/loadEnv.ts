@@ -0,0 +1,5 @@
+import dotenvx from '@dotenvx/dotenvx';
+
+// Imported by src/app.ts before anything else, so modules that read
+// process.env while being imported already see the env file's values
+dotenvx.config();
@@ -0,0 +1,182 @@
+import pool from './database';
+
+/**
+ * Consecutive failures allowed before each further attempt has to wait
+ */
+const FREE_ATTEMPTS = 3;
+
+/**
+ * Longest forced wait between attempts on one account, in seconds
+ */
+const MAX_DELAY_SECONDS = 300;
+
+/**
+ * Consecutive failures that lock the account
+ */
+const LOCKOUT_THRESHOLD = 10;
+
+/**
+ * First lockout length; each further failure while at the threshold doubles it
+ */
+const LOCKOUT_MINUTES = 30;
+const MAX_LOCKOUT_MINUTES = 24 * 60;
+
+/**
+ * Failed attempts one IP may make across all accounts within the window
+ */
+const IP_FAILURE_LIMIT = 20;
+const IP_WINDOW_MINUTES = 15;
+
+/**
+ * Outcome of reserveLoginAttempt: the login_attempts row reserved for the
+ * attempt, or the seconds until the next attempt is accepted
+ */
+export type LoginReservation =
+  | { allowed: true; attemptId: string }
+  | { allowed: false; retryAfterSeconds: number; reason: 'ip' | 'locked' | 'delay' };
+
+/**
+ * Wait required after the given number of consecutive failures: none for the
+ * first FREE_ATTEMPTS, then 1s, 2s, 4s, ... up to MAX_DELAY_SECONDS
+ */
+export const getLoginDelaySeconds = (failures: number): number =>
+  failures < FREE_ATTEMPTS ? 0 : Math.min(2 ** (failures - FREE_ATTEMPTS), MAX_DELAY_SECONDS);
+
+/**
+ * Checks the limits and, when the attempt may proceed, records it as a
+ * failure in the same transaction, before the password is verified: a
+ * throttled caller learns nothing about the password, and parallel attempts
+ * can't all pass the check before any of them is counted. The account row is
+ * locked with FOR UPDATE and attempts from one IP take an advisory lock, so
+ * concurrent attempts are counted one after another. settleLoginAttempt
+ * clears the failure when the password turns out to be correct.
+ *
+ * @param email - Email being logged in to
+ * @param ip - Caller's IP address
+ */
+export const reserveLoginAttempt = async (email: string, ip: string | null): Promise<LoginReservation> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    if (ip) {
+      await client.query(`SELECT pg_advisory_xact_lock(hashtext('login_attempts:' || $1))`, [ip]);
+      const ipResult = await client.query<{ failures: number; retry_after: number | null }>(
+        `SELECT
+           COUNT(*)::int AS failures,
+           CEIL(EXTRACT(EPOCH FROM MIN(attempted_at) + $2 * INTERVAL '1 minute' - NOW()))::int AS retry_after
+         FROM login_attempts
+         WHERE ip_address = $1
+           AND NOT succeeded
+           AND attempted_at > NOW() - $2 * INTERVAL '1 minute'`,
+        [ip, IP_WINDOW_MINUTES]
+      );
+      const { failures, retry_after } = ipResult.rows[0];
+      if (failures >= IP_FAILURE_LIMIT) {
+        await client.query('ROLLBACK');
+        return { allowed: false, retryAfterSeconds: Math.max(1, retry_after ?? 1), reason: 'ip' };
+      }
+    }
+
+    const userResult = await client.query<{
+      user_id: number;
+      failed_login_count: number;
+      locked_for: number | null;
+      last_failed_ago: number | null;
+    }>(
+      `SELECT
+         user_id,
+         failed_login_count,
+         CEIL(EXTRACT(EPOCH FROM locked_until - NOW()))::int AS locked_for,
+         FLOOR(EXTRACT(EPOCH FROM NOW() - last_failed_login_at))::int AS last_failed_ago
+       FROM users
+       WHERE email = $1
+       FOR UPDATE`,
+      [email]
+    );
+    const user = userResult.rows[0];
+
+    if (user) {
+      if (user.locked_for !== null && user.locked_for > 0) {
+        await client.query('ROLLBACK');
+        return { allowed: false, retryAfterSeconds: user.locked_for, reason: 'locked' };
+      }
+
+      const delay = getLoginDelaySeconds(user.failed_login_count);
+      if (delay > 0 && user.last_failed_ago !== null && user.last_failed_ago < delay) {
+        await client.query('ROLLBACK');
+        return { allowed: false, retryAfterSeconds: delay - user.last_failed_ago, reason: 'delay' };
+      }
+
+      await client.query(
+        `UPDATE users
+         SET failed_login_count = failed_login_count + 1,
+             last_failed_login_at = NOW(),
+             locked_until = CASE
+               WHEN failed_login_count + 1 >= $2
+               THEN NOW() + LEAST($3 * POWER(2, failed_login_count + 1 - $2), $4) * INTERVAL '1 minute'
+               ELSE locked_until
+             END
+         WHERE user_id = $1`,
+        [user.user_id, LOCKOUT_THRESHOLD, LOCKOUT_MINUTES, MAX_LOCKOUT_MINUTES]
+      );
+    }
+
+    const attempt = await client.query<{ attempt_id: string }>(
+      'INSERT INTO login_attempts (email, ip_address, succeeded) VALUES ($1, $2, false) RETURNING attempt_id',
+      [email, ip]
+    );
+
+    await client.query('COMMIT');
+    return { allowed: true, attemptId: attempt.rows[0].attempt_id };
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * Settles a reserved attempt. A failure was already counted by
+ * reserveLoginAttempt; a success marks the attempt and clears the account's
+ * failure count and lockout.
+ *
+ * @param attemptId - From reserveLoginAttempt
+ * @param userId - Matching account, or null when the email is unknown
+ * @param succeeded - Whether the password (or second factor) was correct
+ */
+export const settleLoginAttempt = async (
+  attemptId: string,
+  userId: number | null,
+  succeeded: boolean
+): Promise<void> => {
+  if (!succeeded) return;
+
+  await pool.query('UPDATE login_attempts SET succeeded = true WHERE attempt_id = $1', [attemptId]);
+
+  if (userId === null) return;
+
+  await pool.query(
+    `UPDATE users
+     SET failed_login_count = 0, last_failed_login_at = NULL, locked_until = NULL
+     WHERE user_id = $1`,
+    [userId]
+  );
+};
+
+/**
+ * Clears an account's lockout and failure count
+ *
+ * @returns false when no such user exists
+ */
+export const unlockUser = async (userId: number): Promise<boolean> => {
+  const result = await pool.query(
+    `UPDATE users
+     SET failed_login_count = 0, last_failed_login_at = NULL, locked_until = NULL
+     WHERE user_id = $1`,
+    [userId]
+  );
+  return (result.rowCount ?? 0) > 0;
+};
@@ -0,0 +1,99 @@
+import { tracedFetch } from './tracing';
+
+/**
+ * Outgoing email. MAILER picks the transport:
+ * - none (default): email is off and nothing is sent
+ * - log: prints messages, recipients and links included, instead of sending
+ *   them; for development only
+ * - webhook: POSTs each message as JSON to MAIL_WEBHOOK_URL (with
+ *   MAIL_WEBHOOK_TOKEN as a bearer token), for relays and provider HTTP APIs
+ *
+ * Other transports can be added with registerMailer.
+ */
+export interface MailMessage {
+  to: string;
+  subject: string;
+  text: string;
+  html?: string;
+  headers?: Record<string, string>;
+}
+
+export interface Mailer {
+  name: string;
+  send: (message: MailMessage & { from: string }) => Promise<void>;
+}
+
+const disabledMailer: Mailer = {
+  name: 'none',
+  send: async () => {
+    throw new Error('Email is disabled; set MAILER to send it');
+  }
+};
+
+const logMailer: Mailer = {
+  name: 'log',
+  send: async (message) => {
+    console.log(`[mail] to=${message.to} subject="${message.subject}"\n${message.text}`);
+  }
+};
+
+const webhookMailer: Mailer = {
+  name: 'webhook',
+  send: async (message) => {
+    const url = process.env.MAIL_WEBHOOK_URL;
+    if (!url) {
+      throw new Error('MAIL_WEBHOOK_URL is not set');
+    }
+
+    const headers: Record<string, string> = { 'Content-Type': 'application/json' };
+    if (process.env.MAIL_WEBHOOK_TOKEN) {
+      headers.Authorization = `Bearer ${process.env.MAIL_WEBHOOK_TOKEN}`;
+    }
+
+    const response = await tracedFetch(url, {
+      method: 'POST',
+      headers,
+      body: JSON.stringify(message),
+      signal: AbortSignal.timeout(10_000)
+    });
+    if (!response.ok) {
+      throw new Error(`Mail webhook answered ${response.status}`);
+    }
+  }
+};
+
+const mailers = new Map<string, Mailer>([disabledMailer, logMailer, webhookMailer].map(mailer => [mailer.name, mailer]));
+
+/**
+ * Adds (or replaces) a transport that MAILER can name
+ */
+export const registerMailer = (mailer: Mailer): void => {
+  mailers.set(mailer.name, mailer);
+};
+
+/**
+ * The configured transport
+ */
+export const getMailer = (): Mailer => {
+  const name = process.env.MAILER || disabledMailer.name;
+  const mailer = mailers.get(name);
+  if (!mailer) {
+    throw new Error(`Unknown MAILER "${name}"`);
+  }
+  return mailer;
+};
+
+/**
+ * Whether MAILER names a transport that actually sends
+ */
+export const isMailEnabled = (): boolean => getMailer() !== disabledMailer;
+
+/**
+ * Sends a message through the configured transport, from MAIL_FROM
+ */
+export const sendMail = async (message: MailMessage): Promise<void> => {
+  await getMailer().send({
+    from: process.env.MAIL_FROM || 'TCSS 460 Movie API <no-reply@localhost>',
+    ...message
+  });
+};
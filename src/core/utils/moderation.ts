@@ -0,0 +1,79 @@
+/**
+ * Review moderation. New and edited reviews wait for an admin unless
+ * REVIEWS_AUTO_APPROVE=true, and even then a review any content filter
+ * matches stays pending so a person looks at it.
+ */
+
+/**
+ * Checks review text before it is stored. Returns what it matched (words,
+ * phrases or reasons), or an empty array when the text is clean.
+ */
+export interface ReviewFilter {
+  name: string;
+  check: (text: string) => string[] | Promise<string[]>;
+}
+
+/**
+ * Used when REVIEW_BLOCKED_WORDS isn't set
+ */
+const DEFAULT_BLOCKED_WORDS = [
+  'asshole', 'bastard', 'bitch', 'bullshit', 'cunt', 'fuck', 'motherfucker', 'shit', 'whore'
+];
+
+const escapeRegExp = (text: string): string => text.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+
+/**
+ * REVIEW_BLOCKED_WORDS, or the default list when unset
+ */
+const getBlockedWords = (): string[] => {
+  const configured = process.env.REVIEW_BLOCKED_WORDS;
+  if (configured === undefined) return DEFAULT_BLOCKED_WORDS;
+  return configured.split(',').map(word => word.trim().toLowerCase()).filter(Boolean);
+};
+
+/**
+ * Whole words from the blocked list, including simple inflections
+ * ("shits", "fucking"); case-insensitive
+ */
+const profanityFilter: ReviewFilter = {
+  name: 'profanity',
+  check: (text) => {
+    const lower = text.toLowerCase();
+    return getBlockedWords().filter(word =>
+      new RegExp(`\\b${escapeRegExp(word)}(?:s|es|ed|ing|er|ers|y)?\\b`).test(lower)
+    );
+  }
+};
+
+/**
+ * Every filter a review passes through. Add filters here (e.g. a hosted
+ * moderation API); one that throws flags the review rather than blocking it.
+ */
+export const REVIEW_FILTERS: ReviewFilter[] = [profanityFilter];
+
+/**
+ * Runs every filter over a review's text
+ *
+ * @param text - Sanitized review text
+ * @returns Everything the filters matched, de-duplicated; empty when clean
+ */
+export const checkReviewText = async (text: string | null | undefined): Promise<string[]> => {
+  if (!text) return [];
+
+  const matches = new Set<string>();
+  for (const filter of REVIEW_FILTERS) {
+    try {
+      (await filter.check(text)).forEach(match => matches.add(match));
+    } catch (error) {
+      console.error(`Review filter ${filter.name} failed:`, error);
+      matches.add(`${filter.name} filter unavailable`);
+    }
+  }
+  return [...matches];
+};
+
+/**
+ * Whether reviews no filter flagged are published without waiting for an
+ * admin. Read per call like the other settings.
+ */
+export const reviewsAutoApprove = (): boolean => process.env.REVIEWS_AUTO_APPROVE === 'true';
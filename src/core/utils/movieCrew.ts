@@ -0,0 +1,90 @@
+import { PoolClient } from 'pg';
+import { nameMatchSql } from './nameMatching';
+
+/**
+ * Crew credits beyond directors and producers (migration 047). Each role has
+ * a table of people and a junction table, like directors and producers, and
+ * is a list of names in movie input and exports (writers, composers,
+ * cinematographers, editors).
+ */
+
+export const CREW_ROLES = {
+  writers: { table: 'writers', idColumn: 'writer_id', nameColumn: 'writer_name', linkTable: 'movie_writers' },
+  composers: { table: 'composers', idColumn: 'composer_id', nameColumn: 'composer_name', linkTable: 'movie_composers' },
+  cinematographers: {
+    table: 'cinematographers',
+    idColumn: 'cinematographer_id',
+    nameColumn: 'cinematographer_name',
+    linkTable: 'movie_cinematographers'
+  },
+  editors: { table: 'editors', idColumn: 'editor_id', nameColumn: 'editor_name', linkTable: 'movie_editors' }
+} as const;
+
+export type CrewRole = keyof typeof CREW_ROLES;
+
+export const CREW_ROLE_NAMES = Object.keys(CREW_ROLES) as CrewRole[];
+
+export type MovieCrew = Partial<Record<CrewRole, string[]>>;
+
+/**
+ * Gets or creates a crew member of a role and returns their ID; names are
+ * matched ignoring case and accents
+ */
+export const getOrCreateCrewMemberId = async (client: PoolClient, role: CrewRole, name: string): Promise<number> => {
+  const { table, idColumn, nameColumn } = CREW_ROLES[role];
+  const existing = await client.query(
+    `SELECT ${idColumn} AS id FROM ${table} WHERE ${nameMatchSql(nameColumn, '$1')} ORDER BY ${idColumn} LIMIT 1`,
+    [name.trim()]
+  );
+  if (existing.rows.length > 0) {
+    return existing.rows[0].id;
+  }
+
+  const created = await client.query(
+    `INSERT INTO ${table} (${nameColumn}) VALUES ($1) RETURNING ${idColumn} AS id`,
+    [name.trim()]
+  );
+  return created.rows[0].id;
+};
+
+/**
+ * Links a movie to the crew it lists; roles left out are untouched
+ */
+export const insertMovieCrew = async (client: PoolClient, movieId: number, crew: MovieCrew): Promise<void> => {
+  for (const role of CREW_ROLE_NAMES) {
+    const { idColumn, linkTable } = CREW_ROLES[role];
+    for (const name of crew[role] ?? []) {
+      const personId = await getOrCreateCrewMemberId(client, role, name);
+      await client.query(
+        `INSERT INTO ${linkTable} (movie_id, ${idColumn}) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+        [movieId, personId]
+      );
+    }
+  }
+};
+
+/**
+ * Replaces a movie's crew for each role the input lists (an empty list
+ * clears it); roles left out are untouched
+ */
+export const replaceMovieCrew = async (client: PoolClient, movieId: number, crew: MovieCrew): Promise<void> => {
+  for (const role of CREW_ROLE_NAMES) {
+    if (crew[role] !== undefined) {
+      await client.query(`DELETE FROM ${CREW_ROLES[role].linkTable} WHERE movie_id = $1`, [movieId]);
+    }
+  }
+  await insertMovieCrew(client, movieId, crew);
+};
+
+/**
+ * SELECT-list columns with each role's names as a sorted JSON array, for a
+ * query over movies aliased as m
+ */
+export const CREW_JSON_SQL = CREW_ROLE_NAMES.map(role => {
+  const { table, idColumn, nameColumn, linkTable } = CREW_ROLES[role];
+  return `COALESCE((
+      SELECT JSON_AGG(p.${nameColumn} ORDER BY p.${nameColumn})
+      FROM ${linkTable} l JOIN ${table} p ON l.${idColumn} = p.${idColumn}
+      WHERE l.movie_id = m.movie_id
+    ), '[]') AS ${role}`;
+}).join(',\n    ');
@@ -0,0 +1,82 @@
+import { CREW_JSON_SQL } from './movieCrew';
+import { KEYWORDS_JSON_SQL } from './movieKeywords';
+import { LOCALES_JSON_SQL } from './movieLocales';
+import { CAST_ORDER_SQL } from './movieRelations';
+
+/**
+ * Full movie records with the shape of POST /movies input plus movie_id,
+ * public_id and updated_at (ExportedMovie), shared by GET /api/export/movies
+ * and the dataset snapshots. Add WHERE/ORDER BY on movies aliased as m.
+ */
+export const EXPORTED_MOVIE_SQL = `
+  SELECT
+    m.movie_id,
+    m.public_id,
+    m.title,
+    m.original_title,
+    TO_CHAR(m.release_date, 'YYYY-MM-DD') AS release_date,
+    m.runtime_minutes,
+    m.overview,
+    m.budget::int8,
+    m.revenue::int8,
+    m.vote_count,
+    m.vote_average::float8,
+    m.mpa_rating,
+    m.adult,
+    m.poster_url,
+    m.backdrop_url,
+    c.collection_name,
+    m.updated_at,
+    COALESCE((
+      SELECT JSON_AGG(g.genre_name ORDER BY g.genre_name)
+      FROM movie_genres mg JOIN genres g ON mg.genre_id = g.genre_id
+      WHERE mg.movie_id = m.movie_id
+    ), '[]') AS genres,
+    COALESCE((
+      SELECT JSON_AGG(d.director_name ORDER BY d.director_name)
+      FROM movie_directors md JOIN directors d ON md.director_id = d.director_id
+      WHERE md.movie_id = m.movie_id
+    ), '[]') AS directors,
+    COALESCE((
+      SELECT JSON_AGG(p.producer_name ORDER BY p.producer_name)
+      FROM movie_producers mp JOIN producers p ON mp.producer_id = p.producer_id
+      WHERE mp.movie_id = m.movie_id
+    ), '[]') AS producers,
+    ${CREW_JSON_SQL},
+    ${KEYWORDS_JSON_SQL},
+    ${LOCALES_JSON_SQL},
+    COALESCE((
+      SELECT JSON_AGG(JSON_BUILD_OBJECT(
+        'studio_name', s.studio_name,
+        'logo_url', s.logo_url,
+        'country', s.country
+      ) ORDER BY s.studio_name)
+      FROM movie_studios ms JOIN studios s ON ms.studio_id = s.studio_id
+      WHERE ms.movie_id = m.movie_id
+    ), '[]') AS studios,
+    COALESCE((
+      SELECT JSON_AGG(JSON_BUILD_OBJECT(
+        'actor_name', a.actor_name,
+        'character_name', ma.character_name,
+        'character_names', ma.character_names,
+        'actor_order', ma.actor_order,
+        'billing_order', ma.billing_order,
+        'credited', ma.credited,
+        'profile_url', a.profile_url
+      ) ORDER BY ${CAST_ORDER_SQL})
+      FROM movie_actors ma JOIN actors a ON ma.actor_id = a.actor_id
+      WHERE ma.movie_id = m.movie_id
+    ), '[]') AS "cast",
+    COALESCE((
+      SELECT JSON_AGG(JSON_BUILD_OBJECT(
+        'language', t.language,
+        'title', t.title,
+        'overview', t.overview,
+        'tagline', t.tagline
+      ) ORDER BY t.language)
+      FROM movie_translations t
+      WHERE t.movie_id = m.movie_id
+    ), '[]') AS translations
+  FROM movies m
+  LEFT JOIN collections c ON m.collection_id = c.collection_id
+`;
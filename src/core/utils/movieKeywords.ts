@@ -0,0 +1,81 @@
+import { PoolClient } from 'pg';
+
+/**
+ * Keywords (tags) on movies, in keywords and movie_keywords (migration 048).
+ * Names are stored normalized, so "Time Travel" and " time  travel" are the
+ * same keyword.
+ */
+
+/**
+ * Separator between keywords when they come as one string ("heist; time travel")
+ */
+export const KEYWORD_SEPARATOR = ';';
+
+export const MAX_KEYWORD_LENGTH = 100;
+
+/**
+ * Lower-case with whitespace trimmed and collapsed
+ */
+export const normalizeKeyword = (name: string): string => name.trim().replace(/\s+/g, ' ').toLowerCase();
+
+/**
+ * Keywords from a list or a semicolon-separated string, normalized, without
+ * blanks or repeats
+ */
+export const parseKeywords = (value: string | string[]): string[] => {
+  const names = Array.isArray(value) ? value : value.split(KEYWORD_SEPARATOR);
+  return [...new Set(names.map(normalizeKeyword).filter(name => name.length > 0))];
+};
+
+/**
+ * Gets or creates a keyword and returns its ID
+ */
+export const getOrCreateKeywordId = async (client: PoolClient, name: string): Promise<number> => {
+  const keyword = normalizeKeyword(name);
+  const existing = await client.query('SELECT keyword_id FROM keywords WHERE keyword_name = $1', [keyword]);
+  if (existing.rows.length > 0) {
+    return existing.rows[0].keyword_id;
+  }
+
+  const created = await client.query(
+    'INSERT INTO keywords (keyword_name) VALUES ($1) RETURNING keyword_id',
+    [keyword]
+  );
+  return created.rows[0].keyword_id;
+};
+
+/**
+ * Links a movie to its keywords
+ */
+export const insertMovieKeywords = async (client: PoolClient, movieId: number, keywords: string[]): Promise<void> => {
+  for (const keyword of parseKeywords(keywords)) {
+    const keywordId = await getOrCreateKeywordId(client, keyword);
+    await client.query(
+      'INSERT INTO movie_keywords (movie_id, keyword_id) VALUES ($1, $2) ON CONFLICT DO NOTHING',
+      [movieId, keywordId]
+    );
+  }
+};
+
+/**
+ * Replaces a movie's keywords; undefined leaves them as they are
+ */
+export const replaceMovieKeywords = async (
+  client: PoolClient,
+  movieId: number,
+  keywords: string[] | undefined
+): Promise<void> => {
+  if (keywords === undefined) return;
+  await client.query('DELETE FROM movie_keywords WHERE movie_id = $1', [movieId]);
+  await insertMovieKeywords(client, movieId, keywords);
+};
+
+/**
+ * SELECT-list column with a movie's keywords as a sorted JSON array, for a
+ * query over movies aliased as m
+ */
+export const KEYWORDS_JSON_SQL = `COALESCE((
+      SELECT JSON_AGG(k.keyword_name ORDER BY k.keyword_name)
+      FROM movie_keywords mk JOIN keywords k ON mk.keyword_id = k.keyword_id
+      WHERE mk.movie_id = m.movie_id
+    ), '[]') AS keywords`;
@@ -0,0 +1,198 @@
+import { PoolClient } from 'pg';
+import { COUNTRY_ALIASES } from './countryCentroids';
+
+/**
+ * Spoken languages and production countries of movies (migration 049).
+ * Input may give ISO codes ("en", "US") or names ("English", "United States
+ * of America"); both are stored under the ISO code when it is known here
+ * (ISO 639-1 for languages, ISO 3166-1 alpha-2 for countries) and under the
+ * cleaned-up name otherwise, so nothing the source supplied is dropped.
+ */
+
+export const MAX_LOCALE_LENGTH = 100;
+
+/**
+ * English names by ISO 639-1 code; Cantonese has no 639-1 code and uses
+ * its ISO 639-3 one
+ */
+const LANGUAGE_NAMES: Record<string, string> = {
+  af: 'Afrikaans', ar: 'Arabic', bg: 'Bulgarian', bn: 'Bengali', cs: 'Czech', cy: 'Welsh', da: 'Danish',
+  de: 'German', el: 'Greek', en: 'English', es: 'Spanish', et: 'Estonian', fa: 'Persian', fi: 'Finnish',
+  fr: 'French', ga: 'Irish', he: 'Hebrew', hi: 'Hindi', hr: 'Croatian', hu: 'Hungarian', hy: 'Armenian',
+  id: 'Indonesian', is: 'Icelandic', it: 'Italian', ja: 'Japanese', ka: 'Georgian', kn: 'Kannada',
+  ko: 'Korean', la: 'Latin', lt: 'Lithuanian', lv: 'Latvian', ml: 'Malayalam', mr: 'Marathi', ms: 'Malay',
+  nl: 'Dutch', no: 'Norwegian', pa: 'Punjabi', pl: 'Polish', pt: 'Portuguese', ro: 'Romanian',
+  ru: 'Russian', sk: 'Slovak', sl: 'Slovenian', sr: 'Serbian', sv: 'Swedish', sw: 'Swahili', ta: 'Tamil',
+  te: 'Telugu', th: 'Thai', tl: 'Tagalog', tr: 'Turkish', uk: 'Ukrainian', ur: 'Urdu', vi: 'Vietnamese',
+  yi: 'Yiddish', yue: 'Cantonese', zh: 'Mandarin', zu: 'Zulu'
+};
+
+/**
+ * Other names seen in exports, lower-case
+ */
+const LANGUAGE_ALIASES: Record<string, string> = {
+  chinese: 'zh',
+  farsi: 'fa',
+  filipino: 'tl',
+  gaelic: 'ga',
+  'standard chinese': 'zh'
+};
+
+/**
+ * English names by ISO 3166-1 alpha-2 code. SU, XC and XG are the codes
+ * TMDB keeps for the Soviet Union, Czechoslovakia and East Germany.
+ */
+const COUNTRY_NAMES: Record<string, string> = {
+  AE: 'United Arab Emirates', AR: 'Argentina', AT: 'Austria', AU: 'Australia', BE: 'Belgium', BG: 'Bulgaria',
+  BR: 'Brazil', CA: 'Canada', CH: 'Switzerland', CL: 'Chile', CN: 'China', CO: 'Colombia', CU: 'Cuba',
+  CZ: 'Czech Republic', DE: 'Germany', DK: 'Denmark', EG: 'Egypt', ES: 'Spain', FI: 'Finland', FR: 'France',
+  GB: 'United Kingdom', GR: 'Greece', HK: 'Hong Kong', HU: 'Hungary', ID: 'Indonesia', IE: 'Ireland',
+  IL: 'Israel', IN: 'India', IR: 'Iran', IS: 'Iceland', IT: 'Italy', JP: 'Japan', KR: 'South Korea',
+  LU: 'Luxembourg', MA: 'Morocco', MX: 'Mexico', MY: 'Malaysia', NG: 'Nigeria', NL: 'Netherlands',
+  NO: 'Norway', NZ: 'New Zealand', PE: 'Peru', PH: 'Philippines', PK: 'Pakistan', PL: 'Poland',
+  PT: 'Portugal', RO: 'Romania', RU: 'Russia', SE: 'Sweden', SG: 'Singapore', SU: 'Soviet Union',
+  TH: 'Thailand', TR: 'Turkey', TW: 'Taiwan', UA: 'Ukraine', US: 'United States of America',
+  VE: 'Venezuela', VN: 'Vietnam', XC: 'Czechoslovakia', XG: 'East Germany', ZA: 'South Africa'
+};
+
+/**
+ * Other names seen in exports, lower-case
+ */
+const COUNTRY_NAME_ALIASES: Record<string, string> = {
+  'united states': 'US',
+  'great britain': 'GB',
+  england: 'GB',
+  'korea, republic of': 'KR',
+  'russian federation': 'RU',
+  czechia: 'CZ',
+  'west germany': 'DE',
+  'federal republic of germany': 'DE',
+  ussr: 'SU'
+};
+
+const byLowerCaseName = (names: Record<string, string>, aliases: Record<string, string>): Map<string, string> =>
+  new Map([
+    ...Object.entries(names).map(([code, name]): [string, string] => [name.toLowerCase(), code]),
+    ...Object.entries(aliases)
+  ]);
+
+const LANGUAGE_CODES = byLowerCaseName(LANGUAGE_NAMES, LANGUAGE_ALIASES);
+const COUNTRY_CODES = byLowerCaseName(COUNTRY_NAMES, COUNTRY_NAME_ALIASES);
+
+/**
+ * A language or country as stored: its code, and a name to show
+ */
+export interface ResolvedLocale {
+  code: string;
+  name: string;
+}
+
+const cleanName = (value: string): string => value.trim().replace(/\s+/g, ' ');
+
+/**
+ * Resolves a language name or code; unknown ones keep their cleaned name
+ * as both
+ */
+export const resolveLanguage = (value: string): ResolvedLocale => {
+  const name = cleanName(value);
+  const lower = name.toLowerCase();
+  const code = LANGUAGE_NAMES[lower] ? lower : LANGUAGE_CODES.get(lower);
+  return code ? { code, name: LANGUAGE_NAMES[code] } : { code: name, name };
+};
+
+/**
+ * Resolves a country name or code; unknown ones keep their cleaned name
+ * as both
+ */
+export const resolveCountry = (value: string): ResolvedLocale => {
+  const name = cleanName(value);
+  const upper = name.toUpperCase();
+  const code = COUNTRY_NAMES[upper] ? upper : COUNTRY_ALIASES[upper] ?? COUNTRY_CODES.get(name.toLowerCase());
+  return code ? { code, name: COUNTRY_NAMES[code] ?? code } : { code: name, name };
+};
+
+/**
+ * Names or codes from a list or a comma- or semicolon-separated string
+ * ("English, French"), without blanks. TMDB's "No Language" means none.
+ */
+export const parseLocaleList = (value: string | string[]): string[] =>
+  (Array.isArray(value) ? value : value.split(/[,;]/))
+    .map(cleanName)
+    .filter(name => name.length > 0 && name.toLowerCase() !== 'no language');
+
+const LOCALE_KINDS = {
+  spoken_languages: {
+    table: 'languages', idColumn: 'language_id', codeColumn: 'language_code', nameColumn: 'language_name',
+    linkTable: 'movie_languages', resolve: resolveLanguage
+  },
+  production_countries: {
+    table: 'countries', idColumn: 'country_id', codeColumn: 'country_code', nameColumn: 'country_name',
+    linkTable: 'movie_countries', resolve: resolveCountry
+  }
+} as const;
+
+export type LocaleField = keyof typeof LOCALE_KINDS;
+
+export const LOCALE_FIELDS = Object.keys(LOCALE_KINDS) as LocaleField[];
+
+export type MovieLocales = Partial<Record<LocaleField, string[]>>;
+
+/**
+ * Gets or creates a language or country and returns its ID
+ */
+export const getOrCreateLocaleId = async (client: PoolClient, field: LocaleField, value: string): Promise<number> => {
+  const { table, idColumn, codeColumn, nameColumn, resolve } = LOCALE_KINDS[field];
+  const { code, name } = resolve(value);
+  const existing = await client.query(`SELECT ${idColumn} AS id FROM ${table} WHERE ${codeColumn} = $1`, [code]);
+  if (existing.rows.length > 0) {
+    return existing.rows[0].id;
+  }
+
+  const created = await client.query(
+    `INSERT INTO ${table} (${codeColumn}, ${nameColumn}) VALUES ($1, $2) RETURNING ${idColumn} AS id`,
+    [code, name]
+  );
+  return created.rows[0].id;
+};
+
+/**
+ * Links a movie to the languages and countries it lists
+ */
+export const insertMovieLocales = async (client: PoolClient, movieId: number, locales: MovieLocales): Promise<void> => {
+  for (const field of LOCALE_FIELDS) {
+    const { idColumn, linkTable } = LOCALE_KINDS[field];
+    for (const value of locales[field] ?? []) {
+      const id = await getOrCreateLocaleId(client, field, value);
+      await client.query(
+        `INSERT INTO ${linkTable} (movie_id, ${idColumn}) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+        [movieId, id]
+      );
+    }
+  }
+};
+
+/**
+ * Replaces a movie's languages or countries when the input lists them (an
+ * empty list clears them); left out, they are untouched
+ */
+export const replaceMovieLocales = async (client: PoolClient, movieId: number, locales: MovieLocales): Promise<void> => {
+  for (const field of LOCALE_FIELDS) {
+    if (locales[field] !== undefined) {
+      await client.query(`DELETE FROM ${LOCALE_KINDS[field].linkTable} WHERE movie_id = $1`, [movieId]);
+    }
+  }
+  await insertMovieLocales(client, movieId, locales);
+};
+
+/**
+ * SELECT-list columns with a movie's language and country codes as sorted
+ * JSON arrays, for a query over movies aliased as m
+ */
+export const LOCALES_JSON_SQL = LOCALE_FIELDS.map(field => {
+  const { table, idColumn, codeColumn, linkTable } = LOCALE_KINDS[field];
+  return `COALESCE((
+      SELECT JSON_AGG(x.${codeColumn} ORDER BY x.${codeColumn})
+      FROM ${linkTable} l JOIN ${table} x ON l.${idColumn} = x.${idColumn}
+      WHERE l.movie_id = m.movie_id
+    ), '[]') AS ${field}`;
+}).join(',\n    ');
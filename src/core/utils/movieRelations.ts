@@ -0,0 +1,171 @@
+import { Pool, PoolClient } from 'pg';
+import pool from './database';
+import { assetUrlSql } from './assets';
+
+/**
+ * Related records expanded into movie responses with ?include=.
+ *
+ * Each requested relation is loaded for a whole page of movies with one
+ * query (movie_id = ANY($1)) and grouped in memory, so a list with includes
+ * costs one query per relation rather than one per movie. Cast is cut to
+ * castLimit entries per movie; the rest is paged through GET
+ * /movies/:id/cast.
+ */
+
+export const MOVIE_RELATIONS = ['cast', 'genres', 'studios'] as const;
+
+export type MovieRelation = typeof MOVIE_RELATIONS[number];
+
+/**
+ * Cast entries inlined per movie when the caller doesn't pass cast_limit
+ */
+export const DEFAULT_CAST_LIMIT = 20;
+
+export interface MovieIncludeOptions {
+  castLimit?: number;
+}
+
+/**
+ * SQL ordering for a movie's cast (movie_actors aliased as ma): explicit
+ * billing first, then supplied position, then actor ID so ties are stable
+ */
+export const CAST_ORDER_SQL = 'COALESCE(ma.billing_order, ma.actor_order), ma.actor_order, ma.actor_id';
+
+export interface IncludedCastMember {
+  actor_id: number;
+  actor_name: string;
+  character_name: string | null;
+  character_names: string[];
+  actor_order: number;
+  billing_order: number | null;
+  credited: boolean;
+  profile_url: string | null;
+}
+
+export interface IncludedGenre {
+  genre_id: number;
+  genre_name: string;
+}
+
+export interface IncludedStudio {
+  studio_id: number;
+  studio_name: string;
+  logo_url: string | null;
+  logo_asset_url: string | null;
+  country: string | null;
+}
+
+export interface MovieIncludes {
+  cast?: IncludedCastMember[];
+  cast_total?: number; // Whole cast, of which cast holds the first castLimit
+  genres?: IncludedGenre[];
+  studios?: IncludedStudio[];
+}
+
+/**
+ * One query per relation; every row carries the movie_id it belongs to.
+ * $1 is the movie IDs; cast also takes the per-movie limit as $2.
+ */
+const RELATION_SQL: Record<MovieRelation, string> = {
+  cast: `
+    SELECT *
+    FROM (
+      SELECT
+        ma.movie_id,
+        a.actor_id,
+        a.actor_name,
+        ma.character_name,
+        COALESCE(ma.character_names, '{}') AS character_names,
+        ma.actor_order,
+        ma.billing_order,
+        ma.credited,
+        a.profile_url,
+        ROW_NUMBER() OVER (PARTITION BY ma.movie_id ORDER BY ${CAST_ORDER_SQL}) AS position,
+        COUNT(*) OVER (PARTITION BY ma.movie_id)::int AS cast_total
+      FROM movie_actors ma
+      JOIN actors a ON ma.actor_id = a.actor_id
+      WHERE ma.movie_id = ANY($1::int[])
+    ) ranked
+    WHERE position <= $2
+    ORDER BY movie_id, position
+  `,
+  genres: `
+    SELECT mg.movie_id, g.genre_id, g.genre_name
+    FROM movie_genres mg
+    JOIN genres g ON mg.genre_id = g.genre_id
+    WHERE mg.movie_id = ANY($1::int[])
+    ORDER BY mg.movie_id, g.genre_name
+  `,
+  studios: `
+    SELECT
+      ms.movie_id,
+      s.studio_id,
+      s.studio_name,
+      s.logo_url,
+      ${assetUrlSql('s.logo_url')} AS logo_asset_url,
+      s.country
+    FROM movie_studios ms
+    JOIN studios s ON ms.studio_id = s.studio_id
+    WHERE ms.movie_id = ANY($1::int[])
+    ORDER BY ms.movie_id, s.studio_name
+  `
+};
+
+/**
+ * Loads the requested relations for a set of movies
+ *
+ * @param db - Pool or the caller's transaction client
+ * @returns Each movie's relations; every requested relation is present,
+ *          empty when the movie has none
+ */
+export const loadMovieIncludes = async (
+  movieIds: number[],
+  include: readonly MovieRelation[],
+  { castLimit = DEFAULT_CAST_LIMIT }: MovieIncludeOptions = {},
+  db: Pool | PoolClient = pool
+): Promise<Map<number, MovieIncludes>> => {
+  const relations = [...new Set(include)];
+  const byMovie = new Map<number, MovieIncludes>(
+    movieIds.map(id => [id, Object.fromEntries(relations.map(relation => [relation, []]))])
+  );
+  if (relations.includes('cast')) {
+    byMovie.forEach(includes => { includes.cast_total = 0; });
+  }
+  if (movieIds.length === 0 || relations.length === 0) return byMovie;
+
+  // Run sequentially so a transaction client is never queried concurrently
+  for (const relation of relations) {
+    const params = relation === 'cast' ? [movieIds, castLimit] : [movieIds];
+    const result = await db.query(RELATION_SQL[relation], params);
+    for (const { movie_id, position, cast_total, ...row } of result.rows) {
+      const includes = byMovie.get(movie_id);
+      if (!includes) continue;
+      (includes[relation] as object[]).push(row);
+      if (relation === 'cast') includes.cast_total = cast_total;
+    }
+  }
+
+  return byMovie;
+};
+
+/**
+ * Adds an `included` object holding the requested relations to each movie.
+ * Movies come back in the same order; with nothing to include they're
+ * returned unchanged.
+ */
+export const attachMovieIncludes = async <T extends { movie_id?: number }>(
+  movies: T[],
+  include: readonly MovieRelation[] | undefined,
+  options: MovieIncludeOptions = {},
+  db: Pool | PoolClient = pool
+): Promise<(T & { included?: MovieIncludes })[]> => {
+  if (!include || include.length === 0) return movies;
+
+  const movieIds = movies.flatMap(movie => (movie.movie_id === undefined ? [] : [movie.movie_id]));
+  const byMovie = await loadMovieIncludes(movieIds, include, options, db);
+
+  return movies.map(movie => ({
+    ...movie,
+    included: movie.movie_id === undefined ? undefined : byMovie.get(movie.movie_id)
+  }));
+};
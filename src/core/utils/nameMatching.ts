@@ -0,0 +1,33 @@
+/**
+ * How person and studio names are matched (migration 050): case, accents and
+ * runs of whitespace are ignored, so "WARNER BROS." and "Warner Bros." are
+ * one studio. Lookups compare with the name_key() SQL function; nameKey()
+ * folds names the same way in code, for de-duplicating names before they
+ * reach the database. Keep the two in step. (unaccent also folds a few
+ * letters that aren't accented forms, such as ø and ß; nameKey keeps them.)
+ */
+
+/**
+ * The form a name is compared in
+ */
+export const nameKey = (name: string): string =>
+  name.normalize('NFD').replace(/\p{M}/gu, '').trim().replace(/\s+/g, ' ').toLowerCase();
+
+/**
+ * SQL condition matching a name column against a parameter, using the
+ * column's name_key index
+ */
+export const nameMatchSql = (column: string, placeholder: string): string =>
+  `name_key(${column}) = name_key(${placeholder})`;
+
+/**
+ * Names without repeats under nameKey, keeping the first spelling of each
+ */
+export const uniqueNames = (names: Iterable<string>): string[] => {
+  const seen = new Map<string, string>();
+  for (const name of names) {
+    const key = nameKey(name);
+    if (!seen.has(key)) seen.set(key, name);
+  }
+  return [...seen.values()];
+};
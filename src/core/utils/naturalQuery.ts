@@ -0,0 +1,323 @@
+import pool from './database';
+import { ERA_NAMES } from './inflation';
+import { tracedFetch } from './tracing';
+
+/**
+ * Experimental: turns a request like "comedies from the 90s under 100
+ * minutes" into GET /api/movies filters (genre, startDate/endDate,
+ * maxRuntime...). A small grammar of phrase rules does the parsing; words no
+ * rule understands are returned as unparsed rather than guessed at.
+ *
+ * With NL_QUERY_PARSER=openai the request is first given to an OpenAI chat
+ * model (OPENAI_API_KEY, NL_QUERY_MODEL, default gpt-4o-mini); the rules are
+ * used whenever it fails or answers with filters that don't validate.
+ */
+
+export type NaturalQueryFilters = Record<string, string | number>;
+
+export interface NaturalQuery {
+  filters: NaturalQueryFilters;
+  /** Words the rules didn't understand */
+  unparsed: string[];
+  parser: 'rules' | 'openai';
+}
+
+interface Rule {
+  pattern: RegExp;
+  apply: (match: RegExpExecArray, filters: NaturalQueryFilters) => void;
+}
+
+const GENRE_CACHE_TTL_MS = 10 * 60 * 1000;
+
+/**
+ * Words that end a person's name, e.g. "starring Tom Hanks from the 90s"
+ */
+const NAME_STOP_WORDS = [
+  'from', 'in', 'under', 'over', 'before', 'after', 'since', 'until', 'between', 'rated',
+  'released', 'directed', 'starring', 'featuring', 'with', 'and', 'or', 'that', 'about',
+  'longer', 'shorter', 'less', 'more', 'during', 'by'
+];
+
+const NAME = `([a-z][a-z.'-]*(?:\\s+(?!(?:${NAME_STOP_WORDS.join('|')})\\b)[a-z][a-z.'-]*){0,3})`;
+
+/**
+ * Words that carry no filter ("show me some movies")
+ */
+const FILLER_WORDS = new Set([
+  'a', 'an', 'the', 'me', 'show', 'find', 'list', 'give', 'get', 'i', 'want', 'some', 'any', 'all',
+  'movie', 'movies', 'film', 'films', 'from', 'in', 'of', 'with', 'that', 'are', 'is', 'were',
+  'was', 'and', 'released', 'made', 'came', 'out', 'minutes', 'please', 'which', 'who'
+]);
+
+/**
+ * Other ways of naming a genre; only used when the genre exists
+ */
+const GENRE_ALIASES: Record<string, string> = {
+  'sci-fi': 'Science Fiction',
+  'scifi': 'Science Fiction',
+  'animated': 'Animation',
+  'cartoons': 'Animation',
+  'funny': 'Comedy',
+  'scary': 'Horror',
+  'romantic': 'Romance',
+  'rom-com': 'Romance',
+  'romcom': 'Romance',
+  'docs': 'Documentary'
+};
+
+const MPA_RATING_PATTERN = 'pg-13|nc-17|pg|g|r|nr';
+
+/**
+ * Phrases that ask for an order, longest first
+ */
+const SORT_PHRASES: Record<string, [string, 'asc' | 'desc']> = {
+  'highest grossing': ['revenue', 'desc'],
+  'biggest box office': ['revenue', 'desc'],
+  'most expensive': ['budget', 'desc'],
+  'biggest budget': ['budget', 'desc'],
+  'most popular': ['popularity', 'desc'],
+  'most recent': ['release_date', 'desc'],
+  'popular': ['popularity', 'desc'],
+  'newest': ['release_date', 'desc'],
+  'latest': ['release_date', 'desc'],
+  'recent': ['release_date', 'desc'],
+  'oldest': ['release_date', 'asc'],
+  'longest': ['runtime', 'desc'],
+  'shortest': ['runtime', 'asc']
+};
+
+const MONEY_UNITS: Record<string, number> = {
+  k: 1e3, thousand: 1e3, m: 1e6, million: 1e6, b: 1e9, bn: 1e9, billion: 1e9
+};
+
+const toMinutes = (amount: string, unit: string): number =>
+  Math.round(parseFloat(amount) * (/^h/i.test(unit) ? 60 : 1));
+
+const toDollars = (amount: string, unit: string | undefined): number =>
+  Math.round(parseFloat(amount) * (unit ? MONEY_UNITS[unit.toLowerCase()] : 1));
+
+/**
+ * Full year of a decade like 90s, 1990s or '20s (00s-20s are this century)
+ */
+const decadeStart = (decade: string): number => {
+  const year = parseInt(decade, 10);
+  if (decade.length === 4) return year;
+  return year <= 20 ? 2000 + year : 1900 + year;
+};
+
+const isAbove = (word: string): boolean => /^(over|more than|above|at least|longer than)$/i.test(word);
+
+/**
+ * Phrase rules, applied in order; each match is removed from the text so
+ * later rules don't read it again
+ */
+const RULES: Rule[] = [
+  {
+    pattern: /\b(?:titled|called|named)\s+"([^"]+)"/gi,
+    apply: (match, filters) => { filters.title = match[1]; }
+  },
+  {
+    pattern: new RegExp(`\\b(?:directed by|by director|from director)\\s+${NAME}`, 'gi'),
+    apply: (match, filters) => { filters.director = match[1]; }
+  },
+  {
+    pattern: new RegExp(`\\b(?:starring|featuring|with actor|with actress)\\s+${NAME}`, 'gi'),
+    apply: (match, filters) => { filters.actor = match[1]; }
+  },
+  {
+    pattern: /\b(?:with\s+an?\s+)?(budget|grossing|grossed|revenue|box office|earning|earned)\s+(?:of\s+)?(over|more than|above|at least|under|less than|below|at most)\s+\$?(\d+(?:\.\d+)?)\s*(thousand|million|billion|bn|k|m|b)?\b/gi,
+    apply: (match, filters) => {
+      const field = /budget/i.test(match[1]) ? 'Budget' : 'Revenue';
+      filters[`${isAbove(match[2]) ? 'min' : 'max'}${field}`] = toDollars(match[3], match[4]);
+    }
+  },
+  {
+    pattern: /\b(under|less than|shorter than|at most|no longer than|over|more than|longer than|at least)\s+(\d+(?:\.\d+)?)\s*(minutes?|mins?|hours?|hrs?|h)\b/gi,
+    apply: (match, filters) => {
+      filters[isAbove(match[1]) ? 'minRuntime' : 'maxRuntime'] = toMinutes(match[2], match[3]);
+    }
+  },
+  {
+    pattern: /\bbetween\s+(\d{4})\s+and\s+(\d{4})\b/gi,
+    apply: (match, filters) => {
+      filters.startDate = `${match[1]}-01-01`;
+      filters.endDate = `${match[2]}-12-31`;
+    }
+  },
+  {
+    pattern: /\b(?:the\s+)?'?(\d{2}|\d{4})s\b/gi,
+    apply: (match, filters) => {
+      const start = decadeStart(match[1]);
+      filters.startDate = `${start}-01-01`;
+      filters.endDate = `${start + 9}-12-31`;
+    }
+  },
+  {
+    pattern: /\b(before|until|after|since)\s+(\d{4})\b/gi,
+    apply: (match, filters) => {
+      const year = parseInt(match[2], 10);
+      switch (match[1].toLowerCase()) {
+        case 'before': filters.endDate = `${year - 1}-12-31`; break;
+        case 'until': filters.endDate = `${year}-12-31`; break;
+        case 'after': filters.startDate = `${year + 1}-01-01`; break;
+        default: filters.startDate = `${year}-01-01`;
+      }
+    }
+  },
+  {
+    pattern: /\b((?:18|19|20)\d{2})\b/g,
+    apply: (match, filters) => { filters.year = parseInt(match[1], 10); }
+  },
+  {
+    pattern: new RegExp(`\\b(?:rated\\s+(${MPA_RATING_PATTERN})|(${MPA_RATING_PATTERN})-rated|(pg-13|nc-17|pg))\\b`, 'gi'),
+    apply: (match, filters) => { filters.rating = (match[1] ?? match[2] ?? match[3]).toUpperCase(); }
+  },
+  {
+    // "digital" or "streaming" alone usually isn't about the era, so those need "era" after them
+    pattern: new RegExp(`\\b(?:(${ERA_NAMES.map(era => era.replace(/_/g, '[ _-]')).join('|')})\\s+era|(silent|golden[ -]age|new[ -]hollywood))\\b`, 'gi'),
+    apply: (match, filters) => { filters.era = (match[1] ?? match[2]).toLowerCase().replace(/[ -]/g, '_'); }
+  },
+  {
+    pattern: new RegExp(`\\b(${Object.keys(SORT_PHRASES).map(phrase => phrase.replace(/ /g, '[- ]')).join('|')})\\b`, 'gi'),
+    apply: (match, filters) => {
+      const [sortBy, order] = SORT_PHRASES[match[1].toLowerCase().replace(/-/g, ' ')];
+      filters.sortBy = sortBy;
+      filters.order = order;
+    }
+  }
+];
+
+let genreCache: { names: string[]; loadedAt: number } | null = null;
+
+const getGenreNames = async (): Promise<string[]> => {
+  if (!genreCache || Date.now() - genreCache.loadedAt > GENRE_CACHE_TTL_MS) {
+    const result = await pool.query<{ genre_name: string }>('SELECT genre_name FROM genres');
+    genreCache = { names: result.rows.map(row => row.genre_name), loadedAt: Date.now() };
+  }
+  return genreCache.names;
+};
+
+/**
+ * Every way a genre may be written (Comedy: comedy, comedys, comedies),
+ * longest first so "science fiction" wins over "fiction"
+ */
+const genreVariants = (genres: string[]): [string, string][] => {
+  const variants: [string, string][] = [];
+  for (const genre of genres) {
+    const lower = genre.toLowerCase();
+    variants.push([lower, genre], [`${lower}s`, genre]);
+    if (lower.endsWith('y')) variants.push([`${lower.slice(0, -1)}ies`, genre]);
+  }
+  for (const [alias, genre] of Object.entries(GENRE_ALIASES)) {
+    if (genres.includes(genre)) variants.push([alias, genre]);
+  }
+  return variants.sort((a, b) => b[0].length - a[0].length);
+};
+
+const escapeRegExp = (text: string): string => text.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+
+/**
+ * Parses a request with the phrase rules
+ *
+ * @param text - e.g. "comedies from the 90s under 100 minutes"
+ * @param genres - Genre names in the catalog
+ */
+export const parseNaturalQuery = (text: string, genres: string[]): NaturalQuery => {
+  const filters: NaturalQueryFilters = {};
+  let rest = ` ${text} `;
+
+  for (const rule of RULES) {
+    rest = rest.replace(rule.pattern, (...args) => {
+      const match = args.slice(0, -2) as unknown as RegExpExecArray;
+      rule.apply(match, filters);
+      return ' ';
+    });
+  }
+
+  // GET /movies takes one genre; further genre words stay unparsed
+  for (const [variant, genre] of genreVariants(genres)) {
+    if (filters.genre) break;
+    const pattern = new RegExp(`(^|[^a-z-])${escapeRegExp(variant)}(?![a-z-])`, 'i');
+    if (pattern.test(rest)) {
+      filters.genre = genre;
+      rest = rest.replace(pattern, '$1 ');
+    }
+  }
+
+  const unparsed = rest
+    .toLowerCase()
+    .split(/[^a-z0-9'$.-]+/)
+    .map(word => word.replace(/^[^a-z0-9$]+|[^a-z0-9]+$/g, ''))
+    .filter(word => word && !FILLER_WORDS.has(word));
+
+  return { filters, unparsed, parser: 'rules' };
+};
+
+/**
+ * Asks an OpenAI chat model for the filters
+ */
+const parseWithOpenAi = async (text: string, genres: string[]): Promise<NaturalQueryFilters> => {
+  if (!process.env.OPENAI_API_KEY) {
+    throw new Error('OPENAI_API_KEY is not set');
+  }
+
+  const prompt = [
+    'Translate a movie search request into a JSON object of query filters. Use only these keys:',
+    'title, year, genre (one of the genres below), rating (G, PG, PG-13, R, NC-17, NR), actor, director,',
+    'studio, collection, minBudget, maxBudget, minRevenue, maxRevenue (US dollars), minRuntime, maxRuntime',
+    `(minutes), startDate, endDate (YYYY-MM-DD), era (${ERA_NAMES.join(', ')}),`,
+    'sortBy (popularity, title, release_date, runtime, budget, revenue), order (asc, desc).',
+    `Leave out anything the request does not ask for. Genres: ${genres.join(', ')}`
+  ].join(' ');
+
+  const response = await tracedFetch('https://api.openai.com/v1/chat/completions', {
+    method: 'POST',
+    headers: {
+      'Content-Type': 'application/json',
+      Authorization: `Bearer ${process.env.OPENAI_API_KEY}`
+    },
+    body: JSON.stringify({
+      model: process.env.NL_QUERY_MODEL || 'gpt-4o-mini',
+      temperature: 0,
+      response_format: { type: 'json_object' },
+      messages: [
+        { role: 'system', content: prompt },
+        { role: 'user', content: text }
+      ]
+    }),
+    signal: AbortSignal.timeout(15_000)
+  });
+  if (!response.ok) {
+    throw new Error(`OpenAI answered ${response.status}: ${await response.text()}`);
+  }
+
+  const result = await response.json() as { choices: { message: { content: string } }[] };
+  return JSON.parse(result.choices[0].message.content);
+};
+
+/**
+ * Translates a request into GET /movies filters with the configured parser
+ *
+ * @param text - The request
+ * @param isValid - Checks filters from a model before they are used
+ */
+export const translateNaturalQuery = async (
+  text: string,
+  isValid: (filters: NaturalQueryFilters) => boolean
+): Promise<NaturalQuery> => {
+  const genres = await getGenreNames();
+
+  if (process.env.NL_QUERY_PARSER === 'openai') {
+    try {
+      const filters = await parseWithOpenAi(text, genres);
+      if (isValid(filters)) {
+        return { filters, unparsed: [], parser: 'openai' };
+      }
+      console.warn(`Model filters for "${text}" didn't validate, using the rules instead`);
+    } catch (error) {
+      console.error('Natural-language parsing with OpenAI failed, using the rules instead:', error);
+    }
+  }
+
+  return parseNaturalQuery(text, genres);
+};
@@ -0,0 +1,126 @@
+import { PoolClient } from 'pg';
+import pool from './database';
+
+/**
+ * Postgres LISTEN/NOTIFY for the API process.
+ *
+ * All channels share one connection held out of the pool. If it drops, it's
+ * reopened after RECONNECT_DELAY_MS and every subscriber's onReconnect runs,
+ * since messages sent in between are lost.
+ */
+
+const RECONNECT_DELAY_MS = 5_000;
+
+export interface ChannelSubscriber {
+  onMessage: (payload: string | undefined) => void;
+  /** After the connection is (re)established; messages may have been missed */
+  onReconnect?: () => void;
+}
+
+const subscribers = new Map<string, ChannelSubscriber[]>();
+
+let listener: { client: PoolClient; release: () => void } | null = null;
+let reconnectTimer: NodeJS.Timeout | null = null;
+let stopped = true;
+
+/**
+ * Channel names are identifiers in LISTEN, so only simple ones are accepted
+ */
+const CHANNEL_PATTERN = /^[a-z_][a-z0-9_]*$/;
+
+/**
+ * Subscribes to a channel; takes effect immediately if already listening
+ */
+export const listenTo = (channel: string, subscriber: ChannelSubscriber): void => {
+  if (!CHANNEL_PATTERN.test(channel)) {
+    throw new Error(`Invalid notification channel "${channel}"`);
+  }
+
+  const existing = subscribers.get(channel);
+  subscribers.set(channel, [...(existing ?? []), subscriber]);
+
+  if (listener && !existing) {
+    listener.client.query(`LISTEN ${channel}`)
+      .catch(error => console.error(`Failed to LISTEN on ${channel}:`, error));
+  }
+};
+
+const scheduleReconnect = (): void => {
+  if (stopped || reconnectTimer) return;
+  reconnectTimer = setTimeout(() => {
+    reconnectTimer = null;
+    connect().catch(() => scheduleReconnect());
+  }, RECONNECT_DELAY_MS);
+  reconnectTimer.unref();
+};
+
+const connect = async (): Promise<void> => {
+  const client = await pool.connect();
+
+  // A broken connection can both fail a query and emit 'error'; release once
+  let released = false;
+  const release = (error?: Error) => {
+    if (released) return;
+    released = true;
+    // Closed rather than returned to the pool, where it would still be LISTENing
+    client.release(error ?? true);
+  };
+
+  client.on('notification', message => {
+    for (const subscriber of subscribers.get(message.channel) ?? []) {
+      try {
+        subscriber.onMessage(message.payload);
+      } catch (error) {
+        console.error(`Error handling ${message.channel} notification:`, error);
+      }
+    }
+  });
+  client.on('error', error => {
+    console.error('Notification connection lost:', error.message);
+    if (listener?.client === client) listener = null;
+    release(error);
+    scheduleReconnect();
+  });
+
+  try {
+    for (const channel of subscribers.keys()) {
+      await client.query(`LISTEN ${channel}`);
+    }
+  } catch (error) {
+    release(error instanceof Error ? error : undefined);
+    throw error;
+  }
+
+  if (stopped) {
+    release();
+    return;
+  }
+  listener = { client, release };
+  subscribers.forEach(list => list.forEach(subscriber => subscriber.onReconnect?.()));
+};
+
+/**
+ * Opens the listening connection; keeps retrying in the background if the
+ * database isn't reachable yet
+ */
+export const startNotifications = async (): Promise<void> => {
+  if (!stopped) return;
+  stopped = false;
+
+  try {
+    await connect();
+  } catch (error) {
+    console.error('Could not listen for notifications, retrying:', error);
+    scheduleReconnect();
+  }
+};
+
+export const stopNotifications = (): void => {
+  stopped = true;
+  if (reconnectTimer) {
+    clearTimeout(reconnectTimer);
+    reconnectTimer = null;
+  }
+  listener?.release();
+  listener = null;
+};
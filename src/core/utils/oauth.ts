@@ -0,0 +1,378 @@
+import crypto from 'crypto';
+import jwt from 'jsonwebtoken';
+import pool from './database';
+import { tracedFetch } from './tracing';
+
+/**
+ * OAuth2 / OpenID Connect login (authorization code flow with PKCE).
+ *
+ * Providers are enabled by setting OAUTH_<PROVIDER>_CLIENT_ID and
+ * OAUTH_<PROVIDER>_CLIENT_SECRET (plus OAUTH_<PROVIDER>_ISSUER where there is
+ * no default). Endpoints and signing keys come from the issuer's discovery
+ * document, so any standards-compliant provider works.
+ */
+
+interface ProviderDefinition {
+  label: string;
+  envPrefix: string;
+  defaultIssuer?: string;
+}
+
+export interface OAuthProvider {
+  name: string;
+  label: string;
+  issuer: string;
+  clientId: string;
+  clientSecret: string;
+}
+
+export interface OAuthIdentity {
+  provider: string;
+  subject: string;
+  email?: string;
+  emailVerified: boolean;
+  name?: string;
+}
+
+interface DiscoveryDocument {
+  issuer: string;
+  authorization_endpoint: string;
+  token_endpoint: string;
+  jwks_uri: string;
+}
+
+const PROVIDERS: Record<string, ProviderDefinition> = {
+  google: {
+    label: 'Google',
+    envPrefix: 'OAUTH_GOOGLE',
+    defaultIssuer: 'https://accounts.google.com'
+  },
+  // UW NetID sign-in goes through the university's Entra ID tenant; set
+  // OAUTH_UW_ISSUER to https://login.microsoftonline.com/<tenant id>/v2.0
+  uw: {
+    label: 'UW NetID',
+    envPrefix: 'OAUTH_UW'
+  }
+};
+
+/**
+ * How long a user has to finish signing in at the provider
+ */
+const STATE_TTL_MINUTES = 10;
+
+const METADATA_TTL_MS = 60 * 60 * 1000;
+
+/**
+ * Cookie tying a sign-in to the browser that started it. It holds a hash of
+ * the state, so a callback URL carrying someone else's state (login CSRF) is
+ * refused.
+ */
+export const OAUTH_STATE_COOKIE = 'oauth-state';
+export const OAUTH_STATE_COOKIE_MAX_AGE_MS = STATE_TTL_MINUTES * 60 * 1000;
+
+/**
+ * A sign-in that failed because of the request or the provider's answer
+ * rather than a server fault
+ */
+export class OAuthError extends Error {}
+
+const discoveryCache = new Map<string, { document: DiscoveryDocument; fetchedAt: number }>();
+const jwksCache = new Map<string, { keys: crypto.JsonWebKey[]; fetchedAt: number }>();
+
+/**
+ * A provider's configuration, or undefined when unknown or not configured
+ */
+export const getOAuthProvider = (name: string): OAuthProvider | undefined => {
+  const definition = PROVIDERS[name];
+  if (!definition) return undefined;
+
+  const issuer = process.env[`${definition.envPrefix}_ISSUER`] ?? definition.defaultIssuer;
+  const clientId = process.env[`${definition.envPrefix}_CLIENT_ID`];
+  const clientSecret = process.env[`${definition.envPrefix}_CLIENT_SECRET`];
+  if (!issuer || !clientId || !clientSecret) return undefined;
+
+  return { name, label: definition.label, issuer: issuer.replace(/\/+$/, ''), clientId, clientSecret };
+};
+
+/**
+ * Providers this deployment has credentials for
+ */
+export const getOAuthProviders = (): OAuthProvider[] =>
+  Object.keys(PROVIDERS)
+    .map(getOAuthProvider)
+    .filter((provider): provider is OAuthProvider => provider !== undefined);
+
+const fetchJson = async <T>(url: string, init?: RequestInit): Promise<T> => {
+  const response = await tracedFetch(url, init);
+  const body = await response.json().catch(() => undefined);
+  if (!response.ok) {
+    throw new OAuthError(`Provider request failed with ${response.status}: ${JSON.stringify(body)}`);
+  }
+  return body as T;
+};
+
+const getDiscoveryDocument = async (issuer: string): Promise<DiscoveryDocument> => {
+  const cached = discoveryCache.get(issuer);
+  if (cached && Date.now() - cached.fetchedAt < METADATA_TTL_MS) {
+    return cached.document;
+  }
+
+  const document = await fetchJson<DiscoveryDocument>(`${issuer}/.well-known/openid-configuration`);
+  discoveryCache.set(issuer, { document, fetchedAt: Date.now() });
+  return document;
+};
+
+/**
+ * The provider's public key with the given ID. Keys rotate, so an unknown ID
+ * refetches the key set once before giving up.
+ */
+const getSigningKey = async (jwksUri: string, kid: string | undefined): Promise<crypto.KeyObject> => {
+  for (const forceRefresh of [false, true]) {
+    let cached = jwksCache.get(jwksUri);
+    if (forceRefresh || !cached || Date.now() - cached.fetchedAt >= METADATA_TTL_MS) {
+      const { keys } = await fetchJson<{ keys: crypto.JsonWebKey[] }>(jwksUri);
+      cached = { keys, fetchedAt: Date.now() };
+      jwksCache.set(jwksUri, cached);
+    }
+
+    const jwk = cached.keys.find(key => key.kid === kid) ?? (kid ? undefined : cached.keys[0]);
+    if (jwk) {
+      return crypto.createPublicKey({ key: jwk, format: 'jwk' });
+    }
+  }
+
+  throw new OAuthError('ID token signed with an unknown key');
+};
+
+const base64url = (bytes: Buffer): string => bytes.toString('base64url');
+
+const hashState = (state: string): string => base64url(crypto.createHash('sha256').update(state).digest());
+
+/**
+ * Whether the state cookie the browser sent belongs to the callback's state
+ */
+const stateMatchesCookie = (state: string, cookie: string | undefined): boolean => {
+  if (!cookie) return false;
+  const expected = Buffer.from(hashState(state));
+  const presented = Buffer.from(cookie);
+  return presented.length === expected.length && crypto.timingSafeEqual(presented, expected);
+};
+
+/**
+ * Starts a sign-in: stores a single-use state with its PKCE verifier and nonce
+ * and returns the provider URL to send the user to, with the value for the
+ * OAUTH_STATE_COOKIE the caller sets on the browser
+ *
+ * @param provider - Configured provider
+ * @param redirectUri - This API's callback URL for the provider
+ */
+export const createAuthorizationUrl = async (
+  provider: OAuthProvider,
+  redirectUri: string
+): Promise<{ url: string; stateCookie: string }> => {
+  const discovery = await getDiscoveryDocument(provider.issuer);
+
+  const state = base64url(crypto.randomBytes(24));
+  const nonce = base64url(crypto.randomBytes(24));
+  const codeVerifier = base64url(crypto.randomBytes(48));
+  const codeChallenge = base64url(crypto.createHash('sha256').update(codeVerifier).digest());
+
+  // Abandoned sign-ins are cleaned up as new ones start
+  await pool.query(
+    `DELETE FROM oauth_states WHERE created_at < NOW() - $1 * INTERVAL '1 minute'`,
+    [STATE_TTL_MINUTES]
+  );
+  await pool.query(
+    'INSERT INTO oauth_states (state, provider, code_verifier, nonce) VALUES ($1, $2, $3, $4)',
+    [state, provider.name, codeVerifier, nonce]
+  );
+
+  const url = new URL(discovery.authorization_endpoint);
+  url.search = new URLSearchParams({
+    response_type: 'code',
+    client_id: provider.clientId,
+    redirect_uri: redirectUri,
+    scope: 'openid email profile',
+    state,
+    nonce,
+    code_challenge: codeChallenge,
+    code_challenge_method: 'S256'
+  }).toString();
+
+  return { url: url.toString(), stateCookie: hashState(state) };
+};
+
+/**
+ * Finishes a sign-in: checks the state against the browser's state cookie,
+ * consumes it, exchanges the code and verifies the returned ID token's
+ * signature, issuer, audience, expiry and nonce
+ *
+ * @param provider - Configured provider
+ * @param code - Authorization code from the callback
+ * @param state - State from the callback
+ * @param stateCookie - OAUTH_STATE_COOKIE sent with the callback
+ * @param redirectUri - Same callback URL used to start the sign-in
+ */
+export const completeAuthorization = async (
+  provider: OAuthProvider,
+  code: string,
+  state: string,
+  stateCookie: string | undefined,
+  redirectUri: string
+): Promise<OAuthIdentity> => {
+  // Checked before the state is consumed, so a forged callback can't burn it
+  if (!stateMatchesCookie(state, stateCookie)) {
+    throw new OAuthError('Sign-in was not started in this browser; start again');
+  }
+
+  const stateResult = await pool.query<{ code_verifier: string; nonce: string }>(
+    `DELETE FROM oauth_states
+     WHERE state = $1 AND provider = $2 AND created_at > NOW() - $3 * INTERVAL '1 minute'
+     RETURNING code_verifier, nonce`,
+    [state, provider.name, STATE_TTL_MINUTES]
+  );
+  const pending = stateResult.rows[0];
+  if (!pending) {
+    throw new OAuthError('Sign-in request expired or was already used; start again');
+  }
+
+  const discovery = await getDiscoveryDocument(provider.issuer);
+  const tokens = await fetchJson<{ id_token?: string }>(discovery.token_endpoint, {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/x-www-form-urlencoded', Accept: 'application/json' },
+    body: new URLSearchParams({
+      grant_type: 'authorization_code',
+      code,
+      redirect_uri: redirectUri,
+      client_id: provider.clientId,
+      client_secret: provider.clientSecret,
+      code_verifier: pending.code_verifier
+    }).toString()
+  });
+  if (!tokens.id_token) {
+    throw new OAuthError('Provider did not return an ID token');
+  }
+
+  const decoded = jwt.decode(tokens.id_token, { complete: true });
+  if (!decoded || typeof decoded.payload === 'string') {
+    throw new OAuthError('Malformed ID token');
+  }
+
+  const key = await getSigningKey(discovery.jwks_uri, decoded.header.kid);
+  let claims: jwt.JwtPayload;
+  try {
+    claims = jwt.verify(tokens.id_token, key, {
+      algorithms: ['RS256', 'ES256'],
+      audience: provider.clientId,
+      issuer: discovery.issuer
+    }) as jwt.JwtPayload;
+  } catch (error) {
+    throw new OAuthError(`Invalid ID token: ${error instanceof Error ? error.message : error}`);
+  }
+
+  if (claims.nonce !== pending.nonce || !claims.sub) {
+    throw new OAuthError('ID token does not match this sign-in');
+  }
+
+  return {
+    provider: provider.name,
+    subject: claims.sub,
+    email: typeof claims.email === 'string' ? claims.email.toLowerCase() : undefined,
+    emailVerified: claims.email_verified === true || claims.email_verified === 'true',
+    name: typeof claims.name === 'string' ? claims.name : undefined
+  };
+};
+
+/**
+ * Username for a new account: the email's local part (or the provider's name
+ * for the user), made unique with a numeric suffix when taken
+ */
+const pickUsername = async (identity: OAuthIdentity): Promise<string> => {
+  const base = (identity.email?.split('@')[0] ?? identity.name ?? `${identity.provider}-user`)
+    .toLowerCase()
+    .replace(/[^a-z0-9._-]+/g, '')
+    .slice(0, 40)
+    .padEnd(3, '0');
+
+  const taken = await pool.query<{ username: string }>(
+    'SELECT username FROM users WHERE username = $1 OR username LIKE $2',
+    [base, `${base}-%`]
+  );
+  const names = new Set(taken.rows.map(row => row.username));
+  if (!names.has(base)) return base;
+
+  for (let i = 2; ; i++) {
+    if (!names.has(`${base}-${i}`)) return `${base}-${i}`;
+  }
+};
+
+/**
+ * Local user for an external identity. Known identities map to their user;
+ * otherwise a verified email matching an existing account links to it, and
+ * anything else gets a new account with the 'user' role and no password.
+ *
+ * @returns The user and whether the account was just created
+ */
+export const findOrCreateOAuthUser = async (
+  identity: OAuthIdentity
+): Promise<{ user: { user_id: number; username: string; role: string }; created: boolean }> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const linked = await client.query(
+      `UPDATE user_identities SET last_login_at = NOW()
+       WHERE provider = $1 AND subject = $2
+       RETURNING user_id`,
+      [identity.provider, identity.subject]
+    );
+
+    let userId: number | undefined = linked.rows[0]?.user_id;
+    let created = false;
+
+    if (userId === undefined) {
+      if (identity.email) {
+        const existing = await client.query(
+          'SELECT user_id FROM users WHERE LOWER(email) = $1',
+          [identity.email]
+        );
+        if (existing.rows.length > 0 && !identity.emailVerified) {
+          throw new OAuthError('An account with this email already exists; log in with its password');
+        }
+        userId = existing.rows[0]?.user_id;
+      }
+
+      if (userId === undefined) {
+        if (!identity.email) {
+          throw new OAuthError('Provider did not share an email address');
+        }
+        const inserted = await client.query(
+          `INSERT INTO users (email, username, role) VALUES ($1, $2, 'user') RETURNING user_id`,
+          [identity.email, await pickUsername(identity)]
+        );
+        userId = inserted.rows[0].user_id as number;
+        created = true;
+      }
+
+      await client.query(
+        `INSERT INTO user_identities (provider, subject, user_id, email, last_login_at)
+         VALUES ($1, $2, $3, $4, NOW())`,
+        [identity.provider, identity.subject, userId, identity.email ?? null]
+      );
+    }
+
+    const user = await client.query(
+      'SELECT user_id, username, role FROM users WHERE user_id = $1',
+      [userId]
+    );
+
+    await client.query('COMMIT');
+    return { user: user.rows[0], created };
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
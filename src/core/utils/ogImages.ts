@@ -0,0 +1,396 @@
+import crypto from 'crypto';
+import pool from './database';
+import { nonAdultCondition } from './contentFilter';
+import { decodeGray, GrayImage } from './images';
+import { Canvas, Color, hsl, textHeight, textWidth } from './imageComposition';
+import { wrapText } from './placeholderPosters';
+import { tracedFetch } from './tracing';
+
+/**
+ * Open Graph preview images (1200x630 PNG) for movie, person, director and
+ * genre pages, so links shared in chat apps and social networks show a card:
+ * the title, a few stats, and the poster (movies), profile photo (people) or
+ * a collage of their best-known posters.
+ *
+ * Pictures are drawn as duotones in the card's color because only the luma
+ * of a JPEG can be decoded here (see images). Cards are rendered on request
+ * and kept in an in-process LRU keyed by their content, so the next request
+ * is a lookup, and an edit to the movie or person draws a new one.
+ */
+
+export const OG_IMAGE_TYPES = ['movie', 'person', 'director', 'genre'] as const;
+export type OgImageType = (typeof OG_IMAGE_TYPES)[number];
+
+const WIDTH = 1200;
+const HEIGHT = 630;
+const MARGIN = 40;
+const FOOTER_HEIGHT = 40;
+
+/**
+ * Feature picture (poster or profile photo) size, 2:3
+ */
+const FEATURE_WIDTH = 340;
+const FEATURE_HEIGHT = 510;
+
+const COLLAGE_GAP = 14;
+const COLLAGE_SIZE = 6;
+
+const RENDERED_CACHE_SIZE = 200;
+const PICTURE_CACHE_SIZE = 500;
+
+const MAX_PICTURE_BYTES = 5 * 1024 * 1024;
+const FETCH_TIMEOUT_MS = 5_000;
+
+const BRAND = 'TCSS 460 Movie API';
+
+export interface OgCard {
+  type: OgImageType;
+  title: string;
+  /** Short lines under the title, e.g. "2010 - PG-13 - 148 min" */
+  lines: string[];
+  /** Poster or profile photo shown on the left */
+  featureUrl: string | null;
+  /** Posters shown under the text */
+  collageUrls: string[];
+  /** Changes whenever anything drawn on the card does */
+  etag: string;
+}
+
+/**
+ * Least recently used entries first (Map keeps insertion order)
+ */
+const rendered = new Map<string, Buffer>();
+const pictures = new Map<string, GrayImage | null>();
+
+const remember = <T>(cache: Map<string, T>, key: string, value: T, limit: number): void => {
+  cache.delete(key);
+  cache.set(key, value);
+  while (cache.size > limit) {
+    const oldest = cache.keys().next().value;
+    if (oldest === undefined) break;
+    cache.delete(oldest);
+  }
+};
+
+const compactNumber = (value: number): string =>
+  value >= 1e9 ? `${(value / 1e9).toFixed(1)}B`
+    : value >= 1e6 ? `${(value / 1e6).toFixed(1)}M`
+    : value >= 1e3 ? `${(value / 1e3).toFixed(1)}K`
+    : String(value);
+
+const ratingLine = (average: number | null, count: number | null): string | null =>
+  count && average !== null
+    ? `* ${(Math.round(average * 10) / 10).toFixed(1)}/10 (${compactNumber(count)} votes)`
+    : null;
+
+const yearSpan = (first: number | null, last: number | null): string | null =>
+  first === null ? null : first === last ? String(first) : `${first}-${last}`;
+
+/**
+ * A card from what it shows; empty lines are dropped
+ */
+const buildCard = (
+  type: OgImageType,
+  fields: Omit<OgCard, 'type' | 'lines' | 'etag'> & { lines: (string | null)[] }
+): OgCard => ({
+  type,
+  ...fields,
+  lines: fields.lines.filter((line): line is string => Boolean(line)),
+  etag: `"${crypto.createHash('sha1').update(JSON.stringify([type, fields])).digest('hex')}"`
+});
+
+// ============================================================================
+// Card data
+// ============================================================================
+
+const loadMovieCard = async (id: string): Promise<OgCard | null> => {
+  // Integer ids only resolve where MOVIE_ID_STRATEGY still accepts them
+  const numericId = /^\d+$/.test(id) && process.env.MOVIE_ID_STRATEGY !== 'public'
+    ? parseInt(id, 10)
+    : null;
+
+  const result = await pool.query(
+    `SELECT m.title, EXTRACT(YEAR FROM m.release_date)::int AS year, m.mpa_rating,
+            m.runtime_minutes, m.vote_average::float8, m.vote_count, m.revenue::float8, m.poster_url,
+            (SELECT STRING_AGG(g.genre_name, ', ' ORDER BY g.genre_name)
+             FROM movie_genres mg JOIN genres g ON g.genre_id = mg.genre_id
+             WHERE mg.movie_id = m.movie_id) AS genres,
+            (SELECT STRING_AGG(d.director_name, ', ' ORDER BY d.director_name)
+             FROM movie_directors md JOIN directors d ON d.director_id = md.director_id
+             WHERE md.movie_id = m.movie_id) AS directors
+     FROM movies m
+     WHERE (m.public_id = $1 OR m.movie_id = $2)
+       AND ${nonAdultCondition('m')}
+     LIMIT 1`,
+    [id, numericId]
+  );
+  if (result.rows.length === 0) {
+    return null;
+  }
+
+  const movie = result.rows[0];
+  return buildCard('movie', {
+    title: movie.title,
+    lines: [
+      [movie.year, movie.mpa_rating, movie.runtime_minutes ? `${movie.runtime_minutes} min` : null]
+        .filter(Boolean).join(' - '),
+      movie.genres,
+      ratingLine(movie.vote_average, movie.vote_count),
+      movie.directors ? `Directed by ${movie.directors}` : null,
+      movie.revenue ? `Box office $${compactNumber(movie.revenue)}` : null
+    ],
+    featureUrl: movie.poster_url,
+    collageUrls: []
+  });
+};
+
+interface Filmography {
+  movie_count: number;
+  first_year: number | null;
+  last_year: number | null;
+  average_rating: number | null;
+  top_titles: string[] | null;
+  posters: string[] | null;
+}
+
+/**
+ * Count, years and best-known posters of the movies matching a condition
+ * on m (with the ID as $1)
+ */
+const loadFilmography = async (condition: string, id: number): Promise<Filmography> => {
+  const result = await pool.query<Filmography>(
+    `SELECT COUNT(*)::int AS movie_count,
+            MIN(EXTRACT(YEAR FROM m.release_date))::int AS first_year,
+            MAX(EXTRACT(YEAR FROM m.release_date))::int AS last_year,
+            AVG(m.vote_average) FILTER (WHERE m.vote_count > 0)::float8 AS average_rating,
+            (ARRAY_AGG(m.title ORDER BY m.popularity DESC NULLS LAST, m.vote_count DESC NULLS LAST))[1:3] AS top_titles,
+            (ARRAY_AGG(m.poster_url ORDER BY m.popularity DESC NULLS LAST, m.vote_count DESC NULLS LAST)
+               FILTER (WHERE m.poster_url IS NOT NULL))[1:${COLLAGE_SIZE}] AS posters
+     FROM movies m
+     WHERE ${condition} AND ${nonAdultCondition('m')}`,
+    [id]
+  );
+  return result.rows[0];
+};
+
+/**
+ * "12 movies - 1994-2019" and "Known for ..." lines
+ */
+const filmographyLines = (films: Filmography): [string, string] => [
+  [`${films.movie_count} movie${films.movie_count === 1 ? '' : 's'}`, yearSpan(films.first_year, films.last_year)]
+    .filter(Boolean).join(' - '),
+  films.top_titles?.length ? `Known for ${films.top_titles.join(', ')}` : ''
+];
+
+const loadPersonCard = async (id: number): Promise<OgCard | null> => {
+  const person = await pool.query<{ actor_name: string; profile_url: string | null }>(
+    'SELECT actor_name, profile_url FROM actors WHERE actor_id = $1',
+    [id]
+  );
+  if (person.rows.length === 0) {
+    return null;
+  }
+
+  const films = await loadFilmography(
+    'EXISTS (SELECT 1 FROM movie_actors ma WHERE ma.movie_id = m.movie_id AND ma.actor_id = $1)',
+    id
+  );
+  return buildCard('person', {
+    title: person.rows[0].actor_name,
+    lines: filmographyLines(films),
+    featureUrl: person.rows[0].profile_url,
+    collageUrls: films.posters ?? []
+  });
+};
+
+const loadDirectorCard = async (id: number): Promise<OgCard | null> => {
+  const director = await pool.query<{ director_name: string }>(
+    'SELECT director_name FROM directors WHERE director_id = $1',
+    [id]
+  );
+  if (director.rows.length === 0) {
+    return null;
+  }
+
+  const films = await loadFilmography(
+    'EXISTS (SELECT 1 FROM movie_directors md WHERE md.movie_id = m.movie_id AND md.director_id = $1)',
+    id
+  );
+  const [summary, knownFor] = filmographyLines(films);
+  return buildCard('director', {
+    title: director.rows[0].director_name,
+    lines: [`Director - ${summary}`, knownFor],
+    featureUrl: null,
+    collageUrls: films.posters ?? []
+  });
+};
+
+const loadGenreCard = async (id: number): Promise<OgCard | null> => {
+  const genre = await pool.query<{ genre_name: string }>(
+    'SELECT genre_name FROM genres WHERE genre_id = $1',
+    [id]
+  );
+  if (genre.rows.length === 0) {
+    return null;
+  }
+
+  const films = await loadFilmography(
+    'EXISTS (SELECT 1 FROM movie_genres mg WHERE mg.movie_id = m.movie_id AND mg.genre_id = $1)',
+    id
+  );
+  return buildCard('genre', {
+    title: genre.rows[0].genre_name,
+    lines: [
+      filmographyLines(films)[0],
+      films.average_rating !== null ? `Average rating ${films.average_rating.toFixed(1)}/10` : ''
+    ],
+    featureUrl: null,
+    collageUrls: films.posters ?? []
+  });
+};
+
+/**
+ * What a page's card shows
+ *
+ * @param id - movie_id or public_id for movies, the integer ID otherwise
+ * @returns null when there is no such page (or the movie is adult content)
+ */
+export const loadOgCard = async (type: OgImageType, id: string): Promise<OgCard | null> => {
+  if (type === 'movie') {
+    return loadMovieCard(id);
+  }
+  if (!/^\d+$/.test(id)) {
+    return null;
+  }
+  switch (type) {
+    case 'person': return loadPersonCard(parseInt(id, 10));
+    case 'director': return loadDirectorCard(parseInt(id, 10));
+    default: return loadGenreCard(parseInt(id, 10));
+  }
+};
+
+// ============================================================================
+// Drawing
+// ============================================================================
+
+/**
+ * Downloads and decodes a picture; failures are remembered too, so a dead
+ * URL isn't fetched for every card that shows it
+ */
+const loadPicture = async (url: string): Promise<GrayImage | null> => {
+  if (pictures.has(url)) {
+    const picture = pictures.get(url)!;
+    remember(pictures, url, picture, PICTURE_CACHE_SIZE);
+    return picture;
+  }
+
+  let picture: GrayImage | null = null;
+  try {
+    const response = await tracedFetch(url, { signal: AbortSignal.timeout(FETCH_TIMEOUT_MS) });
+    if (response.ok && Number(response.headers.get('content-length') ?? 0) <= MAX_PICTURE_BYTES) {
+      const data = Buffer.from(await response.arrayBuffer());
+      picture = data.length <= MAX_PICTURE_BYTES ? decodeGray(data) : null;
+    }
+  } catch (error) {
+    console.warn(`Couldn't load ${url} for an OG image:`, error instanceof Error ? error.message : error);
+  }
+  remember(pictures, url, picture, PICTURE_CACHE_SIZE);
+  return picture;
+};
+
+/**
+ * Wraps text into at most maxLines lines that fit width at a scale, cutting
+ * the last one with an ellipsis
+ */
+const fitLines = (text: string, width: number, scale: number, maxLines: number): string[] => {
+  const charsPerLine = Math.max(1, Math.floor((width + scale) / (6 * scale)));
+  const lines = wrapText(text, charsPerLine);
+  if (lines.length <= maxLines) {
+    return lines;
+  }
+  const kept = lines.slice(0, maxLines);
+  kept[maxLines - 1] = `${kept[maxLines - 1].slice(0, charsPerLine - 3).trimEnd()}...`;
+  return kept;
+};
+
+/**
+ * Draws a card
+ */
+const drawCard = async (card: OgCard): Promise<Buffer> => {
+  const hue = crypto.createHash('sha1').update(card.title).digest().readUInt16BE(0) % 360;
+  const dark: Color = hsl(hue, 0.5, 0.08);
+  const light: Color = hsl(hue, 0.3, 0.92);
+  const muted: Color = hsl(hue, 0.25, 0.78);
+
+  const canvas = new Canvas(WIDTH, HEIGHT);
+  canvas.fillGradient(hsl(hue, 0.45, 0.24), dark);
+
+  const [feature, ...collage] = await Promise.all([
+    card.featureUrl ? loadPicture(card.featureUrl) : null,
+    ...card.collageUrls.map(loadPicture)
+  ]);
+
+  let left = MARGIN;
+  if (card.featureUrl) {
+    canvas.fillRect(MARGIN, MARGIN, FEATURE_WIDTH, FEATURE_HEIGHT, hsl(hue, 0.35, 0.3));
+    if (feature) {
+      canvas.drawGrayImage(feature, MARGIN, MARGIN, FEATURE_WIDTH, FEATURE_HEIGHT, dark, light);
+    }
+    left += FEATURE_WIDTH + MARGIN;
+  }
+  const columnWidth = WIDTH - MARGIN - left;
+
+  // Titles that need more than two lines get a smaller font
+  const titleScale = fitLines(card.title, columnWidth, 7, Infinity).length > 2 ? 5 : 7;
+  const titleLines = fitLines(card.title, columnWidth, titleScale, titleScale === 7 ? 2 : 3);
+
+  let y = MARGIN + 10;
+  for (const line of titleLines) {
+    canvas.drawText(line, left, y, titleScale, [255, 255, 255]);
+    y += textHeight(titleScale) + 3 * titleScale;
+  }
+  y += 16;
+
+  for (const text of card.lines) {
+    for (const line of fitLines(text, columnWidth, 4, 2)) {
+      canvas.drawText(line, left, y, 4, muted);
+      y += textHeight(4) + 12;
+    }
+    y += 6;
+  }
+
+  // Posters along the bottom of the text column
+  const posters = collage.filter((picture): picture is GrayImage => picture !== null);
+  const collageTop = y + 10;
+  const collageHeight = HEIGHT - FOOTER_HEIGHT - MARGIN / 2 - collageTop;
+  const posterWidth = Math.round(collageHeight * 2 / 3);
+  if (posters.length > 0 && posterWidth >= 60) {
+    const fits = Math.floor((columnWidth + COLLAGE_GAP) / (posterWidth + COLLAGE_GAP));
+    posters.slice(0, fits).forEach((poster, i) => {
+      canvas.drawGrayImage(poster, left + i * (posterWidth + COLLAGE_GAP), collageTop, posterWidth, collageHeight, dark, light);
+    });
+  }
+
+  // Footer bar with the site name
+  canvas.fillRect(0, HEIGHT - FOOTER_HEIGHT, WIDTH, FOOTER_HEIGHT, hsl(hue, 0.55, 0.4));
+  canvas.drawText(BRAND, MARGIN, HEIGHT - FOOTER_HEIGHT + (FOOTER_HEIGHT - textHeight(3)) / 2, 3, [255, 255, 255]);
+  const label = card.type.toUpperCase();
+  canvas.drawText(label, WIDTH - MARGIN - textWidth(label, 3), HEIGHT - FOOTER_HEIGHT + (FOOTER_HEIGHT - textHeight(3)) / 2, 3, light);
+
+  return canvas.toPng();
+};
+
+/**
+ * A card's PNG, drawn on first request and cached by its ETag
+ */
+export const renderOgImage = async (card: OgCard): Promise<Buffer> => {
+  const cached = rendered.get(card.etag);
+  if (cached) {
+    remember(rendered, card.etag, cached, RENDERED_CACHE_SIZE);
+    return cached;
+  }
+
+  const png = await drawCard(card);
+  remember(rendered, card.etag, png, RENDERED_CACHE_SIZE);
+  return png;
+};
@@ -0,0 +1,79 @@
+import type { Enricher, MovieMetadata } from './enrichers';
+import { sanitizeText } from './sanitize';
+import { tracedFetch } from './tracing';
+
+/**
+ * OMDb (IMDb data) as a metadata provider (see enrichers). Needs an API key
+ * in OMDB_API_KEY.
+ *
+ * Supplies the overview (full plot), runtime, poster and IMDb rating and
+ * vote count. OMDb's box office figure is US-only, so revenue isn't taken
+ * from it. "N/A" means unknown and comes back as null.
+ */
+
+const API_URL = 'https://www.omdbapi.com/';
+
+interface OmdbMovie {
+  Response: 'True' | 'False';
+  Error?: string;
+  Plot?: string;
+  Runtime?: string;
+  Poster?: string;
+  imdbRating?: string;
+  imdbVotes?: string;
+}
+
+const known = (value: string | undefined): string | null =>
+  value && value !== 'N/A' ? value : null;
+
+/**
+ * Leading number of values like "148 min" or "2,345,678"
+ */
+const parseNumber = (value: string | undefined): number | null => {
+  const digits = known(value)?.replace(/,/g, '').match(/^\d+(\.\d+)?/);
+  return digits ? Number(digits[0]) : null;
+};
+
+/**
+ * Looks a movie up by exact title and release year
+ *
+ * @returns Its metadata, or null if OMDb has no match
+ */
+const fetchOmdbMetadata = async (title: string, year: number | null): Promise<MovieMetadata | null> => {
+  const url = new URL(API_URL);
+  url.searchParams.set('apikey', process.env.OMDB_API_KEY ?? '');
+  url.searchParams.set('t', title);
+  url.searchParams.set('type', 'movie');
+  url.searchParams.set('plot', 'full');
+  if (year) {
+    url.searchParams.set('y', String(year));
+  }
+
+  const response = await tracedFetch(url);
+  if (!response.ok) {
+    throw new Error(`OMDb lookup failed with ${response.status}`);
+  }
+
+  const movie = await response.json() as OmdbMovie;
+  if (movie.Response !== 'True') {
+    // "Movie not found!" is a miss; anything else (bad key, limit reached) is an error
+    if (movie.Error?.toLowerCase().includes('not found')) {
+      return null;
+    }
+    throw new Error(`OMDb: ${movie.Error ?? 'lookup failed'}`);
+  }
+
+  return {
+    overview: sanitizeText(known(movie.Plot)),
+    runtime_minutes: parseNumber(movie.Runtime) || null,
+    poster_url: known(movie.Poster),
+    vote_count: parseNumber(movie.imdbVotes) || null,
+    vote_average: parseNumber(movie.imdbRating)
+  };
+};
+
+export const omdbEnricher: Enricher = {
+  name: 'omdb',
+  isConfigured: () => Boolean(process.env.OMDB_API_KEY),
+  lookup: fetchOmdbMetadata
+};
@@ -0,0 +1,141 @@
+import path from 'path';
+import YAML from 'yamljs';
+
+/**
+ * The OpenAPI spec in api-docs/swagger.yaml, read for tools that work from
+ * it (the Postman collection, the mock server), and example values built
+ * from its schemas for operations that don't give one.
+ */
+
+export const API_SPEC_PATH = path.join(__dirname, '../../../api-docs/swagger.yaml');
+
+export const HTTP_METHODS = ['get', 'post', 'put', 'patch', 'delete'] as const;
+export type HttpMethod = (typeof HTTP_METHODS)[number];
+
+export interface OpenApiSchema {
+  $ref?: string;
+  type?: string;
+  format?: string;
+  example?: unknown;
+  default?: unknown;
+  enum?: unknown[];
+  properties?: Record<string, OpenApiSchema>;
+  required?: string[];
+  items?: OpenApiSchema;
+  allOf?: OpenApiSchema[];
+  oneOf?: OpenApiSchema[];
+  anyOf?: OpenApiSchema[];
+  additionalProperties?: boolean | OpenApiSchema;
+}
+
+export interface OpenApiMedia {
+  schema?: OpenApiSchema;
+  example?: unknown;
+}
+
+export interface OpenApiResponse {
+  $ref?: string;
+  description?: string;
+  headers?: Record<string, unknown>;
+  content?: Record<string, OpenApiMedia>;
+}
+
+export interface OpenApiOperation {
+  operationId?: string;
+  summary?: string;
+  description?: string;
+  tags?: string[];
+  requestBody?: { content?: Record<string, OpenApiMedia> };
+  responses?: Record<string, OpenApiResponse>;
+}
+
+export interface OpenApiSpec {
+  paths: Record<string, Partial<Record<HttpMethod, OpenApiOperation>>>;
+  components: {
+    schemas: Record<string, OpenApiSchema>;
+    responses?: Record<string, OpenApiResponse>;
+  };
+}
+
+/**
+ * Deepest nesting filled in; self-referencing schemas stop here
+ */
+const MAX_EXAMPLE_DEPTH = 6;
+
+/**
+ * Fixed so generated examples are the same from run to run
+ */
+const EXAMPLE_DATE_TIME = '2024-01-01T00:00:00.000Z';
+
+let spec: OpenApiSpec | null | undefined;
+
+/**
+ * The spec, read once; null when it can't be read
+ */
+export const loadApiSpec = (): OpenApiSpec | null => {
+  if (spec === undefined) {
+    try {
+      spec = YAML.load(API_SPEC_PATH) as OpenApiSpec;
+    } catch (error) {
+      console.warn(`Couldn't read ${API_SPEC_PATH}:`, error instanceof Error ? error.message : error);
+      spec = null;
+    }
+  }
+  return spec;
+};
+
+/**
+ * The component a #/components/... reference points at
+ */
+export const resolveRef = <T>(current: OpenApiSpec, ref: string): T | undefined => {
+  const [, , section, name] = ref.split('/');
+  return (current.components as unknown as Record<string, Record<string, T> | undefined>)[section]?.[name];
+};
+
+/**
+ * A response with its $ref followed
+ */
+export const resolveResponse = (current: OpenApiSpec, response: OpenApiResponse): OpenApiResponse =>
+  response.$ref ? resolveRef<OpenApiResponse>(current, response.$ref) ?? {} : response;
+
+/**
+ * Example value for a schema: its example, or one built from its type
+ *
+ * @param full - Fill in every property and one array item (for responses);
+ *               otherwise only required properties and empty arrays (for
+ *               request bodies to edit)
+ */
+export const exampleFor = (current: OpenApiSpec, schema: OpenApiSchema | undefined, full = false, depth = 0): unknown => {
+  if (!schema || depth > MAX_EXAMPLE_DEPTH) return null;
+  if (schema.$ref) return exampleFor(current, resolveRef<OpenApiSchema>(current, schema.$ref), full, depth + 1);
+  if (schema.example !== undefined) return schema.example;
+  if (schema.allOf) {
+    return Object.assign({}, ...schema.allOf.map(part => exampleFor(current, part, full, depth + 1)));
+  }
+  const alternatives = schema.oneOf ?? schema.anyOf;
+  if (alternatives) return exampleFor(current, alternatives[0], full, depth + 1);
+  if (schema.enum) return schema.enum[0];
+  if (schema.default !== undefined) return schema.default;
+
+  if (schema.type === 'object' || schema.properties) {
+    // Required fields only, or every field when none are marked required
+    const names = !full && schema.required?.length ? schema.required : Object.keys(schema.properties ?? {});
+    return Object.fromEntries(names.map(name => [name, exampleFor(current, schema.properties?.[name], full, depth + 1)]));
+  }
+  switch (schema.type) {
+    case 'array': return full ? [exampleFor(current, schema.items, full, depth + 1)] : [];
+    case 'string':
+      if (!full) return '';
+      switch (schema.format) {
+        case 'date': return EXAMPLE_DATE_TIME.slice(0, 10);
+        case 'date-time': return EXAMPLE_DATE_TIME;
+        case 'email': return 'user@example.com';
+        case 'uri': return 'https://example.com';
+        default: return 'string';
+      }
+    case 'integer': return full ? 1 : 0;
+    case 'number': return full ? 1.5 : 0;
+    case 'boolean': return full;
+    default: return null;
+  }
+};
@@ -0,0 +1,220 @@
+import { Pool, PoolClient } from 'pg';
+import pool from './database';
+import { detectImage, hashDistance, perceptualHash } from './images';
+import { tracedFetch } from './tracing';
+
+/**
+ * Actor profile images (person_images, migration 033).
+ *
+ * Imports record every profile URL they see for an actor. The person-images
+ * job later downloads new ones, hashes them, and per actor groups photos
+ * whose perceptual hashes are within DUPLICATE_DISTANCE bits. The largest
+ * copy in each group is kept as its representative; the canonical image is
+ * the representative of the group holding the current canonical photo, so
+ * a sharper copy of the same photo replaces it but a different photo
+ * doesn't. Images that fail to download can't stay canonical; they're
+ * tried again after RETRY_FAILED_AFTER.
+ */
+
+/**
+ * Hash bits two images may differ by and still count as one photo
+ */
+const DUPLICATE_DISTANCE = 10;
+
+/**
+ * Images downloaded per job run
+ */
+const HASH_BATCH_SIZE = 200;
+
+const MAX_IMAGE_BYTES = 10 * 1024 * 1024;
+const FETCH_TIMEOUT_MS = 10_000;
+
+const RETRY_FAILED_AFTER = '7 days';
+
+interface PersonImageRow {
+  image_id: number;
+  url: string;
+  width: number | null;
+  height: number | null;
+  phash: string | null;
+  fetch_error: string | null;
+  is_canonical: boolean;
+}
+
+/**
+ * Records a profile URL for an actor; the first one becomes canonical
+ *
+ * @param db - Pool or the caller's transaction client
+ */
+export const recordPersonImage = async (db: Pool | PoolClient, actorId: number, url: string): Promise<void> => {
+  await db.query(
+    `INSERT INTO person_images (actor_id, url, is_canonical)
+     VALUES ($1, $2, NOT EXISTS (SELECT 1 FROM person_images WHERE actor_id = $1 AND is_canonical))
+     ON CONFLICT (actor_id, url) DO NOTHING`,
+    [actorId, url]
+  );
+};
+
+/**
+ * Downloads an image and reads its type, size and hash
+ */
+const inspectImage = async (url: string) => {
+  const response = await tracedFetch(url, { signal: AbortSignal.timeout(FETCH_TIMEOUT_MS) });
+  if (!response.ok) {
+    throw new Error(`HTTP ${response.status}`);
+  }
+  if (Number(response.headers.get('content-length') ?? 0) > MAX_IMAGE_BYTES) {
+    throw new Error('Image too large');
+  }
+
+  const data = Buffer.from(await response.arrayBuffer());
+  const info = detectImage(data);
+  if (!info) {
+    throw new Error('Not an image');
+  }
+  return { ...info, phash: perceptualHash(data) };
+};
+
+/**
+ * Regroups one actor's hashed images and picks the canonical one
+ *
+ * @returns Whether the actor's profile_url changed
+ */
+export const reconcilePersonImages = async (client: PoolClient, actorId: number): Promise<boolean> => {
+  const actor = await client.query<{ profile_url: string | null }>(
+    'SELECT profile_url FROM actors WHERE actor_id = $1 FOR UPDATE',
+    [actorId]
+  );
+  if (actor.rows.length === 0) return false;
+
+  const allImages = (await client.query<PersonImageRow>(
+    `SELECT image_id, url, width, height, phash, fetch_error, is_canonical
+     FROM person_images
+     WHERE actor_id = $1
+     ORDER BY image_id`,
+    [actorId]
+  )).rows;
+  const current = allImages.find(image => image.is_canonical);
+  const images = allImages.filter(image => image.phash !== null);
+  if (images.length === 0) return false;
+
+  // Union-find over near-identical hashes
+  const parent = images.map((_, i) => i);
+  const find = (i: number): number => (parent[i] === i ? i : (parent[i] = find(parent[i])));
+  for (let i = 0; i < images.length; i++) {
+    for (let j = i + 1; j < images.length; j++) {
+      if (hashDistance(images[i].phash!, images[j].phash!) <= DUPLICATE_DISTANCE) {
+        parent[find(j)] = find(i);
+      }
+    }
+  }
+
+  const groups = new Map<number, PersonImageRow[]>();
+  images.forEach((image, i) => {
+    const root = find(i);
+    groups.set(root, [...(groups.get(root) ?? []), image]);
+  });
+
+  // Largest copy represents each group; ties go to the oldest
+  const area = (image: PersonImageRow) => (image.width ?? 0) * (image.height ?? 0);
+  const representatives = new Map<PersonImageRow[], PersonImageRow>();
+  for (const group of groups.values()) {
+    representatives.set(group, group.reduce((best, image) => (area(image) > area(best) ? image : best)));
+  }
+
+  for (const [group, representative] of representatives) {
+    await client.query(
+      `UPDATE person_images
+       SET duplicate_of = CASE WHEN image_id = $2 THEN NULL ELSE $2 END
+       WHERE image_id = ANY($1::int[])`,
+      [group.map(image => image.image_id), representative.image_id]
+    );
+  }
+
+  // A working canonical image that can't be hashed (GIF, WebP) stays
+  if (current && current.phash === null && current.fetch_error === null) return false;
+
+  const groupList = [...groups.values()];
+  const canonicalGroup =
+    groupList.find(group => group.some(image => image.is_canonical))
+    ?? groupList.find(group => group.some(image => image.url === actor.rows[0].profile_url))
+    ?? groupList.reduce((largest, group) => (group.length > largest.length ? group : largest));
+  const canonical = representatives.get(canonicalGroup)!;
+
+  // Clear first; the partial unique index allows one canonical per actor
+  await client.query(
+    'UPDATE person_images SET is_canonical = FALSE WHERE actor_id = $1 AND is_canonical AND image_id <> $2',
+    [actorId, canonical.image_id]
+  );
+  await client.query(
+    'UPDATE person_images SET is_canonical = TRUE WHERE image_id = $1',
+    [canonical.image_id]
+  );
+
+  if (actor.rows[0].profile_url === canonical.url) return false;
+  await client.query(
+    'UPDATE actors SET profile_url = $2 WHERE actor_id = $1',
+    [actorId, canonical.url]
+  );
+  return true;
+};
+
+/**
+ * Hashes images not seen yet and regroups the actors they belong to
+ * (the person-images job)
+ *
+ * @returns Summary for job_runs
+ */
+export const hashPersonImages = async (): Promise<string> => {
+  const pending = await pool.query<{ image_id: number; actor_id: number; url: string }>(
+    `SELECT image_id, actor_id, url
+     FROM person_images
+     WHERE hashed_at IS NULL
+        OR (fetch_error IS NOT NULL AND hashed_at < NOW() - $2::interval)
+     ORDER BY image_id
+     LIMIT $1`,
+    [HASH_BATCH_SIZE, RETRY_FAILED_AFTER]
+  );
+
+  let failed = 0;
+  const actorIds = new Set<number>();
+
+  for (const image of pending.rows) {
+    try {
+      const info = await inspectImage(image.url);
+      await pool.query(
+        `UPDATE person_images
+         SET content_type = $2, width = $3, height = $4, phash = $5,
+             fetch_error = NULL, hashed_at = NOW()
+         WHERE image_id = $1`,
+        [image.image_id, info.contentType, info.width, info.height, info.phash]
+      );
+    } catch (error) {
+      failed++;
+      await pool.query(
+        `UPDATE person_images
+         SET fetch_error = $2, hashed_at = NOW()
+         WHERE image_id = $1`,
+        [image.image_id, (error instanceof Error ? error.message : String(error)).slice(0, 200)]
+      );
+    }
+    actorIds.add(image.actor_id);
+  }
+
+  let updated = 0;
+  for (const actorId of actorIds) {
+    const client = await pool.connect();
+    try {
+      await client.query('BEGIN');
+      if (await reconcilePersonImages(client, actorId)) updated++;
+      await client.query('COMMIT');
+    } catch (error) {
+      await client.query('ROLLBACK');
+      console.error(`Error reconciling images for actor ${actorId}:`, error);
+    } finally {
+      client.release();
+    }
+  }
+
+  return `${pending.rows.length - failed} images hashed, ${failed} failed, ${updated} profile photos changed`;
+};
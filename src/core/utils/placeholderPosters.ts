@@ -0,0 +1,140 @@
+import crypto from 'crypto';
+import { Pool, PoolClient } from 'pg';
+import { escapeXml } from './sanitize';
+
+/**
+ * Stand-in posters for movies without a poster_url, so front-end grids have
+ * an image for every movie. Each is an SVG (title and year on a tinted 2:3
+ * card) generated when the movie is written and stored in
+ * placeholder_posters; GET /api/movies/:id/placeholder.svg serves it.
+ */
+
+const WIDTH = 500;
+const HEIGHT = 750;
+
+/**
+ * Title lines before the rest is cut with an ellipsis
+ */
+const MAX_TITLE_LINES = 5;
+
+/**
+ * Wraps text on spaces into lines of at most width characters; words longer
+ * than a line are split
+ */
+export const wrapText = (text: string, width: number): string[] => {
+  const lines: string[] = [];
+  let current = '';
+  for (const word of text.split(/\s+/).filter(Boolean)) {
+    let rest = word;
+    while (rest.length > width) {
+      if (current) lines.push(current);
+      lines.push(rest.slice(0, width));
+      rest = rest.slice(width);
+      current = '';
+    }
+    if (!current) {
+      current = rest;
+    } else if (current.length + 1 + rest.length <= width) {
+      current += ` ${rest}`;
+    } else {
+      lines.push(current);
+      current = rest;
+    }
+  }
+  if (current) lines.push(current);
+  return lines;
+};
+
+/**
+ * Renders a placeholder poster; the background hue comes from the title so
+ * a grid of them isn't one flat color
+ *
+ * @param title - Movie title
+ * @param year - Release year, if known
+ * @returns SVG document
+ */
+export const renderPlaceholderPoster = (title: string, year: number | null): string => {
+  const hue = crypto.createHash('sha1').update(title).digest().readUInt16BE(0) % 360;
+
+  // Long titles get a smaller font so they fit in fewer lines
+  const fontSize = title.length > 40 ? 40 : 52;
+  const charsPerLine = Math.floor((WIDTH - 80) / (fontSize * 0.58));
+  let lines = wrapText(title, charsPerLine);
+  if (lines.length > MAX_TITLE_LINES) {
+    lines = lines.slice(0, MAX_TITLE_LINES);
+    lines[MAX_TITLE_LINES - 1] = `${lines[MAX_TITLE_LINES - 1].slice(0, charsPerLine - 1).trimEnd()}…`;
+  }
+
+  const lineHeight = Math.round(fontSize * 1.2);
+  const blockHeight = lines.length * lineHeight + (year !== null ? 70 : 0);
+  const firstBaseline = Math.round((HEIGHT - blockHeight) / 2 + fontSize);
+
+  return [
+    `<svg xmlns="http://www.w3.org/2000/svg" width="${WIDTH}" height="${HEIGHT}" viewBox="0 0 ${WIDTH} ${HEIGHT}">`,
+    '<defs>',
+    '<linearGradient id="bg" x1="0" y1="0" x2="0" y2="1">',
+    `<stop offset="0" stop-color="hsl(${hue}, 45%, 32%)"/>`,
+    `<stop offset="1" stop-color="hsl(${hue}, 50%, 12%)"/>`,
+    '</linearGradient>',
+    '</defs>',
+    `<rect width="${WIDTH}" height="${HEIGHT}" fill="url(#bg)"/>`,
+    `<rect x="20" y="20" width="${WIDTH - 40}" height="${HEIGHT - 40}" fill="none" stroke="#fff" stroke-opacity="0.25" stroke-width="2"/>`,
+    '<g fill="#fff" font-family="Helvetica, Arial, sans-serif" text-anchor="middle">',
+    `<text x="${WIDTH / 2}" y="80" font-size="18" letter-spacing="6" fill-opacity="0.6">NO POSTER</text>`,
+    ...lines.map((line, i) =>
+      `<text x="${WIDTH / 2}" y="${firstBaseline + i * lineHeight}" font-size="${fontSize}" font-weight="bold">${escapeXml(line)}</text>`
+    ),
+    ...(year !== null
+      ? [`<text x="${WIDTH / 2}" y="${firstBaseline + (lines.length - 1) * lineHeight + 70}" font-size="32" fill-opacity="0.8">${year}</text>`]
+      : []),
+    '</g>',
+    '</svg>'
+  ].join('\n');
+};
+
+/**
+ * Generates stored placeholders for movies without a poster_url whose
+ * placeholder is missing or shows an old title or year, and drops those of
+ * movies that have a poster now. Call after writing a movie.
+ *
+ * @param db - Pool or the caller's transaction client
+ * @param movieIds - Movies to check; omit for every movie
+ * @returns Number of placeholders generated
+ */
+export const generatePlaceholderPosters = async (db: Pool | PoolClient, movieIds?: number[]): Promise<number> => {
+  if (movieIds && movieIds.length === 0) return 0;
+
+  await db.query(
+    `DELETE FROM placeholder_posters pp
+     USING movies m
+     WHERE m.movie_id = pp.movie_id
+       AND m.poster_url IS NOT NULL
+       AND ($1::int[] IS NULL OR m.movie_id = ANY($1::int[]))`,
+    [movieIds ?? null]
+  );
+
+  const stale = await db.query<{ movie_id: number; title: string; release_year: number | null }>(
+    `SELECT m.movie_id, m.title, EXTRACT(YEAR FROM m.release_date)::int AS release_year
+     FROM movies m
+     LEFT JOIN placeholder_posters pp ON pp.movie_id = m.movie_id
+     WHERE m.poster_url IS NULL
+       AND ($1::int[] IS NULL OR m.movie_id = ANY($1::int[]))
+       AND (pp.movie_id IS NULL
+            OR pp.title IS DISTINCT FROM m.title
+            OR pp.release_year IS DISTINCT FROM EXTRACT(YEAR FROM m.release_date)::int)`,
+    [movieIds ?? null]
+  );
+
+  for (const movie of stale.rows) {
+    await db.query(
+      `INSERT INTO placeholder_posters (movie_id, title, release_year, svg)
+       VALUES ($1, $2, $3, $4)
+       ON CONFLICT (movie_id) DO UPDATE
+       SET title = EXCLUDED.title, release_year = EXCLUDED.release_year,
+           svg = EXCLUDED.svg, generated_at = NOW()`,
+      [movie.movie_id, movie.title, movie.release_year, renderPlaceholderPoster(movie.title, movie.release_year)]
+    );
+  }
+
+  return stale.rows.length;
+};
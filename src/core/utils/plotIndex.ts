@@ -0,0 +1,110 @@
+import pool from './database';
+
+/**
+ * TF-IDF vectors of movie overviews, for "movies like this plot" when no
+ * embeddings are available (see embeddings). Postgres' english text search
+ * config does the stemming and stop words; each term is weighted
+ * (1 + ln tf) * ln(N / df) and vectors are normalized, so the dot product of
+ * two movies is their cosine similarity.
+ *
+ * The index is built in memory on first use and rebuilt once it's older than
+ * PLOT_INDEX_TTL_MS, so new and edited overviews show up within the hour.
+ */
+
+const PLOT_INDEX_TTL_MS = 60 * 60 * 1000;
+
+interface PlotIndex {
+  /** Normalized term weights of each movie */
+  vectors: Map<number, Map<string, number>>;
+  /** Movies containing each term, with the term's weight */
+  postings: Map<string, { movieId: number; weight: number }[]>;
+  builtAt: number;
+}
+
+let index: PlotIndex | null = null;
+let building: Promise<PlotIndex> | null = null;
+
+const buildIndex = async (): Promise<PlotIndex> => {
+  const result = await pool.query<{ movie_id: number; terms: Record<string, number> }>(
+    `SELECT m.movie_id, JSON_OBJECT_AGG(t.lexeme, COALESCE(ARRAY_LENGTH(t.positions, 1), 1)) AS terms
+     FROM movies m
+     CROSS JOIN LATERAL UNNEST(TO_TSVECTOR('english', m.overview)) AS t
+     WHERE m.overview IS NOT NULL AND m.overview <> ''
+     GROUP BY m.movie_id`
+  );
+
+  const documentFrequency = new Map<string, number>();
+  for (const row of result.rows) {
+    for (const term of Object.keys(row.terms)) {
+      documentFrequency.set(term, (documentFrequency.get(term) ?? 0) + 1);
+    }
+  }
+
+  const total = result.rows.length;
+  const vectors = new Map<number, Map<string, number>>();
+  const postings = new Map<string, { movieId: number; weight: number }[]>();
+
+  for (const row of result.rows) {
+    const weights = new Map<string, number>();
+    for (const [term, frequency] of Object.entries(row.terms)) {
+      const weight = (1 + Math.log(frequency)) * Math.log(total / documentFrequency.get(term)!);
+      if (weight > 0) weights.set(term, weight);
+    }
+
+    const norm = Math.sqrt([...weights.values()].reduce((sum, weight) => sum + weight * weight, 0));
+    if (norm === 0) continue;
+
+    for (const [term, weight] of weights) {
+      weights.set(term, weight / norm);
+      const list = postings.get(term) ?? [];
+      list.push({ movieId: row.movie_id, weight: weight / norm });
+      postings.set(term, list);
+    }
+    vectors.set(row.movie_id, weights);
+  }
+
+  return { vectors, postings, builtAt: Date.now() };
+};
+
+const getPlotIndex = async (): Promise<PlotIndex> => {
+  if (index && Date.now() - index.builtAt < PLOT_INDEX_TTL_MS) {
+    return index;
+  }
+  // Concurrent requests share one build
+  building ??= buildIndex()
+    .then(built => (index = built))
+    .finally(() => { building = null; });
+  return building;
+};
+
+/**
+ * Movies whose overview shares the most distinctive words with a movie's
+ *
+ * @param movieId - Movie to compare against
+ * @param limit - Candidates to return
+ * @returns Movie IDs with their cosine similarity, most similar first; empty
+ *   when the movie has no overview
+ */
+export const findSimilarPlots = async (
+  movieId: number,
+  limit: number
+): Promise<{ movieId: number; similarity: number }[]> => {
+  const { vectors, postings } = await getPlotIndex();
+  const vector = vectors.get(movieId);
+  if (!vector) {
+    return [];
+  }
+
+  const scores = new Map<number, number>();
+  for (const [term, weight] of vector) {
+    for (const posting of postings.get(term) ?? []) {
+      if (posting.movieId === movieId) continue;
+      scores.set(posting.movieId, (scores.get(posting.movieId) ?? 0) + weight * posting.weight);
+    }
+  }
+
+  return [...scores.entries()]
+    .sort((a, b) => b[1] - a[1])
+    .slice(0, limit)
+    .map(([id, similarity]) => ({ movieId: id, similarity }));
+};
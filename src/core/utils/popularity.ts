@@ -0,0 +1,124 @@
+import { Client } from 'pg';
+
+/**
+ * Composite popularity score per movie, between 0 and 1:
+ * - revenue: percentile of inflation-adjusted revenue
+ * - votes: percentile of audience vote count
+ * - rating: audience votes combined with approved user reviews, pulled toward
+ *   the catalog mean when a movie has few (Bayesian average), so one 10/10
+ *   vote doesn't top the list
+ * - recency: halves every RECENCY_HALF_LIFE_YEARS since release
+ */
+const WEIGHTS = {
+  revenue: 0.35,
+  votes: 0.25,
+  rating: 0.2,
+  recency: 0.2
+};
+
+/**
+ * Votes a rating needs before it counts as much as the catalog mean
+ */
+const RATING_PRIOR_VOTES = 50;
+
+const RECENCY_HALF_LIFE_YEARS = 3;
+
+/**
+ * How long the recompute may run. The shared pool gives up on queries after
+ * 4 seconds, far less than scoring a large catalog takes.
+ */
+const REFRESH_TIMEOUT_MS = 10 * 60_000;
+
+/**
+ * Movies written per UPDATE, so row locks are held briefly rather than on
+ * the whole catalog at once
+ */
+const UPDATE_BATCH_SIZE = 5000;
+
+/**
+ * Recomputes every movie's popularity score
+ *
+ * Scores are computed once into a temporary table (percentiles need the whole
+ * catalog), then copied onto movies in movie_id batches. Runs on its own
+ * connection and timeout so it neither holds a pool client nor hits the
+ * pool's query timeout.
+ *
+ * @returns Number of movies scored
+ */
+export const refreshPopularityScores = async (): Promise<number> => {
+  const client = new Client({
+    connectionString: process.env.DB_URL,
+    connectionTimeoutMillis: 2000,
+    statement_timeout: REFRESH_TIMEOUT_MS
+  });
+  await client.connect();
+
+  try {
+    await client.query(
+      `CREATE TEMP TABLE popularity_scores AS
+       WITH catalog AS (
+         SELECT COALESCE(AVG(vote_average) FILTER (WHERE vote_count > 0), 0) AS mean_rating
+         FROM movies
+       ),
+       user_ratings AS (
+         SELECT movie_id, COUNT(*) AS review_count, SUM(rating) AS rating_total
+         FROM reviews
+         WHERE status = 'approved'
+         GROUP BY movie_id
+       ),
+       components AS (
+         SELECT
+           m.movie_id,
+           PERCENT_RANK() OVER (ORDER BY COALESCE(m.revenue_adjusted, m.revenue, 0)) AS revenue,
+           PERCENT_RANK() OVER (ORDER BY COALESCE(m.vote_count, 0)) AS votes,
+           (COALESCE(m.vote_count, 0) * COALESCE(m.vote_average, 0)
+             + COALESCE(ur.rating_total, 0) + $1 * c.mean_rating)
+             / (COALESCE(m.vote_count, 0) + COALESCE(ur.review_count, 0) + $1) / 10 AS rating,
+           CASE WHEN m.release_date IS NULL THEN 0
+                ELSE POWER(0.5, GREATEST(CURRENT_DATE - m.release_date, 0) / ($2 * 365.25))
+           END AS recency
+         FROM movies m
+         CROSS JOIN catalog c
+         LEFT JOIN user_ratings ur ON ur.movie_id = m.movie_id
+       )
+       SELECT movie_id,
+              ROUND(($3 * revenue + $4 * votes + $5 * rating + $6 * recency)::numeric, 4) AS popularity
+       FROM components`,
+      [
+        RATING_PRIOR_VOTES,
+        RECENCY_HALF_LIFE_YEARS,
+        WEIGHTS.revenue,
+        WEIGHTS.votes,
+        WEIGHTS.rating,
+        WEIGHTS.recency
+      ]
+    );
+    await client.query('CREATE INDEX ON popularity_scores (movie_id)');
+
+    let scored = 0;
+    let lastMovieId = 0;
+    for (;;) {
+      const next = await client.query<{ until: number | null }>(
+        `SELECT MAX(movie_id) AS until
+         FROM (SELECT movie_id FROM popularity_scores WHERE movie_id > $1 ORDER BY movie_id LIMIT $2) batch`,
+        [lastMovieId, UPDATE_BATCH_SIZE]
+      );
+      const until = next.rows[0].until;
+      if (until === null) break;
+
+      const updated = await client.query(
+        `UPDATE movies m
+         SET popularity = s.popularity,
+             popularity_updated_at = NOW()
+         FROM popularity_scores s
+         WHERE m.movie_id = s.movie_id AND s.movie_id > $1 AND s.movie_id <= $2`,
+        [lastMovieId, until]
+      );
+      scored += updated.rowCount ?? 0;
+      lastMovieId = until;
+    }
+    return scored;
+  } finally {
+    await client.end();
+  }
+};
@@ -0,0 +1,236 @@
+import { Router } from 'express';
+import { exampleFor, HttpMethod, loadApiSpec, OpenApiSpec } from './openApi';
+import { CSRF_COOKIE } from './sessions';
+
+/**
+ * A Postman (v2.1) collection built from the routers the server is actually
+ * running, so it can't drift from the routes the way a hand-kept export does.
+ * Hoppscotch imports the same format.
+ *
+ * Auth follows the route's middleware and the server's configuration: routes
+ * behind the API key check send {{apiKey}} (none in READ_ONLY mode), admin
+ * routes {{adminApiKey}} plus the bearer token, and account routes the
+ * {{accessToken}} bearer token (or the session cookie when
+ * AUTH_MODE=session). A collection-level test
+ * script saves the key from POST /api-key and the token from login/register,
+ * so a run can start with no variables set. Names and example bodies come
+ * from api-docs/swagger.yaml where the route is documented there.
+ */
+
+const POSTMAN_SCHEMA = 'https://schema.getpostman.com/json/collection/v2.1.0/collection.json';
+
+const BODY_METHODS = ['POST', 'PUT', 'PATCH'];
+
+export interface RouteInfo {
+  method: string;
+  /** Express path under the mount point, e.g. /movies/:id */
+  path: string;
+  /** Names of the route's own middleware and handlers */
+  handlers: string[];
+  /** Whether the router checks API keys before the route */
+  requiresApiKey: boolean;
+}
+
+export interface PostmanOptions {
+  /** Where the routers are mounted, e.g. https://example.com/api */
+  baseUrl: string;
+  readOnly: boolean;
+  tokensEnabled: boolean;
+  sessionsEnabled: boolean;
+}
+
+interface RouterLayer {
+  name: string;
+  route?: {
+    path: string | string[];
+    methods: Record<string, boolean>;
+    stack: { name: string }[];
+  };
+}
+
+/**
+ * Routes of a router in registration order. Router-level middleware
+ * (router.use) named requireApiKeyUnlessReadOnly marks the router's routes
+ * as needing a key.
+ */
+export const listRoutes = (router: Router): RouteInfo[] => {
+  const stack = (router as unknown as { stack: RouterLayer[] }).stack;
+  const requiresApiKey = stack.some(layer => !layer.route && layer.name === 'requireApiKeyUnlessReadOnly');
+
+  return stack.flatMap(layer => {
+    if (!layer.route) return [];
+    const { route } = layer;
+    const paths = Array.isArray(route.path) ? route.path : [route.path];
+    const methods = Object.keys(route.methods).filter(method => method !== '_all');
+    return paths.flatMap(routePath => methods.map(method => ({
+      method: method.toUpperCase(),
+      path: routePath,
+      handlers: route.stack.map(handler => handler.name),
+      requiresApiKey
+    })));
+  });
+};
+
+/**
+ * Request auth, plus any header it needs besides the auth block
+ */
+const routeAuth = (route: RouteInfo, options: PostmanOptions): { auth?: object; header: object[] } => {
+  const apiKey = (variable: string) => ({
+    type: 'apikey',
+    apikey: [
+      { key: 'key', value: 'X-API-Key', type: 'string' },
+      { key: 'value', value: `{{${variable}}}`, type: 'string' },
+      { key: 'in', value: 'header', type: 'string' }
+    ]
+  });
+  const needsKey = route.requiresApiKey && !options.readOnly;
+
+  if (route.handlers.includes('requireAdmin')) {
+    // Admin routes also want the signed-in (2FA) admin user
+    return {
+      auth: apiKey('adminApiKey'),
+      header: options.tokensEnabled ? [{ key: 'Authorization', value: 'Bearer {{accessToken}}' }] : []
+    };
+  }
+  if (route.handlers.includes('requireUser') && options.tokensEnabled) {
+    return {
+      auth: { type: 'bearer', bearer: [{ key: 'token', value: '{{accessToken}}', type: 'string' }] },
+      header: needsKey ? [{ key: 'X-API-Key', value: '{{apiKey}}' }] : []
+    };
+  }
+  // Inherit the collection's API key, or send nothing (session routes ride on the cookie jar)
+  return needsKey ? { header: [] } : { auth: { type: 'noauth' }, header: [] };
+};
+
+/**
+ * One Postman request for a route
+ */
+const buildItem = (route: RouteInfo, apiPrefix: string, options: PostmanOptions, current: OpenApiSpec | null): object => {
+  const openApiPath = `${apiPrefix}${route.path.replace(/:(\w+)/g, '{$1}')}`;
+  const operation = current?.paths[openApiPath]?.[route.method.toLowerCase() as HttpMethod];
+
+  // Whole-segment parameters become Postman path variables, others collection variables
+  const segments = route.path.split('/').filter(Boolean).map(segment =>
+    /^:\w+$/.test(segment) ? segment : segment.replace(/:(\w+)/g, '{{$1}}'));
+  const pathVariables = segments.filter(segment => segment.startsWith(':')).map(segment => ({ key: segment.slice(1), value: '' }));
+
+  const { auth, header } = routeAuth(route, options);
+  const request: Record<string, unknown> = {
+    method: route.method,
+    header,
+    url: {
+      raw: `{{baseUrl}}/${segments.join('/')}`,
+      host: ['{{baseUrl}}'],
+      path: segments,
+      ...(pathVariables.length > 0 ? { variable: pathVariables } : {})
+    },
+    ...(auth ? { auth } : {}),
+    ...(operation?.description ? { description: operation.description.trim() } : {})
+  };
+
+  if (BODY_METHODS.includes(route.method)) {
+    const media = operation?.requestBody?.content?.['application/json'];
+    const example = media?.example ?? (media ? exampleFor(current!, media.schema) : {});
+    request.body = {
+      mode: 'raw',
+      raw: JSON.stringify(example, null, 2),
+      options: { raw: { language: 'json' } }
+    };
+  }
+
+  return {
+    name: operation?.summary ?? `${route.method} ${route.path}`,
+    request,
+    response: []
+  };
+};
+
+const folderName = (routePath: string): string => {
+  const first = routePath.split('/').filter(Boolean)[0] ?? '';
+  return first ? first[0].toUpperCase() + first.slice(1).replace(/[-_]/g, ' ') : 'Root';
+};
+
+/**
+ * Saves the API key and access token from responses that issue them
+ */
+const SAVE_CREDENTIALS_SCRIPT = [
+  'let body = null;',
+  'try { body = pm.response.json(); } catch (e) { /* not JSON */ }',
+  "if (body && body.api_key) pm.collectionVariables.set('apiKey', body.api_key);",
+  "if (body && body.jwt && body.jwt.accessToken) pm.collectionVariables.set('accessToken', body.jwt.accessToken);"
+];
+
+/**
+ * Copies the CSRF cookie into the header session writes need
+ */
+const CSRF_SCRIPT = [
+  `const csrf = pm.cookies.get('${CSRF_COOKIE}');`,
+  "if (csrf && pm.request.method !== 'GET') pm.request.headers.upsert({ key: 'X-CSRF-Token', value: csrf });"
+];
+
+/**
+ * Builds the collection
+ *
+ * @param routers - Routers mounted at options.baseUrl, in mount order
+ * @param apiPrefix - Their mount path as the spec writes it, e.g. /api
+ */
+export const buildPostmanCollection = (routers: Router[], apiPrefix: string, options: PostmanOptions): object => {
+  const current = loadApiSpec();
+  const folders = new Map<string, object[]>();
+  const seen = new Set<string>();
+  const pathVariables = new Set<string>();
+
+  for (const route of routers.flatMap(listRoutes)) {
+    // A route registered on two routers is only reachable through the first
+    const key = `${route.method} ${route.path}`;
+    if (seen.has(key)) continue;
+    seen.add(key);
+
+    // Parameters inside a segment (/og/:type/:id.png) need a collection variable
+    for (const segment of route.path.split('/')) {
+      if (/^:\w+$/.test(segment)) continue;
+      for (const [, name] of segment.matchAll(/:(\w+)/g)) pathVariables.add(name);
+    }
+    const folder = folderName(route.path);
+    folders.set(folder, [...(folders.get(folder) ?? []), buildItem(route, apiPrefix, options, current)]);
+  }
+
+  const authSummary = [
+    options.readOnly ? 'read-only (no API key needed for reads)' : 'API key in X-API-Key',
+    options.tokensEnabled ? 'bearer tokens for account routes' : null,
+    options.sessionsEnabled ? 'cookie sessions with CSRF header' : null
+  ].filter(Boolean).join('; ');
+
+  return {
+    info: {
+      name: 'TCSS 460 Movie API',
+      description: `Generated from the running server's routes at ${new Date().toISOString()}. Auth: ${authSummary}. ` +
+        'Run POST /api-key (or set apiKey) first; login fills accessToken.',
+      schema: POSTMAN_SCHEMA
+    },
+    item: [...folders.entries()].map(([name, item]) => ({ name, item })),
+    auth: options.readOnly
+      ? { type: 'noauth' }
+      : {
+          type: 'apikey',
+          apikey: [
+            { key: 'key', value: 'X-API-Key', type: 'string' },
+            { key: 'value', value: '{{apiKey}}', type: 'string' },
+            { key: 'in', value: 'header', type: 'string' }
+          ]
+        },
+    event: [
+      ...(options.sessionsEnabled
+        ? [{ listen: 'prerequest', script: { type: 'text/javascript', exec: CSRF_SCRIPT } }]
+        : []),
+      { listen: 'test', script: { type: 'text/javascript', exec: SAVE_CREDENTIALS_SCRIPT } }
+    ],
+    variable: [
+      { key: 'baseUrl', value: options.baseUrl },
+      { key: 'apiKey', value: '' },
+      { key: 'adminApiKey', value: '' },
+      { key: 'accessToken', value: '' },
+      ...[...pathVariables].map(name => ({ key: name, value: '' }))
+    ]
+  };
+};
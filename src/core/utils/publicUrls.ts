@@ -0,0 +1,52 @@
+import { Request } from 'express';
+
+/**
+ * Absolute links the API hands out in feeds and embeds.
+ *
+ * PUBLIC_BASE_URL overrides the request's host when the API sits behind a
+ * proxy. SITE_MOVIE_URL (e.g. https://movies.example.com/movie/{id}) points
+ * movie links at the front end; without it they go to GET /api/movies/:id.
+ */
+export const getApiBaseUrl = (req: Request): string =>
+  process.env.PUBLIC_BASE_URL?.replace(/\/+$/, '') ?? `${req.protocol}://${req.get('host')}`;
+
+/**
+ * Where people should land for a movie; public_id is preferred so links
+ * keep working if integer ids are turned off
+ */
+export const getMovieUrl = (req: Request, movie: { movie_id: number; public_id: string | null }): string => {
+  const id = movie.public_id ?? String(movie.movie_id);
+  const template = process.env.SITE_MOVIE_URL;
+  return template
+    ? template.replace('{id}', encodeURIComponent(id))
+    : `${getApiBaseUrl(req)}/api/movies/${encodeURIComponent(id)}`;
+};
+
+const escapeRegExp = (text: string): string => text.replace(/[.*+?^${}()|[\]\\]/g, '\\$&');
+
+/**
+ * The movie id (public_id or integer) in a link built by getMovieUrl, or
+ * null when the URL isn't a movie link of this deployment
+ */
+export const parseMovieUrl = (req: Request, url: string): string | null => {
+  const patterns = [new RegExp(`^${escapeRegExp(getApiBaseUrl(req))}/api/movies/([^/?#]+)/?(?:[?#].*)?$`)];
+  const template = process.env.SITE_MOVIE_URL;
+  if (template) {
+    const [before, after = ''] = template.split('{id}');
+    patterns.unshift(new RegExp(`^${escapeRegExp(before)}([^/?#]+)${escapeRegExp(after)}/?(?:[?#].*)?$`));
+  }
+
+  for (const pattern of patterns) {
+    const match = pattern.exec(url.trim());
+    if (match) return decodeURIComponent(match[1]);
+  }
+  return null;
+};
+
+/**
+ * Redirect URL for a short link code; SHORT_LINK_BASE_URL
+ * (e.g. https://go.example.com/m) points it at a shorter domain that
+ * forwards /m/:code here
+ */
+export const getShortLinkUrl = (req: Request, code: string): string =>
+  `${process.env.SHORT_LINK_BASE_URL?.replace(/\/+$/, '') ?? `${getApiBaseUrl(req)}/api/m`}/${code}`;
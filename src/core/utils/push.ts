@@ -0,0 +1,275 @@
+import http2 from 'http2';
+import jwt from 'jsonwebtoken';
+import pool from './database';
+import { tracedFetch } from './tracing';
+
+/**
+ * Push notifications to the mobile app through Firebase Cloud Messaging
+ * (Android and web) and Apple Push Notification service (iOS).
+ *
+ * FCM is enabled by FCM_PROJECT_ID, FCM_CLIENT_EMAIL and FCM_PRIVATE_KEY
+ * (from a service account key). APNs is enabled by APNS_KEY_ID,
+ * APNS_TEAM_ID, APNS_PRIVATE_KEY (the .p8 key) and APNS_BUNDLE_ID; it uses
+ * the sandbox unless APNS_PRODUCTION=true. A platform that isn't configured
+ * doesn't accept device registrations.
+ */
+export type PushPlatform = 'fcm' | 'apns';
+
+export interface PushMessage {
+  title: string;
+  body: string;
+  /** Passed through to the app (all values strings, as FCM requires) */
+  data: Record<string, string>;
+}
+
+/**
+ * 'invalid' means the service no longer knows the token; it is deleted
+ */
+type SendResult = 'sent' | 'invalid';
+
+export interface PushPublisher {
+  platform: PushPlatform;
+  isConfigured: () => boolean;
+  send: (token: string, message: PushMessage) => Promise<SendResult>;
+}
+
+/**
+ * Keys pasted into env files usually have their newlines escaped
+ */
+const readPrivateKey = (value: string | undefined): string | undefined => value?.replace(/\\n/g, '\n');
+
+// ============================================================================
+// Firebase Cloud Messaging (HTTP v1 API)
+// ============================================================================
+
+let fcmAccessToken: { token: string; expiresAt: number } | null = null;
+
+/**
+ * OAuth access token for the service account, reused until shortly before it expires
+ */
+const getFcmAccessToken = async (): Promise<string> => {
+  if (fcmAccessToken && fcmAccessToken.expiresAt > Date.now() + 60_000) {
+    return fcmAccessToken.token;
+  }
+
+  const assertion = jwt.sign(
+    { scope: 'https://www.googleapis.com/auth/firebase.messaging' },
+    readPrivateKey(process.env.FCM_PRIVATE_KEY)!,
+    {
+      algorithm: 'RS256',
+      issuer: process.env.FCM_CLIENT_EMAIL,
+      audience: 'https://oauth2.googleapis.com/token',
+      expiresIn: '1h'
+    }
+  );
+
+  const response = await tracedFetch('https://oauth2.googleapis.com/token', {
+    method: 'POST',
+    headers: { 'Content-Type': 'application/x-www-form-urlencoded' },
+    body: new URLSearchParams({
+      grant_type: 'urn:ietf:params:oauth:grant-type:jwt-bearer',
+      assertion
+    }),
+    signal: AbortSignal.timeout(10_000)
+  });
+  if (!response.ok) {
+    throw new Error(`FCM token request answered ${response.status}`);
+  }
+
+  const body = await response.json() as { access_token: string; expires_in: number };
+  fcmAccessToken = { token: body.access_token, expiresAt: Date.now() + body.expires_in * 1000 };
+  return fcmAccessToken.token;
+};
+
+const fcmPublisher: PushPublisher = {
+  platform: 'fcm',
+  isConfigured: () => Boolean(
+    process.env.FCM_PROJECT_ID && process.env.FCM_CLIENT_EMAIL && process.env.FCM_PRIVATE_KEY
+  ),
+  send: async (token, message) => {
+    const response = await tracedFetch(
+      `https://fcm.googleapis.com/v1/projects/${process.env.FCM_PROJECT_ID}/messages:send`,
+      {
+        method: 'POST',
+        headers: {
+          Authorization: `Bearer ${await getFcmAccessToken()}`,
+          'Content-Type': 'application/json'
+        },
+        body: JSON.stringify({
+          message: {
+            token,
+            notification: { title: message.title, body: message.body },
+            data: message.data
+          }
+        }),
+        signal: AbortSignal.timeout(10_000)
+      }
+    );
+
+    // 404 UNREGISTERED: the app was uninstalled or the token rotated
+    if (response.status === 404) return 'invalid';
+    if (!response.ok) {
+      throw new Error(`FCM answered ${response.status}`);
+    }
+    return 'sent';
+  }
+};
+
+// ============================================================================
+// Apple Push Notification service (HTTP/2 API)
+// ============================================================================
+
+let apnsProviderToken: { token: string; issuedAt: number } | null = null;
+
+/**
+ * Apple rejects provider tokens older than an hour and throttles ones
+ * refreshed more often than every 20 minutes
+ */
+const getApnsProviderToken = (): string => {
+  if (apnsProviderToken && apnsProviderToken.issuedAt > Date.now() - 45 * 60_000) {
+    return apnsProviderToken.token;
+  }
+
+  const token = jwt.sign({}, readPrivateKey(process.env.APNS_PRIVATE_KEY)!, {
+    algorithm: 'ES256',
+    issuer: process.env.APNS_TEAM_ID,
+    keyid: process.env.APNS_KEY_ID
+  });
+  apnsProviderToken = { token, issuedAt: Date.now() };
+  return token;
+};
+
+const apnsPublisher: PushPublisher = {
+  platform: 'apns',
+  isConfigured: () => Boolean(
+    process.env.APNS_KEY_ID && process.env.APNS_TEAM_ID
+      && process.env.APNS_PRIVATE_KEY && process.env.APNS_BUNDLE_ID
+  ),
+  send: (token, message) => new Promise<SendResult>((resolve, reject) => {
+    const host = process.env.APNS_PRODUCTION === 'true'
+      ? 'https://api.push.apple.com'
+      : 'https://api.sandbox.push.apple.com';
+    const session = http2.connect(host);
+    session.on('error', reject);
+    session.setTimeout(10_000, () => session.destroy(new Error('APNs request timed out')));
+
+    const request = session.request({
+      ':method': 'POST',
+      ':path': `/3/device/${token}`,
+      authorization: `bearer ${getApnsProviderToken()}`,
+      'apns-topic': process.env.APNS_BUNDLE_ID!,
+      'apns-push-type': 'alert',
+      'content-type': 'application/json'
+    });
+
+    let status = 0;
+    let responseBody = '';
+    request.on('response', headers => { status = Number(headers[':status']); });
+    request.on('data', chunk => { responseBody += chunk; });
+    request.on('error', reject);
+    request.on('end', () => {
+      session.close();
+      // 410: the token is no longer active; 400 BadDeviceToken: it never was
+      // (or belongs to the other environment)
+      if (status === 410 || (status === 400 && responseBody.includes('BadDeviceToken'))) {
+        resolve('invalid');
+      } else if (status === 200) {
+        resolve('sent');
+      } else {
+        reject(new Error(`APNs answered ${status}: ${responseBody}`));
+      }
+    });
+
+    request.end(JSON.stringify({
+      aps: { alert: { title: message.title, body: message.body } },
+      ...message.data
+    }));
+  })
+};
+
+const PUBLISHERS: Record<PushPlatform, PushPublisher> = {
+  fcm: fcmPublisher,
+  apns: apnsPublisher
+};
+
+/**
+ * Whether registrations for a platform are accepted
+ */
+export const isPushPlatformConfigured = (platform: PushPlatform): boolean =>
+  PUBLISHERS[platform].isConfigured();
+
+// ============================================================================
+// Watchlist notifications
+// ============================================================================
+
+export type MovieChange = 'movie_updated' | 'trailer_added';
+
+/**
+ * An edit notifies a movie's watchers at most once per this many minutes
+ * (per API instance); a new trailer always does
+ */
+const UPDATE_COOLDOWN_MINUTES = 60;
+
+const lastUpdateNotice = new Map<number, number>();
+
+/**
+ * Pushes a notice to every registered device of every user with the movie on
+ * their watchlist. Called after the change commits and not awaited, so
+ * failures are logged rather than affecting the edit.
+ *
+ * @param movieId - Changed movie
+ * @param change - What happened to it
+ */
+export const notifyMovieWatchers = async (movieId: number, change: MovieChange): Promise<void> => {
+  if (!Object.values(PUBLISHERS).some(publisher => publisher.isConfigured())) return;
+
+  if (change === 'movie_updated') {
+    const last = lastUpdateNotice.get(movieId);
+    if (last !== undefined && last > Date.now() - UPDATE_COOLDOWN_MINUTES * 60_000) return;
+    lastUpdateNotice.set(movieId, Date.now());
+  }
+
+  try {
+    const result = await pool.query<{ device_id: number; platform: PushPlatform; token: string; title: string; public_id: string | null }>(
+      `SELECT d.device_id, d.platform, d.token, m.title, m.public_id
+       FROM watchlist w
+       JOIN device_tokens d ON d.user_id = w.user_id
+       JOIN movies m ON m.movie_id = w.movie_id
+       WHERE w.movie_id = $1`,
+      [movieId]
+    );
+    if (result.rows.length === 0) return;
+
+    const { title, public_id } = result.rows[0];
+    const message: PushMessage = {
+      title,
+      body: change === 'trailer_added'
+        ? 'A trailer was added for a movie on your watchlist'
+        : 'A movie on your watchlist was updated',
+      data: {
+        type: change,
+        movie_id: String(movieId),
+        ...(public_id ? { public_id } : {})
+      }
+    };
+
+    const invalid: number[] = [];
+    await Promise.all(result.rows.map(async (device) => {
+      const publisher = PUBLISHERS[device.platform];
+      if (!publisher.isConfigured()) return;
+      try {
+        if (await publisher.send(device.token, message) === 'invalid') {
+          invalid.push(device.device_id);
+        }
+      } catch (error) {
+        console.error(`Push to device ${device.device_id} failed:`, error);
+      }
+    }));
+
+    if (invalid.length > 0) {
+      await pool.query('DELETE FROM device_tokens WHERE device_id = ANY($1::int[])', [invalid]);
+    }
+  } catch (error) {
+    console.error(`Notifying watchers of movie ${movieId} failed:`, error);
+  }
+};
@@ -0,0 +1,391 @@
+import { encodePng } from './imageComposition';
+
+/**
+ * QR codes for short links, rendered as PNG without image libraries.
+ *
+ * Encodes bytes (byte mode) at error correction level M, in the smallest of
+ * versions 1-10 that fits, which holds up to 213 bytes - far more than a
+ * short URL needs. Follows ISO/IEC 18004; the module placement mirrors
+ * Project Nayuki's reference implementation.
+ */
+
+/**
+ * Per version (index 0 = version 1), level M: error correction codewords per
+ * block, then [block count, data codewords per block] for each block group
+ */
+const EC_BLOCKS_M: [number, [number, number][]][] = [
+  [10, [[1, 16]]],
+  [16, [[1, 28]]],
+  [26, [[1, 44]]],
+  [18, [[2, 32]]],
+  [24, [[2, 43]]],
+  [16, [[4, 27]]],
+  [18, [[4, 31]]],
+  [22, [[2, 38], [2, 39]]],
+  [22, [[3, 36], [2, 37]]],
+  [26, [[4, 43], [1, 44]]]
+];
+
+const ALIGNMENT_POSITIONS: number[][] = [
+  [],
+  [6, 18],
+  [6, 22],
+  [6, 26],
+  [6, 30],
+  [6, 34],
+  [6, 22, 38],
+  [6, 24, 42],
+  [6, 26, 46],
+  [6, 28, 50]
+];
+
+/**
+ * Level M's two format bits
+ */
+const EC_LEVEL_M_BITS = 0b00;
+
+// ============================================================================
+// Reed-Solomon over GF(256)
+// ============================================================================
+
+const gfMultiply = (x: number, y: number): number => {
+  let result = 0;
+  for (let i = 7; i >= 0; i--) {
+    result = (result << 1) ^ ((result >>> 7) * 0x11d);
+    result ^= ((y >>> i) & 1) * x;
+  }
+  return result & 0xff;
+};
+
+const reedSolomonDivisor = (degree: number): number[] => {
+  const result = new Array<number>(degree).fill(0);
+  result[degree - 1] = 1;
+  let root = 1;
+  for (let i = 0; i < degree; i++) {
+    for (let j = 0; j < degree; j++) {
+      result[j] = gfMultiply(result[j], root);
+      if (j + 1 < degree) result[j] ^= result[j + 1];
+    }
+    root = gfMultiply(root, 0x02);
+  }
+  return result;
+};
+
+const reedSolomonRemainder = (data: number[], divisor: number[]): number[] => {
+  const result = new Array<number>(divisor.length).fill(0);
+  for (const byte of data) {
+    const factor = byte ^ (result.shift() as number);
+    result.push(0);
+    divisor.forEach((coefficient, i) => { result[i] ^= gfMultiply(coefficient, factor); });
+  }
+  return result;
+};
+
+// ============================================================================
+// Encoding
+// ============================================================================
+
+const dataCapacity = (version: number): number =>
+  EC_BLOCKS_M[version - 1][1].reduce((sum, [count, size]) => sum + count * size, 0);
+
+/**
+ * Data codewords (mode, length, bytes, terminator and padding) for a version
+ */
+const buildDataCodewords = (bytes: Buffer, version: number): number[] => {
+  const bits: number[] = [];
+  const push = (value: number, length: number) => {
+    for (let i = length - 1; i >= 0; i--) bits.push((value >>> i) & 1);
+  };
+
+  push(0b0100, 4);
+  push(bytes.length, version < 10 ? 8 : 16);
+  bytes.forEach(byte => push(byte, 8));
+
+  const capacityBits = dataCapacity(version) * 8;
+  push(0, Math.min(4, capacityBits - bits.length));
+  push(0, (8 - (bits.length % 8)) % 8);
+  for (let pad = 0xec; bits.length < capacityBits; pad ^= 0xec ^ 0x11) {
+    push(pad, 8);
+  }
+
+  const codewords: number[] = [];
+  for (let i = 0; i < bits.length; i += 8) {
+    codewords.push(bits.slice(i, i + 8).reduce((byte, bit) => (byte << 1) | bit, 0));
+  }
+  return codewords;
+};
+
+/**
+ * Splits data into blocks, adds error correction and interleaves them
+ */
+const addErrorCorrection = (data: number[], version: number): number[] => {
+  const [ecLength, groups] = EC_BLOCKS_M[version - 1];
+  const divisor = reedSolomonDivisor(ecLength);
+  const dataBlocks: number[][] = [];
+  let offset = 0;
+  for (const [count, size] of groups) {
+    for (let i = 0; i < count; i++) {
+      dataBlocks.push(data.slice(offset, offset + size));
+      offset += size;
+    }
+  }
+  const ecBlocks = dataBlocks.map(block => reedSolomonRemainder(block, divisor));
+
+  const result: number[] = [];
+  const longest = Math.max(...dataBlocks.map(block => block.length));
+  for (let i = 0; i < longest; i++) {
+    dataBlocks.forEach(block => { if (i < block.length) result.push(block[i]); });
+  }
+  for (let i = 0; i < ecLength; i++) {
+    ecBlocks.forEach(block => result.push(block[i]));
+  }
+  return result;
+};
+
+// ============================================================================
+// Module placement
+// ============================================================================
+
+class QrMatrix {
+  readonly size: number;
+  readonly modules: boolean[][];
+  readonly isFunction: boolean[][];
+
+  constructor(readonly version: number) {
+    this.size = version * 4 + 17;
+    this.modules = Array.from({ length: this.size }, () => new Array<boolean>(this.size).fill(false));
+    this.isFunction = Array.from({ length: this.size }, () => new Array<boolean>(this.size).fill(false));
+  }
+
+  setFunction(x: number, y: number, dark: boolean): void {
+    this.modules[y][x] = dark;
+    this.isFunction[y][x] = true;
+  }
+
+  drawFunctionPatterns(): void {
+    for (let i = 0; i < this.size; i++) {
+      this.setFunction(6, i, i % 2 === 0);
+      this.setFunction(i, 6, i % 2 === 0);
+    }
+
+    this.drawFinder(3, 3);
+    this.drawFinder(this.size - 4, 3);
+    this.drawFinder(3, this.size - 4);
+
+    const positions = ALIGNMENT_POSITIONS[this.version - 1];
+    const last = positions.length - 1;
+    positions.forEach((x, i) => positions.forEach((y, j) => {
+      // Skip the three corners the finder patterns occupy
+      if (!((i === 0 && j === 0) || (i === 0 && j === last) || (i === last && j === 0))) {
+        this.drawAlignment(x, y);
+      }
+    }));
+
+    // Reserve the format areas; the real bits go in once the mask is known
+    this.drawFormatBits(0);
+    this.drawVersion();
+  }
+
+  private drawFinder(cx: number, cy: number): void {
+    for (let dy = -4; dy <= 4; dy++) {
+      for (let dx = -4; dx <= 4; dx++) {
+        const x = cx + dx;
+        const y = cy + dy;
+        if (x < 0 || x >= this.size || y < 0 || y >= this.size) continue;
+        const distance = Math.max(Math.abs(dx), Math.abs(dy));
+        this.setFunction(x, y, distance !== 2 && distance !== 4);
+      }
+    }
+  }
+
+  private drawAlignment(cx: number, cy: number): void {
+    for (let dy = -2; dy <= 2; dy++) {
+      for (let dx = -2; dx <= 2; dx++) {
+        this.setFunction(cx + dx, cy + dy, Math.max(Math.abs(dx), Math.abs(dy)) !== 1);
+      }
+    }
+  }
+
+  drawFormatBits(mask: number): void {
+    const data = (EC_LEVEL_M_BITS << 3) | mask;
+    let remainder = data;
+    for (let i = 0; i < 10; i++) {
+      remainder = (remainder << 1) ^ ((remainder >>> 9) * 0x537);
+    }
+    const bits = ((data << 10) | remainder) ^ 0x5412;
+    const bit = (i: number) => ((bits >>> i) & 1) !== 0;
+
+    for (let i = 0; i <= 5; i++) this.setFunction(8, i, bit(i));
+    this.setFunction(8, 7, bit(6));
+    this.setFunction(8, 8, bit(7));
+    this.setFunction(7, 8, bit(8));
+    for (let i = 9; i < 15; i++) this.setFunction(14 - i, 8, bit(i));
+
+    for (let i = 0; i < 8; i++) this.setFunction(this.size - 1 - i, 8, bit(i));
+    for (let i = 8; i < 15; i++) this.setFunction(8, this.size - 15 + i, bit(i));
+    this.setFunction(8, this.size - 8, true);
+  }
+
+  private drawVersion(): void {
+    if (this.version < 7) return;
+
+    let remainder = this.version;
+    for (let i = 0; i < 12; i++) {
+      remainder = (remainder << 1) ^ ((remainder >>> 11) * 0x1f25);
+    }
+    const bits = (this.version << 12) | remainder;
+
+    for (let i = 0; i < 18; i++) {
+      const dark = ((bits >>> i) & 1) !== 0;
+      const a = this.size - 11 + (i % 3);
+      const b = Math.floor(i / 3);
+      this.setFunction(a, b, dark);
+      this.setFunction(b, a, dark);
+    }
+  }
+
+  drawCodewords(codewords: number[]): void {
+    let i = 0;
+    for (let right = this.size - 1; right >= 1; right -= 2) {
+      if (right === 6) right = 5;
+      for (let vertical = 0; vertical < this.size; vertical++) {
+        for (let j = 0; j < 2; j++) {
+          const x = right - j;
+          const upward = ((right + 1) & 2) === 0;
+          const y = upward ? this.size - 1 - vertical : vertical;
+          if (!this.isFunction[y][x] && i < codewords.length * 8) {
+            this.modules[y][x] = ((codewords[i >>> 3] >>> (7 - (i & 7))) & 1) !== 0;
+            i++;
+          }
+        }
+      }
+    }
+  }
+
+  applyMask(mask: number): void {
+    for (let y = 0; y < this.size; y++) {
+      for (let x = 0; x < this.size; x++) {
+        if (!this.isFunction[y][x] && MASKS[mask](x, y)) {
+          this.modules[y][x] = !this.modules[y][x];
+        }
+      }
+    }
+  }
+
+  /**
+   * Penalty score from the standard's four rules; lower scans more reliably
+   */
+  penalty(): number {
+    const { size, modules } = this;
+    let score = 0;
+
+    const lines = [
+      ...modules,
+      ...modules.map((_, x) => modules.map(row => row[x]))
+    ];
+    for (const line of lines) {
+      // Rule 1: runs of five or more same-colored modules
+      let run = 1;
+      for (let i = 1; i <= size; i++) {
+        if (i < size && line[i] === line[i - 1]) {
+          run++;
+        } else {
+          if (run >= 5) score += 3 + (run - 5);
+          run = 1;
+        }
+      }
+      // Rule 3: finder-like 1:1:3:1:1 patterns with four light modules on a side
+      const text = line.map(dark => (dark ? '1' : '0')).join('');
+      score += 40 * ((text.match(/(?=10111010000)/g)?.length ?? 0) + (text.match(/(?=00001011101)/g)?.length ?? 0));
+    }
+
+    // Rule 2: 2x2 blocks of one color
+    for (let y = 0; y < size - 1; y++) {
+      for (let x = 0; x < size - 1; x++) {
+        const color = modules[y][x];
+        if (color === modules[y][x + 1] && color === modules[y + 1][x] && color === modules[y + 1][x + 1]) {
+          score += 3;
+        }
+      }
+    }
+
+    // Rule 4: dark share far from half
+    const dark = modules.reduce((sum, row) => sum + row.filter(Boolean).length, 0);
+    score += 10 * Math.floor(Math.abs(dark * 20 - size * size * 10) / (size * size));
+
+    return score;
+  }
+}
+
+const MASKS: ((x: number, y: number) => boolean)[] = [
+  (x, y) => (x + y) % 2 === 0,
+  (_x, y) => y % 2 === 0,
+  (x) => x % 3 === 0,
+  (x, y) => (x + y) % 3 === 0,
+  (x, y) => (Math.floor(x / 3) + Math.floor(y / 2)) % 2 === 0,
+  (x, y) => ((x * y) % 2) + ((x * y) % 3) === 0,
+  (x, y) => (((x * y) % 2) + ((x * y) % 3)) % 2 === 0,
+  (x, y) => (((x + y) % 2) + ((x * y) % 3)) % 2 === 0
+];
+
+/**
+ * Encodes text as a QR code
+ *
+ * @param text - Usually a URL
+ * @returns Modules by row, true = dark (no quiet zone)
+ * @throws When the text is too long for version 10
+ */
+export const encodeQrCode = (text: string): boolean[][] => {
+  const bytes = Buffer.from(text, 'utf8');
+  const version = EC_BLOCKS_M.findIndex((_, i) => {
+    const headerBits = 4 + (i + 1 < 10 ? 8 : 16);
+    return headerBits + bytes.length * 8 <= dataCapacity(i + 1) * 8;
+  }) + 1;
+  if (version === 0) {
+    throw new Error(`Text too long for a QR code (${bytes.length} bytes)`);
+  }
+
+  const codewords = addErrorCorrection(buildDataCodewords(bytes, version), version);
+
+  let best: QrMatrix | null = null;
+  let bestPenalty = Infinity;
+  for (let mask = 0; mask < MASKS.length; mask++) {
+    const matrix = new QrMatrix(version);
+    matrix.drawFunctionPatterns();
+    matrix.drawCodewords(codewords);
+    matrix.applyMask(mask);
+    matrix.drawFormatBits(mask);
+    const penalty = matrix.penalty();
+    if (penalty < bestPenalty) {
+      best = matrix;
+      bestPenalty = penalty;
+    }
+  }
+  return best!.modules;
+};
+
+// ============================================================================
+// PNG output
+// ============================================================================
+
+/**
+ * Renders a QR code as a black-on-white grayscale PNG
+ *
+ * @param modules - From encodeQrCode
+ * @param scale - Pixels per module
+ * @param quietZone - Light border in modules (the standard asks for 4)
+ */
+export const renderQrPng = (modules: boolean[][], scale = 8, quietZone = 4): Buffer => {
+  const dimension = (modules.length + quietZone * 2) * scale;
+  const pixels = new Uint8Array(dimension * dimension).fill(0xff);
+
+  for (let py = 0; py < dimension; py++) {
+    const y = Math.floor(py / scale) - quietZone;
+    for (let px = 0; px < dimension; px++) {
+      const x = Math.floor(px / scale) - quietZone;
+      if (modules[y]?.[x]) pixels[py * dimension + px] = 0x00;
+    }
+  }
+
+  return encodePng(dimension, dimension, pixels, 1);
+};
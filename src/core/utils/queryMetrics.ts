@@ -0,0 +1,261 @@
+import { AsyncLocalStorage } from 'async_hooks';
+import type { Request } from 'express';
+import type { PoolClient } from 'pg';
+import { SpanKind, startSpan } from './tracing';
+
+/**
+ * Histogram bucket upper bounds in milliseconds. The last bucket is unbounded.
+ */
+const BUCKETS_MS = [5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000];
+
+const DEFAULT_SLOW_QUERY_MS = 500;
+const DEFAULT_SLO_LATENCY_MS = 500;
+
+/**
+ * Queries slower than this are logged (SLOW_QUERY_MS)
+ */
+const getSlowQueryMs = (): number =>
+  parseInt(process.env.SLOW_QUERY_MS ?? '', 10) || DEFAULT_SLOW_QUERY_MS;
+
+/**
+ * Response time target used for the SLO figures (SLO_LATENCY_MS)
+ */
+const getSloLatencyMs = (): number =>
+  parseInt(process.env.SLO_LATENCY_MS ?? '', 10) || DEFAULT_SLO_LATENCY_MS;
+
+/**
+ * Fixed-bucket latency histogram. Percentiles are estimated as the upper bound
+ * of the bucket they fall in, which is precise enough to spot a bad endpoint.
+ */
+class LatencyHistogram {
+  private counts = new Array<number>(BUCKETS_MS.length + 1).fill(0);
+  count = 0;
+  sumMs = 0;
+  maxMs = 0;
+
+  observe(ms: number): void {
+    const index = BUCKETS_MS.findIndex(bound => ms <= bound);
+    this.counts[index === -1 ? BUCKETS_MS.length : index]++;
+    this.count++;
+    this.sumMs += ms;
+    this.maxMs = Math.max(this.maxMs, ms);
+  }
+
+  percentile(p: number): number {
+    if (this.count === 0) return 0;
+
+    const rank = Math.ceil(this.count * p);
+    let seen = 0;
+    for (let i = 0; i < this.counts.length; i++) {
+      seen += this.counts[i];
+      if (seen >= rank) {
+        return i < BUCKETS_MS.length ? BUCKETS_MS[i] : this.maxMs;
+      }
+    }
+    return this.maxMs;
+  }
+
+  /**
+   * Share of observations at or under the largest bucket bound not above targetMs
+   */
+  fractionWithin(targetMs: number): number {
+    if (this.count === 0) return 1;
+
+    let within = 0;
+    for (let i = 0; i < BUCKETS_MS.length && BUCKETS_MS[i] <= targetMs; i++) {
+      within += this.counts[i];
+    }
+    return within / this.count;
+  }
+
+  summary() {
+    return {
+      count: this.count,
+      avg_ms: this.count ? Math.round(this.sumMs / this.count) : 0,
+      p50_ms: this.percentile(0.5),
+      p95_ms: this.percentile(0.95),
+      p99_ms: this.percentile(0.99),
+      max_ms: Math.round(this.maxMs)
+    };
+  }
+}
+
+interface EndpointMetrics {
+  requests: LatencyHistogram;
+  queries: LatencyHistogram;
+  errors: number;
+}
+
+interface RequestContext {
+  req: Request;
+}
+
+const requestContext = new AsyncLocalStorage<RequestContext>();
+const metrics = new Map<string, EndpointMetrics>();
+let collectingSince = new Date();
+
+/**
+ * Low-cardinality label for a request: method plus the matched route pattern
+ */
+const getEndpointLabel = (req: Request): string =>
+  req.route
+    ? `${req.method} ${req.baseUrl}${req.route.path}`
+    : `${req.method} (unmatched)`;
+
+/**
+ * Endpoint the current async context belongs to, or "background" outside requests
+ */
+const getCurrentEndpoint = (): string => {
+  const context = requestContext.getStore();
+  return context ? getEndpointLabel(context.req) : 'background';
+};
+
+const getMetrics = (endpoint: string): EndpointMetrics => {
+  let entry = metrics.get(endpoint);
+  if (!entry) {
+    entry = { requests: new LatencyHistogram(), queries: new LatencyHistogram(), errors: 0 };
+    metrics.set(endpoint, entry);
+  }
+  return entry;
+};
+
+/**
+ * Replaces parameter values with their type and size so logs never hold user data
+ */
+const redactParams = (values: unknown): unknown[] | undefined => {
+  if (!Array.isArray(values)) return undefined;
+
+  return values.map(value => {
+    if (value === null || value === undefined) return value;
+    if (typeof value === 'number' || typeof value === 'boolean') return value;
+    if (typeof value === 'string') return `<string:${value.length}>`;
+    if (Array.isArray(value)) return `<array:${value.length}>`;
+    if (value instanceof Date) return '<date>';
+    return `<${typeof value}>`;
+  });
+};
+
+/**
+ * Records one query's duration and logs it when slow
+ */
+const recordQuery = (endpoint: string, ms: number, text: string, values: unknown, failed: boolean): void => {
+  getMetrics(endpoint).queries.observe(ms);
+
+  if (ms >= getSlowQueryMs()) {
+    console.warn('[Slow Query]', {
+      endpoint,
+      duration_ms: Math.round(ms),
+      failed,
+      sql: text.replace(/\s+/g, ' ').trim(),
+      params: redactParams(values)
+    });
+  }
+};
+
+/**
+ * Runs the rest of a request inside a context so its queries are attributed to it
+ *
+ * @param req - The request
+ * @param fn - Continuation (normally Express's next)
+ */
+export const runWithRequestContext = (req: Request, fn: () => void): void => {
+  requestContext.run({ req }, fn);
+};
+
+/**
+ * Records a finished request's latency under its endpoint
+ *
+ * @param req - The request
+ * @param ms - Time from arrival to response finish
+ * @param statusCode - Response status
+ */
+export const recordRequest = (req: Request, ms: number, statusCode: number): void => {
+  const entry = getMetrics(getEndpointLabel(req));
+  entry.requests.observe(ms);
+  if (statusCode >= 500) entry.errors++;
+};
+
+/**
+ * Wraps a pool client's query method to time every query and trace it as a
+ * child span of the current request. Handles both the promise and callback
+ * forms, since pool.query uses the callback form internally.
+ *
+ * @param client - Newly connected client (from the pool's connect event)
+ */
+export const instrumentClient = (client: PoolClient): void => {
+  const originalQuery = client.query.bind(client) as (...args: unknown[]) => unknown;
+
+  client.query = ((...args: unknown[]) => {
+    const endpoint = getCurrentEndpoint();
+    const config = args[0] as string | { text?: string; values?: unknown };
+    const text = typeof config === 'string' ? config : config?.text ?? '';
+    const values = Array.isArray(args[1]) ? args[1] : typeof config === 'object' ? config?.values : undefined;
+    const start = process.hrtime.bigint();
+    const elapsedMs = () => Number(process.hrtime.bigint() - start) / 1e6;
+
+    const statement = text.replace(/\s+/g, ' ').trim();
+    const span = startSpan(`pg ${statement.split(' ')[0]?.toUpperCase() || 'query'}`, {
+      kind: SpanKind.CLIENT,
+      attributes: {
+        'db.system': 'postgresql',
+        'db.statement': statement
+      }
+    });
+
+    const finish = (error: unknown) => {
+      recordQuery(endpoint, elapsedMs(), text, values, Boolean(error));
+      if (error) span.recordError(error);
+      span.end();
+    };
+
+    const callbackIndex = args.findIndex(arg => typeof arg === 'function');
+    if (callbackIndex !== -1) {
+      const callback = args[callbackIndex] as (err: unknown, ...rest: unknown[]) => void;
+      args[callbackIndex] = (err: unknown, ...rest: unknown[]) => {
+        finish(err);
+        callback(err, ...rest);
+      };
+      return originalQuery(...args);
+    }
+
+    const result = originalQuery(...args);
+    if (result && typeof (result as Promise<unknown>).then === 'function') {
+      (result as Promise<unknown>).then(() => finish(null), error => finish(error ?? true));
+    }
+    return result;
+  }) as PoolClient['query'];
+};
+
+/**
+ * Per-endpoint request and query latency since startup (or the last reset),
+ * slowest p95 first
+ */
+export const getLatencyMetrics = () => {
+  const sloMs = getSloLatencyMs();
+
+  const endpoints = [...metrics.entries()]
+    .map(([endpoint, entry]) => ({
+      endpoint,
+      requests: entry.requests.summary(),
+      errors: entry.errors,
+      slo_compliance: Number(entry.requests.fractionWithin(sloMs).toFixed(4)),
+      queries: entry.queries.summary()
+    }))
+    .sort((a, b) => b.requests.p95_ms - a.requests.p95_ms || b.queries.p95_ms - a.queries.p95_ms);
+
+  return {
+    collecting_since: collectingSince.toISOString(),
+    slo_latency_ms: sloMs,
+    slow_query_ms: getSlowQueryMs(),
+    buckets_ms: BUCKETS_MS,
+    endpoints
+  };
+};
+
+/**
+ * Clears all collected latency data
+ */
+export const resetLatencyMetrics = (): void => {
+  metrics.clear();
+  collectingSince = new Date();
+};
@@ -0,0 +1,169 @@
+import crypto from 'crypto';
+import { Pool, PoolClient } from 'pg';
+import pool from './database';
+
+/**
+ * Lifetime of each refresh token. Rotation issues a fresh one, so an active
+ * client stays logged in indefinitely and an idle one for this long.
+ */
+export const REFRESH_TOKEN_DAYS = 7;
+
+/**
+ * Cookie the refresh token is set in for browser clients
+ */
+export const REFRESH_COOKIE = 'refresh-token';
+
+export interface IssuedRefreshToken {
+  token: string;
+  expiresAt: Date;
+}
+
+export type RefreshRotation =
+  | { status: 'rotated'; user: { user_id: number; username: string; role: string }; refresh: IssuedRefreshToken }
+  | { status: 'invalid' | 'expired' | 'reused' };
+
+/**
+ * Only the SHA-256 of a refresh token is stored, so a database leak can't be replayed
+ */
+const hashToken = (token: string): string =>
+  crypto.createHash('sha256').update(token).digest('hex');
+
+/**
+ * Creates and stores a new opaque refresh token
+ *
+ * @param db - Pool or the caller's transaction client
+ * @param userId - Owner of the token
+ * @param familyId - Family to continue when rotating; a new login starts a new one
+ * @param replacing - Token this one succeeds, linked for auditing
+ */
+export const issueRefreshToken = async (
+  db: Pool | PoolClient,
+  userId: number,
+  familyId: string = crypto.randomUUID(),
+  replacing?: number
+): Promise<IssuedRefreshToken> => {
+  const token = crypto.randomBytes(32).toString('base64url');
+  const expiresAt = new Date(Date.now() + REFRESH_TOKEN_DAYS * 24 * 60 * 60 * 1000);
+
+  const result = await db.query<{ token_id: number }>(
+    `INSERT INTO refresh_tokens (user_id, token_hash, family_id, expires_at)
+     VALUES ($1, $2, $3, $4)
+     RETURNING token_id`,
+    [userId, hashToken(token), familyId, expiresAt]
+  );
+
+  if (replacing !== undefined) {
+    await db.query(
+      'UPDATE refresh_tokens SET replaced_by = $2 WHERE token_id = $1',
+      [replacing, result.rows[0].token_id]
+    );
+  }
+
+  return { token, expiresAt };
+};
+
+/**
+ * Exchanges a refresh token for its successor.
+ *
+ * A token can be exchanged once. Presenting it again means it was copied, so
+ * its whole family (every token descended from the same login) is revoked and
+ * both the thief and the legitimate client have to log in again.
+ *
+ * @param token - Refresh token presented by the client
+ */
+export const rotateRefreshToken = async (token: string): Promise<RefreshRotation> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const result = await client.query(
+      `SELECT rt.token_id, rt.family_id, rt.used_at, rt.revoked_at,
+              rt.expires_at < NOW() AS expired,
+              u.user_id, u.username, u.role
+       FROM refresh_tokens rt
+       JOIN users u ON u.user_id = rt.user_id
+       WHERE rt.token_hash = $1
+       FOR UPDATE OF rt`,
+      [hashToken(token)]
+    );
+    const row = result.rows[0];
+
+    if (!row) {
+      await client.query('ROLLBACK');
+      return { status: 'invalid' };
+    }
+
+    if (row.used_at || row.revoked_at) {
+      await client.query(
+        'UPDATE refresh_tokens SET revoked_at = NOW() WHERE family_id = $1 AND revoked_at IS NULL',
+        [row.family_id]
+      );
+      await client.query('COMMIT');
+      console.warn(`Refresh token reuse detected for user ${row.user_id}; revoked token family ${row.family_id}`);
+      return { status: 'reused' };
+    }
+
+    if (row.expired) {
+      await client.query('ROLLBACK');
+      return { status: 'expired' };
+    }
+
+    await client.query('UPDATE refresh_tokens SET used_at = NOW() WHERE token_id = $1', [row.token_id]);
+    const refresh = await issueRefreshToken(client, row.user_id, row.family_id, row.token_id);
+
+    await client.query('COMMIT');
+
+    return {
+      status: 'rotated',
+      user: { user_id: row.user_id, username: row.username, role: row.role },
+      refresh
+    };
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * Revokes the family a refresh token belongs to (logging out one device)
+ *
+ * @returns The owning user's ID, or null when the token is unknown
+ */
+export const revokeRefreshTokenFamily = async (token: string): Promise<number | null> => {
+  const result = await pool.query<{ user_id: number }>(
+    `UPDATE refresh_tokens SET revoked_at = COALESCE(revoked_at, NOW())
+     WHERE family_id = (SELECT family_id FROM refresh_tokens WHERE token_hash = $1)
+     RETURNING user_id`,
+    [hashToken(token)]
+  );
+  return result.rows[0]?.user_id ?? null;
+};
+
+/**
+ * Revokes every refresh token a user holds (logging out all devices)
+ *
+ * @returns Number of tokens that were still active
+ */
+export const revokeAllRefreshTokens = async (userId: number): Promise<number> => {
+  const result = await pool.query(
+    `UPDATE refresh_tokens SET revoked_at = NOW()
+     WHERE user_id = $1 AND revoked_at IS NULL AND used_at IS NULL AND expires_at > NOW()`,
+    [userId]
+  );
+  return result.rowCount ?? 0;
+};
+
+/**
+ * The user a refresh token belongs to, if it is still usable
+ */
+export const getRefreshTokenOwner = async (token: string): Promise<number | null> => {
+  const result = await pool.query<{ user_id: number }>(
+    `SELECT user_id FROM refresh_tokens
+     WHERE token_hash = $1 AND revoked_at IS NULL AND used_at IS NULL AND expires_at > NOW()`,
+    [hashToken(token)]
+  );
+  return result.rows[0]?.user_id ?? null;
+};
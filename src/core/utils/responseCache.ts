@@ -0,0 +1,72 @@
+/**
+ * A cached GET response
+ */
+export interface CachedResponse {
+  statusCode: number;
+  headers: Record<string, string>;
+  body: string | Buffer;
+  /** Served as-is until this time (ms since epoch) */
+  freshUntil: number;
+  /** Served while being refreshed in the background until this time */
+  staleUntil: number;
+  /** When a background refresh started, so only one runs per entry */
+  revalidatingSince?: number;
+}
+
+const DEFAULT_MAX_ENTRIES = 500;
+
+/**
+ * Entry limit, overridable with RESPONSE_CACHE_MAX_ENTRIES
+ */
+const getMaxEntries = (): number =>
+  parseInt(process.env.RESPONSE_CACHE_MAX_ENTRIES ?? '', 10) || DEFAULT_MAX_ENTRIES;
+
+/**
+ * Bodies larger than this (bytes) are never cached
+ */
+export const MAX_CACHED_BODY_BYTES = 1024 * 1024;
+
+/**
+ * In-process LRU cache of API responses, for deployments without a shared cache.
+ *
+ * Map iteration order is insertion order, so re-inserting on read keeps the
+ * least recently used entry first and eviction just drops it.
+ */
+class ResponseCache {
+  private entries = new Map<string, CachedResponse>();
+
+  get(key: string): CachedResponse | undefined {
+    const entry = this.entries.get(key);
+    if (!entry) return undefined;
+
+    if (Date.now() >= entry.staleUntil) {
+      this.entries.delete(key);
+      return undefined;
+    }
+
+    this.entries.delete(key);
+    this.entries.set(key, entry);
+    return entry;
+  }
+
+  set(key: string, entry: CachedResponse): void {
+    this.entries.delete(key);
+    this.entries.set(key, entry);
+
+    while (this.entries.size > getMaxEntries()) {
+      const oldest = this.entries.keys().next().value;
+      if (oldest === undefined) break;
+      this.entries.delete(oldest);
+    }
+  }
+
+  clear(): void {
+    this.entries.clear();
+  }
+
+  get size(): number {
+    return this.entries.size;
+  }
+}
+
+export const responseCache = new ResponseCache();
@@ -0,0 +1,81 @@
+/**
+ * Sanitizes free text that users or imports supply (overviews, taglines,
+ * reviews) before it is stored, so front ends that render it as HTML can't
+ * be made to run script.
+ *
+ * HTML_SANITIZE selects the policy:
+ * - strip (default): remove markup, keeping the text (like bluemonday's
+ *   StrictPolicy); script/style blocks are removed with their contents
+ * - escape: keep everything but HTML-escape it, so markup shows literally
+ * - off: store as given
+ */
+export type SanitizeMode = 'strip' | 'escape' | 'off';
+
+/**
+ * Elements whose content is code, not text, and goes with the tag
+ */
+const DANGEROUS_BLOCKS = /<(script|style|iframe|object|embed|noscript|template|svg|math)\b[^>]*>[\s\S]*?<\/\1\s*>/gi;
+
+const HTML_ESCAPES: Record<string, string> = {
+  '&': '&amp;',
+  '<': '&lt;',
+  '>': '&gt;',
+  '"': '&quot;',
+  "'": '&#39;'
+};
+
+/**
+ * How text fields are cleaned of HTML (HTML_SANITIZE)
+ */
+export const getSanitizeMode = (): SanitizeMode => {
+  const mode = process.env.HTML_SANITIZE?.toLowerCase();
+  return mode === 'escape' || mode === 'off' ? mode : 'strip';
+};
+
+/**
+ * Plain text from possibly-HTML input. Line breaks and paragraphs become
+ * newlines; a '<' that doesn't start a tag ("a < b") is kept.
+ */
+export const stripHtml = (text: string): string =>
+  text
+    .replace(/<!--[\s\S]*?(?:-->|$)/g, '')
+    .replace(DANGEROUS_BLOCKS, '')
+    .replace(/<br\s*\/?>/gi, '\n')
+    .replace(/<\/p\s*>/gi, '\n\n')
+    .replace(/<\/?[a-zA-Z][^>]*>/g, '')
+    .replace(/<[!?][^>]*>/g, '')
+    // Unterminated tags ("<img src=x onerror=...") lose their opening bracket
+    .replace(/<(?=[a-zA-Z/!?])/g, '')
+    .replace(/\n{3,}/g, '\n\n')
+    .trim();
+
+/**
+ * HTML-escapes text. Existing entities are left alone, so text that is read
+ * back and saved again isn't escaped twice.
+ */
+export const escapeHtml = (text: string): string =>
+  text.replace(/&(?!(?:[a-zA-Z]+|#\d+|#x[0-9a-fA-F]+);)|[<>"']/g, char => HTML_ESCAPES[char]);
+
+/**
+ * Escapes text for XML documents we generate (feeds, sitemaps). Unlike
+ * escapeHtml every '&' is escaped, since XML knows only five named entities.
+ */
+export const escapeXml = (text: string): string =>
+  text.replace(/[&<>"']/g, char => HTML_ESCAPES[char]);
+
+/**
+ * Applies the configured policy. null and undefined pass through, so this
+ * can wrap optional fields directly.
+ */
+export const sanitizeText = <T extends string | null | undefined>(value: T): T => {
+  if (typeof value !== 'string') return value;
+
+  switch (getSanitizeMode()) {
+    case 'strip':
+      return stripHtml(value) as T;
+    case 'escape':
+      return escapeHtml(value) as T;
+    default:
+      return value;
+  }
+};
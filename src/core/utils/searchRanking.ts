@@ -0,0 +1,116 @@
+import pool from './database';
+
+/**
+ * Knobs for sortBy=relevance on GET /api/movies. A result's score is
+ *
+ *   title_weight * title match + popularity_weight * popularity + recency_weight * recency
+ *
+ * where each component is between 0 and 1. Change them through
+ * /api/admin/search-ranking to tune results without redeploying, and compare
+ * with ?explain=true, which returns each result's components.
+ */
+export const RANKING_SETTINGS = {
+  title_weight: {
+    default: 0.6,
+    description: 'How much a close title match counts (exact > prefix > word > substring)'
+  },
+  popularity_weight: {
+    default: 0.3,
+    description: 'How much the nightly popularity score counts'
+  },
+  recency_weight: {
+    default: 0.1,
+    description: 'How much a recent release date counts'
+  },
+  recency_half_life_years: {
+    default: 5,
+    description: 'Years after release at which the recency component halves'
+  }
+} as const;
+
+export type RankingSetting = keyof typeof RANKING_SETTINGS;
+
+export type RankingWeights = Record<RankingSetting, number>;
+
+export interface RankingSettingState {
+  setting: RankingSetting;
+  value: number;
+  source: 'env' | 'database' | 'default';
+  description: string;
+}
+
+/**
+ * How long database values are reused before being read again
+ */
+const CACHE_TTL_MS = 30_000;
+
+let databaseValues: Map<string, number> | null = null;
+let loadedAt = 0;
+
+export const isRankingSetting = (key: string): key is RankingSetting =>
+  Object.prototype.hasOwnProperty.call(RANKING_SETTINGS, key);
+
+/**
+ * Environment override, e.g. RANKING_TITLE_WEIGHT=0.8
+ */
+const getEnvOverride = (setting: RankingSetting): number | undefined => {
+  const value = parseFloat(process.env[`RANKING_${setting.toUpperCase()}`] ?? '');
+  return Number.isFinite(value) && value >= 0 ? value : undefined;
+};
+
+const loadDatabaseValues = async (): Promise<Map<string, number>> => {
+  if (databaseValues && Date.now() - loadedAt < CACHE_TTL_MS) {
+    return databaseValues;
+  }
+
+  try {
+    const result = await pool.query<{ setting_key: string; value: number }>(
+      'SELECT setting_key, value FROM search_ranking_settings'
+    );
+    databaseValues = new Map(result.rows.map(row => [row.setting_key, row.value]));
+    loadedAt = Date.now();
+  } catch (error) {
+    // Keep ranking with the last known values (or defaults) if the table is unreachable
+    console.error('Failed to load search ranking settings:', error);
+    databaseValues ??= new Map();
+  }
+
+  return databaseValues;
+};
+
+/**
+ * Resolves every setting, with the same precedence as feature flags:
+ * environment variable, then database row, then default
+ */
+export const getRankingSettings = async (): Promise<RankingSettingState[]> => {
+  const stored = await loadDatabaseValues();
+
+  return (Object.keys(RANKING_SETTINGS) as RankingSetting[]).map(setting => {
+    const envValue = getEnvOverride(setting);
+    const storedValue = stored.get(setting);
+
+    return {
+      setting,
+      value: envValue ?? storedValue ?? RANKING_SETTINGS[setting].default,
+      source: envValue !== undefined ? 'env' : storedValue !== undefined ? 'database' : 'default',
+      description: RANKING_SETTINGS[setting].description
+    };
+  });
+};
+
+/**
+ * Current value of every setting, keyed by name
+ */
+export const getRankingWeights = async (): Promise<RankingWeights> => {
+  const settings = await getRankingSettings();
+  return Object.fromEntries(settings.map(state => [state.setting, state.value])) as RankingWeights;
+};
+
+/**
+ * Forgets cached database values so a change takes effect immediately on this
+ * instance (invalidateCache() also clears other
+ * replicas; without the cache bus they pick it up within CACHE_TTL_MS)
+ */
+export const clearRankingCache = (): void => {
+  databaseValues = null;
+};
@@ -0,0 +1,135 @@
+import crypto from 'crypto';
+import pool from './database';
+
+/**
+ * Cookie sessions, an alternative to bearer tokens for browser front ends.
+ *
+ * AUTH_MODE selects what login hands out and what authenticates requests:
+ * - token (default): access + refresh tokens only
+ * - session: an httpOnly session cookie only
+ * - both: tokens, or a session when the login asks for one ("session": true)
+ *
+ * Session-authenticated writes need a CSRF token (see csrfProtection).
+ */
+export type AuthMode = 'token' | 'session' | 'both';
+
+export const SESSION_COOKIE = 'sid';
+export const CSRF_COOKIE = 'csrf-token';
+export const CSRF_HEADER = 'x-csrf-token';
+
+/**
+ * Sessions end after this long without a request...
+ */
+const DEFAULT_IDLE_MINUTES = 120;
+
+/**
+ * ...and after this long regardless
+ */
+const MAX_SESSION_DAYS = 7;
+
+export interface SessionUser {
+  user_id: number;
+  username: string;
+  role: string;
+}
+
+/**
+ * How users sign in (AUTH_MODE): bearer tokens, cookie sessions or both
+ */
+export const getAuthMode = (): AuthMode => {
+  const mode = process.env.AUTH_MODE?.toLowerCase();
+  return mode === 'session' || mode === 'both' ? mode : 'token';
+};
+
+export const sessionsEnabled = (): boolean => getAuthMode() !== 'token';
+
+export const tokensEnabled = (): boolean => getAuthMode() !== 'session';
+
+const getIdleMinutes = (): number =>
+  parseInt(process.env.SESSION_IDLE_MINUTES ?? '', 10) || DEFAULT_IDLE_MINUTES;
+
+const hashSessionId = (sessionId: string): string =>
+  crypto.createHash('sha256').update(sessionId).digest('hex');
+
+const getCsrfSecret = (): string => {
+  const secret = process.env.CSRF_SECRET ?? process.env.ACCESS_SECRET ?? process.env.REFRESH_SECRET;
+  if (!secret) {
+    throw new Error('Must set CSRF_SECRET (or ACCESS_SECRET) env variable for cookie sessions');
+  }
+  return secret;
+};
+
+/**
+ * CSRF token for a session: an HMAC of the session ID, so a token planted in
+ * the browser by another site can't match a session it doesn't know
+ */
+export const csrfTokenFor = (sessionId: string): string =>
+  crypto.createHmac('sha256', getCsrfSecret()).update(sessionId).digest('base64url');
+
+/**
+ * Constant-time check of a presented CSRF token against the session's
+ */
+export const isValidCsrfToken = (sessionId: string, token: string | undefined): boolean => {
+  if (!token) return false;
+  const expected = Buffer.from(csrfTokenFor(sessionId));
+  const presented = Buffer.from(token);
+  return presented.length === expected.length && crypto.timingSafeEqual(presented, expected);
+};
+
+/**
+ * Creates a session for a user who just logged in
+ *
+ * @returns The session ID for the cookie and when the session ends at the latest
+ */
+export const createSession = async (
+  userId: number,
+  meta: { ip?: string | null; userAgent?: string | null } = {}
+): Promise<{ sessionId: string; expiresAt: Date }> => {
+  const sessionId = crypto.randomBytes(32).toString('base64url');
+  const expiresAt = new Date(Date.now() + MAX_SESSION_DAYS * 24 * 60 * 60 * 1000);
+
+  // Expired sessions are cleaned up as new ones start
+  await pool.query('DELETE FROM user_sessions WHERE expires_at < NOW()');
+  await pool.query(
+    `INSERT INTO user_sessions (session_hash, user_id, expires_at, ip_address, user_agent)
+     VALUES ($1, $2, $3, $4, $5)`,
+    [hashSessionId(sessionId), userId, expiresAt, meta.ip ?? null, meta.userAgent ?? null]
+  );
+
+  return { sessionId, expiresAt };
+};
+
+/**
+ * The user a session belongs to, refreshing its idle timer; null when the
+ * session is unknown, idle too long or past its end
+ */
+export const getSessionUser = async (sessionId: string): Promise<SessionUser | null> => {
+  const result = await pool.query<SessionUser>(
+    `UPDATE user_sessions s SET last_seen_at = NOW()
+     FROM users u
+     WHERE s.session_hash = $1
+       AND u.user_id = s.user_id
+       AND s.expires_at > NOW()
+       AND s.last_seen_at > NOW() - $2 * INTERVAL '1 minute'
+     RETURNING u.user_id, u.username, u.role`,
+    [hashSessionId(sessionId), getIdleMinutes()]
+  );
+  return result.rows[0] ?? null;
+};
+
+/**
+ * Ends one session (logging out this browser)
+ */
+export const destroySession = async (sessionId: string): Promise<void> => {
+  await pool.query('DELETE FROM user_sessions WHERE session_hash = $1', [hashSessionId(sessionId)]);
+};
+
+/**
+ * Ends every session a user has
+ *
+ * @returns Number of sessions ended
+ */
+export const destroyAllSessions = async (userId: number): Promise<number> => {
+  const result = await pool.query('DELETE FROM user_sessions WHERE user_id = $1', [userId]);
+  return result.rowCount ?? 0;
+};
@@ -0,0 +1,161 @@
+/**
+ * Builder for the dynamic WHERE clauses of list and search endpoints.
+ *
+ * Values only ever reach SQL as numbered parameters: a condition is written
+ * as a function of its placeholder, and the builder assigns the number. Sort
+ * columns and other identifiers must still come from a whitelist.
+ */
+
+export type SqlValue = string | number | boolean | null | string[];
+
+export class SqlFilter {
+  readonly params: SqlValue[] = [];
+  private readonly conditions: string[] = [];
+
+  /**
+   * Adds a parameter outside the WHERE clause (LIMIT, a join condition...)
+   *
+   * @returns Its placeholder, e.g. $3
+   */
+  param(value: SqlValue): string {
+    this.params.push(value);
+    return `$${this.params.length}`;
+  }
+
+  /**
+   * Adds a condition on one value
+   *
+   * @example
+   * filter.add(p => `m.budget >= ${p}`, minBudget);
+   */
+  add(condition: (placeholder: string) => string, value: SqlValue): this {
+    this.conditions.push(condition(this.param(value)));
+    return this;
+  }
+
+  /**
+   * Adds a condition that takes no parameters
+   */
+  addRaw(condition: string): this {
+    this.conditions.push(condition);
+    return this;
+  }
+
+  get isEmpty(): boolean {
+    return this.conditions.length === 0;
+  }
+
+  /**
+   * Conditions joined with AND, or TRUE when there are none
+   */
+  get conditionSql(): string {
+    return this.isEmpty ? 'TRUE' : this.conditions.join(' AND ');
+  }
+
+  /**
+   * "WHERE ..." for the conditions, or an empty string when there are none
+   */
+  get whereSql(): string {
+    return this.isEmpty ? '' : `WHERE ${this.conditions.join(' AND ')}`;
+  }
+}
+
+/**
+ * Movie filters shared by GET /movies and the bulk endpoints; conditions are
+ * on movies aliased as m
+ */
+export interface MovieFilterCriteria {
+  title?: string;
+  year?: number;
+  minYear?: number;
+  maxYear?: number;
+  genre?: string;
+  /** Keywords the movie must all have */
+  keywords?: string[];
+  rating?: string;
+  actor?: string;
+  director?: string;
+  studio?: string;
+  collection?: string;
+  minBudget?: number;
+  maxBudget?: number;
+  minRevenue?: number;
+  maxRevenue?: number;
+  minRuntime?: number;
+  maxRuntime?: number;
+  startDate?: string;
+  endDate?: string;
+  era?: string;
+}
+
+/**
+ * Adds a condition for each criterion that's set. Name filters match
+ * substrings case-insensitively; genre and keywords match whole names.
+ */
+export const addMovieFilters = (filter: SqlFilter, criteria: MovieFilterCriteria): SqlFilter => {
+  const {
+    title, year, minYear, maxYear, genre, keywords, rating,
+    actor, director, studio, collection,
+    minBudget, maxBudget, minRevenue, maxRevenue,
+    minRuntime, maxRuntime,
+    startDate, endDate, era
+  } = criteria;
+
+  if (title) filter.add(p => `m.title ILIKE ${p}`, `%${title}%`);
+  if (year !== undefined) filter.add(p => `EXTRACT(YEAR FROM m.release_date) = ${p}`, year);
+  if (minYear !== undefined) filter.add(p => `EXTRACT(YEAR FROM m.release_date) >= ${p}`, minYear);
+  if (maxYear !== undefined) filter.add(p => `EXTRACT(YEAR FROM m.release_date) <= ${p}`, maxYear);
+  if (genre) {
+    filter.add(p => `EXISTS (
+      SELECT 1 FROM movie_genres mg2
+      JOIN genres g2 ON mg2.genre_id = g2.genre_id
+      WHERE mg2.movie_id = m.movie_id AND LOWER(g2.genre_name) = LOWER(${p})
+    )`, genre);
+  }
+  if (keywords && keywords.length > 0) {
+    filter.add(p => `(
+      SELECT COUNT(DISTINCT k2.keyword_id) FROM movie_keywords mk2
+      JOIN keywords k2 ON mk2.keyword_id = k2.keyword_id
+      WHERE mk2.movie_id = m.movie_id AND k2.keyword_name = ANY(${p}::text[])
+    ) = ${keywords.length}`, keywords);
+  }
+  if (rating) filter.add(p => `m.mpa_rating = ${p}`, rating);
+  if (actor) {
+    filter.add(p => `EXISTS (
+      SELECT 1 FROM movie_actors ma2
+      JOIN actors a2 ON ma2.actor_id = a2.actor_id
+      WHERE ma2.movie_id = m.movie_id AND LOWER(a2.actor_name) LIKE LOWER(${p})
+    )`, `%${actor}%`);
+  }
+  if (director) {
+    filter.add(p => `EXISTS (
+      SELECT 1 FROM movie_directors md2
+      JOIN directors d2 ON md2.director_id = d2.director_id
+      WHERE md2.movie_id = m.movie_id AND LOWER(d2.director_name) LIKE LOWER(${p})
+    )`, `%${director}%`);
+  }
+  if (studio) {
+    filter.add(p => `EXISTS (
+      SELECT 1 FROM movie_studios ms2
+      JOIN studios s2 ON ms2.studio_id = s2.studio_id
+      WHERE ms2.movie_id = m.movie_id AND LOWER(s2.studio_name) LIKE LOWER(${p})
+    )`, `%${studio}%`);
+  }
+  if (collection) {
+    filter.add(p => `EXISTS (
+      SELECT 1 FROM collections c2
+      WHERE m.collection_id = c2.collection_id AND LOWER(c2.collection_name) LIKE LOWER(${p})
+    )`, `%${collection}%`);
+  }
+  if (minBudget !== undefined) filter.add(p => `m.budget >= ${p}`, minBudget);
+  if (maxBudget !== undefined) filter.add(p => `m.budget <= ${p}`, maxBudget);
+  if (minRevenue !== undefined) filter.add(p => `m.revenue >= ${p}`, minRevenue);
+  if (maxRevenue !== undefined) filter.add(p => `m.revenue <= ${p}`, maxRevenue);
+  if (minRuntime !== undefined) filter.add(p => `m.runtime_minutes >= ${p}`, minRuntime);
+  if (maxRuntime !== undefined) filter.add(p => `m.runtime_minutes <= ${p}`, maxRuntime);
+  if (startDate) filter.add(p => `m.release_date >= ${p}`, startDate);
+  if (endDate) filter.add(p => `m.release_date <= ${p}`, endDate);
+  if (era) filter.add(p => `m.era = ${p}`, era);
+
+  return filter;
+};
@@ -0,0 +1,98 @@
+import type { Enricher, MovieMetadata } from './enrichers';
+import { sanitizeText } from './sanitize';
+import { tracedFetch } from './tracing';
+
+/**
+ * The Movie Database (TMDB) as a metadata provider (see enrichers). Needs a
+ * v3 API key in TMDB_API_KEY.
+ *
+ * A movie is found by searching its title within its release year and taking
+ * the top result. TMDB reports unknown runtimes and amounts as 0; those come
+ * back as null so they never overwrite a known value.
+ */
+
+const API_BASE_URL = 'https://api.themoviedb.org/3';
+const IMAGE_BASE_URL = 'https://image.tmdb.org/t/p';
+
+interface TmdbSearchResponse {
+  results: { id: number; title: string; release_date?: string }[];
+}
+
+interface TmdbMovie {
+  id: number;
+  original_title: string | null;
+  overview: string | null;
+  runtime: number | null;
+  budget: number;
+  revenue: number;
+  vote_count: number;
+  vote_average: number;
+  poster_path: string | null;
+  backdrop_path: string | null;
+  videos?: { results: { site: string; type: string; key: string; official?: boolean }[] };
+}
+
+const getJson = async <T>(path: string, params: Record<string, string>): Promise<T> => {
+  const url = new URL(`${API_BASE_URL}${path}`);
+  url.searchParams.set('api_key', process.env.TMDB_API_KEY ?? '');
+  for (const [name, value] of Object.entries(params)) {
+    url.searchParams.set(name, value);
+  }
+
+  const response = await tracedFetch(url);
+  if (!response.ok) {
+    throw new Error(`TMDB ${path} failed with ${response.status}`);
+  }
+  return await response.json() as T;
+};
+
+/**
+ * YouTube URL of the movie's trailer, preferring official ones
+ */
+const trailerUrl = (movie: TmdbMovie): string | null => {
+  const trailers = (movie.videos?.results ?? [])
+    .filter(video => video.site === 'YouTube' && video.type === 'Trailer');
+  const trailer = trailers.find(video => video.official) ?? trailers[0];
+  return trailer ? `https://www.youtube.com/watch?v=${trailer.key}` : null;
+};
+
+const imageUrl = (path: string | null, size: string): string | null =>
+  path ? `${IMAGE_BASE_URL}/${size}${path}` : null;
+
+/**
+ * Looks a movie up by title and release year
+ *
+ * @returns Its metadata, or null if TMDB has no match
+ */
+const fetchTmdbMetadata = async (title: string, year: number | null): Promise<MovieMetadata | null> => {
+  const search = await getJson<TmdbSearchResponse>('/search/movie', {
+    query: title,
+    ...(year ? { primary_release_year: String(year) } : {})
+  });
+  const match = search.results[0];
+  if (!match) {
+    return null;
+  }
+
+  const movie = await getJson<TmdbMovie>(`/movie/${match.id}`, { append_to_response: 'videos' });
+
+  return {
+    original_title: movie.original_title || null,
+    overview: sanitizeText(movie.overview) || null,
+    runtime_minutes: movie.runtime || null,
+    budget: movie.budget || null,
+    revenue: movie.revenue || null,
+    vote_count: movie.vote_count || null,
+    // Stored with one decimal
+    vote_average: movie.vote_count ? Math.round(movie.vote_average * 10) / 10 : null,
+    poster_url: imageUrl(movie.poster_path, 'w500'),
+    backdrop_url: imageUrl(movie.backdrop_path, 'w1280'),
+    trailer_url: trailerUrl(movie)
+  };
+};
+
+export const tmdbEnricher: Enricher = {
+  name: 'tmdb',
+  isConfigured: () => Boolean(process.env.TMDB_API_KEY),
+  lookup: fetchTmdbMetadata
+};
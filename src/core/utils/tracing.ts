@@ -0,0 +1,302 @@
+import { AsyncLocalStorage } from 'async_hooks';
+import crypto from 'crypto';
+
+/**
+ * Minimal OpenTelemetry-compatible tracer.
+ *
+ * Spans are batched and sent as OTLP/HTTP JSON to the collector named by the
+ * standard OTEL_EXPORTER_OTLP_ENDPOINT (or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT)
+ * variable, with OTEL_EXPORTER_OTLP_HEADERS and OTEL_SERVICE_NAME honoured.
+ * Tracing is off when no endpoint is configured. Incoming and outgoing requests
+ * carry W3C traceparent headers so traces join up with other services.
+ */
+
+/** OTLP span kinds */
+export enum SpanKind {
+  INTERNAL = 1,
+  SERVER = 2,
+  CLIENT = 3
+}
+
+type AttributeValue = string | number | boolean;
+
+interface SpanOptions {
+  kind?: SpanKind;
+  attributes?: Record<string, AttributeValue | undefined>;
+  /** Parent from an incoming traceparent header; defaults to the active span */
+  parent?: { traceId: string; spanId: string };
+}
+
+const FLUSH_INTERVAL_MS = 5000;
+const MAX_BATCH_SIZE = 512;
+const MAX_QUEUE_SIZE = 4096;
+
+const DEFAULT_SERVICE_NAME = 'tcss460-api';
+
+/**
+ * Current wall-clock time in nanoseconds, with sub-millisecond precision
+ */
+const nowUnixNano = (): bigint =>
+  BigInt(Math.round((performance.timeOrigin + performance.now()) * 1e6));
+
+const getTracesEndpoint = (): string | undefined => {
+  if (process.env.OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) {
+    return process.env.OTEL_EXPORTER_OTLP_TRACES_ENDPOINT;
+  }
+  if (process.env.OTEL_EXPORTER_OTLP_ENDPOINT) {
+    return `${process.env.OTEL_EXPORTER_OTLP_ENDPOINT.replace(/\/+$/, '')}/v1/traces`;
+  }
+  return undefined;
+};
+
+/**
+ * Whether spans are being recorded
+ */
+export const isTracingEnabled = (): boolean => getTracesEndpoint() !== undefined;
+
+export class Span {
+  readonly spanId = crypto.randomBytes(8).toString('hex');
+  readonly traceId: string;
+  readonly parentSpanId?: string;
+  readonly kind: SpanKind;
+  private readonly startTime = nowUnixNano();
+  private endTime?: bigint;
+  private attributes: Record<string, AttributeValue> = {};
+  private status: { code: 0 | 1 | 2; message?: string } = { code: 0 };
+
+  constructor(
+    public name: string,
+    readonly recording: boolean,
+    options: SpanOptions
+  ) {
+    const parent = options.parent ?? activeSpan.getStore();
+    this.traceId = parent?.traceId ?? crypto.randomBytes(16).toString('hex');
+    this.parentSpanId = parent?.spanId;
+    this.kind = options.kind ?? SpanKind.INTERNAL;
+    this.setAttributes(options.attributes ?? {});
+  }
+
+  setAttributes(attributes: Record<string, AttributeValue | undefined>): this {
+    for (const [key, value] of Object.entries(attributes)) {
+      if (value !== undefined) this.attributes[key] = value;
+    }
+    return this;
+  }
+
+  recordError(error: unknown): this {
+    this.status = { code: 2, message: error instanceof Error ? error.message : String(error) };
+    if (error instanceof Error) {
+      this.attributes['exception.type'] = error.name;
+    }
+    return this;
+  }
+
+  end(): void {
+    if (this.endTime !== undefined) return;
+    this.endTime = nowUnixNano();
+    if (this.recording) enqueue(this);
+  }
+
+  /**
+   * W3C traceparent header value pointing at this span
+   */
+  get traceparent(): string {
+    return `00-${this.traceId}-${this.spanId}-01`;
+  }
+
+  toOtlp() {
+    return {
+      traceId: this.traceId,
+      spanId: this.spanId,
+      parentSpanId: this.parentSpanId ?? '',
+      name: this.name,
+      kind: this.kind,
+      startTimeUnixNano: this.startTime.toString(),
+      endTimeUnixNano: (this.endTime ?? nowUnixNano()).toString(),
+      attributes: toOtlpAttributes(this.attributes),
+      status: this.status
+    };
+  }
+}
+
+const activeSpan = new AsyncLocalStorage<Span>();
+
+const toOtlpAttributes = (attributes: Record<string, AttributeValue>) =>
+  Object.entries(attributes).map(([key, value]) => ({
+    key,
+    value: typeof value === 'string'
+      ? { stringValue: value }
+      : typeof value === 'boolean'
+        ? { boolValue: value }
+        : Number.isInteger(value)
+          ? { intValue: String(value) }
+          : { doubleValue: value }
+  }));
+
+// ============================================================================
+// Export
+// ============================================================================
+
+let queue: Span[] = [];
+let flushTimer: NodeJS.Timeout | null = null;
+
+const parseHeaders = (raw: string | undefined): Record<string, string> => {
+  const headers: Record<string, string> = {};
+  for (const pair of (raw ?? '').split(',')) {
+    const index = pair.indexOf('=');
+    if (index > 0) {
+      headers[decodeURIComponent(pair.slice(0, index).trim())] = decodeURIComponent(pair.slice(index + 1).trim());
+    }
+  }
+  return headers;
+};
+
+const enqueue = (span: Span): void => {
+  // Drop rather than grow without bound if the collector is down
+  if (queue.length >= MAX_QUEUE_SIZE) return;
+
+  queue.push(span);
+  if (queue.length >= MAX_BATCH_SIZE) {
+    void flushSpans();
+  } else if (!flushTimer) {
+    flushTimer = setTimeout(() => void flushSpans(), FLUSH_INTERVAL_MS);
+    flushTimer.unref();
+  }
+};
+
+/**
+ * Sends all queued spans to the collector. Export failures are logged, never thrown.
+ */
+export const flushSpans = async (): Promise<void> => {
+  if (flushTimer) {
+    clearTimeout(flushTimer);
+    flushTimer = null;
+  }
+
+  const endpoint = getTracesEndpoint();
+  const batch = queue;
+  queue = [];
+  if (!endpoint || batch.length === 0) return;
+
+  const body = {
+    resourceSpans: [{
+      resource: {
+        attributes: toOtlpAttributes({
+          'service.name': process.env.OTEL_SERVICE_NAME ?? DEFAULT_SERVICE_NAME,
+          'deployment.environment': process.env.NODE_ENV ?? 'development'
+        })
+      },
+      scopeSpans: [{
+        scope: { name: DEFAULT_SERVICE_NAME },
+        spans: batch.map(span => span.toOtlp())
+      }]
+    }]
+  };
+
+  try {
+    const response = await fetch(endpoint, {
+      method: 'POST',
+      headers: {
+        'Content-Type': 'application/json',
+        ...parseHeaders(process.env.OTEL_EXPORTER_OTLP_HEADERS)
+      },
+      body: JSON.stringify(body)
+    });
+    if (!response.ok) {
+      console.error(`Trace export failed with ${response.status}: ${await response.text()}`);
+    }
+  } catch (error) {
+    console.error('Trace export failed:', error instanceof Error ? error.message : error);
+  }
+};
+
+// ============================================================================
+// API
+// ============================================================================
+
+/**
+ * Starts a span, parented to the active span unless options.parent is given.
+ * The caller must end() it. Returns a non-recording span when tracing is off.
+ *
+ * @param name - Span name, e.g. "GET /api/movies/:id" or "pg.query"
+ * @param options - Kind, attributes and optional explicit parent
+ */
+export const startSpan = (name: string, options: SpanOptions = {}): Span =>
+  new Span(name, isTracingEnabled(), options);
+
+/**
+ * Runs fn with a new span active, so spans started inside become its children.
+ * The span ends when fn settles and records the error if fn throws.
+ *
+ * @param name - Span name
+ * @param options - Kind and attributes
+ * @param fn - Work to trace; receives the span to add attributes
+ */
+export const withSpan = async <T>(
+  name: string,
+  options: SpanOptions,
+  fn: (span: Span) => Promise<T>
+): Promise<T> => {
+  const span = startSpan(name, options);
+  try {
+    return await activeSpan.run(span, () => fn(span));
+  } catch (error) {
+    span.recordError(error);
+    throw error;
+  } finally {
+    span.end();
+  }
+};
+
+/**
+ * Runs fn with an already-started span active (used by the HTTP middleware)
+ */
+export const runInSpan = <T>(span: Span, fn: () => T): T => activeSpan.run(span, fn);
+
+/**
+ * The span active in the current async context, if any
+ */
+export const getActiveSpan = (): Span | undefined => activeSpan.getStore();
+
+/**
+ * Parses a W3C traceparent header
+ *
+ * @returns The remote parent, or undefined when missing or malformed
+ */
+export const parseTraceparent = (header: string | undefined): { traceId: string; spanId: string } | undefined => {
+  const match = header?.match(/^[0-9a-f]{2}-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$/);
+  if (!match || /^0+$/.test(match[1]) || /^0+$/.test(match[2])) return undefined;
+  return { traceId: match[1], spanId: match[2] };
+};
+
+/**
+ * fetch wrapped in a CLIENT span that propagates the trace to the callee
+ *
+ * @param input - URL to fetch
+ * @param init - Regular fetch options
+ */
+export const tracedFetch = (input: string | URL, init: RequestInit = {}): Promise<globalThis.Response> => {
+  const url = new URL(input);
+  const method = init.method ?? 'GET';
+
+  return withSpan(`${method} ${url.host}`, {
+    kind: SpanKind.CLIENT,
+    attributes: {
+      'http.request.method': method,
+      'url.full': `${url.origin}${url.pathname}`,
+      'server.address': url.hostname
+    }
+  }, async (span) => {
+    const headers = new Headers(init.headers);
+    if (span.recording) {
+      headers.set('traceparent', span.traceparent);
+    }
+
+    const response = await fetch(url, { ...init, headers });
+    span.setAttributes({ 'http.response.status_code': response.status });
+    if (response.status >= 500) {
+      span.recordError(new Error(`HTTP ${response.status}`));
+    }
+    return response;
+  });
+};
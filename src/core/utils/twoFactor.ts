@@ -0,0 +1,278 @@
+import crypto from 'crypto';
+import { Pool, PoolClient } from 'pg';
+import pool from './database';
+
+/**
+ * TOTP two-factor authentication (RFC 6238: HMAC-SHA1, 30-second steps,
+ * 6 digits), compatible with Google Authenticator, Duo, 1Password etc.
+ */
+
+const STEP_SECONDS = 30;
+const DIGITS = 6;
+
+/**
+ * Steps either side of now still accepted, for clock drift
+ */
+const DRIFT_STEPS = 1;
+
+const RECOVERY_CODE_COUNT = 10;
+
+const ISSUER = 'TCSS 460 Movie API';
+
+const BASE32_ALPHABET = 'ABCDEFGHIJKLMNOPQRSTUVWXYZ234567';
+
+export interface TwoFactorStatus {
+  enabled: boolean;
+  /** Enrollment started but not yet confirmed with a code */
+  pending: boolean;
+  recoveryCodesRemaining: number;
+  /** Admins must use 2FA (unless REQUIRE_ADMIN_2FA=false) */
+  required: boolean;
+}
+
+/**
+ * Whether admin accounts must have 2FA before they can sign in
+ */
+export const isTwoFactorRequiredForRole = (role: string): boolean =>
+  role === 'admin' && process.env.REQUIRE_ADMIN_2FA !== 'false';
+
+const base32Encode = (bytes: Buffer): string => {
+  let bits = 0;
+  let value = 0;
+  let out = '';
+  for (const byte of bytes) {
+    value = ((value << 8) | byte) & 0xfff;
+    bits += 8;
+    while (bits >= 5) {
+      out += BASE32_ALPHABET[(value >>> (bits - 5)) & 31];
+      bits -= 5;
+    }
+  }
+  if (bits > 0) {
+    out += BASE32_ALPHABET[(value << (5 - bits)) & 31];
+  }
+  return out;
+};
+
+const base32Decode = (text: string): Buffer => {
+  let bits = 0;
+  let value = 0;
+  const out: number[] = [];
+  for (const char of text.toUpperCase().replace(/[^A-Z2-7]/g, '')) {
+    value = ((value << 5) | BASE32_ALPHABET.indexOf(char)) & 0xfff;
+    bits += 5;
+    if (bits >= 8) {
+      out.push((value >>> (bits - 8)) & 255);
+      bits -= 8;
+    }
+  }
+  return Buffer.from(out);
+};
+
+const hotp = (secret: Buffer, counter: number): string => {
+  const message = Buffer.alloc(8);
+  message.writeBigUInt64BE(BigInt(counter));
+  const hmac = crypto.createHmac('sha1', secret).update(message).digest();
+  const offset = hmac[hmac.length - 1] & 0xf;
+  const code = (hmac.readUInt32BE(offset) & 0x7fffffff) % 10 ** DIGITS;
+  return code.toString().padStart(DIGITS, '0');
+};
+
+/**
+ * The time step a code matches, or null. Steps at or before lastStep were
+ * already used and are rejected.
+ */
+export const matchTotpStep = (secret: string, code: string, lastStep: number | null): number | null => {
+  if (!/^\d{6}$/.test(code)) return null;
+
+  const key = base32Decode(secret);
+  const current = Math.floor(Date.now() / 1000 / STEP_SECONDS);
+  for (let step = current - DRIFT_STEPS; step <= current + DRIFT_STEPS; step++) {
+    if (lastStep !== null && step <= lastStep) continue;
+    if (crypto.timingSafeEqual(Buffer.from(hotp(key, step)), Buffer.from(code))) {
+      return step;
+    }
+  }
+  return null;
+};
+
+/**
+ * Recovery codes are compared case- and punctuation-insensitively
+ */
+const hashRecoveryCode = (code: string): string =>
+  crypto.createHash('sha256').update(code.toLowerCase().replace(/[^a-z0-9]/g, '')).digest('hex');
+
+/**
+ * Replaces a user's recovery codes with a fresh set
+ *
+ * @param db - Pool or the caller's transaction client
+ * @returns The new codes; only their hashes are stored, so show them once
+ */
+export const replaceRecoveryCodes = async (db: Pool | PoolClient, userId: number): Promise<string[]> => {
+  const codes = Array.from({ length: RECOVERY_CODE_COUNT }, () => {
+    const raw = base32Encode(crypto.randomBytes(7)).slice(0, 10).toLowerCase();
+    return `${raw.slice(0, 5)}-${raw.slice(5)}`;
+  });
+
+  await db.query('DELETE FROM user_recovery_codes WHERE user_id = $1', [userId]);
+  await db.query(
+    `INSERT INTO user_recovery_codes (user_id, code_hash)
+     SELECT $1, UNNEST($2::char(64)[])`,
+    [userId, codes.map(hashRecoveryCode)]
+  );
+
+  return codes;
+};
+
+/**
+ * A user's 2FA state, or null when the user doesn't exist
+ */
+export const getTwoFactorStatus = async (userId: number): Promise<TwoFactorStatus | null> => {
+  const result = await pool.query(
+    `SELECT
+       u.role,
+       u.totp_enabled_at IS NOT NULL AS enabled,
+       u.totp_secret IS NOT NULL AND u.totp_enabled_at IS NULL AS pending,
+       (SELECT COUNT(*)::int FROM user_recovery_codes r
+        WHERE r.user_id = u.user_id AND r.used_at IS NULL) AS recovery_codes_remaining
+     FROM users u
+     WHERE u.user_id = $1`,
+    [userId]
+  );
+  const row = result.rows[0];
+  if (!row) return null;
+
+  return {
+    enabled: row.enabled,
+    pending: row.pending,
+    recoveryCodesRemaining: row.recovery_codes_remaining,
+    required: isTwoFactorRequiredForRole(row.role)
+  };
+};
+
+/**
+ * Starts (or restarts) enrollment with a new secret. 2FA isn't enforced until
+ * confirmTotpEnrollment succeeds, so an abandoned setup locks nobody out.
+ *
+ * @param userId - User enrolling
+ * @param accountName - Label shown in the authenticator app (the email)
+ * @returns The base32 secret and an otpauth:// URI for a QR code
+ */
+export const beginTotpEnrollment = async (
+  userId: number,
+  accountName: string
+): Promise<{ secret: string; otpauthUrl: string }> => {
+  const secret = base32Encode(crypto.randomBytes(20));
+
+  await pool.query(
+    'UPDATE users SET totp_secret = $2, totp_enabled_at = NULL, totp_last_step = NULL WHERE user_id = $1',
+    [userId, secret]
+  );
+
+  const label = encodeURIComponent(`${ISSUER}:${accountName}`);
+  const params = new URLSearchParams({
+    secret,
+    issuer: ISSUER,
+    algorithm: 'SHA1',
+    digits: String(DIGITS),
+    period: String(STEP_SECONDS)
+  });
+
+  return { secret, otpauthUrl: `otpauth://totp/${label}?${params.toString()}` };
+};
+
+/**
+ * Turns 2FA on once the user proves their authenticator produces valid codes
+ *
+ * @returns Fresh recovery codes, or null when the code is wrong or no
+ *   enrollment is pending
+ */
+export const confirmTotpEnrollment = async (userId: number, code: string): Promise<string[] | null> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const result = await client.query(
+      `SELECT totp_secret FROM users
+       WHERE user_id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL
+       FOR UPDATE`,
+      [userId]
+    );
+    const secret: string | undefined = result.rows[0]?.totp_secret;
+    const step = secret ? matchTotpStep(secret, code, null) : null;
+    if (step === null) {
+      await client.query('ROLLBACK');
+      return null;
+    }
+
+    await client.query(
+      'UPDATE users SET totp_enabled_at = NOW(), totp_last_step = $2 WHERE user_id = $1',
+      [userId, step]
+    );
+    const codes = await replaceRecoveryCodes(client, userId);
+
+    await client.query('COMMIT');
+    return codes;
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * Checks a second factor: a current authenticator code, or (unless
+ * totpOnly) an unused recovery code, which is then spent
+ *
+ * @returns Which kind of code matched, or null
+ */
+export const verifySecondFactor = async (
+  userId: number,
+  code: string,
+  totpOnly = false
+): Promise<'totp' | 'recovery' | null> => {
+  const result = await pool.query<{ totp_secret: string; totp_last_step: string | null }>(
+    'SELECT totp_secret, totp_last_step FROM users WHERE user_id = $1 AND totp_enabled_at IS NOT NULL',
+    [userId]
+  );
+  const row = result.rows[0];
+  if (!row) return null;
+
+  const trimmed = code.replace(/\s+/g, '');
+  const lastStep = row.totp_last_step === null ? null : Number(row.totp_last_step);
+  const step = matchTotpStep(row.totp_secret, trimmed, lastStep);
+  if (step !== null) {
+    // Conditional update so two concurrent requests can't both spend the same code
+    const accepted = await pool.query(
+      `UPDATE users SET totp_last_step = $2
+       WHERE user_id = $1 AND (totp_last_step IS NULL OR totp_last_step < $2)`,
+      [userId, step]
+    );
+    return (accepted.rowCount ?? 0) > 0 ? 'totp' : null;
+  }
+
+  if (totpOnly) return null;
+
+  const recovery = await pool.query(
+    `UPDATE user_recovery_codes SET used_at = NOW()
+     WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+    [userId, hashRecoveryCode(trimmed)]
+  );
+  return (recovery.rowCount ?? 0) > 0 ? 'recovery' : null;
+};
+
+/**
+ * Turns 2FA off and removes the secret and recovery codes
+ *
+ * @returns false when no such user exists
+ */
+export const disableTwoFactor = async (db: Pool | PoolClient, userId: number): Promise<boolean> => {
+  await db.query('DELETE FROM user_recovery_codes WHERE user_id = $1', [userId]);
+  const result = await db.query(
+    'UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_step = NULL WHERE user_id = $1',
+    [userId]
+  );
+  return (result.rowCount ?? 0) > 0;
+};
@@ -0,0 +1,204 @@
+import crypto from 'crypto';
+import { IncomingMessage } from 'http';
+import { Duplex } from 'stream';
+
+/**
+ * Minimal server side of the WebSocket protocol (RFC 6455), enough to push
+ * text messages to browsers: the upgrade handshake, unfragmented text frames
+ * out, and ping/pong/close in. Messages from clients are read and dropped.
+ */
+
+const HANDSHAKE_GUID = '258EAFA5-E914-47DA-95CA-C5AB0DC85B11';
+
+const OPCODE_TEXT = 0x1;
+const OPCODE_CLOSE = 0x8;
+const OPCODE_PING = 0x9;
+const OPCODE_PONG = 0xa;
+
+/**
+ * Largest client frame accepted; clients only ever need to send control frames
+ */
+const MAX_INCOMING_FRAME_BYTES = 64 * 1024;
+
+/**
+ * A connection that misses a pong for this long is dropped
+ */
+const HEARTBEAT_INTERVAL_MS = 30_000;
+
+const encodeFrame = (opcode: number, payload: Buffer): Buffer => {
+  const length = payload.length;
+  let header: Buffer;
+  if (length < 126) {
+    header = Buffer.from([0x80 | opcode, length]);
+  } else if (length < 0x10000) {
+    header = Buffer.alloc(4);
+    header[0] = 0x80 | opcode;
+    header[1] = 126;
+    header.writeUInt16BE(length, 2);
+  } else {
+    header = Buffer.alloc(10);
+    header[0] = 0x80 | opcode;
+    header[1] = 127;
+    header.writeBigUInt64BE(BigInt(length), 2);
+  }
+  return Buffer.concat([header, payload]);
+};
+
+export class WebSocketConnection {
+  private buffered = Buffer.alloc(0);
+  private closed = false;
+  private awaitingPong = false;
+  private readonly heartbeat: NodeJS.Timeout;
+  private readonly closeHandlers: (() => void)[] = [];
+
+  constructor(private readonly socket: Duplex) {
+    socket.on('data', (chunk: Buffer) => this.receive(chunk));
+    socket.on('close', () => this.handleClosed());
+    socket.on('error', () => socket.destroy());
+
+    this.heartbeat = setInterval(() => {
+      if (this.awaitingPong) {
+        this.socket.destroy();
+        return;
+      }
+      this.awaitingPong = true;
+      this.write(OPCODE_PING, Buffer.alloc(0));
+    }, HEARTBEAT_INTERVAL_MS);
+    this.heartbeat.unref();
+  }
+
+  get isOpen(): boolean {
+    return !this.closed;
+  }
+
+  send(text: string): void {
+    this.write(OPCODE_TEXT, Buffer.from(text, 'utf8'));
+  }
+
+  /**
+   * Starts the closing handshake; the socket ends once the client answers
+   */
+  close(code = 1000, reason = ''): void {
+    if (this.closed) return;
+    const payload = Buffer.alloc(2 + Buffer.byteLength(reason));
+    payload.writeUInt16BE(code, 0);
+    payload.write(reason, 2);
+    this.write(OPCODE_CLOSE, payload);
+    this.closed = true;
+    this.socket.end();
+  }
+
+  onClose(handler: () => void): void {
+    this.closeHandlers.push(handler);
+  }
+
+  private write(opcode: number, payload: Buffer): void {
+    if (this.closed || this.socket.destroyed) return;
+    this.socket.write(encodeFrame(opcode, payload));
+  }
+
+  private handleClosed(): void {
+    clearInterval(this.heartbeat);
+    this.closed = true;
+    const handlers = this.closeHandlers.splice(0);
+    handlers.forEach(handler => handler());
+  }
+
+  /**
+   * Reads whole frames from the buffered bytes
+   */
+  private receive(chunk: Buffer): void {
+    this.buffered = Buffer.concat([this.buffered, chunk]);
+
+    while (this.buffered.length >= 2) {
+      const opcode = this.buffered[0] & 0x0f;
+      const masked = (this.buffered[1] & 0x80) !== 0;
+      let length = this.buffered[1] & 0x7f;
+      let offset = 2;
+
+      if (length === 126) {
+        if (this.buffered.length < 4) return;
+        length = this.buffered.readUInt16BE(2);
+        offset = 4;
+      } else if (length === 127) {
+        if (this.buffered.length < 10) return;
+        const longLength = this.buffered.readBigUInt64BE(2);
+        length = longLength > BigInt(MAX_INCOMING_FRAME_BYTES) ? Infinity : Number(longLength);
+        offset = 10;
+      }
+
+      // Clients must mask every frame
+      if (!masked) {
+        this.close(1002, 'Frames must be masked');
+        return;
+      }
+      if (length > MAX_INCOMING_FRAME_BYTES) {
+        this.close(1009, 'Frame too large');
+        return;
+      }
+      if (this.buffered.length < offset + 4 + length) return;
+
+      const mask = this.buffered.subarray(offset, offset + 4);
+      const payload = Buffer.from(this.buffered.subarray(offset + 4, offset + 4 + length));
+      for (let i = 0; i < payload.length; i++) {
+        payload[i] ^= mask[i % 4];
+      }
+      this.buffered = this.buffered.subarray(offset + 4 + length);
+
+      if (opcode === OPCODE_CLOSE) {
+        // Echo the client's close, then hang up
+        this.write(OPCODE_CLOSE, payload.subarray(0, 2));
+        this.closed = true;
+        this.socket.end();
+        return;
+      }
+      if (opcode === OPCODE_PING) {
+        this.write(OPCODE_PONG, payload);
+      } else if (opcode === OPCODE_PONG) {
+        this.awaitingPong = false;
+      }
+    }
+  }
+}
+
+/**
+ * Rejects an upgrade request with a plain HTTP response
+ */
+export const rejectUpgrade = (socket: Duplex, status: number, message: string): void => {
+  socket.end(
+    `HTTP/1.1 ${status} ${message}\r\n` +
+    'Connection: close\r\n' +
+    'Content-Type: text/plain\r\n' +
+    `Content-Length: ${Buffer.byteLength(message)}\r\n\r\n` +
+    message
+  );
+};
+
+/**
+ * Completes the handshake for an HTTP upgrade request
+ *
+ * @returns The open connection, or null if the request wasn't a valid
+ *          WebSocket handshake (it has been answered with 400)
+ */
+export const acceptWebSocket = (req: IncomingMessage, socket: Duplex): WebSocketConnection | null => {
+  const key = req.headers['sec-websocket-key'];
+  if (
+    req.method !== 'GET'
+    || req.headers.upgrade?.toLowerCase() !== 'websocket'
+    || req.headers['sec-websocket-version'] !== '13'
+    || typeof key !== 'string'
+  ) {
+    rejectUpgrade(socket, 400, 'Bad Request');
+    return null;
+  }
+
+  const accept = crypto.createHash('sha1').update(key + HANDSHAKE_GUID).digest('base64');
+  socket.write(
+    'HTTP/1.1 101 Switching Protocols\r\n' +
+    'Upgrade: websocket\r\n' +
+    'Connection: Upgrade\r\n' +
+    `Sec-WebSocket-Accept: ${accept}\r\n\r\n`
+  );
+
+  return new WebSocketConnection(socket);
+};
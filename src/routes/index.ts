@@ -1,64 +1,248 @@
 import { Router } from 'express';
 import * as c from '../controllers/index';
 import { validateGenerateApiKey } from '@middleware/apiKeyVerification';
-import { requireApiKey } from '@middleware/apiKeyAuth';
+import { requireApiKeyUnlessReadOnly } from '@middleware/readOnly';
+import { requireAdmin } from '@middleware/requireAdmin';
+import { cacheResponse } from '@middleware/responseCache';
+import { queryGuardrails } from '@middleware/queryGuardrails';
+import { requireFeature } from '@middleware/requireFeature';
+import { resolveMovieIds } from '@middleware/resolveMovieId';
+import { requireUser } from '@middleware/userAuth';
+import { validateRequest } from '@middleware/validateRequest';
 
 export const publicRouter = Router();
 export const protectedRouter = Router();
-protectedRouter.use(requireApiKey);
+protectedRouter.use(requireApiKeyUnlessReadOnly);
+protectedRouter.use(queryGuardrails);
+
+// In-process response caches (seconds). Writes clear them, so TTLs only bound
+// staleness from changes made outside the API (imports, sync).
+const searchCache = cacheResponse({ ttl: 60, staleWhileRevalidate: 300 });
+const detailCache = cacheResponse({ ttl: 300, staleWhileRevalidate: 1800 });
+const statsCache = cacheResponse({ ttl: 900, staleWhileRevalidate: 3600 });
+
+// Movie :id parameters accept the integer movie_id or the public_id
+const movieId = resolveMovieIds();
 
 // System routes
 publicRouter.get('/api-info', c.info);
 publicRouter.get('/health', c.healthCheck);
+publicRouter.get('/features', c.getEnabledFeatures);
+publicRouter.get('/postman-collection', c.getPostmanCollection);
+
+// Shared movie lists (public and unlisted only)
+publicRouter.get('/lists/:slug', detailCache, c.getSharedList);
+
+// Unsubscribe links in digest emails (POST is one-click unsubscribe)
+publicRouter.get('/digest/unsubscribe', c.unsubscribeByToken);
+publicRouter.post('/digest/unsubscribe', c.unsubscribeByToken);
+
+// Upcoming releases for calendar apps (no API key; ?feed= limits it to a watchlist)
+publicRouter.get('/releases/upcoming.ics', searchCache, c.getUpcomingReleasesCalendar);
+
+// Latest additions for embedding on other sites (no API key)
+publicRouter.get('/feeds/new-movies.atom', searchCache, c.getNewMoviesFeed);
+
+// Sitemaps for crawlers (no API key)
+publicRouter.get('/sitemap.xml', statsCache, c.getSitemapIndex);
+publicRouter.get('/sitemaps/movies-:page.xml', statsCache, c.getMovieSitemap);
+
+// Link unfurling for chat apps (no API key)
+publicRouter.get('/oembed', detailCache, c.getOEmbed);
+
+// Short links printed on posters (no API key; not cached so visits are counted)
+publicRouter.get('/m/:code', c.followShortLink);
+
+// Stand-in posters for <img> tags (no API key)
+publicRouter.get('/movies/:id/placeholder.svg', movieId, c.getPlaceholderPoster);
+
+// Open Graph preview images for shared links (no API key, rendered and cached on request)
+publicRouter.get('/og/:type/:id.png', c.getOgImage);
+
+// Mirrored images by content hash (no API key)
+publicRouter.get('/assets/:hash', c.getAsset);
+
+// Password login (throttled per account and IP, see loginThrottle)
+publicRouter.post('/auth/login', c.login);
+publicRouter.post('/auth/register', c.register);
+publicRouter.post('/auth/refresh', c.refreshAccessToken);
+publicRouter.post('/auth/logout', c.logout);
+publicRouter.post('/auth/logout-all', c.logoutAll);
+publicRouter.get('/auth/session', c.getCurrentSession);
+publicRouter.get('/auth/oauth/providers', c.getOAuthProviderList);
+publicRouter.get('/auth/oauth/:provider', c.startOAuthLogin);
+publicRouter.get('/auth/oauth/:provider/callback', c.completeOAuthLogin);
+
+// Two-factor authentication; setup/enable also accept an admin's login mfaToken
+publicRouter.get('/auth/2fa', requireUser, c.getTwoFactorSettings);
+publicRouter.post('/auth/2fa/setup', c.setupTwoFactor);
+publicRouter.post('/auth/2fa/enable', c.enableTwoFactor);
+publicRouter.post('/auth/2fa/verify', c.verifyTwoFactor);
+publicRouter.post('/auth/2fa/disable', requireUser, c.disableTwoFactorForUser);
+publicRouter.post('/auth/2fa/recovery-codes', requireUser, c.regenerateRecoveryCodes);
 
-// router.post('/login', c.login)
-// router.post('/register', c.register)
 publicRouter.get('/api-key', c.serveApiKeyForm);
 publicRouter.get('/api-key/info', c.getApiKeyInfo);
 
 publicRouter.post('/api-key', validateGenerateApiKey, c.generateApiKeyController);
 
 // GET
-protectedRouter.get('/movies', c.getAllMovies);
-protectedRouter.get('/movies/:id', c.getMovieById);
-protectedRouter.get('/studios/:id/movies', c.getMoviesByStudioId);
-protectedRouter.get('/studios/name/:name/movies', c.getMoviesByStudio);
-protectedRouter.get('/directors/:id/movies', c.getMoviesByDirectorId);
-protectedRouter.get('/directors/name/:name/movies', c.getMoviesByDirector);
-protectedRouter.get('/actors/:id/movies', c.getMoviesByActorId);
-protectedRouter.get('/actors/name/:name/movies', c.getMoviesByActor);
-protectedRouter.get('/collections/:id/movies', c.getMoviesByCollectionId);
-protectedRouter.get('/collections/name/:name/movies', c.getMoviesByCollection);
+protectedRouter.get('/movies', searchCache, c.getAllMovies);
+protectedRouter.get('/search/semantic', searchCache, c.semanticSearch);
+protectedRouter.get('/search/natural', searchCache, c.naturalLanguageSearch);
+protectedRouter.get('/movies/:id', detailCache, movieId, c.getMovieById);
+protectedRouter.get('/movies/:id/translations', detailCache, movieId, c.getMovieTranslations);
+protectedRouter.get('/movies/:id/cast', detailCache, movieId, c.getMovieCast);
+protectedRouter.get('/movies/:id/history', detailCache, movieId, c.getMovieHistory);
+protectedRouter.get('/movies/:id/similar-by-plot', detailCache, movieId, c.getSimilarByPlot);
+protectedRouter.get('/movies/:id/jsonld', detailCache, movieId, c.getMovieJsonLd);
+protectedRouter.get('/movies/:id/short-link', movieId, c.getMovieShortLink);
+protectedRouter.get('/movies/:id/qr.png', movieId, c.getMovieQrCode);
+protectedRouter.get('/export/movies', c.exportMovies);
+protectedRouter.get('/datasets', c.listDatasets);
+protectedRouter.get('/datasets/:file', c.downloadDataset);
+protectedRouter.get('/studios/:id/movies', searchCache, c.getMoviesByStudioId);
+protectedRouter.get('/studios/name/:name/movies', searchCache, c.getMoviesByStudio);
+protectedRouter.get('/directors/:id/movies', searchCache, c.getMoviesByDirectorId);
+protectedRouter.get('/directors/name/:name/movies', searchCache, c.getMoviesByDirector);
+protectedRouter.get('/actors/:id/movies', searchCache, c.getMoviesByActorId);
+protectedRouter.get('/actors/name/:name/movies', searchCache, c.getMoviesByActor);
+protectedRouter.get('/collections/:id/movies', searchCache, c.getMoviesByCollectionId);
+protectedRouter.get('/collections/name/:name/movies', searchCache, c.getMoviesByCollection);
 
 // POST routes - Add movies
-protectedRouter.post('/movies', c.addMovie);
-protectedRouter.post('/movies/bulk', c.addMoviesBulk);
+protectedRouter.post('/movies', validateRequest({ body: c.movieCreateSchema }), c.stageImports(body => [body]), c.addMovie);
+protectedRouter.post('/movies/bulk', validateRequest({ body: c.bulkImportSchema }), c.stageImports(body => body.movies), c.addMoviesBulk);
 
 // PUT routes - Complete update
-protectedRouter.put('/movies/:id', c.updateMovie);
+protectedRouter.put('/movies/:id', movieId, validateRequest({ body: c.movieUpdateSchema }), c.updateMovie);
 
 // PATCH routes - Partial updates
-protectedRouter.patch('/movies/:id', c.patchMovie);
-protectedRouter.patch('/movies/:id/cast', c.updateCast);
+protectedRouter.patch('/movies/:id', movieId, validateRequest({ body: c.movieUpdateSchema }), c.patchMovie);
+protectedRouter.patch('/movies/:id/cast', movieId, validateRequest({ body: c.castUpdateSchema }), c.updateCast);
+protectedRouter.put('/movies/:id/translations/:lang', movieId, c.upsertMovieTranslation);
 
 // DELETE routes - Delete movie
-protectedRouter.delete('/movies/:id', c.deleteMovieById);
+protectedRouter.delete('/movies/:id', movieId, c.deleteMovieById);
 
 // other get stuff
-protectedRouter.get('/actors', c.getAllActors)
-protectedRouter.get('/actors/:id', c.getActorById)
-protectedRouter.get('/actors/search', c.searchActors)
+protectedRouter.get('/actors', searchCache, c.getAllActors)
+protectedRouter.get('/actors/:id', detailCache, c.getActorById)
+protectedRouter.get('/actors/:id/images', detailCache, c.getActorImages);
+protectedRouter.get('/actors/:id/costars', detailCache, c.getActorCoStars);
+protectedRouter.get('/actors/:id/path-to/:otherId', requireFeature('enable_actor_paths'), detailCache, c.getActorPath);
+protectedRouter.get('/actors/search', searchCache, c.searchActors)
+
+protectedRouter.get('/collections', searchCache, c.getAllCollections)
+protectedRouter.get('/collections/:id', detailCache, c.getCollectionById)
+protectedRouter.get('/collections/search', searchCache, c.searchCollections)
+
+protectedRouter.get('/directors', searchCache, c.getAllDirectors)
+protectedRouter.get('/directors/:id', detailCache, c.getDirectorById)
+protectedRouter.get('/directories/search', searchCache, c.searchDirectors)
+
+protectedRouter.get('/studios', searchCache, c.getAllStudios)
+protectedRouter.get('/studios/countries', statsCache, c.getStudioCountryStats);
+protectedRouter.get('/studios/:id', detailCache, c.getStudioById)
+protectedRouter.get('/studios/search', searchCache, c.searchStudios)
+
+protectedRouter.get('/keywords', searchCache, c.getAllKeywords);
+
+// Statistics
+protectedRouter.get('/stats/box-office', statsCache, c.getBoxOfficeTimeSeries);
+
+// Saved searches for the calling API key
+const savedSearchesFeature = requireFeature('enable_saved_searches');
+protectedRouter.get('/me/searches', savedSearchesFeature, c.getSavedSearches);
+protectedRouter.post('/me/searches', savedSearchesFeature, c.createSavedSearch);
+protectedRouter.get('/me/searches/:id', savedSearchesFeature, c.getSavedSearch);
+protectedRouter.patch('/me/searches/:id', savedSearchesFeature, c.updateSavedSearch);
+protectedRouter.delete('/me/searches/:id', savedSearchesFeature, c.deleteSavedSearch);
+protectedRouter.get('/me/searches/:id/results', savedSearchesFeature, c.getSavedSearchResults);
+
+// Reviews; new and edited ones wait for moderation
+const reviewsFeature = requireFeature('enable_reviews');
+protectedRouter.get('/movies/:id/reviews', reviewsFeature, searchCache, movieId, c.getMovieReviews);
+protectedRouter.post('/movies/:id/reviews', reviewsFeature, requireUser, movieId, c.submitReview);
+protectedRouter.get('/reviews/mine', reviewsFeature, requireUser, c.getMyReviews);
+protectedRouter.delete('/reviews/:reviewId', reviewsFeature, requireUser, c.deleteMyReview);
+protectedRouter.put('/reviews/:reviewId/reaction', reviewsFeature, requireUser, c.setReviewReaction);
+protectedRouter.delete('/reviews/:reviewId/reaction', reviewsFeature, requireUser, c.removeReviewReaction);
+
+// The signed-in user's own data
+protectedRouter.get('/me/data', requireUser, c.exportMyData);
+protectedRouter.delete('/me', requireUser, c.deleteMyAccount);
+
+// Signed-in users: watchlist, following and the activity feed
+protectedRouter.get('/me/watchlist', requireUser, c.getWatchlist);
+protectedRouter.put('/me/watchlist/:id', requireUser, movieId, c.addToWatchlist);
+protectedRouter.delete('/me/watchlist/:id', requireUser, movieId, c.removeFromWatchlist);
+protectedRouter.get('/me/following', requireUser, c.getFollowing);
+protectedRouter.get('/me/followers', requireUser, c.getFollowers);
+protectedRouter.get('/me/feed', requireUser, c.getFeed);
+protectedRouter.put('/users/:id/follow', requireUser, c.followUser);
+protectedRouter.delete('/users/:id/follow', requireUser, c.unfollowUser);
+
+// Signed-in users: custom movie lists
+protectedRouter.get('/me/lists', requireUser, c.getMyLists);
+protectedRouter.post('/me/lists', requireUser, c.createList);
+protectedRouter.get('/me/lists/:listId', requireUser, c.getMyList);
+protectedRouter.patch('/me/lists/:listId', requireUser, c.updateList);
+protectedRouter.delete('/me/lists/:listId', requireUser, c.deleteList);
+protectedRouter.put('/me/lists/:listId/order', requireUser, c.reorderList);
+protectedRouter.put('/me/lists/:listId/movies/:id', requireUser, movieId, c.putListItem);
+protectedRouter.delete('/me/lists/:listId/movies/:id', requireUser, movieId, c.deleteListItem);
+
+// Signed-in users: favorite genres and the new-releases email digest
+protectedRouter.get('/me/genres', requireUser, c.getFavoriteGenres);
+protectedRouter.put('/me/genres', requireUser, c.setFavoriteGenres);
+protectedRouter.get('/me/digest', requireUser, c.getDigestSubscription);
+protectedRouter.put('/me/digest', requireUser, c.subscribeToDigest);
+protectedRouter.delete('/me/digest', requireUser, c.unsubscribeFromDigest);
 
-protectedRouter.get('/collections', c.getAllCollections)
-protectedRouter.get('/collections/:id', c.getCollectionById)
-protectedRouter.get('/collections/search', c.searchCollections)
+// Signed-in users: devices receiving watchlist push notifications
+protectedRouter.get('/me/devices', requireUser, c.getMyDevices);
+protectedRouter.post('/me/devices', requireUser, c.registerDevice);
+protectedRouter.delete('/me/devices/:deviceId', requireUser, c.unregisterDevice);
 
-protectedRouter.get('/directors', c.getAllDirectors)
-protectedRouter.get('/directors/:id', c.getDirectorById)
-protectedRouter.get('/directories/search', c.searchDirectors)
+// Signed-in users: personal calendar feed of watchlisted releases
+protectedRouter.get('/me/calendar', requireUser, c.getMyCalendarFeed);
+protectedRouter.post('/me/calendar', requireUser, c.createMyCalendarFeed);
+protectedRouter.delete('/me/calendar', requireUser, c.deleteMyCalendarFeed);
 
-protectedRouter.get('/studios', c.getAllStudios)
-protectedRouter.get('/studios/:id', c.getStudioById)
-protectedRouter.get('/studios/search', c.searchStudios)
+// Admin routes
+protectedRouter.post('/admin/people/:id/merge-into/:targetId', requireAdmin, c.mergePerson);
+protectedRouter.post('/admin/movies/duplicates/scan', requireAdmin, c.scanDuplicateMovies);
+protectedRouter.get('/admin/movies/duplicates', requireAdmin, c.getDuplicateFlags);
+protectedRouter.patch('/admin/movies/duplicates/:flagId', requireAdmin, c.updateDuplicateFlag);
+protectedRouter.post('/admin/movies/duplicates/:flagId/merge', requireAdmin, c.mergeDuplicateFlag);
+protectedRouter.post('/admin/movies/:id/flag-duplicate/:otherId', requireAdmin, resolveMovieIds('id', 'otherId'), c.flagDuplicateMovie);
+protectedRouter.post('/admin/movies/:id/merge-into/:targetId', requireAdmin, resolveMovieIds('id', 'targetId'), c.mergeMovieInto);
+protectedRouter.post('/admin/movies/bulk-delete', requireAdmin, c.bulkDeleteMovies);
+protectedRouter.post('/admin/movies/bulk-update', requireAdmin, c.bulkUpdateMovies);
+protectedRouter.get('/admin/movies/:id/lineage', requireAdmin, resolveMovieIds(), c.getMovieLineage);
+protectedRouter.post('/admin/movies/:id/undo', requireAdmin, resolveMovieIds(), c.undoMovieChange);
+protectedRouter.post('/admin/stats/box-office/refresh', requireAdmin, c.refreshBoxOfficeAggregates);
+protectedRouter.get('/admin/jobs', requireAdmin, c.getJobList);
+protectedRouter.post('/admin/jobs/:name/run', requireAdmin, c.runJobNow);
+protectedRouter.get('/admin/metrics/latency', requireAdmin, c.getEndpointLatency);
+protectedRouter.delete('/admin/metrics/latency', requireAdmin, c.resetEndpointLatency);
+protectedRouter.get('/admin/features', requireAdmin, c.getFeatureFlagList);
+protectedRouter.put('/admin/features/:flag', requireAdmin, c.setFeatureFlag);
+protectedRouter.delete('/admin/features/:flag', requireAdmin, c.resetFeatureFlag);
+protectedRouter.get('/admin/search-ranking', requireAdmin, c.getSearchRanking);
+protectedRouter.patch('/admin/search-ranking', requireAdmin, c.updateSearchRanking);
+protectedRouter.delete('/admin/search-ranking/:setting', requireAdmin, c.resetSearchRankingSetting);
+protectedRouter.get('/admin/users/locked', requireAdmin, c.getLockedUsers);
+protectedRouter.post('/admin/users/:id/unlock', requireAdmin, c.unlockUserAccount);
+protectedRouter.post('/admin/users/:id/2fa/reset', requireAdmin, c.resetUserTwoFactor);
+protectedRouter.get('/admin/reviews', requireAdmin, reviewsFeature, c.getModerationQueue);
+protectedRouter.post('/admin/reviews/:reviewId/approve', requireAdmin, reviewsFeature, c.approveReview);
+protectedRouter.post('/admin/reviews/:reviewId/reject', requireAdmin, reviewsFeature, c.rejectReview);
+protectedRouter.get('/admin/imports', requireAdmin, c.listImportBatches);
+protectedRouter.get('/admin/imports/:batchId', requireAdmin, c.getImportBatch);
+protectedRouter.get('/admin/imports/:batchId/profile', requireAdmin, c.getImportBatchProfile);
+protectedRouter.post('/admin/imports/:batchId/approve', requireAdmin, c.approveImportBatch);
+protectedRouter.post('/admin/imports/:batchId/reject', requireAdmin, c.rejectImportBatch);
 
 export default publicRouter;
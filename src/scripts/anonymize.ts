@@ -0,0 +1,233 @@
+// server/src/scripts/anonymize.ts
+//
+// Scrub user data from a database copy so its dump can be shared.
+//
+//   DB_URL=postgres://.../scratch npm run anonymize -- --yes
+//
+// Intended workflow: restore a dump into a scratch database, run this against
+// it, then pg_dump the result. The movie catalog is left untouched. Account
+// data is hashed (so rows still join up and counts stay meaningful) or
+// stripped; every API key is invalidated. Hashes use a random salt per run, so
+// the same email does not produce the same hash in two shared dumps.
+//
+// Runs in one transaction: either every step applies or none does. Tables
+// that don't exist in the target schema are skipped.
+
+import crypto from 'crypto';
+import pool from '@utils/database';
+
+interface ScrubStep {
+  table: string;
+  description: string;
+  /** May use $1, the per-run salt */
+  sql: string;
+}
+
+/**
+ * Salted SHA-256 of a text expression, as hex
+ */
+const hashSql = (expression: string): string =>
+  `ENCODE(SHA256(CONVERT_TO($1 || ${expression}, 'UTF8')), 'hex')`;
+
+/**
+ * Every table holding user-generated data. Add a step here with any new one.
+ */
+const SCRUB_STEPS: ScrubStep[] = [
+  {
+    table: 'api_keys',
+    description: 'hash emails, replace names, invalidate keys',
+    sql: `
+      UPDATE api_keys SET
+        api_key = ${hashSql('api_key')},
+        name = 'user-' || api_key_id,
+        email = CASE WHEN email IS NULL THEN NULL
+                ELSE 'user-' || LEFT(${hashSql('LOWER(email)')}, 12) || '@example.invalid' END,
+        last_used_at = NULL
+    `
+  },
+  {
+    table: 'users',
+    description: 'hash emails, replace usernames',
+    sql: `
+      UPDATE users SET
+        email = 'user-' || LEFT(${hashSql('LOWER(email)')}, 12) || '@example.invalid',
+        username = 'user-' || user_id
+    `
+  },
+  {
+    table: 'password_login',
+    description: 'remove password hashes',
+    sql: 'DELETE FROM password_login'
+  },
+  {
+    table: 'refresh_tokens',
+    description: 'remove refresh tokens',
+    sql: 'DELETE FROM refresh_tokens'
+  },
+  {
+    table: 'user_sessions',
+    description: 'remove cookie sessions',
+    sql: 'DELETE FROM user_sessions'
+  },
+  {
+    table: 'users',
+    description: 'remove 2FA secrets',
+    sql: 'UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_step = NULL'
+  },
+  {
+    table: 'user_recovery_codes',
+    description: 'remove 2FA recovery codes',
+    sql: 'DELETE FROM user_recovery_codes'
+  },
+  {
+    table: 'user_identities',
+    description: 'scrub linked sign-in emails',
+    sql: 'UPDATE user_identities SET email = NULL'
+  },
+  {
+    table: 'oauth_states',
+    description: 'remove pending sign-ins',
+    sql: 'DELETE FROM oauth_states'
+  },
+  {
+    table: 'login_attempts',
+    description: 'remove login history',
+    sql: 'DELETE FROM login_attempts'
+  },
+  {
+    table: 'api_key_usage',
+    description: 'strip IP addresses and user agents',
+    sql: 'UPDATE api_key_usage SET ip_address = NULL, user_agent = NULL'
+  },
+  {
+    table: 'saved_searches',
+    description: 'replace search names',
+    sql: `UPDATE saved_searches SET name = 'Saved search ' || search_id`
+  },
+  {
+    table: 'reviews',
+    description: 'remove review text and moderation notes',
+    sql: 'UPDATE reviews SET body = NULL, moderation_note = NULL, flagged_terms = DEFAULT'
+  },
+  {
+    table: 'review_reactions',
+    description: 'remove who reacted to which review (helpful counts are kept)',
+    sql: 'DELETE FROM review_reactions'
+  },
+  {
+    table: 'user_follows',
+    description: 'remove who follows whom',
+    sql: 'DELETE FROM user_follows'
+  },
+  {
+    table: 'watchlist',
+    description: 'remove watchlists',
+    sql: 'DELETE FROM watchlist'
+  },
+  {
+    table: 'activities',
+    description: 'remove activity feed entries',
+    sql: 'DELETE FROM activities'
+  },
+  {
+    table: 'movie_lists',
+    description: 'replace list names, drop descriptions, reset share slugs',
+    sql: `UPDATE movie_lists SET name = 'List ' || list_id, description = NULL, slug = 'list-' || list_id`
+  },
+  {
+    table: 'movie_list_items',
+    description: 'remove notes on listed movies',
+    sql: 'UPDATE movie_list_items SET note = NULL'
+  },
+  {
+    table: 'device_tokens',
+    description: 'remove push notification device tokens',
+    sql: 'DELETE FROM device_tokens'
+  },
+  {
+    table: 'calendar_feeds',
+    description: 'replace calendar feed tokens',
+    sql: `UPDATE calendar_feeds SET token = ${hashSql('token')}`
+  },
+  {
+    table: 'digest_subscriptions',
+    description: 'replace digest unsubscribe tokens',
+    sql: `UPDATE digest_subscriptions SET unsubscribe_token = ${hashSql('unsubscribe_token')}`
+  },
+  {
+    table: 'audit_log',
+    description: 'strip details of API key actions',
+    sql: `UPDATE audit_log SET details = NULL WHERE entity_type = 'api_key'`
+  },
+  {
+    table: 'movie_deletion_log',
+    description: 'strip who deleted each movie',
+    sql: 'UPDATE movie_deletion_log SET deleted_by = NULL'
+  }
+];
+
+/**
+ * Host and database name of DB_URL, for the confirmation prompt
+ */
+const describeTarget = (): string => {
+  try {
+    const url = new URL(process.env.DB_URL ?? '');
+    return `${url.hostname}${url.pathname}`;
+  } catch {
+    return '(DB_URL not set)';
+  }
+};
+
+const main = async (): Promise<void> => {
+  const args = process.argv.slice(2);
+  const unknown = args.filter(arg => arg !== '--yes');
+  if (unknown.length > 0) {
+    throw new Error(`Unknown argument: ${unknown[0]}`);
+  }
+
+  if (process.env.NODE_ENV === 'production') {
+    throw new Error('Refusing to anonymize with NODE_ENV=production; point DB_URL at a copy');
+  }
+  if (!args.includes('--yes')) {
+    throw new Error(
+      `This permanently rewrites user data in ${describeTarget()}.\n` +
+      'Run it against a copy, then confirm with: npm run anonymize -- --yes'
+    );
+  }
+
+  const salt = crypto.randomBytes(16).toString('hex');
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    for (const step of SCRUB_STEPS) {
+      const exists = await client.query<{ found: boolean }>(
+        'SELECT TO_REGCLASS($1) IS NOT NULL AS found',
+        [step.table]
+      );
+      if (!exists.rows[0].found) {
+        console.log(`  ${step.table}: skipped (no such table)`);
+        continue;
+      }
+
+      const result = await client.query(step.sql, step.sql.includes('$1') ? [salt] : []);
+      console.log(`  ${step.table}: ${step.description} (${result.rowCount} rows)`);
+    }
+
+    await client.query('COMMIT');
+    console.log(`Done: ${describeTarget()} is safe to dump`);
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+main()
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(() => pool.end());
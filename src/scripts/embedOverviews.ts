@@ -0,0 +1,37 @@
+// server/src/scripts/embedOverviews.ts
+//
+// Compute embedding vectors of movie overviews for GET /api/search/semantic.
+//
+//   EMBEDDINGS=openai npm run embed-overviews [-- --all]
+//
+// Only overviews without a vector for the current model, or edited since, are
+// sent to the provider; --all re-embeds everything. The nightly embeddings job
+// does the same for new movies, so run this once after migration 040 and after
+// changing EMBEDDING_MODEL.
+
+import pool from '@utils/database';
+import { embedMovieOverviews } from '@utils/embeddings';
+import { flushSpans, withSpan } from '@utils/tracing';
+
+const main = async (): Promise<void> => {
+  const args = process.argv.slice(2);
+  const unknown = args.filter(arg => arg !== '--all');
+  if (unknown.length > 0) {
+    throw new Error(`Unknown argument: ${unknown[0]}`);
+  }
+  if (!process.env.EMBEDDINGS) {
+    throw new Error('Set EMBEDDINGS (openai or ollama) to pick an embedding provider');
+  }
+
+  console.log(`  ${await embedMovieOverviews(args.includes('--all'))}`);
+};
+
+withSpan('embed-overviews', {}, main)
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(async () => {
+    await flushSpans();
+    await pool.end();
+  });
@@ -0,0 +1,247 @@
+// server/src/scripts/enrichMetadata.ts
+//
+// Refresh selected fields of some movies from metadata providers (TMDB, OMDb).
+//
+//   npm run enrich-metadata -- --fields overview,runtime_minutes [--where "<condition>"] [--providers tmdb,omdb] [--dry-run]
+//
+// --fields lists the columns to refresh (original_title, overview,
+// runtime_minutes, budget, revenue, vote_count, vote_average, poster_url,
+// backdrop_url, trailer_url); other columns are left alone. --where picks the
+// movies, as conditions joined with AND, e.g.
+//
+//   --where "release_date > 2020-01-01 AND overview IS NULL"
+//
+// Each condition is <column> <op> <value> (op: = != < <= > >=) or
+// <column> IS [NOT] NULL. Values no provider knows never blank a field.
+// --providers overrides ENRICHERS (the providers to ask, in order; see
+// utils/enrichers). --dry-run prints the changes without writing them. Writes
+// are recorded as an 'enrich' import run (see GET /api/admin/movies/:id/lineage).
+//
+// Needs TMDB_API_KEY and/or OMDB_API_KEY. Movies are spaced out to stay under
+// the providers' rate limits.
+
+import pool from '@utils/database';
+import { refreshBoxOfficeStats } from '@utils/boxOfficeStats';
+import { enrichMovies } from '@utils/inflation';
+import { recordMovieLineage, snapshotMovie, startImportRun } from '@utils/lineage';
+import { SqlFilter } from '@utils/sqlFilter';
+import { Enricher, getEnricherChain, lookupMetadata, METADATA_FIELDS, MetadataField } from '@utils/enrichers';
+import { flushSpans, withSpan } from '@utils/tracing';
+
+const USAGE = 'Usage: npm run enrich-metadata -- --fields <field,...> [--where "<condition>"] [--providers <name,...>] [--dry-run]';
+
+const REQUEST_DELAY_MS = 250;
+
+/**
+ * Columns --where may test
+ */
+const WHERE_COLUMNS = [
+  'movie_id', 'title', 'original_title', 'release_date', 'runtime_minutes',
+  'overview', 'budget', 'revenue', 'mpa_rating', 'vote_count', 'vote_average',
+  'poster_url', 'backdrop_url', 'trailer_url', 'adult', 'imdb_id', 'updated_at'
+];
+
+const COMPARISON = /^(\w+)\s*(=|!=|<>|<=|>=|<|>)\s*(.+)$/;
+const NULL_TEST = /^(\w+)\s+IS\s+(NOT\s+)?NULL$/i;
+
+interface EnrichOptions {
+  fields: MetadataField[];
+  where: SqlFilter;
+  chain: Enricher[];
+  dryRun: boolean;
+}
+
+/**
+ * Turns --where into parameterized conditions; columns come from WHERE_COLUMNS
+ */
+const parseWhere = (where: string): SqlFilter => {
+  const filter = new SqlFilter();
+
+  for (const clause of where.split(/\s+AND\s+/i).map(part => part.trim()).filter(Boolean)) {
+    const nullTest = clause.match(NULL_TEST);
+    const comparison = nullTest ? null : clause.match(COMPARISON);
+    const column = (nullTest ?? comparison)?.[1];
+
+    if (!column || !WHERE_COLUMNS.includes(column)) {
+      throw new Error(`Can't filter on "${clause}"; columns: ${WHERE_COLUMNS.join(', ')}`);
+    }
+
+    if (nullTest) {
+      filter.addRaw(`m.${column} IS ${nullTest[2] ? 'NOT ' : ''}NULL`);
+    } else if (comparison) {
+      const value = comparison[3].trim().replace(/^'(.*)'$|^"(.*)"$/, '$1$2');
+      filter.add(p => `m.${column} ${comparison[2]} ${p}`, value);
+    }
+  }
+
+  return filter;
+};
+
+const parseArgs = (argv: string[]): EnrichOptions => {
+  let fields: string[] = [];
+  let where = '';
+  let providers: string[] | undefined;
+  let dryRun = false;
+
+  for (let i = 0; i < argv.length; i++) {
+    switch (argv[i]) {
+      case '--fields':
+        fields = (argv[++i] ?? '').split(',').map(field => field.trim()).filter(Boolean);
+        break;
+      case '--where':
+        where = argv[++i] ?? '';
+        break;
+      case '--providers':
+        providers = (argv[++i] ?? '').split(',').map(name => name.trim()).filter(Boolean);
+        break;
+      case '--dry-run':
+        dryRun = true;
+        break;
+      default:
+        throw new Error(`Unknown argument: ${argv[i]}\n${USAGE}`);
+    }
+  }
+
+  if (fields.length === 0) {
+    throw new Error(USAGE);
+  }
+  const unknown = fields.find(field => !(METADATA_FIELDS as readonly string[]).includes(field));
+  if (unknown) {
+    throw new Error(`Unknown field "${unknown}"; can refresh: ${METADATA_FIELDS.join(', ')}`);
+  }
+
+  const chain = getEnricherChain(providers);
+  if (chain.length === 0) {
+    throw new Error('No metadata provider is configured; set TMDB_API_KEY or OMDB_API_KEY');
+  }
+
+  return { fields: fields as MetadataField[], where: parseWhere(where), chain, dryRun };
+};
+
+const sleep = (ms: number) => new Promise(resolve => setTimeout(resolve, ms));
+
+/**
+ * Writes the changed fields of one movie in its own transaction
+ */
+const applyChanges = async (
+  runId: number,
+  movieId: number,
+  changes: Partial<Record<MetadataField, string | number | null>>
+): Promise<void> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const before = await snapshotMovie(client, movieId);
+    const fields = Object.keys(changes) as MetadataField[];
+    const assignments = fields.map((field, i) => `${field} = $${i + 2}`);
+
+    await client.query(
+      `UPDATE movies
+       SET ${assignments.join(', ')}, version = version + 1, updated_at = NOW()
+       WHERE movie_id = $1`,
+      [movieId, ...fields.map(field => changes[field])]
+    );
+    if (fields.includes('budget') || fields.includes('revenue')) {
+      await enrichMovies(client, [movieId]);
+    }
+    await recordMovieLineage(client, runId, movieId, before);
+
+    await client.query('COMMIT');
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+const main = async (): Promise<void> => {
+  const options = parseArgs(process.argv.slice(2));
+  const providerNames = options.chain.map(enricher => enricher.name).join(',');
+
+  const movies = await pool.query(
+    `SELECT m.movie_id, m.title, EXTRACT(YEAR FROM m.release_date)::int AS year, ${options.fields.map(field => `m.${field}`).join(', ')}
+     FROM movies m
+     ${options.where.whereSql}
+     ORDER BY m.movie_id`,
+    options.where.params
+  );
+
+  console.log(
+    `${movies.rows.length} movies to refresh (${options.fields.join(', ')}) from ${providerNames}` +
+    `${options.dryRun ? ', dry run' : ''}`
+  );
+
+  const runId = options.dryRun ? null : await startImportRun(pool, 'enrich', { source: providerNames });
+  const counts = { updated: 0, unchanged: 0, not_found: 0, failed: 0 };
+  let moneyChanged = false;
+
+  for (const movie of movies.rows) {
+    try {
+      const result = await withSpan('enrich.movie', {
+        attributes: { 'movie.id': movie.movie_id, 'movie.title': movie.title }
+      }, () => lookupMetadata(options.chain, movie.title, movie.year, options.fields));
+
+      if (!result) {
+        counts.not_found++;
+        console.log(`  not found: "${movie.title}" (${movie.year ?? 'no year'})`);
+        continue;
+      }
+
+      // pg returns BIGINT and NUMERIC as strings, so numbers are compared as numbers
+      const changes: Partial<Record<MetadataField, string | number | null>> = {};
+      for (const field of options.fields) {
+        const value = result.metadata[field];
+        const current = movie[field];
+        const changed = typeof value === 'number'
+          ? current === null || Number(current) !== value
+          : value != null && value !== current;
+        if (changed) {
+          changes[field] = value;
+        }
+      }
+
+      if (Object.keys(changes).length === 0) {
+        counts.unchanged++;
+        continue;
+      }
+
+      const summary = (Object.keys(changes) as MetadataField[]).map(field => `${field} (${result.sources[field]})`);
+      console.log(`  ${movie.movie_id} "${movie.title}": ${summary.join(', ')}`);
+      if (runId !== null) {
+        await applyChanges(runId, movie.movie_id, changes);
+      }
+      counts.updated++;
+      moneyChanged ||= 'budget' in changes || 'revenue' in changes;
+    } catch (error) {
+      counts.failed++;
+      console.error(`  failed: "${movie.title}":`, error instanceof Error ? error.message : error);
+    } finally {
+      await sleep(REQUEST_DELAY_MS);
+    }
+  }
+
+  if (moneyChanged && runId !== null) {
+    await refreshBoxOfficeStats();
+  }
+
+  console.log(
+    `Done: ${counts.updated} ${options.dryRun ? 'would change' : 'updated'}, ` +
+    `${counts.unchanged} unchanged, ${counts.not_found} not found, ${counts.failed} failed`
+  );
+  if (counts.failed > 0) {
+    process.exitCode = 1;
+  }
+};
+
+withSpan('enrich-metadata', {}, main)
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(async () => {
+    await flushSpans();
+    await pool.end();
+  });
@@ -0,0 +1,34 @@
+// server/src/scripts/enrichMovies.ts
+//
+// Recompute every movie's inflation-adjusted budget/revenue and era.
+//
+//   npm run enrich-movies
+//
+// Movies are enriched as they are written; run this once after migration 021,
+// and again after adding a year to cpi_annual or changing CPI_BASE_YEAR so the
+// adjusted amounts move to the new base year.
+
+import pool from '@utils/database';
+import { enrichMovies, resolveCpiBaseYear } from '@utils/inflation';
+
+const main = async (): Promise<void> => {
+  const unknown = process.argv.slice(2);
+  if (unknown.length > 0) {
+    throw new Error(`Unknown argument: ${unknown[0]}`);
+  }
+
+  const baseYear = await resolveCpiBaseYear(pool);
+  if (baseYear === null) {
+    throw new Error('cpi_annual is empty; run migration 021 first');
+  }
+
+  const updated = await enrichMovies(pool);
+  console.log(`  ${updated} movies enriched (amounts in ${baseYear} dollars)`);
+};
+
+main()
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(() => pool.end());
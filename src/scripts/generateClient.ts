@@ -0,0 +1,944 @@
+// server/src/scripts/generateClient.ts
+//
+// Generate the typed TypeScript and Go clients in client/ from the OpenAPI spec.
+//
+//   npm run generate-client [-- --check]
+//
+// Writes client/index.ts: an interface per schema in components.schemas and a
+// MovieApiClient class with one method per operation in api-docs/swagger.yaml.
+// Methods are named by operationId, or else by verb and path
+// (GET /api/movies/{id}/similar -> getMoviesByIdSimilar); path parameters are
+// positional, and query, body and header parameters go in a typed options
+// object. client/go/client.go is the same client as Go package movieapi:
+// a struct per schema and a Client method per operation (GetMoviesByIDSimilar),
+// taking the path parameters and a *Params struct for the rest. Run it after
+// every change to the spec and commit the output. --check only compares, and
+// exits 1 when either client is out of date (for CI).
+
+import fs from 'fs';
+import path from 'path';
+import YAML from 'yamljs';
+
+const SPEC_PATH = path.join(__dirname, '../../api-docs/swagger.yaml');
+const OUTPUT_PATH = path.join(__dirname, '../../client/index.ts');
+const GO_OUTPUT_PATH = path.join(__dirname, '../../client/go/client.go');
+
+const HTTP_METHODS = ['get', 'post', 'put', 'patch', 'delete'] as const;
+
+interface Schema {
+  $ref?: string;
+  type?: string;
+  format?: string;
+  description?: string;
+  nullable?: boolean;
+  enum?: (string | number | boolean | null)[];
+  items?: Schema;
+  properties?: Record<string, Schema>;
+  required?: string[];
+  additionalProperties?: boolean | Schema;
+  allOf?: Schema[];
+  oneOf?: Schema[];
+  anyOf?: Schema[];
+}
+
+interface Parameter {
+  $ref?: string;
+  name: string;
+  in: 'path' | 'query' | 'header' | 'cookie';
+  required?: boolean;
+  description?: string;
+  schema?: Schema;
+}
+
+interface MediaTypes {
+  [contentType: string]: { schema?: Schema };
+}
+
+interface ResponseSpec {
+  $ref?: string;
+  description?: string;
+  content?: MediaTypes;
+}
+
+interface Operation {
+  operationId?: string;
+  summary?: string;
+  parameters?: Parameter[];
+  requestBody?: { required?: boolean; content?: MediaTypes };
+  responses: Record<string, ResponseSpec>;
+}
+
+interface Spec {
+  info: { title: string; version: string };
+  paths: Record<string, Partial<Record<(typeof HTTP_METHODS)[number], Operation>> & { parameters?: Parameter[] }>;
+  components: {
+    schemas: Record<string, Schema>;
+    parameters?: Record<string, Parameter>;
+    responses?: Record<string, ResponseSpec>;
+  };
+}
+
+const isJson = (contentType: string): boolean => /[/+]json\b/.test(contentType);
+
+const isIdentifier = (name: string): boolean => /^[A-Za-z_$][A-Za-z0-9_$]*$/.test(name);
+
+const propertyName = (name: string): string => (isIdentifier(name) ? name : JSON.stringify(name));
+
+const pascalCase = (text: string): string =>
+  text
+    .split(/[^A-Za-z0-9]+/)
+    .filter(Boolean)
+    .map(word => word[0].toUpperCase() + word.slice(1))
+    .join('');
+
+const camelCase = (text: string): string => {
+  const pascal = pascalCase(text);
+  return pascal[0].toLowerCase() + pascal.slice(1);
+};
+
+/**
+ * First paragraph of a description, as a one-line doc comment
+ */
+const docComment = (text: string | undefined, indent: string): string => {
+  const line = text?.trim().split(/\n\s*\n/)[0].replace(/\s+/g, ' ').replace(/\*\//g, '*\\/');
+  return line ? `${indent}/** ${line} */\n` : '';
+};
+
+const refName = (ref: string): string => ref.slice(ref.lastIndexOf('/') + 1);
+
+/**
+ * Wraps a union or intersection in parentheses so it can be suffixed with []
+ * or combined
+ */
+const group = (type: string): string => (/[|&]/.test(type) && !/^[{(]/.test(type) ? `(${type})` : type);
+
+/**
+ * TypeScript type of a schema
+ */
+const schemaType = (schema: Schema | undefined, indent = ''): string => {
+  if (!schema) return 'unknown';
+
+  let type: string;
+  if (schema.$ref) {
+    type = refName(schema.$ref);
+  } else if (schema.allOf) {
+    type = schema.allOf.map(part => group(schemaType(part, indent))).join(' & ');
+  } else if (schema.oneOf || schema.anyOf) {
+    type = (schema.oneOf ?? schema.anyOf)!.map(part => group(schemaType(part, indent))).join(' | ');
+  } else if (schema.enum) {
+    type = schema.enum.map(value => JSON.stringify(value)).join(' | ');
+  } else if (schema.type === 'array') {
+    type = `${group(schemaType(schema.items, indent))}[]`;
+  } else if (schema.type === 'object' || schema.properties) {
+    type = objectType(schema, indent);
+  } else {
+    switch (schema.type) {
+      case 'string': type = 'string'; break;
+      case 'integer':
+      case 'number': type = 'number'; break;
+      case 'boolean': type = 'boolean'; break;
+      default: type = 'unknown';
+    }
+  }
+
+  return schema.nullable && type !== 'unknown' ? `${group(type)} | null` : type;
+};
+
+const objectType = (schema: Schema, indent: string): string => {
+  const properties = Object.entries(schema.properties ?? {});
+  const extra = schema.additionalProperties;
+
+  if (properties.length === 0) {
+    if (extra && typeof extra === 'object') return `Record<string, ${schemaType(extra, indent)}>`;
+    return 'Record<string, unknown>';
+  }
+
+  const inner = `${indent}  `;
+  const lines = properties.map(([name, property]) => {
+    const optional = schema.required?.includes(name) ? '' : '?';
+    return `${docComment(property.description, inner)}${inner}${propertyName(name)}${optional}: ${schemaType(property, inner)};\n`;
+  });
+  if (extra) {
+    lines.push(`${inner}[key: string]: ${extra === true ? 'unknown' : `${schemaType(extra, inner)} | undefined`};\n`);
+  }
+  return `{\n${lines.join('')}${indent}}`;
+};
+
+/**
+ * Interfaces (or type aliases) for components.schemas
+ */
+const renderSchemas = (spec: Spec): string =>
+  Object.entries(spec.components.schemas)
+    .map(([name, schema]) => {
+      const doc = docComment(schema.description, '');
+      const type = schemaType(schema);
+      return type.startsWith('{') && !schema.nullable
+        ? `${doc}export interface ${name} ${type}\n`
+        : `${doc}export type ${name} = ${type};\n`;
+    })
+    .join('\n');
+
+// ============================================================================
+// Operations
+// ============================================================================
+
+const resolveParameter = (spec: Spec, parameter: Parameter): Parameter =>
+  parameter.$ref ? spec.components.parameters![refName(parameter.$ref)] : parameter;
+
+const resolveResponse = (spec: Spec, response: ResponseSpec): ResponseSpec =>
+  response.$ref ? spec.components.responses![refName(response.$ref)] : response;
+
+/**
+ * Method name from the verb and path: POST /api/movies/{id}/reviews -> postMoviesByIdReviews
+ */
+const methodName = (method: string, route: string): string =>
+  method + route
+    .replace(/^\/api\//, '')
+    .split('/')
+    .map(segment => segment
+      .replace(/\{(\w+)\}/g, (_, name: string) => ` By ${pascalCase(name)} `)
+      .split(' ')
+      .map(pascalCase)
+      .join(''))
+    .join('');
+
+/**
+ * Type of an options group (query, headers), and whether any member is required
+ */
+const parameterGroup = (parameters: Parameter[], indent: string): { type: string; required: boolean } => {
+  const inner = `${indent}  `;
+  const lines = parameters.map(parameter =>
+    `${docComment(parameter.description, inner)}${inner}${propertyName(parameter.name)}${parameter.required ? '' : '?'}: ` +
+    `${schemaType(parameter.schema, inner)};\n`
+  );
+  return { type: `{\n${lines.join('')}${indent}}`, required: parameters.some(parameter => parameter.required) };
+};
+
+const renderOperation = (spec: Spec, route: string, method: string, operation: Operation, name: string): string => {
+  const parameters = [...(spec.paths[route].parameters ?? []), ...(operation.parameters ?? [])]
+    .map(parameter => resolveParameter(spec, parameter));
+  const pathParameters = parameters.filter(parameter => parameter.in === 'path');
+  const queryParameters = parameters.filter(parameter => parameter.in === 'query');
+  const headerParameters = parameters.filter(parameter => parameter.in === 'header');
+
+  const options: string[] = [];
+  let optionsRequired = false;
+  if (queryParameters.length > 0) {
+    const query = parameterGroup(queryParameters, '    ');
+    options.push(`    query${query.required ? '' : '?'}: ${query.type};\n`);
+    optionsRequired ||= query.required;
+  }
+  const bodyType = operation.requestBody?.content
+    ? Object.entries(operation.requestBody.content).map(([type, media]) => (isJson(type) ? schemaType(media.schema, '    ') : 'string'))[0]
+    : null;
+  if (bodyType) {
+    const required = operation.requestBody?.required ?? false;
+    options.push(`    body${required ? '' : '?'}: ${bodyType};\n`);
+    optionsRequired ||= required;
+  }
+  if (headerParameters.length > 0) {
+    const headers = parameterGroup(headerParameters, '    ');
+    options.push(`    headers${headers.required ? '' : '?'}: ${headers.type};\n`);
+    optionsRequired ||= headers.required;
+  }
+
+  // JSON responses are parsed; anything else (images, CSV, XML) is returned as the raw Response
+  const successes = Object.entries(operation.responses)
+    .filter(([code]) => /^2/.test(code))
+    .map(([, response]) => resolveResponse(spec, response));
+  const contents = successes.flatMap(response => Object.entries(response.content ?? {}));
+  const raw = contents.length > 0 && !contents.some(([type]) => isJson(type));
+  const resultTypes = [...new Set(contents.filter(([type]) => isJson(type)).map(([, media]) => schemaType(media.schema, '  ')))];
+  const resultType = raw ? 'Response' : resultTypes.length > 0 ? resultTypes.join(' | ') : 'void';
+
+  const signature = [
+    ...pathParameters.map(parameter => `${camelCase(parameter.name)}: string | number`),
+    ...(options.length > 0 ? [`options${optionsRequired ? '' : '?'}: {\n${options.join('')}  }`] : [])
+  ];
+  const url = route.replace(/\{(\w+)\}/g, (_, parameter: string) =>
+    `\${encodeURIComponent(String(${camelCase(parameter)}))}`);
+  const requestOptions = [
+    queryParameters.length > 0 ? 'query: options?.query' : null,
+    bodyType ? 'body: options?.body' : null,
+    headerParameters.length > 0 ? 'headers: options?.headers' : null,
+    raw ? 'raw: true' : null
+  ].filter(Boolean);
+
+  return [
+    `  /**\n   * ${method.toUpperCase()} ${route}${operation.summary ? `\n   * ${operation.summary.replace(/\*\//g, '*\\/')}` : ''}\n   */\n`,
+    `  ${name}(${signature.join(', ')}): Promise<${resultType}> {\n`,
+    `    return this.request('${method.toUpperCase()}', \`${url}\`${requestOptions.length > 0 ? `, { ${requestOptions.join(', ')} }` : ''});\n`,
+    '  }\n'
+  ].join('');
+};
+
+const renderOperations = (spec: Spec): string => {
+  const names = new Map<string, number>();
+  const methods: string[] = [];
+
+  for (const [route, item] of Object.entries(spec.paths)) {
+    for (const method of HTTP_METHODS) {
+      const operation = item[method];
+      if (!operation) continue;
+
+      let name = operation.operationId ? camelCase(operation.operationId) : methodName(method, route);
+      const seen = names.get(name) ?? 0;
+      names.set(name, seen + 1);
+      if (seen > 0) name += seen + 1;
+
+      methods.push(renderOperation(spec, route, method, operation, name));
+    }
+  }
+  return methods.join('\n');
+};
+
+// ============================================================================
+// Client
+// ============================================================================
+
+const CLIENT_CLASS = `export interface ClientOptions {
+  /** Server root, e.g. http://localhost:4000 (paths already start with /api) */
+  baseUrl: string;
+  /** Sent as X-API-Key */
+  apiKey?: string;
+  /** Access token from /api/auth/login, sent as a bearer token */
+  accessToken?: string;
+  /** Headers sent with every request */
+  headers?: Record<string, string>;
+  /** fetch implementation; the global one by default */
+  fetch?: typeof fetch;
+}
+
+interface RequestOptions {
+  query?: object;
+  body?: unknown;
+  headers?: object;
+  raw?: boolean;
+}
+
+/**
+ * A response outside 2xx; body is the parsed JSON error when there is one
+ */
+export class ApiClientError extends Error {
+  constructor(readonly status: number, readonly body: unknown) {
+    super(\`Request failed with status \${status}\${
+      body && typeof body === 'object' && 'message' in body && typeof body.message === 'string' ? \`: \${body.message}\` : ''
+    }\`);
+    this.name = 'ApiClientError';
+  }
+}
+
+export class MovieApiClient {
+  private readonly options: ClientOptions;
+
+  constructor(options: ClientOptions) {
+    this.options = { ...options };
+  }
+
+  /** Replaces the bearer token, e.g. after a refresh */
+  setAccessToken(accessToken: string | undefined): void {
+    this.options.accessToken = accessToken;
+  }
+
+  private async request<T>(method: string, path: string, options: RequestOptions = {}): Promise<T> {
+    const url = new URL(path, this.options.baseUrl);
+    for (const [name, value] of Object.entries(options.query ?? {})) {
+      if (value === undefined || value === null) continue;
+      for (const item of Array.isArray(value) ? value : [value]) {
+        url.searchParams.append(name, String(item));
+      }
+    }
+
+    const headers: Record<string, string> = { Accept: 'application/json', ...this.options.headers };
+    if (this.options.apiKey) headers['X-API-Key'] = this.options.apiKey;
+    if (this.options.accessToken) headers.Authorization = \`Bearer \${this.options.accessToken}\`;
+    for (const [name, value] of Object.entries(options.headers ?? {})) {
+      if (value !== undefined) headers[name] = String(value);
+    }
+
+    let body: string | undefined;
+    if (options.body !== undefined) {
+      body = typeof options.body === 'string' ? options.body : JSON.stringify(options.body);
+      if (!headers['Content-Type']) headers['Content-Type'] = 'application/json';
+    }
+
+    const response = await (this.options.fetch ?? fetch)(url, { method, headers, body });
+    if (!response.ok) {
+      const text = await response.text();
+      let error: unknown = text;
+      try {
+        error = JSON.parse(text);
+      } catch {
+        // Not JSON; keep the text
+      }
+      throw new ApiClientError(response.status, error);
+    }
+
+    if (options.raw) return response as T;
+    if (response.status === 204) return undefined as T;
+    const text = await response.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+`;
+
+const renderClient = (spec: Spec): string => [
+  `// Generated by npm run generate-client from api-docs/swagger.yaml (${spec.info.title} ${spec.info.version}).\n`,
+  '// Do not edit; change the spec and regenerate.\n',
+  '\n',
+  '/* eslint-disable */\n',
+  '\n',
+  '// ============================================================================\n',
+  '// Schemas\n',
+  '// ============================================================================\n',
+  '\n',
+  renderSchemas(spec),
+  '\n',
+  '// ============================================================================\n',
+  '// Client\n',
+  '// ============================================================================\n',
+  '\n',
+  CLIENT_CLASS,
+  '\n',
+  renderOperations(spec),
+  '}\n'
+].join('');
+
+// ============================================================================
+// Go client
+// ============================================================================
+
+const GO_KEYWORDS = new Set([
+  'break', 'case', 'chan', 'const', 'continue', 'default', 'defer', 'else', 'fallthrough', 'for', 'func',
+  'go', 'goto', 'if', 'import', 'interface', 'map', 'package', 'range', 'return', 'select', 'struct',
+  'switch', 'type', 'var'
+]);
+
+const GO_SCALARS: Record<string, string> = { string: 'string', integer: 'int64', number: 'float64', boolean: 'bool' };
+
+/**
+ * Names the package itself declares
+ */
+const GO_RESERVED = new Set(['Client', 'NewClient', 'APIError']);
+
+/**
+ * Exported Go name, with Go's initialisms: movie_id -> MovieID, api-keys -> APIKeys
+ */
+const goName = (text: string): string => {
+  const name = pascalCase(text).replace(/(Id|Url|Uri|Api|Json|Jwt|Http|Ip|Uuid|Html|Xml|Csv|Sql)(?=[A-Z0-9]|$)/g, word => word.toUpperCase());
+  return /^[0-9]/.test(name) ? `X${name}` : name;
+};
+
+/**
+ * Unexported Go name for a function parameter: targetId -> targetID, id -> id
+ */
+const goParameterName = (text: string): string => {
+  const exported = goName(text);
+  const name = /^[A-Z0-9]+$/.test(exported)
+    ? exported.toLowerCase()
+    : exported.replace(/^[A-Z]+(?=[A-Z][a-z])|^[A-Z]/, prefix => prefix.toLowerCase());
+  return GO_KEYWORDS.has(name) ? `${name}Value` : name;
+};
+
+const goComment = (text: string | undefined, indent: string): string => {
+  const line = text?.trim().split(/\n\s*\n/)[0].replace(/\s+/g, ' ');
+  return line ? `${indent}// ${line}\n` : '';
+};
+
+/**
+ * Whether a Go type is already nil-able, so optional values need no pointer
+ */
+const goNilable = (type: string): boolean => /^(\[\]|map\[|any$|json\.RawMessage$|\*)/.test(type);
+
+interface GoField {
+  doc?: string;
+  name: string;
+  type: string;
+  tag?: string;
+}
+
+/**
+ * Struct body laid out the way gofmt does: names, types and tags line up
+ * within each run of fields not broken by a comment
+ */
+const goStructBody = (embeds: string[], fields: GoField[]): string => {
+  const runs: GoField[][] = [];
+  fields.forEach((field, i) => {
+    if (i === 0 || field.doc) runs.push([]);
+    runs[runs.length - 1].push(field);
+  });
+
+  const lines = embeds.map(embed => `\t${embed}\n`);
+  for (const run of runs) {
+    const nameWidth = Math.max(...run.map(field => field.name.length));
+    const typeWidth = Math.max(...run.map(field => field.type.length));
+    for (const field of run) {
+      const type = field.tag ? `${field.type.padEnd(typeWidth)} ${field.tag}` : field.type;
+      lines.push(`${goComment(field.doc, '\t')}\t${field.name.padEnd(nameWidth)} ${type}\n`);
+    }
+  }
+  return `struct {\n${lines.join('')}}`;
+};
+
+/**
+ * Named types of the Go client, in output order. Inline objects in the spec
+ * become named structs too (ParentField), so callers can build them.
+ */
+class GoTypes {
+  readonly declarations: string[] = [];
+  private readonly names = new Set(GO_RESERVED);
+
+  constructor(readonly spec: Spec) {
+    for (const name of Object.keys(spec.components.schemas)) {
+      this.reserve(goName(name));
+    }
+  }
+
+  /**
+   * Claims a type name, numbering it when it's taken
+   */
+  unique(name: string): string {
+    let candidate = name;
+    for (let n = 2; this.names.has(candidate); n++) candidate = `${name}${n}`;
+    this.names.add(candidate);
+    return candidate;
+  }
+
+  private reserve(name: string): void {
+    if (this.names.has(name)) throw new Error(`Go type name ${name} is used twice`);
+    this.names.add(name);
+  }
+
+  /**
+   * Go type of a schema; name is used if it needs a struct of its own
+   */
+  type(schema: Schema | undefined, name: string): string {
+    if (!schema) return 'any';
+    if (schema.$ref) return goName(refName(schema.$ref));
+    if (schema.allOf) {
+      return schema.allOf.length === 1 && !schema.properties
+        ? this.type(schema.allOf[0], name)
+        : this.declareStruct(this.unique(name), schema);
+    }
+    if (schema.oneOf || schema.anyOf) {
+      const types = new Set((schema.oneOf ?? schema.anyOf)!.map(part => this.type(part, name)));
+      return types.size === 1 ? [...types][0] : 'any';
+    }
+    if (schema.type === 'array') return `[]${this.type(schema.items, `${name}Item`)}`;
+    if (schema.properties && Object.keys(schema.properties).length > 0) {
+      return this.declareStruct(this.unique(name), schema);
+    }
+    if (schema.type === 'object') {
+      const extra = schema.additionalProperties;
+      return `map[string]${extra && typeof extra === 'object' ? this.type(extra, `${name}Value`) : 'any'}`;
+    }
+    return GO_SCALARS[schema.type ?? ''] ?? 'any';
+  }
+
+  /**
+   * Type of a struct field: a pointer when it may be missing or null
+   */
+  fieldType(schema: Schema, required: boolean, name: string): string {
+    const type = this.type(schema, name);
+    return goNilable(type) || (required && !schema.nullable) ? type : `*${type}`;
+  }
+
+  /**
+   * Declares a component schema
+   */
+  declareSchema(name: string, schema: Schema): void {
+    const isStruct = (schema.allOf && (schema.allOf.length > 1 || schema.properties)) ||
+      (schema.properties && Object.keys(schema.properties).length > 0);
+    if (isStruct) {
+      this.declareStruct(name, schema);
+      return;
+    }
+    const index = this.declarations.push('') - 1;
+    this.declarations[index] = `${goComment(schema.description, '')}type ${name} = ${this.type(schema, name)}\n`;
+  }
+
+  /**
+   * Declares a struct with the schema's properties; allOf references are embedded
+   */
+  private declareStruct(name: string, schema: Schema): string {
+    const index = this.declarations.push('') - 1;
+
+    const embeds: string[] = [];
+    const properties: [string, Schema][] = [];
+    const required = new Set<string>();
+    const collect = (part: Schema): void => {
+      if (part.$ref) {
+        embeds.push(goName(refName(part.$ref)));
+        return;
+      }
+      part.allOf?.forEach(collect);
+      properties.push(...Object.entries(part.properties ?? {}));
+      part.required?.forEach(property => required.add(property));
+    };
+    collect(schema);
+
+    const fieldNames = new Set(embeds);
+    const fields = properties.map(([property, propertySchema]): GoField => {
+      let fieldName = goName(property);
+      for (let n = 2; fieldNames.has(fieldName); n++) fieldName = `${goName(property)}${n}`;
+      fieldNames.add(fieldName);
+      const isRequired = required.has(property);
+      return {
+        doc: propertySchema.description,
+        name: fieldName,
+        type: this.fieldType(propertySchema, isRequired, `${name}${fieldName}`),
+        tag: `\`json:"${property}${isRequired ? '' : ',omitempty'}"\``
+      };
+    });
+
+    this.declarations[index] = `${goComment(schema.description, '')}type ${name} ${goStructBody(embeds, fields)}\n`;
+    return name;
+  }
+}
+
+const renderGoOperation = (
+  types: GoTypes,
+  route: string,
+  method: string,
+  operation: Operation,
+  name: string
+): string => {
+  const { spec } = types;
+  const parameters = [...(spec.paths[route].parameters ?? []), ...(operation.parameters ?? [])]
+    .map(parameter => resolveParameter(spec, parameter));
+  const pathParameters = parameters.filter(parameter => parameter.in === 'path');
+  const optionParameters = parameters.filter(parameter => parameter.in === 'query' || parameter.in === 'header');
+
+  // Query and header parameters and the body go in a Params struct
+  const fields: GoField[] = [];
+  const setters: string[] = [];
+  const fieldNames = new Set<string>();
+  const fieldName = (text: string): string => {
+    let candidate = goName(text);
+    for (let n = 2; fieldNames.has(candidate); n++) candidate = `${goName(text)}${n}`;
+    fieldNames.add(candidate);
+    return candidate;
+  };
+
+  for (const parameter of optionParameters) {
+    const field = fieldName(parameter.name);
+    const type = types.type(parameter.schema, `${name}${field}`);
+    const target = parameter.in === 'query' ? 'r.query' : 'r.header';
+    if (parameter.in === 'query' && type.startsWith('[]') && GO_SCALARS[parameter.schema?.items?.type ?? '']) {
+      fields.push({ doc: parameter.description, name: field, type });
+      setters.push(`\t\taddQueryList(${target}, ${JSON.stringify(parameter.name)}, params.${field})\n`);
+    } else {
+      const scalar = Object.values(GO_SCALARS).includes(type) ? type : 'string';
+      fields.push({ doc: parameter.description, name: field, type: `*${scalar}` });
+      setters.push(`\t\t${parameter.in === 'query' ? 'addQuery' : 'addHeader'}(${target}, ${JSON.stringify(parameter.name)}, params.${field})\n`);
+    }
+  }
+
+  const body = operation.requestBody?.content ? Object.entries(operation.requestBody.content)[0] : null;
+  let contentType = '';
+  if (body) {
+    const field = fieldName('body');
+    let type = 'string';
+    if (isJson(body[0])) {
+      type = types.type(body[1].schema, `${name}Body`);
+    } else {
+      contentType = body[0];
+    }
+    fields.push({ doc: operation.requestBody?.required ? 'Request body (required)' : 'Request body', name: field, type: goNilable(type) ? type : `*${type}` });
+    setters.push(`\t\tif params.${field} != nil {\n\t\t\tr.body = params.${field}\n\t\t}\n`);
+  }
+
+  // JSON responses are decoded; anything else is returned as the *http.Response
+  const successes = Object.entries(operation.responses)
+    .filter(([code]) => /^2/.test(code))
+    .map(([, response]) => resolveResponse(spec, response));
+  const contents = successes.flatMap(response => Object.entries(response.content ?? {}));
+  const raw = contents.length > 0 && !contents.some(([type]) => isJson(type));
+  const resultTypes = [...new Set(contents.filter(([type]) => isJson(type)).map(([, media]) => types.type(media.schema, `${name}Response`)))];
+  // A result that may be one of several shapes is left for the caller to decode
+  const resultType = raw ? '*http.Response'
+    : resultTypes.length > 1 || resultTypes[0] === 'any' ? 'json.RawMessage'
+    : resultTypes[0] ?? null;
+
+  const paramsType = fields.length > 0 ? types.unique(`${name}Params`) : null;
+  const signature = [
+    'ctx context.Context',
+    ...pathParameters.map(parameter => {
+      const type = types.type(parameter.schema, `${name}${goName(parameter.name)}`);
+      return `${goParameterName(parameter.name)} ${type === 'int64' || type === 'string' ? type : 'any'}`;
+    }),
+    ...(paramsType ? [`params *${paramsType}`] : [])
+  ];
+  const routePath = route
+    .split(/\{(\w+)\}/)
+    .map((part, i) => (i % 2 === 1 ? `pathValue(${goParameterName(part)})` : part ? JSON.stringify(part) : null))
+    .filter(Boolean)
+    .join(' + ');
+  const request = [
+    `method: "${method.toUpperCase()}"`,
+    `path: ${routePath}`,
+    ...(optionParameters.some(parameter => parameter.in === 'query') ? ['query: url.Values{}'] : []),
+    ...(optionParameters.some(parameter => parameter.in === 'header') ? ['header: http.Header{}'] : []),
+    ...(contentType ? [`contentType: ${JSON.stringify(contentType)}`] : [])
+  ];
+
+  const lines: string[] = [];
+  if (paramsType) {
+    lines.push(
+      `// ${paramsType} holds the query, header and body parameters of ${name}.\n`,
+      `type ${paramsType} ${goStructBody([], fields)}\n`,
+      '\n'
+    );
+  }
+  lines.push(`// ${name} calls ${method.toUpperCase()} ${route}.\n`);
+  if (operation.summary) lines.push('//\n', goComment(operation.summary, ''));
+  lines.push(
+    `func (c *Client) ${name}(${signature.join(', ')}) ${resultType ? `(${goNilable(resultType) ? resultType : `*${resultType}`}, error)` : 'error'} {\n`,
+    `\tr := request{${request.join(', ')}}\n`
+  );
+  if (paramsType) lines.push('\tif params != nil {\n', ...setters, '\t}\n');
+
+  if (raw) {
+    lines.push('\treturn c.send(ctx, r)\n');
+  } else if (!resultType) {
+    lines.push('\treturn c.call(ctx, r, nil)\n');
+  } else {
+    const pointer = !goNilable(resultType);
+    lines.push(
+      `\tvar result ${resultType}\n`,
+      '\tif err := c.call(ctx, r, &result); err != nil {\n',
+      '\t\treturn nil, err\n',
+      '\t}\n',
+      `\treturn ${pointer ? '&result' : 'result'}, nil\n`
+    );
+  }
+  lines.push('}\n');
+  return lines.join('');
+};
+
+const GO_RUNTIME = `// Client calls the API. Routes that need a key or a signed-in user read
+// APIKey and AccessToken.
+type Client struct {
+	// BaseURL is the server root, e.g. http://localhost:4000 (paths already start with /api)
+	BaseURL string
+	// APIKey is sent as X-API-Key
+	APIKey string
+	// AccessToken from /api/auth/login is sent as a bearer token
+	AccessToken string
+	// Header is sent with every request
+	Header http.Header
+	// HTTPClient sends the requests; http.DefaultClient when nil
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for the server at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: baseURL}
+}
+
+// APIError is a response outside 2xx. Code and Message are read from the
+// error body when it is JSON.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("request failed with status %d", e.StatusCode)
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	header      http.Header
+	body        any
+	contentType string
+}
+
+// send makes the request and returns the response when it is a 2xx; the
+// caller closes its body.
+func (c *Client) send(ctx context.Context, r request) (*http.Response, error) {
+	target := strings.TrimSuffix(c.BaseURL, "/") + r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+
+	var body io.Reader
+	if text, ok := r.body.(*string); ok && r.contentType != "" {
+		body = strings.NewReader(*text)
+	} else if r.body != nil {
+		encoded, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	for name, values := range c.Header {
+		req.Header[name] = values
+	}
+	if c.APIKey != "" {
+		req.Header.Set("X-API-Key", c.APIKey)
+	}
+	if c.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.AccessToken)
+	}
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+	if body != nil && req.Header.Get("Content-Type") == "" {
+		contentType := r.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		apiErr := &APIError{StatusCode: resp.StatusCode, Body: data}
+		var parsed struct {
+			Code    string \`json:"code"\`
+			Message any    \`json:"message"\`
+		}
+		if json.Unmarshal(data, &parsed) == nil {
+			apiErr.Code = parsed.Code
+			apiErr.Message, _ = parsed.Message.(string)
+		}
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// call makes the request and decodes the JSON response into result, if given.
+func (c *Client) call(ctx context.Context, r request, result any) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil || result == nil || len(data) == 0 {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func addQuery[T any](query url.Values, name string, value *T) {
+	if value != nil {
+		query.Add(name, fmt.Sprint(*value))
+	}
+}
+
+func addQueryList[T any](query url.Values, name string, values []T) {
+	for _, value := range values {
+		query.Add(name, fmt.Sprint(value))
+	}
+}
+
+func addHeader[T any](header http.Header, name string, value *T) {
+	if value != nil {
+		header.Set(name, fmt.Sprint(*value))
+	}
+}
+
+func pathValue(value any) string {
+	return url.PathEscape(fmt.Sprint(value))
+}
+`;
+
+const renderGoClient = (spec: Spec): string => {
+  const types = new GoTypes(spec);
+  for (const [name, schema] of Object.entries(spec.components.schemas)) {
+    types.declareSchema(goName(name), schema);
+  }
+
+  const names = new Map<string, number>();
+  const operations: string[] = [];
+  for (const [route, item] of Object.entries(spec.paths)) {
+    for (const method of HTTP_METHODS) {
+      const operation = item[method];
+      if (!operation) continue;
+
+      let name = goName(operation.operationId ?? methodName(method, route));
+      const seen = names.get(name) ?? 0;
+      names.set(name, seen + 1);
+      if (seen > 0) name += seen + 1;
+
+      operations.push(renderGoOperation(types, route, method, operation, name));
+    }
+  }
+
+  return [
+    `// Code generated by npm run generate-client from api-docs/swagger.yaml (${spec.info.title} ${spec.info.version}). DO NOT EDIT.\n`,
+    '\n',
+    `// Package movieapi is a typed client for the ${spec.info.title}: a struct per\n`,
+    '// schema and a Client method per documented operation.\n',
+    'package movieapi\n',
+    '\n',
+    'import (\n',
+    ...['bytes', 'context', 'encoding/json', 'fmt', 'io', 'net/http', 'net/url', 'strings'].map(pkg => `\t"${pkg}"\n`),
+    ')\n',
+    '\n',
+    GO_RUNTIME,
+    '\n',
+    types.declarations.join('\n'),
+    '\n',
+    operations.join('\n')
+  ].join('');
+};
+
+const main = (): void => {
+  const check = process.argv.includes('--check');
+  const spec = YAML.load(SPEC_PATH) as Spec;
+  const outputs: [string, string][] = [
+    [OUTPUT_PATH, renderClient(spec)],
+    [GO_OUTPUT_PATH, renderGoClient(spec)]
+  ];
+
+  if (check) {
+    for (const [file, output] of outputs) {
+      const current = fs.existsSync(file) ? fs.readFileSync(file, 'utf8') : '';
+      if (current !== output) {
+        console.error(`${path.relative(process.cwd(), file)} is out of date; run npm run generate-client`);
+        process.exitCode = 1;
+      }
+    }
+    if (!process.exitCode) console.log('Clients are up to date');
+    return;
+  }
+
+  const operations = Object.values(spec.paths)
+    .reduce((count, item) => count + HTTP_METHODS.filter(method => item[method]).length, 0);
+  for (const [file, output] of outputs) {
+    fs.writeFileSync(file, output);
+    console.log(`Wrote ${path.relative(process.cwd(), file)}: ${Object.keys(spec.components.schemas).length} schemas, ${operations} operations`);
+  }
+};
+
+try {
+  main();
+} catch (error) {
+  console.error(error instanceof Error ? error.message : error);
+  process.exitCode = 1;
+}
@@ -0,0 +1,29 @@
+// server/src/scripts/generatePlaceholders.ts
+//
+// Generate placeholder posters for every movie without a poster_url.
+//
+//   npm run generate-placeholders
+//
+// Placeholders are generated as movies are written; run this once after
+// migration 032 so movies already in the catalog get one too. Re-running only
+// redraws placeholders whose title or year changed.
+
+import pool from '@utils/database';
+import { generatePlaceholderPosters } from '@utils/placeholderPosters';
+
+const main = async (): Promise<void> => {
+  const unknown = process.argv.slice(2);
+  if (unknown.length > 0) {
+    throw new Error(`Unknown argument: ${unknown[0]}`);
+  }
+
+  const generated = await generatePlaceholderPosters(pool);
+  console.log(`  ${generated} placeholder posters generated`);
+};
+
+main()
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(() => pool.end());
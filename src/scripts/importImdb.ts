@@ -0,0 +1,692 @@
+// server/src/scripts/importImdb.ts
+//
+// Seed the catalog from the official IMDb dataset dumps
+// (https://datasets.imdbws.com/).
+//
+//   npm run import-imdb -- --dir ./imdb [--min-votes 1000] [--limit 5000] [--include-adult] [--dry-run]
+//   npm run import-imdb -- --dir ./imdb-new --since ./imdb-old [--dry-run]
+//   npm run import-imdb -- --dir './imdb/19*' [--min-votes 1000] [--dry-run]
+//
+// Reads title.basics, title.crew, title.principals, name.basics and
+// title.ratings from the directory (.tsv or .tsv.gz, as downloaded), keeps
+// feature films with at least --min-votes votes (0 takes every film and makes
+// title.ratings optional) and joins them into movies with their genres,
+// directors, writers, producers, composers, cinematographers, editors and
+// top-billed cast. --limit keeps the most-voted.
+//
+// IMDb has only a release year, so release_date is January 1 of it, and no
+// plots, posters or certificates; fill in the first two afterwards with
+//
+//   npm run enrich-metadata -- --fields overview,poster_url --where "imdb_id IS NOT NULL"
+//
+// Imported movies keep their tconst in movies.imdb_id (migration 044): a
+// re-run skips them, and a movie already in the catalog with the same title
+// and year is linked instead of duplicated. Needs no network access.
+//
+// --since names the directory of the dumps the last import read. Only titles
+// that are new or have a changed row there are applied: new ones are
+// imported as above, and movies already imported get just the fields whose
+// IMDb value changed, so local edits to the rest are kept. Titles dropped
+// from the newer dumps are left alone.
+//
+// A dataset shipped in partitions (say one set of dumps per decade) is a
+// directory with a manifest.json, {"partitions": ["1980s", "1990s"]}, naming
+// a subdirectory of dumps per partition in the order to import them. --dir
+// can also be a pattern with * in its last part, matching several partition
+// directories; they go in their parent's manifest order, or by name when it
+// has none. Each partition is imported in its own transaction, so one that
+// fails part way leaves nothing behind and the others still go in; a title
+// that fails is skipped without failing its partition. --since then names
+// the previous dataset's directory and each partition is compared with the
+// one of the same name there.
+
+import fs from 'fs';
+import path from 'path';
+import readline from 'readline';
+import zlib from 'zlib';
+import { PoolClient } from 'pg';
+import pool from '@utils/database';
+import { refreshBoxOfficeStats } from '@utils/boxOfficeStats';
+import { recordMovieLineage, snapshotMovie, startImportRun } from '@utils/lineage';
+import { CREW_ROLE_NAMES, MovieCrew, replaceMovieCrew } from '@utils/movieCrew';
+import { uniqueNames } from '@utils/nameMatching';
+import { CastMember, MovieCreateInput } from '@models/movieModel';
+import {
+  getOrCreateDirectorId,
+  getOrCreateGenreId,
+  getOrCreateProducerId,
+  insertCastMembers,
+  insertMovie
+} from '../controllers/moviePostControllers';
+
+const USAGE = 'Usage: npm run import-imdb -- --dir <directory or pattern> [--since <previous directory>] [--min-votes 1000] [--limit <n>] [--include-adult] [--dry-run]';
+
+const MANIFEST = 'manifest.json';
+
+const CAST_CATEGORIES = ['actor', 'actress', 'self'];
+
+/**
+ * title.principals categories stored as crew, by the movie field they fill
+ */
+const CREW_CATEGORIES = {
+  producer: 'producers',
+  composer: 'composers',
+  cinematographer: 'cinematographers',
+  editor: 'editors'
+} as const;
+
+/**
+ * Movie columns a delta import updates, by the input field holding them
+ */
+const DELTA_COLUMNS = {
+  title: 'title',
+  original_title: 'original_title',
+  release_date: 'release_date',
+  runtime_minutes: 'runtime_minutes',
+  adult: 'adult',
+  vote_count: 'vote_count',
+  vote_average: 'vote_average'
+} as const;
+
+/**
+ * Link tables a delta import replaces, by the input field holding them
+ */
+const DELTA_LINKS = {
+  genres: { table: 'movie_genres', idColumn: 'genre_id', getOrCreateId: getOrCreateGenreId },
+  directors: { table: 'movie_directors', idColumn: 'director_id', getOrCreateId: getOrCreateDirectorId },
+  producers: { table: 'movie_producers', idColumn: 'producer_id', getOrCreateId: getOrCreateProducerId }
+} as const;
+
+type DeltaField = keyof typeof DELTA_COLUMNS | keyof typeof DELTA_LINKS | keyof MovieCrew | 'cast';
+
+const DELTA_FIELDS = [
+  ...Object.keys(DELTA_COLUMNS),
+  ...Object.keys(DELTA_LINKS),
+  ...CREW_ROLE_NAMES,
+  'cast'
+] as DeltaField[];
+
+interface ImdbOptions {
+  dir: string;
+  /** Directory of the previously imported dumps, for a delta import */
+  since: string | null;
+  minVotes: number;
+  limit: number | null;
+  includeAdult: boolean;
+  dryRun: boolean;
+}
+
+/**
+ * One set of dumps to import
+ */
+interface Partition {
+  name: string;
+  dir: string;
+}
+
+type ImportCounts = Record<ImportOutcome | 'failed', number>;
+
+interface ImdbTitle {
+  tconst: string;
+  title: string;
+  originalTitle: string;
+  year: number;
+  runtime: number | null;
+  genres: string[];
+  adult: boolean;
+  votes: number | null;
+  rating: number | null;
+  directors: string[];
+  writers: string[];
+  producers: string[];
+  composers: string[];
+  cinematographers: string[];
+  editors: string[];
+  cast: { nconst: string; ordering: number; characters: string[] }[];
+}
+
+const parseArgs = (args: string[]): ImdbOptions => {
+  const options: ImdbOptions = { dir: '', since: null, minVotes: 1000, limit: null, includeAdult: false, dryRun: false };
+  const count = (value: string | undefined): number => {
+    const parsed = Number(value);
+    if (!Number.isInteger(parsed) || parsed < 0) {
+      throw new Error(USAGE);
+    }
+    return parsed;
+  };
+
+  for (let i = 0; i < args.length; i++) {
+    switch (args[i]) {
+      case '--dir':
+        options.dir = args[++i] ?? '';
+        break;
+      case '--since':
+        options.since = args[++i] || null;
+        if (!options.since) {
+          throw new Error(USAGE);
+        }
+        break;
+      case '--min-votes':
+        options.minVotes = count(args[++i]);
+        break;
+      case '--limit':
+        options.limit = count(args[++i]) || null;
+        break;
+      case '--include-adult':
+        options.includeAdult = true;
+        break;
+      case '--dry-run':
+        options.dryRun = true;
+        break;
+      default:
+        throw new Error(`Unknown argument: ${args[i]}\n${USAGE}`);
+    }
+  }
+  if (!options.dir) {
+    throw new Error(USAGE);
+  }
+  return options;
+};
+
+/**
+ * Path of a dump in the directory, compressed or not; null when missing
+ */
+const findDump = (dir: string, name: string): string | null =>
+  [`${name}.tsv.gz`, `${name}.tsv`]
+    .map(file => path.join(dir, file))
+    .find(file => fs.existsSync(file)) ?? null;
+
+const requireDump = (dir: string, name: string): string => {
+  const file = findDump(dir, name);
+  if (!file) {
+    throw new Error(`${name}.tsv(.gz) not found in ${dir}`);
+  }
+  return file;
+};
+
+/**
+ * Partition names listed in a directory's manifest.json; null without one
+ */
+const readManifest = (dir: string): string[] | null => {
+  const file = path.join(dir, MANIFEST);
+  if (!fs.existsSync(file)) {
+    return null;
+  }
+  const manifest = JSON.parse(fs.readFileSync(file, 'utf8'));
+  const names: unknown = manifest?.partitions;
+  if (!Array.isArray(names) || names.some(name => typeof name !== 'string' || !name)) {
+    throw new Error(`${file} must be {"partitions": ["<subdirectory>", ...]}`);
+  }
+  return names;
+};
+
+/**
+ * The partitions --dir names, in import order; null when it's a single
+ * directory of dumps
+ */
+const resolvePartitions = (dir: string): Partition[] | null => {
+  const parent = path.dirname(dir);
+  const pattern = path.basename(dir);
+  const partition = (name: string, root: string): Partition => {
+    const partitionDir = path.join(root, name);
+    if (!fs.existsSync(partitionDir)) {
+      throw new Error(`Partition ${name} not found in ${root}`);
+    }
+    return { name, dir: partitionDir };
+  };
+
+  if (!pattern.includes('*')) {
+    return readManifest(dir)?.map(name => partition(name, dir)) ?? null;
+  }
+
+  const literals = pattern.split('*').map(part => part.replace(/[.+?^${}()|[\]\\]/g, '\\$&'));
+  const matcher = new RegExp(`^${literals.join('.*')}$`);
+  const matched = fs.readdirSync(parent, { withFileTypes: true })
+    .filter(entry => entry.isDirectory() && matcher.test(entry.name))
+    .map(entry => entry.name);
+  if (matched.length === 0) {
+    throw new Error(`No directories match ${dir}`);
+  }
+
+  const manifest = readManifest(parent);
+  if (!manifest) {
+    return matched.sort().map(name => partition(name, parent));
+  }
+  const unlisted = matched.filter(name => !manifest.includes(name));
+  if (unlisted.length > 0) {
+    throw new Error(`${unlisted.join(', ')} not listed in ${path.join(parent, MANIFEST)}`);
+  }
+  return manifest.filter(name => matched.includes(name)).map(name => partition(name, parent));
+};
+
+/**
+ * Rows of an IMDb TSV dump as objects keyed by its header. IMDb doesn't quote
+ * fields, so rows are split on tabs; \N (no value) becomes null.
+ */
+async function* readDump(file: string): AsyncIterable<Record<string, string | null>> {
+  const input = fs.createReadStream(file);
+  const lines = readline.createInterface({
+    input: file.endsWith('.gz') ? input.pipe(zlib.createGunzip()) : input,
+    crlfDelay: Infinity
+  });
+
+  let header: string[] | null = null;
+  for await (const line of lines) {
+    const fields = line.split('\t');
+    if (!header) {
+      header = fields;
+      continue;
+    }
+    if (fields.length < header.length) continue;
+    yield Object.fromEntries(header.map((column, i) => [column, fields[i] === '\\N' ? null : fields[i]]));
+  }
+}
+
+const splitList = (value: string | null): string[] => (value ? value.split(',') : []);
+
+/**
+ * Feature films to import, with their votes, crew and cast as IMDb IDs.
+ * Given `only`, just those titles are read, whatever their votes.
+ */
+const loadTitles = async (options: ImdbOptions, only: Set<string> | null = null): Promise<Map<string, ImdbTitle>> => {
+  const minVotes = only ? 0 : options.minVotes;
+  const ratingsFile = minVotes > 0 ? requireDump(options.dir, 'title.ratings') : findDump(options.dir, 'title.ratings');
+  const ratings = new Map<string, { votes: number; rating: number }>();
+  if (ratingsFile) {
+    for await (const row of readDump(ratingsFile)) {
+      const votes = Number(row.numVotes);
+      if (votes >= minVotes && (!only || only.has(row.tconst!))) {
+        ratings.set(row.tconst!, { votes, rating: Number(row.averageRating) });
+      }
+    }
+    console.log(`  title.ratings: ${ratings.size} titles with at least ${minVotes} votes`);
+  }
+
+  let titles: ImdbTitle[] = [];
+  for await (const row of readDump(requireDump(options.dir, 'title.basics'))) {
+    if (only && !only.has(row.tconst!)) continue;
+    if (row.titleType !== 'movie' || !row.startYear || !row.genres || !row.primaryTitle) continue;
+    if (row.isAdult === '1' && !options.includeAdult) continue;
+    const rating = ratings.get(row.tconst!);
+    if (minVotes > 0 && !rating) continue;
+
+    titles.push({
+      tconst: row.tconst!,
+      title: row.primaryTitle,
+      originalTitle: row.originalTitle || row.primaryTitle,
+      year: Number(row.startYear),
+      runtime: row.runtimeMinutes ? Number(row.runtimeMinutes) || null : null,
+      genres: splitList(row.genres),
+      adult: row.isAdult === '1',
+      votes: rating?.votes ?? null,
+      rating: rating?.rating ?? null,
+      directors: [],
+      writers: [],
+      producers: [],
+      composers: [],
+      cinematographers: [],
+      editors: [],
+      cast: []
+    });
+  }
+  if (options.limit !== null && !only) {
+    titles = titles.sort((a, b) => (b.votes ?? 0) - (a.votes ?? 0)).slice(0, options.limit);
+  }
+  const byId = new Map(titles.map(title => [title.tconst, title]));
+  console.log(`  title.basics: ${byId.size} feature films to import`);
+
+  for await (const row of readDump(requireDump(options.dir, 'title.crew'))) {
+    const title = byId.get(row.tconst!);
+    if (title) {
+      title.directors = splitList(row.directors);
+      title.writers = splitList(row.writers);
+    }
+  }
+
+  for await (const row of readDump(requireDump(options.dir, 'title.principals'))) {
+    const title = byId.get(row.tconst!);
+    if (!title) continue;
+    const crewField = CREW_CATEGORIES[row.category as keyof typeof CREW_CATEGORIES];
+    if (crewField) {
+      title[crewField].push(row.nconst!);
+    } else if (CAST_CATEGORIES.includes(row.category ?? '')) {
+      let characters: string[] = [];
+      try {
+        characters = row.characters ? JSON.parse(row.characters) : [];
+      } catch {
+        // A few rows have malformed character lists; keep the credit without them
+      }
+      title.cast.push({ nconst: row.nconst!, ordering: Number(row.ordering), characters });
+    }
+  }
+
+  return byId;
+};
+
+/**
+ * Names of the people the titles credit
+ */
+const loadNames = async (dir: string, titles: Iterable<ImdbTitle>): Promise<Map<string, string>> => {
+  const wanted = new Set<string>();
+  for (const title of titles) {
+    [title.directors, title.writers, title.producers, title.composers, title.cinematographers, title.editors]
+      .forEach(ids => ids.forEach(id => wanted.add(id)));
+    title.cast.forEach(member => wanted.add(member.nconst));
+  }
+
+  const names = new Map<string, string>();
+  for await (const row of readDump(requireDump(dir, 'name.basics'))) {
+    if (wanted.has(row.nconst!) && row.primaryName) {
+      names.set(row.nconst!, row.primaryName);
+    }
+  }
+  console.log(`  name.basics: ${names.size} of ${wanted.size} credited people found`);
+  return names;
+};
+
+/**
+ * The movie as POST /api/movies would take it; people IMDb has no name
+ * for are left out
+ */
+const toMovieInput = (title: ImdbTitle, names: Map<string, string>): MovieCreateInput => {
+  const named = (ids: string[]) => uniqueNames(ids.map(id => names.get(id)).filter((name): name is string => !!name));
+
+  const cast: CastMember[] = [...title.cast]
+    .sort((a, b) => a.ordering - b.ordering)
+    .filter(member => names.has(member.nconst))
+    .map((member, i) => ({
+      actor_name: names.get(member.nconst)!,
+      character_names: member.characters,
+      actor_order: i + 1,
+      billing_order: member.ordering
+    }));
+
+  return {
+    title: title.title,
+    original_title: title.originalTitle,
+    release_date: `${title.year}-01-01`,
+    runtime_minutes: title.runtime,
+    genres: title.genres,
+    overview: '',
+    mpa_rating: '',
+    adult: title.adult,
+    ...(title.votes !== null ? { vote_count: title.votes, vote_average: title.rating ?? undefined } : {}),
+    directors: named(title.directors),
+    writers: named(title.writers),
+    producers: named(title.producers),
+    composers: named(title.composers),
+    cinematographers: named(title.cinematographers),
+    editors: named(title.editors),
+    cast
+  };
+};
+
+/**
+ * The fields of a title that differ between two versions of the dumps,
+ * compared as they would be stored, so a renamed person counts as a change
+ */
+const changedFields = (current: MovieCreateInput, previous: MovieCreateInput): DeltaField[] =>
+  DELTA_FIELDS.filter(field => JSON.stringify(current[field] ?? null) !== JSON.stringify(previous[field] ?? null));
+
+/**
+ * Applies the changed fields of a title to the movie imported from it
+ *
+ * @returns False when no movie has the title's IMDb ID
+ */
+const updateTitle = async (
+  client: PoolClient,
+  tconst: string,
+  input: MovieCreateInput,
+  fields: DeltaField[],
+  runId: number
+): Promise<boolean> => {
+  const found = await client.query<{ movie_id: number }>('SELECT movie_id FROM movies WHERE imdb_id = $1 FOR UPDATE', [tconst]);
+  if (found.rows.length === 0) {
+    return false;
+  }
+  const movieId = found.rows[0].movie_id;
+  const before = await snapshotMovie(client, movieId);
+
+  const columns = fields.filter((field): field is keyof typeof DELTA_COLUMNS => field in DELTA_COLUMNS);
+  if (columns.length > 0) {
+    const assignments = columns.map((field, i) => `${DELTA_COLUMNS[field]} = $${i + 2}`);
+    await client.query(
+      `UPDATE movies SET ${assignments.join(', ')}, version = version + 1, updated_at = NOW() WHERE movie_id = $1`,
+      [movieId, ...columns.map(field => input[field] ?? null)]
+    );
+  }
+
+  for (const field of fields) {
+    if (!(field in DELTA_LINKS)) continue;
+    const { table, idColumn, getOrCreateId } = DELTA_LINKS[field as keyof typeof DELTA_LINKS];
+    await client.query(`DELETE FROM ${table} WHERE movie_id = $1`, [movieId]);
+    for (const name of input[field as keyof typeof DELTA_LINKS] ?? []) {
+      const id = await getOrCreateId(client, name);
+      await client.query(`INSERT INTO ${table} (movie_id, ${idColumn}) VALUES ($1, $2) ON CONFLICT DO NOTHING`, [movieId, id]);
+    }
+  }
+
+  const crew: MovieCrew = Object.fromEntries(
+    CREW_ROLE_NAMES.filter(role => fields.includes(role)).map(role => [role, input[role] ?? []])
+  );
+  await replaceMovieCrew(client, movieId, crew);
+
+  if (fields.includes('cast')) {
+    await client.query('DELETE FROM movie_actors WHERE movie_id = $1', [movieId]);
+    await insertCastMembers(client, movieId, input.cast ?? []);
+  }
+
+  await recordMovieLineage(client, runId, movieId, before);
+  return true;
+};
+
+type ImportOutcome = 'created' | 'linked' | 'updated' | 'skipped';
+
+/**
+ * Imports one title: skipped when already imported, linked to a catalog
+ * movie with the same title and year, created otherwise
+ */
+const importTitle = async (client: PoolClient, title: ImdbTitle, names: Map<string, string>, runId: number): Promise<ImportOutcome> => {
+  const existing = await client.query('SELECT 1 FROM movies WHERE imdb_id = $1', [title.tconst]);
+  if (existing.rows.length > 0) {
+    return 'skipped';
+  }
+
+  const linked = await client.query(
+    `UPDATE movies SET imdb_id = $1
+     WHERE movie_id = (
+       SELECT movie_id FROM movies
+       WHERE imdb_id IS NULL AND LOWER(title) = LOWER($2)
+         AND EXTRACT(YEAR FROM release_date) = $3
+       ORDER BY movie_id LIMIT 1
+     )`,
+    [title.tconst, title.title, title.year]
+  );
+  if ((linked.rowCount ?? 0) > 0) {
+    return 'linked';
+  }
+
+  const movie = await insertMovie(client, toMovieInput(title, names), runId);
+  await client.query('UPDATE movies SET imdb_id = $2 WHERE movie_id = $1', [movie.movie_id, title.tconst]);
+  return 'created';
+};
+
+/**
+ * Runs one title's changes in their own transaction, or in a savepoint when
+ * the whole partition shares one
+ */
+const inTitleTransaction = async <T>(client: PoolClient, nested: boolean, work: () => Promise<T>): Promise<T> => {
+  await client.query(nested ? 'SAVEPOINT title' : 'BEGIN');
+  try {
+    const result = await work();
+    await client.query(nested ? 'RELEASE SAVEPOINT title' : 'COMMIT');
+    return result;
+  } catch (error) {
+    await client.query(nested ? 'ROLLBACK TO SAVEPOINT title' : 'ROLLBACK');
+    throw error;
+  }
+};
+
+const formatCounts = (counts: ImportCounts): string =>
+  `${counts.created} created, ${counts.linked} linked to existing movies, ${counts.updated} updated, ${counts.skipped} already imported, ${counts.failed} failed`;
+
+/**
+ * Imports one set of dumps. Partitions run in a single transaction that
+ * commits once every title has been tried; a plain directory commits each
+ * title as it goes.
+ *
+ * @param since - Directory of the previous dumps of this set, for a delta import
+ * @returns What happened to the titles; null on a dry run
+ */
+const importDumps = async (
+  partition: Partition,
+  since: string | null,
+  options: ImdbOptions,
+  atomic: boolean
+): Promise<ImportCounts | null> => {
+  const dumps = { ...options, dir: partition.dir };
+  console.log(`Reading IMDb dumps from ${partition.dir}`);
+  const titles = await loadTitles(dumps);
+  const names = await loadNames(partition.dir, titles.values());
+
+  // For a delta import, the fields that changed in each title since the
+  // previous dumps; titles missing from this map are unchanged
+  let changes: Map<string, DeltaField[] | null> | null = null;
+  if (since) {
+    console.log(`Reading previous IMDb dumps from ${since}`);
+    const previous = fs.existsSync(since)
+      ? await loadTitles({ ...options, dir: since }, new Set(titles.keys()))
+      : new Map<string, ImdbTitle>();
+    const previousNames = previous.size > 0 ? await loadNames(since, previous.values()) : new Map<string, string>();
+
+    changes = new Map();
+    for (const title of titles.values()) {
+      const old = previous.get(title.tconst);
+      if (!old) {
+        changes.set(title.tconst, null);
+        continue;
+      }
+      const fields = changedFields(toMovieInput(title, names), toMovieInput(old, previousNames));
+      if (fields.length > 0) {
+        changes.set(title.tconst, fields);
+      }
+    }
+    const added = [...changes.values()].filter(fields => fields === null).length;
+    console.log(`  ${added} new and ${changes.size - added} changed of ${titles.size} titles`);
+  }
+
+  if (options.dryRun) {
+    if (changes) {
+      for (const [tconst, fields] of [...changes].slice(0, 20)) {
+        console.log(`  ${tconst} "${titles.get(tconst)!.title}": ${fields ? fields.join(', ') : 'new'}`);
+      }
+      console.log(`Dry run: ${changes.size} movies would be imported or updated`);
+      return null;
+    }
+    const [sample] = titles.values();
+    if (sample) {
+      console.log('First movie as it would be imported:');
+      console.log(JSON.stringify(toMovieInput(sample, names), null, 2));
+    }
+    console.log(`Dry run: ${titles.size} movies would be imported or linked`);
+    return null;
+  }
+
+  const counts: ImportCounts = { created: 0, linked: 0, updated: 0, skipped: 0, failed: 0 };
+  const pending = changes ? [...changes.keys()].map(tconst => titles.get(tconst)!) : [...titles.values()];
+  let done = 0;
+  const client = await pool.connect();
+
+  try {
+    if (atomic) {
+      await client.query('BEGIN');
+    }
+    const runId = await startImportRun(client, 'imdb', { source: path.resolve(partition.dir) });
+
+    for (const title of pending) {
+      try {
+        const outcome = await inTitleTransaction(client, atomic, async () => {
+          const fields = changes?.get(title.tconst);
+          const updated = fields ? await updateTitle(client, title.tconst, toMovieInput(title, names), fields, runId) : false;
+          return updated ? 'updated' : await importTitle(client, title, names, runId);
+        });
+        counts[outcome]++;
+      } catch (error) {
+        counts.failed++;
+        console.error(`  failed: "${title.title}" (${title.tconst}):`, error instanceof Error ? error.message : error);
+      }
+      if (++done % 1000 === 0) {
+        console.log(`  ${done}/${pending.length}: ${counts.created} created, ${counts.linked} linked, ${counts.updated} updated, ${counts.skipped} already imported`);
+      }
+    }
+
+    if (atomic) {
+      await client.query('COMMIT');
+    }
+  } catch (error) {
+    if (atomic) {
+      await client.query('ROLLBACK');
+    }
+    throw error;
+  } finally {
+    client.release();
+  }
+
+  console.log(`Done with ${partition.name}: ${formatCounts(counts)}`);
+  return counts;
+};
+
+const main = async (): Promise<void> => {
+  const options = parseArgs(process.argv.slice(2));
+  const partitions = resolvePartitions(options.dir);
+
+  if (!partitions) {
+    const counts = await importDumps({ name: options.dir, dir: options.dir }, options.since, options, false);
+    if (counts && (counts.created > 0 || counts.updated > 0)) {
+      await refreshBoxOfficeStats();
+    }
+    if (counts?.failed) {
+      process.exitCode = 1;
+    }
+    return;
+  }
+
+  console.log(`Importing ${partitions.length} partitions: ${partitions.map(partition => partition.name).join(', ')}`);
+  const totals: ImportCounts = { created: 0, linked: 0, updated: 0, skipped: 0, failed: 0 };
+  const failedPartitions: string[] = [];
+
+  for (const partition of partitions) {
+    try {
+      const since = options.since ? path.join(options.since, partition.name) : null;
+      const counts = await importDumps(partition, since, options, true);
+      for (const [outcome, count] of Object.entries(counts ?? {})) {
+        totals[outcome as keyof ImportCounts] += count;
+      }
+    } catch (error) {
+      failedPartitions.push(partition.name);
+      console.error(`Partition ${partition.name} failed and was rolled back:`, error instanceof Error ? error.message : error);
+    }
+  }
+
+  if (options.dryRun) {
+    return;
+  }
+  if (totals.created > 0 || totals.updated > 0) {
+    await refreshBoxOfficeStats();
+  }
+
+  console.log(`All partitions: ${partitions.length - failedPartitions.length} of ${partitions.length} imported; ${formatCounts(totals)}`);
+  if (failedPartitions.length > 0) {
+    console.log(`Failed partitions: ${failedPartitions.join(', ')}`);
+  }
+  if (failedPartitions.length > 0 || totals.failed > 0) {
+    process.exitCode = 1;
+  }
+};
+
+main()
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(() => pool.end());
@@ -0,0 +1,195 @@
+// server/src/scripts/imports.ts
+//
+// Review staged imports from the command line.
+//
+//   npm run imports -- list [pending|approved|rejected]
+//   npm run imports -- show <batch id>
+//   npm run imports -- profile <batch id>
+//   npm run imports -- approve <batch id>
+//   npm run imports -- reject <batch id> [note]
+//
+// Same as the /api/admin/imports endpoints. profile prints each field's null
+// rate, type, distinct count, range and most common values, with warnings
+// for fields worth a look before approving. Approving inserts every movie of
+// the batch in one transaction; if any fails nothing is inserted. Actions are
+// audited without an API key.
+
+import pool from '@utils/database';
+import { FieldProfile } from '@utils/fieldProfiles';
+import {
+  discardImportBatch,
+  ImportBatchSummary,
+  profileImportBatch,
+  promoteImportBatch,
+  summarizeImportBatch
+} from '../controllers/importControllers';
+
+const USAGE = 'Usage: npm run imports -- list [status] | show <id> | profile <id> | approve <id> | reject <id> [note]';
+
+const STATUSES = ['pending', 'approved', 'rejected'];
+
+const parseBatchId = (value: string | undefined): number => {
+  const batchId = Number(value);
+  if (!Number.isInteger(batchId) || batchId <= 0) {
+    throw new Error(USAGE);
+  }
+  return batchId;
+};
+
+const printSummary = (summary: ImportBatchSummary): void => {
+  console.log(`Batch ${summary.batch_id}: ${summary.status}, ${summary.movie_count} movies`);
+  console.log(`  submitted ${new Date(summary.submitted_at).toISOString()} by key ${summary.submitted_by ?? '-'}`);
+  if (summary.reviewed_at) {
+    console.log(`  reviewed ${new Date(summary.reviewed_at).toISOString()} by key ${summary.reviewed_by ?? '-'}`);
+  }
+  if (summary.review_note) {
+    console.log(`  note: ${summary.review_note}`);
+  }
+
+  console.log('Movies:');
+  for (const movie of summary.movies) {
+    const flags = [
+      movie.existing_movie_id !== null ? `possible duplicate of movie ${movie.existing_movie_id}` : null,
+      movie.movie_id !== null ? `imported as movie ${movie.movie_id}` : null
+    ].filter(Boolean);
+    console.log(`  ${movie.position}. ${movie.title} (${movie.release_date})${flags.length ? ` - ${flags.join(', ')}` : ''}`);
+  }
+
+  console.log(`Possible duplicates: ${summary.possible_duplicates}`);
+  for (const [kind, names] of Object.entries(summary.new_names)) {
+    if (names.length > 0) {
+      console.log(`New ${kind} (${names.length}): ${names.join(', ')}`);
+    }
+  }
+};
+
+/**
+ * Longest value shown in the top values column
+ */
+const MAX_VALUE_LENGTH = 24;
+
+const printProfile = (fields: FieldProfile[]): void => {
+  const rows = fields.map(field => {
+    const range = field.min !== null
+      ? `${field.min}..${field.max}`
+      : field.min_length !== null ? `len ${field.min_length}..${field.max_length}` : '';
+    const top = field.top_values
+      .slice(0, 3)
+      .map(({ value, count }) => {
+        const text = String(value);
+        return `${text.length > MAX_VALUE_LENGTH ? `${text.slice(0, MAX_VALUE_LENGTH - 1)}…` : text} (${count})`;
+      })
+      .join(', ');
+    return [field.field, field.type, `${(field.null_rate * 100).toFixed(1)}%`, String(field.distinct), range, top];
+  });
+
+  const header = ['field', 'type', 'empty', 'distinct', 'range', 'top values'];
+  const widths = header.map((title, i) => Math.max(title.length, ...rows.map(row => row[i].length)));
+  const line = (cells: string[]) => cells.map((cell, i) => cell.padEnd(widths[i])).join('  ').trimEnd();
+
+  console.log(line(header));
+  rows.forEach(row => console.log(line(row)));
+
+  const flagged = fields.filter(field => field.warnings.length > 0);
+  if (flagged.length > 0) {
+    console.log('\nWarnings:');
+    flagged.forEach(field => console.log(`  ${field.field}: ${field.warnings.join('; ')}`));
+  }
+};
+
+const list = async (status = 'pending'): Promise<void> => {
+  if (!STATUSES.includes(status)) {
+    throw new Error(USAGE);
+  }
+
+  const result = await pool.query(
+    `SELECT batch_id, movie_count, submitted_by, submitted_at
+     FROM import_batches
+     WHERE status = $1
+     ORDER BY submitted_at, batch_id`,
+    [status]
+  );
+
+  if (result.rows.length === 0) {
+    console.log(`No ${status} batches`);
+    return;
+  }
+  for (const batch of result.rows) {
+    console.log(
+      `${batch.batch_id}\t${batch.movie_count} movies\t` +
+      `${new Date(batch.submitted_at).toISOString()}\tkey ${batch.submitted_by ?? '-'}`
+    );
+  }
+};
+
+const main = async (): Promise<void> => {
+  const [command, arg, ...rest] = process.argv.slice(2);
+
+  switch (command) {
+    case 'list':
+      await list(arg);
+      return;
+
+    case 'show': {
+      const batchId = parseBatchId(arg);
+      const summary = await summarizeImportBatch(batchId);
+      if (!summary) {
+        throw new Error(`Import batch ${batchId} not found`);
+      }
+      printSummary(summary);
+      return;
+    }
+
+    case 'profile': {
+      const batchId = parseBatchId(arg);
+      const profile = await profileImportBatch(batchId);
+      if (!profile) {
+        throw new Error(`Import batch ${batchId} not found`);
+      }
+      console.log(`Batch ${batchId}: ${profile.movie_count} movies`);
+      printProfile(profile.fields);
+      return;
+    }
+
+    case 'approve': {
+      const batchId = parseBatchId(arg);
+      const result = await promoteImportBatch(batchId, null);
+      switch (result.outcome) {
+        case 'not_found':
+          throw new Error(`Import batch ${batchId} not found`);
+        case 'not_pending':
+          throw new Error(`Import batch ${batchId} is already ${result.status}`);
+        case 'failed':
+          throw new Error(
+            `Movie ${result.position} ("${result.title}") failed to import, nothing was approved: ${result.error}`
+          );
+        case 'approved':
+          console.log(`Imported ${result.movie_ids.length} movies: ${result.movie_ids.join(', ')}`);
+      }
+      return;
+    }
+
+    case 'reject': {
+      const batchId = parseBatchId(arg);
+      const previous = await discardImportBatch(batchId, null, rest.join(' ').trim() || null);
+      if (previous === null) {
+        throw new Error(`Import batch ${batchId} not found`);
+      }
+      if (previous !== 'pending') {
+        throw new Error(`Import batch ${batchId} is already ${previous}`);
+      }
+      console.log(`Rejected import batch ${batchId}`);
+      return;
+    }
+
+    default:
+      throw new Error(USAGE);
+  }
+};
+
+main()
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(() => pool.end());
@@ -0,0 +1,471 @@
+// server/src/scripts/loadtest.ts
+//
+// Generate traffic against a running deployment at a fixed request rate and
+// report latency percentiles per endpoint.
+//
+//   npm run loadtest -- --base-url http://localhost:4000 --rps 200 --duration 2m --scenario browse
+//   npm run loadtest -- --base-url http://localhost:4000 --scenario search --json
+//
+// Scenarios:
+//   browse  movie list pages, movie details and cast, actor and director pages
+//   search  title, genre, actor, date and revenue searches built from the catalog
+//   write   creates, updates and deletes its own "Load test" movies, with reads
+//           of them in between; whatever it created is deleted at the end
+//
+// Requests are sent on a fixed schedule whatever the response times (an open
+// model), so a slow server shows up as latency rather than as fewer requests;
+// past --max-in-flight outstanding requests, scheduled ones are dropped and
+// counted. Without --api-key (or API_KEY) a key named "loadtest" is created.
+// The key's rate limit applies: raise it for runs above it, or expect 429s.
+
+import { performance } from 'perf_hooks';
+
+type Scenario = 'browse' | 'search' | 'write';
+
+const SCENARIOS: Scenario[] = ['browse', 'search', 'write'];
+
+interface Options {
+  baseUrl: string;
+  apiKey: string | null;
+  rps: number;
+  durationMs: number;
+  scenario: Scenario;
+  maxInFlight: number;
+  json: boolean;
+}
+
+interface Sample {
+  endpoint: string;
+  status: number;
+  ms: number;
+}
+
+/**
+ * A request for the scenario to send; endpoint groups samples in the report
+ */
+interface PlannedRequest {
+  endpoint: string;
+  method: string;
+  path: string;
+  body?: unknown;
+  /** Called with the parsed body of a 2xx response */
+  onSuccess?: (body: any) => void;
+}
+
+interface Catalog {
+  movieIds: number[];
+  moviePages: number;
+  titleWords: string[];
+  genres: string[];
+  actorIds: number[];
+  actorNames: string[];
+  directorIds: number[];
+}
+
+const REQUEST_TIMEOUT_MS = 30_000;
+
+const PROGRESS_INTERVAL_MS = 10_000;
+
+const PERCENTILES = [50, 90, 95, 99];
+
+/**
+ * "90", "90s", "2m" or "500ms" in milliseconds
+ */
+const parseDuration = (value: string): number => {
+  const match = /^(\d+(?:\.\d+)?)(ms|s|m)?$/.exec(value);
+  if (!match) {
+    throw new Error(`Invalid duration: ${value} (use e.g. 30s or 2m)`);
+  }
+  const unit = match[2] ?? 's';
+  return Math.round(parseFloat(match[1]) * (unit === 'm' ? 60_000 : unit === 's' ? 1000 : 1));
+};
+
+const parseArgs = (args: string[]): Options => {
+  const options: Options = {
+    baseUrl: '',
+    apiKey: process.env.API_KEY || null,
+    rps: 50,
+    durationMs: 30_000,
+    scenario: 'browse',
+    maxInFlight: 1000,
+    json: false
+  };
+  const value = (i: number): string => {
+    if (args[i] === undefined) {
+      throw new Error(`${args[i - 1]} needs a value`);
+    }
+    return args[i];
+  };
+
+  for (let i = 0; i < args.length; i++) {
+    switch (args[i]) {
+      case '--base-url':
+        options.baseUrl = value(++i).replace(/\/+$/, '');
+        break;
+      case '--api-key':
+        options.apiKey = value(++i);
+        break;
+      case '--rps':
+        options.rps = Number(value(++i));
+        break;
+      case '--duration':
+        options.durationMs = parseDuration(value(++i));
+        break;
+      case '--scenario':
+        options.scenario = value(++i) as Scenario;
+        break;
+      case '--max-in-flight':
+        options.maxInFlight = Number(value(++i));
+        break;
+      case '--json':
+        options.json = true;
+        break;
+      default:
+        throw new Error(`Unknown argument: ${args[i]}`);
+    }
+  }
+
+  if (!/^https?:\/\//.test(options.baseUrl)) {
+    throw new Error('Usage: loadtest --base-url <http(s)://host[:port]> [--rps 50] [--duration 30s] [--scenario browse|search|write]');
+  }
+  if (!SCENARIOS.includes(options.scenario)) {
+    throw new Error(`--scenario must be one of ${SCENARIOS.join(', ')}`);
+  }
+  if (!(options.rps > 0) || !(options.maxInFlight >= 1) || options.durationMs <= 0) {
+    throw new Error('--rps, --duration and --max-in-flight must be positive');
+  }
+  return options;
+};
+
+const pick = <T>(items: T[]): T => items[Math.floor(Math.random() * items.length)];
+
+const randomInt = (min: number, max: number): number => min + Math.floor(Math.random() * (max - min + 1));
+
+/**
+ * Picks one of the weighted entries
+ */
+const weighted = <T>(entries: [number, () => T][]): T => {
+  const total = entries.reduce((sum, [weight]) => sum + weight, 0);
+  let roll = Math.random() * total;
+  for (const [weight, make] of entries) {
+    roll -= weight;
+    if (roll < 0) return make();
+  }
+  return entries[entries.length - 1][1]();
+};
+
+class LoadClient {
+  constructor(private readonly baseUrl: string, private apiKey: string | null) {}
+
+  setApiKey(apiKey: string): void {
+    this.apiKey = apiKey;
+  }
+
+  /**
+   * Sends a request; network errors and timeouts come back as status 0
+   */
+  async send(method: string, path: string, body?: unknown): Promise<{ status: number; ms: number; body: any }> {
+    const headers: Record<string, string> = { Accept: 'application/json' };
+    if (this.apiKey) headers['X-API-Key'] = this.apiKey;
+    if (body !== undefined) headers['Content-Type'] = 'application/json';
+
+    const started = performance.now();
+    try {
+      const response = await fetch(`${this.baseUrl}${path}`, {
+        method,
+        headers,
+        body: body === undefined ? undefined : JSON.stringify(body),
+        signal: AbortSignal.timeout(REQUEST_TIMEOUT_MS)
+      });
+      const text = await response.text();
+      const ms = performance.now() - started;
+      let parsed: unknown = null;
+      try {
+        parsed = text ? JSON.parse(text) : null;
+      } catch {
+        // Not JSON; only successful JSON bodies are used
+      }
+      return { status: response.status, ms, body: parsed };
+    } catch {
+      return { status: 0, ms: performance.now() - started, body: null };
+    }
+  }
+
+  async get(path: string): Promise<any> {
+    const result = await this.send('GET', path);
+    if (result.status !== 200) {
+      throw new Error(`GET ${path} returned ${result.status || 'no response'}`);
+    }
+    return result.body;
+  }
+}
+
+/**
+ * IDs, titles and names to build requests from, sampled from the catalog
+ * before the run so the traffic hits rows that exist
+ */
+const loadCatalog = async (client: LoadClient): Promise<Catalog> => {
+  const first = await client.get('/api/movies?limit=100&sortBy=popularity&order=desc');
+  const pages = await Promise.all([2, 3].filter(page => page <= first.meta.pages)
+    .map(page => client.get(`/api/movies?limit=100&page=${page}&sortBy=popularity&order=desc`)));
+  const movies = [first, ...pages].flatMap(page => page.data);
+
+  const [actors, directors] = await Promise.all([
+    client.get('/api/actors?limit=100').catch(() => ({ data: [] })),
+    client.get('/api/directors?limit=100').catch(() => ({ data: [] }))
+  ]);
+
+  const titleWords = [...new Set(movies.flatMap(movie => String(movie.title).toLowerCase().split(/\W+/)))]
+    .filter(word => word.length >= 4);
+  const genres = [...new Set(movies.flatMap(movie => String(movie.genres ?? '').split(', ')).filter(Boolean))];
+
+  return {
+    movieIds: movies.map(movie => movie.movie_id),
+    moviePages: Math.max(1, Math.ceil(first.meta.total / 20)),
+    titleWords: titleWords.length > 0 ? titleWords : ['the'],
+    genres: genres.length > 0 ? genres : ['Drama'],
+    actorIds: actors.data.map((actor: any) => actor.actor_id),
+    actorNames: actors.data.map((actor: any) => actor.actor_name),
+    directorIds: directors.data.map((director: any) => director.director_id)
+  };
+};
+
+const browseRequest = (catalog: Catalog): PlannedRequest => weighted<PlannedRequest>([
+  [30, () => ({
+    endpoint: 'GET /movies',
+    method: 'GET',
+    path: `/api/movies?page=${randomInt(1, Math.min(catalog.moviePages, 50))}&limit=20&sortBy=${pick(['popularity', 'release_date', 'title'])}`
+  })],
+  [30, () => ({ endpoint: 'GET /movies/:id', method: 'GET', path: `/api/movies/${pick(catalog.movieIds)}` })],
+  [10, () => ({ endpoint: 'GET /movies/:id/cast', method: 'GET', path: `/api/movies/${pick(catalog.movieIds)}/cast` })],
+  [10, () => ({ endpoint: 'GET /movies?genre', method: 'GET', path: `/api/movies?genre=${encodeURIComponent(pick(catalog.genres))}&limit=20` })],
+  ...(catalog.actorIds.length > 0
+    ? [[10, () => ({ endpoint: 'GET /actors/:id', method: 'GET', path: `/api/actors/${pick(catalog.actorIds)}` })] as [number, () => PlannedRequest]]
+    : []),
+  ...(catalog.directorIds.length > 0
+    ? [[10, () => ({ endpoint: 'GET /directors/:id/movies', method: 'GET', path: `/api/directors/${pick(catalog.directorIds)}/movies` })] as [number, () => PlannedRequest]]
+    : [])
+]);
+
+const searchRequest = (catalog: Catalog): PlannedRequest => weighted<PlannedRequest>([
+  [40, () => ({ endpoint: 'GET /movies?title', method: 'GET', path: `/api/movies?title=${encodeURIComponent(pick(catalog.titleWords))}&limit=20` })],
+  [20, () => {
+    const year = randomInt(1970, new Date().getFullYear() - 1);
+    return {
+      endpoint: 'GET /movies?genre&dates',
+      method: 'GET',
+      path: `/api/movies?genre=${encodeURIComponent(pick(catalog.genres))}&startDate=${year}-01-01&endDate=${year + 9}-12-31&sortBy=revenue&order=desc`
+    };
+  }],
+  [15, () => ({ endpoint: 'GET /movies?minRevenue', method: 'GET', path: `/api/movies?minRevenue=${randomInt(1, 500) * 1_000_000}&sortBy=revenue&order=desc` })],
+  [15, () => ({ endpoint: 'GET /movies?title&facets', method: 'GET', path: `/api/movies?title=${encodeURIComponent(pick(catalog.titleWords))}&facets=true` })],
+  ...(catalog.actorNames.length > 0
+    ? [[10, () => ({ endpoint: 'GET /movies?actor', method: 'GET', path: `/api/movies?actor=${encodeURIComponent(pick(catalog.actorNames))}` })] as [number, () => PlannedRequest]]
+    : [])
+]);
+
+/**
+ * The write scenario: every movie it touches is one it created
+ */
+const createWriteScenario = (catalog: Catalog) => {
+  const created: number[] = [];
+  let counter = 0;
+
+  const newMovie = () => {
+    counter++;
+    return {
+      title: `Load test ${Date.now().toString(36)}-${counter}`,
+      original_title: `Load test ${counter}`,
+      release_date: `20${String(randomInt(0, 24)).padStart(2, '0')}-0${randomInt(1, 9)}-1${randomInt(0, 9)}`,
+      runtime_minutes: randomInt(80, 160),
+      genres: [pick(catalog.genres)],
+      overview: 'Created by the load test; deleted when the run ends.',
+      mpa_rating: pick(['G', 'PG', 'PG-13', 'R'])
+    };
+  };
+
+  const create = (): PlannedRequest => ({
+    endpoint: 'POST /movies',
+    method: 'POST',
+    path: '/api/movies',
+    body: newMovie(),
+    onSuccess: body => {
+      if (typeof body?.movie_id === 'number') created.push(body.movie_id);
+    }
+  });
+
+  const next = (): PlannedRequest => {
+    if (created.length < 5) {
+      return create();
+    }
+    return weighted<PlannedRequest>([
+      [35, () => ({ endpoint: 'GET /movies/:id', method: 'GET', path: `/api/movies/${pick(created)}` })],
+      [25, create],
+      [25, () => ({
+        endpoint: 'PATCH /movies/:id',
+        method: 'PATCH',
+        path: `/api/movies/${pick(created)}`,
+        body: { overview: `Updated by the load test at ${new Date().toISOString()}.`, runtime_minutes: randomInt(80, 160) }
+      })],
+      [15, () => {
+        const id = created.splice(Math.floor(Math.random() * created.length), 1)[0];
+        return { endpoint: 'DELETE /movies/:id', method: 'DELETE', path: `/api/movies/${id}` };
+      }]
+    ]);
+  };
+
+  const cleanUp = async (client: LoadClient): Promise<number> => {
+    const ids = created.splice(0);
+    await Promise.all(ids.map(id => client.send('DELETE', `/api/movies/${id}`)));
+    return ids.length;
+  };
+
+  return { next, cleanUp };
+};
+
+/**
+ * Nearest-rank percentile of sorted values
+ */
+const percentile = (sorted: number[], p: number): number =>
+  sorted.length === 0 ? 0 : sorted[Math.min(sorted.length - 1, Math.ceil((p / 100) * sorted.length) - 1)];
+
+interface LatencySummary {
+  endpoint: string;
+  requests: number;
+  errors: number;
+  percentiles: Record<string, number>;
+  max: number;
+}
+
+const summarize = (endpoint: string, samples: Sample[]): LatencySummary => {
+  const sorted = samples.map(sample => sample.ms).sort((a, b) => a - b);
+  return {
+    endpoint,
+    requests: samples.length,
+    errors: samples.filter(sample => sample.status === 0 || sample.status >= 400).length,
+    percentiles: Object.fromEntries(PERCENTILES.map(p => [`p${p}`, Math.round(percentile(sorted, p) * 10) / 10])),
+    max: Math.round((sorted[sorted.length - 1] ?? 0) * 10) / 10
+  };
+};
+
+const printReport = (options: Options, samples: Sample[], elapsedMs: number, dropped: number, cleaned: number): void => {
+  const statuses = new Map<string, number>();
+  for (const sample of samples) {
+    const key = sample.status === 0 ? 'no response' : String(sample.status);
+    statuses.set(key, (statuses.get(key) ?? 0) + 1);
+  }
+
+  const endpoints = [...new Set(samples.map(sample => sample.endpoint))].sort();
+  const rows = [
+    summarize('all', samples),
+    ...endpoints.map(endpoint => summarize(endpoint, samples.filter(sample => sample.endpoint === endpoint)))
+  ];
+
+  console.log(`\nScenario ${options.scenario} against ${options.baseUrl}`);
+  console.log(`  Target ${options.rps} req/s for ${(options.durationMs / 1000).toFixed(0)}s; achieved ${(samples.length / (elapsedMs / 1000)).toFixed(1)} req/s over ${(elapsedMs / 1000).toFixed(1)}s`);
+  console.log(`  ${samples.length} requests, ${dropped} dropped (over --max-in-flight)`);
+  console.log(`  Status codes: ${[...statuses.entries()].sort().map(([status, count]) => `${status} x${count}`).join(', ')}`);
+  if (cleaned > 0) {
+    console.log(`  Deleted ${cleaned} movies left over from the run`);
+  }
+
+  const header = ['endpoint'.padEnd(30), 'requests'.padStart(9), 'errors'.padStart(7),
+    ...PERCENTILES.map(p => `p${p} ms`.padStart(9)), 'max ms'.padStart(9)];
+  console.log(`\n${header.join('')}`);
+  for (const row of rows) {
+    console.log([
+      row.endpoint.padEnd(30),
+      String(row.requests).padStart(9),
+      String(row.errors).padStart(7),
+      ...PERCENTILES.map(p => row.percentiles[`p${p}`].toFixed(1).padStart(9)),
+      row.max.toFixed(1).padStart(9)
+    ].join(''));
+  }
+};
+
+const main = async (): Promise<void> => {
+  const options = parseArgs(process.argv.slice(2));
+  const client = new LoadClient(options.baseUrl, options.apiKey);
+
+  if (!options.apiKey) {
+    const result = await client.send('POST', '/api/api-key', { name: 'loadtest' });
+    if (typeof result.body?.api_key !== 'string') {
+      throw new Error(`Couldn't create an API key (POST /api/api-key returned ${result.status}); pass --api-key`);
+    }
+    client.setApiKey(result.body.api_key);
+  }
+
+  const catalog = await loadCatalog(client);
+  if (catalog.movieIds.length === 0) {
+    throw new Error('The catalog has no movies to load test against');
+  }
+  const writes = options.scenario === 'write' ? createWriteScenario(catalog) : null;
+  const nextRequest = (): PlannedRequest =>
+    writes ? writes.next() : options.scenario === 'search' ? searchRequest(catalog) : browseRequest(catalog);
+
+  const samples: Sample[] = [];
+  const pending = new Set<Promise<void>>();
+  let dropped = 0;
+
+  const fire = (planned: PlannedRequest): void => {
+    if (pending.size >= options.maxInFlight) {
+      dropped++;
+      return;
+    }
+    const request = client.send(planned.method, planned.path, planned.body).then(result => {
+      samples.push({ endpoint: planned.endpoint, status: result.status, ms: result.ms });
+      if (result.status >= 200 && result.status < 300) planned.onSuccess?.(result.body);
+    });
+    pending.add(request);
+    void request.finally(() => pending.delete(request));
+  };
+
+  // Requests go out on schedule; each tick catches up on any that are due
+  const intervalMs = 1000 / options.rps;
+  const started = performance.now();
+  let sent = 0;
+  let nextProgress = PROGRESS_INTERVAL_MS;
+
+  while (performance.now() - started < options.durationMs) {
+    const due = Math.min(
+      Math.floor((performance.now() - started) / intervalMs) + 1,
+      Math.ceil(options.durationMs / intervalMs)
+    );
+    for (; sent < due; sent++) {
+      fire(nextRequest());
+    }
+    const elapsed = performance.now() - started;
+    if (!options.json && elapsed >= nextProgress) {
+      const recent = summarize('recent', samples.slice(-Math.round(options.rps * PROGRESS_INTERVAL_MS / 1000)));
+      console.error(`  ${Math.round(elapsed / 1000)}s: ${samples.length} done, ${pending.size} in flight, p95 ${recent.percentiles.p95} ms`);
+      nextProgress += PROGRESS_INTERVAL_MS;
+    }
+    await new Promise(resolve => setTimeout(resolve, Math.max(1, Math.min(intervalMs, 20))));
+  }
+
+  await Promise.all(pending);
+  const elapsedMs = performance.now() - started;
+  const cleaned = writes ? await writes.cleanUp(client) : 0;
+
+  if (options.json) {
+    const endpoints = [...new Set(samples.map(sample => sample.endpoint))].sort();
+    console.log(JSON.stringify({
+      scenario: options.scenario,
+      base_url: options.baseUrl,
+      target_rps: options.rps,
+      achieved_rps: Math.round(samples.length / (elapsedMs / 1000) * 10) / 10,
+      duration_seconds: Math.round(elapsedMs / 100) / 10,
+      dropped,
+      deleted: cleaned,
+      overall: summarize('all', samples),
+      endpoints: endpoints.map(endpoint => summarize(endpoint, samples.filter(sample => sample.endpoint === endpoint)))
+    }, null, 2));
+  } else {
+    printReport(options, samples, elapsedMs, dropped, cleaned);
+  }
+};
+
+main().catch(error => {
+  console.error(error instanceof Error ? error.message : error);
+  process.exitCode = 1;
+});
@@ -0,0 +1,253 @@
+// server/src/scripts/mockServer.ts
+//
+// Serve the whole API from canned data, with no database.
+//
+//   npm run mock
+//   npm run mock -- --port 4100
+//
+// Every operation in api-docs/swagger.yaml is served at its real path and
+// answers with its documented success response: the example given in the
+// spec, or one built from the response schema. Front ends can be developed
+// offline and the API demoed before a database exists; anything the spec
+// doesn't document returns 404, and nothing is stored. API keys and tokens
+// are accepted without being checked.
+//
+// Movie browsing comes from the fixture catalog (src/fixtures/movies.json),
+// the same 100 movies `npm run seed -- --fixture` loads: GET /api/movies
+// pages through them, filtered by title, genre and year, and
+// GET /api/movies/{id} returns one by movie_id (1-100) or public_id.
+//
+// To try an error state, send X-Mock-Status: <code> (or ?__status=<code>) and
+// the operation's documented response for that status comes back instead.
+// Swagger UI is served at /api-docs as usual. Responses carry X-Mock: true.
+
+import zlib from 'zlib';
+import cors from 'cors';
+import express, { NextFunction, Request, RequestHandler, Response } from 'express';
+import swaggerUi from 'swagger-ui-express';
+import { localizeErrors } from '@middleware/localizeErrors';
+import { ApiError } from '@utils/httpError';
+import { fixtureMovieRecords } from '@utils/fixtures';
+import { HttpStatus } from '@utils/httpStatus';
+import { encodePng } from '@utils/imageComposition';
+import {
+  exampleFor,
+  HTTP_METHODS,
+  loadApiSpec,
+  OpenApiOperation,
+  OpenApiResponse,
+  OpenApiSpec,
+  resolveResponse
+} from '@utils/openApi';
+
+const DEFAULT_PORT = 4000;
+
+const DEFAULT_PAGE_SIZE = 20;
+const MAX_PAGE_SIZE = 100;
+
+/**
+ * Bodies for documented non-JSON responses without an example
+ */
+const TEXT_BODIES: Record<string, string> = {
+  'text/calendar': 'BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//TCSS 460 Movie API//Mock//EN\r\nEND:VCALENDAR\r\n',
+  'application/xml': '<?xml version="1.0" encoding="UTF-8"?>\n<mock/>\n',
+  'application/atom+xml': '<?xml version="1.0" encoding="UTF-8"?>\n<feed xmlns="http://www.w3.org/2005/Atom"><title>Mock feed</title></feed>\n',
+  'text/html': '<!DOCTYPE html>\n<html><body><p>Mock response</p></body></html>\n',
+  'image/svg+xml': '<svg xmlns="http://www.w3.org/2000/svg" width="1" height="1"/>\n',
+  'text/csv': 'movie_id,title\n1,Mock movie\n'
+};
+
+const parsePort = (args: string[]): number => {
+  if (args.length === 0) {
+    return Number(process.env.SERVER_PORT) || DEFAULT_PORT;
+  }
+  const port = Number(args[1]);
+  if (args[0] !== '--port' || args.length !== 2 || !Number.isInteger(port) || port <= 0) {
+    throw new Error('Usage: npm run mock [-- --port <port>]');
+  }
+  return port;
+};
+
+/**
+ * Express path for an OpenAPI path: /api/movies/{id} -> /api/movies/:id
+ */
+const toExpressPath = (openApiPath: string): string => openApiPath.replace(/\{(\w+)\}/g, ':$1');
+
+/**
+ * The status to answer with: the one asked for, or the first documented
+ * success (or redirect)
+ */
+const chooseStatus = (operation: OpenApiOperation, req: Request): string | null => {
+  const documented = Object.keys(operation.responses ?? {});
+  const requested = req.get('X-Mock-Status') ?? (typeof req.query.__status === 'string' ? req.query.__status : undefined);
+  if (requested) {
+    return documented.includes(requested) ? requested : null;
+  }
+  return documented.filter(status => /^[23]\d\d$/.test(status)).sort()[0] ?? documented.sort()[0] ?? '200';
+};
+
+/**
+ * Sends a documented response
+ */
+const sendResponse = (res: Response, current: OpenApiSpec, status: number, response: OpenApiResponse): void => {
+  const [contentType, media] = Object.entries(response.content ?? {})[0] ?? [];
+  res.status(status);
+
+  if (status >= 300 && status < 400) {
+    res.location('/api-docs').end();
+    return;
+  }
+  if (!contentType || !media) {
+    if (status >= 400) {
+      res.json(ApiError.createResponse(status, response.description ?? 'Mock error'));
+    } else {
+      res.end();
+    }
+    return;
+  }
+
+  if (contentType.includes('json')) {
+    res.type(contentType).send(JSON.stringify(media.example ?? exampleFor(current, media.schema, true), null, 2));
+  } else if (typeof media.example === 'string') {
+    res.type(contentType).send(media.example);
+  } else if (contentType.startsWith('image/') && contentType !== 'image/svg+xml') {
+    res.type('image/png').send(encodePng(1, 1, new Uint8Array([128]), 1));
+  } else if (contentType === 'application/gzip') {
+    res.type(contentType).send(zlib.gzipSync(''));
+  } else {
+    res.type(contentType).send(TEXT_BODIES[contentType] ?? '');
+  }
+};
+
+const mockStatusRequested = (req: Request): boolean =>
+  req.get('X-Mock-Status') !== undefined || req.query.__status !== undefined;
+
+/**
+ * GET /api/movies from the fixture catalog
+ */
+const listFixtureMovies = (req: Request, res: Response, next: NextFunction): void => {
+  if (mockStatusRequested(req)) {
+    next();
+    return;
+  }
+
+  const page = req.query.page === undefined ? 1 : Number(req.query.page);
+  const limit = req.query.limit === undefined ? DEFAULT_PAGE_SIZE : Number(req.query.limit);
+  if (!Number.isInteger(page) || page < 1 || !Number.isInteger(limit) || limit < 1 || limit > MAX_PAGE_SIZE) {
+    res.status(HttpStatus.BAD_REQUEST).json(
+      ApiError.badRequest(`page must be a positive integer and limit between 1 and ${MAX_PAGE_SIZE}`)
+    );
+    return;
+  }
+
+  const title = typeof req.query.title === 'string' ? req.query.title.toLowerCase() : null;
+  const genre = typeof req.query.genre === 'string' ? req.query.genre.toLowerCase() : null;
+  const year = typeof req.query.year === 'string' ? req.query.year : null;
+  const matching = fixtureMovieRecords().filter(movie =>
+    (!title || String(movie.title).toLowerCase().includes(title)) &&
+    (!genre || String(movie.genres).toLowerCase().split(', ').includes(genre)) &&
+    (!year || String(movie.release_date).startsWith(`${year}-`)));
+
+  const pages = Math.max(1, Math.ceil(matching.length / limit));
+  res.set('X-Mock', 'true').json({
+    data: matching.slice((page - 1) * limit, page * limit).map(({ included: _included, ...movie }) => movie),
+    meta: {
+      page,
+      limit,
+      total: matching.length,
+      pages,
+      hasNextPage: page < pages,
+      hasPreviousPage: page > 1
+    }
+  });
+};
+
+/**
+ * GET /api/movies/{id} from the fixture catalog
+ */
+const getFixtureMovie = (req: Request, res: Response, next: NextFunction): void => {
+  if (mockStatusRequested(req)) {
+    next();
+    return;
+  }
+  const movie = fixtureMovieRecords().find(record =>
+    String(record.movie_id) === req.params.id || record.public_id === req.params.id);
+  if (!movie) {
+    res.set('X-Mock', 'true').status(HttpStatus.NOT_FOUND).json(ApiError.notFound('Movie not found', 'MOVIE_NOT_FOUND'));
+    return;
+  }
+  res.set('X-Mock', 'true').json(movie);
+};
+
+/**
+ * Operations answered from the fixture catalog before falling back to the spec
+ */
+const FIXTURE_HANDLERS: Record<string, RequestHandler> = {
+  'get /api/movies': listFixtureMovies,
+  'get /api/movies/{id}': getFixtureMovie
+};
+
+const mockOperation = (current: OpenApiSpec, operation: OpenApiOperation) =>
+  (req: Request, res: Response): void => {
+    res.set('X-Mock', 'true');
+    const status = chooseStatus(operation, req);
+    if (!status) {
+      res.status(HttpStatus.BAD_REQUEST).json(ApiError.badRequest(
+        `This operation documents responses ${Object.keys(operation.responses ?? {}).join(', ')} only`
+      ));
+      return;
+    }
+    const response = operation.responses?.[status];
+    sendResponse(res, current, Number(status), response ? resolveResponse(current, response) : {});
+  };
+
+const main = (): void => {
+  const port = parsePort(process.argv.slice(2));
+  const current = loadApiSpec();
+  if (!current) {
+    throw new Error('The mock server needs api-docs/swagger.yaml');
+  }
+
+  const app = express();
+  app.use(localizeErrors);
+  app.use(cors({ exposedHeaders: ['ETag', 'X-Mock'] }));
+  app.use(express.json({ limit: '10mb' }));
+
+  // Literal paths first, so /api/actors/search isn't taken for /api/actors/:id
+  const paths = Object.keys(current.paths).sort((a, b) =>
+    (a.match(/\{/g)?.length ?? 0) - (b.match(/\{/g)?.length ?? 0));
+
+  let operations = 0;
+  for (const openApiPath of paths) {
+    for (const method of HTTP_METHODS) {
+      const operation = current.paths[openApiPath][method];
+      if (operation) {
+        const fixtureHandler = FIXTURE_HANDLERS[`${method} ${openApiPath}`];
+        app[method](
+          toExpressPath(openApiPath),
+          ...(fixtureHandler ? [fixtureHandler] : []),
+          mockOperation(current, operation)
+        );
+        operations++;
+      }
+    }
+  }
+
+  app.use('/api-docs', swaggerUi.serve, swaggerUi.setup(current));
+  app.use((req, res) => {
+    res.set('X-Mock', 'true').status(HttpStatus.NOT_FOUND).json(
+      ApiError.notFound(`${req.method} ${req.path} is not in the API spec`)
+    );
+  });
+
+  app.listen(port, () => {
+    console.log(`Mock API serving ${operations} operations on http://localhost:${port}/api (docs at /api-docs)`);
+  });
+};
+
+try {
+  main();
+} catch (error) {
+  console.error(error instanceof Error ? error.message : error);
+  process.exitCode = 1;
+}
@@ -0,0 +1,74 @@
+// server/src/scripts/sanitizeText.ts
+//
+// Apply the HTML_SANITIZE policy to text stored before sanitization existed.
+//
+//   npm run sanitize-text -- [--dry-run]
+//
+// New writes are sanitized as they arrive; this cleans movie overviews and
+// translated overviews/taglines already in the database. Only rows that
+// contain markup characters are read, and only rows the policy changes are
+// written, so it is safe to re-run.
+
+import pool from '@utils/database';
+import { getSanitizeMode, sanitizeText } from '@utils/sanitize';
+
+interface TextColumn {
+  table: string;
+  key: string[];
+  column: string;
+}
+
+const COLUMNS: TextColumn[] = [
+  { table: 'movies', key: ['movie_id'], column: 'overview' },
+  { table: 'movie_translations', key: ['movie_id', 'language'], column: 'overview' },
+  { table: 'movie_translations', key: ['movie_id', 'language'], column: 'tagline' }
+];
+
+const sanitizeColumn = async ({ table, key, column }: TextColumn, dryRun: boolean): Promise<number> => {
+  const rows = await pool.query(
+    `SELECT ${key.join(', ')}, ${column} AS text FROM ${table} WHERE ${column} ~ '[<>&]'`
+  );
+
+  let changed = 0;
+  for (const row of rows.rows) {
+    const cleaned = sanitizeText(row.text as string);
+    if (cleaned === row.text) continue;
+
+    changed++;
+    if (dryRun) continue;
+
+    const where = key.map((name, i) => `${name} = $${i + 2}`).join(' AND ');
+    await pool.query(
+      `UPDATE ${table} SET ${column} = $1 WHERE ${where}`,
+      [cleaned, ...key.map(name => row[name])]
+    );
+  }
+  return changed;
+};
+
+const main = async (): Promise<void> => {
+  const args = process.argv.slice(2);
+  const unknown = args.filter(arg => arg !== '--dry-run');
+  if (unknown.length > 0) {
+    throw new Error(`Unknown argument: ${unknown[0]}`);
+  }
+  const dryRun = args.includes('--dry-run');
+
+  const mode = getSanitizeMode();
+  if (mode === 'off') {
+    console.log('HTML_SANITIZE=off; nothing to do');
+    return;
+  }
+
+  for (const target of COLUMNS) {
+    const changed = await sanitizeColumn(target, dryRun);
+    console.log(`  ${target.table}.${target.column}: ${changed} rows ${dryRun ? 'would change' : 'sanitized'} (${mode})`);
+  }
+};
+
+main()
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(() => pool.end());
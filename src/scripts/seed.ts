@@ -0,0 +1,100 @@
+// server/src/scripts/seed.ts
+//
+// Load known data into an empty or development database.
+//
+//   npm run seed -- --fixture [--dry-run]
+//
+// --fixture inserts the fixture catalog (src/fixtures/movies.json): 100
+// made-up movies with genres, directors, producers, studios, cast,
+// collections and translations, the same data the mock server serves. It is
+// the quickest way to a working local catalog for a new developer.
+//
+// Everything is inserted in one transaction, as one import run of kind
+// 'fixture' (migration 045). Movies already in the database with the same
+// title and release date are skipped, so running it again adds nothing.
+// --dry-run only reports what would be inserted.
+
+import pool from '@utils/database';
+import { refreshBoxOfficeStats } from '@utils/boxOfficeStats';
+import { FIXTURE_MOVIES, FIXTURE_VERSION } from '@utils/fixtures';
+import { startImportRun } from '@utils/lineage';
+import { insertMovie } from '../controllers/moviePostControllers';
+
+const USAGE = 'Usage: npm run seed -- --fixture [--dry-run]';
+
+interface SeedOptions {
+  fixture: boolean;
+  dryRun: boolean;
+}
+
+const parseArgs = (args: string[]): SeedOptions => {
+  const options: SeedOptions = { fixture: false, dryRun: false };
+  for (const arg of args) {
+    switch (arg) {
+      case '--fixture':
+        options.fixture = true;
+        break;
+      case '--dry-run':
+        options.dryRun = true;
+        break;
+      default:
+        throw new Error(`Unknown argument: ${arg}\n${USAGE}`);
+    }
+  }
+  if (!options.fixture) {
+    throw new Error(USAGE);
+  }
+  return options;
+};
+
+const main = async (): Promise<void> => {
+  const options = parseArgs(process.argv.slice(2));
+  const client = await pool.connect();
+  let created = 0;
+
+  try {
+    await client.query('BEGIN');
+
+    // Title + release date identifies a fixture movie across databases
+    const existing = await client.query<{ key: string }>(
+      `SELECT LOWER(title) || '|' || release_date::text AS key
+       FROM movies
+       WHERE LOWER(title) = ANY($1::text[])`,
+      [FIXTURE_MOVIES.map(movie => movie.title.toLowerCase())]
+    );
+    const present = new Set(existing.rows.map(row => row.key));
+    const missing = FIXTURE_MOVIES.filter(movie =>
+      !present.has(`${movie.title.toLowerCase()}|${movie.release_date}`));
+
+    console.log(`Fixture v${FIXTURE_VERSION}: ${FIXTURE_MOVIES.length} movies, ${FIXTURE_MOVIES.length - missing.length} already in the database`);
+    if (options.dryRun || missing.length === 0) {
+      if (options.dryRun) {
+        console.log(`Dry run: ${missing.length} movies would be inserted`);
+      }
+      await client.query('ROLLBACK');
+      return;
+    }
+
+    const runId = await startImportRun(client, 'fixture', { source: `fixture v${FIXTURE_VERSION}` });
+    for (const movie of missing) {
+      await insertMovie(client, movie, runId);
+      created++;
+    }
+    await client.query('COMMIT');
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+
+  await refreshBoxOfficeStats();
+  console.log(`Done: ${created} movies inserted`);
+};
+
+main()
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(() => pool.end());
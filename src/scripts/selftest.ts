@@ -0,0 +1,343 @@
+// server/src/scripts/selftest.ts
+//
+// Contract tests against a running deployment: pagination, auth rejection,
+// search parameters and error response shapes, reported as a pass/fail rubric.
+//
+//   npm run selftest -- --base-url https://movies.example.com
+//   npm run selftest -- --base-url http://localhost:4000 --api-key <key>
+//   npm run selftest -- --base-url http://localhost:4000 --json
+//
+// Only reads, apart from POST /api/api-key: without --api-key (or API_KEY)
+// the run creates a key named "selftest" on the deployment, unless it is in
+// READ_ONLY mode and needs none. Needs some movies in the catalog. Exits 1
+// when any check fails.
+
+interface Options {
+  baseUrl: string;
+  apiKey: string | null;
+  json: boolean;
+}
+
+type Outcome = 'pass' | 'fail' | 'skip';
+
+interface CheckResult {
+  section: string;
+  name: string;
+  outcome: Outcome;
+  detail?: string;
+}
+
+interface HttpResult {
+  status: number;
+  contentType: string;
+  body: any;
+}
+
+const REQUEST_TIMEOUT_MS = 10_000;
+
+const PAGE_LIMIT = 5;
+
+const parseArgs = (args: string[]): Options => {
+  const options: Options = { baseUrl: '', apiKey: process.env.API_KEY || null, json: false };
+  for (let i = 0; i < args.length; i++) {
+    switch (args[i]) {
+      case '--base-url':
+        options.baseUrl = (args[++i] ?? '').replace(/\/+$/, '');
+        break;
+      case '--api-key':
+        options.apiKey = args[++i] ?? null;
+        break;
+      case '--json':
+        options.json = true;
+        break;
+      default:
+        throw new Error(`Unknown argument: ${args[i]}`);
+    }
+  }
+  if (!/^https?:\/\//.test(options.baseUrl)) {
+    throw new Error('Usage: selftest --base-url <http(s)://host[:port]> [--api-key <key>] [--json]');
+  }
+  return options;
+};
+
+/**
+ * A check failure; its message is shown in the report
+ */
+class CheckFailed extends Error {}
+
+const expect = (condition: unknown, message: string): void => {
+  if (!condition) {
+    throw new CheckFailed(message);
+  }
+};
+
+class SelfTest {
+  readonly results: CheckResult[] = [];
+  private section = '';
+
+  constructor(private readonly baseUrl: string) {}
+
+  /**
+   * @param init - body is sent as JSON, rawBody (a string) as it is
+   */
+  async request(
+    method: string,
+    path: string,
+    init: { apiKey?: string | null; body?: unknown; rawBody?: string; headers?: Record<string, string> } = {}
+  ): Promise<HttpResult> {
+    const headers: Record<string, string> = { Accept: 'application/json' };
+    if (init.apiKey) headers['X-API-Key'] = init.apiKey;
+    if (init.body !== undefined || init.rawBody !== undefined) headers['Content-Type'] = 'application/json';
+
+    const response = await fetch(`${this.baseUrl}${path}`, {
+      method,
+      headers: { ...headers, ...init.headers },
+      body: init.rawBody ?? (init.body === undefined ? undefined : JSON.stringify(init.body)),
+      signal: AbortSignal.timeout(REQUEST_TIMEOUT_MS)
+    });
+    const contentType = response.headers.get('content-type') ?? '';
+    const text = await response.text();
+    let body: unknown = text;
+    if (contentType.includes('json')) {
+      try {
+        body = JSON.parse(text);
+      } catch {
+        // Left as text; shape checks report it
+      }
+    }
+    return { status: response.status, contentType, body };
+  }
+
+  begin(section: string): void {
+    this.section = section;
+  }
+
+  async check(name: string, run: () => Promise<void>): Promise<void> {
+    try {
+      await run();
+      this.results.push({ section: this.section, name, outcome: 'pass' });
+    } catch (error) {
+      const detail = error instanceof CheckFailed ? error.message
+        : `request failed: ${error instanceof Error ? error.message : error}`;
+      this.results.push({ section: this.section, name, outcome: 'fail', detail });
+    }
+  }
+
+  skip(name: string, reason: string): void {
+    this.results.push({ section: this.section, name, outcome: 'skip', detail: reason });
+  }
+}
+
+/**
+ * The standard error body: { statusCode, message, code, timestamp }, with
+ * statusCode matching the HTTP status
+ */
+const expectErrorShape = (result: HttpResult, status: number): void => {
+  expect(result.status === status, `expected HTTP ${status}, got ${result.status}`);
+  expect(result.contentType.includes('application/json'), `expected a JSON body, got "${result.contentType || 'no content type'}"`);
+  const body = result.body;
+  expect(body && typeof body === 'object', 'body is not a JSON object');
+  expect(body.statusCode === status, `statusCode is ${JSON.stringify(body.statusCode)}, not ${status}`);
+  expect(typeof body.message === 'string' || Array.isArray(body.message), 'message is missing or not a string/array');
+  expect(typeof body.code === 'string' && body.code.length > 0, 'code is missing');
+  expect(typeof body.timestamp === 'string' && !isNaN(Date.parse(body.timestamp)), 'timestamp is missing or not a date');
+};
+
+const expectPageMeta = (result: HttpResult, page: number, limit: number): any => {
+  expect(result.status === 200, `expected HTTP 200, got ${result.status}`);
+  const { data, meta } = result.body ?? {};
+  expect(Array.isArray(data), 'data is not an array');
+  expect(meta && typeof meta === 'object', 'meta is missing');
+  expect(meta.page === page, `meta.page is ${meta.page}, not ${page}`);
+  expect(meta.limit === limit, `meta.limit is ${meta.limit}, not ${limit}`);
+  expect(Number.isInteger(meta.total) && meta.total >= 0, 'meta.total is not a count');
+  expect(meta.pages === Math.max(1, Math.ceil(meta.total / limit)), `meta.pages is ${meta.pages} for ${meta.total} results`);
+  expect(meta.hasNextPage === page < meta.pages, `meta.hasNextPage is ${meta.hasNextPage} on page ${page} of ${meta.pages}`);
+  expect(meta.hasPreviousPage === page > 1, `meta.hasPreviousPage is ${meta.hasPreviousPage} on page ${page}`);
+  expect(data.length <= limit, `${data.length} items on a page of ${limit}`);
+  return result.body;
+};
+
+/**
+ * An API key for the run: the given one, or a new one from POST /api/api-key
+ */
+const obtainApiKey = async (test: SelfTest, given: string | null, readOnly: boolean): Promise<string | null> => {
+  if (given || readOnly) {
+    return given;
+  }
+  let apiKey: string | null = null;
+  await test.check('POST /api/api-key issues a key', async () => {
+    const result = await test.request('POST', '/api/api-key', { body: { name: 'selftest' } });
+    expect(result.status === 201, `expected HTTP 201, got ${result.status}`);
+    expect(typeof result.body?.api_key === 'string', 'api_key is missing');
+    apiKey = result.body.api_key;
+  });
+  return apiKey;
+};
+
+const run = async (test: SelfTest, options: Options): Promise<void> => {
+  let readOnly = false;
+
+  test.begin('System');
+  await test.check('GET /api/health responds 200', async () => {
+    const result = await test.request('GET', '/api/health');
+    expect(result.status === 200, `expected HTTP 200, got ${result.status}`);
+  });
+  await test.check('GET /api/api-info describes the API', async () => {
+    const result = await test.request('GET', '/api/api-info');
+    expect(result.status === 200, `expected HTTP 200, got ${result.status}`);
+    expect(typeof result.body?.name === 'string', 'name is missing');
+    expect(typeof result.body?.read_only === 'boolean', 'read_only is missing');
+    readOnly = result.body.read_only;
+  });
+
+  test.begin('Authentication');
+  const apiKey = await obtainApiKey(test, options.apiKey, readOnly);
+  if (readOnly) {
+    // Reads need no key; account, admin and key routes are hidden and writes refused
+    await test.check('Account and admin routes are hidden', async () => {
+      expectErrorShape(await test.request('GET', '/api/me/watchlist'), 404);
+      expectErrorShape(await test.request('GET', '/api/admin/jobs'), 404);
+    });
+    await test.check('Writes are refused with 405', async () => {
+      expectErrorShape(await test.request('DELETE', '/api/movies/1'), 405);
+    });
+  } else {
+    await test.check('Protected routes reject a missing API key', async () => {
+      expectErrorShape(await test.request('GET', `/api/movies?limit=${PAGE_LIMIT}`), 401);
+    });
+    await test.check('Protected routes reject an unknown API key', async () => {
+      expectErrorShape(await test.request('GET', `/api/movies?limit=${PAGE_LIMIT}`, { apiKey: 'x'.repeat(40) }), 401);
+    });
+    await test.check('Account routes reject a request without a sign-in', async () => {
+      expectErrorShape(await test.request('GET', '/api/me/watchlist', { apiKey }), 401);
+    });
+    await test.check('Account routes reject an invalid bearer token', async () => {
+      const result = await test.request('GET', '/api/me/watchlist', {
+        apiKey,
+        headers: { Authorization: 'Bearer not-a-real-token' }
+      });
+      expectErrorShape(result, 401);
+    });
+    await test.check('Admin routes reject a non-admin key', async () => {
+      expectErrorShape(await test.request('GET', '/api/admin/jobs', { apiKey }), 403);
+    });
+  }
+
+  test.begin('Pagination');
+  const listPath = (page: number) => `/api/movies?page=${page}&limit=${PAGE_LIMIT}&sortBy=title&order=asc`;
+  let first: any = null;
+  await test.check('Page 1 has consistent meta', async () => {
+    first = expectPageMeta(await test.request('GET', listPath(1), { apiKey }), 1, PAGE_LIMIT);
+    expect(first.data.length === Math.min(PAGE_LIMIT, first.meta.total), `page 1 has ${first.data.length} of ${first.meta.total} results`);
+  });
+  if (first && first.meta.pages > 1) {
+    await test.check('Page 2 continues page 1 without repeats', async () => {
+      const second = expectPageMeta(await test.request('GET', listPath(2), { apiKey }), 2, PAGE_LIMIT);
+      expect(second.meta.total === first.meta.total, 'meta.total changed between pages');
+      const seen = new Set(first.data.map((movie: any) => movie.movie_id));
+      const repeated = second.data.filter((movie: any) => seen.has(movie.movie_id));
+      expect(repeated.length === 0, `movie ${repeated[0]?.movie_id} is on both pages`);
+    });
+    await test.check('Last page holds the remainder', async () => {
+      const { pages, total } = first.meta;
+      const last = expectPageMeta(await test.request('GET', listPath(pages), { apiKey }), pages, PAGE_LIMIT);
+      const expected = total - (pages - 1) * PAGE_LIMIT;
+      expect(last.data.length === expected, `last page has ${last.data.length} results, expected ${expected}`);
+    });
+  } else {
+    test.skip('Page 2 continues page 1 without repeats', first ? 'fewer movies than one page' : 'page 1 failed');
+    test.skip('Last page holds the remainder', first ? 'fewer movies than one page' : 'page 1 failed');
+  }
+  await test.check('Pages past the end are not served as results', async () => {
+    const pages = first?.meta.pages ?? 1;
+    const result = await test.request('GET', listPath(pages + 1), { apiKey });
+    // An empty page, or the 404 GET /movies gives when nothing matches
+    if (result.status === 404) {
+      expectErrorShape(result, 404);
+    } else {
+      expect(expectPageMeta(result, pages + 1, PAGE_LIMIT).data.length === 0, 'page past the end has results');
+    }
+  });
+  for (const [query, label] of [['page=0', 'page=0'], ['limit=101', 'limit over 100'], ['limit=abc', 'non-numeric limit']]) {
+    await test.check(`Rejects ${label} with 400`, async () => {
+      expectErrorShape(await test.request('GET', `/api/movies?${query}`, { apiKey }), 400);
+    });
+  }
+
+  test.begin('Search');
+  await test.check('GET /api/search/natural accepts q', async () => {
+    const q = 'comedies from the 90s under 100 minutes';
+    const result = await test.request('GET', `/api/search/natural?q=${encodeURIComponent(q)}&limit=${PAGE_LIMIT}`, { apiKey });
+    expect(result.status === 200, `expected HTTP 200, got ${result.status}${result.body?.message ? ` (${result.body.message})` : ''}`);
+    expect(result.body?.query?.text === q, 'query.text does not echo q');
+    expect(Array.isArray(result.body?.data), 'data is not an array');
+  });
+
+  test.begin('Error shapes');
+  await test.check('Unknown movie ID gives 404', async () => {
+    expectErrorShape(await test.request('GET', '/api/movies/2147483647', { apiKey }), 404);
+  });
+  await test.check('Invalid filter gives 400 with validation details', async () => {
+    const result = await test.request('GET', '/api/movies?title=a', { apiKey });
+    expectErrorShape(result, 400);
+    expect(Array.isArray(result.body.message) && result.body.message.length > 0, 'message does not list the validation issues');
+  });
+  if (readOnly) {
+    test.skip('Invalid request body gives 400 with validation details', 'server is in READ_ONLY mode');
+  } else {
+    await test.check('Invalid request body gives 400 with validation details', async () => {
+      const result = await test.request('POST', '/api/api-key', { body: { email: 'not-an-email' } });
+      expectErrorShape(result, 400);
+      expect(Array.isArray(result.body.message) && result.body.message.length > 0, 'message does not list the validation issues');
+    });
+  }
+  await test.check('Malformed JSON body gives 400', async () => {
+    const result = await test.request('POST', '/api/auth/login', { rawBody: '{"email": ' });
+    expect(result.status === 400, `expected HTTP 400, got ${result.status}`);
+  });
+  await test.check('Unknown /api route gives 404', async () => {
+    const result = await test.request('GET', '/api/selftest-no-such-route', { apiKey });
+    expect(result.status === 404, `expected HTTP 404, got ${result.status}`);
+  });
+};
+
+const printReport = (baseUrl: string, results: CheckResult[]): void => {
+  console.log(`Self-test against ${baseUrl}\n`);
+  const sections = [...new Set(results.map(result => result.section))];
+  for (const section of sections) {
+    const rows = results.filter(result => result.section === section);
+    const scored = rows.filter(result => result.outcome !== 'skip');
+    const passed = scored.filter(result => result.outcome === 'pass').length;
+    console.log(`${section.padEnd(60)}${passed}/${scored.length}`);
+    for (const row of rows) {
+      const mark = row.outcome === 'pass' ? 'PASS' : row.outcome === 'fail' ? 'FAIL' : 'SKIP';
+      console.log(`  ${mark}  ${row.name}${row.detail ? ` (${row.detail})` : ''}`);
+    }
+    console.log('');
+  }
+
+  const count = (outcome: Outcome) => results.filter(result => result.outcome === outcome).length;
+  const scored = count('pass') + count('fail');
+  console.log(`Score: ${count('pass')}/${scored} checks passed` + (count('skip') ? `, ${count('skip')} skipped` : ''));
+};
+
+const main = async (): Promise<void> => {
+  const options = parseArgs(process.argv.slice(2));
+  const test = new SelfTest(options.baseUrl);
+  await run(test, options);
+
+  if (options.json) {
+    console.log(JSON.stringify({ base_url: options.baseUrl, results: test.results }, null, 2));
+  } else {
+    printReport(options.baseUrl, test.results);
+  }
+  if (test.results.some(result => result.outcome === 'fail')) {
+    process.exitCode = 1;
+  }
+};
+
+main().catch(error => {
+  console.error(error instanceof Error ? error.message : error);
+  process.exitCode = 1;
+});
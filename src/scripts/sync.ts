@@ -0,0 +1,545 @@
+// server/src/scripts/sync.ts
+//
+// Pull movies from another running instance into this database.
+//
+//   npm run sync -- --from https://other-team-api.example.com --api-key <key> [--since <ISO time> | --full]
+//
+// Reads the remote GET /api/export/movies feed page by page and upserts each
+// movie locally. Movies already pulled from the same instance are updated in
+// place (tracked in movie_sync_map), so the command can be re-run. Without
+// --since or --full only movies changed since the last sync are fetched.
+// Movies deleted on the remote are not removed locally.
+// Each run's changes are recorded per movie; see GET /api/admin/movies/:id/lineage.
+//
+//   npm run sync -- --snapshots s3://bucket/datasets [--full]
+//   npm run sync -- --snapshots https://cdn.example.com/datasets [--full]
+//
+// Follows another instance through its published dataset snapshots instead
+// of its API, so no live link is needed: reads manifest.json at the location,
+// loads the newest full snapshot the first time, and afterwards applies each
+// day's diff in order (adds, updates and deletes). --full reloads the newest
+// full snapshot; movies from that source missing from it are deleted. s3://
+// locations are read with S3_ACCESS_KEY_ID/S3_SECRET_ACCESS_KEY (S3_REGION,
+// S3_ENDPOINT); http(s) locations must be readable without credentials.
+
+import crypto from 'crypto';
+import readline from 'readline';
+import { Readable } from 'stream';
+import zlib from 'zlib';
+import { PoolClient } from 'pg';
+import pool from '@utils/database';
+import { refreshBoxOfficeStats } from '@utils/boxOfficeStats';
+import { enrichMovies } from '@utils/inflation';
+import { generatePlaceholderPosters } from '@utils/placeholderPosters';
+import { MovieSnapshot, recordMovieLineage, snapshotMovie, startImportRun } from '@utils/lineage';
+import { flushSpans, tracedFetch, withSpan } from '@utils/tracing';
+import { sanitizeText } from '@utils/sanitize';
+import { replaceMovieCrew } from '@utils/movieCrew';
+import { replaceMovieKeywords } from '@utils/movieKeywords';
+import { replaceMovieLocales } from '@utils/movieLocales';
+import { DatasetManifestEntry, getS3Object } from '@utils/datasets';
+import { ExportedMovie } from '@models/movieModel';
+import {
+  getOrCreateCollectionId,
+  getOrCreateDirectorId,
+  getOrCreateGenreId,
+  getOrCreateProducerId,
+  getOrCreateStudioId,
+  insertCastMembers
+} from '../controllers/moviePostControllers';
+import { saveMovieTranslations } from '../controllers/translationControllers';
+
+const PAGE_SIZE = 100;
+
+interface SyncOptions {
+  from: string;
+  apiKey: string;
+  since?: string;
+  full: boolean;
+}
+
+interface SnapshotSyncOptions {
+  snapshots: string;
+  full: boolean;
+}
+
+interface DiffOperation {
+  op: 'add' | 'update' | 'delete';
+  public_id: string;
+  record?: ExportedMovie;
+}
+
+const USAGE = [
+  'Usage: npm run sync -- --from <base url> --api-key <key> [--since <ISO time> | --full]',
+  '       npm run sync -- --snapshots <s3://bucket/prefix | https://...> [--full]'
+].join('\n');
+
+interface ExportPage {
+  data: ExportedMovie[];
+  meta: { hasNextPage: boolean; next_cursor: string | null; server_time: string; total: number };
+}
+
+/**
+ * Parses --from, --api-key, --since, --snapshots and --full from the command line
+ */
+const parseArgs = (argv: string[]): SyncOptions | SnapshotSyncOptions => {
+  const options: Partial<SyncOptions & SnapshotSyncOptions> = { full: false };
+
+  for (let i = 0; i < argv.length; i++) {
+    switch (argv[i]) {
+      case '--from':
+        options.from = argv[++i]?.replace(/\/+$/, '');
+        break;
+      case '--snapshots':
+        options.snapshots = argv[++i]?.replace(/\/+$/, '');
+        break;
+      case '--api-key':
+        options.apiKey = argv[++i];
+        break;
+      case '--since':
+        options.since = argv[++i];
+        break;
+      case '--full':
+        options.full = true;
+        break;
+      default:
+        throw new Error(`Unknown argument: ${argv[i]}`);
+    }
+  }
+
+  if (options.snapshots) {
+    if (options.from || options.since) {
+      throw new Error(`--snapshots can't be combined with --from or --since\n${USAGE}`);
+    }
+    return { snapshots: options.snapshots, full: options.full ?? false };
+  }
+
+  const apiKey = options.apiKey ?? process.env.SYNC_API_KEY;
+  if (!options.from || !apiKey) {
+    throw new Error(USAGE);
+  }
+  if (options.since && isNaN(Date.parse(options.since))) {
+    throw new Error(`--since must be an ISO timestamp, got "${options.since}"`);
+  }
+
+  return { from: options.from, apiKey, since: options.since, full: options.full ?? false };
+};
+
+/**
+ * Fetches one page of the remote export feed
+ */
+const fetchExportPage = async (options: SyncOptions, since: string | null, cursor: string | null): Promise<ExportPage> => {
+  const url = new URL(`${options.from}/api/export/movies`);
+  url.searchParams.set('limit', String(PAGE_SIZE));
+  if (since) {
+    url.searchParams.set('since', since);
+  }
+  if (cursor) {
+    url.searchParams.set('cursor', cursor);
+  }
+
+  const response = await tracedFetch(url, { headers: { 'X-API-Key': options.apiKey } });
+  if (!response.ok) {
+    throw new Error(`GET ${url} failed with ${response.status}: ${await response.text()}`);
+  }
+
+  return await response.json() as ExportPage;
+};
+
+/**
+ * Replaces a local movie's related rows with the remote record's
+ */
+const replaceRelations = async (client: PoolClient, movieId: number, movie: ExportedMovie): Promise<void> => {
+  for (const table of ['movie_genres', 'movie_directors', 'movie_producers', 'movie_studios', 'movie_actors', 'movie_translations']) {
+    await client.query(`DELETE FROM ${table} WHERE movie_id = $1`, [movieId]);
+  }
+
+  for (const genreName of movie.genres ?? []) {
+    const genreId = await getOrCreateGenreId(client, genreName);
+    await client.query('INSERT INTO movie_genres (movie_id, genre_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, genreId]);
+  }
+  for (const directorName of movie.directors ?? []) {
+    const directorId = await getOrCreateDirectorId(client, directorName);
+    await client.query('INSERT INTO movie_directors (movie_id, director_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, directorId]);
+  }
+  for (const producerName of movie.producers ?? []) {
+    const producerId = await getOrCreateProducerId(client, producerName);
+    await client.query('INSERT INTO movie_producers (movie_id, producer_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, producerId]);
+  }
+  // Snapshots from servers without migrations 047-049 leave crew roles,
+  // keywords, languages and countries out, so the local ones are kept
+  await replaceMovieCrew(client, movieId, movie);
+  await replaceMovieKeywords(client, movieId, movie.keywords);
+  await replaceMovieLocales(client, movieId, movie);
+  for (const studio of movie.studios ?? []) {
+    const studioId = await getOrCreateStudioId(client, studio);
+    await client.query('INSERT INTO movie_studios (movie_id, studio_id) VALUES ($1, $2) ON CONFLICT DO NOTHING', [movieId, studioId]);
+  }
+  if (movie.cast && movie.cast.length > 0) {
+    await insertCastMembers(client, movieId, movie.cast);
+  }
+  if (movie.translations && movie.translations.length > 0) {
+    await saveMovieTranslations(client, movieId, movie.translations);
+  }
+};
+
+/**
+ * Inserts or updates one remote movie in its own transaction
+ *
+ * @returns Whether the movie was created or updated locally
+ */
+const upsertMovie = async (sourceId: number, runId: number, movie: ExportedMovie): Promise<'created' | 'updated'> => {
+  const client = await pool.connect();
+
+  try {
+    await client.query('BEGIN');
+
+    const collectionId = movie.collection_name
+      ? await getOrCreateCollectionId(client, movie.collection_name)
+      : null;
+
+    const values = [
+      movie.title,
+      movie.original_title,
+      movie.release_date,
+      movie.runtime_minutes || null,
+      sanitizeText(movie.overview),
+      movie.budget || null,
+      movie.revenue || null,
+      movie.mpa_rating,
+      collectionId,
+      movie.poster_url || null,
+      movie.backdrop_url || null,
+      movie.adult ?? false,
+      movie.vote_count ?? null,
+      movie.vote_average ?? null
+    ];
+
+    const mapResult = await client.query<{ movie_id: number }>(
+      'SELECT movie_id FROM movie_sync_map WHERE source_id = $1 AND source_movie_id = $2 FOR UPDATE',
+      [sourceId, movie.movie_id]
+    );
+
+    let movieId: number;
+    let outcome: 'created' | 'updated';
+    let before: MovieSnapshot | null = null;
+
+    if (mapResult.rows.length > 0) {
+      movieId = mapResult.rows[0].movie_id;
+      outcome = 'updated';
+      before = await snapshotMovie(client, movieId);
+      await client.query(
+        `UPDATE movies
+         SET title = $1, original_title = $2, release_date = $3, runtime_minutes = $4,
+             overview = $5, budget = $6, revenue = $7, mpa_rating = $8, collection_id = $9,
+             poster_url = $10, backdrop_url = $11, adult = $12,
+             vote_count = $13, vote_average = $14,
+             version = version + 1, updated_at = NOW()
+         WHERE movie_id = $15`,
+        [...values, movieId]
+      );
+      await client.query(
+        `UPDATE movie_sync_map SET synced_at = NOW(), source_public_id = $3
+         WHERE source_id = $1 AND source_movie_id = $2`,
+        [sourceId, movie.movie_id, movie.public_id ?? null]
+      );
+    } else {
+      const insertResult = await client.query<{ movie_id: number }>(
+        `INSERT INTO movies (
+           title, original_title, release_date, runtime_minutes,
+           overview, budget, revenue, mpa_rating, collection_id,
+           poster_url, backdrop_url, adult, vote_count, vote_average, public_id
+         ) VALUES (
+           $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14,
+           -- Keep the remote public ID so links work on both instances, unless it's taken here
+           COALESCE(
+             (SELECT $15::varchar WHERE NOT EXISTS (SELECT 1 FROM movies WHERE public_id = $15)),
+             generate_public_id()
+           )
+         )
+         RETURNING movie_id`,
+        [...values, movie.public_id ?? null]
+      );
+      movieId = insertResult.rows[0].movie_id;
+      outcome = 'created';
+      await client.query(
+        `INSERT INTO movie_sync_map (source_id, source_movie_id, source_public_id, movie_id)
+         VALUES ($1, $2, $3, $4)`,
+        [sourceId, movie.movie_id, movie.public_id ?? null, movieId]
+      );
+    }
+
+    await enrichMovies(client, [movieId]);
+    await generatePlaceholderPosters(client, [movieId]);
+    await replaceRelations(client, movieId, movie);
+    await recordMovieLineage(client, runId, movieId, before);
+
+    await client.query('COMMIT');
+    return outcome;
+  } catch (error) {
+    await client.query('ROLLBACK');
+    throw error;
+  } finally {
+    client.release();
+  }
+};
+
+/**
+ * Deletes the local copy of a movie the source deleted
+ *
+ * @returns Whether there was a local copy
+ */
+const deleteSyncedMovie = async (sourceId: number, publicId: string): Promise<boolean> => {
+  const result = await pool.query(
+    `DELETE FROM movies
+     WHERE movie_id IN (
+       SELECT movie_id FROM movie_sync_map WHERE source_id = $1 AND source_public_id = $2
+     )`,
+    [sourceId, publicId]
+  );
+  return (result.rowCount ?? 0) > 0;
+};
+
+/**
+ * Reads a published file from an s3:// or http(s) location and checks it
+ * against the manifest's checksum when one is given
+ */
+const readSnapshotFile = async (location: string, fileName: string, sha256?: string): Promise<Buffer> => {
+  let data: Buffer | null;
+  const s3 = location.match(/^s3:\/\/([^/]+)\/?(.*)$/);
+
+  if (s3) {
+    data = await getS3Object(s3[1], s3[2] ? `${s3[2]}/${fileName}` : fileName);
+  } else {
+    const response = await tracedFetch(`${location}/${fileName}`);
+    if (!response.ok && response.status !== 404) {
+      throw new Error(`GET ${location}/${fileName} failed with ${response.status}`);
+    }
+    data = response.ok ? Buffer.from(await response.arrayBuffer()) : null;
+  }
+
+  if (!data) {
+    throw new Error(`${fileName} not found at ${location}`);
+  }
+  if (sha256 && crypto.createHash('sha256').update(data).digest('hex') !== sha256) {
+    throw new Error(`${fileName} doesn't match its checksum in the manifest`);
+  }
+  return data;
+};
+
+/**
+ * Lines of a gzipped JSONL file, decompressed as they're read
+ */
+const readJsonLines = (data: Buffer): AsyncIterable<string> =>
+  readline.createInterface({ input: Readable.from(data).pipe(zlib.createGunzip()), crlfDelay: Infinity });
+
+/**
+ * The full snapshot to load (if any) and the diffs to apply after it, in order
+ *
+ * @param current - Snapshot version this instance is at, or null
+ */
+const planSnapshotSync = (
+  files: DatasetManifestEntry[],
+  current: string | null,
+  full: boolean
+): { snapshot: DatasetManifestEntry | null; diffs: DatasetManifestEntry[] } => {
+  const diffs: DatasetManifestEntry[] = [];
+  let version = current;
+
+  if (version && !full) {
+    for (;;) {
+      const next = files.find(file => file.format === 'diff' && file.base_version === version);
+      if (!next) break;
+      diffs.push(next);
+      version = next.version;
+    }
+  }
+
+  const newest = files
+    .filter(file => file.format === 'jsonl')
+    .sort((a, b) => b.version.localeCompare(a.version))[0];
+
+  // Start over from a full snapshot when there's no unbroken chain of diffs to the newest
+  if (newest && (full || !version || version < newest.version)) {
+    return { snapshot: newest, diffs: [] };
+  }
+  return { snapshot: null, diffs };
+};
+
+/**
+ * Brings this instance up to date from published dataset snapshots
+ */
+const syncFromSnapshots = async (options: SnapshotSyncOptions): Promise<void> => {
+  const manifest = JSON.parse(
+    (await readSnapshotFile(options.snapshots, 'manifest.json')).toString('utf8')
+  ) as { files: DatasetManifestEntry[] };
+
+  const sourceResult = await pool.query<{ source_id: number; snapshot_version: string | null }>(
+    `INSERT INTO sync_sources (base_url) VALUES ($1)
+     ON CONFLICT (base_url) DO UPDATE SET base_url = EXCLUDED.base_url
+     RETURNING source_id, TO_CHAR(snapshot_version, 'YYYY-MM-DD') AS snapshot_version`,
+    [options.snapshots]
+  );
+  const { source_id: sourceId, snapshot_version: current } = sourceResult.rows[0];
+
+  const plan = planSnapshotSync(manifest.files, current, options.full);
+  if (!plan.snapshot && plan.diffs.length === 0) {
+    console.log(`Already at snapshot ${current ?? '(none published)'} from ${options.snapshots}`);
+    return;
+  }
+
+  const runId = await startImportRun(pool, 'sync', { source: options.snapshots });
+  const counts = { created: 0, updated: 0, deleted: 0, failed: 0 };
+  let version = current;
+
+  const apply = async (movie: ExportedMovie): Promise<void> => {
+    try {
+      const outcome = await withSpan('sync.movie', {
+        attributes: { 'movie.title': movie.title, 'sync.source_movie_id': movie.movie_id }
+      }, () => upsertMovie(sourceId, runId, movie));
+      counts[outcome]++;
+    } catch (error) {
+      counts.failed++;
+      console.error(`  failed: "${movie.title}" (${movie.public_id}):`, error instanceof Error ? error.message : error);
+    }
+  };
+
+  if (plan.snapshot) {
+    console.log(`Loading full snapshot ${plan.snapshot.version} (${plan.snapshot.movie_count} movies) from ${options.snapshots}`);
+    const data = await readSnapshotFile(options.snapshots, plan.snapshot.file_name, plan.snapshot.sha256);
+    const seen: string[] = [];
+
+    for await (const line of readJsonLines(data)) {
+      if (!line.trim()) continue;
+      const movie = JSON.parse(line) as ExportedMovie;
+      seen.push(movie.public_id!);
+      await apply(movie);
+    }
+
+    // Whatever this source sent before but isn't in the snapshot was deleted there
+    const gone = await pool.query<{ source_public_id: string }>(
+      `SELECT source_public_id FROM movie_sync_map
+       WHERE source_id = $1 AND source_public_id IS NOT NULL AND NOT (source_public_id = ANY($2::varchar[]))`,
+      [sourceId, seen]
+    );
+    for (const { source_public_id: publicId } of gone.rows) {
+      if (await deleteSyncedMovie(sourceId, publicId)) counts.deleted++;
+    }
+    version = plan.snapshot.version;
+  }
+
+  for (const diff of plan.diffs) {
+    console.log(`Applying diff ${diff.base_version} -> ${diff.version} (${diff.change_count} changes)`);
+    const data = await readSnapshotFile(options.snapshots, diff.file_name, diff.sha256);
+
+    for await (const line of readJsonLines(data)) {
+      if (!line.trim()) continue;
+      const operation = JSON.parse(line) as DiffOperation;
+      if (operation.op === 'delete') {
+        if (await deleteSyncedMovie(sourceId, operation.public_id)) counts.deleted++;
+      } else if (operation.record) {
+        await apply(operation.record);
+      }
+    }
+    version = diff.version;
+  }
+
+  // Only advance when everything landed, so failures are retried next run
+  if (counts.failed === 0) {
+    await pool.query('UPDATE sync_sources SET snapshot_version = $2, last_synced_at = NOW() WHERE source_id = $1', [sourceId, version]);
+  }
+
+  if (counts.created + counts.updated + counts.deleted > 0) {
+    await refreshBoxOfficeStats();
+  }
+
+  console.log(
+    `Done at snapshot ${version}: ${counts.created} created, ${counts.updated} updated, ` +
+    `${counts.deleted} deleted, ${counts.failed} failed`
+  );
+  if (counts.failed > 0) {
+    process.exitCode = 1;
+  }
+};
+
+/**
+ * Runs a sync from the command-line options
+ */
+const main = async (): Promise<void> => {
+  const parsed = parseArgs(process.argv.slice(2));
+  if ('snapshots' in parsed) {
+    await syncFromSnapshots(parsed);
+    return;
+  }
+  const options = parsed;
+
+  const sourceResult = await pool.query<{ source_id: number; last_synced_at: Date | null }>(
+    `INSERT INTO sync_sources (base_url) VALUES ($1)
+     ON CONFLICT (base_url) DO UPDATE SET base_url = EXCLUDED.base_url
+     RETURNING source_id, last_synced_at`,
+    [options.from]
+  );
+  const { source_id: sourceId, last_synced_at: lastSyncedAt } = sourceResult.rows[0];
+
+  const since = options.full
+    ? null
+    : options.since ?? (lastSyncedAt ? lastSyncedAt.toISOString() : null);
+
+  const runId = await startImportRun(pool, 'sync', { source: options.from });
+
+  console.log(`Syncing from ${options.from} ${since ? `(changes since ${since})` : '(full catalog)'}`);
+
+  let page = 1;
+  let cursor: string | null = null;
+  let serverTime: string | undefined;
+  const counts = { created: 0, updated: 0, failed: 0 };
+
+  for (;;) {
+    const result = await fetchExportPage(options, since, cursor);
+
+    // The first page's server time marks where the next delta should start
+    serverTime ??= result.meta.server_time;
+
+    for (const movie of result.data) {
+      try {
+        const outcome = await withSpan('sync.movie', {
+          attributes: { 'movie.title': movie.title, 'sync.source_movie_id': movie.movie_id }
+        }, () => upsertMovie(sourceId, runId, movie));
+        counts[outcome]++;
+      } catch (error) {
+        counts.failed++;
+        console.error(`  failed: "${movie.title}" (remote id ${movie.movie_id}):`, error instanceof Error ? error.message : error);
+      }
+    }
+
+    console.log(`  page ${page}: ${result.data.length} movies (${counts.created} created, ${counts.updated} updated so far)`);
+
+    if (!result.meta.next_cursor) break;
+    cursor = result.meta.next_cursor;
+    page++;
+  }
+
+  // Only advance the watermark when everything landed, so failures are retried next run
+  if (serverTime && counts.failed === 0) {
+    await pool.query('UPDATE sync_sources SET last_synced_at = $2 WHERE source_id = $1', [sourceId, serverTime]);
+  }
+
+  if (counts.created + counts.updated > 0) {
+    await refreshBoxOfficeStats();
+  }
+
+  console.log(`Done: ${counts.created} created, ${counts.updated} updated, ${counts.failed} failed`);
+  if (counts.failed > 0) {
+    process.exitCode = 1;
+  }
+};
+
+withSpan('sync', {}, main)
+  .catch(error => {
+    console.error(error instanceof Error ? error.message : error);
+    process.exitCode = 1;
+  })
+  .finally(async () => {
+    await flushSpans();
+    await pool.end();
+  });